@@ -2,33 +2,57 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 
 	"github.com/ntttrang/go-genai-slack-assistant/internal/controller"
 	"github.com/ntttrang/go-genai-slack-assistant/internal/middleware"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
 	"github.com/ntttrang/go-genai-slack-assistant/internal/queue"
 	gormmysql "github.com/ntttrang/go-genai-slack-assistant/internal/repository/gorm-mysql"
 	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service/discord"
 	slackservice "github.com/ntttrang/go-genai-slack-assistant/internal/service/slack"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service/teams"
 	"github.com/ntttrang/go-genai-slack-assistant/pkg/ai"
 	"github.com/ntttrang/go-genai-slack-assistant/pkg/cache"
 	"github.com/ntttrang/go-genai-slack-assistant/pkg/config"
 	"github.com/ntttrang/go-genai-slack-assistant/pkg/database"
 	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/ratelimit"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/secrets"
 	"github.com/ntttrang/go-genai-slack-assistant/pkg/security"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/storage"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/tracing"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/webhook"
 )
 
 func main() {
+	platforms := flag.String("platforms", "", "comma-separated chat platforms to run: slack, teams, discord (defaults to PLATFORMS)")
+	slackMode := flag.String("slack-mode", "", "how Slack events are received: http or socket (defaults to SLACK_MODE)")
+	runMode := flag.String("run-mode", "", "all to receive and process events, worker to only process the Redis queue (defaults to RUN_MODE)")
+	flag.Parse()
+	if *slackMode != "" {
+		_ = os.Setenv("SLACK_MODE", *slackMode)
+	}
+	if *runMode != "" {
+		_ = os.Setenv("RUN_MODE", *runMode)
+	}
+
 	// Initialize logger
 	log, err := zap.NewProduction()
 	if err != nil {
@@ -41,14 +65,31 @@ func main() {
 	log.Info("Starting Slack Translation Bot...")
 
 	// Load configuration
-	cfg, err := config.Load()
+	cfg, err := config.LoadPlatforms(parsePlatforms(*platforms))
 	if err != nil {
 		log.Error("Failed to load configuration", zap.Error(err))
 		os.Exit(1)
 	}
 	log.Info("Configuration loaded successfully",
 		zap.String("environment", cfg.Application.Environment),
-		zap.String("server_address", fmt.Sprintf("%s:%s", cfg.Server.Address, cfg.Server.Port)))
+		zap.String("server_address", fmt.Sprintf("%s:%s", cfg.Server.Address, cfg.Server.Port)),
+		zap.Strings("platforms", cfg.Platforms),
+		zap.String("run_mode", cfg.RunMode))
+	logConfigReport(log, cfg.Report)
+
+	// Initialize tracing, exporting spans when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Endpoint:    cfg.Tracing.Endpoint,
+		ServiceName: cfg.Tracing.ServiceName,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		log.Error("Failed to initialize tracing", zap.Error(err))
+		os.Exit(1)
+	}
+	if cfg.Tracing.Endpoint != "" {
+		log.Info("Tracing enabled", zap.String("endpoint", cfg.Tracing.Endpoint))
+	}
 
 	// Initialize database
 	dbConfig := database.DBConfig{
@@ -59,11 +100,21 @@ func main() {
 		Database: cfg.Database.Database,
 	}
 
-	gormDB, err := database.NewGormDB(dbConfig)
+	var gormDB *gorm.DB
+	if cfg.Database.Driver == config.DatabaseDriverSQLite {
+		gormDB, err = database.NewSQLiteDB(cfg.Database.SQLitePath)
+		log.Warn("Using the SQLite database for local development", zap.String("path", cfg.Database.SQLitePath))
+	} else {
+		gormDB, err = database.NewGormDB(dbConfig)
+	}
 	if err != nil {
 		log.Error("Failed to initialize GORM database", zap.Error(err))
 		os.Exit(1)
 	}
+	if err := database.SetQueryTimeout(gormDB, cfg.Database.Timeout); err != nil {
+		log.Error("Failed to set database query timeout", zap.Error(err))
+		os.Exit(1)
+	}
 	sqlDB, err := gormDB.DB()
 	if err != nil {
 		log.Error("Failed to get sql.DB from GORM", zap.Error(err))
@@ -74,14 +125,6 @@ func main() {
 	}()
 	log.Info("Database connected successfully")
 
-	// Initialize cache (which also connects to Redis)
-	_, err = cache.NewRedisCache(cfg.Redis.Host, cfg.Redis.Port, cfg.Redis.Password)
-	if err != nil {
-		log.Error("Failed to initialize cache", zap.Error(err))
-		os.Exit(1)
-	}
-	log.Info("Redis connected successfully")
-
 	// Create redis client for health checks
 	redisClient := redis.NewClient(&redis.Options{
 		Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
@@ -92,44 +135,463 @@ func main() {
 		_ = redisClient.Close()
 	}()
 
+	// The bot starts while Redis is down: the cache is then served from
+	// memory until Redis answers again
+	pingCtx, cancelPing := context.WithTimeout(context.Background(), 5*time.Second)
+	if err := redisClient.Ping(pingCtx).Err(); err != nil {
+		log.Warn("Redis unreachable, starting with the in-memory cache", zap.Error(err))
+	} else {
+		log.Info("Redis connected successfully")
+	}
+	cancelPing()
+
 	// Initialize metrics
 	metricsManager := metrics.NewMetrics()
 
-	// Initialize AI provider (Gemini)
-	geminiProvider, err := ai.NewGeminiProvider(cfg.Gemini.APIKey, cfg.Gemini.Model, metricsManager)
+	// Replace the built-in prompts with the templates of AI_PROMPT_TEMPLATE_DIR
+	if cfg.AI.PromptDir != "" {
+		templates, err := ai.LoadPromptTemplates(cfg.AI.PromptDir)
+		if err != nil {
+			log.Error("Failed to load prompt templates", zap.Error(err), zap.String("dir", cfg.AI.PromptDir))
+			os.Exit(1)
+		}
+		log.Info("Prompt templates loaded",
+			zap.String("dir", cfg.AI.PromptDir),
+			zap.Strings("templates", templates))
+	}
+
+	// Cap the AI tokens used each day, counted in Redis by every instance
+	var tokenBudget *ai.TokenBudget
+	if cfg.AI.DailyTokens > 0 {
+		tokenBudget = ai.NewTokenBudget(redisClient, cfg.AI.DailyTokens)
+		tokenBudget.SetKeyPrefix(cfg.Redis.KeyPrefix)
+		tokenBudget.SetMetrics(metricsManager)
+		log.Info("Daily AI token budget enabled", zap.Int64("tokens", cfg.AI.DailyTokens))
+	}
+
+	// Initialize AI providers (AI_PROVIDER, Gemini by default, then AI_FALLBACK_PROVIDERS)
+	aiChain := make([]ai.NamedProvider, 0, len(cfg.AI.Chain()))
+	for _, name := range cfg.AI.Chain() {
+		provider, err := ai.NewProvider(name, aiProviderConfig(cfg, name, metricsManager, tokenBudget))
+		if err != nil {
+			log.Error("Failed to initialize AI provider", zap.Error(err), zap.String("provider", name))
+			os.Exit(1)
+		}
+		aiChain = append(aiChain, ai.NamedProvider{Name: name, Provider: provider})
+	}
+	aiProvider, err := ai.NewChainedProvider(aiChain, cfg.AI.BreakerFailures, cfg.AI.BreakerCooldown)
 	if err != nil {
-		log.Error("Failed to initialize Gemini provider", zap.Error(err))
+		log.Error("Failed to initialize AI provider chain", zap.Error(err))
 		os.Exit(1)
 	}
+	aiProvider.SetMetrics(metricsManager)
+	if cfg.AI.HedgePercentile > 0 {
+		aiProvider.SetHedging(cfg.AI.HedgePercentile, cfg.AI.HedgeMinDelay)
+		log.Info("AI request hedging enabled",
+			zap.Float64("percentile", cfg.AI.HedgePercentile),
+			zap.Duration("min_delay", cfg.AI.HedgeMinDelay))
+	}
 	defer func() {
-		_ = geminiProvider.Close()
+		_ = aiProvider.Close()
 	}()
-	log.Info("Gemini provider initialized successfully")
+	log.Info("AI providers initialized successfully", zap.Strings("providers", cfg.AI.Chain()))
 
-	// Initialize cache instance
-	cacheInstance, err := cache.NewRedisCache(cfg.Redis.Host, cfg.Redis.Port, cfg.Redis.Password)
-	if err != nil {
-		log.Error("Failed to initialize cache instance", zap.Error(err))
-		os.Exit(1)
+	// Initialize cache instance: the primary Redis, failing over to the
+	// secondary Redis, if any, then to memory
+	primaryCache := cache.NewUncheckedRedisCache(cfg.Redis.Host, cfg.Redis.Port, cfg.Redis.Password)
+	primaryCache.SetTimeout(cfg.Redis.Timeout)
+	var secondaryCache service.Cache
+	if cfg.Redis.SecondaryHost != "" {
+		secondary := cache.NewUncheckedRedisCache(cfg.Redis.SecondaryHost, cfg.Redis.SecondaryPort, cfg.Redis.SecondaryPassword)
+		secondary.SetTimeout(cfg.Redis.Timeout)
+		secondaryCache = secondary
+	}
+	cacheInstance := cache.NewFailoverCache(primaryCache, secondaryCache, cache.NewMemoryCache(cfg.Redis.MemoryMaxEntries), cfg.Redis.FailoverRetry, log)
+	cacheInstance.SetMetrics(metricsManager)
+	// Ping the Redis backends so an unreachable one is skipped right away and
+	// used again once it answers
+	cacheCtx, stopCache := context.WithCancel(context.Background())
+	defer stopCache()
+	go cacheInstance.Run(cacheCtx, cfg.Redis.FailoverRetry)
+	// Keys are namespaced by CACHE_KEY_PREFIX and the cache schema version
+	appCache := cache.NewNamespacedCache(cacheInstance, cfg.Redis.KeyPrefix)
+	appCache.SetMaxTTL(int64(cfg.Redis.MaxTTL.Seconds()))
+
+	// Sample the primary Redis memory use and warn when evictions hurt the cache
+	if cfg.Redis.MemoryCheckInterval > 0 {
+		memoryGuard := cache.NewMemoryGuard(redisClient, log)
+		memoryGuard.SetMetrics(metricsManager)
+		memoryGuardCtx, stopMemoryGuard := context.WithCancel(context.Background())
+		defer stopMemoryGuard()
+		go memoryGuard.Run(memoryGuardCtx, cfg.Redis.MemoryCheckInterval)
 	}
 
-	// Initialize translation repository (implements model.TranslationRepository interface)
+	// Watch the primary database: while it is not writable, translations are
+	// served without being saved, and looked up in the replica, if any
+	var dbFailover *database.Failover
+	if cfg.Database.Driver == config.DatabaseDriverMySQL {
+		dbFailover = database.NewFailover(sqlDB, log)
+		dbFailover.SetMetrics(metricsManager)
+		failoverCtx, stopFailover := context.WithCancel(context.Background())
+		defer stopFailover()
+		go dbFailover.Run(failoverCtx, cfg.Database.FailoverCheckInterval)
+	}
+
+	var translationReplica service.TranslationRepository
+	if cfg.Database.Driver == config.DatabaseDriverMySQL && cfg.Database.ReplicaHost != "" {
+		replicaConfig := dbConfig
+		replicaConfig.Host = cfg.Database.ReplicaHost
+		replicaConfig.Port = cfg.Database.ReplicaPort
+		replicaDB, err := database.NewGormDB(replicaConfig)
+		if err == nil {
+			err = database.SetQueryTimeout(replicaDB, cfg.Database.Timeout)
+		}
+		if err != nil {
+			log.Warn("Database replica unreachable, lookups are skipped while the primary is not writable", zap.Error(err))
+		} else {
+			translationReplica = gormmysql.NewTranslationRepository(replicaDB)
+		}
+	}
+
+	// Initialize repositories (GORM-backed, implement the service repository interfaces)
 	translationRepo := gormmysql.NewTranslationRepository(gormDB)
+	channelRepo := gormmysql.NewChannelRepository(gormDB)
 
 	// Initialize security components
 	inputValidator := security.NewInputValidator(cfg.Security.MaxInputLength)
+	inputValidator.Allow(cfg.Security.InjectionAllowlist...)
+	// Phrases allowed through the admin API are stored and added on startup
+	injectionAllowlistRepo := gormmysql.NewInjectionAllowlistRepository(gormDB)
+	if phrases, err := injectionAllowlistRepo.GetAll(); err != nil {
+		log.Warn("Failed to load the stored injection allowlist", zap.Error(err))
+	} else {
+		inputValidator.Allow(phrases...)
+	}
 	outputValidator := security.NewOutputValidator(cfg.Security.MaxOutputLength)
 	securityMiddleware := middleware.NewSecurityMiddleware(inputValidator, outputValidator, log, cfg.Security.BlockHighThreat, cfg.Security.LogSuspiciousActivity)
+	securityMiddleware.SetMetrics(metricsManager)
+	securityMiddleware.SetShadowMode(cfg.Security.InjectionShadowMode)
+	securityMiddleware.SetAllowlistStore(injectionAllowlistRepo)
+
+	// Audit log for admin changes and security incidents
+	auditUseCase := service.NewAuditUseCase(gormmysql.NewAuditRepository(gormDB))
+
+	// Signed webhook for admin notifications and security alerts
+	var adminWebhook *webhook.Client
+	if cfg.Admin.WebhookURL != "" {
+		adminWebhook = webhook.NewClient(cfg.Admin.WebhookURL, cfg.Admin.WebhookSecret, 10*time.Second)
+	}
 
 	// Initialize translation use case
 	cacheTTL := int64(cfg.Application.CacheTTLTranslation)
-	translationUseCase := service.NewTranslationUseCase(log, translationRepo, cacheInstance, geminiProvider, cacheTTL, securityMiddleware, metricsManager)
+	// Hot translations are also kept in a local cache in front of Redis
+	var translationCache service.Cache = appCache
+	if cfg.Redis.LocalMaxEntries > 0 {
+		tieredCache := cache.NewTieredCache(appCache, cfg.Redis.LocalMaxEntries, cfg.Redis.LocalTTL)
+		tieredCache.SetMetrics(metricsManager)
+		translationCache = tieredCache
+	}
+	translationUseCase := service.NewTranslationUseCase(log, translationRepo, translationCache, aiProvider, cacheTTL, securityMiddleware, metricsManager)
+	translationUseCase.SetUnitOfWork(gormmysql.NewUnitOfWork(gormDB))
+	if dbFailover != nil {
+		translationUseCase.SetFailover(dbFailover, translationReplica)
+	}
+	translationUseCase.SetAuditor(auditUseCase)
+	analyticsUseCase := service.NewAnalyticsUseCase(gormmysql.NewAnalyticsRepository(gormDB), log)
+	analyticsUseCase.SetTokenPrices(cfg.Application.TokenPricePrompt, cfg.Application.TokenPriceOutput)
+	translationUseCase.SetUsageRecorder(analyticsUseCase)
+	if adminWebhook != nil {
+		translationUseCase.SetAlerter(adminWebhook)
+	}
+
+	// Initialize channel configuration use case
+	channelUseCase := service.NewChannelUseCase(channelRepo, appCache)
+
+	// Route canary channels to the newest prompt/provider version
+	if cfg.Gemini.CanaryEnabled {
+		canaryProvider, err := ai.NewGeminiProvider(cfg.Gemini.APIKey, cfg.Gemini.CanaryModel, metricsManager)
+		if err != nil {
+			log.Error("Failed to initialize canary Gemini provider", zap.Error(err))
+			os.Exit(1)
+		}
+		defer func() {
+			_ = canaryProvider.Close()
+		}()
+		if err := canaryProvider.SetPromptVersion(cfg.Gemini.CanaryPromptVersion); err != nil {
+			log.Error("Invalid canary prompt version", zap.Error(err))
+			os.Exit(1)
+		}
+		canaryProvider.SetBudget(tokenBudget)
+		translationUseCase.SetCanary(canaryProvider, channelUseCase)
+		log.Info("Canary translation enabled",
+			zap.String("model", cfg.Gemini.CanaryModel),
+			zap.String("prompt_version", cfg.Gemini.CanaryPromptVersion))
+	}
+
+	// Translate the text of images shared without a message
+	var imageOCR slackservice.ImageTextExtractor
+	if cfg.Gemini.OCREnabled {
+		ocrProvider, err := ai.NewGeminiProvider(cfg.Gemini.APIKey, cfg.Gemini.OCRModel, metricsManager)
+		if err != nil {
+			log.Error("Failed to initialize image OCR Gemini provider", zap.Error(err))
+			os.Exit(1)
+		}
+		defer func() {
+			_ = ocrProvider.Close()
+		}()
+		ocrProvider.SetBudget(tokenBudget)
+		imageOCR = ocrProvider
+		log.Info("Image OCR enabled", zap.String("model", cfg.Gemini.OCRModel))
+	}
+
+	// Limit translations per user and channel (RATE_LIMIT_PER_USER, RATE_LIMIT_PER_CHANNEL)
+	rateLimiter := ratelimit.NewRedisRateLimiter(redisClient)
+	rateLimiter.SetLimits(cfg.Application.RateLimitPerUser, cfg.Application.RateLimitPerChannel)
+	rateLimiter.SetKeyPrefix(cfg.Redis.KeyPrefix)
+
+	// Limit "Explain this message" shortcuts apart from translations
+	// (EXPLAIN_RATE_LIMIT_PER_USER, EXPLAIN_RATE_LIMIT_PER_CHANNEL)
+	explainRateLimiter := ratelimit.NewRedisRateLimiter(redisClient)
+	explainRateLimiter.SetLimits(cfg.Application.ExplainLimitPerUser, cfg.Application.ExplainLimitPerChannel)
+	explainKeyPrefix := "explain"
+	if cfg.Redis.KeyPrefix != "" {
+		explainKeyPrefix = cfg.Redis.KeyPrefix + ":explain"
+	}
+	explainRateLimiter.SetKeyPrefix(explainKeyPrefix)
 
 	// Initialize Slack client
 	slackClient := slackservice.NewSlackClient(cfg.Slack.BotToken)
 
+	// Object storage holds compliance exports and, when enabled, attachments
+	var objectStore storage.Store
+	if cfg.Storage.Backend != "" {
+		objectStore, err = storage.New(context.Background(), storage.Config{
+			Backend:     cfg.Storage.Backend,
+			Bucket:      cfg.Storage.Bucket,
+			Prefix:      cfg.Storage.Prefix,
+			LocalDir:    cfg.Storage.LocalDir,
+			S3Endpoint:  cfg.Storage.S3Endpoint,
+			S3Region:    cfg.Storage.S3Region,
+			S3AccessKey: cfg.Storage.S3AccessKey,
+			S3SecretKey: cfg.Storage.S3SecretKey,
+		})
+		if err != nil {
+			log.Error("Failed to initialize object storage", zap.Error(err))
+			os.Exit(1)
+		}
+	}
+
+	// Keep downloaded attachments and the text read from them in object
+	// storage, deleted by a lifecycle rule once they are old enough
+	var artifactStore slackservice.ArtifactStore
+	if cfg.Storage.ArtifactRetentionDays > 0 {
+		artifactCtx, stopArtifacts := context.WithCancel(context.Background())
+		defer stopArtifacts()
+		if err := objectStore.ExpireAfter(artifactCtx, "artifacts/", cfg.Storage.ArtifactRetentionDays); err != nil {
+			// The bucket may be managed elsewhere, with its own lifecycle rules
+			log.Warn("Failed to set the expiry of stored artifacts", zap.Error(err))
+		}
+		artifactStore = storage.WithPrefix(objectStore, "artifacts/")
+		log.Info("Keeping attachments in object storage",
+			zap.String("backend", cfg.Storage.Backend),
+			zap.Int("retention_days", cfg.Storage.ArtifactRetentionDays))
+	}
+
+	// Record what the bot posts and export it, signed, to object storage for e-discovery
+	var complianceUseCase *service.ComplianceUseCase
+	if cfg.Compliance.SigningKey != "" {
+		complianceUseCase, err = service.NewComplianceUseCase(
+			gormmysql.NewBotPostRepository(gormDB),
+			gormmysql.NewComplianceExportRepository(gormDB),
+			objectStore,
+			cfg.Compliance.SigningKey,
+			cfg.Compliance.MaxExportDays,
+			log,
+		)
+		if err != nil {
+			log.Error("Invalid COMPLIANCE_SIGNING_KEY", zap.Error(err))
+			os.Exit(1)
+		}
+		if cfg.Compliance.RecordPosts {
+			// Workspace clients inherit the recorder
+			slackClient.SetPostRecorder(complianceUseCase)
+			log.Info("Recording bot posts for compliance exports")
+		}
+	}
+
+	channelUseCase.SetChannelDirectory(slackClient)
+
+	// Install the app in other workspaces through OAuth, keeping their bot tokens encrypted
+	var workspaceUseCase *service.WorkspaceUseCase
+	var slackClients *slackservice.ClientPool
+	if cfg.Slack.MultiWorkspace() {
+		keyService, err := secrets.NewLocalKeyService(cfg.Secrets.MasterKeys, cfg.Secrets.CurrentKeyID)
+		if err != nil {
+			log.Error("Invalid secrets master keys", zap.Error(err))
+			os.Exit(1)
+		}
+		workspaceRepo := gormmysql.NewWorkspaceRepository(gormDB, secrets.NewEnvelope(keyService))
+		workspaceUseCase = service.NewWorkspaceUseCase(workspaceRepo, log)
+		slackClients = slackservice.NewClientPool(slackClient, workspaceUseCase, log)
+		log.Info("Multi-workspace installation enabled")
+	}
+
+	// Initialize per-channel message filter rules
+	filterRuleUseCase := service.NewFilterRuleUseCase(
+		gormmysql.NewFilterRuleRepository(gormDB),
+		slackClient,
+		cfg.Application.FilterRuleCacheTTL,
+		log,
+	)
+
+	// Hold translations for channels with a review channel until a reviewer approves them
+	reviewUseCase := service.NewTranslationReviewUseCase(
+		gormmysql.NewPendingTranslationRepository(gormDB),
+		channelUseCase,
+		slackClient,
+		log,
+	)
+	correctionUseCase := service.NewCorrectionUseCase(gormmysql.NewCorrectionRepository(gormDB))
+	reviewUseCase.SetCorrectionRecorder(correctionUseCase)
+
+	// Cross-post translations between paired channels
+	channelPairUseCase := service.NewChannelPairUseCase(gormmysql.NewChannelPairRepository(gormDB), log)
+
+	// Temporarily stop translation in a channel (slash command and admin API)
+	channelPauseUseCase := service.NewChannelPauseUseCase(appCache, log)
+
+	// Hold every queue during maintenance (admin API)
+	maintenanceUseCase := service.NewMaintenanceUseCase(appCache, cfg.Application.MaintenanceNotice, log)
+
+	// Serve the prompt version activated through the admin API to regular channels
+	promptDeploymentUseCase := service.NewPromptDeploymentUseCase(appCache, log)
+	translationUseCase.SetPromptVersions(promptDeploymentUseCase)
+	translationUseCase.SetRightToLeftFormatting(cfg.Application.RTLFormatting)
+
+	// Sample translations with the configured generation parameters, which
+	// workspaces and channels can override
+	translationUseCase.SetGeneration(ai.GenerationConfig{
+		Temperature:     cfg.AI.Temperature,
+		TopP:            cfg.AI.TopP,
+		MaxOutputTokens: cfg.AI.MaxOutputTokens,
+	})
+	var generationWorkspaces service.WorkspaceLookup
+	if workspaceUseCase != nil {
+		generationWorkspaces = workspaceUseCase
+	}
+	translationUseCase.SetGenerationOverrides(generationWorkspaces, channelUseCase)
+
+	// Translate messages arriving together in one AI call
+	if cfg.AI.BatchWindow > 0 {
+		translationUseCase.SetBatching(cfg.AI.BatchWindow, cfg.AI.BatchMaxSize)
+		log.Info("Translation batching enabled",
+			zap.Duration("window", cfg.AI.BatchWindow),
+			zap.Int("max_size", cfg.AI.BatchMaxSize))
+	}
+
+	// Route detected languages to target languages in unconfigured channels
+	languageRouter, err := service.NewLanguageRouter(cfg.Application.LanguagePairs)
+	if err != nil {
+		log.Error("Invalid language pairs", zap.Error(err))
+		os.Exit(1)
+	}
+
+	// 👍 and 👎 reactions rate posted translations
+	feedbackUseCase := service.NewFeedbackUseCase(gormmysql.NewFeedbackRepository(gormDB), appCache, log)
+	postedTranslationUseCase := service.NewPostedTranslationUseCase(appCache, log)
+
+	// Preferences users set for their own messages on the App Home tab
+	userPreferenceUseCase := service.NewUserPreferenceUseCase(gormmysql.NewUserPreferenceRepository(gormDB), appCache, log)
+	userPreferenceUseCase.SetHomeViewPublisher(slackClient)
+
+	// "Show original" and "Try another language" below translations
+	messageActions := slackservice.NewMessageActions(translationUseCase, slackClient, log)
+	if slackClients != nil {
+		messageActions.SetWorkspaceClients(slackClients)
+	}
+
+	// Direct messages to the bot are answered as an assistant
+	var directMessages service.DirectMessageService
+	if cfg.Slack.DirectMessageAssistant {
+		directMessageUseCase := service.NewDirectMessageUseCase(translationUseCase, appCache, cfg.Slack.DirectMessageContextTTL, log)
+		directMessageUseCase.SetUserPreferences(userPreferenceUseCase)
+		directMessageUseCase.SetLanguageRouter(languageRouter)
+		directMessages = directMessageUseCase
+	}
+
+	// Setup wizard sent to the user who installs the app in a new workspace
+	var onboarding service.OnboardingService
+	if workspaceUseCase != nil && cfg.Slack.OnboardingWizard {
+		wizard := slackservice.NewOnboardingWizard(slackClient)
+		wizard.SetWorkspaceClients(slackClients)
+		onboarding = service.NewOnboardingUseCase(appCache, channelUseCase, workspaceUseCase, wizard, log)
+	}
+
+	// Configure the channels the bot is invited to and ask which language they translate to
+	var channelOnboarding service.ChannelOnboardingService
+	if cfg.Slack.ChannelOnboarding {
+		setupMessenger := slackservice.NewChannelSetupMessenger(slackClient)
+		setupMessenger.SetWorkspaceClients(slackClients)
+		channelOnboardingUseCase, err := service.NewChannelOnboardingUseCase(channelUseCase, setupMessenger, cfg.Slack.ChannelDefaultLanguage, log)
+		if err != nil {
+			log.Error("Invalid SLACK_CHANNEL_DEFAULT_LANGUAGE", zap.Error(err))
+			os.Exit(1)
+		}
+		channelOnboarding = channelOnboardingUseCase
+	}
+
+	// Bilingual summaries of threads, on /summarize and "@bot summarize"
+	threadReader := slackservice.NewThreadReader(slackClient)
+	summarizationUseCase := service.NewSummarizationUseCase(threadReader, aiProvider, translationUseCase, log)
+	summarizationUseCase.SetChannels(channelUseCase)
+	summarizationUseCase.SetLanguageRouter(languageRouter)
+	threadSummaries := slackservice.NewThreadSummaryPoster(summarizationUseCase, slackClient, log)
+	if slackClients != nil {
+		threadReader.SetWorkspaceClients(slackClients)
+		threadSummaries.SetWorkspaceClients(slackClients)
+	}
+
+	// Explanations of idioms and slang, on the "Explain this message" shortcut
+	explanationUseCase := service.NewExplanationUseCase(aiProvider, log)
+	explanationUseCase.SetUserPreferences(userPreferenceUseCase)
+	explanationUseCase.SetRateLimiter(explainRateLimiter)
+
+	// Batch translations of channels in digest mode
+	digest := slackservice.NewDigest(channelUseCase, slackClient, log)
+
+	// Post translations off the workers, in order per thread
+	var outbox *slackservice.Outbox
+	if cfg.Slack.OutboxMaxPending > 0 {
+		outbox = slackservice.NewOutbox(cfg.Slack.OutboxMaxPending, log)
+		outbox.SetRetry(cfg.Slack.OutboxRetryFor, cfg.Slack.OutboxRetryMaxBuffered)
+		outbox.SetMetrics(metricsManager)
+	}
+
 	// Initialize event processor (implements slack.EventProcessor interface)
-	eventProc := slackservice.NewEventProcessor(translationUseCase, slackClient, log)
+	eventProc := slackservice.NewEventProcessor(translationUseCase, slackClient, log,
+		slackservice.WithMessageFilter(filterRuleUseCase),
+		slackservice.WithMetrics(metricsManager),
+		slackservice.WithTranslationReview(reviewUseCase),
+		slackservice.WithChannelPairs(channelPairUseCase),
+		slackservice.WithDigest(digest),
+		slackservice.WithChannelConfigs(channelUseCase),
+		slackservice.WithChannelPauses(channelPauseUseCase),
+		slackservice.WithWorkspaceClients(slackClients),
+		slackservice.WithImageOCR(imageOCR),
+		slackservice.WithRateLimiter(rateLimiter),
+		slackservice.WithLanguageRouter(languageRouter),
+		slackservice.WithFilePrivacy(slackservice.FilePrivacyPolicy(cfg.Slack.FilePrivacy)),
+		slackservice.WithThumbnails(cfg.Slack.ThumbnailSize),
+		slackservice.WithFeedback(feedbackUseCase),
+		slackservice.WithEditRetranslation(postedTranslationUseCase),
+		slackservice.WithOutbox(outbox),
+		slackservice.WithUserPreferences(userPreferenceUseCase),
+		slackservice.WithReplyButtons(cfg.Slack.ReplyButtons),
+		slackservice.WithDirectMessageAssistant(directMessages),
+		slackservice.WithThreadSummaries(threadSummaries),
+		slackservice.WithChannelOnboarding(channelOnboarding),
+		slackservice.WithArtifactStore(artifactStore),
+	)
 
 	// Initialize worker pool for ordered message processing
 	workerPool := queue.NewWorkerPool(
@@ -138,12 +600,112 @@ func main() {
 		cfg.Application.QueueIdleTimeout,
 		log,
 	)
+	workerPool.SetName(config.PlatformSlack)
+	workerPool.SetMaxWorkers(cfg.Application.QueueMaxWorkers)
+	workerPool.SetReorderWindow(cfg.Application.MessageReorderWindow)
+	workerPool.SetProcessingTimeout(cfg.Application.EventProcessingTimeout)
+	workerPool.SetMetrics(metricsManager)
+	workerPool.StartWatchdog(cfg.Application.QueueWatchdogMaxAge)
+	workerPool.SetBacklogNotice(slackservice.NewBacklogNotice(slackClient, log), cfg.Application.QueueBacklogThreshold)
+	workerPool.SetPayloadLimits(cfg.Application.QueuePayloadCompressBytes, cfg.Application.QueueMaxPayloadBytes)
+	workerPool.SetChannelPauses(channelPauseUseCase)
+	processingMarker := queue.NewRedisProcessingMarker(redisClient, cfg.Application.EventProcessingTimeout)
+	processingMarker.SetKeyPrefix(cfg.Redis.KeyPrefix)
+	workerPool.SetProcessingMarker(processingMarker)
+	// Slack's retries are dropped on every instance, by event_id
+	eventDedup := queue.NewRedisEventDeduplicator(redisClient, cfg.Application.EventDedupTTL)
+	eventDedup.SetKeyPrefix(cfg.Redis.KeyPrefix)
+	workerPool.SetEventDeduplicator(eventDedup)
+	workerPool.SetEventDedupLimits(cfg.Application.EventDedupTTL, cfg.Application.EventDedupMaxEntries)
+	maintenanceNotice := slackservice.NewMaintenanceNotice(maintenanceUseCase, slackClient, log)
+	workerPool.SetMaintenance(maintenanceUseCase, maintenanceNotice)
 	log.Info("Worker pool initialized",
 		zap.Int("buffer_size", cfg.Application.QueueBufferSize),
-		zap.Duration("idle_timeout", cfg.Application.QueueIdleTimeout))
+		zap.Duration("idle_timeout", cfg.Application.QueueIdleTimeout),
+		zap.Int("max_workers", cfg.Application.QueueMaxWorkers),
+		zap.Duration("reorder_window", cfg.Application.MessageReorderWindow))
+
+	// Persist Slack events in a Redis stream before the worker pool processes them
+	var slackQueue queue.Queue = workerPool
+	var redisQueue *queue.RedisQueue
+	if cfg.Queue.Backend == config.QueueBackendRedis {
+		redisQueue = queue.NewRedisQueue(redisClient, cfg.Queue.Stream, cfg.Queue.ConsumerGroup, cfg.Queue.ConsumerName, workerPool, log)
+		redisQueue.SetMaxLen(cfg.Queue.MaxLen)
+		redisQueue.SetClaimIdle(cfg.Queue.ClaimIdle)
+		redisQueue.SetMaintenance(maintenanceUseCase, maintenanceNotice)
+		if err := redisQueue.Start(); err != nil {
+			log.Error("Failed to start Redis queue", zap.Error(err))
+			os.Exit(1)
+		}
+		slackQueue = redisQueue
+	}
+
+	// Other chat platforms share the translation core, each on a worker pool of its own
+	chatTranslationUseCase := service.NewChatTranslationUseCase(translationUseCase, log)
+	chatTranslationUseCase.SetLanguageRouter(languageRouter)
+
+	var teamsPool *queue.WorkerPool
+	if cfg.PlatformEnabled(config.PlatformTeams) {
+		teamsProc := teams.NewProcessor(
+			teams.NewClient(cfg.Teams.AppID, cfg.Teams.AppPassword),
+			chatTranslationUseCase,
+			log,
+		)
+		teamsPool = newChatWorkerPool(config.PlatformTeams, teamsProc, cfg.Application, metricsManager, log)
+		teamsPool.SetChannelPauses(channelPauseUseCase)
+		teamsPool.SetMaintenance(maintenanceUseCase, nil)
+		log.Info("Microsoft Teams adapter enabled")
+	}
 
-	// Initialize router
-	r := gin.Default()
+	gatewayCtx, stopGateway := context.WithCancel(context.Background())
+	defer stopGateway()
+	var discordPool *queue.WorkerPool
+	if cfg.PlatformEnabled(config.PlatformDiscord) {
+		discordProc := discord.NewProcessor(discord.NewClient(cfg.Discord.BotToken), chatTranslationUseCase, log)
+		discordPool = newChatWorkerPool(config.PlatformDiscord, discordProc, cfg.Application, metricsManager, log)
+		discordPool.SetChannelPauses(channelPauseUseCase)
+		discordPool.SetMaintenance(maintenanceUseCase, nil)
+		gateway := discord.NewGateway(cfg.Discord.BotToken, discordPool, log)
+		go func() {
+			if err := gateway.Run(gatewayCtx); err != nil {
+				log.Error("Discord gateway stopped", zap.Error(err))
+			}
+		}()
+		log.Info("Discord adapter enabled")
+	}
+
+	// Post periodic security summaries to the ops channel
+	reportCtx, stopReports := context.WithCancel(context.Background())
+	defer stopReports()
+	if cfg.PlatformEnabled(config.PlatformSlack) && cfg.Slack.OpsChannelID != "" && cfg.Slack.SecurityReportInterval > 0 {
+		reporter := slackservice.NewSecurityReporter(metricsManager, slackClient, cfg.Slack.OpsChannelID, log)
+		go reporter.Run(reportCtx, cfg.Slack.SecurityReportInterval)
+		log.Info("Security summaries enabled",
+			zap.String("channel_id", cfg.Slack.OpsChannelID),
+			zap.Duration("interval", cfg.Slack.SecurityReportInterval))
+	}
+
+	// Alert when reply latency burns through its SLO error budget
+	var sloAlerter service.Alerter
+	if adminWebhook != nil {
+		sloAlerter = adminWebhook
+	}
+	sloMonitor := service.NewLatencySLOMonitor(metricsManager, sloAlerter,
+		cfg.Application.LatencySLOThreshold, cfg.Application.LatencySLOTarget, log)
+	go sloMonitor.Run(reportCtx, time.Minute)
+
+	// Post channel digests as they fall due
+	go digest.Run(reportCtx, 30*time.Second)
+
+	// Initialize router: release mode outside development, with requests and
+	// recovered panics logged through zap and panics reported to the admin webhook
+	gin.SetMode(middleware.GinMode(cfg.Application.Environment))
+	var panicReporter middleware.ErrorReporter
+	if adminWebhook != nil {
+		panicReporter = adminWebhook
+	}
+	r := gin.New()
+	r.Use(middleware.RequestLoggerGin(log), middleware.RecoveryGin(log, panicReporter))
 
 	// Health check endpoint
 	healthHandler := controller.NewHealthCheckHandler(sqlDB, redisClient, log)
@@ -153,31 +715,227 @@ func main() {
 	metricsHandler := controller.NewMetricsHandler(metricsManager, log)
 	r.GET("/metrics", metricsHandler.HandleMetricsGin)
 
-	// Slack webhook with signature verification
-	slackGroup := r.Group("/slack")
-	slackGroup.Use(middleware.VerifySlackSignatureGin(cfg.Slack.SigningSecret))
-	{
-		slackHandler := controller.NewSlackWebhookHandler(workerPool, log)
-		slackGroup.POST("/events", slackHandler.HandleSlackEventsGin)
+	// Slack webhook with signature verification, or a Socket Mode connection.
+	// Workers receive nothing from Slack: they read the events of the stream.
+	if cfg.PlatformEnabled(config.PlatformSlack) && cfg.RunMode != config.RunModeWorker {
+		slackHandler := controller.NewSlackWebhookHandler(slackQueue, log)
+		interactionHandler := controller.NewSlackInteractionHandler(reviewUseCase, log)
+		interactionHandler.SetUserPreferences(userPreferenceUseCase)
+		interactionHandler.SetMessageActions(messageActions)
+		interactionHandler.SetOnboarding(onboarding)
+		interactionHandler.SetChannelOnboarding(channelOnboarding)
+		interactionHandler.SetExplanations(explanationUseCase)
+		commandHandler := controller.NewSlackCommandHandler(channelPauseUseCase, log)
+		commandHandler.SetAnalytics(analyticsUseCase)
+		commandHandler.SetThreadSummaries(threadSummaries)
+		if workspaceUseCase != nil {
+			installer := slackservice.NewOAuthInstaller(cfg.Slack.ClientID, cfg.Slack.ClientSecret, cfg.Slack.OAuthRedirectURL, cfg.Slack.OAuthScopes)
+			oauthHandler := controller.NewSlackOAuthHandler(installer, workspaceUseCase, log)
+			oauthHandler.SetOnboarding(onboarding)
+			r.GET("/slack/install", oauthHandler.InstallGin)
+			r.GET("/slack/oauth/callback", oauthHandler.CallbackGin)
+		}
+		if cfg.Slack.Mode == config.SlackModeSocket {
+			socketClient := socketmode.New(slack.New(cfg.Slack.BotToken, slack.OptionAppLevelToken(cfg.Slack.AppToken)))
+			socketHandler := controller.NewSlackSocketHandler(slackHandler, interactionHandler, log)
+			socketHandler.SetCommandHandler(commandHandler)
+			go func() {
+				if err := socketHandler.Run(gatewayCtx, socketClient); err != nil {
+					log.Error("Slack Socket Mode connection stopped", zap.Error(err))
+				}
+			}()
+			log.Info("Slack Socket Mode enabled")
+		} else {
+			slackGroup := r.Group("/slack")
+			if workspaceUseCase != nil {
+				slackGroup.Use(middleware.VerifySlackWorkspaceSignatureGin(cfg.Slack.SigningSecret, workspaceUseCase))
+			} else {
+				slackGroup.Use(middleware.VerifySlackSignatureGin(cfg.Slack.SigningSecret))
+			}
+			{
+				slackGroup.POST("/events", slackHandler.HandleSlackEventsGin)
+				slackGroup.POST("/interactions", interactionHandler.HandleInteractionGin)
+				slackGroup.POST("/commands", commandHandler.HandleCommandGin)
+			}
+		}
 	}
 
-	// Start HTTP server
-	address := net.JoinHostPort(cfg.Server.Address, cfg.Server.Port)
-	server := &http.Server{
-		Addr:         address,
-		Handler:      r,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+	// Microsoft Teams messaging endpoint, authenticated with Bot Framework tokens
+	if teamsPool != nil {
+		teamsHandler := controller.NewTeamsHandler(teamsPool, log)
+		r.POST("/teams/messages",
+			middleware.VerifyTeamsTokenGin(teams.NewTokenVerifier(cfg.Teams.AppID)),
+			teamsHandler.HandleActivityGin)
+	}
+
+	// Admin API (only mounted when ADMIN_API_TOKEN or ADMIN_API_KEYS is configured)
+	adminKeys, err := buildAdminKeys(cfg.Admin)
+	if err != nil {
+		log.Error("Invalid admin API keys", zap.Error(err))
+		os.Exit(1)
 	}
+	if len(adminKeys) > 0 {
+		adminGroup := r.Group("/admin")
+		adminGroup.Use(middleware.AuthenticateAdminGin(adminKeys))
+
+		channelHandler := controller.NewChannelConfigHandler(channelUseCase, log)
+		if adminWebhook != nil {
+			channelHandler.SetNotifier(adminWebhook)
+		}
+		filterRuleHandler := controller.NewFilterRuleHandler(filterRuleUseCase, log)
+
+		channelHandler.SetAuditor(auditUseCase)
+		filterRuleHandler.SetAuditor(auditUseCase)
+		auditHandler := controller.NewAuditHandler(auditUseCase, log)
+		securityHandler := controller.NewSecurityHandler(securityMiddleware, log)
+		securityHandler.SetAuditor(auditUseCase)
+		correctionHandler := controller.NewCorrectionHandler(correctionUseCase, log)
+		feedbackHandler := controller.NewFeedbackHandler(feedbackUseCase, log)
+		channelPairHandler := controller.NewChannelPairHandler(channelPairUseCase, log)
+		channelPairHandler.SetAuditor(auditUseCase)
+		channelPauseHandler := controller.NewChannelPauseHandler(channelPauseUseCase, log)
+		channelPauseHandler.SetAuditor(auditUseCase)
+		maintenanceHandler := controller.NewMaintenanceHandler(maintenanceUseCase, log)
+		maintenanceHandler.SetAuditor(auditUseCase)
+		promptHandler := controller.NewPromptHandler(promptDeploymentUseCase, log)
+		promptHandler.SetAuditor(auditUseCase)
+		translationHandler := controller.NewTranslationHandler(translationUseCase, log)
+		queueHandler := controller.NewQueueHandler(log)
+		queueHandler.SetAuditor(auditUseCase)
+		queueHandler.AddPool(config.PlatformSlack, workerPool)
+		var workspaceHandler *controller.WorkspaceHandler
+		if workspaceUseCase != nil {
+			workspaceHandler = controller.NewWorkspaceHandler(workspaceUseCase, log)
+			workspaceHandler.SetAuditor(auditUseCase)
+		}
+		configBundleUseCase := service.NewConfigBundleUseCase(channelUseCase, filterRuleUseCase, channelPairUseCase, log)
+		if workspaceUseCase != nil {
+			configBundleUseCase.SetWorkspaces(workspaceUseCase)
+		}
+		configBundleHandler := controller.NewConfigBundleHandler(configBundleUseCase, log)
+		configBundleHandler.SetAuditor(auditUseCase)
+		configReportHandler := controller.NewConfigReportHandler(cfg.Report)
+		if teamsPool != nil {
+			queueHandler.AddPool(config.PlatformTeams, teamsPool)
+		}
+		if discordPool != nil {
+			queueHandler.AddPool(config.PlatformDiscord, discordPool)
+		}
+		// The backlog of the Redis queue is that of every instance, workers included
+		scalingHandler := controller.NewScalingHandler(metricsManager, log)
+		scalingHandler.SetProviders(aiProvider)
+		if redisQueue != nil {
+			scalingHandler.AddQueue(config.PlatformSlack, redisQueue)
+		} else {
+			scalingHandler.AddQueue(config.PlatformSlack, workerPool)
+		}
+		if teamsPool != nil {
+			scalingHandler.AddQueue(config.PlatformTeams, teamsPool)
+		}
+		if discordPool != nil {
+			scalingHandler.AddQueue(config.PlatformDiscord, discordPool)
+		}
 
-	log.Info("Starting HTTP server", zap.String("address", address))
+		// Viewer: read configuration
+		viewerGroup := adminGroup.Group("", middleware.RequireRoleGin(model.RoleViewer))
+		{
+			viewerGroup.GET("/channels", channelHandler.ListGin)
+			viewerGroup.GET("/channels/:channel_id", channelHandler.GetGin)
+			viewerGroup.GET("/channels/:channel_id/pause", channelPauseHandler.GetGin)
+			viewerGroup.GET("/rules", filterRuleHandler.ListGin)
+			viewerGroup.GET("/channel-pairs", channelPairHandler.ListGin)
+			viewerGroup.GET("/security/allowlist", securityHandler.AllowlistGin)
+			viewerGroup.GET("/corrections/suggestions", correctionHandler.SuggestionsGin)
+			viewerGroup.GET("/feedback/summary", feedbackHandler.SummaryGin)
+			viewerGroup.GET("/queues", queueHandler.ListGin)
+			viewerGroup.GET("/scaling", scalingHandler.GetGin)
+			viewerGroup.GET("/maintenance", maintenanceHandler.GetGin)
+			viewerGroup.GET("/prompts", promptHandler.GetGin)
+			viewerGroup.GET("/translations/:translation_id", translationHandler.GetGin)
+			viewerGroup.GET("/config", configReportHandler.GetGin)
+			viewerGroup.GET("/config/export", configBundleHandler.ExportGin)
+			if workspaceHandler != nil {
+				viewerGroup.GET("/workspaces", workspaceHandler.ListGin)
+			}
+		}
+
+		// Operator: modify configuration
+		operatorGroup := adminGroup.Group("", middleware.RequireRoleGin(model.RoleOperator))
+		{
+			operatorGroup.POST("/channels", channelHandler.CreateGin)
+			operatorGroup.POST("/channels/bulk", channelHandler.BulkApplyGin)
+			operatorGroup.PUT("/channels/:channel_id", channelHandler.UpdateGin)
+			operatorGroup.POST("/channels/:channel_id/pause", channelPauseHandler.PauseGin)
+			operatorGroup.POST("/channels/:channel_id/resume", channelPauseHandler.ResumeGin)
+			operatorGroup.POST("/rules", filterRuleHandler.CreateGin)
+			operatorGroup.PUT("/rules/:rule_id", filterRuleHandler.UpdateGin)
+			operatorGroup.POST("/channel-pairs", channelPairHandler.CreateGin)
+			operatorGroup.POST("/security/feedback", securityHandler.FeedbackGin)
+			operatorGroup.POST("/queues/:key/pause", queueHandler.PauseGin)
+			operatorGroup.POST("/queues/:key/resume", queueHandler.ResumeGin)
+			operatorGroup.POST("/maintenance/enable", maintenanceHandler.EnableGin)
+			operatorGroup.POST("/maintenance/disable", maintenanceHandler.DisableGin)
+			operatorGroup.PUT("/prompts/active", promptHandler.ActivateGin)
+			operatorGroup.POST("/prompts/rollback", promptHandler.RollbackGin)
+			operatorGroup.POST("/translations", translationHandler.TranslateGin)
+			if workspaceHandler != nil {
+				operatorGroup.PUT("/workspaces/:team_id/signing-secret", workspaceHandler.SetSigningSecretGin)
+				operatorGroup.PUT("/workspaces/:team_id/generation", workspaceHandler.SetGenerationGin)
+			}
+		}
+
+		// Admin: delete data and review the audit trail
+		fullAdminGroup := adminGroup.Group("", middleware.RequireRoleGin(model.RoleAdmin))
+		{
+			fullAdminGroup.GET("/audit", auditHandler.ListGin)
+			fullAdminGroup.DELETE("/channels/:channel_id", channelHandler.DeleteGin)
+			fullAdminGroup.DELETE("/rules/:rule_id", filterRuleHandler.DeleteGin)
+			fullAdminGroup.DELETE("/channel-pairs/:pair_id", channelPairHandler.DeleteGin)
+			// Flushing drops queued messages for good
+			fullAdminGroup.POST("/queues/:key/flush", queueHandler.FlushGin)
+			// Applying a bundle may delete configuration
+			fullAdminGroup.POST("/config/import", configBundleHandler.ImportGin)
+			if workspaceHandler != nil {
+				fullAdminGroup.DELETE("/workspaces/:team_id", workspaceHandler.DeleteGin)
+			}
+			if complianceUseCase != nil {
+				complianceHandler := controller.NewComplianceHandler(complianceUseCase, log)
+				complianceHandler.SetAuditor(auditUseCase)
+				fullAdminGroup.POST("/compliance/exports", complianceHandler.ExportGin)
+				fullAdminGroup.GET("/compliance/exports", complianceHandler.ListGin)
+				fullAdminGroup.GET("/compliance/public-key", complianceHandler.PublicKeyGin)
+			}
+		}
+	} else {
+		log.Info("ADMIN_API_TOKEN and ADMIN_API_KEYS not set, admin API disabled")
+	}
 
 	// Channel to listen for server errors
 	serverErrors := make(chan error, 1)
-	go func() {
-		serverErrors <- server.ListenAndServe()
-	}()
+
+	// Start HTTP server, except on workers, which only process the Redis
+	// queue and scale apart from the instances receiving events
+	var server *http.Server
+	if cfg.RunMode == config.RunModeWorker {
+		log.Info("Running as a queue worker, HTTP server disabled",
+			zap.String("stream", cfg.Queue.Stream),
+			zap.String("group", cfg.Queue.ConsumerGroup),
+			zap.String("consumer", cfg.Queue.ConsumerName))
+	} else {
+		address := net.JoinHostPort(cfg.Server.Address, cfg.Server.Port)
+		server = newHTTPServer(address, r, cfg.Server)
+
+		log.Info("Starting HTTP server",
+			zap.String("address", address),
+			zap.Duration("read_timeout", cfg.Server.ReadTimeout),
+			zap.Duration("write_timeout", cfg.Server.WriteTimeout),
+			zap.Duration("idle_timeout", cfg.Server.IdleTimeout),
+			zap.Bool("http2", cfg.Server.HTTP2Enabled))
+
+		go func() {
+			serverErrors <- server.ListenAndServe()
+		}()
+	}
 
 	// Channel to listen for interrupt signals
 	sigChan := make(chan os.Signal, 1)
@@ -193,23 +951,169 @@ func main() {
 	case sig := <-sigChan:
 		log.Info("Received shutdown signal", zap.String("signal", sig.String()))
 
-		// Step 1: Shutdown worker pool (drain remaining messages)
+		// Stop reading Discord messages and Slack Socket Mode events before draining their queues
+		stopGateway()
+
+		// Step 1: Shutdown worker pool (drain remaining messages). The Redis
+		// queue stops first, so the events left in the stream wait for the next start.
+		if redisQueue != nil {
+			redisQueue.Stop()
+		}
 		log.Info("Shutting down worker pool...")
 		if err := workerPool.Shutdown(30 * time.Second); err != nil {
 			log.Error("Worker pool shutdown error", zap.Error(err))
 		} else {
 			log.Info("Worker pool stopped successfully")
 		}
+		if teamsPool != nil {
+			if err := teamsPool.Shutdown(30 * time.Second); err != nil {
+				log.Error("Teams worker pool shutdown error", zap.Error(err))
+			}
+		}
+		if discordPool != nil {
+			if err := discordPool.Shutdown(30 * time.Second); err != nil {
+				log.Error("Discord worker pool shutdown error", zap.Error(err))
+			}
+		}
+
+		// Post the replies of the drained messages
+		if outbox != nil {
+			if err := outbox.Shutdown(30 * time.Second); err != nil {
+				log.Error("Outbox shutdown error", zap.Error(err))
+			}
+		}
+
+		// Post pending digests so drained translations are not lost
+		digest.Flush()
 
 		// Step 2: Shutdown HTTP server
-		log.Info("Shutting down HTTP server...")
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
-		if err := server.Shutdown(ctx); err != nil {
-			log.Error("Server shutdown error", zap.Error(err))
-			os.Exit(1)
+		if server != nil {
+			log.Info("Shutting down HTTP server...")
+			if err := server.Shutdown(ctx); err != nil {
+				log.Error("Server shutdown error", zap.Error(err))
+				os.Exit(1)
+			}
+		}
+
+		// Step 3: Flush the spans of the last events
+		if err := shutdownTracing(ctx); err != nil {
+			log.Error("Tracing shutdown error", zap.Error(err))
 		}
 	}
 
 	log.Info("Application stopped gracefully")
 }
+
+// newHTTPServer builds the HTTP server from its configuration. The server
+// has no TLS, so HTTP/2 is served unencrypted (h2c) when enabled.
+func newHTTPServer(address string, handler http.Handler, cfg config.ServerConfig) *http.Server {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(cfg.HTTP2Enabled)
+
+	return &http.Server{
+		Addr:           address,
+		Handler:        handler,
+		ReadTimeout:    cfg.ReadTimeout,
+		WriteTimeout:   cfg.WriteTimeout,
+		IdleTimeout:    cfg.IdleTimeout,
+		MaxHeaderBytes: cfg.MaxHeaderBytes,
+		Protocols:      protocols,
+		HTTP2: &http.HTTP2Config{
+			MaxConcurrentStreams: cfg.HTTP2MaxConcurrentStreams,
+		},
+	}
+}
+
+// logConfigReport logs the settings that differ from their defaults and warns
+// about unknown and deprecated environment variables
+func logConfigReport(log *zap.Logger, report config.Report) {
+	overridden := report.Overridden()
+	fields := make([]zap.Field, 0, len(overridden))
+	for _, setting := range overridden {
+		fields = append(fields, zap.String(setting.Key, setting.Value))
+	}
+	log.Info("Configuration overrides", fields...)
+
+	for _, setting := range overridden {
+		if setting.Source == config.SourceInvalid {
+			log.Warn("Invalid environment variable ignored, using the default",
+				zap.String("key", setting.Key),
+				zap.String("value", setting.Value),
+				zap.String("default", setting.Default))
+		}
+	}
+	for _, unknown := range report.Unknown {
+		log.Warn("Unknown environment variable ignored",
+			zap.String("key", unknown.Key),
+			zap.String("did_you_mean", unknown.Suggestion))
+	}
+	for _, deprecated := range report.Deprecated {
+		log.Warn("Deprecated environment variable, rename it",
+			zap.String("key", deprecated.Key),
+			zap.String("replacement", deprecated.Replacement))
+	}
+}
+
+// aiProviderConfig returns the settings of the named AI provider
+func aiProviderConfig(cfg *config.Config, name string, m *metrics.Metrics, budget *ai.TokenBudget) ai.ProviderConfig {
+	if name == ai.ProviderOpenAI {
+		return ai.ProviderConfig{
+			APIKey:  cfg.OpenAI.APIKey,
+			Model:   cfg.OpenAI.Model,
+			BaseURL: cfg.OpenAI.BaseURL,
+			Metrics: m,
+			Models:  cfg.OpenAI.ChannelModels,
+			Budget:  budget,
+		}
+	}
+	return ai.ProviderConfig{
+		APIKey:  cfg.Gemini.APIKey,
+		Model:   cfg.Gemini.Model,
+		Metrics: m,
+		Models:  cfg.Gemini.ChannelModels,
+		Budget:  budget,
+	}
+}
+
+// newChatWorkerPool creates the worker pool of a chat platform other than Slack
+func newChatWorkerPool(platform string, processor slackservice.EventProcessor, cfg config.ApplicationConfig, m *metrics.Metrics, log *zap.Logger) *queue.WorkerPool {
+	pool := queue.NewWorkerPool(processor, cfg.QueueBufferSize, cfg.QueueIdleTimeout, log)
+	pool.SetName(platform)
+	pool.SetMaxWorkers(cfg.QueueMaxWorkers)
+	pool.SetProcessingTimeout(cfg.EventProcessingTimeout)
+	pool.SetMetrics(m)
+	pool.SetEventDedupLimits(cfg.EventDedupTTL, cfg.EventDedupMaxEntries)
+	pool.StartWatchdog(cfg.QueueWatchdogMaxAge)
+	return pool
+}
+
+// parsePlatforms splits the -platforms flag; an empty flag keeps PLATFORMS
+func parsePlatforms(value string) []string {
+	var platforms []string
+	for _, platform := range strings.Split(value, ",") {
+		if platform = strings.TrimSpace(platform); platform != "" {
+			platforms = append(platforms, platform)
+		}
+	}
+	return platforms
+}
+
+// buildAdminKeys maps configured API keys to roles. ADMIN_API_TOKEN keeps
+// working as a key with the admin role.
+func buildAdminKeys(cfg config.AdminConfig) (middleware.APIKeyRoles, error) {
+	keys := make(middleware.APIKeyRoles, len(cfg.APIKeys)+1)
+	for key, roleName := range cfg.APIKeys {
+		role, err := model.ParseAdminRole(roleName)
+		if err != nil {
+			return nil, err
+		}
+		keys[key] = role
+	}
+	if cfg.APIToken != "" {
+		keys[cfg.APIToken] = model.RoleAdmin
+	}
+	return keys, nil
+}
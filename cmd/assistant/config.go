@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// configClient calls the config endpoints of a deployment's admin API
+type configClient struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// addConfigFlags registers the flags selecting the deployment
+func addConfigFlags(flags *flag.FlagSet) *configClient {
+	client := &configClient{http: &http.Client{Timeout: 60 * time.Second}}
+	flags.StringVar(&client.baseURL, "url", envOr("ASSISTANT_URL", "http://localhost:8080"), "base URL of the deployment (defaults to ASSISTANT_URL)")
+	flags.StringVar(&client.token, "token", os.Getenv("ADMIN_API_TOKEN"), "admin API token (defaults to ADMIN_API_TOKEN)")
+	return client
+}
+
+// runConfig dispatches the config subcommands and returns the exit code
+func runConfig(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+	switch args[0] {
+	case "export":
+		return runConfigExport(args[1:])
+	case "import":
+		return runConfigImport(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown config command %q\n\n%s", args[0], usage)
+		return 2
+	}
+}
+
+// runConfigExport writes the configuration bundle of the deployment
+func runConfigExport(args []string) int {
+	flags := flag.NewFlagSet("config export", flag.ExitOnError)
+	client := addConfigFlags(flags)
+	format := flags.String("format", "yaml", "bundle format: yaml or json")
+	output := flags.String("o", "", "file to write the bundle to (defaults to stdout)")
+	_ = flags.Parse(args)
+
+	body, err := client.do(http.MethodGet, "/admin/config/export?format="+*format, "", nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "export failed: %v\n", err)
+		return 1
+	}
+	if *output == "" {
+		_, _ = os.Stdout.Write(body)
+		return 0
+	}
+	if err := os.WriteFile(*output, body, 0o600); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write bundle: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runConfigImport applies a bundle to the deployment and prints the changes.
+// It exits 1 when a change could not be applied.
+func runConfigImport(args []string) int {
+	flags := flag.NewFlagSet("config import", flag.ExitOnError)
+	client := addConfigFlags(flags)
+	dryRun := flags.Bool("dry-run", false, "only print the changes the bundle would make")
+	prune := flags.Bool("prune", false, "delete the channels, rules and channel pairs missing from the bundle")
+	_ = flags.Parse(args)
+	if flags.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: assistant config import [flags] <bundle.yaml|bundle.json>")
+		return 2
+	}
+
+	path := flags.Arg(0)
+	bundle, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read bundle: %v\n", err)
+		return 1
+	}
+	contentType := "application/json"
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" {
+		contentType = "application/yaml"
+	}
+
+	query := fmt.Sprintf("/admin/config/import?dry_run=%t&prune=%t", *dryRun, *prune)
+	body, err := client.do(http.MethodPost, query, contentType, bundle)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "import failed: %v\n", err)
+		return 1
+	}
+
+	var plan configPlan
+	if err := json.Unmarshal(body, &plan); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to decode plan: %v\n", err)
+		return 1
+	}
+	plan.print(os.Stdout)
+	if plan.failed() > 0 {
+		return 1
+	}
+	return 0
+}
+
+func (c *configClient) do(method, path, contentType string, payload []byte) ([]byte, error) {
+	req, err := http.NewRequest(method, strings.TrimSuffix(c.baseURL, "/")+path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// configPlan is the plan returned by the import endpoint
+type configPlan struct {
+	DryRun  bool `json:"dry_run"`
+	Changes []struct {
+		Resource string                 `json:"resource"`
+		Key      string                 `json:"key"`
+		Action   string                 `json:"action"`
+		Status   string                 `json:"status"`
+		Before   map[string]interface{} `json:"before"`
+		After    map[string]interface{} `json:"after"`
+		Error    string                 `json:"error"`
+	} `json:"changes"`
+	Unchanged int      `json:"unchanged"`
+	Warnings  []string `json:"warnings"`
+}
+
+func (p *configPlan) failed() int {
+	failed := 0
+	for _, change := range p.Changes {
+		if change.Status == "failed" {
+			failed++
+		}
+	}
+	return failed
+}
+
+// print writes the plan as a diff: + created, ~ updated and - deleted
+func (p *configPlan) print(w io.Writer) {
+	symbols := map[string]string{"create": "+", "update": "~", "delete": "-"}
+	for _, change := range p.Changes {
+		fmt.Fprintf(w, "%s %s %s", symbols[change.Action], change.Resource, change.Key)
+		if change.Status == "failed" {
+			fmt.Fprintf(w, " (failed: %s)", change.Error)
+		}
+		fmt.Fprintln(w)
+		if change.Action != "update" {
+			continue
+		}
+		// Fields left out of one side were reset to their zero value
+		fields := make([]string, 0, len(change.After))
+		for field := range change.After {
+			fields = append(fields, field)
+		}
+		for field := range change.Before {
+			if _, ok := change.After[field]; !ok {
+				fields = append(fields, field)
+			}
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			before, _ := json.Marshal(change.Before[field])
+			after, _ := json.Marshal(change.After[field])
+			if !bytes.Equal(before, after) {
+				fmt.Fprintf(w, "    %s: %s -> %s\n", field, before, after)
+			}
+		}
+	}
+	for _, warning := range p.Warnings {
+		fmt.Fprintf(w, "warning: %s\n", warning)
+	}
+
+	verb := "applied"
+	if p.DryRun {
+		verb = "planned"
+	}
+	fmt.Fprintf(w, "%d changes %s, %d failed, %d unchanged\n", len(p.Changes), verb, p.failed(), p.Unchanged)
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
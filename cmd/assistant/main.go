@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/probe"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/config"
+)
+
+const usage = `Usage: assistant <command> [flags]
+
+Commands:
+  check    validate the configuration, connect to MySQL and Redis, verify the
+           Slack token scopes and AI provider credentials, and print a JSON
+           report. Exits 0 when every check passes and 1 otherwise.
+  config export
+           write the configuration bundle of a deployment (channels, filter
+           rules, channel pairs and workspaces) as YAML or JSON.
+  config import [-dry-run] [-prune] <bundle>
+           apply a bundle exported from another deployment and print the
+           changes. -dry-run only prints them; -prune also deletes what is
+           missing from the bundle. Exits 1 when a change fails.
+  secrets rotate
+           re-wrap the stored workspace bot tokens and signing secrets with
+           SECRETS_CURRENT_KEY_ID, connecting to the configured database.
+           The previous master keys must stay in SECRETS_MASTER_KEYS until it
+           succeeds. Exits 1 when a workspace could not be rotated.
+
+The config commands call the admin API at -url (ASSISTANT_URL) with -token
+(ADMIN_API_TOKEN); importing needs an admin key.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "check":
+		os.Exit(runCheck(os.Args[2:]))
+	case "config":
+		os.Exit(runConfig(os.Args[2:]))
+	case "secrets":
+		os.Exit(runSecrets(os.Args[2:]))
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(os.Stdout, usage)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+}
+
+// runCheck probes the dependencies of the configuration and returns the exit code
+func runCheck(args []string) int {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	platforms := flags.String("platforms", "", "comma-separated chat platforms to check: slack, teams, discord (defaults to PLATFORMS)")
+	timeout := flags.Duration("timeout", 10*time.Second, "timeout of each check")
+	_ = flags.Parse(args)
+
+	var report probe.Report
+	cfg, err := config.LoadPlatforms(parsePlatforms(*platforms))
+	if err != nil {
+		report = probe.ConfigFailed(err)
+	} else {
+		report = probe.Run(context.Background(), probe.NewProber(cfg).Checks(), *timeout)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to encode report: %v\n", err)
+		return 1
+	}
+	if !report.OK() {
+		return 1
+	}
+	return 0
+}
+
+// parsePlatforms splits the -platforms flag; an empty flag keeps PLATFORMS
+func parsePlatforms(value string) []string {
+	var platforms []string
+	for _, platform := range strings.Split(value, ",") {
+		if platform = strings.TrimSpace(platform); platform != "" {
+			platforms = append(platforms, platform)
+		}
+	}
+	return platforms
+}
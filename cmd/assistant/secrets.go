@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	gormmysql "github.com/ntttrang/go-genai-slack-assistant/internal/repository/gorm-mysql"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/config"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/database"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/secrets"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// runSecrets dispatches the secrets subcommands and returns the exit code
+func runSecrets(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+	switch args[0] {
+	case "rotate":
+		return runSecretsRotate(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown secrets command %q\n\n%s", args[0], usage)
+		return 2
+	}
+}
+
+// runSecretsRotate re-wraps the stored workspace credentials with
+// SECRETS_CURRENT_KEY_ID, connecting to the database of the configuration
+func runSecretsRotate(args []string) int {
+	flags := flag.NewFlagSet("secrets rotate", flag.ExitOnError)
+	timeout := flags.Duration("timeout", 5*time.Minute, "timeout of the rotation")
+	_ = flags.Parse(args)
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration: %v\n", err)
+		return 1
+	}
+	keys, err := secrets.NewLocalKeyService(cfg.Secrets.MasterKeys, cfg.Secrets.CurrentKeyID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid secrets master keys: %v\n", err)
+		return 1
+	}
+	db, err := openDatabase(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to the database: %v\n", err)
+		return 1
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	workspaces := service.NewWorkspaceUseCase(gormmysql.NewWorkspaceRepository(db, secrets.NewEnvelope(keys)), zap.NewNop())
+	rotation, err := workspaces.RotateKeys(ctx)
+	if rotation != nil {
+		fmt.Fprintf(os.Stdout, "%d workspace(s) re-wrapped with key %s\n", rotation.Rotated, keys.CurrentKeyID())
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rotation failed: %v\n", err)
+		return 1
+	}
+	if rotation.Skipped > 0 {
+		fmt.Fprintf(os.Stderr, "%d workspace(s) changed during the rotation and were skipped; run it again\n", rotation.Skipped)
+		return 1
+	}
+	return 0
+}
+
+// openDatabase connects to the database of the configuration like the API does
+func openDatabase(cfg *config.Config) (*gorm.DB, error) {
+	if cfg.Database.Driver == config.DatabaseDriverSQLite {
+		return database.NewSQLiteDB(cfg.Database.SQLitePath)
+	}
+	return database.NewGormDB(database.DBConfig{
+		Host:     cfg.Database.Host,
+		Port:     cfg.Database.Port,
+		User:     cfg.Database.User,
+		Password: cfg.Database.Password,
+		Database: cfg.Database.Database,
+	})
+}
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS filter_rules (
+    id VARCHAR(36) PRIMARY KEY,
+    channel_id VARCHAR(255) NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    pattern TEXT,
+    `values` JSON,
+    enabled BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_channel_id (channel_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+ALTER TABLE channel_configs
+    ADD COLUMN canary BOOLEAN DEFAULT FALSE AFTER enabled;
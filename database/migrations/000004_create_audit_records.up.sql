@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS audit_records (
+    id VARCHAR(36) PRIMARY KEY,
+    actor VARCHAR(255) NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    resource_type VARCHAR(64) NOT NULL,
+    resource_id VARCHAR(255) NOT NULL,
+    `before` TEXT,
+    `after` TEXT,
+    changed_fields JSON,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_resource (resource_type, resource_id),
+    INDEX idx_actor (actor),
+    INDEX idx_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS pending_translations;
+
+ALTER TABLE channel_configs
+    DROP COLUMN review_channel_id;
@@ -0,0 +1,22 @@
+ALTER TABLE channel_configs
+    ADD COLUMN review_channel_id VARCHAR(255) NOT NULL DEFAULT '' AFTER canary;
+
+CREATE TABLE IF NOT EXISTS pending_translations (
+    id VARCHAR(36) PRIMARY KEY,
+    channel_id VARCHAR(255) NOT NULL,
+    thread_ts VARCHAR(32) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    original_text TEXT NOT NULL,
+    translated_text TEXT NOT NULL,
+    bot_name VARCHAR(255),
+    bot_avatar VARCHAR(1024),
+    as_quote BOOLEAN DEFAULT FALSE,
+    review_channel_id VARCHAR(255) NOT NULL,
+    review_message_ts VARCHAR(32),
+    status VARCHAR(16) NOT NULL,
+    reviewer_id VARCHAR(255),
+    reviewed_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_channel_id (channel_id),
+    INDEX idx_status (status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
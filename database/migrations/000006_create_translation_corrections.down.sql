@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS translation_corrections;
+
+ALTER TABLE pending_translations
+    DROP COLUMN target_language,
+    DROP COLUMN source_language;
@@ -0,0 +1,18 @@
+ALTER TABLE pending_translations
+    ADD COLUMN source_language VARCHAR(50) NOT NULL DEFAULT '' AFTER user_id,
+    ADD COLUMN target_language VARCHAR(50) NOT NULL DEFAULT '' AFTER source_language;
+
+CREATE TABLE IF NOT EXISTS translation_corrections (
+    id VARCHAR(36) PRIMARY KEY,
+    pending_translation_id VARCHAR(36) NOT NULL,
+    channel_id VARCHAR(255) NOT NULL,
+    source_language VARCHAR(50) NOT NULL,
+    target_language VARCHAR(50) NOT NULL,
+    source_text TEXT NOT NULL,
+    machine_text TEXT NOT NULL,
+    corrected_text TEXT NOT NULL,
+    reviewer_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_channel_id (channel_id),
+    INDEX idx_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
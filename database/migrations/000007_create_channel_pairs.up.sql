@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS channel_pairs (
+    id VARCHAR(36) PRIMARY KEY,
+    channel_id VARCHAR(255) NOT NULL UNIQUE,
+    paired_channel_id VARCHAR(255) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
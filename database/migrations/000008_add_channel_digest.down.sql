@@ -0,0 +1,3 @@
+ALTER TABLE channel_configs
+    DROP COLUMN digest_max_messages,
+    DROP COLUMN digest_interval_minutes;
@@ -0,0 +1,3 @@
+ALTER TABLE channel_configs
+    ADD COLUMN digest_interval_minutes INT NOT NULL DEFAULT 0 AFTER review_channel_id,
+    ADD COLUMN digest_max_messages INT NOT NULL DEFAULT 0 AFTER digest_interval_minutes;
@@ -0,0 +1,2 @@
+ALTER TABLE translations
+    DROP COLUMN provider;
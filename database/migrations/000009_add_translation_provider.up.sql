@@ -0,0 +1,2 @@
+ALTER TABLE translations
+    ADD COLUMN provider VARCHAR(50) NOT NULL DEFAULT '' AFTER channel_id;
@@ -0,0 +1,2 @@
+ALTER TABLE channel_configs
+    ADD COLUMN debug BOOLEAN DEFAULT FALSE AFTER canary;
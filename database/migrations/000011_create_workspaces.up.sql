@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS workspaces (
+    team_id VARCHAR(32) PRIMARY KEY,
+    team_name VARCHAR(255) NOT NULL DEFAULT '',
+    bot_user_id VARCHAR(32) NOT NULL DEFAULT '',
+    bot_token_key_id VARCHAR(64) NOT NULL,
+    bot_token_wrapped_key VARBINARY(512) NOT NULL,
+    bot_token_ciphertext VARBINARY(1024) NOT NULL,
+    signing_secret_key_id VARCHAR(64) NOT NULL DEFAULT '',
+    signing_secret_wrapped_key VARBINARY(512),
+    signing_secret_ciphertext VARBINARY(1024),
+    installed_by VARCHAR(32) NOT NULL DEFAULT '',
+    installed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS translation_feedback (
+    id VARCHAR(36) PRIMARY KEY,
+    translation_id VARCHAR(36) NOT NULL DEFAULT '',
+    channel_id VARCHAR(255) NOT NULL,
+    message_ts VARCHAR(32) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    source_language VARCHAR(50) NOT NULL,
+    target_language VARCHAR(50) NOT NULL,
+    rating TINYINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uniq_reply_user (channel_id, message_ts, user_id),
+    INDEX idx_translation_id (translation_id),
+    INDEX idx_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+ALTER TABLE translations
+    ADD COLUMN prompt_version VARCHAR(20) NOT NULL DEFAULT '' AFTER provider;
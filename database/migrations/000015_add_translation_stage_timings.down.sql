@@ -0,0 +1,3 @@
+ALTER TABLE translations
+    DROP COLUMN stage_timings,
+    DROP COLUMN failed_stage;
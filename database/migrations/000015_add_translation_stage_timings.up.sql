@@ -0,0 +1,3 @@
+ALTER TABLE translations
+    ADD COLUMN stage_timings JSON AFTER prompt_version,
+    ADD COLUMN failed_stage VARCHAR(20) NOT NULL DEFAULT '' AFTER stage_timings;
@@ -0,0 +1,2 @@
+ALTER TABLE channel_configs
+    DROP COLUMN delivery_mode;
@@ -0,0 +1,2 @@
+ALTER TABLE channel_configs
+    ADD COLUMN delivery_mode VARCHAR(20) NOT NULL DEFAULT '' AFTER digest_max_messages;
@@ -0,0 +1,6 @@
+ALTER TABLE translations
+    DROP COLUMN generation;
+ALTER TABLE workspaces
+    DROP COLUMN generation;
+ALTER TABLE channel_configs
+    DROP COLUMN generation;
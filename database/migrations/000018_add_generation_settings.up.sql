@@ -0,0 +1,6 @@
+ALTER TABLE channel_configs
+    ADD COLUMN generation JSON AFTER delivery_mode;
+ALTER TABLE workspaces
+    ADD COLUMN generation JSON AFTER installed_by;
+ALTER TABLE translations
+    ADD COLUMN generation JSON AFTER failed_stage;
@@ -0,0 +1,2 @@
+ALTER TABLE workspaces
+    ADD COLUMN monthly_budget DECIMAL(10, 2) NOT NULL DEFAULT 0 AFTER generation;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS compliance_exports;
+DROP TABLE IF EXISTS bot_posts;
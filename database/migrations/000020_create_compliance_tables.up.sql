@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS bot_posts (
+    id VARCHAR(36) PRIMARY KEY,
+    team_id VARCHAR(255) NOT NULL DEFAULT '',
+    channel_id VARCHAR(255) NOT NULL,
+    message_ts VARCHAR(32) NOT NULL DEFAULT '',
+    thread_ts VARCHAR(32) NOT NULL DEFAULT '',
+    action VARCHAR(16) NOT NULL,
+    user_id VARCHAR(255) NOT NULL DEFAULT '',
+    text MEDIUMTEXT,
+    blocks MEDIUMTEXT,
+    attachments MEDIUMTEXT,
+    posted_at TIMESTAMP NOT NULL,
+    INDEX idx_posted_at (posted_at, id),
+    INDEX idx_team_posted_at (team_id, posted_at, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS compliance_exports (
+    id VARCHAR(36) PRIMARY KEY,
+    team_id VARCHAR(255) NOT NULL DEFAULT '',
+    range_start TIMESTAMP NOT NULL,
+    range_end TIMESTAMP NOT NULL,
+    object_key VARCHAR(1024) NOT NULL,
+    manifest_key VARCHAR(1024) NOT NULL,
+    records INT NOT NULL DEFAULT 0,
+    prev_hash CHAR(64) NOT NULL,
+    final_hash CHAR(64) NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    signature VARCHAR(255) NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    INDEX idx_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
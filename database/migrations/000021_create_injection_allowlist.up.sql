@@ -0,0 +1,4 @@
+CREATE TABLE IF NOT EXISTS injection_allowlist (
+    phrase VARCHAR(255) PRIMARY KEY,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
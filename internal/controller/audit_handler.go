@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/middleware"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"go.uber.org/zap"
+)
+
+// AuditHandler exposes the admin audit trail
+type AuditHandler struct {
+	auditService service.AuditService
+	logger       *zap.Logger
+}
+
+func NewAuditHandler(auditService service.AuditService, logger *zap.Logger) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+		logger:       logger,
+	}
+}
+
+// ListGin handles GET /admin/audit?resource_type=&resource_id=&actor=&since=&limit=
+func (h *AuditHandler) ListGin(c *gin.Context) {
+	query := model.AuditQuery{
+		Actor:        c.Query("actor"),
+		ResourceType: c.Query("resource_type"),
+		ResourceID:   c.Query("resource_id"),
+	}
+
+	if since := c.Query("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC 3339 timestamp"})
+			return
+		}
+		query.Since = t
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return
+		}
+		query.Limit = n
+	}
+
+	records, err := h.auditService.List(query)
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"records": records})
+}
+
+// recordAudit writes an audit record for a completed admin mutation. The
+// mutation has already happened, so a failure is logged rather than returned.
+func recordAudit(c *gin.Context, auditor service.AuditService, logger *zap.Logger, entry model.AuditEntry) {
+	if auditor == nil {
+		return
+	}
+	entry.Actor = middleware.AdminActor(c)
+	if err := auditor.Record(entry); err != nil {
+		logger.Error("Failed to record audit entry",
+			zap.Error(err),
+			zap.String("action", entry.Action),
+			zap.String("resource_type", entry.ResourceType),
+			zap.String("resource_id", entry.ResourceID))
+	}
+}
@@ -0,0 +1,274 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"go.uber.org/zap"
+)
+
+// Notifier delivers admin job notifications to an external receiver
+type Notifier interface {
+	Send(ctx context.Context, event string, data interface{}) error
+}
+
+// ChannelConfigHandler exposes channel configuration on the admin API
+type ChannelConfigHandler struct {
+	channelService service.ChannelService
+	notifier       Notifier
+	auditor        service.AuditService
+	logger         *zap.Logger
+}
+
+func NewChannelConfigHandler(channelService service.ChannelService, logger *zap.Logger) *ChannelConfigHandler {
+	return &ChannelConfigHandler{
+		channelService: channelService,
+		logger:         logger,
+	}
+}
+
+// SetNotifier sends a notification when a bulk configuration job completes
+func (h *ChannelConfigHandler) SetNotifier(notifier Notifier) {
+	h.notifier = notifier
+}
+
+// SetAuditor records every configuration change in the admin audit trail
+func (h *ChannelConfigHandler) SetAuditor(auditor service.AuditService) {
+	h.auditor = auditor
+}
+
+// ListGin handles GET /admin/channels
+func (h *ChannelConfigHandler) ListGin(c *gin.Context) {
+	configs, err := h.channelService.ListAllChannelConfigs()
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"channels": configs})
+}
+
+// GetGin handles GET /admin/channels/:channel_id
+func (h *ChannelConfigHandler) GetGin(c *gin.Context) {
+	config, err := h.channelService.GetChannelConfig(c.Param("channel_id"))
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+	c.JSON(http.StatusOK, config)
+}
+
+// CreateGin handles POST /admin/channels
+func (h *ChannelConfigHandler) CreateGin(c *gin.Context) {
+	var req request.ChannelConfig
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	config := req.ToModel()
+	if err := h.channelService.CreateChannelConfig(config); err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+		Action:       model.AuditActionCreate,
+		ResourceType: model.AuditResourceChannelConfig,
+		ResourceID:   config.ChannelID,
+		After:        config,
+	})
+	c.JSON(http.StatusCreated, config)
+}
+
+// UpdateGin handles PUT /admin/channels/:channel_id
+func (h *ChannelConfigHandler) UpdateGin(c *gin.Context) {
+	var req request.ChannelConfig
+	req.ChannelID = c.Param("channel_id")
+	if !bindAndValidate(c, &req) {
+		return
+	}
+	if req.ChannelID != c.Param("channel_id") {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":  "validation failed",
+			"fields": []dto.ValidationError{{Field: "channel_id", Message: "channel_id does not match the URL"}},
+		})
+		return
+	}
+
+	before := h.auditSnapshot(req.ChannelID)
+	config := req.ToModel()
+	if err := h.channelService.UpdateChannelConfig(config); err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+		Action:       model.AuditActionUpdate,
+		ResourceType: model.AuditResourceChannelConfig,
+		ResourceID:   config.ChannelID,
+		Before:       before,
+		After:        config,
+	})
+	c.JSON(http.StatusOK, config)
+}
+
+// DeleteGin handles DELETE /admin/channels/:channel_id
+func (h *ChannelConfigHandler) DeleteGin(c *gin.Context) {
+	channelID := c.Param("channel_id")
+	before := h.auditSnapshot(channelID)
+	if err := h.channelService.DeleteChannelConfig(channelID); err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+		Action:       model.AuditActionDelete,
+		ResourceType: model.AuditResourceChannelConfig,
+		ResourceID:   channelID,
+		Before:       before,
+	})
+	c.Status(http.StatusNoContent)
+}
+
+// auditSnapshot loads the current configuration for the audit before state.
+// It returns nil when auditing is disabled or the configuration cannot be read.
+func (h *ChannelConfigHandler) auditSnapshot(channelID string) *model.ChannelConfig {
+	if h.auditor == nil {
+		return nil
+	}
+	config, err := h.channelService.GetChannelConfig(channelID)
+	if err != nil {
+		return nil
+	}
+	return config
+}
+
+// validatable is implemented by admin request DTOs
+type validatable interface {
+	Validate() *dto.Validator
+}
+
+// BulkApplyGin handles POST /admin/channels/bulk
+func (h *ChannelConfigHandler) BulkApplyGin(c *gin.Context) {
+	var req request.BulkChannelConfig
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	template := req.Template.ToModel()
+	results, err := h.channelService.ApplyChannelTemplate(template, req.Selector(), req.DryRun)
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	succeeded, failed := 0, 0
+	for _, result := range results {
+		if result.Status == model.BulkStatusFailed {
+			failed++
+		} else {
+			succeeded++
+		}
+	}
+
+	h.logger.Info("Bulk channel configuration applied",
+		zap.Bool("dry_run", req.DryRun),
+		zap.Int("succeeded", succeeded),
+		zap.Int("failed", failed))
+
+	if !req.DryRun {
+		recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+			Action:       model.AuditActionBulk,
+			ResourceType: model.AuditResourceChannelConfig,
+			ResourceID:   "*",
+			After: gin.H{
+				"template": template,
+				"results":  results,
+			},
+		})
+	}
+
+	if h.notifier != nil && !req.DryRun {
+		h.notify("channels.bulk_applied", gin.H{
+			"results":   results,
+			"succeeded": succeeded,
+			"failed":    failed,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"dry_run":   req.DryRun,
+		"results":   results,
+		"succeeded": succeeded,
+		"failed":    failed,
+	})
+}
+
+// notify sends the notification in the background so the receiver never
+// delays the admin response; failures are only logged.
+func (h *ChannelConfigHandler) notify(event string, data interface{}) {
+	go func() {
+		if err := h.notifier.Send(context.Background(), event, data); err != nil {
+			h.logger.Warn("Failed to send admin notification",
+				zap.String("event", event),
+				zap.Error(err))
+		}
+	}()
+}
+
+// bindAndValidate decodes and validates the request body.
+// It writes the error response itself and returns false when the body is unusable.
+func bindAndValidate(c *gin.Context, req validatable) bool {
+	if err := json.NewDecoder(c.Request.Body).Decode(req); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error": "validation failed",
+				"fields": []dto.ValidationError{{
+					Field:   typeErr.Field,
+					Message: fmt.Sprintf("expected %s", typeErr.Type.String()),
+				}},
+			})
+			return false
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return false
+	}
+
+	if v := req.Validate(); !v.Valid() {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":  "validation failed",
+			"fields": v.Errors(),
+		})
+		return false
+	}
+	return true
+}
+
+// respondServiceError maps domain errors returned by services to HTTP statuses
+func respondServiceError(c *gin.Context, logger *zap.Logger, err error) {
+	var domainErr *model.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Type {
+		case model.ErrorTypeValidation:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": domainErr.Message})
+			return
+		case model.ErrorTypeNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": domainErr.Message})
+			return
+		case model.ErrorTypeBadRequest:
+			c.JSON(http.StatusBadRequest, gin.H{"error": domainErr.Message})
+			return
+		}
+	}
+
+	logger.Error("Admin request failed", zap.Error(err), zap.String("path", c.FullPath()))
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+}
@@ -0,0 +1,286 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func setupChannelRouter(handler *ChannelConfigHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/admin/channels", handler.CreateGin)
+	r.GET("/admin/channels/:channel_id", handler.GetGin)
+	r.PUT("/admin/channels/:channel_id", handler.UpdateGin)
+	return r
+}
+
+func TestChannelConfigHandlerCreate(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*mocks.MockChannelService)
+		expectedStatus int
+	}{
+		{
+			name: "valid config",
+			body: `{"channel_id":"C123","source_languages":["en","vi"],"target_language":"vi"}`,
+			setupMock: func(svc *mocks.MockChannelService) {
+				svc.EXPECT().CreateChannelConfig(gomock.Any()).DoAndReturn(func(config *model.ChannelConfig) error {
+					assert.Equal(t, model.LanguageList{"en", "vi"}, config.SourceLanguages)
+					assert.True(t, config.Enabled)
+					return nil
+				})
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "unknown language code",
+			body:           `{"channel_id":"C123","source_languages":["klingon"],"target_language":"vi"}`,
+			setupMock:      func(svc *mocks.MockChannelService) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "missing target language",
+			body:           `{"channel_id":"C123"}`,
+			setupMock:      func(svc *mocks.MockChannelService) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "source languages as raw JSON string",
+			body:           `{"channel_id":"C123","source_languages":"[\"en\"]","target_language":"vi"}`,
+			setupMock:      func(svc *mocks.MockChannelService) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "malformed JSON",
+			body:           `{"channel_id":`,
+			setupMock:      func(svc *mocks.MockChannelService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service validation error",
+			body: `{"channel_id":"C123","target_language":"vi"}`,
+			setupMock: func(svc *mocks.MockChannelService) {
+				svc.EXPECT().CreateChannelConfig(gomock.Any()).
+					Return(fmt.Errorf("invalid channel config: %w", model.NewValidationError("bad config")))
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockChannelService(ctrl)
+			tt.setupMock(mockService)
+			router := setupChannelRouter(NewChannelConfigHandler(mockService, zap.NewNop()))
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/admin/channels", bytes.NewBufferString(tt.body))
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusUnprocessableEntity {
+				var body map[string]interface{}
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.NotEmpty(t, body["error"])
+			}
+		})
+	}
+}
+
+func TestChannelConfigHandlerGetNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockChannelService(ctrl)
+	mockService.EXPECT().GetChannelConfig("C999").
+		Return(nil, fmt.Errorf("failed to get channel config: %w", model.NewNotFoundError("channel config not found")))
+	router := setupChannelRouter(NewChannelConfigHandler(mockService, zap.NewNop()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/channels/C999", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestChannelConfigHandlerUpdateMismatchedChannel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockChannelService(ctrl)
+	router := setupChannelRouter(NewChannelConfigHandler(mockService, zap.NewNop()))
+
+	rec := httptest.NewRecorder()
+	body := bytes.NewBufferString(`{"channel_id":"C456","target_language":"vi"}`)
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/channels/C123", body))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
+
+func TestChannelConfigHandlerBulkApply(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*mocks.MockChannelService)
+		expectedStatus int
+		expectedFailed float64
+	}{
+		{
+			name: "dry run by pattern",
+			body: `{"template":{"target_language":"vi"},"name_pattern":"proj-*","dry_run":true}`,
+			setupMock: func(svc *mocks.MockChannelService) {
+				svc.EXPECT().ApplyChannelTemplate(gomock.Any(), model.ChannelSelector{NamePattern: "proj-*"}, true).
+					Return([]model.BulkChannelResult{
+						{ChannelID: "C1", Action: model.BulkActionCreate, Status: model.BulkStatusPlanned},
+						{ChannelID: "C2", Action: model.BulkActionUpdate, Status: model.BulkStatusFailed, Error: "boom"},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedFailed: 1,
+		},
+		{
+			name:           "no channels selected",
+			body:           `{"template":{"target_language":"vi"}}`,
+			setupMock:      func(svc *mocks.MockChannelService) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "invalid template",
+			body:           `{"template":{"target_language":"xx"},"channel_ids":["C1"]}`,
+			setupMock:      func(svc *mocks.MockChannelService) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockChannelService(ctrl)
+			tt.setupMock(mockService)
+			handler := NewChannelConfigHandler(mockService, zap.NewNop())
+			router := setupChannelRouter(handler)
+			router.POST("/admin/channels/bulk", handler.BulkApplyGin)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/admin/channels/bulk", bytes.NewBufferString(tt.body))
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body map[string]interface{}
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, tt.expectedFailed, body["failed"])
+			}
+		})
+	}
+}
+
+type recordingNotifier struct {
+	events chan string
+}
+
+func (n *recordingNotifier) Send(ctx context.Context, event string, data interface{}) error {
+	n.events <- event
+	return nil
+}
+
+func TestChannelConfigHandlerBulkApplyNotifies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockChannelService(ctrl)
+	mockService.EXPECT().ApplyChannelTemplate(gomock.Any(), model.ChannelSelector{ChannelIDs: []string{"C1"}}, false).
+		Return([]model.BulkChannelResult{
+			{ChannelID: "C1", Action: model.BulkActionCreate, Status: model.BulkStatusApplied},
+		}, nil)
+
+	notifier := &recordingNotifier{events: make(chan string, 1)}
+	handler := NewChannelConfigHandler(mockService, zap.NewNop())
+	handler.SetNotifier(notifier)
+	router := setupChannelRouter(handler)
+	router.POST("/admin/channels/bulk", handler.BulkApplyGin)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/admin/channels/bulk",
+		bytes.NewBufferString(`{"template":{"target_language":"vi"},"channel_ids":["C1"]}`))
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	select {
+	case event := <-notifier.events:
+		assert.Equal(t, "channels.bulk_applied", event)
+	case <-time.After(time.Second):
+		t.Fatal("expected a bulk job notification")
+	}
+}
+
+func TestChannelConfigHandlerUpdateRecordsAudit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockChannelService(ctrl)
+	mockAuditor := mocks.NewMockAuditService(ctrl)
+
+	before := &model.ChannelConfig{ChannelID: "C123", TargetLanguage: "en", Enabled: true}
+	mockService.EXPECT().GetChannelConfig("C123").Return(before, nil)
+	mockService.EXPECT().UpdateChannelConfig(gomock.Any()).Return(nil)
+	mockAuditor.EXPECT().Record(gomock.Any()).DoAndReturn(func(entry model.AuditEntry) error {
+		assert.Equal(t, model.AuditActionUpdate, entry.Action)
+		assert.Equal(t, model.AuditResourceChannelConfig, entry.ResourceType)
+		assert.Equal(t, "C123", entry.ResourceID)
+		assert.Equal(t, before, entry.Before)
+		assert.Equal(t, "vi", entry.After.(*model.ChannelConfig).TargetLanguage)
+		return nil
+	})
+
+	handler := NewChannelConfigHandler(mockService, zap.NewNop())
+	handler.SetAuditor(mockAuditor)
+	router := setupChannelRouter(handler)
+
+	rec := httptest.NewRecorder()
+	body := bytes.NewBufferString(`{"channel_id":"C123","target_language":"vi"}`)
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/channels/C123", body))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestChannelConfigHandlerFailedUpdateNotAudited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockChannelService(ctrl)
+	mockAuditor := mocks.NewMockAuditService(ctrl)
+
+	mockService.EXPECT().GetChannelConfig("C123").
+		Return(nil, fmt.Errorf("failed to get channel config: %w", model.NewNotFoundError("channel config not found")))
+	mockService.EXPECT().UpdateChannelConfig(gomock.Any()).
+		Return(fmt.Errorf("failed to update channel config: %w", model.NewNotFoundError("channel config not found")))
+
+	handler := NewChannelConfigHandler(mockService, zap.NewNop())
+	handler.SetAuditor(mockAuditor)
+	router := setupChannelRouter(handler)
+
+	rec := httptest.NewRecorder()
+	body := bytes.NewBufferString(`{"channel_id":"C123","target_language":"vi"}`)
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/channels/C123", body))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
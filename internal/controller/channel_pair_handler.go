@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"go.uber.org/zap"
+)
+
+// ChannelPairHandler exposes channel pairs on the admin API
+type ChannelPairHandler struct {
+	pairService service.ChannelPairService
+	auditor     service.AuditService
+	logger      *zap.Logger
+}
+
+func NewChannelPairHandler(pairService service.ChannelPairService, logger *zap.Logger) *ChannelPairHandler {
+	return &ChannelPairHandler{
+		pairService: pairService,
+		logger:      logger,
+	}
+}
+
+// SetAuditor records every pair change in the admin audit trail
+func (h *ChannelPairHandler) SetAuditor(auditor service.AuditService) {
+	h.auditor = auditor
+}
+
+// ListGin handles GET /admin/channel-pairs
+func (h *ChannelPairHandler) ListGin(c *gin.Context) {
+	pairs, err := h.pairService.ListPairs()
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"pairs": pairs})
+}
+
+// CreateGin handles POST /admin/channel-pairs
+func (h *ChannelPairHandler) CreateGin(c *gin.Context) {
+	var req request.ChannelPair
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	pair := req.ToModel()
+	if err := h.pairService.CreatePair(pair); err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+		Action:       model.AuditActionCreate,
+		ResourceType: model.AuditResourceChannelPair,
+		ResourceID:   pair.ID,
+		After:        pair,
+	})
+	c.JSON(http.StatusCreated, pair)
+}
+
+// DeleteGin handles DELETE /admin/channel-pairs/:pair_id
+func (h *ChannelPairHandler) DeleteGin(c *gin.Context) {
+	pairID := c.Param("pair_id")
+	before := h.auditSnapshot(pairID)
+	if err := h.pairService.DeletePair(pairID); err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+		Action:       model.AuditActionDelete,
+		ResourceType: model.AuditResourceChannelPair,
+		ResourceID:   pairID,
+		Before:       before,
+	})
+	c.Status(http.StatusNoContent)
+}
+
+// auditSnapshot loads the current pair for the audit before state.
+// It returns nil when auditing is disabled or the pair cannot be read.
+func (h *ChannelPairHandler) auditSnapshot(pairID string) *model.ChannelPair {
+	if h.auditor == nil {
+		return nil
+	}
+	pair, err := h.pairService.GetPair(pairID)
+	if err != nil {
+		return nil
+	}
+	return pair
+}
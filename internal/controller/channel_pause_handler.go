@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/middleware"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"go.uber.org/zap"
+)
+
+// ChannelPauseHandler pauses and resumes translation in a channel on the admin API
+type ChannelPauseHandler struct {
+	pauses  service.ChannelPauseService
+	auditor service.AuditService
+	logger  *zap.Logger
+}
+
+func NewChannelPauseHandler(pauses service.ChannelPauseService, logger *zap.Logger) *ChannelPauseHandler {
+	return &ChannelPauseHandler{
+		pauses: pauses,
+		logger: logger,
+	}
+}
+
+// SetAuditor records pauses and resumes in the admin audit trail
+func (h *ChannelPauseHandler) SetAuditor(auditor service.AuditService) {
+	h.auditor = auditor
+}
+
+// GetGin handles GET /admin/channels/:channel_id/pause
+func (h *ChannelPauseHandler) GetGin(c *gin.Context) {
+	pause, err := h.pauses.GetPause(c.Param("channel_id"))
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"paused": pause != nil, "pause": pause})
+}
+
+// PauseGin handles POST /admin/channels/:channel_id/pause
+func (h *ChannelPauseHandler) PauseGin(c *gin.Context) {
+	var req request.ChannelPause
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	channelID := c.Param("channel_id")
+	pause, err := h.pauses.Pause(channelID, middleware.AdminActor(c), req.Duration())
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+		Action:       model.AuditActionPause,
+		ResourceType: model.AuditResourceChannelConfig,
+		ResourceID:   channelID,
+		After:        pause,
+	})
+	c.JSON(http.StatusOK, pause)
+}
+
+// ResumeGin handles POST /admin/channels/:channel_id/resume
+func (h *ChannelPauseHandler) ResumeGin(c *gin.Context) {
+	channelID := c.Param("channel_id")
+	if err := h.pauses.Resume(channelID); err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+		Action:       model.AuditActionResume,
+		ResourceType: model.AuditResourceChannelConfig,
+		ResourceID:   channelID,
+	})
+	c.Status(http.StatusNoContent)
+}
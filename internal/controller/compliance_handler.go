@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/middleware"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"go.uber.org/zap"
+)
+
+// ComplianceHandler exports what the bot posted on the admin API
+type ComplianceHandler struct {
+	compliance service.ComplianceService
+	auditor    service.AuditService
+	logger     *zap.Logger
+}
+
+func NewComplianceHandler(compliance service.ComplianceService, logger *zap.Logger) *ComplianceHandler {
+	return &ComplianceHandler{
+		compliance: compliance,
+		logger:     logger,
+	}
+}
+
+// SetAuditor records every export in the admin audit trail
+func (h *ComplianceHandler) SetAuditor(auditor service.AuditService) {
+	h.auditor = auditor
+}
+
+// ExportGin handles POST /admin/compliance/exports
+func (h *ComplianceHandler) ExportGin(c *gin.Context) {
+	var req request.ComplianceExport
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	export, err := h.compliance.Export(c.Request.Context(), req.TeamID, req.From, req.To, middleware.AdminActor(c))
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+		Action:       model.AuditActionCreate,
+		ResourceType: model.AuditResourceComplianceExport,
+		ResourceID:   export.ID,
+		After:        export,
+	})
+	c.JSON(http.StatusCreated, export)
+}
+
+// ListGin handles GET /admin/compliance/exports?limit=
+func (h *ComplianceHandler) ListGin(c *gin.Context) {
+	limit := 0
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return
+		}
+		limit = n
+	}
+
+	exports, err := h.compliance.ListExports(limit)
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"exports": exports})
+}
+
+// PublicKeyGin handles GET /admin/compliance/public-key, the Ed25519 key that
+// verifies export manifests
+func (h *ComplianceHandler) PublicKeyGin(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"algorithm": "ed25519", "public_key": h.compliance.PublicKey()})
+}
@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// maxConfigBundleSize bounds the bundles accepted by ImportGin
+const maxConfigBundleSize = 10 << 20
+
+// ConfigBundleHandler exports the bot configuration on the admin API and
+// applies bundles exported from another deployment
+type ConfigBundleHandler struct {
+	bundles service.ConfigBundleService
+	auditor service.AuditService
+	logger  *zap.Logger
+}
+
+func NewConfigBundleHandler(bundles service.ConfigBundleService, logger *zap.Logger) *ConfigBundleHandler {
+	return &ConfigBundleHandler{
+		bundles: bundles,
+		logger:  logger,
+	}
+}
+
+// SetAuditor records every applied bundle in the admin audit trail
+func (h *ConfigBundleHandler) SetAuditor(auditor service.AuditService) {
+	h.auditor = auditor
+}
+
+// ExportGin handles GET /admin/config/export?format=json|yaml
+func (h *ConfigBundleHandler) ExportGin(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "yaml" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or yaml"})
+		return
+	}
+
+	bundle, err := h.bundles.Export()
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	if format == "yaml" {
+		body, err := encodeYAML(bundle)
+		if err != nil {
+			respondServiceError(c, h.logger, err)
+			return
+		}
+		c.Data(http.StatusOK, "application/yaml", body)
+		return
+	}
+	c.JSON(http.StatusOK, bundle)
+}
+
+// ImportGin handles POST /admin/config/import?dry_run=&prune=. The bundle is
+// read as YAML when the Content-Type says so and as JSON otherwise.
+func (h *ConfigBundleHandler) ImportGin(c *gin.Context) {
+	var opts model.ConfigApplyOptions
+	for name, value := range map[string]*bool{"dry_run": &opts.DryRun, "prune": &opts.Prune} {
+		if raw := c.Query(name); raw != "" {
+			parsed, err := strconv.ParseBool(raw)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": name + " must be true or false"})
+				return
+			}
+			*value = parsed
+		}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxConfigBundleSize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+	if strings.Contains(c.ContentType(), "yaml") {
+		if body, err = yamlToJSON(body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid YAML: %v", err)})
+			return
+		}
+	}
+	var bundle model.ConfigBundle
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&bundle); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid config bundle: %v", err)})
+		return
+	}
+
+	plan, err := h.bundles.Apply(&bundle, opts)
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	h.logger.Info("Config bundle applied",
+		zap.Bool("dry_run", opts.DryRun),
+		zap.Bool("prune", opts.Prune),
+		zap.Int("changes", len(plan.Changes)),
+		zap.Int("failed", plan.Failed()))
+
+	if !opts.DryRun && len(plan.Changes) > 0 {
+		recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+			Action:       model.AuditActionImport,
+			ResourceType: model.AuditResourceConfigBundle,
+			ResourceID:   "*",
+			After:        plan,
+		})
+	}
+	c.JSON(http.StatusOK, plan)
+}
+
+// encodeYAML writes v as YAML with the field names of its JSON encoding
+func encodeYAML(v interface{}) ([]byte, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config bundle: %w", err)
+	}
+	var document interface{}
+	if err := json.Unmarshal(encoded, &document); err != nil {
+		return nil, fmt.Errorf("failed to encode config bundle: %w", err)
+	}
+	out, err := yaml.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config bundle: %w", err)
+	}
+	return out, nil
+}
+
+// yamlToJSON converts a YAML document, so it is decoded like a JSON one
+func yamlToJSON(body []byte) ([]byte, error) {
+	var document interface{}
+	if err := yaml.Unmarshal(body, &document); err != nil {
+		return nil, err
+	}
+	return json.Marshal(document)
+}
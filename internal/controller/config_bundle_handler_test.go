@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeConfigBundles struct {
+	bundle  *model.ConfigBundle
+	applied *model.ConfigBundle
+	opts    model.ConfigApplyOptions
+}
+
+func (f *fakeConfigBundles) Export() (*model.ConfigBundle, error) {
+	return f.bundle, nil
+}
+
+func (f *fakeConfigBundles) Apply(bundle *model.ConfigBundle, opts model.ConfigApplyOptions) (*model.ConfigPlan, error) {
+	f.applied, f.opts = bundle, opts
+	return &model.ConfigPlan{DryRun: opts.DryRun, Changes: []model.ConfigChange{}}, nil
+}
+
+func setupConfigBundleRouter(bundles *fakeConfigBundles) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := NewConfigBundleHandler(bundles, zap.NewNop())
+	r := gin.New()
+	r.GET("/admin/config/export", handler.ExportGin)
+	r.POST("/admin/config/import", handler.ImportGin)
+	return r
+}
+
+func TestConfigBundleHandler_YAMLRoundTrip(t *testing.T) {
+	bundles := &fakeConfigBundles{bundle: &model.ConfigBundle{
+		Version: model.ConfigBundleVersion,
+		Channels: []model.BundleChannel{
+			{ChannelID: "C1", AutoTranslate: true, SourceLanguages: model.LanguageList{"en"}, TargetLanguage: "vi", Enabled: true},
+		},
+		Rules:        []model.BundleRule{{ChannelID: "C1", Type: model.FilterRuleSkipRegex, Pattern: "^!", Enabled: true}},
+		ChannelPairs: []model.BundleChannelPair{},
+	}}
+	r := setupConfigBundleRouter(bundles)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/config/export?format=yaml", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "channel_id: C1")
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/config/import?dry_run=true", bytes.NewReader(w.Body.Bytes()))
+	req.Header.Set("Content-Type", "application/yaml")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, bundles.opts.DryRun)
+	assert.False(t, bundles.opts.Prune)
+	assert.Equal(t, bundles.bundle.Channels, bundles.applied.Channels)
+	assert.Equal(t, bundles.bundle.Rules, bundles.applied.Rules)
+
+	var plan model.ConfigPlan
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &plan))
+	assert.True(t, plan.DryRun)
+}
+
+func TestConfigBundleHandler_ImportRejectsBadRequests(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		body  string
+	}{
+		{name: "invalid dry_run", query: "?dry_run=maybe", body: `{"version":1}`},
+		{name: "malformed JSON", body: `{"version":`},
+		{name: "unknown field", body: `{"version":1,"glossaries":[]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundles := &fakeConfigBundles{}
+			r := setupConfigBundleRouter(bundles)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/config/import"+tt.query, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Nil(t, bundles.applied)
+		})
+	}
+}
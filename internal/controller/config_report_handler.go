@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/config"
+)
+
+// ConfigReportHandler shows on the admin API how the configuration was loaded
+type ConfigReportHandler struct {
+	report config.Report
+}
+
+func NewConfigReportHandler(report config.Report) *ConfigReportHandler {
+	return &ConfigReportHandler{report: report}
+}
+
+// GetGin handles GET /admin/config?overridden=true. Settings are listed with
+// their defaults, or only those that differ from them with overridden=true.
+func (h *ConfigReportHandler) GetGin(c *gin.Context) {
+	report := h.report
+	if c.Query("overridden") == "true" {
+		report.Settings = report.Overridden()
+		if report.Settings == nil {
+			report.Settings = []config.Setting{}
+		}
+	}
+	c.JSON(http.StatusOK, report)
+}
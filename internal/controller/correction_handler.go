@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"go.uber.org/zap"
+)
+
+// CorrectionHandler exposes suggestions built from reviewer corrections
+type CorrectionHandler struct {
+	correctionService service.CorrectionService
+	logger            *zap.Logger
+}
+
+func NewCorrectionHandler(correctionService service.CorrectionService, logger *zap.Logger) *CorrectionHandler {
+	return &CorrectionHandler{
+		correctionService: correctionService,
+		logger:            logger,
+	}
+}
+
+// SuggestionsGin handles GET /admin/corrections/suggestions?channel_id=&min_count=&limit=
+func (h *CorrectionHandler) SuggestionsGin(c *gin.Context) {
+	query := model.CorrectionQuery{
+		ChannelID: c.Query("channel_id"),
+	}
+
+	if minCount := c.Query("min_count"); minCount != "" {
+		n, err := strconv.Atoi(minCount)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "min_count must be a positive integer"})
+			return
+		}
+		query.MinCount = n
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return
+		}
+		query.Limit = n
+	}
+
+	suggestions, err := h.correctionService.Suggestions(query)
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+	c.JSON(http.StatusOK, suggestions)
+}
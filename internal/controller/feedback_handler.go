@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"go.uber.org/zap"
+)
+
+// FeedbackHandler exposes how users rate translations
+type FeedbackHandler struct {
+	feedbackService service.FeedbackService
+	logger          *zap.Logger
+}
+
+func NewFeedbackHandler(feedbackService service.FeedbackService, logger *zap.Logger) *FeedbackHandler {
+	return &FeedbackHandler{
+		feedbackService: feedbackService,
+		logger:          logger,
+	}
+}
+
+// SummaryGin handles GET /admin/feedback/summary?channel_id=&since=
+func (h *FeedbackHandler) SummaryGin(c *gin.Context) {
+	query := model.FeedbackQuery{
+		ChannelID: c.Query("channel_id"),
+	}
+
+	if since := c.Query("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC 3339 timestamp"})
+			return
+		}
+		query.Since = t
+	}
+
+	pairs, err := h.feedbackService.Summary(query)
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"language_pairs": pairs})
+}
@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"go.uber.org/zap"
+)
+
+// FilterRuleHandler exposes per-channel filter rules on the admin API
+type FilterRuleHandler struct {
+	filterService service.FilterRuleService
+	auditor       service.AuditService
+	logger        *zap.Logger
+}
+
+func NewFilterRuleHandler(filterService service.FilterRuleService, logger *zap.Logger) *FilterRuleHandler {
+	return &FilterRuleHandler{
+		filterService: filterService,
+		logger:        logger,
+	}
+}
+
+// SetAuditor records every rule change in the admin audit trail
+func (h *FilterRuleHandler) SetAuditor(auditor service.AuditService) {
+	h.auditor = auditor
+}
+
+// ListGin handles GET /admin/rules?channel_id=...
+func (h *FilterRuleHandler) ListGin(c *gin.Context) {
+	channelID := c.Query("channel_id")
+	if channelID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "channel_id query parameter is required"})
+		return
+	}
+
+	rules, err := h.filterService.ListRules(channelID)
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"rules": rules})
+}
+
+// CreateGin handles POST /admin/rules
+func (h *FilterRuleHandler) CreateGin(c *gin.Context) {
+	var req request.FilterRule
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	rule := req.ToModel()
+	if err := h.filterService.CreateRule(rule); err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+		Action:       model.AuditActionCreate,
+		ResourceType: model.AuditResourceFilterRule,
+		ResourceID:   rule.ID,
+		After:        rule,
+	})
+	c.JSON(http.StatusCreated, rule)
+}
+
+// UpdateGin handles PUT /admin/rules/:rule_id
+func (h *FilterRuleHandler) UpdateGin(c *gin.Context) {
+	var req request.FilterRuleUpdate
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	rule := req.ToModel()
+	rule.ID = c.Param("rule_id")
+	before := h.auditSnapshot(rule.ID)
+	if err := h.filterService.UpdateRule(rule); err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+		Action:       model.AuditActionUpdate,
+		ResourceType: model.AuditResourceFilterRule,
+		ResourceID:   rule.ID,
+		Before:       before,
+		After:        rule,
+	})
+	c.JSON(http.StatusOK, rule)
+}
+
+// DeleteGin handles DELETE /admin/rules/:rule_id
+func (h *FilterRuleHandler) DeleteGin(c *gin.Context) {
+	ruleID := c.Param("rule_id")
+	before := h.auditSnapshot(ruleID)
+	if err := h.filterService.DeleteRule(ruleID); err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+		Action:       model.AuditActionDelete,
+		ResourceType: model.AuditResourceFilterRule,
+		ResourceID:   ruleID,
+		Before:       before,
+	})
+	c.Status(http.StatusNoContent)
+}
+
+// auditSnapshot loads the current rule for the audit before state.
+// It returns nil when auditing is disabled or the rule cannot be read.
+func (h *FilterRuleHandler) auditSnapshot(ruleID string) *model.FilterRule {
+	if h.auditor == nil {
+		return nil
+	}
+	rule, err := h.filterService.GetRule(ruleID)
+	if err != nil {
+		return nil
+	}
+	return rule
+}
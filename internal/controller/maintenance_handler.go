@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/middleware"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"go.uber.org/zap"
+)
+
+// MaintenanceHandler switches maintenance mode on the admin API
+type MaintenanceHandler struct {
+	maintenance service.MaintenanceService
+	auditor     service.AuditService
+	logger      *zap.Logger
+}
+
+func NewMaintenanceHandler(maintenance service.MaintenanceService, logger *zap.Logger) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		maintenance: maintenance,
+		logger:      logger,
+	}
+}
+
+// SetAuditor records maintenance switches in the admin audit trail
+func (h *MaintenanceHandler) SetAuditor(auditor service.AuditService) {
+	h.auditor = auditor
+}
+
+// GetGin handles GET /admin/maintenance
+func (h *MaintenanceHandler) GetGin(c *gin.Context) {
+	maintenance, err := h.maintenance.Status()
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+	c.JSON(http.StatusOK, maintenance)
+}
+
+// EnableGin handles POST /admin/maintenance/enable
+func (h *MaintenanceHandler) EnableGin(c *gin.Context) {
+	var req request.Maintenance
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	maintenance, err := h.maintenance.Enable(middleware.AdminActor(c), req.Notice)
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+		Action:       model.AuditActionEnable,
+		ResourceType: model.AuditResourceMaintenance,
+		ResourceID:   "global",
+		After:        maintenance,
+	})
+	c.JSON(http.StatusOK, maintenance)
+}
+
+// DisableGin handles POST /admin/maintenance/disable
+func (h *MaintenanceHandler) DisableGin(c *gin.Context) {
+	if err := h.maintenance.Disable(); err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+		Action:       model.AuditActionDisable,
+		ResourceType: model.AuditResourceMaintenance,
+		ResourceID:   "global",
+	})
+	c.Status(http.StatusNoContent)
+}
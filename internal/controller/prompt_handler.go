@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/middleware"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"go.uber.org/zap"
+)
+
+// PromptHandler switches the translation prompt version on the admin API
+type PromptHandler struct {
+	prompts service.PromptDeploymentService
+	auditor service.AuditService
+	logger  *zap.Logger
+}
+
+func NewPromptHandler(prompts service.PromptDeploymentService, logger *zap.Logger) *PromptHandler {
+	return &PromptHandler{
+		prompts: prompts,
+		logger:  logger,
+	}
+}
+
+// SetAuditor records prompt version switches in the admin audit trail
+func (h *PromptHandler) SetAuditor(auditor service.AuditService) {
+	h.auditor = auditor
+}
+
+// GetGin handles GET /admin/prompts
+func (h *PromptHandler) GetGin(c *gin.Context) {
+	deployment, err := h.prompts.Status()
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+	c.JSON(http.StatusOK, deployment)
+}
+
+// ActivateGin handles PUT /admin/prompts/active
+func (h *PromptHandler) ActivateGin(c *gin.Context) {
+	var req request.PromptActivation
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	before, _ := h.prompts.Status()
+	deployment, err := h.prompts.Activate(req.Version, middleware.AdminActor(c))
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+		Action:       model.AuditActionUpdate,
+		ResourceType: model.AuditResourcePromptVersion,
+		ResourceID:   "active",
+		Before:       before,
+		After:        deployment,
+	})
+	c.JSON(http.StatusOK, deployment)
+}
+
+// RollbackGin handles POST /admin/prompts/rollback
+func (h *PromptHandler) RollbackGin(c *gin.Context) {
+	before, _ := h.prompts.Status()
+	deployment, err := h.prompts.Rollback(middleware.AdminActor(c))
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+		Action:       model.AuditActionRollback,
+		ResourceType: model.AuditResourcePromptVersion,
+		ResourceID:   "active",
+		Before:       before,
+		After:        deployment,
+	})
+	c.JSON(http.StatusOK, deployment)
+}
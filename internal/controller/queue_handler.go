@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"go.uber.org/zap"
+)
+
+// QueueManager inspects and manages the channel queues of a worker pool
+type QueueManager interface {
+	Queues() []model.QueueStatus
+	FlushQueue(queueKey string) (int, error)
+	PauseQueue(queueKey string) error
+	ResumeQueue(queueKey string) error
+}
+
+type namedQueueManager struct {
+	name    string
+	manager QueueManager
+}
+
+// QueueHandler exposes the worker pool queues on the admin API
+type QueueHandler struct {
+	pools   []namedQueueManager
+	auditor service.AuditService
+	logger  *zap.Logger
+}
+
+func NewQueueHandler(logger *zap.Logger) *QueueHandler {
+	return &QueueHandler{logger: logger}
+}
+
+// AddPool exposes the queues of a worker pool under the given name, e.g. slack
+func (h *QueueHandler) AddPool(name string, manager QueueManager) {
+	h.pools = append(h.pools, namedQueueManager{name: name, manager: manager})
+}
+
+// SetAuditor records queue operations in the admin audit trail
+func (h *QueueHandler) SetAuditor(auditor service.AuditService) {
+	h.auditor = auditor
+}
+
+// ListGin handles GET /admin/queues
+func (h *QueueHandler) ListGin(c *gin.Context) {
+	queues := []model.QueueStatus{}
+	for _, pool := range h.pools {
+		for _, status := range pool.manager.Queues() {
+			status.Pool = pool.name
+			queues = append(queues, status)
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"queues": queues})
+}
+
+// FlushGin handles POST /admin/queues/:key/flush
+func (h *QueueHandler) FlushGin(c *gin.Context) {
+	h.operate(c, model.AuditActionFlush, func(manager QueueManager, key string) (gin.H, error) {
+		dropped, err := manager.FlushQueue(key)
+		return gin.H{"key": key, "dropped": dropped}, err
+	})
+}
+
+// PauseGin handles POST /admin/queues/:key/pause
+func (h *QueueHandler) PauseGin(c *gin.Context) {
+	h.operate(c, model.AuditActionPause, func(manager QueueManager, key string) (gin.H, error) {
+		return gin.H{"key": key, "paused": true}, manager.PauseQueue(key)
+	})
+}
+
+// ResumeGin handles POST /admin/queues/:key/resume
+func (h *QueueHandler) ResumeGin(c *gin.Context) {
+	h.operate(c, model.AuditActionResume, func(manager QueueManager, key string) (gin.H, error) {
+		return gin.H{"key": key, "paused": false}, manager.ResumeQueue(key)
+	})
+}
+
+// operate applies an operation to the pool that holds the queue and records it
+func (h *QueueHandler) operate(c *gin.Context, action string, op func(QueueManager, string) (gin.H, error)) {
+	key := c.Param("key")
+	manager, ok := h.poolOf(key)
+	if !ok {
+		respondServiceError(c, h.logger, model.NewNotFoundError("queue not found"))
+		return
+	}
+
+	result, err := op(manager, key)
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+		Action:       action,
+		ResourceType: model.AuditResourceQueue,
+		ResourceID:   key,
+		After:        result,
+	})
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *QueueHandler) poolOf(key string) (QueueManager, bool) {
+	for _, pool := range h.pools {
+		for _, status := range pool.manager.Queues() {
+			if status.Key == key {
+				return pool.manager, true
+			}
+		}
+	}
+	return nil, false
+}
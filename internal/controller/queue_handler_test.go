@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeQueueManager struct {
+	queues  []model.QueueStatus
+	flushed []string
+	paused  []string
+}
+
+func (f *fakeQueueManager) Queues() []model.QueueStatus {
+	return f.queues
+}
+
+func (f *fakeQueueManager) FlushQueue(queueKey string) (int, error) {
+	f.flushed = append(f.flushed, queueKey)
+	return 2, nil
+}
+
+func (f *fakeQueueManager) PauseQueue(queueKey string) error {
+	f.paused = append(f.paused, queueKey)
+	return nil
+}
+
+func (f *fakeQueueManager) ResumeQueue(queueKey string) error {
+	return nil
+}
+
+func TestQueueHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	slackPool := &fakeQueueManager{queues: []model.QueueStatus{{Key: "C123", Depth: 3}}}
+	teamsPool := &fakeQueueManager{queues: []model.QueueStatus{{Key: "19:abc", Depth: 1}}}
+
+	handler := NewQueueHandler(zap.NewNop())
+	handler.AddPool("slack", slackPool)
+	handler.AddPool("teams", teamsPool)
+
+	r := gin.New()
+	r.GET("/admin/queues", handler.ListGin)
+	r.POST("/admin/queues/:key/flush", handler.FlushGin)
+	r.POST("/admin/queues/:key/pause", handler.PauseGin)
+
+	t.Run("list queues of every pool", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/queues", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var body struct {
+			Queues []model.QueueStatus `json:"queues"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, []model.QueueStatus{
+			{Key: "C123", Pool: "slack", Depth: 3},
+			{Key: "19:abc", Pool: "teams", Depth: 1},
+		}, body.Queues)
+	})
+
+	t.Run("operations go to the pool holding the queue", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/queues/19:abc/flush", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"key":"19:abc","dropped":2}`, w.Body.String())
+
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/queues/C123/pause", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		assert.Equal(t, []string{"19:abc"}, teamsPool.flushed)
+		assert.Equal(t, []string{"C123"}, slackPool.paused)
+		assert.Empty(t, slackPool.flushed)
+	})
+
+	t.Run("unknown queue", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/queues/C404/flush", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
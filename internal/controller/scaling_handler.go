@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+// scalingLatencyWindow is the period the average processing latency covers
+const scalingLatencyWindow = 5 * time.Minute
+
+// BacklogCounter counts the events a queue holds that are not processed yet,
+// e.g. a worker pool or the Redis queue shared by every instance
+type BacklogCounter interface {
+	Backlog(ctx context.Context) (int64, error)
+}
+
+// ProviderSaturation reports the share of AI providers refusing calls, from
+// 0 to 1, e.g. ai.ChainedProvider
+type ProviderSaturation interface {
+	Saturation() float64
+}
+
+type namedBacklogCounter struct {
+	name    string
+	counter BacklogCounter
+}
+
+// ScalingHandler exposes the signals an autoscaler such as KEDA or an HPA
+// with external metrics scales the workers on, instead of their CPU
+type ScalingHandler struct {
+	queues    []namedBacklogCounter
+	providers ProviderSaturation
+	metrics   *metrics.Metrics
+	logger    *zap.Logger
+	now       func() time.Time
+}
+
+func NewScalingHandler(metrics *metrics.Metrics, logger *zap.Logger) *ScalingHandler {
+	return &ScalingHandler{
+		metrics: metrics,
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+// AddQueue counts the backlog of a queue under the given name, e.g. slack
+func (h *ScalingHandler) AddQueue(name string, counter BacklogCounter) {
+	h.queues = append(h.queues, namedBacklogCounter{name: name, counter: counter})
+}
+
+// SetProviders reports the saturation of the AI providers
+func (h *ScalingHandler) SetProviders(providers ProviderSaturation) {
+	h.providers = providers
+}
+
+// GetGin handles GET /admin/scaling. A backlog that cannot be counted fails
+// the request rather than reading as empty, which would scale the workers down.
+func (h *ScalingHandler) GetGin(c *gin.Context) {
+	signals := response.ScalingSignals{BacklogByQueue: make(map[string]int64, len(h.queues))}
+	for _, queue := range h.queues {
+		backlog, err := queue.counter.Backlog(c.Request.Context())
+		if err != nil {
+			h.logger.Error("Failed to count queue backlog", zap.Error(err), zap.String("queue", queue.name))
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "queue backlog unavailable"})
+			return
+		}
+		signals.BacklogByQueue[queue.name] = backlog
+		signals.Backlog += backlog
+	}
+
+	latency, replies := h.metrics.AverageReplyLatencySince(h.now().Add(-scalingLatencyWindow))
+	signals.AvgProcessingMS = float64(latency.Microseconds()) / 1000
+	signals.Replies = replies
+	if h.providers != nil {
+		signals.ProviderSaturation = h.providers.Saturation()
+	}
+	c.JSON(http.StatusOK, signals)
+}
@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type fakeBacklogCounter struct {
+	backlog int64
+	err     error
+}
+
+func (f fakeBacklogCounter) Backlog(context.Context) (int64, error) {
+	return f.backlog, f.err
+}
+
+type fakeProviderSaturation float64
+
+func (f fakeProviderSaturation) Saturation() float64 {
+	return float64(f)
+}
+
+func TestScalingHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	now := time.Now()
+	m := metrics.NewMetrics()
+	// Replies older than the window are left out of the average
+	m.RecordReplyLatency(metrics.NewLatencyTrace(now.Add(-time.Hour-3*time.Second)), now.Add(-time.Hour))
+	m.RecordReplyLatency(metrics.NewLatencyTrace(now.Add(-time.Minute-time.Second)), now.Add(-time.Minute))
+	m.RecordReplyLatency(metrics.NewLatencyTrace(now.Add(-2*time.Second)), now)
+
+	serve := func(handler *ScalingHandler) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.GET("/admin/scaling", handler.GetGin)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/scaling", nil))
+		return w
+	}
+
+	t.Run("signals", func(t *testing.T) {
+		handler := NewScalingHandler(m, zap.NewNop())
+		handler.now = func() time.Time { return now }
+		handler.AddQueue("slack", fakeBacklogCounter{backlog: 42})
+		handler.AddQueue("teams", fakeBacklogCounter{backlog: 3})
+		handler.SetProviders(fakeProviderSaturation(0.5))
+
+		w := serve(handler)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{
+			"backlog": 45,
+			"backlog_by_queue": {"slack": 42, "teams": 3},
+			"avg_processing_ms": 1500,
+			"replies": 2,
+			"provider_saturation": 0.5
+		}`, w.Body.String())
+	})
+
+	t.Run("backlog unavailable", func(t *testing.T) {
+		handler := NewScalingHandler(m, zap.NewNop())
+		handler.AddQueue("slack", fakeBacklogCounter{err: errors.New("redis down")})
+
+		w := serve(handler)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/security"
+	"go.uber.org/zap"
+)
+
+// InjectionReviewer re-evaluates reported messages and manages the injection allowlist
+type InjectionReviewer interface {
+	ReviewInput(text string, falsePositive bool, allowPhrase string) (security.ValidationResult, error)
+	Allowlist() []string
+}
+
+// SecurityHandler exposes the injection detection feedback loop on the admin API
+type SecurityHandler struct {
+	reviewer InjectionReviewer
+	auditor  service.AuditService
+	logger   *zap.Logger
+}
+
+func NewSecurityHandler(reviewer InjectionReviewer, logger *zap.Logger) *SecurityHandler {
+	return &SecurityHandler{
+		reviewer: reviewer,
+		logger:   logger,
+	}
+}
+
+// SetAuditor records allowlist changes in the admin audit trail
+func (h *SecurityHandler) SetAuditor(auditor service.AuditService) {
+	h.auditor = auditor
+}
+
+// AllowlistGin handles GET /admin/security/allowlist
+func (h *SecurityHandler) AllowlistGin(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"allowlist": h.reviewer.Allowlist()})
+}
+
+// FeedbackGin handles POST /admin/security/feedback
+func (h *SecurityHandler) FeedbackGin(c *gin.Context) {
+	var req request.InjectionFeedback
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	before := h.reviewer.Allowlist()
+	result, err := h.reviewer.ReviewInput(req.Text, req.FalsePositive, req.AllowPhrase)
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+	after := h.reviewer.Allowlist()
+
+	if len(after) != len(before) {
+		recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+			Action:       model.AuditActionUpdate,
+			ResourceType: model.AuditResourceInjectionAllowlist,
+			ResourceID:   "input_validator",
+			Before:       gin.H{"phrases": before},
+			After:        gin.H{"phrases": after},
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"threat_level":      result.ThreatLevel.String(),
+		"detected_patterns": result.DetectedPatterns,
+		"allowlist":         after,
+	})
+}
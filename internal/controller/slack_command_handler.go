@@ -0,0 +1,256 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// Slash command response types: visible to the caller only, or to the whole channel
+const (
+	slashResponseEphemeral = "ephemeral"
+	slashResponseInChannel = "in_channel"
+)
+
+// statsCommand shows the channel's translation stats of the last statsDays
+// days, today included
+const (
+	statsCommand = "/translate-stats"
+	statsDays    = 7
+)
+
+// summarizeCommand summarizes the thread of a message link
+const summarizeCommand = "/summarize"
+
+// ThreadSummaryPoster posts the summary of a thread in the thread, e.g.
+// slack.ThreadSummaryPoster
+type ThreadSummaryPoster interface {
+	Post(ctx context.Context, channelID, threadTS string) error
+}
+
+// SlackCommandHandler handles the bot's slash commands, e.g.
+// `/translate pause 2h`, `/translate resume`, `/translate status`,
+// `/translate-stats` and `/summarize <message link>`
+type SlackCommandHandler struct {
+	pauses    service.ChannelPauseService
+	analytics service.AnalyticsService
+	summaries ThreadSummaryPoster
+	logger    *zap.Logger
+}
+
+func NewSlackCommandHandler(pauses service.ChannelPauseService, logger *zap.Logger) *SlackCommandHandler {
+	return &SlackCommandHandler{
+		pauses: pauses,
+		logger: logger,
+	}
+}
+
+// SetAnalytics answers `/translate-stats` with the channel's usage
+func (h *SlackCommandHandler) SetAnalytics(analytics service.AnalyticsService) {
+	h.analytics = analytics
+}
+
+// SetThreadSummaries answers `/summarize <message link>` by posting the
+// summary of the linked thread
+func (h *SlackCommandHandler) SetThreadSummaries(summaries ThreadSummaryPoster) {
+	h.summaries = summaries
+}
+
+// HandleCommandGin handles POST /slack/commands
+func (h *SlackCommandHandler) HandleCommandGin(c *gin.Context) {
+	cmd, err := slack.SlashCommandParse(c.Request)
+	if err != nil {
+		h.logger.Error("Failed to parse slash command", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+	c.JSON(http.StatusOK, h.HandleCommand(cmd))
+}
+
+// HandleCommand runs a slash command, however it was delivered, and returns
+// the reply shown in Slack
+func (h *SlackCommandHandler) HandleCommand(cmd slack.SlashCommand) *slack.Msg {
+	switch cmd.Command {
+	case statsCommand:
+		return h.stats(cmd)
+	case summarizeCommand:
+		return h.summarize(cmd)
+	}
+
+	fields := strings.Fields(cmd.Text)
+	if len(fields) == 0 {
+		return h.usage(cmd.Command)
+	}
+
+	switch strings.ToLower(fields[0]) {
+	case "pause":
+		return h.pause(cmd, fields[1:])
+	case "resume":
+		if err := h.pauses.Resume(cmd.ChannelID); err != nil {
+			return h.failed(cmd, err)
+		}
+		return &slack.Msg{
+			ResponseType: slashResponseInChannel,
+			Text:         fmt.Sprintf("▶️ <@%s> resumed translation in this channel", cmd.UserID),
+		}
+	case "status":
+		pause, err := h.pauses.GetPause(cmd.ChannelID)
+		if err != nil {
+			return h.failed(cmd, err)
+		}
+		text := "Translation is running in this channel"
+		if pause != nil {
+			text = "Translation is paused in this channel " + pauseUntil(pause.Until)
+		}
+		return &slack.Msg{ResponseType: slashResponseEphemeral, Text: text}
+	}
+	return h.usage(cmd.Command)
+}
+
+// pause handles `pause [duration]`, where duration is a Go duration such as 30m or 2h
+func (h *SlackCommandHandler) pause(cmd slack.SlashCommand, args []string) *slack.Msg {
+	var duration time.Duration
+	if len(args) > 0 {
+		parsed, err := time.ParseDuration(args[0])
+		if err != nil || parsed <= 0 {
+			return &slack.Msg{
+				ResponseType: slashResponseEphemeral,
+				Text:         fmt.Sprintf("⚠️ %q is not a duration, try `%s pause 30m` or `%s pause 2h`", args[0], cmd.Command, cmd.Command),
+			}
+		}
+		duration = parsed
+	}
+
+	pause, err := h.pauses.Pause(cmd.ChannelID, "slack:"+cmd.UserID, duration)
+	if err != nil {
+		return h.failed(cmd, err)
+	}
+	return &slack.Msg{
+		ResponseType: slashResponseInChannel,
+		Text:         fmt.Sprintf("⏸️ <@%s> paused translation in this channel %s", cmd.UserID, pauseUntil(pause.Until)),
+	}
+}
+
+// stats replies to the caller only with the channel's translation stats
+func (h *SlackCommandHandler) stats(cmd slack.SlashCommand) *slack.Msg {
+	if h.analytics == nil {
+		return &slack.Msg{ResponseType: slashResponseEphemeral, Text: "Translation stats are not available"}
+	}
+	stats, err := h.analytics.ChannelStats(cmd.ChannelID, time.Now().AddDate(0, 0, 1-statsDays))
+	if err != nil {
+		return h.failed(cmd, err)
+	}
+	return &slack.Msg{ResponseType: slashResponseEphemeral, Text: formatChannelStats(stats)}
+}
+
+// summarize posts the summary of the thread linked in the command's text in
+// the background, since summarizing takes longer than Slack waits for a reply
+func (h *SlackCommandHandler) summarize(cmd slack.SlashCommand) *slack.Msg {
+	if h.summaries == nil {
+		return &slack.Msg{ResponseType: slashResponseEphemeral, Text: "Thread summaries are not available"}
+	}
+	channelID, threadTS, ok := parseMessageLink(strings.TrimSpace(cmd.Text))
+	if !ok {
+		return &slack.Msg{
+			ResponseType: slashResponseEphemeral,
+			Text:         fmt.Sprintf("Usage: `%s <message link>`, where the link is copied from any message of the thread with \"Copy link\"", cmd.Command),
+		}
+	}
+
+	go func() {
+		ctx := service.WithTeam(context.Background(), cmd.TeamID)
+		if err := h.summaries.Post(ctx, channelID, threadTS); err != nil {
+			h.logger.Warn("Thread summary command failed",
+				zap.Error(err),
+				zap.String("channel_id", channelID),
+				zap.String("thread_ts", threadTS),
+				zap.String("user_id", cmd.UserID))
+		}
+	}()
+	return &slack.Msg{ResponseType: slashResponseEphemeral, Text: "🧾 Summarizing the thread, the summary will be posted in it"}
+}
+
+// messageLinkPattern matches a Slack message link, e.g.
+// https://acme.slack.com/archives/C123/p1700000000000100
+var messageLinkPattern = regexp.MustCompile(`^<?https://[^/]+/archives/([A-Z0-9]+)/p(\d{7,})(\?[^>|]*)?(\|[^>]*)?>?$`)
+
+// parseMessageLink returns the channel and the thread of a message link.
+// Links to replies carry their thread in the thread_ts parameter; other
+// links are to the thread's parent.
+func parseMessageLink(link string) (string, string, bool) {
+	match := messageLinkPattern.FindStringSubmatch(link)
+	if match == nil {
+		return "", "", false
+	}
+	digits := match[2]
+	threadTS := digits[:len(digits)-6] + "." + digits[len(digits)-6:]
+	if match[3] != "" {
+		// Slack escapes & as &amp; in command text
+		query, err := url.ParseQuery(strings.ReplaceAll(strings.TrimPrefix(match[3], "?"), "&amp;", "&"))
+		if err == nil && query.Get("thread_ts") != "" {
+			threadTS = query.Get("thread_ts")
+		}
+	}
+	return match[1], threadTS, true
+}
+
+func (h *SlackCommandHandler) failed(cmd slack.SlashCommand, err error) *slack.Msg {
+	var domainErr *model.DomainError
+	if errors.As(err, &domainErr) && domainErr.Type == model.ErrorTypeValidation {
+		return &slack.Msg{ResponseType: slashResponseEphemeral, Text: "⚠️ " + domainErr.Message}
+	}
+
+	h.logger.Error("Slash command failed",
+		zap.Error(err),
+		zap.String("text", cmd.Text),
+		zap.String("channel_id", cmd.ChannelID),
+		zap.String("user_id", cmd.UserID))
+	return &slack.Msg{ResponseType: slashResponseEphemeral, Text: "⚠️ Sorry, that did not work. Please try again."}
+}
+
+func (h *SlackCommandHandler) usage(command string) *slack.Msg {
+	return &slack.Msg{
+		ResponseType: slashResponseEphemeral,
+		Text: fmt.Sprintf("Usage:\n• `%[1]s pause [duration]` stop translating this channel, e.g. `%[1]s pause 2h`\n"+
+			"• `%[1]s resume` translate this channel again\n• `%[1]s status` show whether translation is paused", command),
+	}
+}
+
+// pauseUntil describes when a pause ends, using Slack date formatting
+func pauseUntil(until *time.Time) string {
+	if until == nil {
+		return "until someone resumes it"
+	}
+	return fmt.Sprintf("until <!date^%d^{time} {date_short}|%s>", until.Unix(), until.UTC().Format(time.RFC1123))
+}
+
+// formatChannelStats renders channel stats as a Slack message
+func formatChannelStats(stats *model.ChannelStats) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "📊 Translation stats for this channel since %s\n", stats.Since.Format("Jan 2"))
+	fmt.Fprintf(&b, "• Messages translated: %d\n", stats.Translations)
+	if len(stats.TopPairs) > 0 {
+		pairs := make([]string, 0, len(stats.TopPairs))
+		for _, pair := range stats.TopPairs {
+			pairs = append(pairs, fmt.Sprintf("%s → %s (%d)", pair.SourceLanguage, pair.TargetLanguage, pair.Translations))
+		}
+		fmt.Fprintf(&b, "• Top language pairs: %s\n", strings.Join(pairs, ", "))
+	}
+	fmt.Fprintf(&b, "• Cache hit rate: %.0f%%\n", stats.CacheHitRate*100)
+	fmt.Fprintf(&b, "• AI tokens: %d prompt, %d output", stats.PromptTokens, stats.OutputTokens)
+	if stats.Cost > 0 {
+		fmt.Fprintf(&b, " (about $%.2f)", stats.Cost)
+	}
+	return b.String()
+}
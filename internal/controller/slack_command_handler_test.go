@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type fakeChannelPauses struct {
+	paused map[string]*model.ChannelPause
+}
+
+func (f *fakeChannelPauses) Pause(channelID, pausedBy string, duration time.Duration) (*model.ChannelPause, error) {
+	if duration > 24*time.Hour {
+		return nil, model.NewValidationError("pause duration too long")
+	}
+	pause := &model.ChannelPause{ChannelID: channelID, PausedBy: pausedBy}
+	if duration > 0 {
+		until := time.Now().Add(duration)
+		pause.Until = &until
+	}
+	f.paused[channelID] = pause
+	return pause, nil
+}
+
+func (f *fakeChannelPauses) Resume(channelID string) error {
+	delete(f.paused, channelID)
+	return nil
+}
+
+func (f *fakeChannelPauses) GetPause(channelID string) (*model.ChannelPause, error) {
+	return f.paused[channelID], nil
+}
+
+func (f *fakeChannelPauses) IsPaused(channelID string) bool {
+	return f.paused[channelID] != nil
+}
+
+func TestSlackCommandHandler_HandleCommand(t *testing.T) {
+	pauses := &fakeChannelPauses{paused: make(map[string]*model.ChannelPause)}
+	handler := NewSlackCommandHandler(pauses, zap.NewNop())
+
+	run := func(text string) *slack.Msg {
+		return handler.HandleCommand(slack.SlashCommand{
+			Command:   "/translate",
+			Text:      text,
+			ChannelID: "C123",
+			UserID:    "U1",
+		})
+	}
+
+	tests := []struct {
+		name         string
+		text         string
+		responseType string
+		contains     string
+		paused       bool
+		timed        bool
+	}{
+		{name: "pause until resumed", text: "pause", responseType: slashResponseInChannel, contains: "until someone resumes it", paused: true},
+		{name: "status while paused", text: "status", responseType: slashResponseEphemeral, contains: "paused", paused: true},
+		{name: "resume", text: "resume", responseType: slashResponseInChannel, contains: "resumed"},
+		{name: "status while running", text: "STATUS", responseType: slashResponseEphemeral, contains: "running"},
+		{name: "timed pause", text: "pause 2h", responseType: slashResponseInChannel, contains: "<!date^", paused: true, timed: true},
+		{name: "invalid duration", text: "pause soon", responseType: slashResponseEphemeral, contains: "not a duration", paused: true, timed: true},
+		{name: "rejected duration", text: "pause 48h", responseType: slashResponseEphemeral, contains: "too long", paused: true, timed: true},
+		{name: "usage", text: "", responseType: slashResponseEphemeral, contains: "/translate pause", paused: true, timed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := run(tt.text)
+			assert.Equal(t, tt.responseType, msg.ResponseType)
+			assert.True(t, strings.Contains(msg.Text, tt.contains), msg.Text)
+
+			pause := pauses.paused["C123"]
+			assert.Equal(t, tt.paused, pause != nil)
+			if pause != nil {
+				assert.Equal(t, "slack:U1", pause.PausedBy)
+				assert.Equal(t, tt.timed, pause.Until != nil)
+			}
+		})
+	}
+}
+
+func TestSlackSocketHandler_HandleSlashCommand(t *testing.T) {
+	pauses := &fakeChannelPauses{paused: make(map[string]*model.ChannelPause)}
+	handler := NewSlackSocketHandler(nil, nil, zap.NewNop())
+	handler.SetCommandHandler(NewSlackCommandHandler(pauses, zap.NewNop()))
+	acker := &fakeSocketAcker{}
+
+	handler.HandleEvent(socketmode.Event{
+		Type:    socketmode.EventTypeSlashCommand,
+		Data:    slack.SlashCommand{Command: "/translate", Text: "pause", ChannelID: "C123", UserID: "U1"},
+		Request: &socketmode.Request{EnvelopeID: "env-1"},
+	}, acker)
+
+	assert.Equal(t, []string{"env-1"}, acker.acked)
+	assert.Len(t, acker.payloads, 1)
+	assert.True(t, pauses.IsPaused("C123"))
+}
+
+type fakeAnalytics struct {
+	stats *model.ChannelStats
+	since time.Time
+}
+
+func (f *fakeAnalytics) RecordTranslation(string, response.Translation) error {
+	return nil
+}
+
+func (f *fakeAnalytics) ChannelStats(channelID string, since time.Time) (*model.ChannelStats, error) {
+	f.since = since
+	stats := *f.stats
+	stats.ChannelID = channelID
+	return &stats, nil
+}
+
+func TestSlackCommandHandler_Stats(t *testing.T) {
+	analytics := &fakeAnalytics{stats: &model.ChannelStats{
+		Since:        time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC),
+		Translations: 42,
+		CacheHits:    14,
+		CacheHitRate: 1.0 / 3,
+		PromptTokens: 12000,
+		OutputTokens: 8000,
+		Cost:         0.0123,
+		TopPairs: []model.LanguagePairUsage{
+			{SourceLanguage: "English", TargetLanguage: "Vietnamese", Translations: 30},
+			{SourceLanguage: "Vietnamese", TargetLanguage: "English", Translations: 12},
+		},
+	}}
+	handler := NewSlackCommandHandler(&fakeChannelPauses{paused: make(map[string]*model.ChannelPause)}, zap.NewNop())
+	handler.SetAnalytics(analytics)
+
+	msg := handler.HandleCommand(slack.SlashCommand{Command: "/translate-stats", ChannelID: "C123", UserID: "U1"})
+
+	assert.Equal(t, slashResponseEphemeral, msg.ResponseType)
+	assert.Equal(t, "📊 Translation stats for this channel since Oct 10\n"+
+		"• Messages translated: 42\n"+
+		"• Top language pairs: English → Vietnamese (30), Vietnamese → English (12)\n"+
+		"• Cache hit rate: 33%\n"+
+		"• AI tokens: 12000 prompt, 8000 output (about $0.01)", msg.Text)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -6), analytics.since, time.Minute)
+}
+
+func TestSlackCommandHandler_StatsUnavailable(t *testing.T) {
+	handler := NewSlackCommandHandler(&fakeChannelPauses{paused: make(map[string]*model.ChannelPause)}, zap.NewNop())
+
+	msg := handler.HandleCommand(slack.SlashCommand{Command: "/translate-stats", ChannelID: "C123", UserID: "U1"})
+	assert.Equal(t, slashResponseEphemeral, msg.ResponseType)
+	assert.Contains(t, msg.Text, "not available")
+}
+
+type postedSummary struct {
+	team, channelID, threadTS string
+}
+
+type fakeThreadSummaries struct {
+	posted chan postedSummary
+}
+
+func (f *fakeThreadSummaries) Post(ctx context.Context, channelID, threadTS string) error {
+	f.posted <- postedSummary{team: service.TeamFrom(ctx), channelID: channelID, threadTS: threadTS}
+	return nil
+}
+
+func TestSlackCommandHandler_Summarize(t *testing.T) {
+	summaries := &fakeThreadSummaries{posted: make(chan postedSummary, 1)}
+	handler := NewSlackCommandHandler(&fakeChannelPauses{paused: make(map[string]*model.ChannelPause)}, zap.NewNop())
+	handler.SetThreadSummaries(summaries)
+
+	msg := handler.HandleCommand(slack.SlashCommand{
+		Command:   "/summarize",
+		Text:      "https://acme.slack.com/archives/C123/p1700000000000100",
+		TeamID:    "T1",
+		ChannelID: "C123",
+		UserID:    "U1",
+	})
+	assert.Equal(t, slashResponseEphemeral, msg.ResponseType)
+	assert.Contains(t, msg.Text, "Summarizing")
+	assert.Equal(t, postedSummary{team: "T1", channelID: "C123", threadTS: "1700000000.000100"}, <-summaries.posted)
+
+	msg = handler.HandleCommand(slack.SlashCommand{Command: "/summarize", Text: "this thread", ChannelID: "C123", UserID: "U1"})
+	assert.Contains(t, msg.Text, "Usage")
+	assert.Empty(t, summaries.posted)
+}
+
+func TestParseMessageLink(t *testing.T) {
+	tests := []struct {
+		name      string
+		link      string
+		channelID string
+		threadTS  string
+		ok        bool
+	}{
+		{name: "parent message", link: "https://acme.slack.com/archives/C123/p1700000000000100", channelID: "C123", threadTS: "1700000000.000100", ok: true},
+		{name: "reply", link: "https://acme.slack.com/archives/C123/p1700000500000200?thread_ts=1700000000.000100&amp;cid=C123", channelID: "C123", threadTS: "1700000000.000100", ok: true},
+		{name: "formatted by Slack", link: "<https://acme.slack.com/archives/C123/p1700000000000100>", channelID: "C123", threadTS: "1700000000.000100", ok: true},
+		{name: "channel link", link: "https://acme.slack.com/archives/C123"},
+		{name: "not a link", link: "summarize please"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			channelID, threadTS, ok := parseMessageLink(tt.link)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.channelID, channelID)
+			assert.Equal(t, tt.threadTS, threadTS)
+		})
+	}
+}
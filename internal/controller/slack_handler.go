@@ -1,26 +1,34 @@
 package controller
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
 	"github.com/ntttrang/go-genai-slack-assistant/internal/queue"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+// SlackWebhookHandler queues Slack events for ordered processing, in memory
+// or in the durable Redis queue
 type SlackWebhookHandler struct {
-	workerPool *queue.WorkerPool
+	workerPool queue.Queue
 	logger     *zap.Logger
 	seqCounter uint64
 }
 
-func NewSlackWebhookHandler(workerPool *queue.WorkerPool, logger *zap.Logger) *SlackWebhookHandler {
+func NewSlackWebhookHandler(workerPool queue.Queue, logger *zap.Logger) *SlackWebhookHandler {
 	return &SlackWebhookHandler{
 		workerPool: workerPool,
 		logger:     logger,
@@ -28,6 +36,9 @@ func NewSlackWebhookHandler(workerPool *queue.WorkerPool, logger *zap.Logger) *S
 }
 
 func (h *SlackWebhookHandler) HandleSlackEvents(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.Tracer().Start(r.Context(), "slack.webhook", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		h.logger.Error("Failed to read request body", zap.Error(err))
@@ -38,28 +49,27 @@ func (h *SlackWebhookHandler) HandleSlackEvents(w http.ResponseWriter, r *http.R
 		_ = r.Body.Close()
 	}()
 
-	var payload map[string]interface{}
-	if err := json.Unmarshal(body, &payload); err != nil {
+	envelope, err := decodeSlackEnvelope(body)
+	if err != nil {
 		h.logger.Error("Failed to unmarshal payload", zap.Error(err))
 		http.Error(w, "Bad request", http.StatusBadRequest)
 		return
 	}
 
 	// Handle URL verification challenge
-	if eventType, ok := payload["type"].(string); ok && eventType == "url_verification" {
-		challenge, ok := payload["challenge"].(string)
-		if !ok {
+	if envelope.Type == "url_verification" {
+		if envelope.Challenge == "" {
 			http.Error(w, "Bad request", http.StatusBadRequest)
 			return
 		}
 		w.Header().Set("Content-Type", "text/plain")
 		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(challenge))
+		_, _ = w.Write([]byte(envelope.Challenge))
 		return
 	}
 
 	// Extract message event and enqueue for processing
-	event, err := h.extractMessageEvent(payload)
+	event, err := h.extractMessageEvent(envelope, body)
 	if err != nil {
 		h.logger.Debug("Skipping non-message event or unable to extract event details", zap.Error(err))
 		w.Header().Set("Content-Type", "application/json")
@@ -69,7 +79,7 @@ func (h *SlackWebhookHandler) HandleSlackEvents(w http.ResponseWriter, r *http.R
 	}
 
 	// Enqueue event for ordered processing
-	h.workerPool.Enqueue(event)
+	h.enqueue(ctx, event)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -77,6 +87,9 @@ func (h *SlackWebhookHandler) HandleSlackEvents(w http.ResponseWriter, r *http.R
 }
 
 func (h *SlackWebhookHandler) HandleSlackEventsGin(c *gin.Context) {
+	ctx, span := tracing.Tracer().Start(c.Request.Context(), "slack.webhook", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
 	body, err := io.ReadAll(c.Request.Body)
 	if err != nil {
 		h.logger.Error("Failed to read request body", zap.Error(err))
@@ -87,30 +100,29 @@ func (h *SlackWebhookHandler) HandleSlackEventsGin(c *gin.Context) {
 		_ = c.Request.Body.Close()
 	}()
 
-	var payload map[string]interface{}
-	if err := json.Unmarshal(body, &payload); err != nil {
+	envelope, err := decodeSlackEnvelope(body)
+	if err != nil {
 		h.logger.Error("Failed to unmarshal payload", zap.Error(err))
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
 		return
 	}
 
-	h.logger.Info("Received Slack event", zap.String("type", fmt.Sprintf("%v", payload["type"])))
+	h.logger.Info("Received Slack event", zap.String("type", envelope.Type))
 
 	// Handle URL verification challenge
-	if eventType, ok := payload["type"].(string); ok && eventType == "url_verification" {
-		challenge, ok := payload["challenge"].(string)
-		if !ok {
+	if envelope.Type == "url_verification" {
+		if envelope.Challenge == "" {
 			h.logger.Error("Challenge parameter missing or invalid")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
 			return
 		}
-		h.logger.Info("Responding to URL verification challenge", zap.String("challenge", challenge))
-		c.Data(http.StatusOK, "text/plain", []byte(challenge))
+		h.logger.Info("Responding to URL verification challenge", zap.String("challenge", envelope.Challenge))
+		c.Data(http.StatusOK, "text/plain", []byte(envelope.Challenge))
 		return
 	}
 
 	// Extract message event and enqueue for processing
-	event, err := h.extractMessageEvent(payload)
+	event, err := h.extractMessageEvent(envelope, body)
 	if err != nil {
 		h.logger.Debug("Skipping non-message event or unable to extract event details", zap.Error(err))
 		c.JSON(http.StatusOK, gin.H{"ok": true})
@@ -118,37 +130,80 @@ func (h *SlackWebhookHandler) HandleSlackEventsGin(c *gin.Context) {
 	}
 
 	// Enqueue event for ordered processing
-	h.workerPool.Enqueue(event)
+	h.enqueue(ctx, event)
 
 	c.JSON(http.StatusOK, gin.H{"ok": true})
 }
 
-// extractMessageEvent extracts relevant fields from the Slack payload and creates a MessageEvent.
-func (h *SlackWebhookHandler) extractMessageEvent(payload map[string]interface{}) (*model.MessageEvent, error) {
-	// Get event_id if available
-	eventID, _ := payload["event_id"].(string)
+// enqueue queues event, carrying the trace of ctx to the worker that processes it
+func (h *SlackWebhookHandler) enqueue(ctx context.Context, event *model.MessageEvent) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.String("slack.event_type", event.EventType),
+		attribute.String("slack.channel_id", event.ChannelID),
+		attribute.String("slack.message_ts", event.MessageTS),
+	)
+	event.TraceContext = tracing.Inject(ctx)
+	h.workerPool.Enqueue(event)
+}
+
+// slackEnvelope holds the fields of a Slack Events API payload the webhook
+// needs to answer and route it. The full payload is decoded on the worker.
+type slackEnvelope struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	EventID   string `json:"event_id"`
+	// Authorizations lists the installations the event is delivered to,
+	// whose user_id is the bot user
+	Authorizations []struct {
+		UserID string `json:"user_id"`
+	} `json:"authorizations"`
+	Event *struct {
+		Type        string `json:"type"`
+		Channel     string `json:"channel"`
+		ChannelType string `json:"channel_type"`
+		User        string `json:"user"`
+		TS          string `json:"ts"`
+		Text        string `json:"text"`
+		// Item is the reacted message of reaction events
+		Item struct {
+			Channel string `json:"channel"`
+			TS      string `json:"ts"`
+		} `json:"item"`
+	} `json:"event"`
+}
+
+// decodeSlackEnvelope decodes the routing fields of body. Fields of an
+// unexpected JSON type, e.g. the channel object of channel_created events,
+// are left empty rather than rejecting the event.
+func decodeSlackEnvelope(body []byte) (*slackEnvelope, error) {
+	var envelope slackEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) {
+			return nil, err
+		}
+	}
+	return &envelope, nil
+}
 
-	// Get event callback data
-	eventCallback, ok := payload["event"].(map[string]interface{})
-	if !ok {
+// extractMessageEvent creates a MessageEvent from the envelope, carrying body
+// undecoded for the worker
+func (h *SlackWebhookHandler) extractMessageEvent(envelope *slackEnvelope, body []byte) (*model.MessageEvent, error) {
+	if envelope.Event == nil {
 		return nil, fmt.Errorf("missing event callback in payload")
 	}
 
 	// Extract channel_id, user_id, and message timestamp
-	channelID, _ := eventCallback["channel"].(string)
-	userID, _ := eventCallback["user"].(string)
-	messageTS, _ := eventCallback["ts"].(string)
+	channelID := envelope.Event.Channel
+	userID := envelope.Event.User
+	messageTS := envelope.Event.TS
 
 	// For reaction events, extract from item
-	if channelID == "" || messageTS == "" {
-		if item, ok := eventCallback["item"].(map[string]interface{}); ok {
-			if channelID == "" {
-				channelID, _ = item["channel"].(string)
-			}
-			if messageTS == "" {
-				messageTS, _ = item["ts"].(string)
-			}
-		}
+	if channelID == "" {
+		channelID = envelope.Event.Item.Channel
+	}
+	if messageTS == "" {
+		messageTS = envelope.Event.Item.TS
 	}
 
 	// Validate we have minimum required fields
@@ -162,12 +217,28 @@ func (h *SlackWebhookHandler) extractMessageEvent(payload map[string]interface{}
 	}
 
 	return &model.MessageEvent{
-		EventID:    eventID,
+		EventID:    envelope.EventID,
 		ChannelID:  channelID,
 		UserID:     userID,
 		MessageTS:  messageTS,
-		Payload:    payload,
+		EventType:  envelope.Event.Type,
+		RawPayload: body,
 		ReceivedAt: time.Now(),
 		Sequence:   atomic.AddUint64(&h.seqCounter, 1),
+		Priority:   eventPriority(envelope),
 	}, nil
 }
+
+// eventPriority gives direct messages to the bot and mentions of it high
+// priority, so they are answered ahead of bulk channel traffic
+func eventPriority(envelope *slackEnvelope) model.Priority {
+	if envelope.Event.Type == "app_mention" || envelope.Event.ChannelType == "im" {
+		return model.PriorityHigh
+	}
+	for _, authorization := range envelope.Authorizations {
+		if authorization.UserID != "" && strings.Contains(envelope.Event.Text, "<@"+authorization.UserID+">") {
+			return model.PriorityHigh
+		}
+	}
+	return model.PriorityNormal
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
 	"github.com/ntttrang/go-genai-slack-assistant/internal/queue"
 	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
 	"github.com/stretchr/testify/assert"
@@ -154,3 +155,124 @@ func TestSlackWebhookHandlerImplementsCorrectSignature(t *testing.T) {
 	assert.NotNil(t, handler)
 	assert.NotNil(t, handler.workerPool)
 }
+
+func TestSlackWebhookHandler_ExtractMessageEvent(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		wantErr       bool
+		wantChannelID string
+		wantUserID    string
+		wantTS        string
+		wantType      string
+		wantPriority  model.Priority
+	}{
+		{
+			name:          "message",
+			body:          `{"type":"event_callback","event_id":"Ev1","event":{"type":"message","channel":"C123","user":"U456","ts":"1700000000.000100","text":"Hello"}}`,
+			wantChannelID: "C123",
+			wantUserID:    "U456",
+			wantTS:        "1700000000.000100",
+			wantType:      "message",
+		},
+		{
+			name:          "reaction uses the reacted message",
+			body:          `{"type":"event_callback","event":{"type":"reaction_added","user":"U456","item":{"type":"message","channel":"C123","ts":"1700000000.000100"}}}`,
+			wantChannelID: "C123",
+			wantUserID:    "U456",
+			wantTS:        "1700000000.000100",
+			wantType:      "reaction_added",
+		},
+		{
+			name:          "missing user",
+			body:          `{"type":"event_callback","event":{"type":"message","channel":"C123","ts":"1700000000.000100"}}`,
+			wantChannelID: "C123",
+			wantUserID:    "unknown",
+			wantTS:        "1700000000.000100",
+			wantType:      "message",
+		},
+		{
+			name:          "direct message is high priority",
+			body:          `{"type":"event_callback","event":{"type":"message","channel":"D123","channel_type":"im","user":"U456","ts":"1700000000.000100","text":"Hello"}}`,
+			wantChannelID: "D123",
+			wantUserID:    "U456",
+			wantTS:        "1700000000.000100",
+			wantType:      "message",
+			wantPriority:  model.PriorityHigh,
+		},
+		{
+			name:          "bot mention is high priority",
+			body:          `{"type":"event_callback","authorizations":[{"user_id":"U999","is_bot":true}],"event":{"type":"message","channel":"C123","channel_type":"channel","user":"U456","ts":"1700000000.000100","text":"<@U999> help"}}`,
+			wantChannelID: "C123",
+			wantUserID:    "U456",
+			wantTS:        "1700000000.000100",
+			wantType:      "message",
+			wantPriority:  model.PriorityHigh,
+		},
+		{
+			name:          "other mention is normal priority",
+			body:          `{"type":"event_callback","authorizations":[{"user_id":"U999","is_bot":true}],"event":{"type":"message","channel":"C123","channel_type":"channel","user":"U456","ts":"1700000000.000100","text":"<@U111> help"}}`,
+			wantChannelID: "C123",
+			wantUserID:    "U456",
+			wantTS:        "1700000000.000100",
+			wantType:      "message",
+		},
+		{
+			name:    "channel object is skipped",
+			body:    `{"type":"event_callback","event":{"type":"channel_created","channel":{"id":"C123","name":"general"}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "no event",
+			body:    `{"type":"app_rate_limited"}`,
+			wantErr: true,
+		},
+	}
+
+	handler := NewSlackWebhookHandler(nil, zap.NewNop())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope, err := decodeSlackEnvelope([]byte(tt.body))
+			assert.NoError(t, err)
+
+			event, err := handler.extractMessageEvent(envelope, []byte(tt.body))
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantChannelID, event.ChannelID)
+			assert.Equal(t, tt.wantUserID, event.UserID)
+			assert.Equal(t, tt.wantTS, event.MessageTS)
+			assert.Equal(t, tt.wantType, event.EventType)
+			assert.Equal(t, tt.wantPriority, event.Priority)
+			assert.Nil(t, event.Payload)
+			assert.JSONEq(t, tt.body, string(event.RawPayload))
+		})
+	}
+}
+
+var benchmarkSlackBody = []byte(`{"token":"XXYYZZ","team_id":"T123","api_app_id":"A123","event":{"type":"message","channel":"C123","user":"U456","text":"Hello team, can someone review the release notes before 5pm?","ts":"1700000000.000100","event_ts":"1700000000.000100","channel_type":"channel","blocks":[{"type":"rich_text","block_id":"abc","elements":[{"type":"rich_text_section","elements":[{"type":"text","text":"Hello team, can someone review the release notes before 5pm?"}]}]}]},"type":"event_callback","event_id":"Ev123","event_time":1700000000,"authorizations":[{"enterprise_id":null,"team_id":"T123","user_id":"U999","is_bot":true,"is_enterprise_install":false}],"is_ext_shared_channel":false,"event_context":"4-abc"}`)
+
+// BenchmarkSlackWebhookDecode compares decoding the whole payload into a map,
+// as the webhook handler used to, with decoding only the routing envelope
+func BenchmarkSlackWebhookDecode(b *testing.B) {
+	b.Run("map", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var payload map[string]interface{}
+			if err := json.Unmarshal(benchmarkSlackBody, &payload); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("envelope", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := decodeSlackEnvelope(benchmarkSlackBody); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,404 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// SlackInteractionHandler handles Block Kit interactions, such as the review
+// buttons on translations awaiting approval
+type SlackInteractionHandler struct {
+	review      service.TranslationReviewService
+	preferences service.UserPreferenceService
+	actions     service.MessageActionService
+	onboarding  service.OnboardingService
+	channels    service.ChannelOnboardingService
+	explanation service.ExplanationService
+	logger      *zap.Logger
+	respond     func(responseURL, text string) error
+	// background runs work that may take longer than Slack waits for a response
+	background func(func())
+}
+
+func NewSlackInteractionHandler(review service.TranslationReviewService, logger *zap.Logger) *SlackInteractionHandler {
+	return &SlackInteractionHandler{
+		review:     review,
+		logger:     logger,
+		respond:    postEphemeralResponse,
+		background: func(f func()) { go f() },
+	}
+}
+
+// SetUserPreferences saves the preferences users change on the App Home tab
+func (h *SlackInteractionHandler) SetUserPreferences(preferences service.UserPreferenceService) {
+	h.preferences = preferences
+}
+
+// SetMessageActions answers the buttons below posted translations
+func (h *SlackInteractionHandler) SetMessageActions(actions service.MessageActionService) {
+	h.actions = actions
+}
+
+// SetOnboarding answers the steps of the setup wizard sent to the user who
+// installed the app
+func (h *SlackInteractionHandler) SetOnboarding(onboarding service.OnboardingService) {
+	h.onboarding = onboarding
+}
+
+// SetChannelOnboarding answers the language buttons of the setup message
+// posted in channels the bot is invited to
+func (h *SlackInteractionHandler) SetChannelOnboarding(channels service.ChannelOnboardingService) {
+	h.channels = channels
+}
+
+// SetExplanations answers the "Explain this message" message shortcut
+func (h *SlackInteractionHandler) SetExplanations(explanation service.ExplanationService) {
+	h.explanation = explanation
+}
+
+// HandleInteractionGin handles POST /slack/interactions
+func (h *SlackInteractionHandler) HandleInteractionGin(c *gin.Context) {
+	var callback slack.InteractionCallback
+	if err := json.Unmarshal([]byte(c.PostForm("payload")), &callback); err != nil {
+		h.logger.Error("Failed to unmarshal interaction payload", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	// Slack shows an error to the user for anything but a 200
+	if response := h.HandleCallback(callback); response != nil {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// HandleCallback handles an interaction, however it was delivered, and
+// returns the response payload for Slack, or nil for an empty response
+func (h *SlackInteractionHandler) HandleCallback(callback slack.InteractionCallback) *slack.ViewSubmissionResponse {
+	switch callback.Type {
+	case slack.InteractionTypeBlockActions:
+		h.handleBlockActions(callback)
+	case slack.InteractionTypeViewSubmission:
+		return h.handleViewSubmission(callback)
+	case slack.InteractionTypeMessageAction:
+		h.handleMessageShortcut(callback)
+	default:
+		h.logger.Debug("Ignoring interaction type", zap.String("type", string(callback.Type)))
+	}
+	return nil
+}
+
+// handleBlockActions handles the review buttons, the App Home preferences,
+// the buttons below translations, the setup wizard and channel setup messages
+func (h *SlackInteractionHandler) handleBlockActions(callback slack.InteractionCallback) {
+	for _, action := range callback.ActionCallback.BlockActions {
+		var err error
+		switch action.ActionID {
+		case model.TranslationActionShowOriginal, model.TranslationActionRetranslate:
+			h.handleTranslationAction(callback, action)
+			continue
+		case model.HomeActionPreferredLanguage, model.HomeActionTranslateOwnMessages:
+			h.handlePreferenceAction(callback.User.ID, action)
+			continue
+		case model.OnboardingActionLanguage, model.OnboardingActionChannelsNext, model.OnboardingActionBudget:
+			h.handleOnboardingAction(callback, action)
+			continue
+		case model.ReviewActionApprove:
+			_, err = h.review.Approve(action.Value, callback.User.ID)
+		case model.ReviewActionReject:
+			_, err = h.review.Reject(action.Value, callback.User.ID)
+		case model.ReviewActionEdit:
+			err = h.review.StartCorrection(action.Value, callback.TriggerID)
+		default:
+			if code, ok := model.ChannelSetupLanguage(action.ActionID); ok {
+				h.handleChannelSetupAction(callback, code)
+				continue
+			}
+			h.logger.Debug("Ignoring block action", zap.String("action_id", action.ActionID))
+			continue
+		}
+		if err != nil {
+			h.reportActionError(callback, action.ActionID, action.Value, err)
+		}
+	}
+}
+
+// handlePreferenceAction saves a preference changed on the App Home tab
+func (h *SlackInteractionHandler) handlePreferenceAction(userID string, action *slack.BlockAction) {
+	if h.preferences == nil {
+		return
+	}
+
+	var err error
+	switch action.ActionID {
+	case model.HomeActionPreferredLanguage:
+		code := action.SelectedOption.Value
+		if code == model.HomeLanguageChannelDefault {
+			code = ""
+		}
+		_, err = h.preferences.SetPreferredLanguage(userID, code)
+	case model.HomeActionTranslateOwnMessages:
+		_, err = h.preferences.SetTranslateOwnMessages(userID, len(action.SelectedOptions) > 0)
+	}
+	if err != nil {
+		h.logger.Error("Failed to save user preference",
+			zap.String("action_id", action.ActionID),
+			zap.String("user_id", userID),
+			zap.Error(err))
+	}
+}
+
+// handleTranslationAction shows the user who clicked a button below a
+// translation the original message, or its translation into the language
+// they picked. Translating can outlast Slack's 3 second deadline, so the
+// answer is sent to the response URL in the background.
+func (h *SlackInteractionHandler) handleTranslationAction(callback slack.InteractionCallback, action *slack.BlockAction) {
+	messageTS, ok := model.TranslatedMessageTS(action.BlockID)
+	if h.actions == nil || !ok || callback.ResponseURL == "" {
+		return
+	}
+	teamID, channelID, userID := callback.Team.ID, callback.Channel.ID, callback.User.ID
+
+	h.background(func() {
+		var text string
+		var err error
+		switch action.ActionID {
+		case model.TranslationActionShowOriginal:
+			var original string
+			if original, err = h.actions.Original(teamID, channelID, messageTS); err == nil {
+				text = "📝 *Original message*\n" + original
+			}
+		case model.TranslationActionRetranslate:
+			var result response.Translation
+			if result, err = h.actions.Retranslate(context.Background(), teamID, channelID, messageTS, userID, action.SelectedOption.Value); err == nil {
+				text = fmt.Sprintf("🌐 *%s*\n%s", result.TargetLanguage, result.TranslatedText)
+			}
+		}
+
+		if err != nil {
+			text = "❌ Sorry, the message could not be translated. Please try again."
+			var domainErr *model.DomainError
+			if errors.As(err, &domainErr) && domainErr.Type != model.ErrorTypeInternalError {
+				text = "⚠️ " + domainErr.Message
+			} else if message, ok := service.ProviderErrorMessage(err); ok {
+				text = message
+			}
+			h.logger.Warn("Translation action failed",
+				zap.String("action_id", action.ActionID),
+				zap.String("channel_id", channelID),
+				zap.String("message_ts", messageTS),
+				zap.String("user_id", userID),
+				zap.Error(err))
+		}
+		if err := h.respond(callback.ResponseURL, text); err != nil {
+			h.logger.Warn("Failed to respond to interaction", zap.Error(err))
+		}
+	})
+}
+
+// handleMessageShortcut answers the "Explain this message" shortcut with an
+// explanation only the user who asked sees. Like translations, explaining can
+// outlast Slack's 3 second deadline, so it is sent to the response URL in the
+// background.
+func (h *SlackInteractionHandler) handleMessageShortcut(callback slack.InteractionCallback) {
+	if callback.CallbackID != model.ExplainMessageCallbackID {
+		h.logger.Debug("Ignoring message shortcut", zap.String("callback_id", callback.CallbackID))
+		return
+	}
+	if h.explanation == nil || callback.ResponseURL == "" {
+		return
+	}
+	teamID, channelID, userID, text := callback.Team.ID, callback.Channel.ID, callback.User.ID, callback.Message.Text
+
+	h.background(func() {
+		ctx := service.WithTeam(context.Background(), teamID)
+		var message string
+		explanation, err := h.explanation.Explain(ctx, userID, channelID, text)
+		if err == nil {
+			message = fmt.Sprintf("💡 *Explanation* (%s)\n%s", explanation.Language, explanation.Text)
+		} else {
+			message = "❌ Sorry, the message could not be explained. Please try again."
+			var domainErr *model.DomainError
+			if errors.As(err, &domainErr) && domainErr.Type != model.ErrorTypeInternalError {
+				message = "⚠️ " + domainErr.Message
+			} else if providerMessage, ok := service.ProviderErrorMessage(err); ok {
+				message = providerMessage
+			}
+			h.logger.Warn("Message explanation failed",
+				zap.String("channel_id", channelID),
+				zap.String("message_ts", callback.Message.Timestamp),
+				zap.String("user_id", userID),
+				zap.Error(err))
+		}
+		if err := h.respond(callback.ResponseURL, message); err != nil {
+			h.logger.Warn("Failed to respond to interaction", zap.Error(err))
+		}
+	})
+}
+
+// handleOnboardingAction moves the setup wizard to its next step in the
+// background, since the last step writes every channel's configuration.
+// The channels picked are read from the message state when Next is clicked.
+func (h *SlackInteractionHandler) handleOnboardingAction(callback slack.InteractionCallback, action *slack.BlockAction) {
+	if h.onboarding == nil {
+		return
+	}
+	teamID, userID := callback.Team.ID, callback.User.ID
+	var channelIDs []string
+	if callback.BlockActionState != nil {
+		channelIDs = callback.BlockActionState.Values[model.OnboardingChannelsBlockID][model.OnboardingActionChannels].SelectedConversations
+	}
+
+	h.background(func() {
+		ctx := service.WithTeam(context.Background(), teamID)
+		var err error
+		switch action.ActionID {
+		case model.OnboardingActionLanguage:
+			err = h.onboarding.ChooseLanguage(ctx, teamID, userID, action.SelectedOption.Value)
+		case model.OnboardingActionChannelsNext:
+			err = h.onboarding.ChooseChannels(ctx, teamID, userID, channelIDs)
+		case model.OnboardingActionBudget:
+			budget, parseErr := strconv.ParseFloat(action.SelectedOption.Value, 64)
+			if parseErr != nil {
+				err = model.NewValidationError(fmt.Sprintf("invalid budget: %s", action.SelectedOption.Value))
+				break
+			}
+			err = h.onboarding.ChooseBudget(ctx, teamID, userID, budget)
+		}
+		if err == nil {
+			return
+		}
+
+		text := "❌ Sorry, the setup could not be saved. Please try again."
+		var domainErr *model.DomainError
+		if errors.As(err, &domainErr) && domainErr.Type != model.ErrorTypeInternalError {
+			text = "⚠️ " + domainErr.Message
+		}
+		h.logger.Warn("Setup wizard step failed",
+			zap.String("action_id", action.ActionID),
+			zap.String("team_id", teamID),
+			zap.String("user_id", userID),
+			zap.Error(err))
+		if callback.ResponseURL == "" {
+			return
+		}
+		if err := h.respond(callback.ResponseURL, text); err != nil {
+			h.logger.Warn("Failed to respond to interaction", zap.Error(err))
+		}
+	})
+}
+
+// handleChannelSetupAction sets the language a channel translates to from a
+// button of its setup message
+func (h *SlackInteractionHandler) handleChannelSetupAction(callback slack.InteractionCallback, code string) {
+	if h.channels == nil {
+		return
+	}
+	teamID, channelID, userID, messageTS := callback.Team.ID, callback.Channel.ID, callback.User.ID, callback.Container.MessageTs
+
+	h.background(func() {
+		ctx := service.WithTeam(context.Background(), teamID)
+		err := h.channels.ChooseLanguage(ctx, channelID, messageTS, userID, code)
+		if err == nil {
+			return
+		}
+
+		text := "❌ Sorry, the channel language could not be saved. Please try again."
+		var domainErr *model.DomainError
+		if errors.As(err, &domainErr) && domainErr.Type != model.ErrorTypeInternalError {
+			text = "⚠️ " + domainErr.Message
+		}
+		h.logger.Warn("Channel setup failed",
+			zap.String("channel_id", channelID),
+			zap.String("user_id", userID),
+			zap.String("language", code),
+			zap.Error(err))
+		if callback.ResponseURL == "" {
+			return
+		}
+		if err := h.respond(callback.ResponseURL, text); err != nil {
+			h.logger.Warn("Failed to respond to interaction", zap.Error(err))
+		}
+	})
+}
+
+// handleViewSubmission approves the translation edited in the correction modal.
+// Errors are shown on the text field, keeping the modal open.
+func (h *SlackInteractionHandler) handleViewSubmission(callback slack.InteractionCallback) *slack.ViewSubmissionResponse {
+	if callback.View.CallbackID != model.ReviewCorrectionCallbackID {
+		h.logger.Debug("Ignoring view submission", zap.String("callback_id", callback.View.CallbackID))
+		return nil
+	}
+
+	var correctedText string
+	if callback.View.State != nil {
+		correctedText = callback.View.State.Values[model.ReviewCorrectionBlockID][model.ReviewCorrectionActionID].Value
+	}
+
+	id := callback.View.PrivateMetadata
+	if _, err := h.review.ApproveWithCorrection(id, callback.User.ID, correctedText); err != nil {
+		message := "Sorry, the review could not be completed. Please try again."
+		var domainErr *model.DomainError
+		if errors.As(err, &domainErr) && domainErr.Type != model.ErrorTypeInternalError {
+			message = domainErr.Message
+		}
+		h.logger.Warn("Corrected approval failed",
+			zap.String("id", id),
+			zap.String("user_id", callback.User.ID),
+			zap.Error(err))
+		return slack.NewErrorsViewSubmissionResponse(map[string]string{
+			model.ReviewCorrectionBlockID: message,
+		})
+	}
+
+	// An empty response closes the modal
+	return nil
+}
+
+// reportActionError logs a failed review action and tells the reviewer why
+func (h *SlackInteractionHandler) reportActionError(callback slack.InteractionCallback, actionID, id string, err error) {
+	text := "❌ Sorry, the review could not be completed. Please try again."
+	var domainErr *model.DomainError
+	if errors.As(err, &domainErr) && domainErr.Type != model.ErrorTypeInternalError {
+		text = "⚠️ " + domainErr.Message
+		h.logger.Info("Review action rejected",
+			zap.String("action_id", actionID),
+			zap.String("id", id),
+			zap.String("user_id", callback.User.ID),
+			zap.Error(err))
+	} else {
+		h.logger.Error("Review action failed",
+			zap.String("action_id", actionID),
+			zap.String("id", id),
+			zap.String("user_id", callback.User.ID),
+			zap.Error(err))
+	}
+
+	if callback.ResponseURL == "" {
+		return
+	}
+	if err := h.respond(callback.ResponseURL, text); err != nil {
+		h.logger.Warn("Failed to respond to interaction", zap.Error(err))
+	}
+}
+
+// postEphemeralResponse shows text only to the user who clicked, keeping the original message
+func postEphemeralResponse(responseURL, text string) error {
+	return slack.PostWebhook(responseURL, &slack.WebhookMessage{
+		Text:            text,
+		ResponseType:    slack.ResponseTypeEphemeral,
+		ReplaceOriginal: false,
+	})
+}
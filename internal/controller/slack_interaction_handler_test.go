@@ -0,0 +1,381 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestSlackInteractionHandler_HandleInteractionGin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name          string
+		payload       string
+		mockSetup     func(*mocks.MockTranslationReviewService)
+		expectStatus  int
+		expectRespond string
+		expectBody    string
+	}{
+		{
+			name:    "approve",
+			payload: `{"type":"block_actions","user":{"id":"U9"},"response_url":"https://hooks.slack.test/r","actions":[{"block_id":"translation_review","action_id":"translation_review_approve","value":"p1"}]}`,
+			mockSetup: func(review *mocks.MockTranslationReviewService) {
+				review.EXPECT().Approve("p1", "U9").Return(&model.PendingTranslation{ID: "p1"}, nil)
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:    "reject already reviewed",
+			payload: `{"type":"block_actions","user":{"id":"U9"},"response_url":"https://hooks.slack.test/r","actions":[{"block_id":"translation_review","action_id":"translation_review_reject","value":"p1"}]}`,
+			mockSetup: func(review *mocks.MockTranslationReviewService) {
+				review.EXPECT().Reject("p1", "U9").Return(nil, model.NewBadRequestError("translation has already been reviewed"))
+			},
+			expectStatus:  http.StatusOK,
+			expectRespond: "⚠️ translation has already been reviewed",
+		},
+		{
+			name:    "edit opens correction modal",
+			payload: `{"type":"block_actions","user":{"id":"U9"},"trigger_id":"T1","actions":[{"block_id":"translation_review","action_id":"translation_review_edit","value":"p1"}]}`,
+			mockSetup: func(review *mocks.MockTranslationReviewService) {
+				review.EXPECT().StartCorrection("p1", "T1").Return(nil)
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:    "corrected translation submitted",
+			payload: `{"type":"view_submission","user":{"id":"U9"},"view":{"callback_id":"translation_review_correction","private_metadata":"p1","state":{"values":{"corrected_translation":{"corrected_text":{"type":"plain_text_input","value":"Xin chào đối tác"}}}}}}`,
+			mockSetup: func(review *mocks.MockTranslationReviewService) {
+				review.EXPECT().ApproveWithCorrection("p1", "U9", "Xin chào đối tác").Return(&model.PendingTranslation{ID: "p1"}, nil)
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:    "empty correction keeps modal open",
+			payload: `{"type":"view_submission","user":{"id":"U9"},"view":{"callback_id":"translation_review_correction","private_metadata":"p1","state":{"values":{"corrected_translation":{"corrected_text":{"type":"plain_text_input","value":" "}}}}}}`,
+			mockSetup: func(review *mocks.MockTranslationReviewService) {
+				review.EXPECT().ApproveWithCorrection("p1", "U9", " ").Return(nil, model.NewValidationError("translation must not be empty"))
+			},
+			expectStatus: http.StatusOK,
+			expectBody:   "translation must not be empty",
+		},
+		{
+			name:         "other interaction ignored",
+			payload:      `{"type":"message_action","user":{"id":"U9"}}`,
+			mockSetup:    func(*mocks.MockTranslationReviewService) {},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "invalid payload",
+			payload:      `not json`,
+			mockSetup:    func(*mocks.MockTranslationReviewService) {},
+			expectStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			review := mocks.NewMockTranslationReviewService(ctrl)
+			tt.mockSetup(review)
+
+			handler := NewSlackInteractionHandler(review, zap.NewNop())
+			var responded string
+			handler.respond = func(responseURL, text string) error {
+				responded = text
+				return nil
+			}
+
+			form := url.Values{"payload": {tt.payload}}
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest("POST", "/slack/interactions", strings.NewReader(form.Encode()))
+			ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			handler.HandleInteractionGin(ctx)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+			assert.Equal(t, tt.expectRespond, responded)
+			assert.Contains(t, rec.Body.String(), tt.expectBody)
+		})
+	}
+}
+
+type fakeUserPreferenceService struct {
+	languages []string
+	enabled   []bool
+}
+
+func (f *fakeUserPreferenceService) GetPreference(userID string) (*model.UserPreference, error) {
+	return model.DefaultUserPreference(userID), nil
+}
+
+func (f *fakeUserPreferenceService) SetPreferredLanguage(userID, code string) (*model.UserPreference, error) {
+	f.languages = append(f.languages, code)
+	return model.DefaultUserPreference(userID), nil
+}
+
+func (f *fakeUserPreferenceService) SetTranslateOwnMessages(userID string, enabled bool) (*model.UserPreference, error) {
+	f.enabled = append(f.enabled, enabled)
+	return model.DefaultUserPreference(userID), nil
+}
+
+func (f *fakeUserPreferenceService) PublishHome(string) error {
+	return nil
+}
+
+func TestSlackInteractionHandler_HomePreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	preferences := &fakeUserPreferenceService{}
+	handler := NewSlackInteractionHandler(mocks.NewMockTranslationReviewService(ctrl), zap.NewNop())
+	handler.SetUserPreferences(preferences)
+
+	payloads := []string{
+		`{"type":"block_actions","user":{"id":"U1"},"actions":[{"block_id":"home_language","action_id":"home_preferred_language","selected_option":{"value":"ja"}}]}`,
+		`{"type":"block_actions","user":{"id":"U1"},"actions":[{"block_id":"home_language","action_id":"home_preferred_language","selected_option":{"value":"default"}}]}`,
+		`{"type":"block_actions","user":{"id":"U1"},"actions":[{"block_id":"home_translate_own","action_id":"home_translate_own_messages","selected_options":[]}]}`,
+		`{"type":"block_actions","user":{"id":"U1"},"actions":[{"block_id":"home_translate_own","action_id":"home_translate_own_messages","selected_options":[{"value":"enabled"}]}]}`,
+	}
+	for _, payload := range payloads {
+		form := url.Values{"payload": {payload}}
+		rec := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rec)
+		ctx.Request = httptest.NewRequest("POST", "/slack/interactions", strings.NewReader(form.Encode()))
+		ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		handler.HandleInteractionGin(ctx)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	assert.Equal(t, []string{"ja", ""}, preferences.languages)
+	assert.Equal(t, []bool{false, true}, preferences.enabled)
+}
+
+type fakeMessageActionService struct {
+	originals map[string]string
+}
+
+func (f *fakeMessageActionService) Original(_, _, messageTS string) (string, error) {
+	original, ok := f.originals[messageTS]
+	if !ok {
+		return "", model.NewNotFoundError("the original message is no longer available")
+	}
+	return original, nil
+}
+
+func (f *fakeMessageActionService) Retranslate(_ context.Context, teamID, channelID, messageTS, _, code string) (response.Translation, error) {
+	original, err := f.Original(teamID, channelID, messageTS)
+	if err != nil {
+		return response.Translation{}, err
+	}
+	name, _ := model.LanguageName(code)
+	return response.Translation{TranslatedText: "[" + code + "] " + original, TargetLanguage: name}, nil
+}
+
+func TestSlackInteractionHandler_TranslationActions(t *testing.T) {
+	tests := []struct {
+		name          string
+		payload       string
+		expectRespond string
+	}{
+		{
+			name:          "show original",
+			payload:       `{"type":"block_actions","user":{"id":"U1"},"team":{"id":"T1"},"channel":{"id":"C1"},"response_url":"https://hooks.slack.test/r","actions":[{"block_id":"translation_actions:1.1","action_id":"translation_show_original","value":"1.1"}]}`,
+			expectRespond: "📝 *Original message*\nHello team",
+		},
+		{
+			name:          "try another language",
+			payload:       `{"type":"block_actions","user":{"id":"U1"},"team":{"id":"T1"},"channel":{"id":"C1"},"response_url":"https://hooks.slack.test/r","actions":[{"block_id":"translation_actions:1.1","action_id":"translation_retranslate","selected_option":{"value":"ja"}}]}`,
+			expectRespond: "🌐 *Japanese*\n[ja] Hello team",
+		},
+		{
+			name:          "original deleted",
+			payload:       `{"type":"block_actions","user":{"id":"U1"},"team":{"id":"T1"},"channel":{"id":"C1"},"response_url":"https://hooks.slack.test/r","actions":[{"block_id":"translation_actions:2.2","action_id":"translation_show_original","value":"2.2"}]}`,
+			expectRespond: "⚠️ the original message is no longer available",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := NewSlackInteractionHandler(mocks.NewMockTranslationReviewService(ctrl), zap.NewNop())
+			handler.SetMessageActions(&fakeMessageActionService{originals: map[string]string{"1.1": "Hello team"}})
+			handler.background = func(f func()) { f() }
+			var responded string
+			handler.respond = func(responseURL, text string) error {
+				responded = text
+				return nil
+			}
+
+			form := url.Values{"payload": {tt.payload}}
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest("POST", "/slack/interactions", strings.NewReader(form.Encode()))
+			ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			handler.HandleInteractionGin(ctx)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.expectRespond, responded)
+		})
+	}
+}
+
+func TestSlackInteractionHandler_Onboarding(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	onboarding := &fakeOnboarding{}
+	handler := NewSlackInteractionHandler(mocks.NewMockTranslationReviewService(ctrl), zap.NewNop())
+	handler.SetOnboarding(onboarding)
+	handler.background = func(f func()) { f() }
+	var responded []string
+	handler.respond = func(responseURL, text string) error {
+		responded = append(responded, text)
+		return nil
+	}
+
+	payloads := []string{
+		`{"type":"block_actions","user":{"id":"U1"},"team":{"id":"T1"},"response_url":"https://hooks.slack.test/r","actions":[{"block_id":"onboarding_step","action_id":"onboarding_language","selected_option":{"value":"ja"}}]}`,
+		`{"type":"block_actions","user":{"id":"U1"},"team":{"id":"T1"},"response_url":"https://hooks.slack.test/r","actions":[{"block_id":"onboarding_channels","action_id":"onboarding_channels","selected_conversations":["C1"]}]}`,
+		`{"type":"block_actions","user":{"id":"U1"},"team":{"id":"T1"},"response_url":"https://hooks.slack.test/r","state":{"values":{"onboarding_channels":{"onboarding_channels":{"type":"multi_conversations_select","selected_conversations":["C1","C2"]}}}},"actions":[{"block_id":"onboarding_channels","action_id":"onboarding_channels_next","value":"next"}]}`,
+		`{"type":"block_actions","user":{"id":"U1"},"team":{"id":"T1"},"response_url":"https://hooks.slack.test/r","actions":[{"block_id":"onboarding_step","action_id":"onboarding_budget","selected_option":{"value":"50"}}]}`,
+		`{"type":"block_actions","user":{"id":"U1"},"team":{"id":"T1"},"response_url":"https://hooks.slack.test/r","actions":[{"block_id":"onboarding_step","action_id":"onboarding_budget","selected_option":{"value":"lots"}}]}`,
+	}
+	for _, payload := range payloads {
+		form := url.Values{"payload": {payload}}
+		rec := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rec)
+		ctx.Request = httptest.NewRequest("POST", "/slack/interactions", strings.NewReader(form.Encode()))
+		ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		handler.HandleInteractionGin(ctx)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	assert.Equal(t, []string{"language:ja", "channels:[C1 C2]", "budget:50"}, onboarding.steps)
+	assert.Equal(t, []string{"⚠️ invalid budget: lots"}, responded)
+}
+
+type fakeChannelOnboarding struct {
+	chosen []string
+}
+
+func (f *fakeChannelOnboarding) BotJoined(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (f *fakeChannelOnboarding) ChooseLanguage(ctx context.Context, channelID, messageTS, userID, languageCode string) error {
+	f.chosen = append(f.chosen, fmt.Sprintf("%s/%s/%s/%s/%s", service.TeamFrom(ctx), channelID, messageTS, userID, languageCode))
+	return nil
+}
+
+func TestSlackInteractionHandler_ChannelSetup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	channels := &fakeChannelOnboarding{}
+	handler := NewSlackInteractionHandler(mocks.NewMockTranslationReviewService(ctrl), zap.NewNop())
+	handler.SetChannelOnboarding(channels)
+	handler.background = func(f func()) { f() }
+
+	payload := `{"type":"block_actions","user":{"id":"U1"},"team":{"id":"T1"},"channel":{"id":"C1"},"container":{"type":"message","message_ts":"1.1","channel_id":"C1"},"actions":[{"block_id":"channel_setup","action_id":"channel_setup_language:ja","value":"ja"}]}`
+	form := url.Values{"payload": {payload}}
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequest("POST", "/slack/interactions", strings.NewReader(form.Encode()))
+	ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	handler.HandleInteractionGin(ctx)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"T1/C1/1.1/U1/ja"}, channels.chosen)
+}
+
+// fakeExplanationService explains "slang" and rejects anything else as over
+// the rate limit
+type fakeExplanationService struct {
+	asked []string
+}
+
+func (f *fakeExplanationService) Explain(ctx context.Context, userID, channelID, text string) (response.Explanation, error) {
+	f.asked = append(f.asked, fmt.Sprintf("%s/%s/%s/%s", service.TeamFrom(ctx), channelID, userID, text))
+	if text != "slang" {
+		return response.Explanation{}, model.NewRateLimitError("Please try again in a minute")
+	}
+	return response.Explanation{Language: "Vietnamese", Text: "Tiếng lóng"}, nil
+}
+
+func TestSlackInteractionHandler_ExplainMessage(t *testing.T) {
+	tests := []struct {
+		name          string
+		payload       string
+		expectAsked   []string
+		expectRespond string
+	}{
+		{
+			name:          "explains the message",
+			payload:       `{"type":"message_action","callback_id":"explain_message","user":{"id":"U1"},"team":{"id":"T1"},"channel":{"id":"C1"},"response_url":"https://hooks.slack.test/r","message":{"text":"slang","ts":"1.1"}}`,
+			expectAsked:   []string{"T1/C1/U1/slang"},
+			expectRespond: "💡 *Explanation* (Vietnamese)\nTiếng lóng",
+		},
+		{
+			name:          "rate limited",
+			payload:       `{"type":"message_action","callback_id":"explain_message","user":{"id":"U1"},"team":{"id":"T1"},"channel":{"id":"C1"},"response_url":"https://hooks.slack.test/r","message":{"text":"idiom","ts":"1.2"}}`,
+			expectAsked:   []string{"T1/C1/U1/idiom"},
+			expectRespond: "⚠️ Please try again in a minute",
+		},
+		{
+			name:    "other shortcut",
+			payload: `{"type":"message_action","callback_id":"other","user":{"id":"U1"},"team":{"id":"T1"},"channel":{"id":"C1"},"response_url":"https://hooks.slack.test/r","message":{"text":"slang","ts":"1.1"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			explanations := &fakeExplanationService{}
+			handler := NewSlackInteractionHandler(mocks.NewMockTranslationReviewService(ctrl), zap.NewNop())
+			handler.SetExplanations(explanations)
+			handler.background = func(f func()) { f() }
+			var responded string
+			handler.respond = func(responseURL, text string) error {
+				responded = text
+				return nil
+			}
+
+			form := url.Values{"payload": {tt.payload}}
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest("POST", "/slack/interactions", strings.NewReader(form.Encode()))
+			ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			handler.HandleInteractionGin(ctx)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.expectAsked, explanations.asked)
+			assert.Equal(t, tt.expectRespond, responded)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"go.uber.org/zap"
+)
+
+// oauthStateCookie ties the OAuth callback to the browser that started the
+// installation, so a forged callback cannot install another workspace
+const oauthStateCookie = "slack_oauth_state"
+
+// oauthStateMaxAge is how long a user has to approve the installation in Slack
+const oauthStateMaxAge = 600
+
+// WorkspaceInstaller runs Slack's OAuth v2 installation flow
+type WorkspaceInstaller interface {
+	AuthorizeURL(state string) string
+	Exchange(ctx context.Context, code string) (*model.Workspace, error)
+}
+
+// SlackOAuthHandler installs the app in Slack workspaces through OAuth
+type SlackOAuthHandler struct {
+	installer  WorkspaceInstaller
+	workspaces service.WorkspaceService
+	onboarding service.OnboardingService
+	logger     *zap.Logger
+}
+
+func NewSlackOAuthHandler(installer WorkspaceInstaller, workspaces service.WorkspaceService, logger *zap.Logger) *SlackOAuthHandler {
+	return &SlackOAuthHandler{
+		installer:  installer,
+		workspaces: workspaces,
+		logger:     logger,
+	}
+}
+
+// SetOnboarding sends the setup wizard to the user who installed the app in
+// a new workspace
+func (h *SlackOAuthHandler) SetOnboarding(onboarding service.OnboardingService) {
+	h.onboarding = onboarding
+}
+
+// InstallGin handles GET /slack/install, sending the user to Slack to approve the installation
+func (h *SlackOAuthHandler) InstallGin(c *gin.Context) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		h.logger.Error("Failed to generate OAuth state", zap.Error(err))
+		c.String(http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	state := hex.EncodeToString(nonce)
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthStateCookie, state, oauthStateMaxAge, "/slack", "", isHTTPS(c), true)
+	c.Redirect(http.StatusFound, h.installer.AuthorizeURL(state))
+}
+
+// CallbackGin handles GET /slack/oauth/callback, where Slack sends the user
+// back with a code for the workspace's bot token
+func (h *SlackOAuthHandler) CallbackGin(c *gin.Context) {
+	if reason := c.Query("error"); reason != "" {
+		h.logger.Info("Slack installation cancelled", zap.String("reason", reason))
+		c.String(http.StatusOK, "Installation cancelled.")
+		return
+	}
+
+	state, err := c.Cookie(oauthStateCookie)
+	if err != nil || state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(c.Query("state"))) != 1 {
+		h.logger.Warn("Rejected Slack OAuth callback with invalid state")
+		c.String(http.StatusBadRequest, "Installation link expired, please start again.")
+		return
+	}
+	c.SetCookie(oauthStateCookie, "", -1, "/slack", "", isHTTPS(c), true)
+
+	code := c.Query("code")
+	if code == "" {
+		c.String(http.StatusBadRequest, "Missing authorization code.")
+		return
+	}
+
+	workspace, err := h.installer.Exchange(c.Request.Context(), code)
+	if err != nil {
+		h.logger.Error("Failed to complete Slack installation", zap.Error(err))
+		c.String(http.StatusBadGateway, "Slack did not complete the installation, please try again.")
+		return
+	}
+
+	// Reinstalls keep their setup, so only new workspaces get the wizard
+	existing, err := h.workspaces.GetWorkspace(workspace.TeamID)
+	if err != nil {
+		h.logger.Warn("Failed to check for an earlier installation",
+			zap.Error(err),
+			zap.String("team_id", workspace.TeamID))
+	}
+	firstInstall := err == nil && existing == nil
+
+	if err := h.workspaces.Install(workspace); err != nil {
+		h.logger.Error("Failed to save Slack installation",
+			zap.Error(err),
+			zap.String("team_id", workspace.TeamID))
+		c.String(http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	if h.onboarding != nil && firstInstall && workspace.InstalledBy != "" {
+		if err := h.onboarding.Start(c.Request.Context(), workspace.TeamID, workspace.InstalledBy); err != nil {
+			h.logger.Error("Failed to send setup wizard",
+				zap.Error(err),
+				zap.String("team_id", workspace.TeamID))
+		} else {
+			c.String(http.StatusOK, fmt.Sprintf("Translation bot installed in %s. Check your direct messages in Slack to finish the setup.", workspace.TeamName))
+			return
+		}
+	}
+
+	c.String(http.StatusOK, fmt.Sprintf("Translation bot installed in %s. You can close this page.", workspace.TeamName))
+}
+
+// isHTTPS reports whether the client reached the server over HTTPS,
+// directly or through a TLS-terminating proxy
+func isHTTPS(c *gin.Context) bool {
+	return c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+}
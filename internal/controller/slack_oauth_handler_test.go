@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeInstaller struct {
+	workspace *model.Workspace
+	err       error
+}
+
+func (f *fakeInstaller) AuthorizeURL(state string) string {
+	return "https://slack.com/oauth/v2/authorize?state=" + state
+}
+
+func (f *fakeInstaller) Exchange(_ context.Context, code string) (*model.Workspace, error) {
+	if code != "good-code" {
+		return nil, errors.New("invalid_code")
+	}
+	return f.workspace, f.err
+}
+
+func TestSlackOAuthHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockWorkspaceRepository(ctrl)
+	installer := &fakeInstaller{workspace: &model.Workspace{TeamID: "T1", TeamName: "Acme", BotToken: "xoxb-acme"}}
+	handler := NewSlackOAuthHandler(installer, service.NewWorkspaceUseCase(repo, zap.NewNop()), zap.NewNop())
+
+	r := gin.New()
+	r.GET("/slack/install", handler.InstallGin)
+	r.GET("/slack/oauth/callback", handler.CallbackGin)
+
+	// install starts the flow and returns the state cookie the callback must match
+	install := func(t *testing.T) *http.Cookie {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slack/install", nil))
+		require.Equal(t, http.StatusFound, w.Code)
+
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		redirect, err := url.Parse(w.Header().Get("Location"))
+		require.NoError(t, err)
+		assert.Equal(t, cookies[0].Value, redirect.Query().Get("state"))
+		return cookies[0]
+	}
+
+	callback := func(cookie *http.Cookie, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/slack/oauth/callback?"+query, nil)
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("installs the workspace", func(t *testing.T) {
+		cookie := install(t)
+		repo.EXPECT().GetByTeamID("T1").Return(nil, nil).Times(2)
+		repo.EXPECT().Save(gomock.Any()).DoAndReturn(func(workspace *model.Workspace) error {
+			assert.Equal(t, "xoxb-acme", workspace.BotToken.Reveal())
+			return nil
+		})
+
+		w := callback(cookie, "code=good-code&state="+cookie.Value)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Acme")
+	})
+
+	t.Run("rejects a state not issued to the browser", func(t *testing.T) {
+		cookie := install(t)
+		w := callback(cookie, "code=good-code&state=forged")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = callback(nil, "code=good-code&state="+cookie.Value)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("failed code exchange", func(t *testing.T) {
+		cookie := install(t)
+		w := callback(cookie, "code=bad-code&state="+cookie.Value)
+		assert.Equal(t, http.StatusBadGateway, w.Code)
+	})
+
+	t.Run("cancelled by the user", func(t *testing.T) {
+		w := callback(nil, "error=access_denied")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("sends the setup wizard to the installer of a new workspace", func(t *testing.T) {
+		onboarding := &fakeOnboarding{}
+		handler.SetOnboarding(onboarding)
+		installer.workspace = &model.Workspace{TeamID: "T2", TeamName: "Globex", BotToken: "xoxb-globex", InstalledBy: "U1"}
+
+		cookie := install(t)
+		repo.EXPECT().GetByTeamID("T2").Return(nil, nil).Times(2)
+		repo.EXPECT().Save(gomock.Any()).Return(nil)
+		w := callback(cookie, "code=good-code&state="+cookie.Value)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "direct messages")
+		assert.Equal(t, []string{"T2/U1"}, onboarding.started)
+
+		cookie = install(t)
+		repo.EXPECT().GetByTeamID("T2").Return(&model.Workspace{TeamID: "T2", BotToken: "xoxb-old"}, nil).Times(2)
+		repo.EXPECT().Save(gomock.Any()).Return(nil)
+		w = callback(cookie, "code=good-code&state="+cookie.Value)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, onboarding.started, 1, "reinstalls keep their setup")
+	})
+}
+
+type fakeOnboarding struct {
+	started []string
+	steps   []string
+	err     error
+}
+
+func (f *fakeOnboarding) Start(_ context.Context, teamID, userID string) error {
+	f.started = append(f.started, teamID+"/"+userID)
+	return f.err
+}
+
+func (f *fakeOnboarding) ChooseLanguage(_ context.Context, teamID, userID, languageCode string) error {
+	f.steps = append(f.steps, "language:"+languageCode)
+	return f.err
+}
+
+func (f *fakeOnboarding) ChooseChannels(_ context.Context, teamID, userID string, channelIDs []string) error {
+	f.steps = append(f.steps, fmt.Sprintf("channels:%v", channelIDs))
+	return f.err
+}
+
+func (f *fakeOnboarding) ChooseBudget(_ context.Context, teamID, userID string, budget float64) error {
+	f.steps = append(f.steps, fmt.Sprintf("budget:%g", budget))
+	return f.err
+}
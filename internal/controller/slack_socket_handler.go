@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/tracing"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// SocketAcker acknowledges Socket Mode requests, like socketmode.Client
+type SocketAcker interface {
+	Ack(req socketmode.Request, payload ...interface{})
+}
+
+// SlackSocketHandler receives Slack events and interactions over a Socket
+// Mode connection instead of HTTP, for deployments that cannot expose a
+// public webhook. Events go through the same parsing and worker pool as the
+// Events API webhook.
+type SlackSocketHandler struct {
+	events       *SlackWebhookHandler
+	interactions *SlackInteractionHandler
+	commands     *SlackCommandHandler
+	logger       *zap.Logger
+}
+
+func NewSlackSocketHandler(events *SlackWebhookHandler, interactions *SlackInteractionHandler, logger *zap.Logger) *SlackSocketHandler {
+	return &SlackSocketHandler{
+		events:       events,
+		interactions: interactions,
+		logger:       logger,
+	}
+}
+
+// SetCommandHandler answers the bot's slash command over the connection
+func (h *SlackSocketHandler) SetCommandHandler(commands *SlackCommandHandler) {
+	h.commands = commands
+}
+
+// Run connects client to Slack and handles its events until ctx is done.
+// The client reconnects on its own when the connection drops.
+func (h *SlackSocketHandler) Run(ctx context.Context, client *socketmode.Client) error {
+	errs := make(chan error, 1)
+	go func() {
+		errs <- client.RunContext(ctx)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		case evt := <-client.Events:
+			h.HandleEvent(evt, client)
+		}
+	}
+}
+
+// HandleEvent acknowledges a Socket Mode event and hands it to the pipeline
+// its HTTP counterpart would have used
+func (h *SlackSocketHandler) HandleEvent(evt socketmode.Event, acker SocketAcker) {
+	switch evt.Type {
+	case socketmode.EventTypeConnecting:
+		h.logger.Info("Connecting to Slack Socket Mode")
+	case socketmode.EventTypeConnected:
+		h.logger.Info("Connected to Slack Socket Mode")
+	case socketmode.EventTypeConnectionError:
+		h.logger.Warn("Slack Socket Mode connection failed, retrying")
+	case socketmode.EventTypeDisconnect:
+		h.logger.Warn("Slack Socket Mode disconnected, reconnecting")
+	case socketmode.EventTypeEventsAPI:
+		if evt.Request == nil {
+			return
+		}
+		// Acknowledge first: Slack retries events not acknowledged within 3 seconds
+		acker.Ack(*evt.Request)
+		h.handleEventsAPI(evt.Request.Payload)
+	case socketmode.EventTypeInteractive:
+		if evt.Request == nil {
+			return
+		}
+		callback, ok := evt.Data.(slack.InteractionCallback)
+		if !ok {
+			h.logger.Warn("Ignoring interaction with unexpected data")
+			acker.Ack(*evt.Request)
+			return
+		}
+		if response := h.interactions.HandleCallback(callback); response != nil {
+			acker.Ack(*evt.Request, response)
+			return
+		}
+		acker.Ack(*evt.Request)
+	case socketmode.EventTypeSlashCommand:
+		if evt.Request == nil {
+			return
+		}
+		cmd, ok := evt.Data.(slack.SlashCommand)
+		if !ok || h.commands == nil {
+			h.logger.Warn("Ignoring slash command", zap.Bool("handled", h.commands != nil))
+			acker.Ack(*evt.Request)
+			return
+		}
+		acker.Ack(*evt.Request, h.commands.HandleCommand(cmd))
+	default:
+		if evt.Request != nil && evt.Request.EnvelopeID != "" {
+			acker.Ack(*evt.Request)
+		}
+		h.logger.Debug("Ignoring Socket Mode event", zap.String("type", string(evt.Type)))
+	}
+}
+
+// handleEventsAPI enqueues an Events API payload, which Socket Mode delivers
+// exactly as the webhook receives it
+func (h *SlackSocketHandler) handleEventsAPI(payload []byte) {
+	ctx, span := tracing.Tracer().Start(context.Background(), "slack.socket_event", trace.WithSpanKind(trace.SpanKindConsumer))
+	defer span.End()
+
+	envelope, err := decodeSlackEnvelope(payload)
+	if err != nil {
+		h.logger.Error("Failed to unmarshal Socket Mode payload", zap.Error(err))
+		return
+	}
+
+	event, err := h.events.extractMessageEvent(envelope, payload)
+	if err != nil {
+		h.logger.Debug("Skipping non-message event or unable to extract event details", zap.Error(err))
+		return
+	}
+	h.events.enqueue(ctx, event)
+}
@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/queue"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type fakeSocketAcker struct {
+	acked    []string
+	payloads []interface{}
+}
+
+func (f *fakeSocketAcker) Ack(req socketmode.Request, payload ...interface{}) {
+	f.acked = append(f.acked, req.EnvelopeID)
+	f.payloads = append(f.payloads, payload...)
+}
+
+func TestSlackSocketHandler_HandleEventsAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEventProc := mocks.NewMockEventProcessorService(ctrl)
+	processed := make(chan map[string]interface{}, 1)
+	mockEventProc.EXPECT().ProcessEvent(gomock.Any(), gomock.Any()).
+		Do(func(_ interface{}, payload map[string]interface{}) {
+			processed <- payload
+		})
+
+	workerPool := queue.NewWorkerPool(mockEventProc, 10, time.Minute, zap.NewNop())
+	defer func() {
+		_ = workerPool.Shutdown(5 * time.Second)
+	}()
+	handler := NewSlackSocketHandler(NewSlackWebhookHandler(workerPool, zap.NewNop()), nil, zap.NewNop())
+	acker := &fakeSocketAcker{}
+
+	handler.HandleEvent(socketmode.Event{
+		Type: socketmode.EventTypeEventsAPI,
+		Request: &socketmode.Request{
+			Type:       "events_api",
+			EnvelopeID: "env-1",
+			Payload:    json.RawMessage(`{"type":"event_callback","event_id":"Ev1","event":{"type":"message","channel":"C123","user":"U456","ts":"1700000000.000100","text":"Hello"}}`),
+		},
+	}, acker)
+
+	assert.Equal(t, []string{"env-1"}, acker.acked)
+	select {
+	case payload := <-processed:
+		event := payload["event"].(map[string]interface{})
+		assert.Equal(t, "Hello", event["text"])
+	case <-time.After(time.Second):
+		t.Fatal("expected the event to be processed")
+	}
+}
+
+func TestSlackSocketHandler_HandleInteractive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	review := mocks.NewMockTranslationReviewService(ctrl)
+	review.EXPECT().ApproveWithCorrection("p1", "U9", " ").Return(nil, model.NewValidationError("translation must not be empty"))
+
+	interactions := NewSlackInteractionHandler(review, zap.NewNop())
+	handler := NewSlackSocketHandler(nil, interactions, zap.NewNop())
+	acker := &fakeSocketAcker{}
+
+	handler.HandleEvent(socketmode.Event{
+		Type: socketmode.EventTypeInteractive,
+		Data: slack.InteractionCallback{
+			Type: slack.InteractionTypeViewSubmission,
+			User: slack.User{ID: "U9"},
+			View: slack.View{
+				CallbackID:      model.ReviewCorrectionCallbackID,
+				PrivateMetadata: "p1",
+				State: &slack.ViewState{Values: map[string]map[string]slack.BlockAction{
+					model.ReviewCorrectionBlockID: {model.ReviewCorrectionActionID: {Value: " "}},
+				}},
+			},
+		},
+		Request: &socketmode.Request{Type: "interactive", EnvelopeID: "env-2"},
+	}, acker)
+
+	assert.Equal(t, []string{"env-2"}, acker.acked)
+	if assert.Len(t, acker.payloads, 1) {
+		response := acker.payloads[0].(*slack.ViewSubmissionResponse)
+		assert.Equal(t, "translation must not be empty", response.Errors[model.ReviewCorrectionBlockID])
+	}
+}
+
+func TestSlackSocketHandler_IgnoresConnectionEvents(t *testing.T) {
+	handler := NewSlackSocketHandler(nil, nil, zap.NewNop())
+	acker := &fakeSocketAcker{}
+
+	handler.HandleEvent(socketmode.Event{Type: socketmode.EventTypeConnected}, acker)
+	handler.HandleEvent(socketmode.Event{Type: socketmode.EventTypeHello, Request: &socketmode.Request{Type: "hello"}}, acker)
+
+	assert.Empty(t, acker.acked)
+}
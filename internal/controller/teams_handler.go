@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/middleware"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/queue"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service/teams"
+	"go.uber.org/zap"
+)
+
+// TeamsHandler receives Microsoft Teams activities from Bot Framework
+type TeamsHandler struct {
+	workerPool *queue.WorkerPool
+	logger     *zap.Logger
+	seqCounter uint64
+}
+
+func NewTeamsHandler(workerPool *queue.WorkerPool, logger *zap.Logger) *TeamsHandler {
+	return &TeamsHandler{
+		workerPool: workerPool,
+		logger:     logger,
+	}
+}
+
+// HandleActivityGin enqueues message activities for ordered processing. It
+// must run after middleware.VerifyTeamsTokenGin.
+func (h *TeamsHandler) HandleActivityGin(c *gin.Context) {
+	var activity teams.Activity
+	if err := c.ShouldBindJSON(&activity); err != nil {
+		h.logger.Error("Failed to unmarshal Teams activity", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	// Replies go to the activity's service URL, so it must be the one the token was issued for
+	if !strings.EqualFold(strings.TrimSuffix(activity.ServiceURL, "/"), strings.TrimSuffix(middleware.TeamsServiceURL(c), "/")) {
+		h.logger.Warn("Teams activity service URL does not match its token",
+			zap.String("service_url", activity.ServiceURL))
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if activity.Type != teams.ActivityTypeMessage || activity.Conversation.ID == "" || activity.From == nil {
+		h.logger.Debug("Skipping non-message Teams activity", zap.String("type", activity.Type))
+		c.Status(http.StatusOK)
+		return
+	}
+
+	h.workerPool.Enqueue(&model.MessageEvent{
+		EventID:    activity.ID,
+		ChannelID:  activity.Conversation.ID,
+		UserID:     activity.From.ID,
+		MessageTS:  activity.ID,
+		Payload:    map[string]interface{}{teams.PayloadActivityKey: &activity},
+		ReceivedAt: time.Now(),
+		Sequence:   atomic.AddUint64(&h.seqCounter, 1),
+	})
+
+	c.Status(http.StatusOK)
+}
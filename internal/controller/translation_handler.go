@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"go.uber.org/zap"
+)
+
+// TranslationHandler exposes translations, with how they were produced, on
+// the admin API
+type TranslationHandler struct {
+	translationService service.TranslationService
+	logger             *zap.Logger
+}
+
+func NewTranslationHandler(translationService service.TranslationService, logger *zap.Logger) *TranslationHandler {
+	return &TranslationHandler{
+		translationService: translationService,
+		logger:             logger,
+	}
+}
+
+// TranslateGin handles POST /admin/translations
+func (h *TranslationHandler) TranslateGin(c *gin.Context) {
+	var req request.Translation
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	translation, err := h.translationService.TranslateContext(c.Request.Context(), req)
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+	c.JSON(http.StatusOK, translation)
+}
+
+// GetGin handles GET /admin/translations/:translation_id
+func (h *TranslationHandler) GetGin(c *gin.Context) {
+	translation, err := h.translationService.GetTranslation(c.Request.Context(), c.Param("translation_id"))
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+	c.JSON(http.StatusOK, translation)
+}
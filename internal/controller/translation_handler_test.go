@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTranslationHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	translationService := mocks.NewMockTranslationService(ctrl)
+	handler := NewTranslationHandler(translationService, zap.NewNop())
+
+	r := gin.New()
+	r.POST("/admin/translations", handler.TranslateGin)
+	r.GET("/admin/translations/:translation_id", handler.GetGin)
+
+	t.Run("translate returns metadata", func(t *testing.T) {
+		req := request.Translation{Text: "Hello", SourceLanguage: "en", TargetLanguage: "vi"}
+		translationService.EXPECT().TranslateContext(gomock.Any(), req).Return(response.Translation{
+			ID:             "tr-1",
+			OriginalText:   "Hello",
+			TranslatedText: "Xin chào",
+			SourceLanguage: "en",
+			TargetLanguage: "vi",
+			Provider:       "gemini",
+			Model:          "gemini-1.5-flash",
+			LatencyMS:      850,
+			PromptTokens:   120,
+			OutputTokens:   4,
+			Confidence:     1,
+		}, nil)
+
+		body, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/translations", bytes.NewReader(body)))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var got map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.Equal(t, "tr-1", got["id"])
+		assert.Equal(t, "gemini-1.5-flash", got["model"])
+		assert.Equal(t, false, got["cached"])
+		assert.Equal(t, float64(850), got["latency_ms"])
+		assert.Equal(t, float64(120), got["prompt_tokens"])
+		assert.Equal(t, float64(1), got["confidence"])
+	})
+
+	t.Run("translate rejects missing fields", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/translations", bytes.NewReader([]byte(`{"text":"Hello"}`))))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("unknown translation", func(t *testing.T) {
+		translationService.EXPECT().GetTranslation(gomock.Any(), "missing").Return(response.Translation{}, model.NewNotFoundError("translation not found"))
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/translations/missing", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
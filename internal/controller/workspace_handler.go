@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/secrets"
+	"go.uber.org/zap"
+)
+
+// WorkspaceHandler exposes the Slack workspaces the app is installed in on
+// the admin API. Credentials are returned redacted.
+type WorkspaceHandler struct {
+	workspaces service.WorkspaceService
+	auditor    service.AuditService
+	logger     *zap.Logger
+}
+
+func NewWorkspaceHandler(workspaces service.WorkspaceService, logger *zap.Logger) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		workspaces: workspaces,
+		logger:     logger,
+	}
+}
+
+// SetAuditor records every workspace change in the admin audit trail
+func (h *WorkspaceHandler) SetAuditor(auditor service.AuditService) {
+	h.auditor = auditor
+}
+
+// ListGin handles GET /admin/workspaces
+func (h *WorkspaceHandler) ListGin(c *gin.Context) {
+	workspaces, err := h.workspaces.ListWorkspaces()
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"workspaces": workspaces})
+}
+
+// SetSigningSecretGin handles PUT /admin/workspaces/:team_id/signing-secret
+func (h *WorkspaceHandler) SetSigningSecretGin(c *gin.Context) {
+	teamID := c.Param("team_id")
+	var req request.WorkspaceSigningSecret
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	workspace, err := h.workspaces.SetSigningSecret(teamID, secrets.Secret(req.SigningSecret))
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+		Action:       model.AuditActionUpdate,
+		ResourceType: model.AuditResourceWorkspace,
+		ResourceID:   teamID,
+		After:        workspace,
+	})
+	c.JSON(http.StatusOK, workspace)
+}
+
+// SetGenerationGin handles PUT /admin/workspaces/:team_id/generation
+func (h *WorkspaceHandler) SetGenerationGin(c *gin.Context) {
+	teamID := c.Param("team_id")
+	var req request.WorkspaceGeneration
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	before := h.auditSnapshot(teamID)
+	workspace, err := h.workspaces.SetGeneration(teamID, req.Generation)
+	if err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+		Action:       model.AuditActionUpdate,
+		ResourceType: model.AuditResourceWorkspace,
+		ResourceID:   teamID,
+		Before:       before,
+		After:        workspace,
+	})
+	c.JSON(http.StatusOK, workspace)
+}
+
+// DeleteGin handles DELETE /admin/workspaces/:team_id. The workspace's
+// events are answered with the default bot token afterwards.
+func (h *WorkspaceHandler) DeleteGin(c *gin.Context) {
+	teamID := c.Param("team_id")
+	before := h.auditSnapshot(teamID)
+	if err := h.workspaces.Uninstall(teamID); err != nil {
+		respondServiceError(c, h.logger, err)
+		return
+	}
+
+	recordAudit(c, h.auditor, h.logger, model.AuditEntry{
+		Action:       model.AuditActionDelete,
+		ResourceType: model.AuditResourceWorkspace,
+		ResourceID:   teamID,
+		Before:       before,
+	})
+	c.Status(http.StatusNoContent)
+}
+
+// auditSnapshot loads the current workspace for the audit before state.
+// It returns nil when auditing is disabled or the workspace cannot be read.
+func (h *WorkspaceHandler) auditSnapshot(teamID string) *model.Workspace {
+	if h.auditor == nil {
+		return nil
+	}
+	workspace, err := h.workspaces.GetWorkspace(teamID)
+	if err != nil {
+		return nil
+	}
+	return workspace
+}
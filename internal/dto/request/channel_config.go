@@ -0,0 +1,131 @@
+package request
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+type ChannelConfig struct {
+	ChannelID             string   `json:"channel_id"`
+	AutoTranslate         bool     `json:"auto_translate"`
+	SourceLanguages       []string `json:"source_languages"`
+	TargetLanguage        string   `json:"target_language"`
+	Enabled               *bool    `json:"enabled,omitempty"`
+	Canary                bool     `json:"canary"`
+	Debug                 bool     `json:"debug"`
+	ReviewChannelID       string   `json:"review_channel_id"`
+	DigestIntervalMinutes int      `json:"digest_interval_minutes"`
+	DigestMaxMessages     int      `json:"digest_max_messages"`
+	DeliveryMode          string   `json:"delivery_mode"`
+	// Generation overrides the workspace's AI generation parameters
+	Generation model.GenerationSettings `json:"generation"`
+}
+
+// Validate validates the channel configuration request
+func (c *ChannelConfig) Validate() *dto.Validator {
+	v := dto.NewValidator()
+
+	if c.ChannelID == "" {
+		v.Add("channel_id", "channel_id is required")
+	}
+
+	c.validateSettings(v, "")
+
+	return v
+}
+
+// validateSettings validates everything except the channel ID, prefixing field names
+func (c *ChannelConfig) validateSettings(v *dto.Validator, prefix string) {
+	if c.TargetLanguage == "" {
+		v.Add(prefix+"target_language", "target_language is required")
+	} else if !model.IsSupportedLanguageCode(c.TargetLanguage) {
+		v.Add(prefix+"target_language", fmt.Sprintf("unsupported language code %q", c.TargetLanguage))
+	}
+
+	if c.DigestIntervalMinutes < 0 {
+		v.Add(prefix+"digest_interval_minutes", "digest_interval_minutes must not be negative")
+	}
+	if c.DigestMaxMessages < 0 {
+		v.Add(prefix+"digest_max_messages", "digest_max_messages must not be negative")
+	}
+	if !model.IsValidDeliveryMode(c.DeliveryMode) {
+		v.Add(prefix+"delivery_mode", fmt.Sprintf("delivery_mode must be %s, %s or %s",
+			model.DeliveryModeThread, model.DeliveryModeEphemeral, model.DeliveryModeDM))
+	}
+	if err := c.Generation.Validate(); err != nil {
+		v.Add(prefix+"generation", err.Error())
+	}
+
+	seen := make(map[string]bool, len(c.SourceLanguages))
+	for i, code := range c.SourceLanguages {
+		field := fmt.Sprintf("%ssource_languages[%d]", prefix, i)
+		if !model.IsSupportedLanguageCode(code) {
+			v.Add(field, fmt.Sprintf("unsupported language code %q", code))
+		} else if seen[code] {
+			v.Add(field, fmt.Sprintf("duplicate language code %q", code))
+		}
+		seen[code] = true
+	}
+}
+
+// ToModel converts the request into a channel configuration.
+// Enabled defaults to true when omitted.
+func (c *ChannelConfig) ToModel() *model.ChannelConfig {
+	enabled := true
+	if c.Enabled != nil {
+		enabled = *c.Enabled
+	}
+
+	return &model.ChannelConfig{
+		ChannelID:             c.ChannelID,
+		AutoTranslate:         c.AutoTranslate,
+		SourceLanguages:       model.LanguageList(c.SourceLanguages),
+		TargetLanguage:        c.TargetLanguage,
+		Enabled:               enabled,
+		Canary:                c.Canary,
+		Debug:                 c.Debug,
+		ReviewChannelID:       c.ReviewChannelID,
+		DigestIntervalMinutes: c.DigestIntervalMinutes,
+		DigestMaxMessages:     c.DigestMaxMessages,
+		DeliveryMode:          c.DeliveryMode,
+		Generation:            c.Generation,
+	}
+}
+
+// BulkChannelConfig applies one configuration template to many channels
+type BulkChannelConfig struct {
+	Template    ChannelConfig `json:"template"`
+	ChannelIDs  []string      `json:"channel_ids"`
+	NamePattern string        `json:"name_pattern"`
+	DryRun      bool          `json:"dry_run"`
+}
+
+// Validate validates the bulk channel configuration request
+func (b *BulkChannelConfig) Validate() *dto.Validator {
+	v := dto.NewValidator()
+
+	if len(b.ChannelIDs) == 0 && b.NamePattern == "" {
+		v.Add("channel_ids", "channel_ids or name_pattern is required")
+	}
+
+	if b.NamePattern != "" {
+		if _, err := path.Match(b.NamePattern, ""); err != nil {
+			v.Add("name_pattern", "name_pattern is not a valid glob pattern")
+		}
+	}
+
+	b.Template.validateSettings(v, "template.")
+
+	return v
+}
+
+// Selector returns the channel selection described by the request
+func (b *BulkChannelConfig) Selector() model.ChannelSelector {
+	return model.ChannelSelector{
+		ChannelIDs:  b.ChannelIDs,
+		NamePattern: b.NamePattern,
+	}
+}
@@ -0,0 +1,106 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelConfigValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		req            ChannelConfig
+		expectValid    bool
+		expectedFields []string
+	}{
+		{
+			name: "valid request",
+			req: ChannelConfig{
+				ChannelID:       "C123",
+				SourceLanguages: []string{"en", "vi"},
+				TargetLanguage:  "vi",
+			},
+			expectValid: true,
+		},
+		{
+			name: "empty source languages allowed",
+			req: ChannelConfig{
+				ChannelID:      "C123",
+				TargetLanguage: "en",
+			},
+			expectValid: true,
+		},
+		{
+			name: "missing channel and target",
+			req: ChannelConfig{
+				SourceLanguages: []string{"en"},
+			},
+			expectValid:    false,
+			expectedFields: []string{"channel_id", "target_language"},
+		},
+		{
+			name: "unknown codes",
+			req: ChannelConfig{
+				ChannelID:       "C123",
+				SourceLanguages: []string{"en", "English"},
+				TargetLanguage:  "xx",
+			},
+			expectValid:    false,
+			expectedFields: []string{"target_language", "source_languages[1]"},
+		},
+		{
+			name: "duplicate source language",
+			req: ChannelConfig{
+				ChannelID:       "C123",
+				SourceLanguages: []string{"en", "en"},
+				TargetLanguage:  "vi",
+			},
+			expectValid:    false,
+			expectedFields: []string{"source_languages[1]"},
+		},
+		{
+			name: "ephemeral delivery",
+			req: ChannelConfig{
+				ChannelID:      "C123",
+				TargetLanguage: "vi",
+				DeliveryMode:   "ephemeral",
+			},
+			expectValid: true,
+		},
+		{
+			name: "unknown delivery mode",
+			req: ChannelConfig{
+				ChannelID:      "C123",
+				TargetLanguage: "vi",
+				DeliveryMode:   "email",
+			},
+			expectValid:    false,
+			expectedFields: []string{"delivery_mode"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.req.Validate()
+
+			assert.Equal(t, tt.expectValid, v.Valid())
+			fields := []string{}
+			for _, e := range v.Errors() {
+				fields = append(fields, e.Field)
+			}
+			if len(tt.expectedFields) > 0 {
+				assert.Equal(t, tt.expectedFields, fields)
+			}
+		})
+	}
+}
+
+func TestChannelConfigToModelDefaultsEnabled(t *testing.T) {
+	disabled := false
+
+	enabledConfig := (&ChannelConfig{ChannelID: "C1", TargetLanguage: "vi"}).ToModel()
+	disabledConfig := (&ChannelConfig{ChannelID: "C2", TargetLanguage: "vi", Enabled: &disabled}).ToModel()
+
+	assert.True(t, enabledConfig.Enabled)
+	assert.False(t, disabledConfig.Enabled)
+}
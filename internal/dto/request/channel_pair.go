@@ -0,0 +1,35 @@
+package request
+
+import (
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+type ChannelPair struct {
+	ChannelID       string `json:"channel_id"`
+	PairedChannelID string `json:"paired_channel_id"`
+}
+
+// Validate validates the channel pair request
+func (p *ChannelPair) Validate() *dto.Validator {
+	v := dto.NewValidator()
+
+	if p.ChannelID == "" {
+		v.Add("channel_id", "channel_id is required")
+	}
+	if p.PairedChannelID == "" {
+		v.Add("paired_channel_id", "paired_channel_id is required")
+	} else if p.PairedChannelID == p.ChannelID {
+		v.Add("paired_channel_id", "a channel cannot be paired with itself")
+	}
+
+	return v
+}
+
+// ToModel converts the request into a channel pair
+func (p *ChannelPair) ToModel() *model.ChannelPair {
+	return &model.ChannelPair{
+		ChannelID:       p.ChannelID,
+		PairedChannelID: p.PairedChannelID,
+	}
+}
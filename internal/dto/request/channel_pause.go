@@ -0,0 +1,31 @@
+package request
+
+import (
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto"
+)
+
+// maxPauseMinutes matches the longest pause the channel pause service accepts (30 days)
+const maxPauseMinutes = 30 * 24 * 60
+
+type ChannelPause struct {
+	// DurationMinutes is how long translation stays paused; 0 pauses it until resumed
+	DurationMinutes int `json:"duration_minutes"`
+}
+
+// Validate validates the channel pause request
+func (p *ChannelPause) Validate() *dto.Validator {
+	v := dto.NewValidator()
+
+	if p.DurationMinutes < 0 || p.DurationMinutes > maxPauseMinutes {
+		v.Add("duration_minutes", "duration_minutes must be between 0 and 43200")
+	}
+
+	return v
+}
+
+// Duration returns the requested pause duration
+func (p *ChannelPause) Duration() time.Duration {
+	return time.Duration(p.DurationMinutes) * time.Minute
+}
@@ -0,0 +1,29 @@
+package request
+
+import (
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto"
+)
+
+type ComplianceExport struct {
+	// TeamID limits the export to a workspace; empty exports all of them
+	TeamID string `json:"team_id"`
+	// From and To are RFC 3339 timestamps; From is inclusive and To exclusive
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// Validate validates the compliance export request
+func (e *ComplianceExport) Validate() *dto.Validator {
+	v := dto.NewValidator()
+
+	if e.From.IsZero() {
+		v.Add("from", "from is required")
+	}
+	if e.To.IsZero() {
+		v.Add("to", "to is required")
+	}
+
+	return v
+}
@@ -0,0 +1,80 @@
+package request
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+type FilterRule struct {
+	ChannelID string   `json:"channel_id"`
+	Type      string   `json:"type"`
+	Pattern   string   `json:"pattern"`
+	Values    []string `json:"values"`
+	Enabled   *bool    `json:"enabled,omitempty"`
+}
+
+// Validate validates the filter rule request
+func (f *FilterRule) Validate() *dto.Validator {
+	v := dto.NewValidator()
+
+	if f.ChannelID == "" {
+		v.Add("channel_id", "channel_id is required")
+	}
+
+	f.validateRule(v)
+
+	return v
+}
+
+// validateRule validates the rule definition independent of its channel
+func (f *FilterRule) validateRule(v *dto.Validator) {
+	switch model.FilterRuleType(f.Type) {
+	case model.FilterRuleSkipRegex:
+		if f.Pattern == "" {
+			v.Add("pattern", "pattern is required for skip_regex rules")
+		} else if _, err := regexp.Compile(f.Pattern); err != nil {
+			v.Add("pattern", fmt.Sprintf("invalid regular expression: %v", err))
+		}
+	case model.FilterRuleAllowUsers, model.FilterRuleAllowUserGroups:
+		if len(f.Values) == 0 {
+			v.Add("values", fmt.Sprintf("values are required for %s rules", f.Type))
+		}
+	case model.FilterRuleRequireMention:
+	case "":
+		v.Add("type", "type is required")
+	default:
+		v.Add("type", fmt.Sprintf("unknown rule type %q", f.Type))
+	}
+}
+
+// FilterRuleUpdate is the body of a filter rule update; the channel cannot change
+type FilterRuleUpdate struct {
+	FilterRule
+}
+
+// Validate validates the filter rule update request
+func (f *FilterRuleUpdate) Validate() *dto.Validator {
+	v := dto.NewValidator()
+	f.validateRule(v)
+	return v
+}
+
+// ToModel converts the request into a filter rule.
+// Enabled defaults to true when omitted.
+func (f *FilterRule) ToModel() *model.FilterRule {
+	enabled := true
+	if f.Enabled != nil {
+		enabled = *f.Enabled
+	}
+
+	return &model.FilterRule{
+		ChannelID: f.ChannelID,
+		Type:      model.FilterRuleType(f.Type),
+		Pattern:   f.Pattern,
+		Values:    model.StringList(f.Values),
+		Enabled:   enabled,
+	}
+}
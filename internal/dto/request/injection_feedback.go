@@ -0,0 +1,27 @@
+package request
+
+import "github.com/ntttrang/go-genai-slack-assistant/internal/dto"
+
+// InjectionFeedback reports the review of a message flagged by injection detection
+type InjectionFeedback struct {
+	Text          string `json:"text"`
+	FalsePositive bool   `json:"false_positive"`
+	AllowPhrase   string `json:"allow_phrase"`
+}
+
+// Validate validates the injection feedback request
+func (f *InjectionFeedback) Validate() *dto.Validator {
+	v := dto.NewValidator()
+
+	if f.Text == "" {
+		v.Add("text", "text is required")
+	}
+	if f.AllowPhrase != "" && !f.FalsePositive {
+		v.Add("allow_phrase", "allow_phrase is only accepted for false positives")
+	}
+	if len([]rune(f.AllowPhrase)) > 200 {
+		v.Add("allow_phrase", "allow_phrase must be at most 200 characters")
+	}
+
+	return v
+}
@@ -0,0 +1,22 @@
+package request
+
+import "github.com/ntttrang/go-genai-slack-assistant/internal/dto"
+
+// maxMaintenanceNoticeLength keeps the notice well within a Slack message
+const maxMaintenanceNoticeLength = 1000
+
+type Maintenance struct {
+	// Notice replaces the configured maintenance notice; empty keeps it
+	Notice string `json:"notice"`
+}
+
+// Validate validates the maintenance request
+func (m *Maintenance) Validate() *dto.Validator {
+	v := dto.NewValidator()
+
+	if len([]rune(m.Notice)) > maxMaintenanceNoticeLength {
+		v.Add("notice", "notice must be at most 1000 characters")
+	}
+
+	return v
+}
@@ -0,0 +1,18 @@
+package request
+
+import "github.com/ntttrang/go-genai-slack-assistant/internal/dto"
+
+type PromptActivation struct {
+	Version string `json:"version"`
+}
+
+// Validate validates the prompt activation request
+func (p *PromptActivation) Validate() *dto.Validator {
+	v := dto.NewValidator()
+
+	if p.Version == "" {
+		v.Add("version", "version is required")
+	}
+
+	return v
+}
@@ -0,0 +1,38 @@
+package request
+
+import (
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+type WorkspaceSigningSecret struct {
+	// SigningSecret of the workspace's own copy of the app; empty restores SLACK_SIGNING_SECRET
+	SigningSecret string `json:"signing_secret"`
+}
+
+// Validate validates the workspace signing secret request
+func (w *WorkspaceSigningSecret) Validate() *dto.Validator {
+	v := dto.NewValidator()
+
+	if len(w.SigningSecret) > 128 {
+		v.Add("signing_secret", "signing_secret must be at most 128 characters")
+	}
+
+	return v
+}
+
+type WorkspaceGeneration struct {
+	// Generation overrides the AI generation parameters; omitted ones keep the defaults
+	Generation model.GenerationSettings `json:"generation"`
+}
+
+// Validate validates the workspace generation request
+func (w *WorkspaceGeneration) Validate() *dto.Validator {
+	v := dto.NewValidator()
+
+	if err := w.Generation.Validate(); err != nil {
+		v.Add("generation", err.Error())
+	}
+
+	return v
+}
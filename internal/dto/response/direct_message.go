@@ -0,0 +1,8 @@
+package response
+
+// DirectMessage is the assistant's reply to a direct message: the
+// translation of the message, or a notice when there was nothing to translate
+type DirectMessage struct {
+	Notice      string       `json:"notice,omitempty"`
+	Translation *Translation `json:"translation,omitempty"`
+}
@@ -0,0 +1,8 @@
+package response
+
+// Explanation explains the idioms, slang and cultural references of a
+// message in the language of the user who asked
+type Explanation struct {
+	Language string `json:"language"`
+	Text     string `json:"text"`
+}
@@ -0,0 +1,15 @@
+package response
+
+// ScalingSignals are the load figures an autoscaler scales the workers on,
+// e.g. through a KEDA metrics-api scaler
+type ScalingSignals struct {
+	// Backlog counts the events not processed yet, of every queue
+	Backlog        int64            `json:"backlog"`
+	BacklogByQueue map[string]int64 `json:"backlog_by_queue"`
+	// AvgProcessingMS is the average reply latency of the last minutes, over
+	// Replies replies posted by the instance answering
+	AvgProcessingMS float64 `json:"avg_processing_ms"`
+	Replies         int64   `json:"replies"`
+	// ProviderSaturation is the share of AI providers refusing calls, from 0 to 1
+	ProviderSaturation float64 `json:"provider_saturation"`
+}
@@ -0,0 +1,18 @@
+package response
+
+// ThreadSummary summarizes a thread in its own language and, when the
+// channel translates it, in the language it is translated to
+type ThreadSummary struct {
+	ChannelID string `json:"channel_id"`
+	ThreadTS  string `json:"thread_ts"`
+	// Messages is the number of messages summarized, the oldest ones being
+	// left out of long threads
+	Messages  int       `json:"messages"`
+	Summaries []Summary `json:"summaries"`
+}
+
+// Summary is the summary of a thread in one language
+type Summary struct {
+	Language string `json:"language"`
+	Text     string `json:"text"`
+}
@@ -1,8 +1,39 @@
 package response
 
+import "github.com/ntttrang/go-genai-slack-assistant/internal/model"
+
+// Where a translation was served from
+const (
+	SourceCache    = "cache"
+	SourceDatabase = "database"
+	SourceAI       = "ai"
+)
+
+// Translation is a translated text, with metadata on how it was produced
 type Translation struct {
-	OriginalText   string
-	TranslatedText string
-	SourceLanguage string
-	TargetLanguage string
+	// ID identifies the stored translation. It is empty when the result came
+	// from the cache or was not stored, e.g. while the database is read-only.
+	ID             string `json:"id,omitempty"`
+	OriginalText   string `json:"original_text"`
+	TranslatedText string `json:"translated_text"`
+	SourceLanguage string `json:"source_language"`
+	TargetLanguage string `json:"target_language"`
+	// Provider and Model served the translation; Provider is empty for cache
+	// hits, and Model and PromptVersion for anything not translated by this call
+	Provider      string `json:"provider,omitempty"`
+	Model         string `json:"model,omitempty"`
+	PromptVersion string `json:"prompt_version,omitempty"`
+	// Generation holds the parameters the AI was called with, when known
+	Generation model.GenerationSettings `json:"generation,omitzero"`
+	// Source is SourceCache, SourceDatabase or SourceAI; Cached is set for
+	// anything not translated by the AI
+	Source    string `json:"source"`
+	Cached    bool   `json:"cached"`
+	LatencyMS int64  `json:"latency_ms"`
+	// PromptTokens and OutputTokens are the tokens used by the AI call; zero when cached
+	PromptTokens int64 `json:"prompt_tokens"`
+	OutputTokens int64 `json:"output_tokens"`
+	// Confidence is a heuristic between 0 and 1: 1 when the input and output
+	// security checks raised no warning, lower for each warning
+	Confidence float64 `json:"confidence"`
 }
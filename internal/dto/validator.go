@@ -2,8 +2,8 @@ package dto
 
 // ValidationError represents a single field validation error
 type ValidationError struct {
-	Field   string
-	Message string
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 // Validator accumulates validation errors
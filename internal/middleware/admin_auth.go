@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+// Gin context keys holding the authenticated admin identity
+const (
+	adminRoleKey  = "admin_role"
+	adminActorKey = "admin_actor"
+)
+
+// RoleResolver maps a bearer credential to an admin role.
+// An OIDC integration can implement it by mapping a verified token claim.
+type RoleResolver interface {
+	ResolveRole(credential string) (model.AdminRole, bool)
+}
+
+// APIKeyRoles resolves roles from statically configured API keys
+type APIKeyRoles map[string]model.AdminRole
+
+// ResolveRole compares the credential against every key in constant time
+func (k APIKeyRoles) ResolveRole(credential string) (model.AdminRole, bool) {
+	var role model.AdminRole
+	found := false
+	for key, keyRole := range k {
+		if key != "" && subtle.ConstantTimeCompare([]byte(credential), []byte(key)) == 1 {
+			role, found = keyRole, true
+		}
+	}
+	return role, found
+}
+
+// AuthenticateAdminGin is a Gin middleware that resolves the bearer credential
+// to an admin role and rejects unknown credentials.
+func AuthenticateAdminGin(resolver RoleResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provided := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		role, ok := resolver.ResolveRole(provided)
+		if provided == "" || !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+		c.Set(adminRoleKey, role)
+		c.Set(adminActorKey, actorName(role, provided))
+		c.Next()
+	}
+}
+
+// RequireRoleGin is a Gin middleware that only lets through callers whose
+// role includes required. It must run after AuthenticateAdminGin.
+func RequireRoleGin(required model.AdminRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := c.Get(adminRoleKey)
+		adminRole, _ := role.(model.AdminRole)
+		if !adminRole.Allows(required) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// AdminActor returns the identity of the authenticated admin caller, for audit records
+func AdminActor(c *gin.Context) string {
+	return c.GetString(adminActorKey)
+}
+
+// actorName identifies a caller by role and a short key fingerprint,
+// so audit records never contain the key itself
+func actorName(role model.AdminRole, credential string) string {
+	sum := sha256.Sum256([]byte(credential))
+	return fmt.Sprintf("%s:%s", role, hex.EncodeToString(sum[:4]))
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupAdminRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	keys := APIKeyRoles{
+		"viewer-key":   model.RoleViewer,
+		"operator-key": model.RoleOperator,
+		"admin-key":    model.RoleAdmin,
+	}
+
+	ok := func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"ok": true}) }
+
+	r := gin.New()
+	admin := r.Group("/admin", AuthenticateAdminGin(keys))
+	admin.GET("/channels", RequireRoleGin(model.RoleViewer), ok)
+	admin.POST("/channels", RequireRoleGin(model.RoleOperator), ok)
+	admin.DELETE("/channels/:channel_id", RequireRoleGin(model.RoleAdmin), ok)
+	admin.POST("/queues/:key/pause", RequireRoleGin(model.RoleOperator), ok)
+	admin.POST("/queues/:key/flush", RequireRoleGin(model.RoleAdmin), ok)
+	return r
+}
+
+func TestAdminRoleEnforcement(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		token          string
+		expectedStatus int
+	}{
+		{name: "missing token", method: http.MethodGet, path: "/admin/channels", token: "", expectedStatus: http.StatusUnauthorized},
+		{name: "unknown token", method: http.MethodGet, path: "/admin/channels", token: "nope", expectedStatus: http.StatusUnauthorized},
+		{name: "viewer reads", method: http.MethodGet, path: "/admin/channels", token: "viewer-key", expectedStatus: http.StatusOK},
+		{name: "viewer cannot modify", method: http.MethodPost, path: "/admin/channels", token: "viewer-key", expectedStatus: http.StatusForbidden},
+		{name: "operator modifies", method: http.MethodPost, path: "/admin/channels", token: "operator-key", expectedStatus: http.StatusOK},
+		{name: "operator cannot delete", method: http.MethodDelete, path: "/admin/channels/C1", token: "operator-key", expectedStatus: http.StatusForbidden},
+		{name: "admin deletes", method: http.MethodDelete, path: "/admin/channels/C1", token: "admin-key", expectedStatus: http.StatusOK},
+		{name: "operator pauses a queue", method: http.MethodPost, path: "/admin/queues/slack/pause", token: "operator-key", expectedStatus: http.StatusOK},
+		{name: "operator cannot flush a queue", method: http.MethodPost, path: "/admin/queues/slack/flush", token: "operator-key", expectedStatus: http.StatusForbidden},
+		{name: "admin flushes a queue", method: http.MethodPost, path: "/admin/queues/slack/flush", token: "admin-key", expectedStatus: http.StatusOK},
+		{name: "admin reads", method: http.MethodGet, path: "/admin/channels", token: "admin-key", expectedStatus: http.StatusOK},
+	}
+
+	router := setupAdminRouter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func TestRequireRoleGin_WithoutAuthentication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/admin/channels", RequireRoleGin(model.RoleViewer), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/channels", nil))
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestParseAdminRole(t *testing.T) {
+	role, err := model.ParseAdminRole("operator")
+	assert.NoError(t, err)
+	assert.True(t, role.Allows(model.RoleViewer))
+	assert.False(t, role.Allows(model.RoleAdmin))
+
+	_, err = model.ParseAdminRole("root")
+	assert.Error(t, err)
+}
@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// PanicEvent is the event sent to the error reporter when a request panics
+const PanicEvent = "http.panic"
+
+// panicReportTimeout bounds how long reporting a panic may take
+const panicReportTimeout = 10 * time.Second
+
+// ErrorReporter receives panics recovered while serving requests, e.g. the
+// admin webhook client
+type ErrorReporter interface {
+	Send(ctx context.Context, event string, data interface{}) error
+}
+
+// RecoveryGin is a Gin middleware that recovers from panics in handlers,
+// logs them through zap with the request context and stack trace, reports
+// them to reporter and answers 500. reporter may be nil.
+func RecoveryGin(logger *zap.Logger, reporter ErrorReporter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			// A client that went away is not a server error; there is no one to answer
+			if isBrokenPipe(recovered) {
+				logger.Warn("Client connection closed while writing response",
+					zap.String("method", c.Request.Method),
+					zap.String("path", c.Request.URL.Path),
+					zap.Any("error", recovered))
+				c.Abort()
+				return
+			}
+
+			stack := string(debug.Stack())
+			logger.Error("Recovered from panic while serving request",
+				zap.Any("panic", recovered),
+				zap.String("method", c.Request.Method),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("route", c.FullPath()),
+				zap.String("client_ip", c.ClientIP()),
+				zap.String("stack", stack))
+
+			if reporter != nil {
+				report := map[string]interface{}{
+					"panic":     fmt.Sprint(recovered),
+					"method":    c.Request.Method,
+					"path":      c.Request.URL.Path,
+					"route":     c.FullPath(),
+					"client_ip": c.ClientIP(),
+					"stack":     stack,
+				}
+				go func() {
+					ctx, cancel := context.WithTimeout(context.Background(), panicReportTimeout)
+					defer cancel()
+					if err := reporter.Send(ctx, PanicEvent, report); err != nil {
+						logger.Warn("Failed to report panic", zap.Error(err))
+					}
+				}()
+			}
+
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		}()
+		c.Next()
+	}
+}
+
+// RequestLoggerGin is a Gin middleware that logs every request through zap,
+// in place of Gin's own stdout logger
+func RequestLoggerGin(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		fields := []zap.Field{
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.Int("status", c.Writer.Status()),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("client_ip", c.ClientIP()),
+		}
+		if len(c.Errors) > 0 {
+			fields = append(fields, zap.String("errors", c.Errors.String()))
+		}
+		if c.Writer.Status() >= http.StatusInternalServerError {
+			logger.Error("Request failed", fields...)
+			return
+		}
+		logger.Info("Request handled", fields...)
+	}
+}
+
+// GinMode returns the Gin mode for an application environment: release in
+// production and staging, test under tests and debug otherwise
+func GinMode(environment string) string {
+	switch strings.ToLower(environment) {
+	case "production", "prod", "staging":
+		return gin.ReleaseMode
+	case "test":
+		return gin.TestMode
+	}
+	return gin.DebugMode
+}
+
+// isBrokenPipe reports whether a panic was caused by the client closing the connection
+func isBrokenPipe(recovered interface{}) bool {
+	err, ok := recovered.(error)
+	if !ok {
+		return false
+	}
+	if errors.Is(err, http.ErrAbortHandler) || errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		var syscallErr *os.SyscallError
+		if errors.As(opErr.Err, &syscallErr) {
+			message := strings.ToLower(syscallErr.Error())
+			return strings.Contains(message, "broken pipe") || strings.Contains(message, "connection reset by peer")
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type recordingReporter struct {
+	events chan map[string]interface{}
+}
+
+func (r *recordingReporter) Send(ctx context.Context, event string, data interface{}) error {
+	if event == PanicEvent {
+		r.events <- data.(map[string]interface{})
+	}
+	return nil
+}
+
+func TestRecoveryGin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	core, logs := observer.New(zap.InfoLevel)
+	reporter := &recordingReporter{events: make(chan map[string]interface{}, 1)}
+
+	r := gin.New()
+	r.Use(RecoveryGin(zap.New(core), reporter))
+	r.GET("/channels/:id", func(c *gin.Context) {
+		panic("nil channel config")
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/channels/C123", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.JSONEq(t, `{"error":"Internal server error"}`, rec.Body.String())
+
+	entries := logs.FilterMessage("Recovered from panic while serving request").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "/channels/C123", entries[0].ContextMap()["path"])
+	assert.Equal(t, "/channels/:id", entries[0].ContextMap()["route"])
+
+	select {
+	case report := <-reporter.events:
+		assert.Equal(t, "nil channel config", report["panic"])
+		assert.Equal(t, http.MethodGet, report["method"])
+		assert.Equal(t, "/channels/:id", report["route"])
+		assert.Contains(t, report["stack"], "recovery.go")
+	case <-time.After(time.Second):
+		t.Fatal("expected the panic to be reported")
+	}
+}
+
+func TestRecoveryGin_BrokenPipe(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	reporter := &recordingReporter{events: make(chan map[string]interface{}, 1)}
+	r := gin.New()
+	r.Use(RecoveryGin(zap.NewNop(), reporter))
+	r.GET("/metrics", func(c *gin.Context) {
+		panic(fmt.Errorf("write response: %w", syscall.EPIPE))
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Empty(t, rec.Body.String())
+	assert.Empty(t, reporter.events)
+}
+
+func TestGinMode(t *testing.T) {
+	tests := []struct {
+		environment string
+		expected    string
+	}{
+		{environment: "production", expected: gin.ReleaseMode},
+		{environment: "Staging", expected: gin.ReleaseMode},
+		{environment: "test", expected: gin.TestMode},
+		{environment: "development", expected: gin.DebugMode},
+		{environment: "", expected: gin.DebugMode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.environment, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GinMode(tt.environment))
+		})
+	}
+}
@@ -3,16 +3,26 @@ package middleware
 import (
 	"fmt"
 
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/language"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
 	"github.com/ntttrang/go-genai-slack-assistant/pkg/security"
 	"go.uber.org/zap"
 )
 
 type SecurityMiddleware struct {
-	inputValidator   *security.InputValidator
-	outputValidator  *security.OutputValidator
-	logger           *zap.Logger
-	blockHighThreat  bool
-	logSuspicious    bool
+	inputValidator  *security.InputValidator
+	outputValidator *security.OutputValidator
+	logger          *zap.Logger
+	blockHighThreat bool
+	logSuspicious   bool
+	shadowMode      bool
+	metrics         *metrics.Metrics
+	allowlist       AllowlistStore
+}
+
+// AllowlistStore persists the phrases added to the injection allowlist
+type AllowlistStore interface {
+	Add(phrase string) error
 }
 
 func NewSecurityMiddleware(
@@ -31,19 +41,57 @@ func NewSecurityMiddleware(
 	}
 }
 
-func (sm *SecurityMiddleware) ValidateInput(text string) (security.ValidationResult, error) {
+// SetShadowMode evaluates inputs without blocking them. Inputs that would be
+// blocked are logged and counted so the false-positive rate can be measured
+// before enforcement is turned on.
+func (sm *SecurityMiddleware) SetShadowMode(enabled bool) {
+	sm.shadowMode = enabled
+}
+
+// SetAllowlistStore persists the phrases allowed through ReviewInput, which
+// are otherwise lost on restart
+func (sm *SecurityMiddleware) SetAllowlistStore(store AllowlistStore) {
+	sm.allowlist = store
+}
+
+// SetMetrics records threat levels, patterns, verdicts and review feedback
+func (sm *SecurityMiddleware) SetMetrics(m *metrics.Metrics) {
+	sm.metrics = m
+}
+
+// ValidateInput checks a message posted in channelID for prompt injection.
+// Every result is counted by threat level, pattern and channel in the metrics.
+func (sm *SecurityMiddleware) ValidateInput(channelID, text string) (security.ValidationResult, error) {
 	result := sm.inputValidator.Validate(text)
+	if sm.metrics != nil {
+		sm.metrics.RecordInputThreat(channelID, result.ThreatLevel.String(), result.DetectedPatterns,
+			result.ThreatLevel >= security.ThreatLevelMedium)
+	}
+	wouldBlock := sm.blockHighThreat && result.ThreatLevel >= security.ThreatLevelHigh
 
 	if result.ThreatLevel >= security.ThreatLevelMedium && sm.logSuspicious {
 		sm.logger.Warn("Suspicious input detected",
+			zap.String("channel_id", channelID),
 			zap.String("text_preview", truncate(text, 50)),
 			zap.String("threat_level", result.ThreatLevel.String()),
 			zap.Strings("detected_patterns", result.DetectedPatterns),
 			zap.Strings("warnings", result.Warnings))
 	}
 
-	if sm.blockHighThreat && result.ThreatLevel >= security.ThreatLevelHigh {
+	if wouldBlock && sm.shadowMode {
+		sm.logger.Warn("High threat input would be blocked (shadow mode)",
+			zap.String("channel_id", channelID),
+			zap.String("text_preview", truncate(text, 50)),
+			zap.String("threat_level", result.ThreatLevel.String()),
+			zap.Strings("detected_patterns", result.DetectedPatterns))
+		sm.recordVerdict("shadow_blocked")
+		return result, nil
+	}
+
+	if wouldBlock {
+		sm.recordVerdict("blocked")
 		sm.logger.Error("High threat input blocked",
+			zap.String("channel_id", channelID),
 			zap.String("text_preview", truncate(text, 50)),
 			zap.String("threat_level", result.ThreatLevel.String()),
 			zap.Strings("detected_patterns", result.DetectedPatterns))
@@ -51,9 +99,55 @@ func (sm *SecurityMiddleware) ValidateInput(text string) (security.ValidationRes
 		return result, fmt.Errorf("input blocked due to security concerns: %s", result.ThreatLevel.String())
 	}
 
+	if result.ThreatLevel >= security.ThreatLevelMedium {
+		sm.recordVerdict("flagged")
+	} else {
+		sm.recordVerdict("clean")
+	}
 	return result, nil
 }
 
+// Sanitize trims the text, collapses whitespace and removes control characters
+func (sm *SecurityMiddleware) Sanitize(text string) string {
+	return sm.inputValidator.Sanitize(text)
+}
+
+// ReviewInput re-evaluates a reported message and records whether the
+// detection was a false positive. A non-empty allowPhrase is added to the
+// validator's allowlist so the same wording is no longer flagged, after it
+// was stored when an AllowlistStore is set.
+func (sm *SecurityMiddleware) ReviewInput(text string, falsePositive bool, allowPhrase string) (security.ValidationResult, error) {
+	result := sm.inputValidator.Validate(text)
+
+	if sm.metrics != nil {
+		sm.metrics.RecordInjectionFeedback(falsePositive)
+	}
+	if allowPhrase != "" {
+		if sm.allowlist != nil {
+			if err := sm.allowlist.Add(allowPhrase); err != nil {
+				return result, fmt.Errorf("failed to store allowlist phrase: %w", err)
+			}
+		}
+		sm.inputValidator.Allow(allowPhrase)
+		sm.logger.Info("Injection allowlist phrase added",
+			zap.String("phrase", allowPhrase),
+			zap.Strings("detected_patterns", result.DetectedPatterns))
+	}
+
+	return result, nil
+}
+
+// Allowlist returns the phrases ignored by injection detection
+func (sm *SecurityMiddleware) Allowlist() []string {
+	return sm.inputValidator.Allowlist()
+}
+
+func (sm *SecurityMiddleware) recordVerdict(verdict string) {
+	if sm.metrics != nil {
+		sm.metrics.RecordInjectionVerdict(verdict)
+	}
+}
+
 func (sm *SecurityMiddleware) ValidateOutput(output, originalInput string) (security.OutputValidationResult, error) {
 	result := sm.outputValidator.ValidateTranslation(output, originalInput)
 
@@ -69,8 +163,9 @@ func (sm *SecurityMiddleware) ValidateOutput(output, originalInput string) (secu
 }
 
 func truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
+	truncated := language.Truncate(s, maxLen)
+	if truncated == s {
 		return s
 	}
-	return s[:maxLen] + "..."
+	return truncated + "..."
 }
@@ -4,9 +4,11 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -63,8 +65,62 @@ func VerifySlackSignature(signingSecret string) func(http.Handler) http.Handler
 	}
 }
 
+// SigningSecretLookup returns the signing secret of a workspace running its
+// own copy of the app, or "" when its requests are signed with the default one
+type SigningSecretLookup interface {
+	SigningSecret(teamID string) string
+}
+
 // VerifySlackSignatureGin is a Gin middleware for verifying Slack request signatures
 func VerifySlackSignatureGin(signingSecret string) gin.HandlerFunc {
+	return verifySlackSignatureGin(func([]byte) string {
+		return signingSecret
+	})
+}
+
+// VerifySlackWorkspaceSignatureGin verifies Slack request signatures with the
+// signing secret of the workspace the request comes from, falling back to
+// defaultSecret. The workspace is read from the unverified body only to pick
+// the secret; a forged team ID still fails verification. Since any request
+// triggers a lookup, it must be cheap and bounded, as WorkspaceUseCase is.
+func VerifySlackWorkspaceSignatureGin(defaultSecret string, lookup SigningSecretLookup) gin.HandlerFunc {
+	return verifySlackSignatureGin(func(body []byte) string {
+		if secret := lookup.SigningSecret(requestTeamID(body)); secret != "" {
+			return secret
+		}
+		return defaultSecret
+	})
+}
+
+// requestTeamID returns the workspace of a Slack request: team_id of an
+// Events API payload or slash command, or team.id of an interaction payload
+func requestTeamID(body []byte) string {
+	var event struct {
+		TeamID string `json:"team_id"`
+	}
+	if err := json.Unmarshal(body, &event); err == nil {
+		return event.TeamID
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return ""
+	}
+	if teamID := form.Get("team_id"); teamID != "" {
+		return teamID
+	}
+	var interaction struct {
+		Team struct {
+			ID string `json:"id"`
+		} `json:"team"`
+	}
+	if err := json.Unmarshal([]byte(form.Get("payload")), &interaction); err == nil {
+		return interaction.Team.ID
+	}
+	return ""
+}
+
+func verifySlackSignatureGin(secretFor func(body []byte) string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		timestamp := c.GetHeader("X-Slack-Request-Timestamp")
 		signature := c.GetHeader("X-Slack-Signature")
@@ -100,7 +156,7 @@ func VerifySlackSignatureGin(signingSecret string) gin.HandlerFunc {
 
 		// Verify signature
 		baseString := fmt.Sprintf("v0:%s:%s", timestamp, string(bodyBytes))
-		hash := hmac.New(sha256.New, []byte(signingSecret))
+		hash := hmac.New(sha256.New, []byte(secretFor(bodyBytes)))
 		hash.Write([]byte(baseString))
 		expectedSig := "v0=" + hex.EncodeToString(hash.Sum(nil))
 
@@ -238,3 +238,77 @@ func TestVerifySlackSignatureGin_ValidSignatureMultipleTimes(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 	}
 }
+
+type fakeSigningSecrets map[string]string
+
+func (f fakeSigningSecrets) SigningSecret(teamID string) string {
+	return f[teamID]
+}
+
+func TestVerifySlackWorkspaceSignatureGin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	sign := func(secret, timestamp, body string) string {
+		hash := hmac.New(sha256.New, []byte(secret))
+		hash.Write([]byte(fmt.Sprintf("v0:%s:%s", timestamp, body)))
+		return "v0=" + hex.EncodeToString(hash.Sum(nil))
+	}
+
+	tests := []struct {
+		name         string
+		body         string
+		secret       string
+		expectedCode int
+	}{
+		{
+			name:         "event from a workspace with its own secret",
+			body:         `{"type":"event_callback","team_id":"T1"}`,
+			secret:       "team-secret",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "event from a workspace with its own secret signed with the default one",
+			body:         `{"type":"event_callback","team_id":"T1"}`,
+			secret:       "default-secret",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "event from another workspace",
+			body:         `{"type":"event_callback","team_id":"T2"}`,
+			secret:       "default-secret",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "slash command from a workspace with its own secret",
+			body:         "command=%2Ftranslate-pause&team_id=T1",
+			secret:       "team-secret",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "interaction from a workspace with its own secret",
+			body:         "payload=" + `%7B%22team%22%3A%7B%22id%22%3A%22T1%22%7D%7D`,
+			secret:       "team-secret",
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(VerifySlackWorkspaceSignatureGin("default-secret", fakeSigningSecrets{"T1": "team-secret"}))
+			r.POST("/slack/events", func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"ok": true})
+			})
+
+			timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+			req := httptest.NewRequest("POST", "/slack/events", bytes.NewBufferString(tt.body))
+			req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+			req.Header.Set("X-Slack-Signature", sign(tt.secret, timestamp, tt.body))
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// teamsServiceURLKey is the Gin context key holding the connector service URL
+// the Bot Framework token was issued for
+const teamsServiceURLKey = "teams_service_url"
+
+// BotTokenVerifier checks the Authorization header of a Bot Framework request
+// and returns the service URL claim of its token
+type BotTokenVerifier interface {
+	VerifyBotToken(ctx context.Context, authorization string) (string, error)
+}
+
+// VerifyTeamsTokenGin is a Gin middleware that rejects requests to the Teams
+// messaging endpoint that do not carry a valid Bot Framework token
+func VerifyTeamsTokenGin(verifier BotTokenVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		serviceURL, err := verifier.VerifyBotToken(c.Request.Context(), c.GetHeader("Authorization"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+		c.Set(teamsServiceURLKey, serviceURL)
+		c.Next()
+	}
+}
+
+// TeamsServiceURL returns the service URL claim of the verified Bot Framework token
+func TeamsServiceURL(c *gin.Context) string {
+	return c.GetString(teamsServiceURLKey)
+}
@@ -0,0 +1,37 @@
+package model
+
+import "fmt"
+
+// AdminRole grants access to a tier of the admin API. Roles are ordered:
+// each one includes every permission of the roles below it.
+type AdminRole string
+
+const (
+	// RoleViewer can read configuration and stats
+	RoleViewer AdminRole = "viewer"
+	// RoleOperator can also modify channel configurations and filter rules
+	RoleOperator AdminRole = "operator"
+	// RoleAdmin can also delete and purge data
+	RoleAdmin AdminRole = "admin"
+)
+
+var adminRoleRank = map[AdminRole]int{
+	RoleViewer:   1,
+	RoleOperator: 2,
+	RoleAdmin:    3,
+}
+
+// ParseAdminRole converts a configured role name into an AdminRole
+func ParseAdminRole(name string) (AdminRole, error) {
+	role := AdminRole(name)
+	if _, ok := adminRoleRank[role]; !ok {
+		return "", NewValidationError(fmt.Sprintf("unknown admin role: %q", name))
+	}
+	return role, nil
+}
+
+// Allows reports whether the role grants the permissions of required
+func (r AdminRole) Allows(required AdminRole) bool {
+	rank, ok := adminRoleRank[r]
+	return ok && rank >= adminRoleRank[required]
+}
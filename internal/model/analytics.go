@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// ChannelUsage counts the translations served in a channel for one language
+// pair on one day (UTC). Rows are incremented as translations are served.
+type ChannelUsage struct {
+	ChannelID      string    `json:"channel_id"`
+	Day            time.Time `json:"day"`
+	SourceLanguage string    `json:"source_language"`
+	TargetLanguage string    `json:"target_language"`
+	Translations   int64     `json:"translations"`
+	// CacheHits counts the translations served from the cache or the database
+	CacheHits    int64     `json:"cache_hits"`
+	PromptTokens int64     `json:"prompt_tokens"`
+	OutputTokens int64     `json:"output_tokens"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (ChannelUsage) TableName() string {
+	return "channel_usage"
+}
+
+// LanguagePairUsage counts the translations of one language pair
+type LanguagePairUsage struct {
+	SourceLanguage string `json:"source_language"`
+	TargetLanguage string `json:"target_language"`
+	Translations   int64  `json:"translations"`
+}
+
+// ChannelStats sums up a channel's usage since a day
+type ChannelStats struct {
+	ChannelID    string    `json:"channel_id"`
+	Since        time.Time `json:"since"`
+	Translations int64     `json:"translations"`
+	CacheHits    int64     `json:"cache_hits"`
+	CacheHitRate float64   `json:"cache_hit_rate"`
+	PromptTokens int64     `json:"prompt_tokens"`
+	OutputTokens int64     `json:"output_tokens"`
+	// Cost is the estimated cost of the tokens in USD, zero when token prices
+	// are not configured
+	Cost float64 `json:"cost"`
+	// TopPairs are the most translated language pairs, most translated first
+	TopPairs []LanguagePairUsage `json:"top_pairs"`
+}
@@ -0,0 +1,82 @@
+package model
+
+import "time"
+
+// Audit actions
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+	AuditActionBulk   = "bulk_apply"
+	// AuditActionCompromised records a translation rejected because the
+	// model leaked its prompt canary token
+	AuditActionCompromised = "compromised"
+	// Operations on a channel's message queue
+	AuditActionFlush  = "flush"
+	AuditActionPause  = "pause"
+	AuditActionResume = "resume"
+	// Global maintenance switch
+	AuditActionEnable  = "enable"
+	AuditActionDisable = "disable"
+	// AuditActionImport records a configuration bundle applied to the deployment
+	AuditActionImport = "import"
+	// AuditActionRollback records a switch back to the previous prompt version
+	AuditActionRollback = "rollback"
+)
+
+// AuditActorSystem is the actor of records written by the bot itself
+const AuditActorSystem = "system"
+
+// Audited resource types
+const (
+	AuditResourceChannelConfig      = "channel_config"
+	AuditResourceChannelPair        = "channel_pair"
+	AuditResourceFilterRule         = "filter_rule"
+	AuditResourceInjectionAllowlist = "injection_allowlist"
+	AuditResourceTranslation        = "translation"
+	AuditResourceQueue              = "queue"
+	AuditResourceMaintenance        = "maintenance"
+	AuditResourceWorkspace          = "workspace"
+	AuditResourceConfigBundle       = "config_bundle"
+	AuditResourcePromptVersion      = "prompt_version"
+	AuditResourceComplianceExport   = "compliance_export"
+)
+
+// AuditRecord is an immutable record of one admin mutation.
+// Before and After hold JSON snapshots of the resource; ChangedFields lists
+// the top-level fields whose values differ between them.
+type AuditRecord struct {
+	ID            string     `json:"id"`
+	Actor         string     `json:"actor"`
+	Action        string     `json:"action"`
+	ResourceType  string     `json:"resource_type"`
+	ResourceID    string     `json:"resource_id"`
+	Before        string     `json:"before,omitempty"`
+	After         string     `json:"after,omitempty"`
+	ChangedFields StringList `json:"changed_fields"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+func (AuditRecord) TableName() string {
+	return "audit_records"
+}
+
+// AuditEntry describes a mutation to record; Before and After are the
+// resource states (nil when the resource did not exist)
+type AuditEntry struct {
+	Actor        string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	Before       interface{}
+	After        interface{}
+}
+
+// AuditQuery filters audit records; empty fields match everything
+type AuditQuery struct {
+	Actor        string
+	ResourceType string
+	ResourceID   string
+	Since        time.Time
+	Limit        int
+}
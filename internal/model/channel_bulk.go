@@ -0,0 +1,33 @@
+package model
+
+// SlackChannel identifies a Slack conversation by ID and name
+type SlackChannel struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ChannelSelector picks the channels a bulk operation applies to.
+// Channels listed explicitly and channels whose name matches NamePattern
+// (shell glob, e.g. "proj-*") are combined and de-duplicated.
+type ChannelSelector struct {
+	ChannelIDs  []string
+	NamePattern string
+}
+
+const (
+	BulkActionCreate = "create"
+	BulkActionUpdate = "update"
+
+	BulkStatusPlanned = "planned"
+	BulkStatusApplied = "applied"
+	BulkStatusFailed  = "failed"
+)
+
+// BulkChannelResult reports the outcome of a bulk operation for one channel
+type BulkChannelResult struct {
+	ChannelID   string `json:"channel_id"`
+	ChannelName string `json:"channel_name,omitempty"`
+	Action      string `json:"action,omitempty"`
+	Status      string `json:"status"`
+	Error       string `json:"error,omitempty"`
+}
@@ -1,20 +1,135 @@
 package model
 
 import (
+	"database/sql/driver"
+	"fmt"
 	"time"
 )
 
 type ChannelConfig struct {
-	ID              string
-	ChannelID       string
-	AutoTranslate   bool
-	SourceLanguages string
-	TargetLanguage  string
-	Enabled         bool
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	ID              string       `json:"id"`
+	ChannelID       string       `json:"channel_id"`
+	AutoTranslate   bool         `json:"auto_translate"`
+	SourceLanguages LanguageList `json:"source_languages"`
+	TargetLanguage  string       `json:"target_language"`
+	Enabled         bool         `json:"enabled"`
+	Canary          bool         `json:"canary"`
+	Debug           bool         `json:"debug"` // replies show how each translation was produced
+	ReviewChannelID string       `json:"review_channel_id"`
+	// DigestIntervalMinutes and DigestMaxMessages batch translations into a
+	// digest posted every N minutes or M messages, whichever comes first
+	DigestIntervalMinutes int                `json:"digest_interval_minutes"`
+	DigestMaxMessages     int                `json:"digest_max_messages"`
+	DeliveryMode          string             `json:"delivery_mode"` // one of the DeliveryMode constants, thread when empty
+	Generation            GenerationSettings `json:"generation"`    // overrides the workspace's AI generation parameters
+	CreatedAt             time.Time          `json:"created_at"`
+	UpdatedAt             time.Time          `json:"updated_at"`
+}
+
+// Delivery modes of a channel's translations
+const (
+	// DeliveryModeThread replies in the thread of the translated message
+	DeliveryModeThread = "thread"
+	// DeliveryModeEphemeral shows the translation only to the message author,
+	// or to whoever asked for it with a reaction
+	DeliveryModeEphemeral = "ephemeral"
+	// DeliveryModeDM sends the translation to that user in a direct message
+	DeliveryModeDM = "dm"
+)
+
+// IsValidDeliveryMode reports whether mode is a delivery mode, empty meaning thread
+func IsValidDeliveryMode(mode string) bool {
+	switch mode {
+	case "", DeliveryModeThread, DeliveryModeEphemeral, DeliveryModeDM:
+		return true
+	}
+	return false
 }
 
 func (ChannelConfig) TableName() string {
 	return "channel_configs"
 }
+
+// Validate checks the configuration before it is persisted
+func (c *ChannelConfig) Validate() error {
+	if c.ChannelID == "" {
+		return NewValidationError("channel_id is required")
+	}
+	if c.TargetLanguage == "" {
+		return NewValidationError("target_language is required")
+	}
+	if !IsSupportedLanguageCode(c.TargetLanguage) {
+		return NewValidationError(fmt.Sprintf("unsupported target_language: %s", c.TargetLanguage))
+	}
+	if c.DigestIntervalMinutes < 0 {
+		return NewValidationError("digest_interval_minutes must not be negative")
+	}
+	if c.DigestMaxMessages < 0 {
+		return NewValidationError("digest_max_messages must not be negative")
+	}
+	if !IsValidDeliveryMode(c.DeliveryMode) {
+		return NewValidationError(fmt.Sprintf("unsupported delivery_mode: %s", c.DeliveryMode))
+	}
+	if err := c.Generation.Validate(); err != nil {
+		return err
+	}
+	return c.SourceLanguages.Validate()
+}
+
+// DigestEnabled reports whether translations are batched into digests instead of thread replies
+func (c *ChannelConfig) DigestEnabled() bool {
+	return c.DigestIntervalMinutes > 0 || c.DigestMaxMessages > 0
+}
+
+// PrivateDelivery reports whether translations are delivered only to one
+// user, ephemerally or in a direct message, instead of in the thread
+func (c *ChannelConfig) PrivateDelivery() bool {
+	return c.DeliveryMode == DeliveryModeEphemeral || c.DeliveryMode == DeliveryModeDM
+}
+
+// LanguageList is a list of language codes stored in a JSON column.
+// It implements sql.Scanner and driver.Valuer so GORM (de)serializes it
+// transparently instead of callers handling raw JSON strings.
+type LanguageList []string
+
+// Validate checks that every entry is a known, non-duplicated language code
+func (l LanguageList) Validate() error {
+	seen := make(map[string]bool, len(l))
+	for _, code := range l {
+		if !IsSupportedLanguageCode(code) {
+			return NewValidationError(fmt.Sprintf("unsupported source language: %q", code))
+		}
+		if seen[CanonicalLanguageCode(code)] {
+			return NewValidationError(fmt.Sprintf("duplicate source language: %q", code))
+		}
+		seen[CanonicalLanguageCode(code)] = true
+	}
+	return nil
+}
+
+// Contains reports whether code, or another code of the same language, e.g.
+// "zh" for "zh-Hans", is in the list
+func (l LanguageList) Contains(code string) bool {
+	code = CanonicalLanguageCode(code)
+	for _, c := range l {
+		if CanonicalLanguageCode(c) == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Value implements driver.Valuer
+func (l LanguageList) Value() (driver.Value, error) {
+	return jsonStringsValue(l)
+}
+
+// Scan implements sql.Scanner
+func (l *LanguageList) Scan(value interface{}) error {
+	codes, err := scanJSONStrings(value)
+	if err != nil {
+		return err
+	}
+	*l = LanguageList(codes)
+	return nil
+}
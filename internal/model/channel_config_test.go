@@ -0,0 +1,83 @@
+package model
+
+import "testing"
+
+func TestLanguageListRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected LanguageList
+		wantErr  bool
+	}{
+		{name: "json bytes", input: []byte(`["en","vi"]`), expected: LanguageList{"en", "vi"}},
+		{name: "json string", input: `["fr"]`, expected: LanguageList{"fr"}},
+		{name: "null column", input: nil, expected: LanguageList{}},
+		{name: "invalid json", input: `en,vi`, wantErr: true},
+		{name: "unsupported type", input: 42, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var list LanguageList
+			err := list.Scan(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(list) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, list)
+			}
+
+			value, err := list.Value()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var again LanguageList
+			if err := again.Scan(value); err != nil || len(again) != len(list) {
+				t.Errorf("round trip failed: %v (%v)", again, err)
+			}
+		})
+	}
+}
+
+func TestChannelConfigValidate(t *testing.T) {
+	creative, tooHot, noTopP := 1.2, 2.5, 0.0
+	flash, badModel := "gemini-2.5-flash", "gemini 2.5; drop"
+	tests := []struct {
+		name    string
+		config  ChannelConfig
+		wantErr bool
+	}{
+		{name: "valid", config: ChannelConfig{ChannelID: "C1", TargetLanguage: "vi", SourceLanguages: LanguageList{"en"}}},
+		{name: "missing target", config: ChannelConfig{ChannelID: "C1"}, wantErr: true},
+		{name: "unknown target", config: ChannelConfig{ChannelID: "C1", TargetLanguage: "Vietnamese"}, wantErr: true},
+		{name: "unknown source", config: ChannelConfig{ChannelID: "C1", TargetLanguage: "vi", SourceLanguages: LanguageList{"xx"}}, wantErr: true},
+		{name: "duplicate source", config: ChannelConfig{ChannelID: "C1", TargetLanguage: "vi", SourceLanguages: LanguageList{"en", "en"}}, wantErr: true},
+		{name: "dm delivery", config: ChannelConfig{ChannelID: "C1", TargetLanguage: "vi", DeliveryMode: DeliveryModeDM}},
+		{name: "unknown delivery", config: ChannelConfig{ChannelID: "C1", TargetLanguage: "vi", DeliveryMode: "email"}, wantErr: true},
+		{name: "generation override", config: ChannelConfig{ChannelID: "C1", TargetLanguage: "vi", Generation: GenerationSettings{Temperature: &creative}}},
+		{name: "temperature out of range", config: ChannelConfig{ChannelID: "C1", TargetLanguage: "vi", Generation: GenerationSettings{Temperature: &tooHot}}, wantErr: true},
+		{name: "top_p out of range", config: ChannelConfig{ChannelID: "C1", TargetLanguage: "vi", Generation: GenerationSettings{TopP: &noTopP}}, wantErr: true},
+		{name: "model override", config: ChannelConfig{ChannelID: "C1", TargetLanguage: "vi", Generation: GenerationSettings{Model: &flash}}},
+		{name: "invalid model name", config: ChannelConfig{ChannelID: "C1", TargetLanguage: "vi", Generation: GenerationSettings{Model: &badModel}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error=%v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				if domainErr, ok := err.(*DomainError); !ok || domainErr.Type != ErrorTypeValidation {
+					t.Errorf("expected validation error, got %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+// ChannelPair links two channels, e.g. #announce-en and #announce-vi. A message
+// in either channel is translated and cross-posted to the other one instead
+// of being answered in a thread.
+type ChannelPair struct {
+	ID              string    `json:"id"`
+	ChannelID       string    `json:"channel_id"`
+	PairedChannelID string    `json:"paired_channel_id"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+func (ChannelPair) TableName() string {
+	return "channel_pairs"
+}
+
+// Validate checks the pair before it is persisted
+func (p *ChannelPair) Validate() error {
+	if p.ChannelID == "" {
+		return NewValidationError("channel_id is required")
+	}
+	if p.PairedChannelID == "" {
+		return NewValidationError("paired_channel_id is required")
+	}
+	if p.ChannelID == p.PairedChannelID {
+		return NewValidationError("a channel cannot be paired with itself")
+	}
+	return nil
+}
+
+// Other returns the channel paired with channelID, or "" if channelID is not in the pair
+func (p *ChannelPair) Other(channelID string) string {
+	switch channelID {
+	case p.ChannelID:
+		return p.PairedChannelID
+	case p.PairedChannelID:
+		return p.ChannelID
+	}
+	return ""
+}
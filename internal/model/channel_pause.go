@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// ChannelPause records that translation is stopped in a channel, e.g. during
+// an incident. Messages sent while a channel is paused are not translated.
+// Until is nil when the pause lasts until the channel is resumed.
+type ChannelPause struct {
+	ChannelID string     `json:"channel_id"`
+	PausedBy  string     `json:"paused_by"`
+	PausedAt  time.Time  `json:"paused_at"`
+	Until     *time.Time `json:"until,omitempty"`
+}
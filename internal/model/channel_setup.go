@@ -0,0 +1,23 @@
+package model
+
+import "strings"
+
+// ChannelSetupBlockID holds the language buttons of the setup message the
+// bot posts in a channel it is invited to
+const ChannelSetupBlockID = "channel_setup"
+
+// channelSetupAction prefixes the action ID of a setup message's language
+// button, as action IDs must be unique within a block
+const channelSetupAction = "channel_setup_language:"
+
+// ChannelSetupActionID returns the action ID of the button picking the language code
+func ChannelSetupActionID(code string) string {
+	return channelSetupAction + code
+}
+
+// ChannelSetupLanguage returns the language code picked by the button of
+// actionID, or false when actionID is not a setup message's language button
+func ChannelSetupLanguage(actionID string) (string, bool) {
+	code, ok := strings.CutPrefix(actionID, channelSetupAction)
+	return code, ok && code != ""
+}
@@ -0,0 +1,30 @@
+package model
+
+// ChatMessage is an incoming message on any chat platform
+type ChatMessage struct {
+	ChannelID string
+	// ThreadID is the message or thread the reply is attached to
+	ThreadID  string
+	MessageID string
+	UserID    string
+	Text      string
+}
+
+// ChatUser is a user profile on any chat platform
+type ChatUser struct {
+	ID          string
+	Name        string
+	DisplayName string
+	AvatarURL   string
+}
+
+// ChatReply is a message posted in reply to a ChatMessage
+type ChatReply struct {
+	ChannelID string
+	ThreadID  string
+	Text      string
+	// Username and AvatarURL customize the sender where the platform allows it
+	Username  string
+	AvatarURL string
+	AsQuote   bool
+}
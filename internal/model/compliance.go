@@ -0,0 +1,61 @@
+package model
+
+import "time"
+
+// Actions of recorded bot posts
+const (
+	BotPostActionPost      = "post"
+	BotPostActionUpdate    = "update"
+	BotPostActionEphemeral = "ephemeral"
+	BotPostActionDelete    = "delete"
+)
+
+// BotPost is a message the bot posted, edited or deleted, recorded for
+// compliance exports. Blocks and Attachments are the JSON sent to Slack.
+type BotPost struct {
+	ID        string `json:"id"`
+	TeamID    string `json:"team_id"`
+	ChannelID string `json:"channel_id"`
+	MessageTS string `json:"ts"`
+	ThreadTS  string `json:"thread_ts,omitempty"`
+	Action    string `json:"action"`
+	// UserID is the only user shown an ephemeral message
+	UserID      string    `json:"user_id,omitempty"`
+	Text        string    `json:"text,omitempty"`
+	Blocks      string    `json:"blocks,omitempty"`
+	Attachments string    `json:"attachments,omitempty"`
+	PostedAt    time.Time `json:"posted_at"`
+}
+
+func (BotPost) TableName() string {
+	return "bot_posts"
+}
+
+// ComplianceExport describes an export of the bot posts of a date range, an
+// append-only log of JSON lines chained by SHA-256 hashes: each line's hash
+// covers the previous line's, and the first line continues the chain of the
+// previous export. The manifest stored next to it is signed with Ed25519.
+type ComplianceExport struct {
+	ID string `json:"id"`
+	// TeamID limits the export to a workspace; empty exports all of them
+	TeamID string `json:"team_id,omitempty"`
+	// From is inclusive and To exclusive
+	From        time.Time `json:"from" gorm:"column:range_start"`
+	To          time.Time `json:"to" gorm:"column:range_end"`
+	ObjectKey   string    `json:"object_key"`
+	ManifestKey string    `json:"manifest_key"`
+	Records     int       `json:"records"`
+	// PrevHash is the final hash of the previous export
+	PrevHash  string `json:"prev_hash"`
+	FinalHash string `json:"final_hash"`
+	// SHA256 is the hash of the whole export file
+	SHA256 string `json:"sha256" gorm:"column:sha256"`
+	// Signature is the base64 Ed25519 signature of the manifest
+	Signature string    `json:"signature"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (ComplianceExport) TableName() string {
+	return "compliance_exports"
+}
@@ -0,0 +1,287 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ConfigBundleVersion is the format of the bundles written by Export
+const ConfigBundleVersion = 1
+
+// ConfigBundle is the bot configuration of a deployment, exported to be
+// applied to another one, e.g. from staging to production. Identifiers
+// generated by the database and secrets are left out, so a bundle can be
+// reviewed and kept in version control.
+type ConfigBundle struct {
+	Version      int                 `json:"version"`
+	ExportedAt   time.Time           `json:"exported_at"`
+	Workspaces   []BundleWorkspace   `json:"workspaces,omitempty"`
+	Channels     []BundleChannel     `json:"channels"`
+	Rules        []BundleRule        `json:"rules"`
+	ChannelPairs []BundleChannelPair `json:"channel_pairs"`
+}
+
+// BundleWorkspace is a workspace the app is installed in, with its settings.
+// Installing needs the workspace's OAuth consent, so importing only updates
+// the settings of installed workspaces and warns about the others. Bot
+// tokens and signing secrets are never exported.
+type BundleWorkspace struct {
+	TeamID        string             `json:"team_id"`
+	TeamName      string             `json:"team_name"`
+	Generation    GenerationSettings `json:"generation,omitzero"`
+	MonthlyBudget float64            `json:"monthly_budget,omitempty"`
+}
+
+// NewBundleWorkspace copies the settings of workspace
+func NewBundleWorkspace(workspace *Workspace) BundleWorkspace {
+	return BundleWorkspace{
+		TeamID:        workspace.TeamID,
+		TeamName:      workspace.TeamName,
+		Generation:    workspace.Generation,
+		MonthlyBudget: workspace.MonthlyBudget,
+	}
+}
+
+// SettingsEqual reports whether both workspaces have the same settings; the
+// team name follows the Slack workspace and is not applied
+func (w BundleWorkspace) SettingsEqual(other BundleWorkspace) bool {
+	return w.Generation.Equal(other.Generation) && w.MonthlyBudget == other.MonthlyBudget
+}
+
+// BundleChannel is the translation configuration of a channel
+type BundleChannel struct {
+	ChannelID             string             `json:"channel_id"`
+	AutoTranslate         bool               `json:"auto_translate"`
+	SourceLanguages       LanguageList       `json:"source_languages"`
+	TargetLanguage        string             `json:"target_language"`
+	Enabled               bool               `json:"enabled"`
+	Canary                bool               `json:"canary"`
+	Debug                 bool               `json:"debug"`
+	ReviewChannelID       string             `json:"review_channel_id,omitempty"`
+	DigestIntervalMinutes int                `json:"digest_interval_minutes,omitempty"`
+	DigestMaxMessages     int                `json:"digest_max_messages,omitempty"`
+	DeliveryMode          string             `json:"delivery_mode,omitempty"`
+	Generation            GenerationSettings `json:"generation,omitzero"`
+}
+
+// NewBundleChannel copies the settings of config
+func NewBundleChannel(config *ChannelConfig) BundleChannel {
+	return BundleChannel{
+		ChannelID:             config.ChannelID,
+		AutoTranslate:         config.AutoTranslate,
+		SourceLanguages:       config.SourceLanguages,
+		TargetLanguage:        config.TargetLanguage,
+		Enabled:               config.Enabled,
+		Canary:                config.Canary,
+		Debug:                 config.Debug,
+		ReviewChannelID:       config.ReviewChannelID,
+		DigestIntervalMinutes: config.DigestIntervalMinutes,
+		DigestMaxMessages:     config.DigestMaxMessages,
+		DeliveryMode:          config.DeliveryMode,
+		Generation:            config.Generation,
+	}
+}
+
+// ToConfig returns a channel configuration with the settings of the channel
+func (c BundleChannel) ToConfig() *ChannelConfig {
+	return &ChannelConfig{
+		ChannelID:             c.ChannelID,
+		AutoTranslate:         c.AutoTranslate,
+		SourceLanguages:       c.SourceLanguages,
+		TargetLanguage:        c.TargetLanguage,
+		Enabled:               c.Enabled,
+		Canary:                c.Canary,
+		Debug:                 c.Debug,
+		ReviewChannelID:       c.ReviewChannelID,
+		DigestIntervalMinutes: c.DigestIntervalMinutes,
+		DigestMaxMessages:     c.DigestMaxMessages,
+		DeliveryMode:          c.DeliveryMode,
+		Generation:            c.Generation,
+	}
+}
+
+// Equal reports whether both channels have the same settings
+func (c BundleChannel) Equal(other BundleChannel) bool {
+	return c.ChannelID == other.ChannelID &&
+		c.AutoTranslate == other.AutoTranslate &&
+		strings.Join(c.SourceLanguages, ",") == strings.Join(other.SourceLanguages, ",") &&
+		c.TargetLanguage == other.TargetLanguage &&
+		c.Enabled == other.Enabled &&
+		c.Canary == other.Canary &&
+		c.Debug == other.Debug &&
+		c.ReviewChannelID == other.ReviewChannelID &&
+		c.DigestIntervalMinutes == other.DigestIntervalMinutes &&
+		c.DigestMaxMessages == other.DigestMaxMessages &&
+		c.DeliveryMode == other.DeliveryMode &&
+		c.Generation.Equal(other.Generation)
+}
+
+// BundleRule is a filter rule of a channel. Rules have no name, so a rule is
+// identified by its channel, type, pattern and values; only Enabled is
+// updated in place.
+type BundleRule struct {
+	ChannelID string         `json:"channel_id"`
+	Type      FilterRuleType `json:"type"`
+	Pattern   string         `json:"pattern,omitempty"`
+	Values    StringList     `json:"values,omitempty"`
+	Enabled   bool           `json:"enabled"`
+}
+
+// NewBundleRule copies the settings of rule
+func NewBundleRule(rule *FilterRule) BundleRule {
+	return BundleRule{
+		ChannelID: rule.ChannelID,
+		Type:      rule.Type,
+		Pattern:   rule.Pattern,
+		Values:    rule.Values,
+		Enabled:   rule.Enabled,
+	}
+}
+
+// ToRule returns a filter rule with the settings of the rule
+func (r BundleRule) ToRule() *FilterRule {
+	return &FilterRule{
+		ChannelID: r.ChannelID,
+		Type:      r.Type,
+		Pattern:   r.Pattern,
+		Values:    r.Values,
+		Enabled:   r.Enabled,
+	}
+}
+
+// Key identifies the rule within a bundle
+func (r BundleRule) Key() string {
+	return fmt.Sprintf("%s/%s/%s/%s", r.ChannelID, r.Type, r.Pattern, strings.Join(r.Values, ","))
+}
+
+// BundleChannelPair is a pair of channels whose translations are cross-posted
+type BundleChannelPair struct {
+	ChannelID       string `json:"channel_id"`
+	PairedChannelID string `json:"paired_channel_id"`
+}
+
+// Key identifies the pair regardless of the order of its channels
+func (p BundleChannelPair) Key() string {
+	if p.ChannelID > p.PairedChannelID {
+		return p.PairedChannelID + "/" + p.ChannelID
+	}
+	return p.ChannelID + "/" + p.PairedChannelID
+}
+
+// Validate checks every entry of the bundle, so nothing is applied from a
+// bundle that cannot be applied as a whole
+func (b *ConfigBundle) Validate() error {
+	if b.Version != ConfigBundleVersion {
+		return NewValidationError(fmt.Sprintf("unsupported bundle version %d, expected %d", b.Version, ConfigBundleVersion))
+	}
+
+	workspaces := make(map[string]bool, len(b.Workspaces))
+	for i, workspace := range b.Workspaces {
+		if workspace.TeamID == "" {
+			return NewValidationError(fmt.Sprintf("workspaces[%d]: team_id is required", i))
+		}
+		if workspace.MonthlyBudget < 0 {
+			return NewValidationError(fmt.Sprintf("workspaces[%d]: monthly_budget must not be negative", i))
+		}
+		if err := workspace.Generation.Validate(); err != nil {
+			return NewValidationError(fmt.Sprintf("workspaces[%d]: %v", i, err))
+		}
+		if workspaces[workspace.TeamID] {
+			return NewValidationError(fmt.Sprintf("workspaces[%d]: duplicate workspace %s", i, workspace.TeamID))
+		}
+		workspaces[workspace.TeamID] = true
+	}
+
+	channels := make(map[string]bool, len(b.Channels))
+	for i, channel := range b.Channels {
+		if err := channel.ToConfig().Validate(); err != nil {
+			return NewValidationError(fmt.Sprintf("channels[%d]: %v", i, err))
+		}
+		if channels[channel.ChannelID] {
+			return NewValidationError(fmt.Sprintf("channels[%d]: duplicate channel %s", i, channel.ChannelID))
+		}
+		channels[channel.ChannelID] = true
+	}
+
+	rules := make(map[string]bool, len(b.Rules))
+	for i, rule := range b.Rules {
+		if err := rule.ToRule().Validate(); err != nil {
+			return NewValidationError(fmt.Sprintf("rules[%d]: %v", i, err))
+		}
+		if rules[rule.Key()] {
+			return NewValidationError(fmt.Sprintf("rules[%d]: duplicate rule", i))
+		}
+		rules[rule.Key()] = true
+	}
+
+	paired := make(map[string]bool, 2*len(b.ChannelPairs))
+	for i, pair := range b.ChannelPairs {
+		p := ChannelPair{ChannelID: pair.ChannelID, PairedChannelID: pair.PairedChannelID}
+		if err := p.Validate(); err != nil {
+			return NewValidationError(fmt.Sprintf("channel_pairs[%d]: %v", i, err))
+		}
+		for _, channelID := range []string{pair.ChannelID, pair.PairedChannelID} {
+			if paired[channelID] {
+				return NewValidationError(fmt.Sprintf("channel_pairs[%d]: channel %s is in more than one pair", i, channelID))
+			}
+			paired[channelID] = true
+		}
+	}
+	return nil
+}
+
+// Resources a configuration change applies to
+const (
+	ConfigResourceChannel     = "channel"
+	ConfigResourceRule        = "rule"
+	ConfigResourceChannelPair = "channel_pair"
+	ConfigResourceWorkspace   = "workspace"
+)
+
+// ConfigApplyOptions controls how a bundle is applied
+type ConfigApplyOptions struct {
+	// DryRun only plans the changes
+	DryRun bool
+	// Prune deletes the channels, rules and pairs missing from the bundle;
+	// otherwise they are kept
+	Prune bool
+}
+
+// ConfigChange is one difference between a bundle and the deployment.
+// Action is one of the BulkAction values or ConfigActionDelete and Status
+// one of the BulkStatus values.
+type ConfigChange struct {
+	Resource string      `json:"resource"`
+	Key      string      `json:"key"`
+	Action   string      `json:"action"`
+	Status   string      `json:"status"`
+	Before   interface{} `json:"before,omitempty"`
+	After    interface{} `json:"after,omitempty"`
+	Error    string      `json:"error,omitempty"`
+}
+
+// ConfigActionDelete removes a resource missing from the bundle
+const ConfigActionDelete = "delete"
+
+// ConfigPlan is the difference between a bundle and the deployment, applied
+// unless it was a dry run
+type ConfigPlan struct {
+	DryRun    bool           `json:"dry_run"`
+	Changes   []ConfigChange `json:"changes"`
+	Unchanged int            `json:"unchanged"`
+	// Warnings are differences that cannot be applied, e.g. workspaces the
+	// app is not installed in
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Failed counts the changes that could not be applied
+func (p *ConfigPlan) Failed() int {
+	failed := 0
+	for _, change := range p.Changes {
+		if change.Status == BulkStatusFailed {
+			failed++
+		}
+	}
+	return failed
+}
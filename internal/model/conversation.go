@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+// MaxConversationTurns is how many exchanges of a direct-message
+// conversation are remembered
+const MaxConversationTurns = 5
+
+// Conversation is the short context of a user's direct-message conversation
+// with the bot
+type Conversation struct {
+	UserID string `json:"user_id"`
+	// TargetLanguage is the code chosen with the last to:<lang> prefix, which
+	// the next messages are translated to
+	TargetLanguage string             `json:"target_language,omitempty"`
+	Turns          []ConversationTurn `json:"turns"`
+}
+
+// ConversationTurn is a message of the conversation and its translation
+type ConversationTurn struct {
+	Text           string    `json:"text"`
+	SourceLanguage string    `json:"source_language"`
+	TargetLanguage string    `json:"target_language"`
+	TranslatedText string    `json:"translated_text"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// AddTurn appends a turn, forgetting the oldest beyond MaxConversationTurns
+func (c *Conversation) AddTurn(turn ConversationTurn) {
+	c.Turns = append(c.Turns, turn)
+	if len(c.Turns) > MaxConversationTurns {
+		c.Turns = c.Turns[len(c.Turns)-MaxConversationTurns:]
+	}
+}
+
+// LastTurn returns the latest turn, or false when the conversation has none
+func (c *Conversation) LastTurn() (ConversationTurn, bool) {
+	if len(c.Turns) == 0 {
+		return ConversationTurn{}, false
+	}
+	return c.Turns[len(c.Turns)-1], true
+}
@@ -0,0 +1,57 @@
+package model
+
+import "time"
+
+// TranslationCorrection is a machine translation and the text a reviewer
+// approved instead. Corrections are mined for glossary and translation
+// memory suggestions.
+type TranslationCorrection struct {
+	ID                   string    `json:"id"`
+	PendingTranslationID string    `json:"pending_translation_id"`
+	ChannelID            string    `json:"channel_id"`
+	SourceLanguage       string    `json:"source_language"`
+	TargetLanguage       string    `json:"target_language"`
+	SourceText           string    `json:"source_text"`
+	MachineText          string    `json:"machine_text"`
+	CorrectedText        string    `json:"corrected_text"`
+	ReviewerID           string    `json:"reviewer_id"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+func (TranslationCorrection) TableName() string {
+	return "translation_corrections"
+}
+
+// CorrectionQuery filters the corrections suggestions are built from; empty fields match everything
+type CorrectionQuery struct {
+	ChannelID string
+	// MinCount is how many times a term must be corrected the same way to be suggested
+	MinCount int
+	Limit    int
+}
+
+// GlossarySuggestion is a term reviewers repeatedly replaced in machine translations
+type GlossarySuggestion struct {
+	SourceLanguage string `json:"source_language"`
+	TargetLanguage string `json:"target_language"`
+	MachineTerm    string `json:"machine_term"`
+	CorrectedTerm  string `json:"corrected_term"`
+	Count          int    `json:"count"`
+}
+
+// TranslationMemoryEntry is the latest reviewer-approved translation of a source text
+type TranslationMemoryEntry struct {
+	SourceLanguage  string    `json:"source_language"`
+	TargetLanguage  string    `json:"target_language"`
+	SourceText      string    `json:"source_text"`
+	CorrectedText   string    `json:"corrected_text"`
+	Corrections     int       `json:"corrections"`
+	LastCorrectedAt time.Time `json:"last_corrected_at"`
+}
+
+// CorrectionSuggestions are glossary and translation memory candidates built from reviewer corrections
+type CorrectionSuggestions struct {
+	Corrections       int                      `json:"corrections"`
+	Glossary          []GlossarySuggestion     `json:"glossary"`
+	TranslationMemory []TranslationMemoryEntry `json:"translation_memory"`
+}
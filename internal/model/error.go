@@ -1,6 +1,9 @@
 package model
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 type ErrorType string
 
@@ -13,6 +16,10 @@ const (
 	ErrorTypeBadRequest    ErrorType = "BAD_REQUEST"
 )
 
+// ErrNotFound is returned by repository lookups that found no record, so
+// callers can tell a miss from a failed query
+var ErrNotFound = errors.New("record not found")
+
 type DomainError struct {
 	Type    ErrorType
 	Message string
@@ -68,3 +75,12 @@ func NewBadRequestError(message string) *DomainError {
 		Message: message,
 	}
 }
+
+// IsNotFound reports whether err is ErrNotFound or a not found DomainError
+func IsNotFound(err error) bool {
+	if errors.Is(err, ErrNotFound) {
+		return true
+	}
+	var domainErr *DomainError
+	return errors.As(err, &domainErr) && domainErr.Type == ErrorTypeNotFound
+}
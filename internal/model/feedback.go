@@ -0,0 +1,55 @@
+package model
+
+import "time"
+
+// Ratings users give a translation by reacting to it with 👍 or 👎
+const (
+	RatingPositive = 1
+	RatingNegative = -1
+)
+
+// TranslationFeedback is a user's rating of a translation the bot posted.
+// Each user has one rating per reply; reacting again replaces it.
+type TranslationFeedback struct {
+	ID string `json:"id"`
+	// TranslationID is empty when the translation was served from the cache
+	TranslationID  string    `json:"translation_id"`
+	ChannelID      string    `json:"channel_id"`
+	MessageTS      string    `json:"message_ts"`
+	UserID         string    `json:"user_id"`
+	SourceLanguage string    `json:"source_language"`
+	TargetLanguage string    `json:"target_language"`
+	Rating         int       `json:"rating"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func (TranslationFeedback) TableName() string {
+	return "translation_feedback"
+}
+
+// TranslationReply is a translation the bot posted, remembered so ratings of
+// the reply can be traced back to the translation
+type TranslationReply struct {
+	TranslationID  string `json:"translation_id,omitempty"`
+	ChannelID      string `json:"channel_id"`
+	MessageTS      string `json:"message_ts"`
+	SourceLanguage string `json:"source_language"`
+	TargetLanguage string `json:"target_language"`
+}
+
+// FeedbackQuery filters the feedback summarized; empty fields match everything
+type FeedbackQuery struct {
+	ChannelID string
+	Since     time.Time
+}
+
+// LanguagePairFeedback sums up the ratings of translations between two languages
+type LanguagePairFeedback struct {
+	SourceLanguage string `json:"source_language"`
+	TargetLanguage string `json:"target_language"`
+	Positive       int    `json:"positive"`
+	Negative       int    `json:"negative"`
+	// Satisfaction is the share of positive ratings, between 0 and 1
+	Satisfaction float64 `json:"satisfaction"`
+}
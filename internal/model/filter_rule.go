@@ -0,0 +1,71 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+type FilterRuleType string
+
+const (
+	// FilterRuleSkipRegex skips messages whose text matches Pattern
+	FilterRuleSkipRegex FilterRuleType = "skip_regex"
+	// FilterRuleAllowUsers only translates messages from the user IDs in Values
+	FilterRuleAllowUsers FilterRuleType = "allow_users"
+	// FilterRuleAllowUserGroups only translates messages from members of the user groups in Values
+	FilterRuleAllowUserGroups FilterRuleType = "allow_user_groups"
+	// FilterRuleRequireMention only translates messages that mention someone
+	// (or one of the user IDs in Values, when set)
+	FilterRuleRequireMention FilterRuleType = "require_mention"
+)
+
+// FilterRule is a per-channel rule evaluated before a message is translated.
+// A message is translated only when every enabled rule of its channel passes.
+type FilterRule struct {
+	ID        string         `json:"id"`
+	ChannelID string         `json:"channel_id"`
+	Type      FilterRuleType `json:"type"`
+	Pattern   string         `json:"pattern,omitempty"`
+	Values    StringList     `json:"values,omitempty"`
+	Enabled   bool           `json:"enabled"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+func (FilterRule) TableName() string {
+	return "filter_rules"
+}
+
+// Validate checks that the rule is well-formed for its type
+func (r *FilterRule) Validate() error {
+	if r.ChannelID == "" {
+		return NewValidationError("channel_id is required")
+	}
+
+	switch r.Type {
+	case FilterRuleSkipRegex:
+		if r.Pattern == "" {
+			return NewValidationError("pattern is required for skip_regex rules")
+		}
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return NewValidationError(fmt.Sprintf("invalid pattern: %v", err))
+		}
+	case FilterRuleAllowUsers, FilterRuleAllowUserGroups:
+		if len(r.Values) == 0 {
+			return NewValidationError(fmt.Sprintf("values are required for %s rules", r.Type))
+		}
+	case FilterRuleRequireMention:
+	default:
+		return NewValidationError(fmt.Sprintf("unknown rule type: %q", r.Type))
+	}
+
+	return nil
+}
+
+// FilterInput is the part of a message the filter rules look at
+type FilterInput struct {
+	ChannelID string
+	UserID    string
+	Text      string
+}
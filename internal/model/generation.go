@@ -0,0 +1,123 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/ai"
+)
+
+// GenerationSettings are AI generation parameters stored in a JSON column:
+// the overrides of a workspace or channel, where nil keeps the default, or
+// the parameters a translation was produced with
+type GenerationSettings struct {
+	Temperature     *float64 `json:"temperature,omitempty"`
+	TopP            *float64 `json:"top_p,omitempty"`
+	MaxOutputTokens *int     `json:"max_output_tokens,omitempty"`
+	// Model selects one of the models the AI providers offer in place of
+	// their own, e.g. a stronger model for a critical channel
+	Model *string `json:"model,omitempty"`
+}
+
+// GenerationSettingsOf returns settings recording every parameter of
+// generation, and its model when it selects one
+func GenerationSettingsOf(generation ai.GenerationConfig) GenerationSettings {
+	settings := GenerationSettings{
+		Temperature:     &generation.Temperature,
+		TopP:            &generation.TopP,
+		MaxOutputTokens: &generation.MaxOutputTokens,
+	}
+	if generation.Model != "" {
+		settings.Model = &generation.Model
+	}
+	return settings
+}
+
+// IsZero reports whether no parameter is set
+func (g GenerationSettings) IsZero() bool {
+	return g.Temperature == nil && g.TopP == nil && g.MaxOutputTokens == nil && g.Model == nil
+}
+
+// Equal reports whether both settings set the same parameters to the same values
+func (g GenerationSettings) Equal(other GenerationSettings) bool {
+	return equalPointers(g.Temperature, other.Temperature) &&
+		equalPointers(g.TopP, other.TopP) &&
+		equalPointers(g.MaxOutputTokens, other.MaxOutputTokens) &&
+		equalPointers(g.Model, other.Model)
+}
+
+func equalPointers[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// Validate checks that every parameter set is in the range providers accept
+func (g GenerationSettings) Validate() error {
+	if g.Temperature != nil && (*g.Temperature < 0 || *g.Temperature > ai.MaxTemperature) {
+		return NewValidationError(fmt.Sprintf("temperature must be between 0 and %g", ai.MaxTemperature))
+	}
+	if g.TopP != nil && (*g.TopP <= 0 || *g.TopP > 1) {
+		return NewValidationError("top_p must be above 0 and at most 1")
+	}
+	if g.MaxOutputTokens != nil && (*g.MaxOutputTokens < 0 || *g.MaxOutputTokens > ai.MaxOutputTokenCap) {
+		return NewValidationError(fmt.Sprintf("max_output_tokens must be between 0 and %d", ai.MaxOutputTokenCap))
+	}
+	if g.Model != nil && *g.Model != "" && !ai.ValidModelName(*g.Model) {
+		return NewValidationError(fmt.Sprintf("invalid model name: %s", *g.Model))
+	}
+	return nil
+}
+
+// Apply returns base with the parameters set in g replacing its own
+func (g GenerationSettings) Apply(base ai.GenerationConfig) ai.GenerationConfig {
+	if g.Temperature != nil {
+		base.Temperature = *g.Temperature
+	}
+	if g.TopP != nil {
+		base.TopP = *g.TopP
+	}
+	if g.MaxOutputTokens != nil {
+		base.MaxOutputTokens = *g.MaxOutputTokens
+	}
+	if g.Model != nil {
+		base.Model = *g.Model
+	}
+	return base
+}
+
+// Value implements driver.Valuer
+func (g GenerationSettings) Value() (driver.Value, error) {
+	data, err := json.Marshal(g)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode generation settings: %w", err)
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (g *GenerationSettings) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*g = GenerationSettings{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for generation settings: %T", value)
+	}
+
+	settings := GenerationSettings{}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &settings); err != nil {
+			return fmt.Errorf("failed to decode generation settings: %w", err)
+		}
+	}
+	*g = settings
+	return nil
+}
@@ -0,0 +1,108 @@
+package model
+
+import (
+	"sort"
+	"strings"
+)
+
+// supportedLanguages maps the ISO 639-1 codes accepted in channel
+// configuration to their display names. Chinese is told apart by script,
+// with BCP 47 script subtags.
+var supportedLanguages = map[string]string{
+	"en":      "English",
+	"vi":      "Vietnamese",
+	"es":      "Spanish",
+	"fr":      "French",
+	"de":      "German",
+	"zh-Hans": "Chinese (Simplified)",
+	"zh-Hant": "Chinese (Traditional)",
+	"ja":      "Japanese",
+	"ko":      "Korean",
+	"th":      "Thai",
+	"ar":      "Arabic",
+	"he":      "Hebrew",
+}
+
+// rightToLeftLanguages are the supported languages written right to left
+var rightToLeftLanguages = map[string]bool{
+	"ar": true,
+	"he": true,
+}
+
+// languageAliases maps other codes and names, in lower case, to supported
+// codes. "zh" predates the script subtags and stays accepted as Simplified,
+// and "iw" is the former code of Hebrew.
+var languageAliases = map[string]string{
+	"zh":      "zh-Hans",
+	"zh-cn":   "zh-Hans",
+	"zh-sg":   "zh-Hans",
+	"zh-tw":   "zh-Hant",
+	"zh-hk":   "zh-Hant",
+	"zh-mo":   "zh-Hant",
+	"chinese": "zh-Hans",
+	"iw":      "he",
+}
+
+// CanonicalLanguageCode returns the supported code that code stands for,
+// e.g. "zh-Hans" for "zh" or "zh-hans", or code itself when it is unknown
+func CanonicalLanguageCode(code string) string {
+	if _, ok := supportedLanguages[code]; ok {
+		return code
+	}
+	lower := strings.ToLower(strings.TrimSpace(code))
+	if alias, ok := languageAliases[lower]; ok {
+		return alias
+	}
+	for supported := range supportedLanguages {
+		if strings.ToLower(supported) == lower {
+			return supported
+		}
+	}
+	return code
+}
+
+// IsSupportedLanguageCode reports whether code is a known language code
+func IsSupportedLanguageCode(code string) bool {
+	_, ok := supportedLanguages[CanonicalLanguageCode(code)]
+	return ok
+}
+
+// SupportedLanguageCodes returns the supported language codes, sorted
+func SupportedLanguageCodes() []string {
+	codes := make([]string, 0, len(supportedLanguages))
+	for code := range supportedLanguages {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// LanguageName returns the display name for a language code
+func LanguageName(code string) (string, bool) {
+	name, ok := supportedLanguages[CanonicalLanguageCode(code)]
+	return name, ok
+}
+
+// LanguageCode returns the code for a language display name, as returned by
+// language detection. The name is matched case-insensitively.
+func LanguageCode(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	for code, languageName := range supportedLanguages {
+		if strings.EqualFold(languageName, name) {
+			return code, true
+		}
+	}
+	if code, ok := languageAliases[strings.ToLower(name)]; ok {
+		return code, true
+	}
+	return "", false
+}
+
+// IsRightToLeft reports whether a language, by code or display name, is
+// written right to left, e.g. Arabic or Hebrew
+func IsRightToLeft(language string) bool {
+	if code, ok := LanguageCode(language); ok {
+		return rightToLeftLanguages[code]
+	}
+	return rightToLeftLanguages[CanonicalLanguageCode(language)]
+}
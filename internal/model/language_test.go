@@ -0,0 +1,45 @@
+package model
+
+import "testing"
+
+func TestLanguageAliases(t *testing.T) {
+	tests := []struct {
+		code      string
+		canonical string
+		name      string
+	}{
+		{code: "zh", canonical: "zh-Hans", name: "Chinese (Simplified)"},
+		{code: "zh-CN", canonical: "zh-Hans", name: "Chinese (Simplified)"},
+		{code: "zh-hant", canonical: "zh-Hant", name: "Chinese (Traditional)"},
+		{code: "zh-TW", canonical: "zh-Hant", name: "Chinese (Traditional)"},
+		{code: "KO", canonical: "ko", name: "Korean"},
+		{code: "th", canonical: "th", name: "Thai"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := CanonicalLanguageCode(tt.code); got != tt.canonical {
+				t.Errorf("expected canonical code %q, got %q", tt.canonical, got)
+			}
+			if !IsSupportedLanguageCode(tt.code) {
+				t.Errorf("expected %q to be supported", tt.code)
+			}
+			if got, ok := LanguageName(tt.code); !ok || got != tt.name {
+				t.Errorf("expected name %q, got %q", tt.name, got)
+			}
+		})
+	}
+
+	for language, rightToLeft := range map[string]bool{"ar": true, "Hebrew": true, "iw": true, "en": false, "Japanese": false} {
+		if IsRightToLeft(language) != rightToLeft {
+			t.Errorf("expected IsRightToLeft(%q) to be %v", language, rightToLeft)
+		}
+	}
+
+	if IsSupportedLanguageCode("xx") {
+		t.Error("expected xx to be unsupported")
+	}
+	if !(LanguageList{"zh"}).Contains("zh-Hans") {
+		t.Error("expected zh to match zh-Hans")
+	}
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// Maintenance is the global maintenance switch. While it is enabled, incoming
+// events are queued but not processed, and every channel that receives a
+// message is sent Notice once.
+type Maintenance struct {
+	Enabled   bool       `json:"enabled"`
+	Notice    string     `json:"notice,omitempty"`
+	EnabledBy string     `json:"enabled_by,omitempty"`
+	EnabledAt *time.Time `json:"enabled_at,omitempty"`
+}
@@ -2,17 +2,41 @@ package model
 
 import "time"
 
-// MessageEvent represents a Slack event to be processed
+// MessageEvent represents a Slack event to be processed. Slack webhook
+// events carry the undecoded request body in RawPayload and leave Payload nil
+// until the worker decodes it, keeping the handler off the allocation-heavy path.
+// Compressed reports that RawPayload has been trimmed and gzip-compressed.
+// StreamID identifies an event read from the durable queue and is empty for
+// events held in memory only. TraceContext carries the webhook's trace to the
+// worker, in W3C traceparent form. Priority lets events such as direct
+// messages to the bot jump ahead of the rest of their queue.
 type MessageEvent struct {
-	EventID    string
-	ChannelID  string
-	UserID     string
-	MessageTS  string
-	Payload    map[string]interface{}
-	ReceivedAt time.Time
-	Sequence   uint64
+	EventID      string
+	ChannelID    string
+	UserID       string
+	MessageTS    string
+	EventType    string
+	Payload      map[string]interface{}
+	RawPayload   []byte
+	Compressed   bool
+	ReceivedAt   time.Time
+	Sequence     uint64
+	StreamID     string
+	TraceContext map[string]string
+	Priority     Priority
 }
 
+// Priority is the processing priority of an event within its queue
+type Priority int
+
+const (
+	// PriorityNormal events are processed in order with the channel's traffic
+	PriorityNormal Priority = iota
+	// PriorityHigh events, e.g. direct messages and mentions of the bot, are
+	// processed ahead of the normal events waiting in their queue
+	PriorityHigh
+)
+
 // GetQueueKey returns the key for queue management
 // Using channel_id ensures ordering at channel level for all messages in the channel
 func (e *MessageEvent) GetQueueKey() string {
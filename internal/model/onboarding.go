@@ -0,0 +1,41 @@
+package model
+
+// Action IDs of the setup wizard sent to the user who installed the app
+const (
+	OnboardingActionLanguage     = "onboarding_language"
+	OnboardingActionChannels     = "onboarding_channels"
+	OnboardingActionChannelsNext = "onboarding_channels_next"
+	OnboardingActionBudget       = "onboarding_budget"
+	// OnboardingChannelsBlockID holds the channel menu, whose selection the
+	// Next button reads from the message state
+	OnboardingChannelsBlockID = "onboarding_channels"
+)
+
+// Steps of the setup wizard, in order
+const (
+	OnboardingStepLanguage = "language"
+	OnboardingStepChannels = "channels"
+	OnboardingStepBudget   = "budget"
+	OnboardingStepDone     = "done"
+)
+
+// OnboardingBudgets are the monthly AI budgets offered by the setup wizard,
+// in US dollars; 0 sets no budget
+var OnboardingBudgets = []float64{0, 10, 50, 100, 500}
+
+// OnboardingSession is the progress of a user through the setup wizard of a
+// workspace, which is a single direct message updated at each step
+type OnboardingSession struct {
+	TeamID string `json:"team_id"`
+	UserID string `json:"user_id"`
+	Step   string `json:"step"`
+	// TargetLanguage is the code of the language the chosen channels translate to
+	TargetLanguage string   `json:"target_language"`
+	ChannelIDs     []string `json:"channel_ids"`
+	MonthlyBudget  float64  `json:"monthly_budget"`
+	// ChannelID and MessageTS locate the wizard's message once it is posted
+	ChannelID string `json:"channel_id"`
+	MessageTS string `json:"message_ts"`
+	// Results are the channel configurations written when the wizard finished
+	Results []BulkChannelResult `json:"results,omitempty"`
+}
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+// Pending translation statuses
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+)
+
+// Block Kit action IDs of the review buttons. The button value is the pending translation ID.
+const (
+	ReviewActionApprove = "translation_review_approve"
+	ReviewActionReject  = "translation_review_reject"
+	ReviewActionEdit    = "translation_review_edit"
+)
+
+// Modal that lets a reviewer correct a translation before approving it.
+// The modal's private metadata is the pending translation ID.
+const (
+	ReviewCorrectionCallbackID = "translation_review_correction"
+	ReviewCorrectionBlockID    = "corrected_translation"
+	ReviewCorrectionActionID   = "corrected_text"
+)
+
+// PendingTranslation is a translation held in a reviewers' channel until it
+// is approved. Only approved translations are posted to the original thread.
+type PendingTranslation struct {
+	ID              string     `json:"id"`
+	ChannelID       string     `json:"channel_id"`
+	ThreadTS        string     `json:"thread_ts"`
+	UserID          string     `json:"user_id"`
+	SourceLanguage  string     `json:"source_language"`
+	TargetLanguage  string     `json:"target_language"`
+	OriginalText    string     `json:"original_text"`
+	TranslatedText  string     `json:"translated_text"`
+	BotName         string     `json:"bot_name"`
+	BotAvatar       string     `json:"bot_avatar"`
+	AsQuote         bool       `json:"as_quote"`
+	ReviewChannelID string     `json:"review_channel_id"`
+	ReviewMessageTS string     `json:"review_message_ts"`
+	Status          string     `json:"status"`
+	ReviewerID      string     `json:"reviewer_id,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+func (PendingTranslation) TableName() string {
+	return "pending_translations"
+}
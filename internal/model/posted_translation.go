@@ -0,0 +1,12 @@
+package model
+
+// PostedTranslation links a message to the reply the bot posted with its
+// translation, so the reply can be updated when the message is edited
+type PostedTranslation struct {
+	ChannelID string `json:"channel_id"`
+	MessageTS string `json:"message_ts"`
+	// ReplyChannelID differs from ChannelID when the translation was
+	// cross-posted to a paired channel
+	ReplyChannelID string `json:"reply_channel_id"`
+	ReplyTS        string `json:"reply_ts"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// PromptDeployment is the translation prompt version served to regular
+// channels. Switching versions keeps the previous one, so a version whose
+// translations turn out worse can be rolled back at once.
+type PromptDeployment struct {
+	ActiveVersion   string     `json:"active_version"`
+	PreviousVersion string     `json:"previous_version,omitempty"`
+	SwitchedBy      string     `json:"switched_by,omitempty"`
+	SwitchedAt      *time.Time `json:"switched_at,omitempty"`
+	// Versions lists the prompt versions that can be activated
+	Versions []string `json:"versions"`
+}
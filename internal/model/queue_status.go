@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// QueueStatus describes the message queue of one channel. Depth counts the
+// messages not processed yet, including the one in flight.
+type QueueStatus struct {
+	Key                string     `json:"key"`
+	Pool               string     `json:"pool,omitempty"`
+	Depth              int        `json:"depth"`
+	OldestMessageAgeMS int64      `json:"oldest_message_age_ms"`
+	Processing         bool       `json:"processing"`
+	Paused             bool       `json:"paused"`
+	LastProcessedTS    string     `json:"last_processed_ts,omitempty"`
+	LastProcessedAt    *time.Time `json:"last_processed_at,omitempty"`
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// StageTimings are the milliseconds a translation spent in each stage, e.g.
+// "cache" or "ai", stored in a JSON column
+type StageTimings map[string]int64
+
+// Value implements driver.Valuer
+func (t StageTimings) Value() (driver.Value, error) {
+	if t == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode stage timings: %w", err)
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (t *StageTimings) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*t = StageTimings{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for stage timings: %T", value)
+	}
+
+	timings := StageTimings{}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &timings); err != nil {
+			return fmt.Errorf("failed to decode stage timings: %w", err)
+		}
+	}
+	*t = timings
+	return nil
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// StringList is a list of strings stored in a JSON column
+type StringList []string
+
+// Contains reports whether value is in the list
+func (l StringList) Contains(value string) bool {
+	for _, v := range l {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Value implements driver.Valuer
+func (l StringList) Value() (driver.Value, error) {
+	return jsonStringsValue(l)
+}
+
+// Scan implements sql.Scanner
+func (l *StringList) Scan(value interface{}) error {
+	values, err := scanJSONStrings(value)
+	if err != nil {
+		return err
+	}
+	*l = StringList(values)
+	return nil
+}
+
+func jsonStringsValue(values []string) (driver.Value, error) {
+	if values == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode string list: %w", err)
+	}
+	return string(data), nil
+}
+
+func scanJSONStrings(value interface{}) ([]string, error) {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		return []string{}, nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return nil, fmt.Errorf("unsupported type for string list: %T", value)
+	}
+
+	if len(data) == 0 {
+		return []string{}, nil
+	}
+
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to decode string list: %w", err)
+	}
+	return values, nil
+}
@@ -12,8 +12,16 @@ type Translation struct {
 	Hash            string
 	UserID          string
 	ChannelID       string
-	CreatedAt       time.Time
-	TTL             int64
+	Provider        string
+	PromptVersion   string
+	// StageTimings is the time spent per stage before the translation was
+	// saved, and FailedStage the first stage that failed, if any
+	StageTimings StageTimings
+	FailedStage  string
+	// Generation holds the parameters the AI was called with
+	Generation GenerationSettings
+	CreatedAt  time.Time
+	TTL        int64
 }
 
 func (Translation) TableName() string {
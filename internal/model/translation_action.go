@@ -0,0 +1,29 @@
+package model
+
+import "strings"
+
+// Action IDs of the buttons below a translation the bot posted
+const (
+	TranslationActionShowOriginal = "translation_show_original"
+	TranslationActionRetranslate  = "translation_retranslate"
+)
+
+// ExplainMessageCallbackID is the callback ID of the "Explain this message"
+// message shortcut, set when creating the shortcut in the Slack app
+const ExplainMessageCallbackID = "explain_message"
+
+// translationActionsBlock prefixes the block ID of a translation's buttons
+const translationActionsBlock = "translation_actions:"
+
+// TranslationActionsBlockID returns the block ID of the buttons below the
+// translation of the message messageTS, naming the message they act on
+func TranslationActionsBlockID(messageTS string) string {
+	return translationActionsBlock + messageTS
+}
+
+// TranslatedMessageTS returns the message the buttons of blockID act on, or
+// false when blockID is not the block of a translation's buttons
+func TranslatedMessageTS(blockID string) (string, bool) {
+	messageTS, ok := strings.CutPrefix(blockID, translationActionsBlock)
+	return messageTS, ok && messageTS != ""
+}
@@ -94,7 +94,7 @@ func TestChannelConfigCreation(t *testing.T) {
 				ID:              "1",
 				ChannelID:       "C123",
 				AutoTranslate:   true,
-				SourceLanguages: `["en", "vi"]`,
+				SourceLanguages: LanguageList{"en", "vi"},
 				TargetLanguage:  "es",
 				Enabled:         true,
 				CreatedAt:       now,
@@ -110,8 +110,8 @@ func TestChannelConfigCreation(t *testing.T) {
 				if !config.Enabled {
 					t.Error("expected Enabled to be true")
 				}
-				if config.SourceLanguages != `["en", "vi"]` {
-					t.Errorf("expected source languages [\"en\", \"vi\"], got %s", config.SourceLanguages)
+				if len(config.SourceLanguages) != 2 || !config.SourceLanguages.Contains("vi") {
+					t.Errorf("expected source languages [en vi], got %v", config.SourceLanguages)
 				}
 			},
 		},
@@ -121,7 +121,7 @@ func TestChannelConfigCreation(t *testing.T) {
 				ID:              "2",
 				ChannelID:       "C456",
 				AutoTranslate:   false,
-				SourceLanguages: `["fr"]`,
+				SourceLanguages: LanguageList{"fr"},
 				TargetLanguage:  "en",
 				Enabled:         false,
 				CreatedAt:       now,
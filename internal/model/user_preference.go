@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// Action IDs of the preferences on the bot's App Home tab
+const (
+	HomeActionPreferredLanguage    = "home_preferred_language"
+	HomeActionTranslateOwnMessages = "home_translate_own_messages"
+	// HomeLanguageChannelDefault is the menu value of no preferred language,
+	// since Slack option values cannot be empty
+	HomeLanguageChannelDefault = "default"
+)
+
+// UserPreference is how a user wants their own messages translated, set on
+// the bot's App Home tab. Users without one get DefaultUserPreference.
+type UserPreference struct {
+	UserID string `json:"user_id"`
+	// PreferredLanguage is the code of the language the user's messages are
+	// translated to; empty uses the channel's languages
+	PreferredLanguage    string    `json:"preferred_language"`
+	TranslateOwnMessages bool      `json:"translate_own_messages"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+func (UserPreference) TableName() string {
+	return "user_preferences"
+}
+
+// DefaultUserPreference translates the user's messages with the channel's languages
+func DefaultUserPreference(userID string) *UserPreference {
+	return &UserPreference{UserID: userID, TranslateOwnMessages: true}
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/secrets"
+)
+
+// Workspace is a Slack workspace the app was installed in through OAuth,
+// with the credentials the bot uses there. Credentials are stored encrypted
+// and marshal as "[REDACTED]".
+type Workspace struct {
+	TeamID    string         `json:"team_id"`
+	TeamName  string         `json:"team_name"`
+	BotUserID string         `json:"bot_user_id"`
+	BotToken  secrets.Secret `json:"bot_token"`
+	// SigningSecret verifies requests from a workspace running its own copy
+	// of the app; empty uses SLACK_SIGNING_SECRET
+	SigningSecret secrets.Secret `json:"signing_secret"`
+	InstalledBy   string         `json:"installed_by"`
+	// Generation overrides the AI generation parameters of the workspace's translations
+	Generation GenerationSettings `json:"generation"`
+	// MonthlyBudget is the AI spend the workspace plans per month, in US
+	// dollars, set in the setup wizard; 0 sets none
+	MonthlyBudget float64   `json:"monthly_budget"`
+	InstalledAt   time.Time `json:"installed_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Validate checks the workspace before it is persisted
+func (w *Workspace) Validate() error {
+	if w.TeamID == "" {
+		return NewValidationError("team_id is required")
+	}
+	if w.BotToken == "" {
+		return NewValidationError("bot_token is required")
+	}
+	if w.MonthlyBudget < 0 {
+		return NewValidationError("monthly_budget must not be negative")
+	}
+	return w.Generation.Validate()
+}
+
+// KeyRotation is the outcome of re-wrapping the stored workspace credentials
+// with the current master key. Skipped workspaces changed while they were
+// rotated, e.g. by a reinstall, and are rotated by running it again.
+type KeyRotation struct {
+	Rotated int `json:"rotated"`
+	Skipped int `json:"skipped"`
+}
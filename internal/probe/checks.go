@@ -0,0 +1,220 @@
+package probe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/ai"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/config"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/database"
+)
+
+// Names of the checks in a report. AI provider checks are named "ai:<provider>".
+const (
+	CheckConfig   = "config"
+	CheckDatabase = "database"
+	CheckRedis    = "redis"
+	CheckSlack    = "slack"
+)
+
+const (
+	defaultSlackAPIURL  = "https://slack.com/api"
+	defaultGeminiAPIURL = "https://generativelanguage.googleapis.com/v1beta"
+)
+
+// RequiredSlackScopes are the bot token scopes the Slack integration uses
+var RequiredSlackScopes = []string{
+	"app_mentions:read",
+	"channels:history",
+	"channels:read",
+	"chat:write",
+	"groups:history",
+	"groups:read",
+	"im:history",
+	"reactions:read",
+	"reactions:write",
+	"users:read",
+}
+
+// Prober checks that the dependencies in a configuration are reachable and
+// that its credentials are accepted
+type Prober struct {
+	cfg          *config.Config
+	httpClient   *http.Client
+	slackAPIURL  string
+	geminiAPIURL string
+}
+
+func NewProber(cfg *config.Config) *Prober {
+	return &Prober{
+		cfg:          cfg,
+		httpClient:   &http.Client{},
+		slackAPIURL:  defaultSlackAPIURL,
+		geminiAPIURL: defaultGeminiAPIURL,
+	}
+}
+
+// Checks returns the checks for the configuration: MySQL, Redis, the Slack
+// token when Slack is enabled, and every AI provider in the failover chain.
+// The configuration itself was validated when it was loaded.
+func (p *Prober) Checks() []Check {
+	checks := []Check{
+		{Name: CheckConfig, Run: func(context.Context) error { return nil }},
+		{Name: CheckDatabase, Run: p.checkDatabase},
+		{Name: CheckRedis, Run: p.checkRedis},
+	}
+	if p.cfg.PlatformEnabled(config.PlatformSlack) {
+		checks = append(checks, Check{Name: CheckSlack, Run: p.checkSlack})
+	}
+	for _, name := range p.cfg.AI.Chain() {
+		checks = append(checks, Check{
+			Name: "ai:" + name,
+			Run:  func(ctx context.Context) error { return p.checkAIProvider(ctx, name) },
+		})
+	}
+	return checks
+}
+
+func (p *Prober) checkDatabase(ctx context.Context) error {
+	db, err := database.NewGormDB(database.DBConfig{
+		Host:     p.cfg.Database.Host,
+		Port:     p.cfg.Database.Port,
+		User:     p.cfg.Database.User,
+		Password: p.cfg.Database.Password,
+		Database: p.cfg.Database.Database,
+	})
+	if err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = sqlDB.Close()
+	}()
+
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+	return nil
+}
+
+func (p *Prober) checkRedis(ctx context.Context) error {
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", p.cfg.Redis.Host, p.cfg.Redis.Port),
+		Password: p.cfg.Redis.Password,
+	})
+	defer func() {
+		_ = client.Close()
+	}()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("failed to ping Redis: %w", err)
+	}
+	return nil
+}
+
+// checkSlack calls auth.test, which reports the token's scopes in the
+// X-OAuth-Scopes header, and fails if any required scope is missing
+func (p *Prober) checkSlack(ctx context.Context) error {
+	if p.cfg.Slack.BotToken == "" {
+		return errors.New("SLACK_BOT_TOKEN is not set")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.slackAPIURL+"/auth.test", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.cfg.Slack.BotToken)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call Slack auth.test: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode Slack auth.test response: %w", err)
+	}
+	if !result.OK {
+		return fmt.Errorf("slack rejected the bot token: %s", result.Error)
+	}
+
+	granted := make(map[string]bool)
+	for _, scope := range strings.Split(resp.Header.Get("X-OAuth-Scopes"), ",") {
+		granted[strings.TrimSpace(scope)] = true
+	}
+	var missing []string
+	for _, scope := range RequiredSlackScopes {
+		if !granted[scope] {
+			missing = append(missing, scope)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("slack bot token is missing scopes: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// checkAIProvider looks up the configured model, which verifies the API key
+// without spending tokens
+func (p *Prober) checkAIProvider(ctx context.Context, name string) error {
+	switch name {
+	case ai.ProviderGemini:
+		if p.cfg.Gemini.APIKey == "" {
+			return errors.New("GEMINI_API_KEY is not set")
+		}
+		return p.checkModel(ctx, p.geminiAPIURL+"/models/"+url.PathEscape(p.cfg.Gemini.Model),
+			"x-goog-api-key", p.cfg.Gemini.APIKey)
+	case ai.ProviderOpenAI:
+		baseURL := strings.TrimSuffix(p.cfg.OpenAI.BaseURL, "/")
+		if baseURL == "" {
+			baseURL = ai.DefaultOpenAIBaseURL
+		}
+		return p.checkModel(ctx, baseURL+"/models/"+url.PathEscape(p.cfg.OpenAI.Model),
+			"Authorization", "Bearer "+p.cfg.OpenAI.APIKey)
+	}
+	return fmt.Errorf("no credential check for AI provider %q", name)
+}
+
+func (p *Prober) checkModel(ctx context.Context, modelURL, header, value string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, modelURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(header, value)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach AI provider: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return nil
+	case resp.StatusCode == http.StatusNotFound:
+		return errors.New("model not found")
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden,
+		resp.StatusCode == http.StatusBadRequest:
+		// Gemini answers an invalid API key with 400
+		return fmt.Errorf("credentials rejected (status %d)", resp.StatusCode)
+	}
+	return fmt.Errorf("unexpected status %d", resp.StatusCode)
+}
@@ -0,0 +1,79 @@
+package probe
+
+import (
+	"context"
+	"time"
+)
+
+// Check and report statuses
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// Check is one dependency the bot needs before it can serve traffic
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// CheckResult is the outcome of a check
+type CheckResult struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// Report is the machine-readable result of a probe run. Status is "ok" only
+// when every check passed.
+type Report struct {
+	Status string        `json:"status"`
+	Checks []CheckResult `json:"checks"`
+}
+
+// OK reports whether every check passed
+func (r Report) OK() bool {
+	return r.Status == StatusOK
+}
+
+// ConfigFailed returns the report of a probe whose configuration could not
+// be loaded, so no other check could run
+func ConfigFailed(err error) Report {
+	return Report{
+		Status: StatusFail,
+		Checks: []CheckResult{{Name: CheckConfig, Status: StatusFail, Error: err.Error()}},
+	}
+}
+
+// Run runs the checks in order, each bounded by timeout. All checks run even
+// when one fails, so a single report lists every problem.
+func Run(ctx context.Context, checks []Check, timeout time.Duration) Report {
+	report := Report{Status: StatusOK}
+	for _, check := range checks {
+		result := runCheck(ctx, check, timeout)
+		if result.Status != StatusOK {
+			report.Status = StatusFail
+		}
+		report.Checks = append(report.Checks, result)
+	}
+	return report
+}
+
+func runCheck(ctx context.Context, check Check, timeout time.Duration) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Run(ctx)
+	result := CheckResult{
+		Name:       check.Name,
+		Status:     StatusOK,
+		DurationMS: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,188 @@
+package probe
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/config"
+)
+
+func TestRun(t *testing.T) {
+	report := Run(context.Background(), []Check{
+		{Name: "first", Run: func(context.Context) error { return nil }},
+		{Name: "second", Run: func(context.Context) error { return errors.New("connection refused") }},
+		{Name: "third", Run: func(context.Context) error { return nil }},
+	}, time.Second)
+
+	assert.False(t, report.OK())
+	assert.Equal(t, StatusFail, report.Status)
+	assert.Len(t, report.Checks, 3)
+	assert.Equal(t, StatusOK, report.Checks[0].Status)
+	assert.Equal(t, StatusFail, report.Checks[1].Status)
+	assert.Equal(t, "connection refused", report.Checks[1].Error)
+	assert.Equal(t, StatusOK, report.Checks[2].Status)
+}
+
+func TestRun_Timeout(t *testing.T) {
+	report := Run(context.Background(), []Check{
+		{Name: "hung", Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+	}, 10*time.Millisecond)
+
+	assert.False(t, report.OK())
+	assert.Contains(t, report.Checks[0].Error, "deadline exceeded")
+}
+
+func TestConfigFailed(t *testing.T) {
+	report := ConfigFailed(errors.New("SLACK_SIGNING_SECRET is required"))
+
+	assert.False(t, report.OK())
+	assert.Equal(t, []CheckResult{{Name: CheckConfig, Status: StatusFail, Error: "SLACK_SIGNING_SECRET is required"}}, report.Checks)
+}
+
+func TestProber_CheckSlack(t *testing.T) {
+	allScopes := strings.Join(RequiredSlackScopes, ",")
+	tests := []struct {
+		name      string
+		token     string
+		body      string
+		scopes    string
+		wantError string
+	}{
+		{
+			name:   "all scopes granted",
+			token:  "xoxb-token",
+			body:   `{"ok":true}`,
+			scopes: allScopes,
+		},
+		{
+			name:      "missing scopes",
+			token:     "xoxb-token",
+			body:      `{"ok":true}`,
+			scopes:    "chat:write,users:read",
+			wantError: "missing scopes: app_mentions:read",
+		},
+		{
+			name:      "invalid token",
+			token:     "xoxb-token",
+			body:      `{"ok":false,"error":"invalid_auth"}`,
+			wantError: "invalid_auth",
+		},
+		{
+			name:      "token not set",
+			wantError: "SLACK_BOT_TOKEN is not set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/auth.test", r.URL.Path)
+				assert.Equal(t, "Bearer "+tt.token, r.Header.Get("Authorization"))
+				w.Header().Set("X-OAuth-Scopes", tt.scopes)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			prober := NewProber(&config.Config{Slack: config.SlackConfig{BotToken: tt.token}})
+			prober.slackAPIURL = server.URL
+
+			err := prober.checkSlack(context.Background())
+
+			if tt.wantError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestProber_CheckAIProvider(t *testing.T) {
+	tests := []struct {
+		name      string
+		provider  string
+		status    int
+		wantPath  string
+		wantError string
+	}{
+		{
+			name:     "gemini key accepted",
+			provider: "gemini",
+			status:   http.StatusOK,
+			wantPath: "/models/gemini-1.5-flash",
+		},
+		{
+			name:      "gemini key rejected",
+			provider:  "gemini",
+			status:    http.StatusBadRequest,
+			wantPath:  "/models/gemini-1.5-flash",
+			wantError: "credentials rejected",
+		},
+		{
+			name:     "openai key accepted",
+			provider: "openai",
+			status:   http.StatusOK,
+			wantPath: "/models/gpt-4o-mini",
+		},
+		{
+			name:      "openai model not found",
+			provider:  "openai",
+			status:    http.StatusNotFound,
+			wantPath:  "/models/gpt-4o-mini",
+			wantError: "model not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.wantPath, r.URL.Path)
+				if tt.provider == "gemini" {
+					assert.Equal(t, "gemini-key", r.Header.Get("x-goog-api-key"))
+				} else {
+					assert.Equal(t, "Bearer openai-key", r.Header.Get("Authorization"))
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			prober := NewProber(&config.Config{
+				Gemini: config.GeminiConfig{APIKey: "gemini-key", Model: "gemini-1.5-flash"},
+				OpenAI: config.OpenAIConfig{APIKey: "openai-key", Model: "gpt-4o-mini", BaseURL: server.URL},
+			})
+			prober.geminiAPIURL = server.URL
+
+			err := prober.checkAIProvider(context.Background(), tt.provider)
+
+			if tt.wantError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestProber_Checks(t *testing.T) {
+	prober := NewProber(&config.Config{
+		Platforms: []string{config.PlatformTeams},
+		AI:        config.AIConfig{Provider: "gemini", Fallbacks: []string{"openai"}},
+	})
+
+	var names []string
+	for _, check := range prober.Checks() {
+		names = append(names, check.Name)
+	}
+
+	assert.Equal(t, []string{CheckConfig, CheckDatabase, CheckRedis, "ai:gemini", "ai:openai"}, names)
+}
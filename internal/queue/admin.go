@@ -0,0 +1,222 @@
+package queue
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+// queueState tracks a channel queue for the admin API: the messages not
+// processed yet, the last processed message, and whether the queue is paused
+// or has flushed messages still to be skipped by its worker.
+type queueState struct {
+	mu              sync.Mutex
+	pending         map[*model.MessageEvent]time.Time // unprocessed events and since when they wait
+	flushed         map[*model.MessageEvent]bool      // events the worker skips when it reaches them
+	paused          bool
+	resumed         chan struct{} // closed when a paused queue is resumed
+	lastProcessedTS string
+	lastProcessedAt time.Time
+}
+
+func newQueueState() *queueState {
+	return &queueState{
+		pending: make(map[*model.MessageEvent]time.Time),
+		flushed: make(map[*model.MessageEvent]bool),
+	}
+}
+
+// state returns the state of a queue, creating it if needed
+func (wp *WorkerPool) state(queueKey string) *queueState {
+	state, _ := wp.states.LoadOrStore(queueKey, newQueueState())
+	return state.(*queueState)
+}
+
+func (s *queueState) add(event *model.MessageEvent) {
+	since := event.ReceivedAt
+	if since.IsZero() {
+		since = time.Now()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending[event] = since
+}
+
+// remove forgets an event that was dropped without being processed
+func (s *queueState) remove(event *model.MessageEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.pending, event)
+}
+
+// takeFlushed reports whether the event was flushed and forgets it
+func (s *queueState) takeFlushed(event *model.MessageEvent) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.flushed[event] {
+		return false
+	}
+	delete(s.flushed, event)
+	return true
+}
+
+func (s *queueState) processed(event *model.MessageEvent, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.pending, event)
+	if event.MessageTS != "" {
+		s.lastProcessedTS = event.MessageTS
+	}
+	s.lastProcessedAt = now
+}
+
+func (s *queueState) isPaused() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.paused
+}
+
+// waitWhilePaused blocks while the queue is paused, until it is resumed or
+// the pool shuts down
+func (s *queueState) waitWhilePaused(shutdown <-chan struct{}) {
+	s.mu.Lock()
+	if !s.paused {
+		s.mu.Unlock()
+		return
+	}
+	resumed := s.resumed
+	s.mu.Unlock()
+
+	select {
+	case <-resumed:
+	case <-shutdown:
+	}
+}
+
+// Queues returns the status of every channel queue, sorted by key. Paused
+// queues are listed even when their worker has exited.
+func (wp *WorkerPool) Queues() []model.QueueStatus {
+	now := time.Now()
+	var statuses []model.QueueStatus
+	wp.states.Range(func(key, value interface{}) bool {
+		queueKey := key.(string)
+		state := value.(*queueState)
+
+		status := model.QueueStatus{Key: queueKey}
+		if h, ok := wp.workers.Load(queueKey); ok {
+			_, _, status.Processing = h.(*workerHandle).inFlight()
+		}
+
+		state.mu.Lock()
+		status.Depth = len(state.pending)
+		for _, since := range state.pending {
+			if age := now.Sub(since).Milliseconds(); age > status.OldestMessageAgeMS {
+				status.OldestMessageAgeMS = age
+			}
+		}
+		status.Paused = state.paused
+		status.LastProcessedTS = state.lastProcessedTS
+		if !state.lastProcessedAt.IsZero() {
+			lastProcessedAt := state.lastProcessedAt
+			status.LastProcessedAt = &lastProcessedAt
+		}
+		state.mu.Unlock()
+
+		statuses = append(statuses, status)
+		return true
+	})
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Key < statuses[j].Key })
+	return statuses
+}
+
+// Backlog returns the number of messages of every channel queue not
+// processed yet, including those in flight
+func (wp *WorkerPool) Backlog(context.Context) (int64, error) {
+	var backlog int64
+	for _, status := range wp.Queues() {
+		backlog += int64(status.Depth)
+	}
+	return backlog, nil
+}
+
+// FlushQueue drops the messages waiting in a queue, leaving the one in
+// flight alone, and returns how many were dropped
+func (wp *WorkerPool) FlushQueue(queueKey string) (int, error) {
+	state, err := wp.existingState(queueKey)
+	if err != nil {
+		return 0, err
+	}
+
+	var inFlight *model.MessageEvent
+	if h, ok := wp.workers.Load(queueKey); ok {
+		inFlight, _, _ = h.(*workerHandle).inFlight()
+	}
+
+	state.mu.Lock()
+	flushed := 0
+	for event := range state.pending {
+		if event == inFlight {
+			continue
+		}
+		delete(state.pending, event)
+		state.flushed[event] = true
+		flushed++
+	}
+	state.mu.Unlock()
+
+	wp.logger.Warn("Queue flushed by admin",
+		zap.String("queue_key", queueKey),
+		zap.Int("dropped", flushed))
+	return flushed, nil
+}
+
+// PauseQueue stops a queue's worker before its next message. Messages keep
+// queueing up to the buffer size; later ones are dropped until the queue is
+// resumed. Shutdown still drains a paused queue.
+func (wp *WorkerPool) PauseQueue(queueKey string) error {
+	state, err := wp.existingState(queueKey)
+	if err != nil {
+		return err
+	}
+
+	state.mu.Lock()
+	if !state.paused {
+		state.paused = true
+		state.resumed = make(chan struct{})
+	}
+	state.mu.Unlock()
+
+	wp.logger.Warn("Queue paused by admin", zap.String("queue_key", queueKey))
+	return nil
+}
+
+// ResumeQueue lets a paused queue's worker continue
+func (wp *WorkerPool) ResumeQueue(queueKey string) error {
+	state, err := wp.existingState(queueKey)
+	if err != nil {
+		return err
+	}
+
+	state.mu.Lock()
+	if state.paused {
+		state.paused = false
+		close(state.resumed)
+	}
+	state.mu.Unlock()
+
+	wp.logger.Info("Queue resumed by admin", zap.String("queue_key", queueKey))
+	return nil
+}
+
+func (wp *WorkerPool) existingState(queueKey string) (*queueState, error) {
+	state, ok := wp.states.Load(queueKey)
+	if !ok {
+		return nil, model.NewNotFoundError("queue not found")
+	}
+	return state.(*queueState), nil
+}
@@ -0,0 +1,137 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+// blockingEventProcessor blocks on the "block" message until released
+type blockingEventProcessor struct {
+	started   chan struct{}
+	release   chan struct{}
+	mu        sync.Mutex
+	processed []string
+}
+
+func (p *blockingEventProcessor) ProcessEvent(ctx context.Context, payload map[string]interface{}) {
+	event, _ := payload["event"].(map[string]interface{})
+	ts, _ := event["ts"].(string)
+
+	if ts == "block" {
+		close(p.started)
+		<-p.release
+	}
+
+	p.mu.Lock()
+	p.processed = append(p.processed, ts)
+	p.mu.Unlock()
+}
+
+func (p *blockingEventProcessor) processedTS() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.processed...)
+}
+
+func enqueueTS(wp *WorkerPool, ts string) {
+	wp.Enqueue(&model.MessageEvent{
+		EventID:    "evt-" + ts,
+		ChannelID:  "C123",
+		MessageTS:  ts,
+		Payload:    map[string]interface{}{"event": map[string]interface{}{"ts": ts}},
+		ReceivedAt: time.Now(),
+	})
+}
+
+func waitForProcessed(t *testing.T, processor *blockingEventProcessor, count int) []string {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if processed := processor.processedTS(); len(processed) >= count {
+			return processed
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Expected %d processed messages, got %v", count, processor.processedTS())
+	return nil
+}
+
+func TestWorkerPool_QueueAdmin(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	processor := &blockingEventProcessor{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	workerPool := NewWorkerPool(processor, 10, 1*time.Minute, logger)
+	defer func() {
+		_ = workerPool.Shutdown(5 * time.Second)
+	}()
+
+	enqueueTS(workerPool, "block")
+	<-processor.started
+	enqueueTS(workerPool, "1000.000002")
+	enqueueTS(workerPool, "1000.000003")
+
+	queues := workerPool.Queues()
+	if len(queues) != 1 {
+		t.Fatalf("Expected 1 queue, got %d", len(queues))
+	}
+	if queues[0].Key != "C123" || queues[0].Depth != 3 || !queues[0].Processing {
+		t.Errorf("Expected C123 processing with depth 3, got %+v", queues[0])
+	}
+
+	if err := workerPool.PauseQueue("C123"); err != nil {
+		t.Fatalf("Unexpected pause error: %v", err)
+	}
+	dropped, err := workerPool.FlushQueue("C123")
+	if err != nil {
+		t.Fatalf("Unexpected flush error: %v", err)
+	}
+	if dropped != 2 {
+		t.Errorf("Expected the 2 waiting messages to be flushed, got %d", dropped)
+	}
+
+	// The in-flight message completes; the paused queue holds the next one
+	enqueueTS(workerPool, "1000.000004")
+	close(processor.release)
+	waitForProcessed(t, processor, 1)
+	time.Sleep(50 * time.Millisecond)
+
+	queues = workerPool.Queues()
+	if !queues[0].Paused || queues[0].Depth != 1 || queues[0].LastProcessedTS != "block" {
+		t.Errorf("Expected paused queue holding 1 message after block, got %+v", queues[0])
+	}
+	if processed := processor.processedTS(); len(processed) != 1 {
+		t.Errorf("Expected paused queue not to process, got %v", processed)
+	}
+
+	if err := workerPool.ResumeQueue("C123"); err != nil {
+		t.Fatalf("Unexpected resume error: %v", err)
+	}
+	processed := waitForProcessed(t, processor, 2)
+	if processed[1] != "1000.000004" {
+		t.Errorf("Expected flushed messages to be skipped, got %v", processed)
+	}
+}
+
+func TestWorkerPool_QueueAdminUnknownQueue(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	workerPool := NewWorkerPool(&blockingEventProcessor{}, 10, 1*time.Minute, logger)
+	defer func() {
+		_ = workerPool.Shutdown(5 * time.Second)
+	}()
+
+	var domainErr *model.DomainError
+	if _, err := workerPool.FlushQueue("C404"); !errors.As(err, &domainErr) || domainErr.Type != model.ErrorTypeNotFound {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+	if err := workerPool.PauseQueue("C404"); !errors.As(err, &domainErr) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
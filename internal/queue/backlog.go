@@ -0,0 +1,91 @@
+package queue
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// backlogNoticeCooldown is the minimum time between two backlog notices in
+// the same channel, so a queue hovering around the threshold does not spam it
+const backlogNoticeCooldown = 15 * time.Minute
+
+// BacklogNotifier tells a channel that its translations are delayed
+type BacklogNotifier interface {
+	NotifyBacklog(channelID string, depth int)
+}
+
+// backlogTracker decides when a channel queue is far enough behind to notify
+// the channel. A channel is notified once when its queue reaches the
+// threshold and not again until the queue has drained.
+type backlogTracker struct {
+	notifier  BacklogNotifier
+	threshold int
+
+	mu         sync.Mutex
+	behind     map[string]bool
+	notifiedAt map[string]time.Time
+}
+
+func newBacklogTracker(notifier BacklogNotifier, threshold int) *backlogTracker {
+	return &backlogTracker{
+		notifier:   notifier,
+		threshold:  threshold,
+		behind:     make(map[string]bool),
+		notifiedAt: make(map[string]time.Time),
+	}
+}
+
+// observe records the depth of a queue and reports whether the channel should be notified
+func (b *backlogTracker) observe(queueKey string, depth int, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if depth == 0 {
+		delete(b.behind, queueKey)
+		return false
+	}
+	if depth < b.threshold || b.behind[queueKey] {
+		return false
+	}
+	b.behind[queueKey] = true
+
+	if last, ok := b.notifiedAt[queueKey]; ok && now.Sub(last) < backlogNoticeCooldown {
+		return false
+	}
+	b.notifiedAt[queueKey] = now
+	return true
+}
+
+// SetBacklogNotice posts a notice to a channel once its queue holds threshold
+// or more messages, instead of letting its translations lag silently.
+// It must be called before the first Enqueue.
+func (wp *WorkerPool) SetBacklogNotice(notifier BacklogNotifier, threshold int) {
+	if notifier == nil || threshold <= 0 {
+		wp.backlog = nil
+		return
+	}
+	wp.backlog = newBacklogTracker(notifier, threshold)
+}
+
+// observeQueueDepth records the depth of a channel queue and notifies the
+// channel when it has fallen behind
+func (wp *WorkerPool) observeQueueDepth(queueKey string, depth int) {
+	if wp.metrics != nil {
+		wp.metrics.RecordQueueDepth(queueKey, depth)
+	}
+	if wp.backlog == nil || !wp.backlog.observe(queueKey, depth, time.Now()) {
+		return
+	}
+
+	wp.logger.Warn("Channel queue backed up, notifying channel",
+		zap.String("queue_key", queueKey),
+		zap.Int("depth", depth),
+		zap.Int("threshold", wp.backlog.threshold))
+	if wp.metrics != nil {
+		wp.metrics.RecordError("queue_backlog_notice")
+	}
+	// Post outside the enqueue path so the Slack webhook is acknowledged quickly
+	go wp.backlog.notifier.NotifyBacklog(queueKey, depth)
+}
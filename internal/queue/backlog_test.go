@@ -0,0 +1,85 @@
+package queue
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+type recordingBacklogNotifier struct {
+	mu       sync.Mutex
+	channels []string
+}
+
+func (n *recordingBacklogNotifier) NotifyBacklog(channelID string, depth int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.channels = append(n.channels, channelID)
+}
+
+func (n *recordingBacklogNotifier) notified() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]string(nil), n.channels...)
+}
+
+func TestBacklogTracker_Observe(t *testing.T) {
+	now := time.Now()
+	tracker := newBacklogTracker(&recordingBacklogNotifier{}, 3)
+
+	steps := []struct {
+		depth int
+		at    time.Time
+		want  bool
+	}{
+		{depth: 2, at: now, want: false},
+		{depth: 3, at: now, want: true},
+		{depth: 5, at: now, want: false},                  // still behind
+		{depth: 0, at: now, want: false},                  // drained
+		{depth: 4, at: now.Add(time.Minute), want: false}, // behind again within the cooldown
+		{depth: 0, at: now.Add(time.Minute), want: false}, // drained
+		{depth: 3, at: now.Add(backlogNoticeCooldown + time.Second), want: true},
+	}
+
+	for i, step := range steps {
+		if got := tracker.observe("C123", step.depth, step.at); got != step.want {
+			t.Errorf("step %d (depth %d): expected %v, got %v", i, step.depth, step.want, got)
+		}
+	}
+}
+
+func TestWorkerPool_BacklogNotice(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	processor := newMockEventProcessor(50 * time.Millisecond)
+	workerPool := NewWorkerPool(processor, 20, 1*time.Minute, logger)
+	notifier := &recordingBacklogNotifier{}
+	workerPool.SetBacklogNotice(notifier, 3)
+	m := metrics.NewMetrics()
+	workerPool.SetMetrics(m)
+	defer func() {
+		_ = workerPool.Shutdown(5 * time.Second)
+	}()
+
+	for i := 0; i < 8; i++ {
+		workerPool.Enqueue(&model.MessageEvent{
+			EventID:    fmt.Sprintf("evt%d", i),
+			ChannelID:  "C123",
+			MessageTS:  fmt.Sprintf("1000.%03d", i),
+			Payload:    map[string]interface{}{"event": map[string]interface{}{"ts": fmt.Sprintf("1000.%03d", i)}},
+			ReceivedAt: time.Now(),
+		})
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if notified := notifier.notified(); len(notified) != 1 || notified[0] != "C123" {
+		t.Errorf("Expected one backlog notice for C123, got %v", notified)
+	}
+	if m.ErrorsByType["queue_backlog_notice"] != 1 {
+		t.Errorf("Expected 1 backlog notice metric, got %d", m.ErrorsByType["queue_backlog_notice"])
+	}
+}
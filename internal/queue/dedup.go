@@ -0,0 +1,196 @@
+package queue
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultEventDedupTTL covers Slack's retries of an event, the last of
+	// which arrives minutes after the first delivery
+	DefaultEventDedupTTL = 10 * time.Minute
+	// DefaultEventDedupMaxEntries bounds the event IDs remembered in memory
+	DefaultEventDedupMaxEntries = 100000
+	eventDedupTimeout           = 2 * time.Second
+)
+
+// EventDeduplicator remembers the IDs of the events delivered for a while, so
+// an event Slack delivers again is dropped
+type EventDeduplicator interface {
+	// FirstSeen records the delivery of the event by owner, the delivery's
+	// stream ID, and returns false when another delivery of the event was
+	// seen first. The same owner seeing the event again gets true, so an
+	// event redelivered by the durable queue after a crash is processed.
+	FirstSeen(ctx context.Context, eventID, owner string) (bool, error)
+}
+
+// MemoryEventDeduplicator remembers event IDs in memory, local to the
+// instance, for a TTL and at most a fixed number of them, the oldest being
+// forgotten first
+type MemoryEventDeduplicator struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List // of *seenEvent, oldest first
+	now        func() time.Time
+}
+
+type seenEvent struct {
+	id        string
+	owner     string
+	expiresAt time.Time
+}
+
+// NewMemoryEventDeduplicator remembers up to maxEntries event IDs for ttl
+func NewMemoryEventDeduplicator(ttl time.Duration, maxEntries int) *MemoryEventDeduplicator {
+	return &MemoryEventDeduplicator{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		now:        time.Now,
+	}
+}
+
+func (d *MemoryEventDeduplicator) FirstSeen(_ context.Context, eventID, owner string) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	d.expire(now)
+
+	if element, ok := d.entries[eventID]; ok {
+		seen := element.Value.(*seenEvent)
+		return owner != "" && seen.owner == owner, nil
+	}
+
+	for len(d.entries) >= d.maxEntries && d.order.Len() > 0 {
+		d.remove(d.order.Front())
+	}
+	d.entries[eventID] = d.order.PushBack(&seenEvent{id: eventID, owner: owner, expiresAt: now.Add(d.ttl)})
+	return true, nil
+}
+
+// Len returns the number of event IDs remembered
+func (d *MemoryEventDeduplicator) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.entries)
+}
+
+// expire forgets the event IDs seen longer than the TTL ago. Every ID has the
+// same TTL, so they expire in the order they were seen.
+func (d *MemoryEventDeduplicator) expire(now time.Time) {
+	for element := d.order.Front(); element != nil; element = d.order.Front() {
+		if now.Before(element.Value.(*seenEvent).expiresAt) {
+			return
+		}
+		d.remove(element)
+	}
+}
+
+func (d *MemoryEventDeduplicator) remove(element *list.Element) {
+	d.order.Remove(element)
+	delete(d.entries, element.Value.(*seenEvent).id)
+}
+
+// RedisEventDeduplicator remembers event IDs in Redis, shared by every
+// instance, so a retry delivered to another instance is dropped too
+type RedisEventDeduplicator struct {
+	client    *redis.Client
+	ttl       time.Duration
+	keyPrefix string
+	instance  string
+}
+
+// NewRedisEventDeduplicator remembers event IDs in Redis for ttl
+func NewRedisEventDeduplicator(client *redis.Client, ttl time.Duration) *RedisEventDeduplicator {
+	return &RedisEventDeduplicator{
+		client:   client,
+		ttl:      ttl,
+		instance: newInstanceToken(),
+	}
+}
+
+// SetKeyPrefix namespaces the event IDs, e.g. with CACHE_KEY_PREFIX, so
+// deployments sharing a Redis do not drop each other's events
+func (d *RedisEventDeduplicator) SetKeyPrefix(prefix string) {
+	d.keyPrefix = prefix
+}
+
+func (d *RedisEventDeduplicator) FirstSeen(ctx context.Context, eventID, owner string) (bool, error) {
+	// An event that was not read from the durable queue stands for this
+	// instance, and is never redelivered
+	holder := owner
+	if holder == "" {
+		holder = d.instance
+	}
+	ctx, cancel := context.WithTimeout(ctx, eventDedupTimeout)
+	defer cancel()
+
+	key := d.key(eventID)
+	first, err := d.client.SetNX(ctx, key, holder, d.ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to record event: %w", err)
+	}
+	if first {
+		return true, nil
+	}
+
+	seenBy, err := d.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		// The event was forgotten in between; record it again
+		return d.client.SetNX(ctx, key, holder, d.ttl).Result()
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read event: %w", err)
+	}
+	return owner != "" && seenBy == owner, nil
+}
+
+func (d *RedisEventDeduplicator) key(eventID string) string {
+	key := "event:" + eventID
+	if d.keyPrefix == "" {
+		return key
+	}
+	return d.keyPrefix + ":" + key
+}
+
+// SetEventDeduplicator remembers the events delivered with dedup, e.g. a
+// RedisEventDeduplicator shared by every instance, instead of in memory.
+// While dedup fails, events are deduplicated in memory. It must be called
+// before the first Enqueue.
+func (wp *WorkerPool) SetEventDeduplicator(dedup EventDeduplicator) {
+	wp.dedup = dedup
+}
+
+// SetEventDedupLimits remembers event_ids in memory for ttl, and at most
+// maxEntries of them. It must be called before the first Enqueue.
+func (wp *WorkerPool) SetEventDedupLimits(ttl time.Duration, maxEntries int) {
+	wp.localDedup = NewMemoryEventDeduplicator(ttl, maxEntries)
+}
+
+// firstSeen reports whether the event was not delivered before
+func (wp *WorkerPool) firstSeen(event *model.MessageEvent) bool {
+	if wp.dedup != nil {
+		first, err := wp.dedup.FirstSeen(context.Background(), event.EventID, event.StreamID)
+		if err == nil {
+			return first
+		}
+		wp.logger.Warn("Failed to deduplicate event, remembering it in memory",
+			zap.String("event_id", event.EventID),
+			zap.Error(err))
+		wp.recordError("event_dedup_failed")
+	}
+	first, _ := wp.localDedup.FirstSeen(context.Background(), event.EventID, event.StreamID)
+	return first
+}
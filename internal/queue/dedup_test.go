@@ -0,0 +1,123 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMemoryEventDeduplicator_ForgetsExpiredAndOldest(t *testing.T) {
+	ctx := context.Background()
+	dedup := NewMemoryEventDeduplicator(time.Minute, 3)
+	now := time.Now()
+	dedup.now = func() time.Time { return now }
+
+	first, _ := dedup.FirstSeen(ctx, "Ev1", "")
+	assert.True(t, first)
+	first, _ = dedup.FirstSeen(ctx, "Ev1", "")
+	assert.False(t, first)
+
+	// The store never holds more than its limit, forgetting the oldest first
+	for i := 2; i <= 10; i++ {
+		_, _ = dedup.FirstSeen(ctx, fmt.Sprintf("Ev%d", i), "")
+	}
+	assert.Equal(t, 3, dedup.Len())
+	first, _ = dedup.FirstSeen(ctx, "Ev10", "")
+	assert.False(t, first)
+
+	// Event IDs are forgotten after the TTL
+	now = now.Add(time.Minute)
+	first, _ = dedup.FirstSeen(ctx, "Ev10", "")
+	assert.True(t, first)
+	assert.Equal(t, 1, dedup.Len())
+}
+
+func TestMemoryEventDeduplicator_Redelivery(t *testing.T) {
+	ctx := context.Background()
+	dedup := NewMemoryEventDeduplicator(time.Minute, 10)
+
+	first, _ := dedup.FirstSeen(ctx, "Ev1", "1-0")
+	assert.True(t, first)
+	// A Slack retry is queued again under another stream ID
+	first, _ = dedup.FirstSeen(ctx, "Ev1", "2-0")
+	assert.False(t, first)
+	// The same stream entry redelivered is processed
+	first, _ = dedup.FirstSeen(ctx, "Ev1", "1-0")
+	assert.True(t, first)
+}
+
+func TestRedisEventDeduplicator_SharedByInstances(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = client.Close() }()
+	ctx := context.Background()
+
+	instanceA := NewRedisEventDeduplicator(client, time.Minute)
+	instanceA.SetKeyPrefix("staging")
+	instanceB := NewRedisEventDeduplicator(client, time.Minute)
+	instanceB.SetKeyPrefix("staging")
+
+	first, err := instanceA.FirstSeen(ctx, "Ev1", "")
+	require.NoError(t, err)
+	assert.True(t, first)
+	assert.Equal(t, time.Minute, mr.TTL("staging:event:Ev1"))
+
+	// A retry delivered to another instance, or to the same one, is dropped
+	first, err = instanceB.FirstSeen(ctx, "Ev1", "")
+	require.NoError(t, err)
+	assert.False(t, first)
+	first, err = instanceA.FirstSeen(ctx, "Ev1", "")
+	require.NoError(t, err)
+	assert.False(t, first)
+
+	// A stream entry redelivered after a crash is processed
+	first, err = instanceA.FirstSeen(ctx, "Ev2", "1-0")
+	require.NoError(t, err)
+	assert.True(t, first)
+	first, err = instanceB.FirstSeen(ctx, "Ev2", "1-0")
+	require.NoError(t, err)
+	assert.True(t, first)
+
+	mr.FastForward(time.Minute)
+	first, err = instanceB.FirstSeen(ctx, "Ev1", "")
+	require.NoError(t, err)
+	assert.True(t, first)
+}
+
+func TestWorkerPool_DeduplicatesInMemoryWhileRedisFails(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = client.Close() }()
+	mr.Close()
+
+	processor := newMockEventProcessor(0)
+	wp := NewWorkerPool(processor, 10, time.Second, zap.NewNop())
+	m := metrics.NewMetrics()
+	wp.SetMetrics(m)
+	wp.SetEventDeduplicator(NewRedisEventDeduplicator(client, time.Minute))
+
+	for i := 0; i < 2; i++ {
+		wp.Enqueue(&model.MessageEvent{
+			EventID:   "Ev1",
+			ChannelID: "C123",
+			MessageTS: "1000.000001",
+			Payload:   map[string]interface{}{"event": map[string]interface{}{"ts": "1000.000001"}},
+		})
+	}
+	require.NoError(t, wp.Shutdown(5*time.Second))
+
+	assert.Equal(t, int32(1), processor.getCallCount())
+	assert.Equal(t, int64(2), m.ErrorsByType["event_dedup_failed"])
+}
@@ -0,0 +1,78 @@
+package queue
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// maintenancePollInterval is how often held queues check whether maintenance is over
+var maintenancePollInterval = 5 * time.Second
+
+// MaintenanceMode reports whether the bot is in maintenance
+type MaintenanceMode interface {
+	IsActive() bool
+}
+
+// MaintenanceNotifier tells a channel that its translations are held for maintenance
+type MaintenanceNotifier interface {
+	NotifyMaintenance(channelID string)
+}
+
+// maintenanceGate holds a queue during maintenance. A nil gate never holds.
+type maintenanceGate struct {
+	mode     MaintenanceMode
+	notifier MaintenanceNotifier
+}
+
+func newMaintenanceGate(mode MaintenanceMode, notifier MaintenanceNotifier) *maintenanceGate {
+	if mode == nil {
+		return nil
+	}
+	return &maintenanceGate{mode: mode, notifier: notifier}
+}
+
+func (g *maintenanceGate) active() bool {
+	return g != nil && g.mode.IsActive()
+}
+
+// notify tells a channel about the maintenance without blocking the caller
+func (g *maintenanceGate) notify(channelID string) {
+	if g == nil || g.notifier == nil || channelID == "" {
+		return
+	}
+	go g.notifier.NotifyMaintenance(channelID)
+}
+
+// wait blocks while maintenance is on, until it ends or stop is closed
+func (g *maintenanceGate) wait(stop <-chan struct{}) {
+	ticker := time.NewTicker(maintenancePollInterval)
+	defer ticker.Stop()
+	for g.active() {
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// SetMaintenance holds every queue while mode is active: events are still
+// queued, up to the buffer size, but their workers wait for maintenance to
+// end, then drain the backlog in order. Channels receiving events meanwhile
+// are passed to notifier, which may be nil. It must be called before the
+// first Enqueue.
+func (wp *WorkerPool) SetMaintenance(mode MaintenanceMode, notifier MaintenanceNotifier) {
+	wp.maintenance = newMaintenanceGate(mode, notifier)
+}
+
+// waitWhileMaintenance blocks while maintenance is on, until it ends or the
+// pool shuts down
+func (wp *WorkerPool) waitWhileMaintenance(queueKey string) {
+	if !wp.maintenance.active() {
+		return
+	}
+	wp.logger.Info("Maintenance mode on, holding queue", zap.String("queue_key", queueKey))
+	wp.maintenance.wait(wp.shutdown)
+	wp.logger.Info("Maintenance mode off, resuming queue", zap.String("queue_key", queueKey))
+}
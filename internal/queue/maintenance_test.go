@@ -0,0 +1,66 @@
+package queue
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type fakeMaintenance struct {
+	active atomic.Bool
+
+	mu       sync.Mutex
+	notified []string
+}
+
+func (f *fakeMaintenance) IsActive() bool {
+	return f.active.Load()
+}
+
+func (f *fakeMaintenance) NotifyMaintenance(channelID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.notified = append(f.notified, channelID)
+}
+
+func (f *fakeMaintenance) notifiedChannels() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.notified...)
+}
+
+func TestWorkerPool_HoldsQueuesDuringMaintenance(t *testing.T) {
+	previous := maintenancePollInterval
+	maintenancePollInterval = 10 * time.Millisecond
+	defer func() { maintenancePollInterval = previous }()
+
+	processor := &blockingEventProcessor{}
+	maintenance := &fakeMaintenance{}
+	maintenance.active.Store(true)
+
+	workerPool := NewWorkerPool(processor, 10, 1*time.Minute, zap.NewNop())
+	workerPool.SetMaintenance(maintenance, maintenance)
+	defer func() {
+		_ = workerPool.Shutdown(5 * time.Second)
+	}()
+
+	enqueueTS(workerPool, "1000.000001")
+	enqueueTS(workerPool, "1000.000002")
+
+	time.Sleep(50 * time.Millisecond)
+	if processed := processor.processedTS(); len(processed) != 0 {
+		t.Fatalf("Expected no messages processed during maintenance, got %v", processed)
+	}
+	if notified := maintenance.notifiedChannels(); len(notified) != 2 || notified[0] != "C123" {
+		t.Errorf("Expected the channel to be passed to the notifier for each event, got %v", notified)
+	}
+
+	maintenance.active.Store(false)
+	processed := waitForProcessed(t, processor, 2)
+	if processed[0] != "1000.000001" || processed[1] != "1000.000002" {
+		t.Errorf("Expected the backlog to drain in order, got %v", processed)
+	}
+}
@@ -0,0 +1,138 @@
+package queue
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+// Message ordering outcomes reported to metrics
+const (
+	OrderingInOrder    = "in_order"
+	OrderingOutOfOrder = "out_of_order"
+	OrderingReordered  = "reordered"
+)
+
+// reorderBuffer holds events of one channel for a short window so that
+// messages delivered out of order (e.g. by Slack retries) are released in
+// their original ts order. Only message events are reordered; other events
+// keep their arrival position and act as barriers.
+type reorderBuffer struct {
+	window  time.Duration
+	pending []*model.MessageEvent
+}
+
+func newReorderBuffer(window time.Duration) *reorderBuffer {
+	return &reorderBuffer{window: window}
+}
+
+// push inserts the event and reports whether it was placed ahead of
+// an event that arrived earlier.
+func (b *reorderBuffer) push(event *model.MessageEvent) bool {
+	i := len(b.pending)
+	if isMessageEvent(event) {
+		for i > 0 {
+			prev := b.pending[i-1]
+			if !isMessageEvent(prev) || compareTS(prev.MessageTS, event.MessageTS) <= 0 {
+				break
+			}
+			i--
+		}
+	}
+
+	b.pending = append(b.pending, nil)
+	copy(b.pending[i+1:], b.pending[i:])
+	b.pending[i] = event
+
+	return i < len(b.pending)-1
+}
+
+// popReady removes and returns the leading events whose window has elapsed
+func (b *reorderBuffer) popReady(now time.Time) []*model.MessageEvent {
+	n := 0
+	for n < len(b.pending) && !now.Before(b.pending[n].ReceivedAt.Add(b.window)) {
+		n++
+	}
+	return b.take(n)
+}
+
+// flush removes and returns every buffered event
+func (b *reorderBuffer) flush() []*model.MessageEvent {
+	return b.take(len(b.pending))
+}
+
+// nextRelease returns how long until the first event is due, and false when empty
+func (b *reorderBuffer) nextRelease(now time.Time) (time.Duration, bool) {
+	if len(b.pending) == 0 {
+		return 0, false
+	}
+	wait := b.pending[0].ReceivedAt.Add(b.window).Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
+
+func (b *reorderBuffer) take(n int) []*model.MessageEvent {
+	if n == 0 {
+		return nil
+	}
+	ready := make([]*model.MessageEvent, n)
+	copy(ready, b.pending[:n])
+	b.pending = b.pending[n:]
+	return ready
+}
+
+// isMessageEvent reports whether the event is a Slack message, whose ts
+// reflects posting order. Reaction events carry the ts of the reacted message.
+func isMessageEvent(event *model.MessageEvent) bool {
+	eventType := event.EventType
+	if eventType == "" {
+		callback, ok := event.Payload["event"].(map[string]interface{})
+		if !ok {
+			return false
+		}
+		eventType, _ = callback["type"].(string)
+	}
+	return eventType == "message" && event.MessageTS != ""
+}
+
+// compareTS compares two Slack timestamps ("1700000000.123456").
+// It returns 0 when either cannot be parsed.
+func compareTS(a, b string) int {
+	aSec, aMicro, okA := parseTS(a)
+	bSec, bMicro, okB := parseTS(b)
+	if !okA || !okB {
+		return 0
+	}
+
+	switch {
+	case aSec < bSec:
+		return -1
+	case aSec > bSec:
+		return 1
+	case aMicro < bMicro:
+		return -1
+	case aMicro > bMicro:
+		return 1
+	}
+	return 0
+}
+
+func parseTS(ts string) (int64, int64, bool) {
+	secPart, microPart, _ := strings.Cut(ts, ".")
+	sec, err := strconv.ParseInt(secPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if microPart == "" {
+		return sec, 0, true
+	}
+	micro, err := strconv.ParseInt(microPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return sec, micro, true
+}
@@ -0,0 +1,83 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestEvent(eventType, ts string, receivedAt time.Time) *model.MessageEvent {
+	return &model.MessageEvent{
+		ChannelID:  "C123",
+		MessageTS:  ts,
+		Payload:    map[string]interface{}{"event": map[string]interface{}{"type": eventType, "ts": ts}},
+		ReceivedAt: receivedAt,
+	}
+}
+
+func TestCompareTS(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected int
+	}{
+		{name: "earlier seconds", a: "1000.000500", b: "1001.000001", expected: -1},
+		{name: "later micros", a: "1000.000200", b: "1000.000100", expected: 1},
+		{name: "equal", a: "1000.000100", b: "1000.000100", expected: 0},
+		{name: "no fraction", a: "1000", b: "1000.000001", expected: -1},
+		{name: "unparseable", a: "abc", b: "1000.000001", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, compareTS(tt.a, tt.b))
+		})
+	}
+}
+
+func TestReorderBuffer_PushSortsMessages(t *testing.T) {
+	now := time.Now()
+	buffer := newReorderBuffer(50 * time.Millisecond)
+
+	assert.False(t, buffer.push(newTestEvent("message", "1000.000002", now)))
+	assert.True(t, buffer.push(newTestEvent("message", "1000.000001", now)))
+	assert.False(t, buffer.push(newTestEvent("message", "1000.000003", now)))
+
+	var order []string
+	for _, event := range buffer.flush() {
+		order = append(order, event.MessageTS)
+	}
+	assert.Equal(t, []string{"1000.000001", "1000.000002", "1000.000003"}, order)
+}
+
+func TestReorderBuffer_NonMessageEventIsBarrier(t *testing.T) {
+	now := time.Now()
+	buffer := newReorderBuffer(50 * time.Millisecond)
+
+	buffer.push(newTestEvent("message", "1000.000005", now))
+	buffer.push(newTestEvent("reaction_added", "1000.000005", now))
+	assert.False(t, buffer.push(newTestEvent("message", "1000.000001", now)))
+
+	events := buffer.flush()
+	assert.Equal(t, "1000.000005", events[0].MessageTS)
+	assert.Equal(t, "1000.000001", events[2].MessageTS)
+}
+
+func TestReorderBuffer_PopReadyRespectsWindow(t *testing.T) {
+	now := time.Now()
+	buffer := newReorderBuffer(50 * time.Millisecond)
+
+	buffer.push(newTestEvent("message", "1000.000001", now.Add(-100*time.Millisecond)))
+	buffer.push(newTestEvent("message", "1000.000002", now))
+
+	ready := buffer.popReady(now)
+	assert.Len(t, ready, 1)
+	assert.Equal(t, "1000.000001", ready[0].MessageTS)
+
+	wait, ok := buffer.nextRelease(now)
+	assert.True(t, ok)
+	assert.Equal(t, 50*time.Millisecond, wait)
+}
@@ -0,0 +1,138 @@
+package queue
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+// slackPayloadFields are the top-level fields of a Slack payload the event
+// processor reads; the rest (authorizations, event_context, ...) is dropped
+// from large payloads
+var slackPayloadFields = []string{"type", "team_id", "event_id", "event"}
+
+// payloadLimits bounds the size of the raw payloads held in the queues
+type payloadLimits struct {
+	compressAbove int
+	maxBytes      int
+}
+
+// SetPayloadLimits bounds the raw payloads held in the queues. Payloads over
+// compressAbove bytes keep only the fields the event processor needs and are
+// gzip-compressed; events whose payload is still over maxBytes are dropped.
+// Zero disables either limit. It must be called before the first Enqueue.
+func (wp *WorkerPool) SetPayloadLimits(compressAbove, maxBytes int) {
+	if compressAbove <= 0 && maxBytes <= 0 {
+		wp.payloadLimits = nil
+		return
+	}
+	wp.payloadLimits = &payloadLimits{compressAbove: compressAbove, maxBytes: maxBytes}
+}
+
+// guardPayload shrinks a large raw payload and reports whether the event is
+// small enough to be queued
+func (wp *WorkerPool) guardPayload(event *model.MessageEvent) bool {
+	limits := wp.payloadLimits
+	if limits == nil || event.RawPayload == nil || event.Compressed {
+		return true
+	}
+
+	originalSize := len(event.RawPayload)
+	if limits.compressAbove > 0 && originalSize > limits.compressAbove {
+		compacted, err := compressPayload(trimSlackPayload(event.RawPayload))
+		if err != nil {
+			wp.logger.Warn("Failed to compress event payload, queueing it uncompressed",
+				zap.Error(err),
+				zap.String("event_id", event.EventID))
+		} else {
+			event.RawPayload = compacted
+			event.Compressed = true
+			wp.recordError("queue_payload_compressed")
+		}
+	}
+
+	if limits.maxBytes > 0 && len(event.RawPayload) > limits.maxBytes {
+		wp.logger.Warn("Event payload too large, dropping event",
+			zap.String("event_id", event.EventID),
+			zap.String("channel_id", event.ChannelID),
+			zap.String("message_ts", event.MessageTS),
+			zap.Int("payload_bytes", originalSize),
+			zap.Int("stored_bytes", len(event.RawPayload)),
+			zap.Int("max_bytes", limits.maxBytes))
+		wp.recordError("queue_payload_oversized")
+		return false
+	}
+	return true
+}
+
+func (wp *WorkerPool) recordError(errorType string) {
+	if wp.metrics != nil {
+		wp.metrics.RecordError(errorType)
+	}
+}
+
+// trimSlackPayload keeps the top-level fields the event processor reads and
+// drops the message blocks, which repeat its text. A payload that is not a
+// JSON object is returned unchanged.
+func trimSlackPayload(raw []byte) []byte {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return raw
+	}
+
+	trimmed := make(map[string]json.RawMessage, len(slackPayloadFields))
+	for _, name := range slackPayloadFields {
+		if value, ok := fields[name]; ok {
+			trimmed[name] = value
+		}
+	}
+
+	var event map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed["event"], &event); err == nil {
+		if _, ok := event["blocks"]; ok {
+			delete(event, "blocks")
+			if encoded, err := json.Marshal(event); err == nil {
+				trimmed["event"] = encoded
+			}
+		}
+	}
+
+	encoded, err := json.Marshal(trimmed)
+	if err != nil {
+		return raw
+	}
+	return encoded
+}
+
+func compressPayload(raw []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(raw); err != nil {
+		return nil, fmt.Errorf("failed to compress payload: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress payload: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func decompressPayload(compressed []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress payload: %w", err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress payload: %w", err)
+	}
+	return raw, nil
+}
@@ -0,0 +1,105 @@
+package queue
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+func largeSlackPayload(textSize int) []byte {
+	text := strings.Repeat("hello ", textSize/6)
+	payload := map[string]interface{}{
+		"type":     "event_callback",
+		"team_id":  "T1",
+		"event_id": "Ev1",
+		"authorizations": []map[string]interface{}{
+			{"team_id": "T1", "user_id": "U999", "is_bot": true},
+		},
+		"event": map[string]interface{}{
+			"type":    "message",
+			"channel": "C123",
+			"user":    "U456",
+			"ts":      "1000.001",
+			"text":    text,
+			"blocks":  []map[string]interface{}{{"type": "rich_text", "text": text}},
+		},
+	}
+	raw, _ := json.Marshal(payload)
+	return raw
+}
+
+func TestTrimSlackPayload(t *testing.T) {
+	var trimmed map[string]interface{}
+	if err := json.Unmarshal(trimSlackPayload(largeSlackPayload(600)), &trimmed); err != nil {
+		t.Fatalf("Expected trimmed payload to be JSON: %v", err)
+	}
+
+	if _, ok := trimmed["authorizations"]; ok {
+		t.Error("Expected authorizations to be dropped")
+	}
+	event := trimmed["event"].(map[string]interface{})
+	if _, ok := event["blocks"]; ok {
+		t.Error("Expected blocks to be dropped")
+	}
+	if trimmed["event_id"] != "Ev1" || trimmed["team_id"] != "T1" || event["channel"] != "C123" || event["ts"] != "1000.001" {
+		t.Errorf("Expected the fields the processor reads to be kept, got %v", trimmed)
+	}
+
+	if got := trimSlackPayload([]byte("not json")); string(got) != "not json" {
+		t.Errorf("Expected a non-object payload to be unchanged, got %q", got)
+	}
+}
+
+func TestWorkerPool_PayloadLimits(t *testing.T) {
+	tests := []struct {
+		name           string
+		textSize       int
+		maxBytes       int
+		wantProcessed  bool
+		wantCompressed int64
+		wantOversized  int64
+	}{
+		{name: "small payload queued as is", textSize: 100, maxBytes: 64 * 1024, wantProcessed: true},
+		{name: "large payload compressed", textSize: 20 * 1024, maxBytes: 64 * 1024, wantProcessed: true, wantCompressed: 1},
+		{name: "payload over the limit after compression dropped", textSize: 20 * 1024, maxBytes: 10, wantCompressed: 1, wantOversized: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := newMockEventProcessor(0)
+			m := metrics.NewMetrics()
+			workerPool := NewWorkerPool(processor, 10, 1*time.Minute, zap.NewNop())
+			workerPool.SetMetrics(m)
+			workerPool.SetPayloadLimits(4096, tt.maxBytes)
+
+			workerPool.Enqueue(&model.MessageEvent{
+				EventID:    "Ev1",
+				ChannelID:  "C123",
+				MessageTS:  "1000.001",
+				EventType:  "message",
+				RawPayload: largeSlackPayload(tt.textSize),
+				ReceivedAt: time.Now(),
+			})
+			_ = workerPool.Shutdown(5 * time.Second)
+
+			processed := processor.getProcessedEvents()
+			if tt.wantProcessed && (len(processed) != 1 || processed[0] != "1000.001") {
+				t.Errorf("Expected the event to be processed with its payload, got %v", processed)
+			}
+			if !tt.wantProcessed && len(processed) != 0 {
+				t.Errorf("Expected the event to be dropped, got %v", processed)
+			}
+			if m.ErrorsByType["queue_payload_compressed"] != tt.wantCompressed {
+				t.Errorf("Expected %d compressed payload metrics, got %d", tt.wantCompressed, m.ErrorsByType["queue_payload_compressed"])
+			}
+			if m.ErrorsByType["queue_payload_oversized"] != tt.wantOversized {
+				t.Errorf("Expected %d oversized payload metrics, got %d", tt.wantOversized, m.ErrorsByType["queue_payload_oversized"])
+			}
+		})
+	}
+}
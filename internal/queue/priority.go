@@ -0,0 +1,22 @@
+package queue
+
+import (
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+// priorityQueue returns the buffer holding the high-priority events of a
+// queue, creating it on first use. Workers take events from it before the
+// queue's normal buffer, so direct messages and mentions of the bot do not
+// wait behind a channel's bulk traffic.
+func (wp *WorkerPool) priorityQueue(queueKey string) chan *model.MessageEvent {
+	if priorityChan, ok := wp.priority.Load(queueKey); ok {
+		return priorityChan.(chan *model.MessageEvent)
+	}
+	priorityChan, _ := wp.priority.LoadOrStore(queueKey, make(chan *model.MessageEvent, wp.bufferSize))
+	return priorityChan.(chan *model.MessageEvent)
+}
+
+// isHighPriority reports whether event jumps ahead of its queue
+func isHighPriority(event *model.MessageEvent) bool {
+	return event.Priority >= model.PriorityHigh
+}
@@ -0,0 +1,101 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// blockingProcessor records the order of processed events and holds the
+// first one until release is closed
+type blockingProcessor struct {
+	mu      sync.Mutex
+	order   []string
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p *blockingProcessor) ProcessEvent(ctx context.Context, payload map[string]interface{}) {
+	id, _ := payload["id"].(string)
+	p.mu.Lock()
+	first := len(p.order) == 0
+	p.order = append(p.order, id)
+	p.mu.Unlock()
+	if first {
+		close(p.started)
+		<-p.release
+	}
+}
+
+func TestWorkerPool_HighPriorityJumpsAhead(t *testing.T) {
+	processor := &blockingProcessor{started: make(chan struct{}), release: make(chan struct{})}
+	wp := NewWorkerPool(processor, 10, time.Minute, zap.NewNop())
+
+	enqueue := func(id string, priority model.Priority) {
+		wp.Enqueue(&model.MessageEvent{
+			EventID:   id,
+			ChannelID: "C123",
+			MessageTS: "1000.000001",
+			Payload:   map[string]interface{}{"id": id},
+			Priority:  priority,
+		})
+	}
+
+	enqueue("bulk-0", model.PriorityNormal)
+	<-processor.started
+	for i := 1; i <= 3; i++ {
+		enqueue(fmt.Sprintf("bulk-%d", i), model.PriorityNormal)
+	}
+	enqueue("mention", model.PriorityHigh)
+	enqueue("dm", model.PriorityHigh)
+	close(processor.release)
+
+	require.NoError(t, wp.Shutdown(5*time.Second))
+	assert.Equal(t, []string{"bulk-0", "mention", "dm", "bulk-1", "bulk-2", "bulk-3"}, processor.order)
+}
+
+func TestInsertWaiting_HighPriorityFirst(t *testing.T) {
+	var waiting []waitingQueue
+	waiting = insertWaiting(waiting, waitingQueue{queueKey: "C1"})
+	waiting = insertWaiting(waiting, waitingQueue{queueKey: "D1", highPriority: true})
+	waiting = insertWaiting(waiting, waitingQueue{queueKey: "C2"})
+	waiting = insertWaiting(waiting, waitingQueue{queueKey: "D2", highPriority: true})
+
+	var keys []string
+	for _, q := range waiting {
+		keys = append(keys, q.queueKey)
+	}
+	assert.Equal(t, []string{"D1", "D2", "C1", "C2"}, keys)
+}
+
+func TestStreamEvent_CarriesPriority(t *testing.T) {
+	event := newStreamEvent("1000.000001")
+	event.Priority = model.PriorityHigh
+	values, err := encodeStreamEvent(event)
+	require.NoError(t, err)
+
+	fields := make(map[string]interface{}, len(values))
+	for name, value := range values {
+		if b, ok := value.([]byte); ok {
+			value = string(b)
+		}
+		fields[name] = value
+	}
+	decoded, err := decodeStreamEvent(redis.XMessage{ID: "1-0", Values: fields})
+	require.NoError(t, err)
+	assert.Equal(t, model.PriorityHigh, decoded.Priority)
+
+	// Events queued before priorities were added are normal
+	delete(fields, "priority")
+	decoded, err = decodeStreamEvent(redis.XMessage{ID: "1-0", Values: fields})
+	require.NoError(t, err)
+	assert.Equal(t, model.PriorityNormal, decoded.Priority)
+}
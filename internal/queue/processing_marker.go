@@ -0,0 +1,162 @@
+package queue
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	// processingMarkerMargin is added to the processing timeout for how long
+	// a claim outlives an instance that crashed while processing
+	processingMarkerMargin = 30 * time.Second
+	// processingMarkerDefaultTTL bounds claims when processing has no timeout
+	processingMarkerDefaultTTL = 5 * time.Minute
+	// processingMarkerRetention is how long a claim is kept after processing,
+	// covering Slack's retries, the last of which arrives minutes later
+	processingMarkerRetention = 10 * time.Minute
+	processingMarkerTimeout   = 2 * time.Second
+)
+
+// ProcessingMarker claims Slack messages before they are processed, so a
+// message is translated once even when Slack retries its event while the
+// first delivery is still being processed, possibly on another instance
+type ProcessingMarker interface {
+	// Claim marks the message as being processed by owner, the delivery of
+	// the event. It returns false when another delivery claimed it first.
+	Claim(ctx context.Context, channelID, messageTS, owner string) (bool, error)
+	// Complete keeps the claim for a while after the message is processed,
+	// so retries still arriving are dropped
+	Complete(ctx context.Context, channelID, messageTS string) error
+}
+
+// RedisProcessingMarker keeps claims in Redis, shared by every instance
+type RedisProcessingMarker struct {
+	client    *redis.Client
+	keyPrefix string
+	claimTTL  time.Duration
+	instance  string
+}
+
+// NewRedisProcessingMarker creates a marker whose claims expire shortly after
+// processingTimeout, so a message claimed by an instance that crashed can be
+// processed again
+func NewRedisProcessingMarker(client *redis.Client, processingTimeout time.Duration) *RedisProcessingMarker {
+	claimTTL := processingMarkerDefaultTTL
+	if processingTimeout > 0 {
+		claimTTL = processingTimeout + processingMarkerMargin
+	}
+	return &RedisProcessingMarker{
+		client:   client,
+		claimTTL: claimTTL,
+		instance: newInstanceToken(),
+	}
+}
+
+// SetKeyPrefix namespaces the claims, e.g. with CACHE_KEY_PREFIX, so
+// deployments sharing a Redis do not drop each other's messages
+func (m *RedisProcessingMarker) SetKeyPrefix(prefix string) {
+	m.keyPrefix = prefix
+}
+
+// Claim claims the message for owner. An empty owner, an event that was not
+// read from the durable queue, stands for this instance. Claiming a message
+// again with the same owner succeeds, so an event redelivered by the durable
+// queue after a crash is processed.
+func (m *RedisProcessingMarker) Claim(ctx context.Context, channelID, messageTS, owner string) (bool, error) {
+	if owner == "" {
+		owner = m.instance
+	}
+	ctx, cancel := context.WithTimeout(ctx, processingMarkerTimeout)
+	defer cancel()
+
+	key := m.key(channelID, messageTS)
+	claimed, err := m.client.SetNX(ctx, key, owner, m.claimTTL).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim message: %w", err)
+	}
+	if claimed {
+		return true, nil
+	}
+
+	holder, err := m.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		// The claim expired in between; take it
+		return m.client.SetNX(ctx, key, owner, m.claimTTL).Result()
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read message claim: %w", err)
+	}
+	return holder == owner, nil
+}
+
+// Complete extends the claim to cover Slack's retries
+func (m *RedisProcessingMarker) Complete(ctx context.Context, channelID, messageTS string) error {
+	ctx, cancel := context.WithTimeout(ctx, processingMarkerTimeout)
+	defer cancel()
+
+	if err := m.client.Expire(ctx, m.key(channelID, messageTS), processingMarkerRetention).Err(); err != nil {
+		return fmt.Errorf("failed to complete message claim: %w", err)
+	}
+	return nil
+}
+
+func (m *RedisProcessingMarker) key(channelID, messageTS string) string {
+	key := fmt.Sprintf("processing:%s:%s", channelID, messageTS)
+	if m.keyPrefix == "" {
+		return key
+	}
+	return m.keyPrefix + ":" + key
+}
+
+func newInstanceToken() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return "instance-" + hex.EncodeToString(b)
+}
+
+// SetProcessingMarker claims each message with marker before it is processed
+// and drops messages another delivery claimed first. Reactions and other
+// events are not claimed. It must be called before the first Enqueue.
+func (wp *WorkerPool) SetProcessingMarker(marker ProcessingMarker) {
+	wp.marker = marker
+}
+
+// claimMessage reports whether the event may be processed. Messages are
+// processed when the marker cannot be reached rather than risk losing them.
+func (wp *WorkerPool) claimMessage(event *model.MessageEvent) bool {
+	if wp.marker == nil || !isMessageEvent(event) {
+		return true
+	}
+	claimed, err := wp.marker.Claim(context.Background(), event.ChannelID, event.MessageTS, event.StreamID)
+	if err != nil {
+		wp.logger.Warn("Failed to claim message, processing it anyway",
+			zap.String("channel_id", event.ChannelID),
+			zap.String("message_ts", event.MessageTS),
+			zap.Error(err))
+		wp.recordError("processing_marker_failed")
+		return true
+	}
+	return claimed
+}
+
+// completeMessage keeps the claim of a processed message
+func (wp *WorkerPool) completeMessage(event *model.MessageEvent) {
+	if wp.marker == nil || !isMessageEvent(event) {
+		return
+	}
+	if err := wp.marker.Complete(context.Background(), event.ChannelID, event.MessageTS); err != nil {
+		wp.logger.Warn("Failed to complete message claim",
+			zap.String("channel_id", event.ChannelID),
+			zap.String("message_ts", event.MessageTS),
+			zap.Error(err))
+		wp.recordError("processing_marker_failed")
+	}
+}
@@ -0,0 +1,113 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestMarker(t *testing.T) (*RedisProcessingMarker, *miniredis.Miniredis) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	return NewRedisProcessingMarker(client, time.Minute), mr
+}
+
+func TestRedisProcessingMarker_Claim(t *testing.T) {
+	marker, mr := newTestMarker(t)
+	marker.SetKeyPrefix("staging")
+	ctx := context.Background()
+
+	claimed, err := marker.Claim(ctx, "C123", "1000.000001", "1-0")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+	assert.Equal(t, 90*time.Second, mr.TTL("staging:processing:C123:1000.000001"))
+
+	// A Slack retry is queued again under another stream ID
+	claimed, err = marker.Claim(ctx, "C123", "1000.000001", "2-0")
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	// The same stream entry redelivered after a crash is processed
+	claimed, err = marker.Claim(ctx, "C123", "1000.000001", "1-0")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	require.NoError(t, marker.Complete(ctx, "C123", "1000.000001"))
+	assert.Equal(t, processingMarkerRetention, mr.TTL("staging:processing:C123:1000.000001"))
+
+	// The claim of a crashed instance expires
+	mr.FastForward(processingMarkerRetention)
+	claimed, err = marker.Claim(ctx, "C123", "1000.000001", "2-0")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+}
+
+func TestRedisProcessingMarker_ClaimWithoutStream(t *testing.T) {
+	marker, _ := newTestMarker(t)
+	other := NewRedisProcessingMarker(marker.client, time.Minute)
+	ctx := context.Background()
+
+	claimed, err := marker.Claim(ctx, "C123", "1000.000001", "")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	// Another instance receiving the retry drops it
+	claimed, err = other.Claim(ctx, "C123", "1000.000001", "")
+	require.NoError(t, err)
+	assert.False(t, claimed)
+}
+
+func TestWorkerPool_ProcessingMarkerAcrossInstances(t *testing.T) {
+	marker, _ := newTestMarker(t)
+	other := NewRedisProcessingMarker(marker.client, time.Minute)
+	m := metrics.NewMetrics()
+
+	processor := newMockEventProcessor(50 * time.Millisecond)
+	first := NewWorkerPool(processor, 10, time.Minute, zap.NewNop())
+	first.SetProcessingMarker(marker)
+	second := NewWorkerPool(processor, 10, time.Minute, zap.NewNop())
+	second.SetProcessingMarker(other)
+	second.SetMetrics(m)
+
+	// Slack retries the event on another instance while the first processes it
+	first.Enqueue(newStreamEvent("1000.000001"))
+	time.Sleep(10 * time.Millisecond)
+	second.Enqueue(newStreamEvent("1000.000001"))
+	second.Enqueue(newStreamEvent("1000.000002"))
+
+	require.NoError(t, first.Shutdown(5*time.Second))
+	require.NoError(t, second.Shutdown(5*time.Second))
+	assert.ElementsMatch(t, []string{"1000.000001", "1000.000002"}, processor.getProcessedEvents())
+	assert.Equal(t, int64(1), m.ErrorsByType["duplicate_delivery"])
+}
+
+func TestWorkerPool_ProcessingMarkerUnavailable(t *testing.T) {
+	marker, mr := newTestMarker(t)
+	mr.Close()
+	m := metrics.NewMetrics()
+
+	processor := newMockEventProcessor(0)
+	workerPool := NewWorkerPool(processor, 10, time.Minute, zap.NewNop())
+	workerPool.SetProcessingMarker(marker)
+	workerPool.SetMetrics(m)
+
+	workerPool.Enqueue(newStreamEvent("1000.000001"))
+	require.NoError(t, workerPool.Shutdown(5*time.Second))
+
+	// Messages are still processed without Redis
+	assert.Equal(t, []string{"1000.000001"}, processor.getProcessedEvents())
+	assert.Equal(t, int64(2), m.ErrorsByType["processing_marker_failed"])
+}
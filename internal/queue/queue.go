@@ -0,0 +1,28 @@
+package queue
+
+import "github.com/ntttrang/go-genai-slack-assistant/internal/model"
+
+// Queue accepts message events for ordered processing: a WorkerPool holds
+// them in memory, a RedisQueue persists them before handing them to one
+type Queue interface {
+	Enqueue(event *model.MessageEvent)
+}
+
+var (
+	_ Queue = (*WorkerPool)(nil)
+	_ Queue = (*RedisQueue)(nil)
+)
+
+// EventAcker is told when an event read from a durable queue no longer needs
+// to be delivered again: it was processed or deliberately dropped. Events
+// dropped on shutdown are not acknowledged, so they are delivered again.
+type EventAcker interface {
+	Ack(event *model.MessageEvent)
+}
+
+// ack acknowledges an event taken from a durable queue
+func (wp *WorkerPool) ack(event *model.MessageEvent) {
+	if wp.acker != nil && event.StreamID != "" {
+		wp.acker.Ack(event)
+	}
+}
@@ -0,0 +1,407 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	redisQueueBatchSize  = 10
+	redisQueueReadBlock  = 2 * time.Second
+	redisQueueRetryDelay = time.Second
+	redisQueueTimeout    = 5 * time.Second
+)
+
+// RedisQueue persists message events in a Redis stream before they are
+// processed, so they survive a crash or restart. Instances share the stream
+// through a consumer group: each event is read by one instance and handed to
+// its worker pool, and is removed from the stream once the pool acknowledges
+// it. Events an instance read but never acknowledged are read again when it
+// restarts under the same consumer name, or taken over by another instance
+// once they have been idle for the claim timeout. Delivery is at least once,
+// and per-channel ordering holds within each instance.
+//
+// Payloads are stored as JSON, so only events whose payload survives a JSON
+// round trip, such as Slack's, can be queued.
+type RedisQueue struct {
+	client      *redis.Client
+	stream      string
+	group       string
+	consumer    string
+	pool        *WorkerPool
+	maxLen      int64
+	claimIdle   time.Duration
+	maintenance *maintenanceGate
+	delivered   sync.Map // stream IDs handed to the pool and not acknowledged yet
+	logger      *zap.Logger
+	stop        chan struct{}
+	done        chan struct{}
+}
+
+// NewRedisQueue creates a queue on stream, read by consumer in group, that
+// hands events to pool and removes them once pool has processed them
+func NewRedisQueue(client *redis.Client, stream, group, consumer string, pool *WorkerPool, logger *zap.Logger) *RedisQueue {
+	q := &RedisQueue{
+		client:    client,
+		stream:    stream,
+		group:     group,
+		consumer:  consumer,
+		pool:      pool,
+		claimIdle: 10 * time.Minute,
+		logger:    logger,
+		stop:      make(chan struct{}),
+	}
+	pool.acker = q
+	return q
+}
+
+// SetMaxLen caps the stream at about maxLen events, trimming the oldest, even
+// unprocessed ones. Zero leaves the stream uncapped.
+func (q *RedisQueue) SetMaxLen(maxLen int64) {
+	q.maxLen = maxLen
+}
+
+// SetClaimIdle sets how long an event may stay unacknowledged by the consumer
+// that read it before another consumer takes it over. It must be longer than
+// an event takes to wait in a channel queue and be processed, or busy
+// instances have their events processed twice. It must be called before Start.
+func (q *RedisQueue) SetClaimIdle(idle time.Duration) {
+	q.claimIdle = idle
+}
+
+// SetMaintenance stops reading the stream while mode is active, so events
+// pile up in Redis instead of memory. Channels receiving events meanwhile are
+// passed to notifier, which may be nil. It must be called before Start.
+func (q *RedisQueue) SetMaintenance(mode MaintenanceMode, notifier MaintenanceNotifier) {
+	q.maintenance = newMaintenanceGate(mode, notifier)
+}
+
+// Enqueue appends an event to the stream. When Redis cannot be reached the
+// event is handed to the worker pool directly, without persistence.
+func (q *RedisQueue) Enqueue(event *model.MessageEvent) {
+	if q.maintenance.active() {
+		q.maintenance.notify(event.ChannelID)
+	}
+
+	values, err := encodeStreamEvent(event)
+	if err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), redisQueueTimeout)
+		err = q.client.XAdd(ctx, &redis.XAddArgs{
+			Stream: q.stream,
+			MaxLen: q.maxLen,
+			Approx: true,
+			Values: values,
+		}).Err()
+		cancel()
+	}
+	if err != nil {
+		q.logger.Error("Failed to persist event, queueing it in memory",
+			zap.Error(err),
+			zap.String("event_id", event.EventID),
+			zap.String("channel_id", event.ChannelID))
+		q.pool.recordError("queue_persist_failed")
+		q.pool.Enqueue(event)
+	}
+}
+
+// Start creates the consumer group if needed and starts handing events to the
+// worker pool: first those this consumer read before a restart but never
+// acknowledged, then new ones and those abandoned by other consumers.
+func (q *RedisQueue) Start() error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisQueueTimeout)
+	defer cancel()
+
+	err := q.client.XGroupCreateMkStream(ctx, q.stream, q.group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("failed to create consumer group: %w", err)
+	}
+
+	q.done = make(chan struct{})
+	go q.consume()
+
+	q.logger.Info("Redis queue started",
+		zap.String("stream", q.stream),
+		zap.String("group", q.group),
+		zap.String("consumer", q.consumer))
+	return nil
+}
+
+// Stop stops reading the stream. It must be called before the worker pool
+// shuts down, which then acknowledges the events it drains.
+func (q *RedisQueue) Stop() {
+	close(q.stop)
+	if q.done != nil {
+		<-q.done
+	}
+}
+
+// Backlog returns the number of events in the stream, shared by every
+// instance: those waiting to be read and those read but not processed yet
+func (q *RedisQueue) Backlog(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, redisQueueTimeout)
+	defer cancel()
+	return q.client.XLen(ctx, q.stream).Result()
+}
+
+// Ack removes an event the worker pool is done with from the stream
+func (q *RedisQueue) Ack(event *model.MessageEvent) {
+	q.delivered.Delete(event.StreamID)
+	q.remove(event.StreamID)
+}
+
+func (q *RedisQueue) consume() {
+	defer close(q.done)
+
+	q.deliverPending()
+	nextClaim := time.Now()
+	for !q.stopped() {
+		if q.maintenance.active() {
+			q.logger.Info("Maintenance mode on, not reading the queue")
+			q.maintenance.wait(q.stop)
+			continue
+		}
+
+		if time.Now().After(nextClaim) {
+			q.claimAbandoned()
+			nextClaim = time.Now().Add(q.claimIdle / 2)
+		}
+
+		messages, err := q.read(">")
+		if err != nil {
+			q.logger.Error("Failed to read queue", zap.Error(err), zap.String("stream", q.stream))
+			select {
+			case <-time.After(redisQueueRetryDelay):
+			case <-q.stop:
+			}
+			continue
+		}
+		q.deliver(messages)
+	}
+}
+
+// deliverPending hands the pool the events this consumer read before a
+// restart but never acknowledged, in stream order
+func (q *RedisQueue) deliverPending() {
+	start := "0"
+	for !q.stopped() {
+		messages, err := q.read(start)
+		if err != nil {
+			// Left to claimAbandoned once they have been idle long enough
+			q.logger.Error("Failed to read unacknowledged events", zap.Error(err), zap.String("stream", q.stream))
+			return
+		}
+		if len(messages) == 0 {
+			return
+		}
+		q.logger.Info("Resuming unacknowledged events", zap.Int("count", len(messages)))
+		q.deliver(messages)
+		start = messages[len(messages)-1].ID
+	}
+}
+
+// claimAbandoned takes over the events other consumers read but did not
+// acknowledge within the claim timeout, e.g. because their instance crashed
+func (q *RedisQueue) claimAbandoned() {
+	start := "0-0"
+	for !q.stopped() {
+		ctx, cancel := context.WithTimeout(context.Background(), redisQueueTimeout)
+		messages, next, err := q.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   q.stream,
+			Group:    q.group,
+			MinIdle:  q.claimIdle,
+			Start:    start,
+			Count:    redisQueueBatchSize,
+			Consumer: q.consumer,
+		}).Result()
+		cancel()
+		if err != nil {
+			q.logger.Warn("Failed to claim abandoned events", zap.Error(err), zap.String("stream", q.stream))
+			return
+		}
+		if len(messages) > 0 {
+			q.logger.Warn("Claimed abandoned events", zap.Int("count", len(messages)))
+			q.deliver(messages)
+		}
+		if next == "" || next == "0-0" {
+			return
+		}
+		start = next
+	}
+}
+
+// read reads a batch of events for this consumer: new ones when start is ">",
+// blocking briefly for them, or its unacknowledged ones after start otherwise
+func (q *RedisQueue) read(start string) ([]redis.XMessage, error) {
+	block := time.Duration(-1)
+	if start == ">" {
+		block = redisQueueReadBlock
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisQueueReadBlock+redisQueueTimeout)
+	defer cancel()
+
+	streams, err := q.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    q.group,
+		Consumer: q.consumer,
+		Streams:  []string{q.stream, start},
+		Count:    redisQueueBatchSize,
+		Block:    block,
+	}).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stream: %w", err)
+	}
+	if len(streams) == 0 {
+		return nil, nil
+	}
+	return streams[0].Messages, nil
+}
+
+// deliver hands events to the worker pool, skipping those it already holds
+func (q *RedisQueue) deliver(messages []redis.XMessage) {
+	for _, message := range messages {
+		if _, held := q.delivered.LoadOrStore(message.ID, true); held {
+			continue
+		}
+
+		event, err := decodeStreamEvent(message)
+		if err != nil {
+			q.logger.Error("Failed to decode queued event, dropping it",
+				zap.Error(err),
+				zap.String("stream_id", message.ID))
+			q.pool.recordError("queue_decode_failed")
+			q.delivered.Delete(message.ID)
+			q.remove(message.ID)
+			continue
+		}
+		q.pool.Enqueue(event)
+	}
+}
+
+// remove acknowledges an event and deletes it from the stream
+func (q *RedisQueue) remove(id string) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisQueueTimeout)
+	defer cancel()
+
+	_, err := q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.XAck(ctx, q.stream, q.group, id)
+		pipe.XDel(ctx, q.stream, id)
+		return nil
+	})
+	if err != nil {
+		// The event stays pending and is processed again once claimed
+		q.logger.Warn("Failed to acknowledge queued event",
+			zap.Error(err),
+			zap.String("stream_id", id))
+	}
+}
+
+func (q *RedisQueue) stopped() bool {
+	select {
+	case <-q.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// encodeStreamEvent returns the stream entry fields of an event
+func encodeStreamEvent(event *model.MessageEvent) (map[string]interface{}, error) {
+	payload := event.RawPayload
+	if payload == nil {
+		encoded, err := json.Marshal(event.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode event payload: %w", err)
+		}
+		payload = encoded
+	}
+
+	values := map[string]interface{}{
+		"event_id":    event.EventID,
+		"channel_id":  event.ChannelID,
+		"user_id":     event.UserID,
+		"message_ts":  event.MessageTS,
+		"event_type":  event.EventType,
+		"received_at": event.ReceivedAt.Format(time.RFC3339Nano),
+		"sequence":    strconv.FormatUint(event.Sequence, 10),
+		"compressed":  strconv.FormatBool(event.Compressed),
+		"payload":     payload,
+		"priority":    strconv.Itoa(int(event.Priority)),
+	}
+	if len(event.TraceContext) > 0 {
+		traceContext, err := json.Marshal(event.TraceContext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode trace context: %w", err)
+		}
+		values["trace_context"] = traceContext
+	}
+	return values, nil
+}
+
+// decodeStreamEvent rebuilds an event from its stream entry. The payload is
+// left raw and decoded by the worker.
+func decodeStreamEvent(message redis.XMessage) (*model.MessageEvent, error) {
+	field := func(name string) string {
+		value, _ := message.Values[name].(string)
+		return value
+	}
+
+	payload := field("payload")
+	if payload == "" {
+		return nil, fmt.Errorf("queued event has no payload")
+	}
+	receivedAt, err := time.Parse(time.RFC3339Nano, field("received_at"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid received_at: %w", err)
+	}
+	sequence, err := strconv.ParseUint(field("sequence"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sequence: %w", err)
+	}
+	compressed, err := strconv.ParseBool(field("compressed"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid compressed flag: %w", err)
+	}
+	// Events queued before tracing was added carry no trace context
+	var traceContext map[string]string
+	if encoded := field("trace_context"); encoded != "" {
+		if err := json.Unmarshal([]byte(encoded), &traceContext); err != nil {
+			return nil, fmt.Errorf("invalid trace context: %w", err)
+		}
+	}
+	// Events queued before priorities were added have normal priority
+	priority := model.PriorityNormal
+	if encoded := field("priority"); encoded != "" {
+		value, err := strconv.Atoi(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid priority: %w", err)
+		}
+		priority = model.Priority(value)
+	}
+
+	return &model.MessageEvent{
+		EventID:      field("event_id"),
+		ChannelID:    field("channel_id"),
+		UserID:       field("user_id"),
+		MessageTS:    field("message_ts"),
+		EventType:    field("event_type"),
+		RawPayload:   []byte(payload),
+		Compressed:   compressed,
+		ReceivedAt:   receivedAt,
+		Sequence:     sequence,
+		StreamID:     message.ID,
+		TraceContext: traceContext,
+		Priority:     priority,
+	}, nil
+}
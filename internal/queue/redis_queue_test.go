@@ -0,0 +1,169 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newStreamEvent(ts string) *model.MessageEvent {
+	return &model.MessageEvent{
+		EventID:    "evt-" + ts,
+		ChannelID:  "C123",
+		MessageTS:  ts,
+		EventType:  "message",
+		RawPayload: []byte(`{"type":"event_callback","event":{"type":"message","ts":"` + ts + `"}}`),
+		ReceivedAt: time.Now(),
+	}
+}
+
+func TestRedisQueue_ProcessesAndRemovesEvents(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() {
+		_ = client.Close()
+	}()
+
+	processor := newMockEventProcessor(0)
+	workerPool := NewWorkerPool(processor, 10, time.Minute, zap.NewNop())
+	redisQueue := NewRedisQueue(client, "slack_events", "translators", "instance-1", workerPool, zap.NewNop())
+	require.NoError(t, redisQueue.Start())
+
+	redisQueue.Enqueue(newStreamEvent("1000.000001"))
+	redisQueue.Enqueue(newStreamEvent("1000.000002"))
+
+	assert.Eventually(t, func() bool {
+		return len(processor.getProcessedEvents()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"1000.000001", "1000.000002"}, processor.getProcessedEvents())
+
+	// Processed events are acknowledged and deleted from the stream
+	assert.Eventually(t, func() bool {
+		length, err := client.XLen(context.Background(), "slack_events").Result()
+		return err == nil && length == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	redisQueue.Stop()
+	require.NoError(t, workerPool.Shutdown(5*time.Second))
+}
+
+func TestRedisQueue_ResumesUnacknowledgedEvents(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() {
+		_ = client.Close()
+	}()
+	ctx := context.Background()
+
+	// An instance reads an event and crashes before processing it
+	require.NoError(t, client.XGroupCreateMkStream(ctx, "slack_events", "translators", "0").Err())
+	values, err := encodeStreamEvent(newStreamEvent("1000.000001"))
+	require.NoError(t, err)
+	require.NoError(t, client.XAdd(ctx, &redis.XAddArgs{Stream: "slack_events", Values: values}).Err())
+	read, err := client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    "translators",
+		Consumer: "instance-1",
+		Streams:  []string{"slack_events", ">"},
+		Count:    1,
+		Block:    -1,
+	}).Result()
+	require.NoError(t, err)
+	require.Len(t, read[0].Messages, 1)
+
+	// It restarts under the same consumer name and picks the event up again
+	processor := newMockEventProcessor(0)
+	workerPool := NewWorkerPool(processor, 10, time.Minute, zap.NewNop())
+	redisQueue := NewRedisQueue(client, "slack_events", "translators", "instance-1", workerPool, zap.NewNop())
+	require.NoError(t, redisQueue.Start())
+
+	assert.Eventually(t, func() bool {
+		return len(processor.getProcessedEvents()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	redisQueue.Stop()
+	require.NoError(t, workerPool.Shutdown(5*time.Second))
+
+	pending, err := client.XPending(ctx, "slack_events", "translators").Result()
+	require.NoError(t, err)
+	assert.Zero(t, pending.Count)
+}
+
+func TestRedisQueue_Backlog(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() {
+		_ = client.Close()
+	}()
+
+	// Events enqueued by any instance count until processed
+	processor := newMockEventProcessor(0)
+	workerPool := NewWorkerPool(processor, 10, time.Minute, zap.NewNop())
+	redisQueue := NewRedisQueue(client, "slack_events", "translators", "instance-1", workerPool, zap.NewNop())
+	redisQueue.Enqueue(newStreamEvent("1000.000001"))
+	redisQueue.Enqueue(newStreamEvent("1000.000002"))
+
+	backlog, err := redisQueue.Backlog(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), backlog)
+
+	require.NoError(t, redisQueue.Start())
+	assert.Eventually(t, func() bool {
+		backlog, err := redisQueue.Backlog(context.Background())
+		return err == nil && backlog == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	redisQueue.Stop()
+	require.NoError(t, workerPool.Shutdown(5*time.Second))
+}
+
+func TestDecodeStreamEvent_RejectsIncompleteEntries(t *testing.T) {
+	_, err := decodeStreamEvent(redis.XMessage{ID: "1-0", Values: map[string]interface{}{"event_id": "evt-1"}})
+	assert.Error(t, err)
+}
+
+func TestStreamEvent_CarriesTraceContext(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() {
+		_ = client.Close()
+	}()
+	ctx := context.Background()
+
+	traceparent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	event := newStreamEvent("1000.000001")
+	event.TraceContext = map[string]string{"traceparent": traceparent}
+	values, err := encodeStreamEvent(event)
+	require.NoError(t, err)
+	require.NoError(t, client.XAdd(ctx, &redis.XAddArgs{Stream: "slack_events", Values: values}).Err())
+
+	messages, err := client.XRange(ctx, "slack_events", "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	decoded, err := decodeStreamEvent(messages[0])
+	require.NoError(t, err)
+	assert.Equal(t, traceparent, decoded.TraceContext["traceparent"])
+
+	// Events without a trace context decode as before
+	values, err = encodeStreamEvent(newStreamEvent("1000.000002"))
+	require.NoError(t, err)
+	assert.NotContains(t, values, "trace_context")
+}
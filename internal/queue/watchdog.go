@@ -0,0 +1,131 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+// workerHandle tracks the event a queue worker is currently processing so
+// the watchdog can detect a worker stuck on a hung call and replace it.
+type workerHandle struct {
+	queueKey     string
+	eventChan    chan *model.MessageEvent
+	priorityChan chan *model.MessageEvent
+
+	mu        sync.Mutex
+	current   *model.MessageEvent
+	startedAt time.Time
+	cancel    context.CancelFunc
+	abandoned bool
+}
+
+// registerWorker creates the handle for a new worker and makes it the active one for the queue
+func (wp *WorkerPool) registerWorker(queueKey string, eventChan chan *model.MessageEvent) *workerHandle {
+	h := &workerHandle{queueKey: queueKey, eventChan: eventChan, priorityChan: wp.priorityQueue(queueKey)}
+	wp.workers.Store(queueKey, h)
+	return h
+}
+
+func (h *workerHandle) begin(event *model.MessageEvent, cancel context.CancelFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.current = event
+	h.startedAt = time.Now()
+	h.cancel = cancel
+}
+
+func (h *workerHandle) end() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.current = nil
+	h.cancel = nil
+}
+
+// inFlight returns the event being processed and since when, if any
+func (h *workerHandle) inFlight() (*model.MessageEvent, time.Time, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.current == nil || h.abandoned {
+		return nil, time.Time{}, false
+	}
+	return h.current, h.startedAt, true
+}
+
+// abandon cancels the in-flight context and marks the worker as replaced
+func (h *workerHandle) abandon() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.abandoned = true
+	if h.cancel != nil {
+		h.cancel()
+	}
+}
+
+func (h *workerHandle) isAbandoned() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.abandoned
+}
+
+// StartWatchdog periodically looks for workers whose in-flight message has
+// been processing for longer than maxAge (e.g. a hung AI provider call).
+// A stuck worker has its context cancelled and is replaced by a fresh worker
+// on the same queue, so the rest of the channel's messages keep flowing.
+// The stuck message is dropped. The watchdog stops on Shutdown.
+func (wp *WorkerPool) StartWatchdog(maxAge time.Duration) {
+	if maxAge <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(maxAge / 2)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				wp.checkStuckWorkers(maxAge)
+			case <-wp.shutdown:
+				return
+			}
+		}
+	}()
+
+	wp.logger.Info("Queue watchdog started", zap.Duration("max_age", maxAge))
+}
+
+func (wp *WorkerPool) checkStuckWorkers(maxAge time.Duration) {
+	select {
+	case <-wp.shutdown:
+		return
+	default:
+	}
+
+	now := time.Now()
+	wp.workers.Range(func(key, value interface{}) bool {
+		h := value.(*workerHandle)
+		event, startedAt, ok := h.inFlight()
+		if !ok || now.Sub(startedAt) < maxAge {
+			return true
+		}
+
+		wp.logger.Error("Queue worker stuck, cancelling in-flight event and restarting worker",
+			zap.String("queue_key", h.queueKey),
+			zap.String("event_id", event.EventID),
+			zap.String("message_ts", event.MessageTS),
+			zap.Uint64("sequence", event.Sequence),
+			zap.Duration("in_flight", now.Sub(startedAt)),
+			zap.Duration("message_age", now.Sub(event.ReceivedAt)))
+		if wp.metrics != nil {
+			wp.metrics.RecordError("queue_worker_stuck")
+		}
+
+		h.abandon()
+		wp.spawnWorker(h.queueKey, h.eventChan)
+		return true
+	})
+}
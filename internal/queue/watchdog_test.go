@@ -0,0 +1,80 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+// hangingEventProcessor blocks on the "hang" message until released,
+// ignoring context cancellation like a hung provider call would.
+type hangingEventProcessor struct {
+	release   chan struct{}
+	cancelled chan struct{}
+	mu        sync.Mutex
+	processed []string
+}
+
+func (p *hangingEventProcessor) ProcessEvent(ctx context.Context, payload map[string]interface{}) {
+	event, _ := payload["event"].(map[string]interface{})
+	ts, _ := event["ts"].(string)
+
+	if ts == "hang" {
+		<-ctx.Done()
+		close(p.cancelled)
+		<-p.release
+	}
+
+	p.mu.Lock()
+	p.processed = append(p.processed, ts)
+	p.mu.Unlock()
+}
+
+func TestWorkerPool_WatchdogRestartsStuckWorker(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	processor := &hangingEventProcessor{
+		release:   make(chan struct{}),
+		cancelled: make(chan struct{}),
+	}
+	m := metrics.NewMetrics()
+	workerPool := NewWorkerPool(processor, 10, 1*time.Minute, logger)
+	workerPool.SetMetrics(m)
+	workerPool.StartWatchdog(50 * time.Millisecond)
+
+	for _, ts := range []string{"hang", "1000.000002"} {
+		workerPool.Enqueue(&model.MessageEvent{
+			EventID:    "evt-" + ts,
+			ChannelID:  "C123",
+			MessageTS:  ts,
+			Payload:    map[string]interface{}{"event": map[string]interface{}{"ts": ts}},
+			ReceivedAt: time.Now(),
+		})
+	}
+
+	select {
+	case <-processor.cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("Expected watchdog to cancel the in-flight context")
+	}
+
+	// The replacement worker picks up the rest of the queue
+	time.Sleep(100 * time.Millisecond)
+	processor.mu.Lock()
+	processed := append([]string(nil), processor.processed...)
+	processor.mu.Unlock()
+	if len(processed) != 1 || processed[0] != "1000.000002" {
+		t.Errorf("Expected the queued message to be processed by the new worker, got %v", processed)
+	}
+
+	close(processor.release)
+	_ = workerPool.Shutdown(5 * time.Second)
+
+	if m.ErrorsByType["queue_worker_stuck"] != 1 {
+		t.Errorf("Expected 1 stuck worker, got %d", m.ErrorsByType["queue_worker_stuck"])
+	}
+}
@@ -2,26 +2,49 @@ package queue
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
 	"github.com/ntttrang/go-genai-slack-assistant/internal/service/slack"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
 // WorkerPool manages message queues and workers for ordered message processing.
 // Each unique channel gets its own queue and worker goroutine.
 type WorkerPool struct {
-	queues       sync.Map              // map[string]chan *model.MessageEvent
-	seenEvents   sync.Map              // map[string]bool for deduplication by event_id
-	processor    slack.EventProcessor  // processes events synchronously
-	bufferSize   int                   // buffer size for each queue channel
-	idleTimeout  time.Duration         // time after which idle workers are cleaned up
-	shutdown     chan struct{}         // signal for graceful shutdown
-	wg           sync.WaitGroup        // wait for all workers to finish
-	logger       *zap.Logger
+	queues        sync.Map             // map[string]chan *model.MessageEvent
+	priority      sync.Map             // map[string]chan *model.MessageEvent of each queue's high-priority events
+	workers       sync.Map             // map[string]*workerHandle for the active worker of each queue
+	dedup         EventDeduplicator    // remembers event_ids for every instance (nil keeps them in localDedup)
+	localDedup    EventDeduplicator    // remembers event_ids in memory, also while dedup fails
+	states        sync.Map             // map[string]*queueState inspected and managed by the admin API
+	processor     slack.EventProcessor // processes events synchronously
+	bufferSize    int                  // buffer size for each queue channel
+	idleTimeout   time.Duration        // time after which idle workers are cleaned up
+	shutdown      chan struct{}        // signal for graceful shutdown
+	wg            sync.WaitGroup       // wait for all workers to finish
+	reorderWindow time.Duration        // how long message events are held for reordering (0 disables)
+	eventTimeout  time.Duration        // max processing time per event (0 disables)
+	backlog       *backlogTracker      // notifies channels whose queue is backed up (nil disables)
+	payloadLimits *payloadLimits       // bounds the raw payloads held in the queues (nil disables)
+	pauses        ChannelPauseChecker  // drops events of channels where translation is paused (nil disables)
+	maintenance   *maintenanceGate     // holds every queue while the bot is in maintenance (nil disables)
+	acker         EventAcker           // acknowledges events taken from a durable queue (nil disables)
+	marker        ProcessingMarker     // drops messages already being processed by another delivery (nil disables)
+	slots         *workerSlots         // caps the workers running at once (nil leaves them unbounded)
+	slotsMu       sync.Mutex           // guards slots
+	activeWorkers int64                // running worker goroutines, updated atomically
+	name          string               // labels the pool's gauges in metrics
+	metrics       *metrics.Metrics
+	logger        *zap.Logger
 }
 
 // NewWorkerPool creates a new worker pool for processing message events.
@@ -36,23 +59,55 @@ func NewWorkerPool(
 		processor:   processor,
 		bufferSize:  bufferSize,
 		idleTimeout: idleTimeout,
+		localDedup:  NewMemoryEventDeduplicator(DefaultEventDedupTTL, DefaultEventDedupMaxEntries),
 		shutdown:    make(chan struct{}),
 		logger:      logger,
 	}
 }
 
+// SetReorderWindow holds each message event for up to window so that messages
+// delivered out of order are processed in their original ts order.
+// It must be called before the first Enqueue.
+func (wp *WorkerPool) SetReorderWindow(window time.Duration) {
+	wp.reorderWindow = window
+}
+
+// SetProcessingTimeout bounds how long a single event may be processed.
+// The event's context is cancelled when the deadline passes, so the channel
+// queue moves on instead of waiting on a slow AI call.
+func (wp *WorkerPool) SetProcessingTimeout(timeout time.Duration) {
+	wp.eventTimeout = timeout
+}
+
+// ChannelPauseChecker reports whether translation is paused in a channel
+type ChannelPauseChecker interface {
+	IsPaused(channelID string) bool
+}
+
+// SetChannelPauses drops events of channels where translation is paused
+// instead of queueing them. It must be called before the first Enqueue.
+func (wp *WorkerPool) SetChannelPauses(pauses ChannelPauseChecker) {
+	wp.pauses = pauses
+}
+
+// SetMetrics enables message ordering metrics
+func (wp *WorkerPool) SetMetrics(m *metrics.Metrics) {
+	wp.metrics = m
+}
+
 // Enqueue adds a message event to the appropriate queue based on channel.
 // If no queue exists for this channel, a new one is created and a worker is spawned.
 // Duplicate events (same event_id) are silently dropped to prevent processing duplicates from Slack retries.
 func (wp *WorkerPool) Enqueue(event *model.MessageEvent) {
 	// Deduplicate by event_id
 	if event.EventID != "" {
-		if _, exists := wp.seenEvents.LoadOrStore(event.EventID, true); exists {
+		if !wp.firstSeen(event) {
 			wp.logger.Warn("Duplicate event detected, dropping (SKIPPED)",
 				zap.String("event_id", event.EventID),
 				zap.String("channel_id", event.ChannelID),
 				zap.String("message_ts", event.MessageTS),
 				zap.String("user_id", event.UserID))
+			wp.ack(event)
 			return
 		}
 		wp.logger.Debug("New event_id (ACCEPTED)",
@@ -65,79 +120,218 @@ func (wp *WorkerPool) Enqueue(event *model.MessageEvent) {
 			zap.Uint64("sequence", event.Sequence))
 	}
 
+	if wp.pauses != nil && wp.pauses.IsPaused(event.ChannelID) {
+		wp.logger.Info("Translation paused in channel, dropping event",
+			zap.String("channel_id", event.ChannelID),
+			zap.String("message_ts", event.MessageTS),
+			zap.String("event_id", event.EventID))
+		wp.recordError("channel_paused_dropped")
+		wp.ack(event)
+		return
+	}
+
+	if !wp.guardPayload(event) {
+		wp.ack(event)
+		return
+	}
+
+	maintenance := wp.maintenance.active()
+	if maintenance {
+		wp.maintenance.notify(event.ChannelID)
+	}
+
 	queueKey := event.GetQueueKey()
+	state := wp.state(queueKey)
+	state.add(event)
 
 	// Get existing queue or create new one
 	queueInterface, loaded := wp.queues.LoadOrStore(queueKey, make(chan *model.MessageEvent, wp.bufferSize))
 	eventChan := queueInterface.(chan *model.MessageEvent)
+	priorityChan := wp.priorityQueue(queueKey)
 
-	// If this is a new queue, spawn a worker goroutine
+	// If this is a new queue, spawn a worker goroutine once a worker slot is free
 	if !loaded {
-		wp.wg.Add(1)
-		go wp.worker(queueKey, eventChan)
+		wp.startWorker(queueKey, eventChan, isHighPriority(event))
 		wp.logger.Info("Started new worker for channel queue",
 			zap.String("channel_id", event.ChannelID))
+		wp.observeWorkers()
+	}
+
+	// High-priority events skip ahead of the queue's normal events
+	target := eventChan
+	if isHighPriority(event) {
+		target = priorityChan
 	}
 
 	// Send message to channel
 	select {
-	case eventChan <- event:
+	case target <- event:
 		wp.logger.Debug("Message enqueued",
 			zap.String("queue_key", queueKey),
 			zap.String("message_ts", event.MessageTS),
-			zap.String("event_id", event.EventID))
+			zap.String("event_id", event.EventID),
+			zap.Bool("high_priority", isHighPriority(event)))
 	case <-wp.shutdown:
 		wp.logger.Warn("Dropping message, shutdown in progress",
 			zap.String("queue_key", queueKey))
+		state.remove(event)
+		return
 	default:
+		// A held queue would block the webhook until it is resumed
+		if maintenance {
+			wp.logger.Warn("Queue full during maintenance, dropping message",
+				zap.String("queue_key", queueKey),
+				zap.String("message_ts", event.MessageTS),
+				zap.String("event_id", event.EventID))
+			wp.recordError("maintenance_dropped")
+			state.remove(event)
+			return
+		}
+		if state.isPaused() {
+			wp.logger.Warn("Queue paused and full, dropping message",
+				zap.String("queue_key", queueKey),
+				zap.String("message_ts", event.MessageTS),
+				zap.String("event_id", event.EventID))
+			wp.recordError("queue_paused_dropped")
+			state.remove(event)
+			wp.ack(event)
+			return
+		}
 		// Buffer full - block until space available
 		wp.logger.Warn("Queue buffer full, blocking until space available",
 			zap.String("queue_key", queueKey),
 			zap.Int("buffer_size", wp.bufferSize))
-		eventChan <- event
+		target <- event
 	}
+	wp.observeQueueDepth(queueKey, len(eventChan)+len(priorityChan))
 }
 
 // worker processes messages from a single queue sequentially.
 // It exits when idle timeout is reached or shutdown is signaled.
-func (wp *WorkerPool) worker(queueKey string, eventChan chan *model.MessageEvent) {
+func (wp *WorkerPool) worker(h *workerHandle) {
+	queueKey, eventChan, priorityChan := h.queueKey, h.eventChan, h.priorityChan
 	defer wp.wg.Done()
-	defer wp.cleanup(queueKey, eventChan)
+	defer func() {
+		atomic.AddInt64(&wp.activeWorkers, -1)
+		// A replacement worker owns the queue and its slot once this one was abandoned
+		if !h.isAbandoned() {
+			wp.cleanup(queueKey, eventChan)
+			wp.releaseWorkerSlot()
+		}
+		wp.observeWorkers()
+	}()
 
 	idleTimer := time.NewTimer(wp.idleTimeout)
 	defer idleTimer.Stop()
 
 	wp.logger.Info("Worker started", zap.String("queue_key", queueKey))
 
+	var buffer *reorderBuffer
+	var reorderTimer *time.Timer
+	var reorderC <-chan time.Time
+	if wp.reorderWindow > 0 {
+		buffer = newReorderBuffer(wp.reorderWindow)
+		reorderTimer = time.NewTimer(wp.reorderWindow)
+		reorderTimer.Stop()
+		defer reorderTimer.Stop()
+		reorderC = reorderTimer.C
+	}
+	lastTS := ""
+
+	// resetIdle restarts the idle timer - we have work to do
+	resetIdle := func() {
+		if !idleTimer.Stop() {
+			select {
+			case <-idleTimer.C:
+			default:
+			}
+		}
+		idleTimer.Reset(wp.idleTimeout)
+	}
+
+	// releaseReady processes buffered events whose window elapsed and re-arms the timer
+	releaseReady := func() {
+		for _, event := range buffer.popReady(time.Now()) {
+			wp.processEvent(h, event, &lastTS)
+		}
+		if wait, ok := buffer.nextRelease(time.Now()); ok {
+			reorderTimer.Reset(wait)
+		}
+	}
+
 	for {
+		if h.isAbandoned() {
+			wp.logger.Warn("Replaced worker exiting after hung call returned",
+				zap.String("queue_key", queueKey))
+			if buffer != nil {
+				for _, event := range buffer.flush() {
+					wp.processEvent(h, event, &lastTS)
+				}
+			}
+			return
+		}
+
+		// High-priority events are processed first, bypassing the reorder buffer
+		select {
+		case event := <-priorityChan:
+			resetIdle()
+			wp.processEvent(h, event, &lastTS)
+			continue
+		default:
+		}
+
 		select {
+		case event := <-priorityChan:
+			resetIdle()
+			wp.processEvent(h, event, &lastTS)
+
 		case event := <-eventChan:
-			// Reset idle timer - we have work to do
-			if !idleTimer.Stop() {
+			resetIdle()
+
+			if buffer == nil {
+				// Process event synchronously (ensures ordering)
+				wp.processEvent(h, event, &lastTS)
+				continue
+			}
+
+			if buffer.push(event) {
+				wp.recordOrdering(OrderingReordered)
+				wp.logger.Info("Out-of-order message buffered for reordering",
+					zap.String("queue_key", queueKey),
+					zap.String("message_ts", event.MessageTS),
+					zap.Uint64("sequence", event.Sequence))
+			}
+			if !reorderTimer.Stop() {
 				select {
-				case <-idleTimer.C:
+				case <-reorderTimer.C:
 				default:
 				}
 			}
-			idleTimer.Reset(wp.idleTimeout)
+			releaseReady()
 
-			// Process event synchronously (ensures ordering)
-			wp.logger.Info("Processing event (SEQUENTIAL)",
-				zap.String("queue_key", queueKey),
-				zap.String("message_ts", event.MessageTS),
-				zap.Uint64("sequence", event.Sequence),
-				zap.String("user_id", event.UserID),
-				zap.Time("received_at", event.ReceivedAt))
-
-			ctx := context.Background()
-			wp.processor.ProcessEvent(ctx, event.Payload)
+		case <-reorderC:
+			releaseReady()
 
-			wp.logger.Info("Event processed (COMPLETE)",
-				zap.String("queue_key", queueKey),
-				zap.String("message_ts", event.MessageTS),
-				zap.Uint64("sequence", event.Sequence))
+		case <-wp.workerWanted():
+			// Other queues wait for a worker slot: give this one up once the queue is empty
+			if len(eventChan) > 0 || len(priorityChan) > 0 || wp.queuesWaiting() == 0 {
+				continue
+			}
+			if buffer != nil {
+				for _, event := range buffer.flush() {
+					wp.processEvent(h, event, &lastTS)
+				}
+			}
+			wp.logger.Info("Worker queue empty, handing worker slot to a waiting queue",
+				zap.String("queue_key", queueKey))
+			return
 
 		case <-idleTimer.C:
+			if buffer != nil {
+				for _, event := range buffer.flush() {
+					wp.processEvent(h, event, &lastTS)
+				}
+			}
 			// No messages for idleTimeout duration, exit worker
 			wp.logger.Info("Worker idle timeout reached, exiting",
 				zap.String("queue_key", queueKey),
@@ -145,26 +339,189 @@ func (wp *WorkerPool) worker(queueKey string, eventChan chan *model.MessageEvent
 			return
 
 		case <-wp.shutdown:
-			// Graceful shutdown: drain remaining messages
+			// Graceful shutdown: release buffered events, then drain remaining messages
 			wp.logger.Info("Worker received shutdown signal, draining queue",
 				zap.String("queue_key", queueKey))
+			if buffer != nil {
+				for _, event := range buffer.flush() {
+					wp.processEvent(h, event, &lastTS)
+				}
+			}
+			wp.drainQueue(queueKey, priorityChan)
 			wp.drainQueue(queueKey, eventChan)
 			return
 		}
 	}
 }
 
+// processEvent checks ts monotonicity for the channel and processes the event
+func (wp *WorkerPool) processEvent(h *workerHandle, event *model.MessageEvent, lastTS *string) {
+	queueKey := h.queueKey
+	state := wp.state(queueKey)
+	wp.waitWhileMaintenance(queueKey)
+	state.waitWhilePaused(wp.shutdown)
+	if state.takeFlushed(event) {
+		wp.logger.Info("Skipping flushed event",
+			zap.String("queue_key", queueKey),
+			zap.String("message_ts", event.MessageTS))
+		wp.ack(event)
+		return
+	}
+	if !wp.claimMessage(event) {
+		wp.logger.Warn("Message already claimed by another delivery, dropping (SKIPPED)",
+			zap.String("queue_key", queueKey),
+			zap.String("event_id", event.EventID),
+			zap.String("message_ts", event.MessageTS))
+		wp.recordError("duplicate_delivery")
+		wp.ack(event)
+		return
+	}
+
+	// High-priority messages are expected ahead of the channel's order
+	if isMessageEvent(event) && !isHighPriority(event) {
+		if *lastTS != "" && compareTS(event.MessageTS, *lastTS) < 0 {
+			wp.recordOrdering(OrderingOutOfOrder)
+			wp.logger.Warn("Message processed out of order",
+				zap.String("queue_key", queueKey),
+				zap.String("message_ts", event.MessageTS),
+				zap.String("last_ts", *lastTS),
+				zap.Uint64("sequence", event.Sequence))
+		} else {
+			wp.recordOrdering(OrderingInOrder)
+			*lastTS = event.MessageTS
+		}
+	}
+
+	wp.logger.Info("Processing event (SEQUENTIAL)",
+		zap.String("queue_key", queueKey),
+		zap.String("message_ts", event.MessageTS),
+		zap.Uint64("sequence", event.Sequence),
+		zap.String("user_id", event.UserID),
+		zap.Time("received_at", event.ReceivedAt))
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if wp.eventTimeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), wp.eventTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+	// Trace the reply latency from when Slack delivered the event
+	var latencyTrace *metrics.LatencyTrace
+	if !event.ReceivedAt.IsZero() {
+		latencyTrace = metrics.NewLatencyTrace(event.ReceivedAt)
+		latencyTrace.Add(metrics.StageQueueWait, time.Since(event.ReceivedAt))
+		ctx = metrics.WithLatencyTrace(ctx, latencyTrace)
+	}
+	// Continue the trace started by the webhook that queued the event
+	ctx, span := tracing.Tracer().Start(tracing.Extract(ctx, event.TraceContext), "queue.process",
+		oteltrace.WithSpanKind(oteltrace.SpanKindConsumer),
+		oteltrace.WithAttributes(
+			attribute.String("queue.key", queueKey),
+			attribute.String("slack.event_type", event.EventType),
+			attribute.String("slack.message_ts", event.MessageTS),
+			attribute.Int64("queue.sequence", int64(event.Sequence)),
+		))
+	h.begin(event, cancel)
+	if payload, ok := wp.decodePayload(event); ok {
+		wp.processor.ProcessEvent(ctx, payload)
+	}
+	h.end()
+	if ctx.Err() == context.DeadlineExceeded {
+		wp.logger.Warn("Event processing deadline exceeded",
+			zap.String("queue_key", queueKey),
+			zap.String("message_ts", event.MessageTS),
+			zap.Duration("timeout", wp.eventTimeout))
+		if wp.metrics != nil {
+			wp.metrics.RecordError("processing_timeout")
+		}
+	}
+	tracing.End(span, ctx.Err())
+	cancel()
+	wp.recordFailedStage(queueKey, event, latencyTrace)
+	wp.completeMessage(event)
+	state.processed(event, time.Now())
+	wp.ack(event)
+
+	wp.logger.Info("Event processed (COMPLETE)",
+		zap.String("queue_key", queueKey),
+		zap.String("message_ts", event.MessageTS),
+		zap.Uint64("sequence", event.Sequence))
+	wp.observeQueueDepth(queueKey, len(h.eventChan)+len(h.priorityChan))
+}
+
+// recordFailedStage counts the event under the first stage that failed
+// while it was processed, if any
+func (wp *WorkerPool) recordFailedStage(queueKey string, event *model.MessageEvent, trace *metrics.LatencyTrace) {
+	if trace == nil {
+		return
+	}
+	stage := trace.FailedStage()
+	if stage == "" {
+		return
+	}
+	wp.logger.Warn("Event processing stage failed",
+		zap.String("queue_key", queueKey),
+		zap.String("message_ts", event.MessageTS),
+		zap.String("stage", stage))
+	if wp.metrics != nil {
+		wp.metrics.RecordStageFailure(stage)
+	}
+}
+
+// decodePayload returns the event payload, decompressing and decoding RawPayload on first use
+func (wp *WorkerPool) decodePayload(event *model.MessageEvent) (map[string]interface{}, bool) {
+	if event.Payload != nil || event.RawPayload == nil {
+		return event.Payload, true
+	}
+	raw := event.RawPayload
+	var err error
+	if event.Compressed {
+		raw, err = decompressPayload(raw)
+	}
+	if err == nil {
+		err = json.Unmarshal(raw, &event.Payload)
+	}
+	if err != nil {
+		wp.logger.Error("Failed to decode event payload, dropping event",
+			zap.Error(err),
+			zap.String("event_id", event.EventID),
+			zap.String("channel_id", event.ChannelID))
+		wp.recordError("payload_decode_failed")
+		return nil, false
+	}
+	event.RawPayload = nil
+	event.Compressed = false
+	return event.Payload, true
+}
+
+func (wp *WorkerPool) recordOrdering(outcome string) {
+	if wp.metrics != nil {
+		wp.metrics.RecordMessageOrdering(outcome)
+	}
+}
+
 // drainQueue processes all remaining messages in the queue during shutdown.
 func (wp *WorkerPool) drainQueue(queueKey string, eventChan chan *model.MessageEvent) {
+	state := wp.state(queueKey)
 	drained := 0
 	for {
 		select {
 		case event := <-eventChan:
+			if state.takeFlushed(event) || !wp.claimMessage(event) {
+				wp.ack(event)
+				continue
+			}
 			wp.logger.Debug("Draining event",
 				zap.String("queue_key", queueKey),
 				zap.String("message_ts", event.MessageTS))
 			ctx := context.Background()
-			wp.processor.ProcessEvent(ctx, event.Payload)
+			if payload, ok := wp.decodePayload(event); ok {
+				wp.processor.ProcessEvent(ctx, payload)
+			}
+			wp.completeMessage(event)
+			state.processed(event, time.Now())
+			wp.ack(event)
 			drained++
 		default:
 			// Queue is empty
@@ -182,10 +539,13 @@ func (wp *WorkerPool) drainQueue(queueKey string, eventChan chan *model.MessageE
 func (wp *WorkerPool) cleanup(queueKey string, eventChan chan *model.MessageEvent) {
 	close(eventChan)
 	wp.queues.Delete(queueKey)
-	
-	// Note: We don't clean up seenEvents here since they need to persist across worker lifecycle
-	// to handle Slack's retry window. Memory impact is minimal as events only accumulate briefly.
-	
+	wp.priority.Delete(queueKey)
+	wp.workers.Delete(queueKey)
+	// A paused queue stays paused for the channel's next messages
+	if state, ok := wp.states.Load(queueKey); ok && !state.(*queueState).isPaused() {
+		wp.states.Delete(queueKey)
+	}
+
 	wp.logger.Info("Worker cleaned up",
 		zap.String("queue_key", queueKey))
 }
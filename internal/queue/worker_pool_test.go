@@ -2,12 +2,14 @@ package queue
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
 	"go.uber.org/zap"
 )
 
@@ -390,3 +392,185 @@ func TestWorkerPool_EventDeduplication(t *testing.T) {
 		t.Errorf("Expected 1 processed event (second was deduplicated), got %d", processor.getCallCount())
 	}
 }
+
+func TestWorkerPool_ReorderWindow(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	processor := newMockEventProcessor(0)
+	m := metrics.NewMetrics()
+	workerPool := NewWorkerPool(processor, 10, 1*time.Minute, logger)
+	workerPool.SetReorderWindow(50 * time.Millisecond)
+	workerPool.SetMetrics(m)
+
+	// Slack retry delivers the second message before the first
+	for i, ts := range []string{"1000.000002", "1000.000001", "1000.000003"} {
+		workerPool.Enqueue(&model.MessageEvent{
+			EventID:    fmt.Sprintf("evt%d", i),
+			ChannelID:  "C123",
+			UserID:     "U456",
+			MessageTS:  ts,
+			Payload:    map[string]interface{}{"event": map[string]interface{}{"type": "message", "ts": ts}},
+			ReceivedAt: time.Now(),
+		})
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	// Shutdown waits for the worker, so metrics are safe to read afterwards
+	_ = workerPool.Shutdown(5 * time.Second)
+
+	expected := []string{"1000.000001", "1000.000002", "1000.000003"}
+	processed := processor.getProcessedEvents()
+	if len(processed) != len(expected) {
+		t.Fatalf("Expected %d processed events, got %d", len(expected), len(processed))
+	}
+	for i, ts := range expected {
+		if processed[i] != ts {
+			t.Errorf("Position %d: expected %s, got %s", i, ts, processed[i])
+		}
+	}
+
+	if m.MessageOrdering[OrderingReordered] != 1 {
+		t.Errorf("Expected 1 reordered message, got %d", m.MessageOrdering[OrderingReordered])
+	}
+	if m.MessageOrdering[OrderingOutOfOrder] != 0 {
+		t.Errorf("Expected no out-of-order messages, got %d", m.MessageOrdering[OrderingOutOfOrder])
+	}
+}
+
+func TestWorkerPool_DetectsOutOfOrderWithoutWindow(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	processor := newMockEventProcessor(0)
+	m := metrics.NewMetrics()
+	workerPool := NewWorkerPool(processor, 10, 1*time.Minute, logger)
+	workerPool.SetMetrics(m)
+
+	for i, ts := range []string{"1000.000002", "1000.000001"} {
+		workerPool.Enqueue(&model.MessageEvent{
+			EventID:    fmt.Sprintf("evt%d", i),
+			ChannelID:  "C123",
+			UserID:     "U456",
+			MessageTS:  ts,
+			Payload:    map[string]interface{}{"event": map[string]interface{}{"type": "message", "ts": ts}},
+			ReceivedAt: time.Now(),
+		})
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	_ = workerPool.Shutdown(5 * time.Second)
+
+	if processor.getCallCount() != 2 {
+		t.Errorf("Expected 2 processed events, got %d", processor.getCallCount())
+	}
+	if m.MessageOrdering[OrderingOutOfOrder] != 1 {
+		t.Errorf("Expected 1 out-of-order message, got %d", m.MessageOrdering[OrderingOutOfOrder])
+	}
+}
+
+// deadlineEventProcessor waits for the event context to be done, like a slow AI call
+type deadlineEventProcessor struct {
+	errs chan error
+}
+
+func (p *deadlineEventProcessor) ProcessEvent(ctx context.Context, payload map[string]interface{}) {
+	<-ctx.Done()
+	p.errs <- ctx.Err()
+}
+
+func TestWorkerPool_ProcessingTimeout(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	processor := &deadlineEventProcessor{errs: make(chan error, 2)}
+	m := metrics.NewMetrics()
+	workerPool := NewWorkerPool(processor, 10, 1*time.Minute, logger)
+	workerPool.SetProcessingTimeout(20 * time.Millisecond)
+	workerPool.SetMetrics(m)
+
+	for i := 0; i < 2; i++ {
+		workerPool.Enqueue(&model.MessageEvent{
+			EventID:    fmt.Sprintf("evt%d", i),
+			ChannelID:  "C123",
+			MessageTS:  fmt.Sprintf("1000.00000%d", i),
+			Payload:    map[string]interface{}{"event": map[string]interface{}{}},
+			ReceivedAt: time.Now(),
+		})
+	}
+
+	// Both events finish: the first one's deadline does not block the queue
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-processor.errs:
+			if err != context.DeadlineExceeded {
+				t.Errorf("Expected deadline exceeded, got %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for event processing")
+		}
+	}
+
+	_ = workerPool.Shutdown(5 * time.Second)
+
+	if m.ErrorsByType["processing_timeout"] != 2 {
+		t.Errorf("Expected 2 processing timeouts, got %d", m.ErrorsByType["processing_timeout"])
+	}
+}
+
+func TestWorkerPool_DecodesRawPayload(t *testing.T) {
+	processor := newMockEventProcessor(0)
+	m := metrics.NewMetrics()
+	workerPool := NewWorkerPool(processor, 10, 1*time.Minute, zap.NewNop())
+	workerPool.SetMetrics(m)
+
+	workerPool.Enqueue(&model.MessageEvent{
+		EventID:    "evt1",
+		ChannelID:  "C123",
+		MessageTS:  "1000.001",
+		EventType:  "message",
+		RawPayload: []byte(`{"event":{"type":"message","ts":"1000.001"}}`),
+		ReceivedAt: time.Now(),
+	})
+	workerPool.Enqueue(&model.MessageEvent{
+		EventID:    "evt2",
+		ChannelID:  "C123",
+		MessageTS:  "1000.002",
+		RawPayload: []byte(`{"event":`),
+		ReceivedAt: time.Now(),
+	})
+	_ = workerPool.Shutdown(5 * time.Second)
+
+	if got := processor.getProcessedEvents(); len(got) != 1 || got[0] != "1000.001" {
+		t.Errorf("Expected only the decodable event to be processed, got %v", got)
+	}
+	if m.ErrorsByType["payload_decode_failed"] != 1 {
+		t.Errorf("Expected 1 payload decode failure metric, got %d", m.ErrorsByType["payload_decode_failed"])
+	}
+}
+
+type pausedChannels map[string]bool
+
+func (p pausedChannels) IsPaused(channelID string) bool {
+	return p[channelID]
+}
+
+func TestWorkerPool_DropsPausedChannels(t *testing.T) {
+	processor := newMockEventProcessor(0)
+	m := metrics.NewMetrics()
+	workerPool := NewWorkerPool(processor, 10, 1*time.Minute, zap.NewNop())
+	workerPool.SetMetrics(m)
+	workerPool.SetChannelPauses(pausedChannels{"CPAUSED": true})
+
+	for _, channelID := range []string{"CPAUSED", "C123"} {
+		workerPool.Enqueue(&model.MessageEvent{
+			EventID:    "evt-" + channelID,
+			ChannelID:  channelID,
+			MessageTS:  "1000.001",
+			Payload:    map[string]interface{}{"event": map[string]interface{}{"ts": channelID}},
+			ReceivedAt: time.Now(),
+		})
+	}
+	_ = workerPool.Shutdown(5 * time.Second)
+
+	if got := processor.getProcessedEvents(); len(got) != 1 || got[0] != "C123" {
+		t.Errorf("Expected only the running channel to be processed, got %v", got)
+	}
+	if m.ErrorsByType["channel_paused_dropped"] != 1 {
+		t.Errorf("Expected 1 paused channel drop, got %d", m.ErrorsByType["channel_paused_dropped"])
+	}
+}
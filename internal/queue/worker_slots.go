@@ -0,0 +1,163 @@
+package queue
+
+import (
+	"sync/atomic"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+// waitingQueue is a channel queue with messages but no worker, because every
+// worker slot is taken
+type waitingQueue struct {
+	queueKey     string
+	eventChan    chan *model.MessageEvent
+	highPriority bool
+}
+
+// workerSlots caps the number of queue workers running at once. Queues that
+// get messages while every slot is taken wait for one in arrival order, and
+// workers whose queue has run empty give their slot up to them instead of
+// idling. Queues started by a high-priority event wait ahead of the others.
+type workerSlots struct {
+	slots   chan struct{}
+	waiting []waitingQueue
+	// wanted is closed while queues are waiting, to wake idle workers
+	wanted chan struct{}
+}
+
+func newWorkerSlots(maxWorkers int) *workerSlots {
+	return &workerSlots{
+		slots:  make(chan struct{}, maxWorkers),
+		wanted: make(chan struct{}),
+	}
+}
+
+// SetMaxWorkers caps the number of channel queues processed at once, so a
+// burst across many channels cannot start a worker goroutine for each of
+// them. A queue beyond the cap waits until another queue runs empty.
+// Zero or less leaves the number of workers unbounded. It must be called
+// before the first Enqueue.
+func (wp *WorkerPool) SetMaxWorkers(maxWorkers int) {
+	if maxWorkers <= 0 {
+		wp.slots = nil
+		return
+	}
+	wp.slots = newWorkerSlots(maxWorkers)
+}
+
+// SetName labels the pool's worker and queue gauges in metrics
+func (wp *WorkerPool) SetName(name string) {
+	wp.name = name
+}
+
+// startWorker starts a worker for a new queue, or parks the queue until a
+// worker slot is free
+func (wp *WorkerPool) startWorker(queueKey string, eventChan chan *model.MessageEvent, highPriority bool) {
+	if wp.slots == nil {
+		wp.spawnWorker(queueKey, eventChan)
+		return
+	}
+
+	wp.slotsMu.Lock()
+	defer wp.slotsMu.Unlock()
+	select {
+	case wp.slots.slots <- struct{}{}:
+		wp.spawnWorker(queueKey, eventChan)
+	default:
+		if len(wp.slots.waiting) == 0 {
+			close(wp.slots.wanted)
+		}
+		wp.slots.waiting = insertWaiting(wp.slots.waiting, waitingQueue{queueKey: queueKey, eventChan: eventChan, highPriority: highPriority})
+		wp.logger.Info("Worker cap reached, queue waiting for a worker",
+			zap.String("queue_key", queueKey),
+			zap.Int("max_workers", cap(wp.slots.slots)),
+			zap.Int("waiting", len(wp.slots.waiting)))
+	}
+}
+
+// insertWaiting queues q behind the queues of its priority and ahead of the
+// lower-priority ones
+func insertWaiting(waiting []waitingQueue, q waitingQueue) []waitingQueue {
+	i := len(waiting)
+	if q.highPriority {
+		for i > 0 && !waiting[i-1].highPriority {
+			i--
+		}
+	}
+	waiting = append(waiting, waitingQueue{})
+	copy(waiting[i+1:], waiting[i:])
+	waiting[i] = q
+	return waiting
+}
+
+// spawnWorker runs a new worker for the queue
+func (wp *WorkerPool) spawnWorker(queueKey string, eventChan chan *model.MessageEvent) {
+	atomic.AddInt64(&wp.activeWorkers, 1)
+	wp.wg.Add(1)
+	go wp.worker(wp.registerWorker(queueKey, eventChan))
+}
+
+// releaseWorkerSlot hands the slot of a worker that cleaned up its queue to
+// the longest waiting queue, or frees it
+func (wp *WorkerPool) releaseWorkerSlot() {
+	if wp.slots == nil {
+		return
+	}
+
+	wp.slotsMu.Lock()
+	defer wp.slotsMu.Unlock()
+	if len(wp.slots.waiting) == 0 {
+		<-wp.slots.slots
+		return
+	}
+	next := wp.slots.waiting[0]
+	wp.slots.waiting = wp.slots.waiting[1:]
+	if len(wp.slots.waiting) == 0 {
+		wp.slots.wanted = make(chan struct{})
+	}
+	wp.spawnWorker(next.queueKey, next.eventChan)
+}
+
+// workerWanted returns a channel that is closed while queues wait for a
+// worker slot; it is nil, and never ready, without a worker cap
+func (wp *WorkerPool) workerWanted() <-chan struct{} {
+	if wp.slots == nil {
+		return nil
+	}
+	wp.slotsMu.Lock()
+	defer wp.slotsMu.Unlock()
+	return wp.slots.wanted
+}
+
+// queuesWaiting returns the number of queues waiting for a worker slot
+func (wp *WorkerPool) queuesWaiting() int {
+	if wp.slots == nil {
+		return 0
+	}
+	wp.slotsMu.Lock()
+	defer wp.slotsMu.Unlock()
+	return len(wp.slots.waiting)
+}
+
+// ActiveWorkers returns the number of running queue workers, including
+// workers replaced by the watchdog that are still stuck on a call
+func (wp *WorkerPool) ActiveWorkers() int {
+	return int(atomic.LoadInt64(&wp.activeWorkers))
+}
+
+// observeWorkers records the pool's worker and queue gauges
+func (wp *WorkerPool) observeWorkers() {
+	if wp.metrics == nil {
+		return
+	}
+	maxWorkers := 0
+	if wp.slots != nil {
+		maxWorkers = cap(wp.slots.slots)
+	}
+	name := wp.name
+	if name == "" {
+		name = "default"
+	}
+	wp.metrics.RecordWorkerPool(name, wp.ActiveWorkers(), wp.GetQueueCount(), wp.queuesWaiting(), maxWorkers)
+}
@@ -0,0 +1,95 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// concurrencyProcessor records how many events were processed at once
+type concurrencyProcessor struct {
+	mu        sync.Mutex
+	running   int
+	peak      int
+	processed int
+}
+
+func (p *concurrencyProcessor) ProcessEvent(ctx context.Context, payload map[string]interface{}) {
+	p.mu.Lock()
+	p.running++
+	if p.running > p.peak {
+		p.peak = p.running
+	}
+	p.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	p.mu.Lock()
+	p.running--
+	p.processed++
+	p.mu.Unlock()
+}
+
+func TestWorkerPool_MaxWorkers(t *testing.T) {
+	processor := &concurrencyProcessor{}
+	m := metrics.NewMetrics()
+	wp := NewWorkerPool(processor, 10, time.Minute, zap.NewNop())
+	wp.SetName("slack")
+	wp.SetMetrics(m)
+	wp.SetMaxWorkers(2)
+
+	// Workers idle for up to a minute, so the later channels are only
+	// processed in time when workers hand their slot over
+	for i := 0; i < 6; i++ {
+		for j := 0; j < 2; j++ {
+			wp.Enqueue(&model.MessageEvent{
+				EventID:   fmt.Sprintf("evt-%d-%d", i, j),
+				ChannelID: fmt.Sprintf("C%d", i),
+				MessageTS: fmt.Sprintf("1000.00%d", j),
+				Payload:   map[string]interface{}{},
+			})
+		}
+	}
+
+	assert.Eventually(t, func() bool {
+		processor.mu.Lock()
+		defer processor.mu.Unlock()
+		return processor.processed == 12
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.LessOrEqual(t, processor.peak, 2)
+	assert.LessOrEqual(t, wp.ActiveWorkers(), 2)
+	assert.Zero(t, wp.queuesWaiting())
+
+	require.NoError(t, wp.Shutdown(5*time.Second))
+	assert.Zero(t, wp.ActiveWorkers())
+	gauges, ok := m.GetStats()["worker_pools"].(map[string]metrics.WorkerPoolGauges)["slack"]
+	require.True(t, ok)
+	assert.Equal(t, metrics.WorkerPoolGauges{MaxWorkers: 2}, gauges)
+}
+
+func TestWorkerPool_MaxWorkersDrainsWaitingQueuesOnShutdown(t *testing.T) {
+	processor := &concurrencyProcessor{}
+	wp := NewWorkerPool(processor, 10, time.Minute, zap.NewNop())
+	wp.SetMaxWorkers(1)
+
+	for i := 0; i < 3; i++ {
+		wp.Enqueue(&model.MessageEvent{
+			EventID:   fmt.Sprintf("evt-%d", i),
+			ChannelID: fmt.Sprintf("C%d", i),
+			MessageTS: "1000.001",
+			Payload:   map[string]interface{}{},
+		})
+	}
+
+	require.NoError(t, wp.Shutdown(5*time.Second))
+	assert.Equal(t, 3, processor.processed)
+	assert.Equal(t, 1, processor.peak)
+}
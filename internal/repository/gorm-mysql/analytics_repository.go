@@ -0,0 +1,65 @@
+package gormmysql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AnalyticsRepositoryImpl implements service.AnalyticsRepository interface
+type AnalyticsRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAnalyticsRepository creates a new channel usage repository instance
+func NewAnalyticsRepository(db *gorm.DB) service.AnalyticsRepository {
+	return &AnalyticsRepositoryImpl{db: db}
+}
+
+// Record adds usage to the channel's counters for its day and language pair
+func (ar *AnalyticsRepositoryImpl) Record(usage *model.ChannelUsage) error {
+	err := ar.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "channel_id"}, {Name: "day"}, {Name: "source_language"}, {Name: "target_language"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"translations":  gorm.Expr("translations + " + ar.inserted("translations")),
+			"cache_hits":    gorm.Expr("cache_hits + " + ar.inserted("cache_hits")),
+			"prompt_tokens": gorm.Expr("prompt_tokens + " + ar.inserted("prompt_tokens")),
+			"output_tokens": gorm.Expr("output_tokens + " + ar.inserted("output_tokens")),
+			"updated_at":    gorm.Expr(ar.inserted("updated_at")),
+		}),
+	}).Create(usage).Error
+	if err != nil {
+		return fmt.Errorf("failed to record channel usage: %w", err)
+	}
+	return nil
+}
+
+// inserted refers to column of the row being inserted in an upsert's update
+func (ar *AnalyticsRepositoryImpl) inserted(column string) string {
+	if ar.db.Dialector.Name() == "sqlite" {
+		return "excluded." + column
+	}
+	return "VALUES(" + column + ")"
+}
+
+// SumByLanguagePair sums a channel's usage per language pair from day since
+func (ar *AnalyticsRepositoryImpl) SumByLanguagePair(channelID string, since time.Time) ([]model.ChannelUsage, error) {
+	var usage []model.ChannelUsage
+
+	result := ar.db.Model(&model.ChannelUsage{}).
+		Where("channel_id = ? AND day >= ?", channelID, since).
+		Select("channel_id, source_language, target_language, " +
+			"SUM(translations) AS translations, SUM(cache_hits) AS cache_hits, " +
+			"SUM(prompt_tokens) AS prompt_tokens, SUM(output_tokens) AS output_tokens").
+		Group("channel_id, source_language, target_language").
+		Scan(&usage)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to sum channel usage: %w", result.Error)
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,52 @@
+package gormmysql
+
+import (
+	"fmt"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"gorm.io/gorm"
+)
+
+// AuditRepositoryImpl implements service.AuditRepository interface.
+// Records are append-only: there is no update or delete.
+type AuditRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository creates a new audit repository instance
+func NewAuditRepository(db *gorm.DB) service.AuditRepository {
+	return &AuditRepositoryImpl{db: db}
+}
+
+func (ar *AuditRepositoryImpl) Save(record *model.AuditRecord) error {
+	if err := ar.db.Create(record).Error; err != nil {
+		return fmt.Errorf("failed to save audit record: %w", err)
+	}
+	return nil
+}
+
+func (ar *AuditRepositoryImpl) Find(query model.AuditQuery) ([]*model.AuditRecord, error) {
+	var records []*model.AuditRecord
+
+	db := ar.db
+	if query.Actor != "" {
+		db = db.Where("actor = ?", query.Actor)
+	}
+	if query.ResourceType != "" {
+		db = db.Where("resource_type = ?", query.ResourceType)
+	}
+	if query.ResourceID != "" {
+		db = db.Where("resource_id = ?", query.ResourceID)
+	}
+	if !query.Since.IsZero() {
+		db = db.Where("created_at >= ?", query.Since)
+	}
+
+	result := db.Order("created_at DESC").Limit(query.Limit).Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to query audit records: %w", result.Error)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,79 @@
+package gormmysql
+
+import (
+	"fmt"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"gorm.io/gorm"
+)
+
+// ChannelPairRepositoryImpl implements service.ChannelPairRepository interface
+type ChannelPairRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewChannelPairRepository creates a new channel pair repository instance
+func NewChannelPairRepository(db *gorm.DB) service.ChannelPairRepository {
+	return &ChannelPairRepositoryImpl{db: db}
+}
+
+func (pr *ChannelPairRepositoryImpl) Save(pair *model.ChannelPair) error {
+	if err := pr.db.Create(pair).Error; err != nil {
+		return fmt.Errorf("failed to save channel pair: %w", err)
+	}
+	return nil
+}
+
+func (pr *ChannelPairRepositoryImpl) GetByID(id string) (*model.ChannelPair, error) {
+	pair := &model.ChannelPair{}
+
+	result := pr.db.Where("id = ?", id).First(pair)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, model.NewNotFoundError("channel pair not found")
+		}
+		return nil, fmt.Errorf("failed to get channel pair: %w", result.Error)
+	}
+
+	return pair, nil
+}
+
+// GetByChannelID returns the pair either side of which is channelID, or nil if there is none
+func (pr *ChannelPairRepositoryImpl) GetByChannelID(channelID string) (*model.ChannelPair, error) {
+	pair := &model.ChannelPair{}
+
+	result := pr.db.Where("channel_id = ? OR paired_channel_id = ?", channelID, channelID).First(pair)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get channel pair: %w", result.Error)
+	}
+
+	return pair, nil
+}
+
+func (pr *ChannelPairRepositoryImpl) GetAll() ([]*model.ChannelPair, error) {
+	var pairs []*model.ChannelPair
+
+	result := pr.db.Order("created_at DESC").Find(&pairs)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to query channel pairs: %w", result.Error)
+	}
+
+	return pairs, nil
+}
+
+func (pr *ChannelPairRepositoryImpl) Delete(id string) error {
+	result := pr.db.Where("id = ?", id).Delete(&model.ChannelPair{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete channel pair: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return model.NewNotFoundError("channel pair not found")
+	}
+
+	return nil
+}
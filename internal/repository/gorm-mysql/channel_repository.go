@@ -31,7 +31,7 @@ func (cr *ChannelRepositoryImpl) GetByChannelID(channelID string) (*model.Channe
 	result := cr.db.Where("channel_id = ?", channelID).First(config)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("channel config not found")
+			return nil, model.NewNotFoundError("channel config not found")
 		}
 		return nil, fmt.Errorf("failed to get channel config: %w", result.Error)
 	}
@@ -41,18 +41,25 @@ func (cr *ChannelRepositoryImpl) GetByChannelID(channelID string) (*model.Channe
 
 func (cr *ChannelRepositoryImpl) Update(config *model.ChannelConfig) error {
 	result := cr.db.Model(&model.ChannelConfig{}).Where("channel_id = ?", config.ChannelID).Updates(map[string]interface{}{
-		"auto_translate":   config.AutoTranslate,
-		"source_languages": config.SourceLanguages,
-		"target_language":  config.TargetLanguage,
-		"enabled":          config.Enabled,
-		"updated_at":       config.UpdatedAt,
+		"auto_translate":          config.AutoTranslate,
+		"source_languages":        config.SourceLanguages,
+		"target_language":         config.TargetLanguage,
+		"enabled":                 config.Enabled,
+		"canary":                  config.Canary,
+		"debug":                   config.Debug,
+		"review_channel_id":       config.ReviewChannelID,
+		"digest_interval_minutes": config.DigestIntervalMinutes,
+		"digest_max_messages":     config.DigestMaxMessages,
+		"delivery_mode":           config.DeliveryMode,
+		"generation":              config.Generation,
+		"updated_at":              config.UpdatedAt,
 	})
 	if result.Error != nil {
 		return fmt.Errorf("failed to update channel config: %w", result.Error)
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("channel config not found")
+		return model.NewNotFoundError("channel config not found")
 	}
 
 	return nil
@@ -65,7 +72,7 @@ func (cr *ChannelRepositoryImpl) Delete(channelID string) error {
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("channel config not found")
+		return model.NewNotFoundError("channel config not found")
 	}
 
 	return nil
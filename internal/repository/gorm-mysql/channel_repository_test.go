@@ -48,14 +48,14 @@ func TestChannelRepositoryImpl_Save(t *testing.T) {
 				ChannelID:       "C123456",
 				TargetLanguage:  "Vietnamese",
 				Enabled:         true,
-				SourceLanguages: `["English"]`,
+				SourceLanguages: model.LanguageList{"English"},
 				CreatedAt:       now,
 				UpdatedAt:       now,
 			},
 			mockSetup: func(mock sqlmock.Sqlmock, config *model.ChannelConfig) {
 				mock.ExpectBegin()
 				mock.ExpectExec("INSERT INTO `channel_configs`").
-					WithArgs(config.ID, config.ChannelID, config.AutoTranslate, config.SourceLanguages, config.TargetLanguage, config.Enabled, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(config.ID, config.ChannelID, config.AutoTranslate, config.SourceLanguages, config.TargetLanguage, config.Enabled, config.Canary, config.Debug, config.ReviewChannelID, config.DigestIntervalMinutes, config.DigestMaxMessages, config.DeliveryMode, config.Generation, sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 				mock.ExpectCommit()
 			},
@@ -69,14 +69,14 @@ func TestChannelRepositoryImpl_Save(t *testing.T) {
 				AutoTranslate:   true,
 				TargetLanguage:  "Spanish",
 				Enabled:         true,
-				SourceLanguages: `["French"]`,
+				SourceLanguages: model.LanguageList{"French"},
 				CreatedAt:       now,
 				UpdatedAt:       now,
 			},
 			mockSetup: func(mock sqlmock.Sqlmock, config *model.ChannelConfig) {
 				mock.ExpectBegin()
 				mock.ExpectExec("INSERT INTO `channel_configs`").
-					WithArgs(config.ID, config.ChannelID, config.AutoTranslate, config.SourceLanguages, config.TargetLanguage, config.Enabled, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(config.ID, config.ChannelID, config.AutoTranslate, config.SourceLanguages, config.TargetLanguage, config.Enabled, config.Canary, config.Debug, config.ReviewChannelID, config.DigestIntervalMinutes, config.DigestMaxMessages, config.DeliveryMode, config.Generation, sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 				mock.ExpectCommit()
 			},
@@ -183,13 +183,13 @@ func TestChannelRepositoryImpl_Update(t *testing.T) {
 				ChannelID:       "C123456",
 				TargetLanguage:  "English",
 				Enabled:         false,
-				SourceLanguages: `["Vietnamese"]`,
+				SourceLanguages: model.LanguageList{"Vietnamese"},
 				UpdatedAt:       now,
 			},
 			mockSetup: func(mock sqlmock.Sqlmock, config *model.ChannelConfig) {
 				mock.ExpectBegin()
 				mock.ExpectExec("UPDATE `channel_configs` SET").
-					WithArgs(config.AutoTranslate, config.Enabled, `["Vietnamese"]`, config.TargetLanguage, sqlmock.AnyArg(), config.ChannelID).
+					WithArgs(config.AutoTranslate, config.Canary, config.Debug, config.DeliveryMode, config.DigestIntervalMinutes, config.DigestMaxMessages, config.Enabled, config.Generation, config.ReviewChannelID, `["Vietnamese"]`, config.TargetLanguage, sqlmock.AnyArg(), config.ChannelID).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 				mock.ExpectCommit()
 			},
@@ -202,12 +202,12 @@ func TestChannelRepositoryImpl_Update(t *testing.T) {
 				ChannelID:       "C999999",
 				TargetLanguage:  "English",
 				Enabled:         true,
-				SourceLanguages: `["Vietnamese"]`,
+				SourceLanguages: model.LanguageList{"Vietnamese"},
 			},
 			mockSetup: func(mock sqlmock.Sqlmock, config *model.ChannelConfig) {
 				mock.ExpectBegin()
 				mock.ExpectExec("UPDATE `channel_configs` SET").
-					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), config.ChannelID).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), config.ChannelID).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 				mock.ExpectCommit()
 			},
@@ -0,0 +1,80 @@
+package gormmysql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"gorm.io/gorm"
+)
+
+// BotPostRepositoryImpl implements service.BotPostRepository interface.
+// Posts are append-only: there is no update or delete.
+type BotPostRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewBotPostRepository creates a new bot post repository instance
+func NewBotPostRepository(db *gorm.DB) service.BotPostRepository {
+	return &BotPostRepositoryImpl{db: db}
+}
+
+func (br *BotPostRepositoryImpl) Save(post *model.BotPost) error {
+	if err := br.db.Create(post).Error; err != nil {
+		return fmt.Errorf("failed to save bot post: %w", err)
+	}
+	return nil
+}
+
+func (br *BotPostRepositoryImpl) FindRange(teamID string, from, to time.Time, after *model.BotPost, limit int) ([]*model.BotPost, error) {
+	var posts []*model.BotPost
+
+	db := br.db.Where("posted_at >= ? AND posted_at < ?", from, to)
+	if teamID != "" {
+		db = db.Where("team_id = ?", teamID)
+	}
+	if after != nil {
+		db = db.Where("posted_at > ? OR (posted_at = ? AND id > ?)", after.PostedAt, after.PostedAt, after.ID)
+	}
+
+	result := db.Order("posted_at ASC, id ASC").Limit(limit).Find(&posts)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to query bot posts: %w", result.Error)
+	}
+	return posts, nil
+}
+
+// ComplianceExportRepositoryImpl implements service.ComplianceExportRepository interface
+type ComplianceExportRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewComplianceExportRepository creates a new compliance export repository instance
+func NewComplianceExportRepository(db *gorm.DB) service.ComplianceExportRepository {
+	return &ComplianceExportRepositoryImpl{db: db}
+}
+
+func (cr *ComplianceExportRepositoryImpl) Save(export *model.ComplianceExport) error {
+	if err := cr.db.Create(export).Error; err != nil {
+		return fmt.Errorf("failed to save compliance export: %w", err)
+	}
+	return nil
+}
+
+func (cr *ComplianceExportRepositoryImpl) Latest() (*model.ComplianceExport, error) {
+	exports, err := cr.List(1)
+	if err != nil || len(exports) == 0 {
+		return nil, err
+	}
+	return exports[0], nil
+}
+
+func (cr *ComplianceExportRepositoryImpl) List(limit int) ([]*model.ComplianceExport, error) {
+	var exports []*model.ComplianceExport
+	result := cr.db.Order("created_at DESC, id DESC").Limit(limit).Find(&exports)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to query compliance exports: %w", result.Error)
+	}
+	return exports, nil
+}
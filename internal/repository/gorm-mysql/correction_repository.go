@@ -0,0 +1,42 @@
+package gormmysql
+
+import (
+	"fmt"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"gorm.io/gorm"
+)
+
+// CorrectionRepositoryImpl implements service.CorrectionRepository interface
+type CorrectionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewCorrectionRepository creates a new translation correction repository instance
+func NewCorrectionRepository(db *gorm.DB) service.CorrectionRepository {
+	return &CorrectionRepositoryImpl{db: db}
+}
+
+func (cr *CorrectionRepositoryImpl) Save(correction *model.TranslationCorrection) error {
+	if err := cr.db.Create(correction).Error; err != nil {
+		return fmt.Errorf("failed to save translation correction: %w", err)
+	}
+	return nil
+}
+
+func (cr *CorrectionRepositoryImpl) Find(query model.CorrectionQuery) ([]*model.TranslationCorrection, error) {
+	var corrections []*model.TranslationCorrection
+
+	db := cr.db
+	if query.ChannelID != "" {
+		db = db.Where("channel_id = ?", query.ChannelID)
+	}
+
+	result := db.Order("created_at DESC").Limit(query.Limit).Find(&corrections)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to query translation corrections: %w", result.Error)
+	}
+
+	return corrections, nil
+}
@@ -0,0 +1,56 @@
+package gormmysql
+
+import (
+	"fmt"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FeedbackRepositoryImpl implements service.FeedbackRepository interface
+type FeedbackRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewFeedbackRepository creates a new translation feedback repository instance
+func NewFeedbackRepository(db *gorm.DB) service.FeedbackRepository {
+	return &FeedbackRepositoryImpl{db: db}
+}
+
+// Save stores a rating, replacing the user's earlier rating of the same reply
+func (fr *FeedbackRepositoryImpl) Save(feedback *model.TranslationFeedback) error {
+	err := fr.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "channel_id"}, {Name: "message_ts"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rating", "updated_at"}),
+	}).Create(feedback).Error
+	if err != nil {
+		return fmt.Errorf("failed to save translation feedback: %w", err)
+	}
+	return nil
+}
+
+func (fr *FeedbackRepositoryImpl) SummarizeByLanguagePair(query model.FeedbackQuery) ([]model.LanguagePairFeedback, error) {
+	var pairs []model.LanguagePairFeedback
+
+	db := fr.db.Model(&model.TranslationFeedback{})
+	if query.ChannelID != "" {
+		db = db.Where("channel_id = ?", query.ChannelID)
+	}
+	if !query.Since.IsZero() {
+		db = db.Where("created_at >= ?", query.Since)
+	}
+
+	result := db.Select("source_language, target_language, " +
+		"SUM(CASE WHEN rating > 0 THEN 1 ELSE 0 END) AS positive, " +
+		"SUM(CASE WHEN rating < 0 THEN 1 ELSE 0 END) AS negative").
+		Group("source_language, target_language").
+		Order("source_language, target_language").
+		Scan(&pairs)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to summarize translation feedback: %w", result.Error)
+	}
+
+	return pairs, nil
+}
@@ -0,0 +1,83 @@
+package gormmysql
+
+import (
+	"fmt"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"gorm.io/gorm"
+)
+
+// FilterRuleRepositoryImpl implements service.FilterRuleRepository interface
+type FilterRuleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewFilterRuleRepository creates a new filter rule repository instance
+func NewFilterRuleRepository(db *gorm.DB) service.FilterRuleRepository {
+	return &FilterRuleRepositoryImpl{db: db}
+}
+
+func (fr *FilterRuleRepositoryImpl) Save(rule *model.FilterRule) error {
+	if err := fr.db.Create(rule).Error; err != nil {
+		return fmt.Errorf("failed to save filter rule: %w", err)
+	}
+	return nil
+}
+
+func (fr *FilterRuleRepositoryImpl) GetByID(id string) (*model.FilterRule, error) {
+	rule := &model.FilterRule{}
+
+	result := fr.db.Where("id = ?", id).First(rule)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, model.NewNotFoundError("filter rule not found")
+		}
+		return nil, fmt.Errorf("failed to get filter rule: %w", result.Error)
+	}
+
+	return rule, nil
+}
+
+func (fr *FilterRuleRepositoryImpl) GetByChannelID(channelID string) ([]*model.FilterRule, error) {
+	var rules []*model.FilterRule
+
+	result := fr.db.Where("channel_id = ?", channelID).Order("created_at ASC").Find(&rules)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to query filter rules: %w", result.Error)
+	}
+
+	return rules, nil
+}
+
+func (fr *FilterRuleRepositoryImpl) Update(rule *model.FilterRule) error {
+	result := fr.db.Model(&model.FilterRule{}).Where("id = ?", rule.ID).Updates(map[string]interface{}{
+		"type":       rule.Type,
+		"pattern":    rule.Pattern,
+		"values":     rule.Values,
+		"enabled":    rule.Enabled,
+		"updated_at": rule.UpdatedAt,
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update filter rule: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return model.NewNotFoundError("filter rule not found")
+	}
+
+	return nil
+}
+
+func (fr *FilterRuleRepositoryImpl) Delete(id string) error {
+	result := fr.db.Where("id = ?", id).Delete(&model.FilterRule{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete filter rule: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return model.NewNotFoundError("filter rule not found")
+	}
+
+	return nil
+}
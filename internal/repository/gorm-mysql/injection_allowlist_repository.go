@@ -0,0 +1,50 @@
+package gormmysql
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// injectionAllowlistRow is a phrase ignored by injection detection
+type injectionAllowlistRow struct {
+	Phrase    string `gorm:"primaryKey"`
+	CreatedAt time.Time
+}
+
+func (injectionAllowlistRow) TableName() string {
+	return "injection_allowlist"
+}
+
+// InjectionAllowlistRepositoryImpl stores the phrases allowed through the
+// injection feedback loop, so they survive restarts and reach every instance
+// on its next start
+type InjectionAllowlistRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewInjectionAllowlistRepository creates a new injection allowlist repository instance
+func NewInjectionAllowlistRepository(db *gorm.DB) *InjectionAllowlistRepositoryImpl {
+	return &InjectionAllowlistRepositoryImpl{db: db}
+}
+
+// Add stores a phrase; phrases already stored are left as they are
+func (ar *InjectionAllowlistRepositoryImpl) Add(phrase string) error {
+	row := &injectionAllowlistRow{Phrase: phrase, CreatedAt: time.Now()}
+	if err := ar.db.Clauses(clause.OnConflict{DoNothing: true}).Create(row).Error; err != nil {
+		return fmt.Errorf("failed to save allowlist phrase: %w", err)
+	}
+	return nil
+}
+
+// GetAll returns the stored phrases, oldest first
+func (ar *InjectionAllowlistRepositoryImpl) GetAll() ([]string, error) {
+	var phrases []string
+	result := ar.db.Model(&injectionAllowlistRow{}).Order("created_at, phrase").Pluck("phrase", &phrases)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to query allowlist phrases: %w", result.Error)
+	}
+	return phrases, nil
+}
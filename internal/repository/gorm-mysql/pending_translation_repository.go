@@ -0,0 +1,73 @@
+package gormmysql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"gorm.io/gorm"
+)
+
+// PendingTranslationRepositoryImpl implements service.PendingTranslationRepository interface
+type PendingTranslationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPendingTranslationRepository creates a new pending translation repository instance
+func NewPendingTranslationRepository(db *gorm.DB) service.PendingTranslationRepository {
+	return &PendingTranslationRepositoryImpl{db: db}
+}
+
+func (pr *PendingTranslationRepositoryImpl) Save(draft *model.PendingTranslation) error {
+	if err := pr.db.Create(draft).Error; err != nil {
+		return fmt.Errorf("failed to save pending translation: %w", err)
+	}
+	return nil
+}
+
+func (pr *PendingTranslationRepositoryImpl) GetByID(id string) (*model.PendingTranslation, error) {
+	draft := &model.PendingTranslation{}
+
+	result := pr.db.Where("id = ?", id).First(draft)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, model.NewNotFoundError("pending translation not found")
+		}
+		return nil, fmt.Errorf("failed to get pending translation: %w", result.Error)
+	}
+
+	return draft, nil
+}
+
+func (pr *PendingTranslationRepositoryImpl) SetReviewMessage(id, reviewMessageTS string) error {
+	result := pr.db.Model(&model.PendingTranslation{}).Where("id = ?", id).Update("review_message_ts", reviewMessageTS)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update pending translation: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return model.NewNotFoundError("pending translation not found")
+	}
+
+	return nil
+}
+
+// UpdateStatus moves a translation from one status to another. The update is
+// conditional on the current status so two reviewers cannot both decide it.
+func (pr *PendingTranslationRepositoryImpl) UpdateStatus(id, from, to, reviewerID string, reviewedAt *time.Time) error {
+	result := pr.db.Model(&model.PendingTranslation{}).Where("id = ? AND status = ?", id, from).Updates(map[string]interface{}{
+		"status":      to,
+		"reviewer_id": reviewerID,
+		"reviewed_at": reviewedAt,
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update pending translation status: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return model.NewBadRequestError("translation has already been reviewed")
+	}
+
+	return nil
+}
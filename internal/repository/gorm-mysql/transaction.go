@@ -0,0 +1,18 @@
+package gormmysql
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// WithTx runs fn inside a single database transaction.
+// The transaction is committed when fn returns nil and rolled back otherwise
+// (including when fn panics). Repositories built from tx share the transaction.
+func WithTx(ctx context.Context, db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	if err := db.WithContext(ctx).Transaction(fn); err != nil {
+		return fmt.Errorf("transaction failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package gormmysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestWithTx(t *testing.T) {
+	config := &model.ChannelConfig{
+		ID:             "test-1",
+		ChannelID:      "C123456",
+		TargetLanguage: "Vietnamese",
+		Enabled:        true,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	tests := []struct {
+		name        string
+		mockSetup   func(sqlmock.Sqlmock)
+		fn          func(tx *gorm.DB) error
+		expectError bool
+	}{
+		{
+			name: "commits when fn succeeds",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `channel_configs`").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			fn: func(tx *gorm.DB) error {
+				return NewChannelRepository(tx).Save(config)
+			},
+			expectError: false,
+		},
+		{
+			name: "rolls back when fn fails",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			fn: func(tx *gorm.DB) error {
+				return errors.New("boom")
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gormDB, mock := setupMockDB(t)
+			sqlDB, _ := gormDB.DB()
+			defer closeMockDB(t, sqlDB, mock)
+
+			tt.mockSetup(mock)
+
+			err := WithTx(context.Background(), gormDB, tt.fn)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package gormmysql
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
@@ -18,20 +20,21 @@ func NewTranslationRepository(db *gorm.DB) service.TranslationRepository {
 	return &TranslationRepositoryImpl{db: db}
 }
 
-func (tr *TranslationRepositoryImpl) Save(translation *model.Translation) error {
-	if err := tr.db.Create(translation).Error; err != nil {
+func (tr *TranslationRepositoryImpl) Save(ctx context.Context, translation *model.Translation) error {
+	if err := tr.db.WithContext(ctx).Create(translation).Error; err != nil {
 		return fmt.Errorf("failed to save translation: %w", err)
 	}
 	return nil
 }
 
-func (tr *TranslationRepositoryImpl) GetByHash(hash string) (*model.Translation, error) {
+// GetByHash returns model.ErrNotFound when no translation has the hash
+func (tr *TranslationRepositoryImpl) GetByHash(ctx context.Context, hash string) (*model.Translation, error) {
 	translation := &model.Translation{}
 
-	result := tr.db.Where("hash = ?", hash).First(translation)
+	result := tr.db.WithContext(ctx).Where("hash = ?", hash).First(translation)
 	if result.Error != nil {
-		if result.Error == gorm.ErrRecordNotFound {
-			return nil, nil
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to get translation by hash: %w", result.Error)
 	}
@@ -39,10 +42,10 @@ func (tr *TranslationRepositoryImpl) GetByHash(hash string) (*model.Translation,
 	return translation, nil
 }
 
-func (tr *TranslationRepositoryImpl) GetByID(id string) (*model.Translation, error) {
+func (tr *TranslationRepositoryImpl) GetByID(ctx context.Context, id string) (*model.Translation, error) {
 	translation := &model.Translation{}
 
-	result := tr.db.Where("id = ?", id).First(translation)
+	result := tr.db.WithContext(ctx).Where("id = ?", id).First(translation)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -53,10 +56,10 @@ func (tr *TranslationRepositoryImpl) GetByID(id string) (*model.Translation, err
 	return translation, nil
 }
 
-func (tr *TranslationRepositoryImpl) GetByChannelID(channelID string, limit int) ([]*model.Translation, error) {
+func (tr *TranslationRepositoryImpl) GetByChannelID(ctx context.Context, channelID string, limit int) ([]*model.Translation, error) {
 	var translations []*model.Translation
 
-	result := tr.db.Where("channel_id = ?", channelID).
+	result := tr.db.WithContext(ctx).Where("channel_id = ?", channelID).
 		Order("created_at DESC").
 		Limit(limit).
 		Find(&translations)
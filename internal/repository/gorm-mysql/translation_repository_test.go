@@ -1,6 +1,8 @@
 package gormmysql
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -25,17 +27,19 @@ func TestTranslationRepositoryImpl_Save(t *testing.T) {
 		Hash:            "abc123",
 		UserID:          "user-1",
 		ChannelID:       "channel-1",
+		Provider:        "gemini",
+		PromptVersion:   "v2",
 		CreatedAt:       time.Now(),
 		TTL:             3600,
 	}
 
 	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO `translations`").
-		WithArgs(translation.ID, translation.SourceMessageID, translation.SourceText, translation.SourceLanguage, translation.TargetLanguage, translation.TranslatedText, translation.Hash, translation.UserID, translation.ChannelID, sqlmock.AnyArg(), translation.TTL).
+		WithArgs(translation.ID, translation.SourceMessageID, translation.SourceText, translation.SourceLanguage, translation.TargetLanguage, translation.TranslatedText, translation.Hash, translation.UserID, translation.ChannelID, translation.Provider, translation.PromptVersion, "{}", translation.FailedStage, "{}", sqlmock.AnyArg(), translation.TTL).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
-	err := repo.Save(translation)
+	err := repo.Save(context.Background(), translation)
 
 	assert.NoError(t, err)
 }
@@ -75,7 +79,21 @@ func TestTranslationRepositoryImpl_GetByHash(t *testing.T) {
 					WillReturnRows(rows)
 			},
 			validateResult: func(t *testing.T, result *model.Translation, err error) {
-				assert.NoError(t, err)
+				assert.ErrorIs(t, err, model.ErrNotFound)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "query failure",
+			hash: "abc123",
+			mockSetup: func(mock sqlmock.Sqlmock, hash string, now time.Time) {
+				mock.ExpectQuery("SELECT \\* FROM `translations` WHERE hash = \\?").
+					WithArgs(hash, 1).
+					WillReturnError(errors.New("connection reset"))
+			},
+			validateResult: func(t *testing.T, result *model.Translation, err error) {
+				assert.Error(t, err)
+				assert.False(t, model.IsNotFound(err))
 				assert.Nil(t, result)
 			},
 		},
@@ -90,7 +108,7 @@ func TestTranslationRepositoryImpl_GetByHash(t *testing.T) {
 
 			tt.mockSetup(mock, tt.hash, now)
 
-			result, err := repo.GetByHash(tt.hash)
+			result, err := repo.GetByHash(context.Background(), tt.hash)
 
 			tt.validateResult(t, result, err)
 		})
@@ -114,7 +132,7 @@ func TestTranslationRepositoryImpl_GetByChannelID(t *testing.T) {
 		WithArgs(channelID, limit).
 		WillReturnRows(rows)
 
-	results, err := repo.GetByChannelID(channelID, limit)
+	results, err := repo.GetByChannelID(context.Background(), channelID, limit)
 
 	assert.NoError(t, err)
 	assert.Len(t, results, 2)
@@ -136,7 +154,7 @@ func TestTranslationRepositoryImpl_GetByID(t *testing.T) {
 		WithArgs(id, 1).
 		WillReturnRows(rows)
 
-	result, err := repo.GetByID(id)
+	result, err := repo.GetByID(context.Background(), id)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
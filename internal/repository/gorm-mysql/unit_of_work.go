@@ -0,0 +1,37 @@
+package gormmysql
+
+import (
+	"context"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"gorm.io/gorm"
+)
+
+// UnitOfWorkImpl implements service.UnitOfWork interface on top of WithTx
+type UnitOfWorkImpl struct {
+	db *gorm.DB
+}
+
+// NewUnitOfWork creates a new unit of work bound to the given database
+func NewUnitOfWork(db *gorm.DB) service.UnitOfWork {
+	return &UnitOfWorkImpl{db: db}
+}
+
+func (u *UnitOfWorkImpl) Do(ctx context.Context, fn func(repos service.TxRepositories) error) error {
+	return WithTx(ctx, u.db, func(tx *gorm.DB) error {
+		return fn(&txRepositories{tx: tx})
+	})
+}
+
+// txRepositories hands out repositories that share one transaction
+type txRepositories struct {
+	tx *gorm.DB
+}
+
+func (r *txRepositories) Translations() service.TranslationRepository {
+	return NewTranslationRepository(r.tx)
+}
+
+func (r *txRepositories) Channels() service.ChannelRepository {
+	return NewChannelRepository(r.tx)
+}
@@ -0,0 +1,47 @@
+package gormmysql
+
+import (
+	"fmt"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserPreferenceRepositoryImpl implements service.UserPreferenceRepository interface
+type UserPreferenceRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewUserPreferenceRepository creates a new user preference repository instance
+func NewUserPreferenceRepository(db *gorm.DB) service.UserPreferenceRepository {
+	return &UserPreferenceRepositoryImpl{db: db}
+}
+
+// GetByUserID returns the user's preferences, or nil when they have none
+func (ur *UserPreferenceRepositoryImpl) GetByUserID(userID string) (*model.UserPreference, error) {
+	preference := &model.UserPreference{}
+
+	result := ur.db.Where("user_id = ?", userID).First(preference)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get user preference: %w", result.Error)
+	}
+
+	return preference, nil
+}
+
+// Save stores the user's preferences, replacing earlier ones
+func (ur *UserPreferenceRepositoryImpl) Save(preference *model.UserPreference) error {
+	err := ur.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"preferred_language", "translate_own_messages", "updated_at"}),
+	}).Create(preference).Error
+	if err != nil {
+		return fmt.Errorf("failed to save user preference: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package gormmysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/secrets"
+	"gorm.io/gorm"
+)
+
+// workspaceRow is a workspace as stored, with each credential sealed by
+// envelope encryption
+type workspaceRow struct {
+	TeamID                  string `gorm:"primaryKey"`
+	TeamName                string
+	BotUserID               string
+	BotTokenKeyID           string
+	BotTokenWrappedKey      []byte
+	BotTokenCiphertext      []byte
+	SigningSecretKeyID      string
+	SigningSecretWrappedKey []byte
+	SigningSecretCiphertext []byte
+	InstalledBy             string
+	Generation              model.GenerationSettings
+	MonthlyBudget           float64
+	InstalledAt             time.Time
+	UpdatedAt               time.Time
+}
+
+func (workspaceRow) TableName() string {
+	return "workspaces"
+}
+
+// WorkspaceRepositoryImpl implements service.WorkspaceRepository interface.
+// Bot tokens and signing secrets are encrypted before they reach the database.
+type WorkspaceRepositoryImpl struct {
+	db       *gorm.DB
+	envelope *secrets.Envelope
+}
+
+// NewWorkspaceRepository creates a new workspace repository instance
+func NewWorkspaceRepository(db *gorm.DB, envelope *secrets.Envelope) service.WorkspaceRepository {
+	return &WorkspaceRepositoryImpl{db: db, envelope: envelope}
+}
+
+// Save inserts the workspace or replaces the stored one of the same team
+func (wr *WorkspaceRepositoryImpl) Save(workspace *model.Workspace) error {
+	row, err := wr.seal(workspace)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt workspace credentials: %w", err)
+	}
+	if err := wr.db.Save(row).Error; err != nil {
+		return fmt.Errorf("failed to save workspace: %w", err)
+	}
+	return nil
+}
+
+// GetByTeamID returns the workspace of teamID, or nil if the app is not installed there
+func (wr *WorkspaceRepositoryImpl) GetByTeamID(teamID string) (*model.Workspace, error) {
+	row := &workspaceRow{}
+
+	result := wr.db.Where("team_id = ?", teamID).First(row)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get workspace: %w", result.Error)
+	}
+
+	return wr.open(row)
+}
+
+func (wr *WorkspaceRepositoryImpl) GetAll() ([]*model.Workspace, error) {
+	var rows []*workspaceRow
+
+	result := wr.db.Order("installed_at DESC").Find(&rows)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to query workspaces: %w", result.Error)
+	}
+
+	workspaces := make([]*model.Workspace, 0, len(rows))
+	for _, row := range rows {
+		workspace, err := wr.open(row)
+		if err != nil {
+			return nil, err
+		}
+		workspaces = append(workspaces, workspace)
+	}
+	return workspaces, nil
+}
+
+func (wr *WorkspaceRepositoryImpl) Delete(teamID string) error {
+	result := wr.db.Where("team_id = ?", teamID).Delete(&workspaceRow{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete workspace: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return model.NewNotFoundError("workspace not found")
+	}
+
+	return nil
+}
+
+func (wr *WorkspaceRepositoryImpl) seal(workspace *model.Workspace) (*workspaceRow, error) {
+	ctx := context.Background()
+	botToken, err := wr.envelope.Seal(ctx, workspace.BotToken)
+	if err != nil {
+		return nil, err
+	}
+
+	row := &workspaceRow{
+		TeamID:             workspace.TeamID,
+		TeamName:           workspace.TeamName,
+		BotUserID:          workspace.BotUserID,
+		BotTokenKeyID:      botToken.KeyID,
+		BotTokenWrappedKey: botToken.WrappedKey,
+		BotTokenCiphertext: botToken.Ciphertext,
+		InstalledBy:        workspace.InstalledBy,
+		Generation:         workspace.Generation,
+		MonthlyBudget:      workspace.MonthlyBudget,
+		InstalledAt:        workspace.InstalledAt,
+		UpdatedAt:          workspace.UpdatedAt,
+	}
+	if workspace.SigningSecret != "" {
+		signingSecret, err := wr.envelope.Seal(ctx, workspace.SigningSecret)
+		if err != nil {
+			return nil, err
+		}
+		row.SigningSecretKeyID = signingSecret.KeyID
+		row.SigningSecretWrappedKey = signingSecret.WrappedKey
+		row.SigningSecretCiphertext = signingSecret.Ciphertext
+	}
+	return row, nil
+}
+
+func (wr *WorkspaceRepositoryImpl) open(row *workspaceRow) (*model.Workspace, error) {
+	ctx := context.Background()
+	botToken, err := wr.envelope.Open(ctx, &secrets.Sealed{
+		KeyID:      row.BotTokenKeyID,
+		WrappedKey: row.BotTokenWrappedKey,
+		Ciphertext: row.BotTokenCiphertext,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt bot token of workspace %s: %w", row.TeamID, err)
+	}
+
+	workspace := &model.Workspace{
+		TeamID:        row.TeamID,
+		TeamName:      row.TeamName,
+		BotUserID:     row.BotUserID,
+		BotToken:      botToken,
+		InstalledBy:   row.InstalledBy,
+		Generation:    row.Generation,
+		MonthlyBudget: row.MonthlyBudget,
+		InstalledAt:   row.InstalledAt,
+		UpdatedAt:     row.UpdatedAt,
+	}
+	if row.SigningSecretKeyID != "" {
+		signingSecret, err := wr.envelope.Open(ctx, &secrets.Sealed{
+			KeyID:      row.SigningSecretKeyID,
+			WrappedKey: row.SigningSecretWrappedKey,
+			Ciphertext: row.SigningSecretCiphertext,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt signing secret of workspace %s: %w", row.TeamID, err)
+		}
+		workspace.SigningSecret = signingSecret
+	}
+	return workspace, nil
+}
+
+// RotateKeys re-wraps the data keys of every stored bot token and signing
+// secret with the current master key, leaving their ciphertexts untouched.
+// Workspaces already sealed under the current key are left as they are. A
+// row is only rewritten if its wrapped keys are still the ones read, so a
+// reinstall in between is kept and the workspace counted as skipped.
+func (wr *WorkspaceRepositoryImpl) RotateKeys(ctx context.Context) (*model.KeyRotation, error) {
+	var rows []*workspaceRow
+	if err := wr.db.WithContext(ctx).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to query workspaces: %w", err)
+	}
+
+	rotation := &model.KeyRotation{}
+	for _, row := range rows {
+		updates := map[string]interface{}{}
+		botToken, changed, err := wr.envelope.Rotate(ctx, &secrets.Sealed{
+			KeyID:      row.BotTokenKeyID,
+			WrappedKey: row.BotTokenWrappedKey,
+			Ciphertext: row.BotTokenCiphertext,
+		})
+		if err != nil {
+			return rotation, fmt.Errorf("failed to rotate bot token of workspace %s: %w", row.TeamID, err)
+		}
+		if changed {
+			updates["bot_token_key_id"] = botToken.KeyID
+			updates["bot_token_wrapped_key"] = botToken.WrappedKey
+		}
+		if row.SigningSecretKeyID != "" {
+			signingSecret, changed, err := wr.envelope.Rotate(ctx, &secrets.Sealed{
+				KeyID:      row.SigningSecretKeyID,
+				WrappedKey: row.SigningSecretWrappedKey,
+				Ciphertext: row.SigningSecretCiphertext,
+			})
+			if err != nil {
+				return rotation, fmt.Errorf("failed to rotate signing secret of workspace %s: %w", row.TeamID, err)
+			}
+			if changed {
+				updates["signing_secret_key_id"] = signingSecret.KeyID
+				updates["signing_secret_wrapped_key"] = signingSecret.WrappedKey
+			}
+		}
+		if len(updates) == 0 {
+			continue
+		}
+
+		query := wr.db.WithContext(ctx).Model(&workspaceRow{}).
+			Where("team_id = ? AND bot_token_key_id = ? AND bot_token_wrapped_key = ? AND signing_secret_key_id = ?",
+				row.TeamID, row.BotTokenKeyID, row.BotTokenWrappedKey, row.SigningSecretKeyID)
+		if row.SigningSecretWrappedKey == nil {
+			query = query.Where("signing_secret_wrapped_key IS NULL")
+		} else {
+			query = query.Where("signing_secret_wrapped_key = ?", row.SigningSecretWrappedKey)
+		}
+		result := query.UpdateColumns(updates)
+		if result.Error != nil {
+			return rotation, fmt.Errorf("failed to save rotated keys of workspace %s: %w", row.TeamID, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			rotation.Skipped++
+			continue
+		}
+		rotation.Rotated++
+	}
+	return rotation, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+// AnalyticsRepository defines the interface for channel usage persistence.
+// This interface is owned by the AnalyticsUseCase and defined where it's consumed.
+type AnalyticsRepository interface {
+	Record(usage *model.ChannelUsage) error
+	SumByLanguagePair(channelID string, since time.Time) ([]model.ChannelUsage, error)
+}
+
+// topLanguagePairs is how many language pairs channel stats list
+const topLanguagePairs = 3
+
+var _ AnalyticsService = (*AnalyticsUseCase)(nil)
+
+// AnalyticsUseCase counts the translations served in each channel, with
+// their cache hits and AI tokens, per day and language pair
+type AnalyticsUseCase struct {
+	repo   AnalyticsRepository
+	logger *zap.Logger
+	// Token prices in USD per million tokens; zero leaves the cost out
+	promptTokenPrice float64
+	outputTokenPrice float64
+}
+
+func NewAnalyticsUseCase(repo AnalyticsRepository, logger *zap.Logger) *AnalyticsUseCase {
+	return &AnalyticsUseCase{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// SetTokenPrices estimates the cost of the AI tokens used, from prices in USD
+// per million prompt and output tokens
+func (au *AnalyticsUseCase) SetTokenPrices(promptPerMillion, outputPerMillion float64) {
+	au.promptTokenPrice = promptPerMillion
+	au.outputTokenPrice = outputPerMillion
+}
+
+// RecordTranslation counts a translation served in a channel
+func (au *AnalyticsUseCase) RecordTranslation(channelID string, result response.Translation) error {
+	now := time.Now().UTC()
+	usage := &model.ChannelUsage{
+		ChannelID:      channelID,
+		Day:            now.Truncate(24 * time.Hour),
+		SourceLanguage: result.SourceLanguage,
+		TargetLanguage: result.TargetLanguage,
+		Translations:   1,
+		PromptTokens:   result.PromptTokens,
+		OutputTokens:   result.OutputTokens,
+		UpdatedAt:      now,
+	}
+	if result.Cached {
+		usage.CacheHits = 1
+	}
+	if err := au.repo.Record(usage); err != nil {
+		return fmt.Errorf("failed to record translation usage: %w", err)
+	}
+	return nil
+}
+
+// ChannelStats sums up a channel's usage from the day of since (UTC)
+func (au *AnalyticsUseCase) ChannelStats(channelID string, since time.Time) (*model.ChannelStats, error) {
+	since = since.UTC().Truncate(24 * time.Hour)
+	pairs, err := au.repo.SumByLanguagePair(channelID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel stats: %w", err)
+	}
+
+	stats := &model.ChannelStats{ChannelID: channelID, Since: since}
+	for _, pair := range pairs {
+		stats.Translations += pair.Translations
+		stats.CacheHits += pair.CacheHits
+		stats.PromptTokens += pair.PromptTokens
+		stats.OutputTokens += pair.OutputTokens
+		stats.TopPairs = append(stats.TopPairs, model.LanguagePairUsage{
+			SourceLanguage: pair.SourceLanguage,
+			TargetLanguage: pair.TargetLanguage,
+			Translations:   pair.Translations,
+		})
+	}
+	if stats.Translations > 0 {
+		stats.CacheHitRate = float64(stats.CacheHits) / float64(stats.Translations)
+	}
+	stats.Cost = (float64(stats.PromptTokens)*au.promptTokenPrice + float64(stats.OutputTokens)*au.outputTokenPrice) / 1e6
+
+	sort.SliceStable(stats.TopPairs, func(i, j int) bool {
+		return stats.TopPairs[i].Translations > stats.TopPairs[j].Translations
+	})
+	if len(stats.TopPairs) > topLanguagePairs {
+		stats.TopPairs = stats.TopPairs[:topLanguagePairs]
+	}
+	return stats, nil
+}
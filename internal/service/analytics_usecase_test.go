@@ -0,0 +1,98 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeAnalyticsRepository struct {
+	recorded []*model.ChannelUsage
+	pairs    []model.ChannelUsage
+	since    time.Time
+	err      error
+}
+
+func (f *fakeAnalyticsRepository) Record(usage *model.ChannelUsage) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.recorded = append(f.recorded, usage)
+	return nil
+}
+
+func (f *fakeAnalyticsRepository) SumByLanguagePair(_ string, since time.Time) ([]model.ChannelUsage, error) {
+	f.since = since
+	return f.pairs, f.err
+}
+
+func TestAnalyticsUseCase_RecordTranslation(t *testing.T) {
+	repo := &fakeAnalyticsRepository{}
+	useCase := NewAnalyticsUseCase(repo, zap.NewNop())
+
+	require.NoError(t, useCase.RecordTranslation("C1", response.Translation{
+		SourceLanguage: "English",
+		TargetLanguage: "Vietnamese",
+		PromptTokens:   120,
+		OutputTokens:   80,
+	}))
+	require.NoError(t, useCase.RecordTranslation("C1", response.Translation{
+		SourceLanguage: "English",
+		TargetLanguage: "Vietnamese",
+		Cached:         true,
+	}))
+
+	require.Len(t, repo.recorded, 2)
+	assert.Equal(t, int64(0), repo.recorded[0].CacheHits)
+	assert.Equal(t, int64(120), repo.recorded[0].PromptTokens)
+	assert.Equal(t, int64(1), repo.recorded[1].CacheHits)
+	assert.Equal(t, time.Now().UTC().Truncate(24*time.Hour), repo.recorded[1].Day)
+
+	repo.err = errors.New("connection refused")
+	assert.Error(t, useCase.RecordTranslation("C1", response.Translation{}))
+}
+
+func TestAnalyticsUseCase_ChannelStats(t *testing.T) {
+	repo := &fakeAnalyticsRepository{pairs: []model.ChannelUsage{
+		{SourceLanguage: "Japanese", TargetLanguage: "English", Translations: 2, PromptTokens: 1000, OutputTokens: 500},
+		{SourceLanguage: "English", TargetLanguage: "Vietnamese", Translations: 30, CacheHits: 10, PromptTokens: 500000, OutputTokens: 250000},
+		{SourceLanguage: "Vietnamese", TargetLanguage: "English", Translations: 12, CacheHits: 1, PromptTokens: 200000, OutputTokens: 100000},
+		{SourceLanguage: "French", TargetLanguage: "English", Translations: 6, CacheHits: 4},
+	}}
+	useCase := NewAnalyticsUseCase(repo, zap.NewNop())
+	useCase.SetTokenPrices(0.5, 2)
+
+	since := time.Date(2026, 10, 10, 15, 30, 0, 0, time.UTC)
+	stats, err := useCase.ChannelStats("C1", since)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC), repo.since)
+	assert.Equal(t, int64(50), stats.Translations)
+	assert.Equal(t, int64(15), stats.CacheHits)
+	assert.InDelta(t, 0.3, stats.CacheHitRate, 1e-9)
+	assert.InDelta(t, (701000*0.5+350500*2)/1e6, stats.Cost, 1e-9)
+	assert.Equal(t, []model.LanguagePairUsage{
+		{SourceLanguage: "English", TargetLanguage: "Vietnamese", Translations: 30},
+		{SourceLanguage: "Vietnamese", TargetLanguage: "English", Translations: 12},
+		{SourceLanguage: "French", TargetLanguage: "English", Translations: 6},
+	}, stats.TopPairs)
+}
+
+func TestAnalyticsUseCase_ChannelStatsWithoutPrices(t *testing.T) {
+	repo := &fakeAnalyticsRepository{pairs: []model.ChannelUsage{
+		{SourceLanguage: "English", TargetLanguage: "Vietnamese", Translations: 3, PromptTokens: 300},
+	}}
+	useCase := NewAnalyticsUseCase(repo, zap.NewNop())
+
+	stats, err := useCase.ChannelStats("C1", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), stats.PromptTokens)
+	assert.Zero(t, stats.Cost)
+	assert.Zero(t, stats.CacheHitRate)
+}
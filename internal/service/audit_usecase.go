@@ -0,0 +1,116 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+// AuditRepository defines the interface for audit record persistence.
+// This interface is owned by the AuditUseCase and defined where it's consumed.
+type AuditRepository interface {
+	Save(record *model.AuditRecord) error
+	Find(query model.AuditQuery) ([]*model.AuditRecord, error)
+}
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 500
+)
+
+var _ AuditService = (*AuditUseCase)(nil)
+
+type AuditUseCase struct {
+	repo AuditRepository
+}
+
+func NewAuditUseCase(repo AuditRepository) *AuditUseCase {
+	return &AuditUseCase{repo: repo}
+}
+
+// Record snapshots the before/after states and stores an audit record
+func (au *AuditUseCase) Record(entry model.AuditEntry) error {
+	before, err := snapshot(entry.Before)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit before state: %w", err)
+	}
+	after, err := snapshot(entry.After)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit after state: %w", err)
+	}
+
+	record := &model.AuditRecord{
+		ID:            generateID(),
+		Actor:         entry.Actor,
+		Action:        entry.Action,
+		ResourceType:  entry.ResourceType,
+		ResourceID:    entry.ResourceID,
+		Before:        before,
+		After:         after,
+		ChangedFields: changedFields(before, after),
+		CreatedAt:     time.Now(),
+	}
+
+	if err := au.repo.Save(record); err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return nil
+}
+
+func (au *AuditUseCase) List(query model.AuditQuery) ([]*model.AuditRecord, error) {
+	if query.Limit <= 0 {
+		query.Limit = defaultAuditLimit
+	}
+	if query.Limit > maxAuditLimit {
+		query.Limit = maxAuditLimit
+	}
+
+	records, err := au.repo.Find(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit records: %w", err)
+	}
+	return records, nil
+}
+
+// snapshot encodes a resource state as JSON; nil encodes as an empty string
+func snapshot(state interface{}) (string, error) {
+	if state == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+	if string(data) == "null" {
+		return "", nil
+	}
+	return string(data), nil
+}
+
+// changedFields lists the top-level JSON fields that differ between two snapshots,
+// ignoring bookkeeping timestamps
+func changedFields(before, after string) model.StringList {
+	var b, a map[string]json.RawMessage
+	_ = json.Unmarshal([]byte(before), &b)
+	_ = json.Unmarshal([]byte(after), &a)
+
+	seen := make(map[string]bool)
+	changed := model.StringList{}
+	for _, fields := range []map[string]json.RawMessage{b, a} {
+		for field := range fields {
+			if seen[field] || field == "created_at" || field == "updated_at" {
+				continue
+			}
+			seen[field] = true
+			if string(b[field]) != string(a[field]) {
+				changed = append(changed, field)
+			}
+		}
+	}
+
+	sort.Strings(changed)
+	return changed
+}
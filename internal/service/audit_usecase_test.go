@@ -0,0 +1,111 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditUseCase_Record(t *testing.T) {
+	tests := []struct {
+		name          string
+		entry         model.AuditEntry
+		expectBefore  string
+		expectChanged model.StringList
+		repoErr       error
+		expectErr     bool
+	}{
+		{
+			name: "create has no before state",
+			entry: model.AuditEntry{
+				Action: model.AuditActionCreate,
+				After:  map[string]interface{}{"channel_id": "C1", "enabled": true},
+			},
+			expectBefore:  "",
+			expectChanged: model.StringList{"channel_id", "enabled"},
+		},
+		{
+			name: "update lists changed fields only",
+			entry: model.AuditEntry{
+				Action: model.AuditActionUpdate,
+				Before: map[string]interface{}{"channel_id": "C1", "enabled": true, "updated_at": "t1"},
+				After:  map[string]interface{}{"channel_id": "C1", "enabled": false, "updated_at": "t2"},
+			},
+			expectBefore:  `{"channel_id":"C1","enabled":true,"updated_at":"t1"}`,
+			expectChanged: model.StringList{"enabled"},
+		},
+		{
+			name: "nil pointer before state is omitted",
+			entry: model.AuditEntry{
+				Action: model.AuditActionUpdate,
+				Before: (*model.ChannelConfig)(nil),
+				After:  map[string]interface{}{"channel_id": "C1"},
+			},
+			expectBefore:  "",
+			expectChanged: model.StringList{"channel_id"},
+		},
+		{
+			name:      "repository error",
+			entry:     model.AuditEntry{Action: model.AuditActionDelete},
+			repoErr:   errors.New("db down"),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockAuditRepository(ctrl)
+			var saved *model.AuditRecord
+			repo.EXPECT().Save(gomock.Any()).DoAndReturn(func(record *model.AuditRecord) error {
+				saved = record
+				return tt.repoErr
+			})
+
+			err := NewAuditUseCase(repo).Record(tt.entry)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, saved.ID)
+			assert.Equal(t, tt.expectBefore, saved.Before)
+			assert.Equal(t, tt.expectChanged, saved.ChangedFields)
+		})
+	}
+}
+
+func TestAuditUseCase_ListClampsLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		limit       int
+		expectLimit int
+	}{
+		{name: "default", limit: 0, expectLimit: defaultAuditLimit},
+		{name: "within range", limit: 10, expectLimit: 10},
+		{name: "above maximum", limit: 10000, expectLimit: maxAuditLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockAuditRepository(ctrl)
+			repo.EXPECT().Find(gomock.Any()).DoAndReturn(func(query model.AuditQuery) ([]*model.AuditRecord, error) {
+				assert.Equal(t, tt.expectLimit, query.Limit)
+				return nil, nil
+			})
+
+			_, err := NewAuditUseCase(repo).List(model.AuditQuery{Limit: tt.limit})
+			assert.NoError(t, err)
+		})
+	}
+}
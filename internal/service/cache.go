@@ -1,9 +1,12 @@
 package service
 
-// Cache defines the interface for cache operations
+import "context"
+
+// Cache defines the interface for cache operations. Calls give up when ctx
+// is done.
 type Cache interface {
-	Get(key string) (string, error)
-	Set(key string, value string, ttl int64) error
-	Delete(key string) error
-	Exists(key string) (bool, error)
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key string, value string, ttl int64) error
+	Delete(ctx context.Context, key string) error
+	Exists(ctx context.Context, key string) (bool, error)
 }
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+// ChannelSetupPublisher shows the setup message of a channel, posting it
+// when messageTS is empty and updating it otherwise, e.g.
+// slack.ChannelSetupMessenger. chosenBy is the user who picked the language
+// of config, if any. It returns the message's timestamp.
+type ChannelSetupPublisher interface {
+	PublishChannelSetup(ctx context.Context, config *model.ChannelConfig, messageTS, chosenBy string) (string, error)
+}
+
+// ChannelConfigStore reads and writes channel configurations, e.g. ChannelUseCase
+type ChannelConfigStore interface {
+	GetChannelConfig(channelID string) (*model.ChannelConfig, error)
+	CreateChannelConfig(config *model.ChannelConfig) error
+	UpdateChannelConfig(config *model.ChannelConfig) error
+}
+
+var _ ChannelOnboardingService = (*ChannelOnboardingUseCase)(nil)
+
+// ChannelOnboardingUseCase sets up the channels the bot is invited to, so
+// they translate without an admin writing their configuration first
+type ChannelOnboardingUseCase struct {
+	channels        ChannelConfigStore
+	setup           ChannelSetupPublisher
+	defaultLanguage string
+	logger          *zap.Logger
+}
+
+// NewChannelOnboardingUseCase configures new channels to translate to the
+// language code defaultLanguage until someone picks another
+func NewChannelOnboardingUseCase(channels ChannelConfigStore, setup ChannelSetupPublisher, defaultLanguage string, logger *zap.Logger) (*ChannelOnboardingUseCase, error) {
+	if !model.IsSupportedLanguageCode(defaultLanguage) {
+		return nil, fmt.Errorf("unsupported default channel language: %s", defaultLanguage)
+	}
+	return &ChannelOnboardingUseCase{
+		channels:        channels,
+		setup:           setup,
+		defaultLanguage: defaultLanguage,
+		logger:          logger,
+	}, nil
+}
+
+// BotJoined sets up a channel the bot was invited to: a channel without a
+// configuration gets one translating to the default language, a disabled
+// one is enabled again, and a setup message asks which language to use
+func (cu *ChannelOnboardingUseCase) BotJoined(ctx context.Context, channelID, inviterID string) error {
+	config, err := cu.channels.GetChannelConfig(channelID)
+	switch {
+	case model.IsNotFound(err):
+		config = &model.ChannelConfig{
+			ChannelID:      channelID,
+			TargetLanguage: cu.defaultLanguage,
+			Enabled:        true,
+			AutoTranslate:  true,
+		}
+		if err := cu.channels.CreateChannelConfig(config); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	case !config.Enabled:
+		config.Enabled = true
+		if err := cu.channels.UpdateChannelConfig(config); err != nil {
+			return err
+		}
+	}
+
+	if _, err := cu.setup.PublishChannelSetup(ctx, config, "", ""); err != nil {
+		return fmt.Errorf("failed to post channel setup: %w", err)
+	}
+
+	cu.logger.Info("Channel set up on invite",
+		zap.String("channel_id", channelID),
+		zap.String("inviter_id", inviterID),
+		zap.String("target_language", config.TargetLanguage))
+	return nil
+}
+
+// ChooseLanguage sets the language a channel translates to from the
+// buttons of its setup message
+func (cu *ChannelOnboardingUseCase) ChooseLanguage(ctx context.Context, channelID, messageTS, userID, languageCode string) error {
+	if !model.IsSupportedLanguageCode(languageCode) {
+		return model.NewValidationError(fmt.Sprintf("unsupported language: %s", languageCode))
+	}
+
+	config, err := cu.channels.GetChannelConfig(channelID)
+	if model.IsNotFound(err) {
+		return model.NewNotFoundError("This channel is no longer configured. Invite me again to set it up")
+	}
+	if err != nil {
+		return err
+	}
+	config.TargetLanguage = languageCode
+	config.Enabled = true
+	if err := cu.channels.UpdateChannelConfig(config); err != nil {
+		return err
+	}
+
+	if _, err := cu.setup.PublishChannelSetup(ctx, config, messageTS, userID); err != nil {
+		cu.logger.Warn("Failed to update channel setup message",
+			zap.Error(err),
+			zap.String("channel_id", channelID))
+	}
+
+	cu.logger.Info("Channel language chosen",
+		zap.String("channel_id", channelID),
+		zap.String("user_id", userID),
+		zap.String("target_language", languageCode))
+	return nil
+}
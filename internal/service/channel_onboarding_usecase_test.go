@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeChannelConfigStore map[string]*model.ChannelConfig
+
+func (f fakeChannelConfigStore) GetChannelConfig(channelID string) (*model.ChannelConfig, error) {
+	config, ok := f[channelID]
+	if !ok {
+		return nil, model.NewNotFoundError("channel config not found")
+	}
+	copied := *config
+	return &copied, nil
+}
+
+func (f fakeChannelConfigStore) CreateChannelConfig(config *model.ChannelConfig) error {
+	f[config.ChannelID] = config
+	return nil
+}
+
+func (f fakeChannelConfigStore) UpdateChannelConfig(config *model.ChannelConfig) error {
+	f[config.ChannelID] = config
+	return nil
+}
+
+type fakeChannelSetupPublisher struct {
+	published []string
+}
+
+func (f *fakeChannelSetupPublisher) PublishChannelSetup(_ context.Context, config *model.ChannelConfig, messageTS, chosenBy string) (string, error) {
+	f.published = append(f.published, config.ChannelID+":"+config.TargetLanguage+":"+messageTS+":"+chosenBy)
+	return "1.1", nil
+}
+
+func TestChannelOnboardingUseCase(t *testing.T) {
+	ctx := context.Background()
+	channels := fakeChannelConfigStore{
+		"C2": {ChannelID: "C2", TargetLanguage: "vi", SourceLanguages: model.LanguageList{"en"}},
+	}
+	setup := &fakeChannelSetupPublisher{}
+	useCase, err := NewChannelOnboardingUseCase(channels, setup, "en", zap.NewNop())
+	require.NoError(t, err)
+
+	require.NoError(t, useCase.BotJoined(ctx, "C1", "U1"))
+	assert.Equal(t, &model.ChannelConfig{ChannelID: "C1", TargetLanguage: "en", Enabled: true, AutoTranslate: true}, channels["C1"])
+
+	require.NoError(t, useCase.BotJoined(ctx, "C2", "U1"))
+	assert.True(t, channels["C2"].Enabled, "disabled channels are enabled again")
+	assert.Equal(t, "vi", channels["C2"].TargetLanguage, "existing configurations are kept")
+	assert.Equal(t, model.LanguageList{"en"}, channels["C2"].SourceLanguages)
+
+	require.NoError(t, useCase.ChooseLanguage(ctx, "C1", "1.1", "U2", "ja"))
+	assert.Equal(t, "ja", channels["C1"].TargetLanguage)
+	assert.True(t, isValidationError(useCase.ChooseLanguage(ctx, "C1", "1.1", "U2", "klingon")))
+	assert.True(t, model.IsNotFound(useCase.ChooseLanguage(ctx, "C3", "1.1", "U2", "ja")))
+
+	assert.Equal(t, []string{"C1:en::", "C2:vi::", "C1:ja:1.1:U2"}, setup.published)
+
+	_, err = NewChannelOnboardingUseCase(channels, setup, "klingon", zap.NewNop())
+	assert.Error(t, err)
+}
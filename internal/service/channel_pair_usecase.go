@@ -0,0 +1,129 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+// ChannelPairRepository defines the interface for channel pair persistence.
+// This interface is owned by the ChannelPairUseCase and defined where it's consumed.
+type ChannelPairRepository interface {
+	Save(pair *model.ChannelPair) error
+	GetByID(id string) (*model.ChannelPair, error)
+	GetByChannelID(channelID string) (*model.ChannelPair, error)
+	GetAll() ([]*model.ChannelPair, error)
+	Delete(id string) error
+}
+
+// channelPairCacheTTL bounds how long another instance keeps routing with a stale pair list
+const channelPairCacheTTL = 30 * time.Second
+
+var _ ChannelPairService = (*ChannelPairUseCase)(nil)
+
+// ChannelPairUseCase manages channel pairs. A channel belongs to at most one
+// pair, so a cross-posted translation can never be relayed any further.
+type ChannelPairUseCase struct {
+	repo   ChannelPairRepository
+	logger *zap.Logger
+
+	mu        sync.Mutex
+	paired    map[string]string
+	expiresAt time.Time
+}
+
+func NewChannelPairUseCase(repo ChannelPairRepository, logger *zap.Logger) *ChannelPairUseCase {
+	return &ChannelPairUseCase{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+func (pu *ChannelPairUseCase) CreatePair(pair *model.ChannelPair) error {
+	if err := pair.Validate(); err != nil {
+		return fmt.Errorf("invalid channel pair: %w", err)
+	}
+
+	for _, channelID := range []string{pair.ChannelID, pair.PairedChannelID} {
+		existing, err := pu.repo.GetByChannelID(channelID)
+		if err != nil {
+			return fmt.Errorf("failed to check channel pair: %w", err)
+		}
+		if existing != nil {
+			return model.NewBadRequestError(fmt.Sprintf("channel %s is already paired with %s", channelID, existing.Other(channelID)))
+		}
+	}
+
+	if pair.ID == "" {
+		pair.ID = generateID()
+	}
+	pair.CreatedAt = time.Now()
+
+	if err := pu.repo.Save(pair); err != nil {
+		return fmt.Errorf("failed to create channel pair: %w", err)
+	}
+
+	pu.invalidate()
+	return nil
+}
+
+func (pu *ChannelPairUseCase) GetPair(id string) (*model.ChannelPair, error) {
+	pair, err := pu.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel pair: %w", err)
+	}
+	return pair, nil
+}
+
+func (pu *ChannelPairUseCase) DeletePair(id string) error {
+	if err := pu.repo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete channel pair: %w", err)
+	}
+
+	pu.invalidate()
+	return nil
+}
+
+func (pu *ChannelPairUseCase) ListPairs() ([]*model.ChannelPair, error) {
+	pairs, err := pu.repo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list channel pairs: %w", err)
+	}
+	return pairs, nil
+}
+
+// PairedChannel returns the channel translations of channelID are cross-posted
+// to. Lookup errors fall back to replying in a thread.
+func (pu *ChannelPairUseCase) PairedChannel(channelID string) (string, bool) {
+	pu.mu.Lock()
+	defer pu.mu.Unlock()
+
+	if pu.paired == nil || time.Now().After(pu.expiresAt) {
+		pairs, err := pu.repo.GetAll()
+		if err != nil {
+			pu.logger.Warn("Failed to load channel pairs, replying in thread",
+				zap.Error(err),
+				zap.String("channel_id", channelID))
+			return "", false
+		}
+
+		pu.paired = make(map[string]string, 2*len(pairs))
+		for _, pair := range pairs {
+			pu.paired[pair.ChannelID] = pair.PairedChannelID
+			pu.paired[pair.PairedChannelID] = pair.ChannelID
+		}
+		pu.expiresAt = time.Now().Add(channelPairCacheTTL)
+	}
+
+	paired, ok := pu.paired[channelID]
+	return paired, ok
+}
+
+func (pu *ChannelPairUseCase) invalidate() {
+	pu.mu.Lock()
+	pu.paired = nil
+	pu.mu.Unlock()
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestChannelPairUseCase_CreatePair(t *testing.T) {
+	tests := []struct {
+		name        string
+		pair        *model.ChannelPair
+		mockSetup   func(*mocks.MockChannelPairRepository)
+		expectError bool
+	}{
+		{
+			name: "successful create",
+			pair: &model.ChannelPair{ChannelID: "CEN", PairedChannelID: "CVI"},
+			mockSetup: func(repo *mocks.MockChannelPairRepository) {
+				repo.EXPECT().GetByChannelID("CEN").Return(nil, nil)
+				repo.EXPECT().GetByChannelID("CVI").Return(nil, nil)
+				repo.EXPECT().Save(gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:        "channel paired with itself",
+			pair:        &model.ChannelPair{ChannelID: "CEN", PairedChannelID: "CEN"},
+			mockSetup:   func(*mocks.MockChannelPairRepository) {},
+			expectError: true,
+		},
+		{
+			name: "channel already paired",
+			pair: &model.ChannelPair{ChannelID: "CEN", PairedChannelID: "CJA"},
+			mockSetup: func(repo *mocks.MockChannelPairRepository) {
+				repo.EXPECT().GetByChannelID("CEN").Return(&model.ChannelPair{ID: "p1", ChannelID: "CEN", PairedChannelID: "CVI"}, nil)
+			},
+			expectError: true,
+		},
+		{
+			name: "paired channel already paired",
+			pair: &model.ChannelPair{ChannelID: "CJA", PairedChannelID: "CVI"},
+			mockSetup: func(repo *mocks.MockChannelPairRepository) {
+				repo.EXPECT().GetByChannelID("CJA").Return(nil, nil)
+				repo.EXPECT().GetByChannelID("CVI").Return(&model.ChannelPair{ID: "p1", ChannelID: "CEN", PairedChannelID: "CVI"}, nil)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockChannelPairRepository(ctrl)
+			tt.mockSetup(repo)
+
+			err := NewChannelPairUseCase(repo, zap.NewNop()).CreatePair(tt.pair)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, tt.pair.ID)
+			}
+		})
+	}
+}
+
+func TestChannelPairUseCase_PairedChannel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockChannelPairRepository(ctrl)
+	// Loaded once and cached for both directions
+	repo.EXPECT().GetAll().Return([]*model.ChannelPair{{ID: "p1", ChannelID: "CEN", PairedChannelID: "CVI"}}, nil).Times(1)
+
+	uc := NewChannelPairUseCase(repo, zap.NewNop())
+
+	paired, ok := uc.PairedChannel("CEN")
+	assert.True(t, ok)
+	assert.Equal(t, "CVI", paired)
+
+	paired, ok = uc.PairedChannel("CVI")
+	assert.True(t, ok)
+	assert.Equal(t, "CEN", paired)
+
+	_, ok = uc.PairedChannel("COTHER")
+	assert.False(t, ok)
+}
+
+func TestChannelPairUseCase_PairedChannelLookupError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockChannelPairRepository(ctrl)
+	repo.EXPECT().GetAll().Return(nil, errors.New("database down"))
+
+	_, ok := NewChannelPairUseCase(repo, zap.NewNop()).PairedChannel("CEN")
+	assert.False(t, ok)
+}
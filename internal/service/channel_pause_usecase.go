@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+// maxChannelPause bounds how long a channel can be paused at once
+const maxChannelPause = 30 * 24 * time.Hour
+
+var _ ChannelPauseService = (*ChannelPauseUseCase)(nil)
+
+// ChannelPauseUseCase stops and restarts translation in a channel without
+// touching its configuration. Pauses live in the cache (Redis), so every
+// instance sees them and timed pauses expire on their own.
+type ChannelPauseUseCase struct {
+	cache  Cache
+	logger *zap.Logger
+	now    func() time.Time
+}
+
+func NewChannelPauseUseCase(cache Cache, logger *zap.Logger) *ChannelPauseUseCase {
+	return &ChannelPauseUseCase{
+		cache:  cache,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Pause stops translation in a channel for duration, or until it is resumed
+// when duration is zero
+func (pu *ChannelPauseUseCase) Pause(channelID, pausedBy string, duration time.Duration) (*model.ChannelPause, error) {
+	if channelID == "" {
+		return nil, model.NewValidationError("channel_id is required")
+	}
+	if duration < 0 || duration > maxChannelPause {
+		return nil, model.NewValidationError(fmt.Sprintf("pause duration must be between 0 and %s", maxChannelPause))
+	}
+
+	pause := &model.ChannelPause{
+		ChannelID: channelID,
+		PausedBy:  pausedBy,
+		PausedAt:  pu.now().UTC(),
+	}
+	if duration > 0 {
+		until := pause.PausedAt.Add(duration)
+		pause.Until = &until
+	}
+
+	encoded, err := json.Marshal(pause)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode channel pause: %w", err)
+	}
+	// A TTL of 0 keeps the pause until the channel is resumed
+	ttl := int64(duration.Round(time.Second) / time.Second)
+	if err := pu.cache.Set(context.Background(), channelPauseKey(channelID), string(encoded), ttl); err != nil {
+		return nil, fmt.Errorf("failed to pause channel: %w", err)
+	}
+
+	pu.logger.Info("Channel translation paused",
+		zap.String("channel_id", channelID),
+		zap.String("paused_by", pausedBy),
+		zap.Duration("duration", duration))
+	return pause, nil
+}
+
+// Resume restarts translation in a channel
+func (pu *ChannelPauseUseCase) Resume(channelID string) error {
+	if err := pu.cache.Delete(context.Background(), channelPauseKey(channelID)); err != nil {
+		return fmt.Errorf("failed to resume channel: %w", err)
+	}
+
+	pu.logger.Info("Channel translation resumed", zap.String("channel_id", channelID))
+	return nil
+}
+
+// GetPause returns the pause of a channel, or nil when it is not paused
+func (pu *ChannelPauseUseCase) GetPause(channelID string) (*model.ChannelPause, error) {
+	key := channelPauseKey(channelID)
+	exists, err := pu.cache.Exists(context.Background(), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel pause: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	cached, err := pu.cache.Get(context.Background(), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel pause: %w", err)
+	}
+	pause := &model.ChannelPause{}
+	if err := json.Unmarshal([]byte(cached), pause); err != nil {
+		return nil, fmt.Errorf("failed to decode channel pause: %w", err)
+	}
+	return pause, nil
+}
+
+// IsPaused reports whether translation is paused in a channel. When the
+// cache cannot be reached the channel is treated as not paused.
+func (pu *ChannelPauseUseCase) IsPaused(channelID string) bool {
+	paused, err := pu.cache.Exists(context.Background(), channelPauseKey(channelID))
+	if err != nil {
+		pu.logger.Warn("Failed to check channel pause, translating",
+			zap.Error(err),
+			zap.String("channel_id", channelID))
+		return false
+	}
+	return paused
+}
+
+func channelPauseKey(channelID string) string {
+	return fmt.Sprintf("channel_paused:%s", channelID)
+}
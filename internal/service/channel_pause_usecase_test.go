@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestChannelPauseUseCase_Pause(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		duration    time.Duration
+		expectedTTL int64
+		expectUntil bool
+	}{
+		{name: "until resumed", duration: 0, expectedTTL: 0},
+		{name: "timed", duration: 90 * time.Minute, expectedTTL: 5400, expectUntil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCache := mocks.NewMockCache(ctrl)
+			useCase := NewChannelPauseUseCase(mockCache, zap.NewNop())
+			useCase.now = func() time.Time { return now }
+
+			var stored string
+			mockCache.EXPECT().Set(gomock.Any(), "channel_paused:C123", gomock.Any(), tt.expectedTTL).
+				DoAndReturn(func(_ context.Context, key, value string, ttl int64) error {
+					stored = value
+					return nil
+				})
+
+			pause, err := useCase.Pause("C123", "U1", tt.duration)
+			require.NoError(t, err)
+			assert.Equal(t, "U1", pause.PausedBy)
+			assert.Equal(t, tt.expectUntil, pause.Until != nil)
+
+			var decoded model.ChannelPause
+			require.NoError(t, json.Unmarshal([]byte(stored), &decoded))
+			assert.Equal(t, "C123", decoded.ChannelID)
+			assert.True(t, now.Equal(decoded.PausedAt))
+		})
+	}
+}
+
+func TestChannelPauseUseCase_PauseRejectsInvalidDuration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase := NewChannelPauseUseCase(mocks.NewMockCache(ctrl), zap.NewNop())
+
+	_, err := useCase.Pause("C123", "U1", -time.Minute)
+	assert.Error(t, err)
+	_, err = useCase.Pause("C123", "U1", 31*24*time.Hour)
+	assert.Error(t, err)
+}
+
+func TestChannelPauseUseCase_IsPaused(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCache := mocks.NewMockCache(ctrl)
+	useCase := NewChannelPauseUseCase(mockCache, zap.NewNop())
+
+	mockCache.EXPECT().Exists(gomock.Any(), "channel_paused:C123").Return(true, nil)
+	mockCache.EXPECT().Exists(gomock.Any(), "channel_paused:C456").Return(false, nil)
+	mockCache.EXPECT().Exists(gomock.Any(), "channel_paused:C789").Return(false, assert.AnError)
+
+	assert.True(t, useCase.IsPaused("C123"))
+	assert.False(t, useCase.IsPaused("C456"))
+	// Fails open: a Redis outage must not stop every channel
+	assert.False(t, useCase.IsPaused("C789"))
+}
+
+func TestChannelPauseUseCase_GetPauseAndResume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCache := mocks.NewMockCache(ctrl)
+	useCase := NewChannelPauseUseCase(mockCache, zap.NewNop())
+
+	mockCache.EXPECT().Exists(gomock.Any(), "channel_paused:C123").Return(true, nil)
+	mockCache.EXPECT().Get(gomock.Any(), "channel_paused:C123").Return(`{"channel_id":"C123","paused_by":"admin:ab12","paused_at":"2024-05-01T09:00:00Z"}`, nil)
+
+	pause, err := useCase.GetPause("C123")
+	require.NoError(t, err)
+	assert.Equal(t, "admin:ab12", pause.PausedBy)
+	assert.Nil(t, pause.Until)
+
+	mockCache.EXPECT().Delete(gomock.Any(), "channel_paused:C123").Return(nil)
+	require.NoError(t, useCase.Resume("C123"))
+
+	mockCache.EXPECT().Exists(gomock.Any(), "channel_paused:C123").Return(false, nil)
+	pause, err = useCase.GetPause("C123")
+	require.NoError(t, err)
+	assert.Nil(t, pause)
+}
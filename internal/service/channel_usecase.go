@@ -1,7 +1,12 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"path"
+	"time"
 
 	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
 )
@@ -16,11 +21,25 @@ type ChannelRepository interface {
 	GetAll() ([]*model.ChannelConfig, error)
 }
 
+// ChannelDirectory lists the Slack channels visible to the bot.
+// It is used to resolve channel name patterns in bulk operations.
+type ChannelDirectory interface {
+	ListChannels() ([]model.SlackChannel, error)
+}
+
 var _ ChannelService = (*ChannelUseCase)(nil)
 
+const (
+	// channelConfigCacheTTL is how long channel configurations are cached, in seconds
+	channelConfigCacheTTL = 3600
+	// channelConfigNotFound is cached for channels without a configuration
+	channelConfigNotFound = "-"
+)
+
 type ChannelUseCase struct {
-	repo  ChannelRepository
-	cache Cache
+	repo      ChannelRepository
+	cache     Cache
+	directory ChannelDirectory
 }
 
 func NewChannelUseCase(repo ChannelRepository, cache Cache) *ChannelUseCase {
@@ -30,48 +49,83 @@ func NewChannelUseCase(repo ChannelRepository, cache Cache) *ChannelUseCase {
 	}
 }
 
+// SetChannelDirectory enables name-pattern selection in bulk operations
+func (cu *ChannelUseCase) SetChannelDirectory(directory ChannelDirectory) {
+	cu.directory = directory
+}
+
 func (cu *ChannelUseCase) CreateChannelConfig(config *model.ChannelConfig) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid channel config: %w", err)
+	}
+
+	now := time.Now()
+	if config.ID == "" {
+		config.ID = generateID()
+	}
+	if config.CreatedAt.IsZero() {
+		config.CreatedAt = now
+	}
+	config.UpdatedAt = now
+
 	if err := cu.repo.Save(config); err != nil {
 		return fmt.Errorf("failed to create channel config: %w", err)
 	}
 
 	// Invalidate cache
 	cacheKey := fmt.Sprintf("channel_config:%s", config.ChannelID)
-	_ = cu.cache.Delete(cacheKey)
+	_ = cu.cache.Delete(context.Background(), cacheKey)
 
 	return nil
 }
 
+// GetChannelConfig returns the configuration of a channel. Configurations,
+// and channels without one, are cached so the message pipeline can look them
+// up for every message without hitting the database.
 func (cu *ChannelUseCase) GetChannelConfig(channelID string) (*model.ChannelConfig, error) {
 	cacheKey := fmt.Sprintf("channel_config:%s", channelID)
 
 	// Try cache first
-	_, _ = cu.cache.Get(cacheKey)
+	if cached, err := cu.cache.Get(context.Background(), cacheKey); err == nil && cached != "" {
+		if cached == channelConfigNotFound {
+			return nil, fmt.Errorf("failed to get channel config: %w", model.NewNotFoundError("channel config not found"))
+		}
+		config := &model.ChannelConfig{}
+		if err := json.Unmarshal([]byte(cached), config); err == nil {
+			return config, nil
+		}
+	}
 
 	// Get from database
 	config, err := cu.repo.GetByChannelID(channelID)
 	if err != nil {
+		if isNotFound(err) {
+			_ = cu.cache.Set(context.Background(), cacheKey, channelConfigNotFound, channelConfigCacheTTL)
+		}
 		return nil, fmt.Errorf("failed to get channel config: %w", err)
 	}
 
-	// Cache the result (1 hour TTL)
-	cacheValue := "0"
-	if config.Enabled {
-		cacheValue = "1"
+	if encoded, err := json.Marshal(config); err == nil {
+		_ = cu.cache.Set(context.Background(), cacheKey, string(encoded), channelConfigCacheTTL)
 	}
-	_ = cu.cache.Set(cacheKey, cacheValue, 3600)
 
 	return config, nil
 }
 
 func (cu *ChannelUseCase) UpdateChannelConfig(config *model.ChannelConfig) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid channel config: %w", err)
+	}
+
+	config.UpdatedAt = time.Now()
+
 	if err := cu.repo.Update(config); err != nil {
 		return fmt.Errorf("failed to update channel config: %w", err)
 	}
 
 	// Invalidate cache
 	cacheKey := fmt.Sprintf("channel_config:%s", config.ChannelID)
-	_ = cu.cache.Delete(cacheKey)
+	_ = cu.cache.Delete(context.Background(), cacheKey)
 
 	return nil
 }
@@ -83,7 +137,7 @@ func (cu *ChannelUseCase) DeleteChannelConfig(channelID string) error {
 
 	// Invalidate cache
 	cacheKey := fmt.Sprintf("channel_config:%s", channelID)
-	_ = cu.cache.Delete(cacheKey)
+	_ = cu.cache.Delete(context.Background(), cacheKey)
 
 	return nil
 }
@@ -106,3 +160,113 @@ func (cu *ChannelUseCase) IsChannelEnabled(channelID string) (bool, error) {
 
 	return config.Enabled, nil
 }
+
+// ApplyChannelTemplate applies the template settings to every selected channel,
+// creating or updating each configuration independently. With dryRun set,
+// nothing is written and each result reports the planned action.
+func (cu *ChannelUseCase) ApplyChannelTemplate(template *model.ChannelConfig, selector model.ChannelSelector, dryRun bool) ([]model.BulkChannelResult, error) {
+	targets, err := cu.resolveChannels(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]model.BulkChannelResult, 0, len(targets))
+	for _, target := range targets {
+		results = append(results, cu.applyTemplate(template, target, dryRun))
+	}
+
+	return results, nil
+}
+
+func (cu *ChannelUseCase) resolveChannels(selector model.ChannelSelector) ([]model.SlackChannel, error) {
+	seen := make(map[string]bool)
+	targets := []model.SlackChannel{}
+
+	for _, channelID := range selector.ChannelIDs {
+		if channelID == "" || seen[channelID] {
+			continue
+		}
+		seen[channelID] = true
+		targets = append(targets, model.SlackChannel{ID: channelID})
+	}
+
+	if selector.NamePattern == "" {
+		return targets, nil
+	}
+
+	if _, err := path.Match(selector.NamePattern, ""); err != nil {
+		return nil, model.NewValidationError(fmt.Sprintf("invalid name pattern: %s", selector.NamePattern))
+	}
+
+	if cu.directory == nil {
+		return nil, model.NewBadRequestError("channel name patterns are not available without a Slack channel directory")
+	}
+
+	channels, err := cu.directory.ListChannels()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list channels: %w", err)
+	}
+
+	for _, channel := range channels {
+		if matched, _ := path.Match(selector.NamePattern, channel.Name); !matched || seen[channel.ID] {
+			continue
+		}
+		seen[channel.ID] = true
+		targets = append(targets, channel)
+	}
+
+	return targets, nil
+}
+
+func (cu *ChannelUseCase) applyTemplate(template *model.ChannelConfig, target model.SlackChannel, dryRun bool) model.BulkChannelResult {
+	result := model.BulkChannelResult{
+		ChannelID:   target.ID,
+		ChannelName: target.Name,
+		Action:      model.BulkActionCreate,
+	}
+
+	config := *template
+	config.ID = ""
+	config.ChannelID = target.ID
+	config.CreatedAt = time.Time{}
+
+	existing, err := cu.repo.GetByChannelID(target.ID)
+	if err != nil && !isNotFound(err) {
+		result.Status = model.BulkStatusFailed
+		result.Error = err.Error()
+		return result
+	}
+	if existing != nil {
+		result.Action = model.BulkActionUpdate
+	}
+
+	if err := config.Validate(); err != nil {
+		result.Status = model.BulkStatusFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	if dryRun {
+		result.Status = model.BulkStatusPlanned
+		return result
+	}
+
+	if result.Action == model.BulkActionUpdate {
+		err = cu.UpdateChannelConfig(&config)
+	} else {
+		err = cu.CreateChannelConfig(&config)
+	}
+	if err != nil {
+		result.Status = model.BulkStatusFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Status = model.BulkStatusApplied
+	return result
+}
+
+func isNotFound(err error) bool {
+	var domainErr *model.DomainError
+	return errors.As(err, &domainErr) && domainErr.Type == model.ErrorTypeNotFound
+}
@@ -21,14 +21,14 @@ func TestChannelUseCase(t *testing.T) {
 				config := &model.ChannelConfig{
 					ChannelID:       "C123",
 					AutoTranslate:   true,
-					SourceLanguages: `["en"]`,
+					SourceLanguages: model.LanguageList{"en"},
 					TargetLanguage:  "es",
 					Enabled:         true,
 					CreatedAt:       time.Now(),
 				}
 
 				mockRepo.EXPECT().Save(config).Return(nil)
-				mockCache.EXPECT().Delete(gomock.Any()).Return(nil)
+				mockCache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
 
 				err := useCase.CreateChannelConfig(config)
 
@@ -42,14 +42,14 @@ func TestChannelUseCase(t *testing.T) {
 					ID:              "1",
 					ChannelID:       "C123",
 					AutoTranslate:   true,
-					SourceLanguages: `["en"]`,
+					SourceLanguages: model.LanguageList{"en"},
 					TargetLanguage:  "es",
 					Enabled:         true,
 				}
 
-				mockCache.EXPECT().Get("channel_config:C123").Return("", assert.AnError)
+				mockCache.EXPECT().Get(gomock.Any(), "channel_config:C123").Return("", assert.AnError)
 				mockRepo.EXPECT().GetByChannelID("C123").Return(expectedConfig, nil)
-				mockCache.EXPECT().Set("channel_config:C123", gomock.Any(), int64(3600)).Return(nil)
+				mockCache.EXPECT().Set(gomock.Any(), "channel_config:C123", gomock.Any(), int64(3600)).Return(nil)
 
 				result, err := useCase.GetChannelConfig("C123")
 
@@ -57,11 +57,41 @@ func TestChannelUseCase(t *testing.T) {
 				assert.Equal(t, expectedConfig, result)
 			},
 		},
+		{
+			name: "get channel config from cache",
+			testFunc: func(t *testing.T, mockRepo *mocks.MockChannelRepository, mockCache *mocks.MockCache, useCase ChannelService) {
+				mockCache.EXPECT().Get(gomock.Any(), "channel_config:C123").Return(`{"channel_id":"C123","auto_translate":true,"source_languages":["en"],"target_language":"es","enabled":true}`, nil)
+
+				result, err := useCase.GetChannelConfig("C123")
+
+				assert.NoError(t, err)
+				assert.Equal(t, "C123", result.ChannelID)
+				assert.Equal(t, model.LanguageList{"en"}, result.SourceLanguages)
+				assert.Equal(t, "es", result.TargetLanguage)
+				assert.True(t, result.Enabled)
+			},
+		},
+		{
+			name: "get missing channel config is cached",
+			testFunc: func(t *testing.T, mockRepo *mocks.MockChannelRepository, mockCache *mocks.MockCache, useCase ChannelService) {
+				mockCache.EXPECT().Get(gomock.Any(), "channel_config:C789").Return("", assert.AnError)
+				mockRepo.EXPECT().GetByChannelID("C789").Return(nil, model.NewNotFoundError("channel config not found"))
+				mockCache.EXPECT().Set(gomock.Any(), "channel_config:C789", channelConfigNotFound, int64(3600)).Return(nil)
+
+				_, err := useCase.GetChannelConfig("C789")
+				assert.True(t, isNotFound(err))
+
+				mockCache.EXPECT().Get(gomock.Any(), "channel_config:C789").Return(channelConfigNotFound, nil)
+
+				_, err = useCase.GetChannelConfig("C789")
+				assert.True(t, isNotFound(err))
+			},
+		},
 		{
 			name: "delete channel config",
 			testFunc: func(t *testing.T, mockRepo *mocks.MockChannelRepository, mockCache *mocks.MockCache, useCase ChannelService) {
 				mockRepo.EXPECT().Delete("C123").Return(nil)
-				mockCache.EXPECT().Delete("channel_config:C123").Return(nil)
+				mockCache.EXPECT().Delete(gomock.Any(), "channel_config:C123").Return(nil)
 
 				err := useCase.DeleteChannelConfig("C123")
 
@@ -76,9 +106,9 @@ func TestChannelUseCase(t *testing.T) {
 					Enabled:   true,
 				}
 
-				mockCache.EXPECT().Get("channel_config:C123").Return("", assert.AnError)
+				mockCache.EXPECT().Get(gomock.Any(), "channel_config:C123").Return("", assert.AnError)
 				mockRepo.EXPECT().GetByChannelID("C123").Return(enabledConfig, nil)
-				mockCache.EXPECT().Set("channel_config:C123", "1", int64(3600)).Return(nil)
+				mockCache.EXPECT().Set(gomock.Any(), "channel_config:C123", gomock.Any(), int64(3600)).Return(nil)
 
 				enabled, err := useCase.IsChannelEnabled("C123")
 
@@ -94,9 +124,9 @@ func TestChannelUseCase(t *testing.T) {
 					Enabled:   false,
 				}
 
-				mockCache.EXPECT().Get("channel_config:C456").Return("", assert.AnError)
+				mockCache.EXPECT().Get(gomock.Any(), "channel_config:C456").Return("", assert.AnError)
 				mockRepo.EXPECT().GetByChannelID("C456").Return(disabledConfig, nil)
-				mockCache.EXPECT().Set("channel_config:C456", "0", int64(3600)).Return(nil)
+				mockCache.EXPECT().Set(gomock.Any(), "channel_config:C456", gomock.Any(), int64(3600)).Return(nil)
 
 				enabled, err := useCase.IsChannelEnabled("C456")
 
@@ -132,3 +162,79 @@ func TestChannelUseCaseImplementsInterface(t *testing.T) {
 	var _ ChannelService = useCase
 	assert.NotNil(t, useCase)
 }
+
+type fakeChannelDirectory struct {
+	channels []model.SlackChannel
+}
+
+func (f *fakeChannelDirectory) ListChannels() ([]model.SlackChannel, error) {
+	return f.channels, nil
+}
+
+func TestChannelUseCase_ApplyChannelTemplate(t *testing.T) {
+	template := &model.ChannelConfig{
+		SourceLanguages: model.LanguageList{"en"},
+		TargetLanguage:  "vi",
+		Enabled:         true,
+	}
+	directory := &fakeChannelDirectory{channels: []model.SlackChannel{
+		{ID: "C1", Name: "proj-alpha"},
+		{ID: "C2", Name: "proj-beta"},
+		{ID: "C3", Name: "random"},
+	}}
+	notFound := model.NewNotFoundError("channel config not found")
+
+	t.Run("dry run reports planned actions without writing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockChannelRepository(ctrl)
+		mockCache := mocks.NewMockCache(ctrl)
+		useCase := NewChannelUseCase(mockRepo, mockCache)
+		useCase.SetChannelDirectory(directory)
+
+		mockRepo.EXPECT().GetByChannelID("C1").Return(&model.ChannelConfig{ChannelID: "C1"}, nil)
+		mockRepo.EXPECT().GetByChannelID("C2").Return(nil, notFound)
+
+		results, err := useCase.ApplyChannelTemplate(template, model.ChannelSelector{NamePattern: "proj-*"}, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []model.BulkChannelResult{
+			{ChannelID: "C1", ChannelName: "proj-alpha", Action: model.BulkActionUpdate, Status: model.BulkStatusPlanned},
+			{ChannelID: "C2", ChannelName: "proj-beta", Action: model.BulkActionCreate, Status: model.BulkStatusPlanned},
+		}, results)
+	})
+
+	t.Run("apply reports per-channel failures", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockChannelRepository(ctrl)
+		mockCache := mocks.NewMockCache(ctrl)
+		useCase := NewChannelUseCase(mockRepo, mockCache)
+
+		mockRepo.EXPECT().GetByChannelID("C1").Return(nil, notFound)
+		mockRepo.EXPECT().Save(gomock.Any()).Return(nil)
+		mockCache.EXPECT().Delete(gomock.Any(), "channel_config:C1").Return(nil)
+		mockRepo.EXPECT().GetByChannelID("C2").Return(nil, assert.AnError)
+
+		results, err := useCase.ApplyChannelTemplate(template, model.ChannelSelector{ChannelIDs: []string{"C1", "C2", "C1"}}, false)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, model.BulkStatusApplied, results[0].Status)
+		assert.Equal(t, model.BulkStatusFailed, results[1].Status)
+		assert.NotEmpty(t, results[1].Error)
+	})
+
+	t.Run("name pattern without directory", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase := NewChannelUseCase(mocks.NewMockChannelRepository(ctrl), mocks.NewMockCache(ctrl))
+
+		_, err := useCase.ApplyChannelTemplate(template, model.ChannelSelector{NamePattern: "proj-*"}, true)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/ai"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/security"
+	"go.uber.org/zap"
+)
+
+// Replies shown to users when a message cannot be translated, on every chat platform
+const (
+	MessageUnsupportedLanguage = "⚠️ Sorry! I only translate English and Vietnamese right now, not other languages, slang or numbers"
+	MessageTranslationTimeout  = "⏱️ Sorry, the translation timed out. Please try again later."
+	MessageInvalidInput        = "Sorry, there seems to be an error in your text. Please check the content and try again."
+	MessageTranslationFailed   = "❌ Sorry, I couldn't translate this message. Please try again later."
+	MessageRateLimited         = "🐢 Whoa, that's a lot of messages! I'm pausing translations for a moment, please slow down a little."
+	MessageBudgetExhausted     = "⏳ Sorry, today's AI budget is used up, so I can only repeat translations I've made before until it resets at midnight UTC."
+)
+
+// ChatPlatform is the chat-platform layer under the translation flow, so the
+// translation engine, queue, security and storage are shared by every platform
+type ChatPlatform interface {
+	PostReply(ctx context.Context, reply model.ChatReply) error
+	AddReaction(ctx context.Context, channelID, messageID, emoji string) error
+	GetUser(ctx context.Context, channelID, userID string) (*model.ChatUser, error)
+}
+
+var _ ChatTranslationService = (*ChatTranslationUseCase)(nil)
+
+// ChatTranslationUseCase translates messages from platforms other than Slack,
+// which keeps its own event processor for Slack-only features such as review,
+// digests and cross-posting
+type ChatTranslationUseCase struct {
+	translation TranslationService
+	languages   *LanguageRouter
+	logger      *zap.Logger
+}
+
+func NewChatTranslationUseCase(translation TranslationService, logger *zap.Logger) *ChatTranslationUseCase {
+	return &ChatTranslationUseCase{
+		translation: translation,
+		languages:   DefaultLanguageRouter(),
+		logger:      logger,
+	}
+}
+
+// SetLanguageRouter translates messages along the router's language pairs
+// instead of English <-> Vietnamese
+func (cu *ChatTranslationUseCase) SetLanguageRouter(languages *LanguageRouter) {
+	cu.languages = languages
+}
+
+// HandleMessage translates msg along the language pairs and replies in
+// its thread on platform. Failures are reported to the user in the thread.
+func (cu *ChatTranslationUseCase) HandleMessage(ctx context.Context, platform ChatPlatform, msg model.ChatMessage) {
+	text := strings.TrimSpace(msg.Text)
+	if text == "" {
+		cu.logger.Debug("Skipping message with empty text",
+			zap.String("channel_id", msg.ChannelID))
+		return
+	}
+
+	if err := platform.AddReaction(ctx, msg.ChannelID, msg.MessageID, "eyes"); err != nil {
+		cu.logger.Warn("Failed to add emoji reaction to message",
+			zap.Error(err),
+			zap.String("channel_id", msg.ChannelID),
+			zap.String("message_id", msg.MessageID))
+	}
+
+	botName := "Translator"
+	botAvatar := ""
+	user, err := platform.GetUser(ctx, msg.ChannelID, msg.UserID)
+	if err == nil && user != nil {
+		displayName := user.DisplayName
+		if displayName == "" {
+			displayName = user.Name
+		}
+		if displayName != "" {
+			botName = fmt.Sprintf("%s (Bot)", displayName)
+		}
+		botAvatar = user.AvatarURL
+	} else {
+		cu.logger.Warn("Failed to get user info, using default bot name",
+			zap.Error(err),
+			zap.String("user_id", msg.UserID))
+	}
+
+	reply := func(text string) {
+		err := platform.PostReply(ctx, model.ChatReply{
+			ChannelID: msg.ChannelID,
+			ThreadID:  msg.ThreadID,
+			Text:      text,
+			Username:  botName,
+			AvatarURL: botAvatar,
+		})
+		if err != nil {
+			cu.logger.Error("Failed to post reply",
+				zap.Error(err),
+				zap.String("channel_id", msg.ChannelID))
+		}
+	}
+
+	detectStart := time.Now()
+	detectedLang, err := cu.translation.DetectLanguage(text)
+	metrics.LatencyTraceFrom(ctx).Add(metrics.StageAI, time.Since(detectStart))
+	if err != nil {
+		cu.logger.Error("Failed to detect message language",
+			zap.Error(err),
+			zap.String("channel_id", msg.ChannelID))
+		if errorMessage, ok := ProviderErrorMessage(err); ok {
+			reply(errorMessage)
+		}
+		return
+	}
+
+	targetLang, ok := cu.languages.Route(nil, detectedLang)
+	if !ok {
+		cu.logger.Info("Unsupported language, no language pair translates it",
+			zap.String("detected_language", detectedLang))
+		reply(cu.languages.UnsupportedLanguageMessage())
+		return
+	}
+
+	result, err := cu.translation.TranslateContext(ctx, request.Translation{
+		Text:           text,
+		SourceLanguage: detectedLang,
+		TargetLanguage: targetLang,
+		UserID:         msg.UserID,
+		ChannelID:      msg.ChannelID,
+	})
+	if err != nil {
+		cu.logger.Error("Failed to translate message",
+			zap.Error(err),
+			zap.String("category", string(ai.CategoryOf(err))),
+			zap.String("channel_id", msg.ChannelID))
+		reply(TranslationErrorMessage(err))
+		return
+	}
+
+	emoji := "🇻🇳"
+	if result.TargetLanguage == "English" {
+		emoji = "🇬🇧"
+	}
+	botName = fmt.Sprintf("%s %s", botName, emoji)
+	reply(result.TranslatedText)
+
+	cu.logger.Info("Translation posted successfully",
+		zap.String("channel_id", msg.ChannelID),
+		zap.String("target_language", result.TargetLanguage))
+}
+
+// IsInputRejected reports whether a translation failed because the input
+// failed security validation
+func IsInputRejected(err error) bool {
+	return errors.Is(err, security.ErrCanaryLeaked) ||
+		strings.Contains(err.Error(), "Delimiter tag injection") ||
+		strings.Contains(err.Error(), "input validation failed")
+}
+
+// TranslationErrorMessage returns the message shown to the user when a translation fails
+func TranslationErrorMessage(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return MessageTranslationTimeout
+	}
+	if IsInputRejected(err) {
+		return MessageInvalidInput
+	}
+	if message, ok := ProviderErrorMessage(err); ok {
+		return message
+	}
+	return MessageTranslationFailed
+}
+
+// ProviderErrorMessage returns the message shown to the user for an AI
+// provider error, or false if err is not a categorized provider error
+func ProviderErrorMessage(err error) (string, bool) {
+	switch ai.CategoryOf(err) {
+	case ai.CategoryQuotaExceeded:
+		return "❌ Sorry, I can't translate because the current quota has been exceeded. Please try again later.", true
+	case ai.CategorySafetyBlocked:
+		return "⚠️ Sorry, this content could not be translated due to safety filters.", true
+	case ai.CategoryTimeout:
+		return MessageTranslationTimeout, true
+	case ai.CategoryInvalidResponse:
+		return "❌ Sorry, the translation service returned an unexpected response. Please try again.", true
+	case ai.CategoryAuthFailed:
+		return "❌ Sorry, the translation service is not configured correctly. Please contact an administrator.", true
+	case ai.CategoryBudgetExhausted:
+		return MessageBudgetExhausted, true
+	}
+	return "", false
+}
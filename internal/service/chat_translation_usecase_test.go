@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/ai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeTranslationService struct {
+	detected     string
+	detectErr    error
+	translated   string
+	translateErr error
+	requests     []request.Translation
+}
+
+func (f *fakeTranslationService) Translate(req request.Translation) (response.Translation, error) {
+	return f.TranslateContext(context.Background(), req)
+}
+
+func (f *fakeTranslationService) TranslateContext(_ context.Context, req request.Translation) (response.Translation, error) {
+	f.requests = append(f.requests, req)
+	if f.translateErr != nil {
+		return response.Translation{}, f.translateErr
+	}
+	return response.Translation{
+		SourceLanguage: req.SourceLanguage,
+		TargetLanguage: req.TargetLanguage,
+		TranslatedText: f.translated,
+	}, nil
+}
+
+func (f *fakeTranslationService) GetTranslation(context.Context, string) (response.Translation, error) {
+	return response.Translation{}, model.NewNotFoundError("translation not found")
+}
+
+func (f *fakeTranslationService) DetectLanguage(string) (string, error) {
+	return f.detected, f.detectErr
+}
+
+type fakeChatPlatform struct {
+	user      *model.ChatUser
+	replies   []model.ChatReply
+	reactions []string
+}
+
+func (f *fakeChatPlatform) PostReply(_ context.Context, reply model.ChatReply) error {
+	f.replies = append(f.replies, reply)
+	return nil
+}
+
+func (f *fakeChatPlatform) AddReaction(_ context.Context, _, messageID, emoji string) error {
+	f.reactions = append(f.reactions, messageID+":"+emoji)
+	return nil
+}
+
+func (f *fakeChatPlatform) GetUser(context.Context, string, string) (*model.ChatUser, error) {
+	if f.user == nil {
+		return nil, errors.New("user not found")
+	}
+	return f.user, nil
+}
+
+func TestChatTranslationUseCase_HandleMessage(t *testing.T) {
+	msg := model.ChatMessage{ChannelID: "conv-1", ThreadID: "msg-1", MessageID: "msg-1", UserID: "user-1", Text: "Hello team"}
+
+	tests := []struct {
+		name        string
+		translation *fakeTranslationService
+		user        *model.ChatUser
+		text        string
+		wantText    string
+		wantName    string
+		wantTarget  string
+	}{
+		{
+			name:        "english to vietnamese",
+			translation: &fakeTranslationService{detected: "English", translated: "Xin chào nhóm"},
+			user:        &model.ChatUser{Name: "alice", DisplayName: "Alice", AvatarURL: "https://avatar"},
+			wantText:    "Xin chào nhóm",
+			wantName:    "Alice (Bot) 🇻🇳",
+			wantTarget:  "Vietnamese",
+		},
+		{
+			name:        "vietnamese to english with unknown user",
+			translation: &fakeTranslationService{detected: "Vietnamese", translated: "Hello team"},
+			wantText:    "Hello team",
+			wantName:    "Translator 🇬🇧",
+			wantTarget:  "English",
+		},
+		{
+			name:        "unsupported language",
+			translation: &fakeTranslationService{detected: "French"},
+			wantText:    MessageUnsupportedLanguage,
+			wantName:    "Translator",
+		},
+		{
+			name:        "translation failed",
+			translation: &fakeTranslationService{detected: "English", translateErr: fmt.Errorf("generate: %w", ai.ErrQuotaExceeded)},
+			wantText:    "❌ Sorry, I can't translate because the current quota has been exceeded. Please try again later.",
+			wantName:    "Translator",
+			wantTarget:  "Vietnamese",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			platform := &fakeChatPlatform{user: tt.user}
+			uc := NewChatTranslationUseCase(tt.translation, zap.NewNop())
+
+			uc.HandleMessage(context.Background(), platform, msg)
+
+			assert.Equal(t, []string{"msg-1:eyes"}, platform.reactions)
+			require.Len(t, platform.replies, 1)
+			reply := platform.replies[0]
+			assert.Equal(t, "conv-1", reply.ChannelID)
+			assert.Equal(t, "msg-1", reply.ThreadID)
+			assert.Equal(t, tt.wantText, reply.Text)
+			assert.Equal(t, tt.wantName, reply.Username)
+			if tt.wantTarget != "" {
+				require.Len(t, tt.translation.requests, 1)
+				assert.Equal(t, tt.wantTarget, tt.translation.requests[0].TargetLanguage)
+			} else {
+				assert.Empty(t, tt.translation.requests)
+			}
+		})
+	}
+}
+
+func TestChatTranslationUseCase_HandleMessage_EmptyText(t *testing.T) {
+	platform := &fakeChatPlatform{}
+	translation := &fakeTranslationService{detected: "English"}
+	uc := NewChatTranslationUseCase(translation, zap.NewNop())
+
+	uc.HandleMessage(context.Background(), platform, model.ChatMessage{ChannelID: "conv-1", MessageID: "msg-1", Text: "   "})
+
+	assert.Empty(t, platform.reactions)
+	assert.Empty(t, platform.replies)
+	assert.Empty(t, translation.requests)
+}
+
+func TestTranslationErrorMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "deadline", err: fmt.Errorf("translate: %w", context.DeadlineExceeded), want: MessageTranslationTimeout},
+		{name: "rejected input", err: errors.New("input validation failed: too long"), want: MessageInvalidInput},
+		{name: "safety blocked", err: ai.ErrSafetyBlocked, want: "⚠️ Sorry, this content could not be translated due to safety filters."},
+		{name: "unknown", err: errors.New("boom"), want: MessageTranslationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TranslationErrorMessage(tt.err))
+		})
+	}
+}
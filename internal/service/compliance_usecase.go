@@ -0,0 +1,314 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+// BotPostRepository defines the interface for the record of bot posts.
+// This interface is owned by the ComplianceUseCase and defined where it's consumed.
+type BotPostRepository interface {
+	Save(post *model.BotPost) error
+	// FindRange returns up to limit posts of teamID (all teams when empty)
+	// posted in [from, to), ordered by PostedAt then ID, starting after the
+	// given post when it is not nil
+	FindRange(teamID string, from, to time.Time, after *model.BotPost, limit int) ([]*model.BotPost, error)
+}
+
+// ComplianceExportRepository defines the interface for compliance export persistence
+type ComplianceExportRepository interface {
+	Save(export *model.ComplianceExport) error
+	// Latest returns the most recent export, or nil when there is none
+	Latest() (*model.ComplianceExport, error)
+	List(limit int) ([]*model.ComplianceExport, error)
+}
+
+// ObjectStore writes objects that cannot be replaced, e.g. storage.Store
+type ObjectStore interface {
+	Create(ctx context.Context, key string, data []byte, contentType string) error
+}
+
+const (
+	// complianceGenesisHash is the previous hash of the first export
+	complianceGenesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+	compliancePageSize    = 500
+	defaultExportLimit    = 50
+)
+
+var _ ComplianceService = (*ComplianceUseCase)(nil)
+
+// ComplianceUseCase records what the bot posts and exports it for
+// e-discovery. Exports are JSON lines, each with the SHA-256 hash of the
+// line chained to the previous one across exports, and a manifest signed
+// with an Ed25519 key, written once to object storage.
+type ComplianceUseCase struct {
+	posts         BotPostRepository
+	exports       ComplianceExportRepository
+	store         ObjectStore
+	signingKey    ed25519.PrivateKey
+	maxExportDays int
+	logger        *zap.Logger
+	now           func() time.Time
+
+	// exporting keeps exports from forking the hash chain
+	exporting sync.Mutex
+}
+
+// NewComplianceUseCase signs manifests with the Ed25519 key of the
+// base64-encoded 32-byte seed. Exports span at most maxExportDays.
+func NewComplianceUseCase(posts BotPostRepository, exports ComplianceExportRepository, store ObjectStore, signingSeed string, maxExportDays int, logger *zap.Logger) (*ComplianceUseCase, error) {
+	seed, err := base64.StdEncoding.DecodeString(signingSeed)
+	if err != nil {
+		return nil, fmt.Errorf("signing key is not valid base64: %w", err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("signing key must be %d bytes, got %d", ed25519.SeedSize, len(seed))
+	}
+
+	return &ComplianceUseCase{
+		posts:         posts,
+		exports:       exports,
+		store:         store,
+		signingKey:    ed25519.NewKeyFromSeed(seed),
+		maxExportDays: maxExportDays,
+		logger:        logger,
+		now:           time.Now,
+	}, nil
+}
+
+// RecordPost keeps a message the bot posted, edited or deleted. Failures are
+// logged: a post is never held back by its record.
+func (cu *ComplianceUseCase) RecordPost(_ context.Context, post *model.BotPost) {
+	post.ID = generateID()
+	if post.PostedAt.IsZero() {
+		post.PostedAt = cu.now()
+	}
+	post.PostedAt = post.PostedAt.UTC()
+
+	if err := cu.posts.Save(post); err != nil {
+		cu.logger.Error("Failed to record bot post for compliance",
+			zap.Error(err),
+			zap.String("team_id", post.TeamID),
+			zap.String("channel_id", post.ChannelID),
+			zap.String("ts", post.MessageTS),
+			zap.String("action", post.Action))
+	}
+}
+
+// exportLine is a line of an export. Hash is the SHA-256 of the line's JSON
+// without the hash field, which comes last.
+type exportLine struct {
+	Seq int `json:"seq"`
+	model.BotPost
+	PrevHash string `json:"prev_hash"`
+}
+
+// exportManifest describes an export file; its JSON is what is signed
+type exportManifest struct {
+	ExportID  string    `json:"export_id"`
+	TeamID    string    `json:"team_id,omitempty"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	ObjectKey string    `json:"object_key"`
+	Records   int       `json:"records"`
+	PrevHash  string    `json:"prev_hash"`
+	FinalHash string    `json:"final_hash"`
+	SHA256    string    `json:"sha256"`
+	PublicKey string    `json:"public_key"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Export writes the bot posts of teamID (all teams when empty) posted in
+// [from, to) to object storage as <key>.jsonl, with <key>.manifest.json and
+// its detached signature <key>.manifest.json.sig
+func (cu *ComplianceUseCase) Export(ctx context.Context, teamID string, from, to time.Time, requestedBy string) (*model.ComplianceExport, error) {
+	from, to = from.UTC(), to.UTC()
+	if !from.Before(to) {
+		return nil, model.NewValidationError("from must be before to")
+	}
+	if to.After(cu.now()) {
+		return nil, model.NewValidationError("to must not be in the future, so the exported range is complete")
+	}
+	if cu.maxExportDays > 0 && to.Sub(from) > time.Duration(cu.maxExportDays)*24*time.Hour {
+		return nil, model.NewValidationError(fmt.Sprintf("an export spans at most %d days", cu.maxExportDays))
+	}
+
+	cu.exporting.Lock()
+	defer cu.exporting.Unlock()
+
+	prevHash := complianceGenesisHash
+	previous, err := cu.exports.Latest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load previous export: %w", err)
+	}
+	if previous != nil {
+		prevHash = previous.FinalHash
+	}
+
+	export := &model.ComplianceExport{
+		ID:        generateID(),
+		TeamID:    teamID,
+		From:      from,
+		To:        to,
+		PrevHash:  prevHash,
+		CreatedBy: requestedBy,
+		CreatedAt: cu.now().UTC(),
+	}
+	scope := teamID
+	if scope == "" {
+		scope = "all"
+	}
+	key := fmt.Sprintf("exports/%s/%s_%s_%s", scope, from.Format("20060102T150405Z"), to.Format("20060102T150405Z"), export.ID)
+	export.ObjectKey = key + ".jsonl"
+	export.ManifestKey = key + ".manifest.json"
+
+	var file bytes.Buffer
+	hash := prevHash
+	var after *model.BotPost
+	for {
+		posts, err := cu.posts.FindRange(teamID, from, to, after, compliancePageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bot posts: %w", err)
+		}
+		for _, post := range posts {
+			export.Records++
+			if hash, err = writeExportLine(&file, exportLine{Seq: export.Records, BotPost: *post, PrevHash: hash}); err != nil {
+				return nil, err
+			}
+		}
+		if len(posts) < compliancePageSize {
+			break
+		}
+		after = posts[len(posts)-1]
+	}
+	export.FinalHash = hash
+	fileHash := sha256.Sum256(file.Bytes())
+	export.SHA256 = hex.EncodeToString(fileHash[:])
+
+	manifest, err := json.MarshalIndent(exportManifest{
+		ExportID:  export.ID,
+		TeamID:    export.TeamID,
+		From:      export.From,
+		To:        export.To,
+		ObjectKey: export.ObjectKey,
+		Records:   export.Records,
+		PrevHash:  export.PrevHash,
+		FinalHash: export.FinalHash,
+		SHA256:    export.SHA256,
+		PublicKey: cu.PublicKey(),
+		CreatedBy: export.CreatedBy,
+		CreatedAt: export.CreatedAt,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode export manifest: %w", err)
+	}
+	export.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(cu.signingKey, manifest))
+
+	objects := []struct {
+		key         string
+		data        []byte
+		contentType string
+	}{
+		{export.ObjectKey, file.Bytes(), "application/x-ndjson"},
+		{export.ManifestKey, manifest, "application/json"},
+		{export.ManifestKey + ".sig", []byte(export.Signature), "text/plain"},
+	}
+	for _, object := range objects {
+		if err := cu.store.Create(ctx, object.key, object.data, object.contentType); err != nil {
+			return nil, fmt.Errorf("failed to store %s: %w", object.key, err)
+		}
+	}
+
+	if err := cu.exports.Save(export); err != nil {
+		return nil, fmt.Errorf("failed to save compliance export: %w", err)
+	}
+
+	cu.logger.Info("Compliance export written",
+		zap.String("export_id", export.ID),
+		zap.String("team_id", teamID),
+		zap.Time("from", from),
+		zap.Time("to", to),
+		zap.Int("records", export.Records),
+		zap.String("object_key", export.ObjectKey),
+		zap.String("created_by", requestedBy))
+	return export, nil
+}
+
+// writeExportLine appends line to file and returns its hash
+func writeExportLine(file *bytes.Buffer, line exportLine) (string, error) {
+	encoded, err := json.Marshal(line)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode bot post %s: %w", line.ID, err)
+	}
+	sum := sha256.Sum256(encoded)
+	hash := hex.EncodeToString(sum[:])
+
+	// The hash field is spliced in before the closing brace
+	file.Write(encoded[:len(encoded)-1])
+	fmt.Fprintf(file, `,"hash":%q}`, hash)
+	file.WriteByte('\n')
+	return hash, nil
+}
+
+// ListExports returns the most recent exports first
+func (cu *ComplianceUseCase) ListExports(limit int) ([]*model.ComplianceExport, error) {
+	if limit <= 0 || limit > defaultExportLimit {
+		limit = defaultExportLimit
+	}
+	exports, err := cu.exports.List(limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list compliance exports: %w", err)
+	}
+	return exports, nil
+}
+
+// PublicKey returns the base64-encoded Ed25519 key that verifies manifests
+func (cu *ComplianceUseCase) PublicKey() string {
+	public, _ := cu.signingKey.Public().(ed25519.PublicKey)
+	return base64.StdEncoding.EncodeToString(public)
+}
+
+// VerifyExport checks the hash chain of an export file that continues from
+// prevHash and returns the number of lines and the final hash
+func VerifyExport(file []byte, prevHash string) (int, string, error) {
+	hash := prevHash
+	lines := strings.Split(strings.TrimSuffix(string(file), "\n"), "\n")
+	if len(file) == 0 {
+		lines = nil
+	}
+	for i, line := range lines {
+		var fields struct {
+			PrevHash string `json:"prev_hash"`
+			Hash     string `json:"hash"`
+		}
+		if err := json.Unmarshal([]byte(line), &fields); err != nil {
+			return 0, "", fmt.Errorf("line %d is not valid JSON: %w", i+1, err)
+		}
+		suffix := fmt.Sprintf(`,"hash":%q}`, fields.Hash)
+		if !strings.HasSuffix(line, suffix) {
+			return 0, "", fmt.Errorf("line %d does not end with its hash", i+1)
+		}
+		if fields.PrevHash != hash {
+			return 0, "", fmt.Errorf("line %d does not follow the previous line", i+1)
+		}
+		sum := sha256.Sum256([]byte(strings.TrimSuffix(line, suffix) + "}"))
+		if hex.EncodeToString(sum[:]) != fields.Hash {
+			return 0, "", fmt.Errorf("line %d does not match its hash", i+1)
+		}
+		hash = fields.Hash
+	}
+	return len(lines), hash, nil
+}
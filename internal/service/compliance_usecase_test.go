@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeBotPostRepository struct {
+	posts []*model.BotPost
+}
+
+func (f *fakeBotPostRepository) Save(post *model.BotPost) error {
+	f.posts = append(f.posts, post)
+	return nil
+}
+
+func (f *fakeBotPostRepository) FindRange(teamID string, from, to time.Time, after *model.BotPost, limit int) ([]*model.BotPost, error) {
+	sort.Slice(f.posts, func(i, j int) bool {
+		if f.posts[i].PostedAt.Equal(f.posts[j].PostedAt) {
+			return f.posts[i].ID < f.posts[j].ID
+		}
+		return f.posts[i].PostedAt.Before(f.posts[j].PostedAt)
+	})
+	var found []*model.BotPost
+	for _, post := range f.posts {
+		if post.PostedAt.Before(from) || !post.PostedAt.Before(to) || (teamID != "" && post.TeamID != teamID) {
+			continue
+		}
+		if after != nil && (post.PostedAt.Before(after.PostedAt) || post.PostedAt.Equal(after.PostedAt) && post.ID <= after.ID) {
+			continue
+		}
+		if len(found) == limit {
+			break
+		}
+		found = append(found, post)
+	}
+	return found, nil
+}
+
+type fakeComplianceExportRepository struct {
+	exports []*model.ComplianceExport
+}
+
+func (f *fakeComplianceExportRepository) Save(export *model.ComplianceExport) error {
+	f.exports = append(f.exports, export)
+	return nil
+}
+
+func (f *fakeComplianceExportRepository) Latest() (*model.ComplianceExport, error) {
+	if len(f.exports) == 0 {
+		return nil, nil
+	}
+	return f.exports[len(f.exports)-1], nil
+}
+
+func (f *fakeComplianceExportRepository) List(limit int) ([]*model.ComplianceExport, error) {
+	return f.exports, nil
+}
+
+type fakeObjectStore map[string][]byte
+
+func (f fakeObjectStore) Create(_ context.Context, key string, data []byte, _ string) error {
+	f[key] = data
+	return nil
+}
+
+func TestComplianceUseCase_Export(t *testing.T) {
+	ctx := context.Background()
+	seed := make([]byte, ed25519.SeedSize)
+	posts := &fakeBotPostRepository{}
+	exports := &fakeComplianceExportRepository{}
+	store := fakeObjectStore{}
+	useCase, err := NewComplianceUseCase(posts, exports, store, base64.StdEncoding.EncodeToString(seed), 31, zap.NewNop())
+	require.NoError(t, err)
+
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	useCase.now = func() time.Time { return day.Add(10 * 24 * time.Hour) }
+	for i := 0; i < compliancePageSize+2; i++ {
+		useCase.RecordPost(ctx, &model.BotPost{
+			TeamID:    "T1",
+			ChannelID: "C1",
+			MessageTS: "1.1",
+			Action:    model.BotPostActionPost,
+			Text:      "🇻🇳 Xin chào <@U1> & \"team\"",
+			PostedAt:  day.Add(time.Duration(i) * time.Second),
+		})
+	}
+	useCase.RecordPost(ctx, &model.BotPost{TeamID: "T2", ChannelID: "C2", Action: model.BotPostActionDelete, PostedAt: day})
+
+	export, err := useCase.Export(ctx, "T1", day, day.Add(24*time.Hour), "alice")
+	require.NoError(t, err)
+	assert.Equal(t, compliancePageSize+2, export.Records, "every page is exported")
+	assert.Equal(t, complianceGenesisHash, export.PrevHash)
+
+	file := store[export.ObjectKey]
+	records, finalHash, err := VerifyExport(file, export.PrevHash)
+	require.NoError(t, err)
+	assert.Equal(t, export.Records, records)
+	assert.Equal(t, export.FinalHash, finalHash)
+
+	manifest := store[export.ManifestKey]
+	assert.Contains(t, string(manifest), export.SHA256)
+	signature, err := base64.StdEncoding.DecodeString(string(store[export.ManifestKey+".sig"]))
+	require.NoError(t, err)
+	publicKey, err := base64.StdEncoding.DecodeString(useCase.PublicKey())
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(publicKey, manifest, signature))
+
+	tampered := strings.Replace(string(file), "Xin chào", "Tạm biệt", 1)
+	_, _, err = VerifyExport([]byte(tampered), export.PrevHash)
+	assert.Error(t, err, "edited lines break the chain")
+
+	next, err := useCase.Export(ctx, "", day, day.Add(24*time.Hour), "alice")
+	require.NoError(t, err)
+	assert.Equal(t, export.FinalHash, next.PrevHash, "exports continue the chain")
+	assert.Equal(t, compliancePageSize+3, next.Records)
+	_, _, err = VerifyExport(store[next.ObjectKey], next.PrevHash)
+	assert.NoError(t, err)
+}
+
+func TestComplianceUseCase_ExportRange(t *testing.T) {
+	seed := base64.StdEncoding.EncodeToString(make([]byte, ed25519.SeedSize))
+	useCase, err := NewComplianceUseCase(&fakeBotPostRepository{}, &fakeComplianceExportRepository{}, fakeObjectStore{}, seed, 31, zap.NewNop())
+	require.NoError(t, err)
+	now := time.Now()
+
+	_, err = useCase.Export(context.Background(), "", now.Add(-time.Hour), now.Add(-2*time.Hour), "alice")
+	assert.True(t, isValidationError(err), "from after to")
+	_, err = useCase.Export(context.Background(), "", now.Add(-time.Hour), now.Add(time.Hour), "alice")
+	assert.True(t, isValidationError(err), "ranges not over yet")
+	_, err = useCase.Export(context.Background(), "", now.Add(-40*24*time.Hour), now, "alice")
+	assert.True(t, isValidationError(err), "ranges over COMPLIANCE_MAX_EXPORT_DAYS")
+
+	_, err = NewComplianceUseCase(nil, nil, nil, "c2hvcnQ=", 31, zap.NewNop())
+	assert.Error(t, err)
+}
@@ -0,0 +1,355 @@
+package service
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+var _ ConfigBundleService = (*ConfigBundleUseCase)(nil)
+
+// ConfigBundleUseCase exports the bot configuration as a bundle and applies
+// bundles exported elsewhere, like Terraform plans and applies its state:
+// every difference is listed first and applied only when it is not a dry run.
+type ConfigBundleUseCase struct {
+	channels   ChannelService
+	rules      FilterRuleService
+	pairs      ChannelPairService
+	workspaces WorkspaceService
+	logger     *zap.Logger
+}
+
+func NewConfigBundleUseCase(channels ChannelService, rules FilterRuleService, pairs ChannelPairService, logger *zap.Logger) *ConfigBundleUseCase {
+	return &ConfigBundleUseCase{
+		channels: channels,
+		rules:    rules,
+		pairs:    pairs,
+		logger:   logger,
+	}
+}
+
+// SetWorkspaces exports the workspaces the app is installed in and warns
+// about the bundle's workspaces missing from the deployment
+func (bu *ConfigBundleUseCase) SetWorkspaces(workspaces WorkspaceService) {
+	bu.workspaces = workspaces
+}
+
+// configState is the configuration of the deployment, keyed like the bundle
+type configState struct {
+	channels map[string]*model.ChannelConfig
+	rules    map[string]*model.FilterRule
+	pairs    map[string]*model.ChannelPair
+}
+
+// Export returns the configuration of the deployment. The rules of channels
+// that are neither configured nor paired are not exported.
+func (bu *ConfigBundleUseCase) Export() (*model.ConfigBundle, error) {
+	state, err := bu.load(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &model.ConfigBundle{
+		Version:      model.ConfigBundleVersion,
+		ExportedAt:   time.Now().UTC(),
+		Channels:     make([]model.BundleChannel, 0, len(state.channels)),
+		Rules:        make([]model.BundleRule, 0, len(state.rules)),
+		ChannelPairs: make([]model.BundleChannelPair, 0, len(state.pairs)),
+	}
+	for _, config := range state.channels {
+		bundle.Channels = append(bundle.Channels, model.NewBundleChannel(config))
+	}
+	for _, rule := range state.rules {
+		bundle.Rules = append(bundle.Rules, model.NewBundleRule(rule))
+	}
+	for _, pair := range state.pairs {
+		bundle.ChannelPairs = append(bundle.ChannelPairs, model.BundleChannelPair{
+			ChannelID:       pair.ChannelID,
+			PairedChannelID: pair.PairedChannelID,
+		})
+	}
+	// A stable order keeps bundles of the same configuration identical
+	sort.Slice(bundle.Channels, func(i, j int) bool { return bundle.Channels[i].ChannelID < bundle.Channels[j].ChannelID })
+	sort.Slice(bundle.Rules, func(i, j int) bool { return bundle.Rules[i].Key() < bundle.Rules[j].Key() })
+	sort.Slice(bundle.ChannelPairs, func(i, j int) bool { return bundle.ChannelPairs[i].Key() < bundle.ChannelPairs[j].Key() })
+
+	if bu.workspaces != nil {
+		workspaces, err := bu.workspaces.ListWorkspaces()
+		if err != nil {
+			return nil, fmt.Errorf("failed to export workspaces: %w", err)
+		}
+		for _, workspace := range workspaces {
+			bundle.Workspaces = append(bundle.Workspaces, model.NewBundleWorkspace(workspace))
+		}
+		sort.Slice(bundle.Workspaces, func(i, j int) bool { return bundle.Workspaces[i].TeamID < bundle.Workspaces[j].TeamID })
+	}
+
+	return bundle, nil
+}
+
+// Apply plans the changes that make the deployment match the bundle and
+// applies them unless opts.DryRun is set. A change that fails is reported
+// and the others are still applied. Channels, rules and pairs missing from
+// the bundle are only deleted with opts.Prune.
+func (bu *ConfigBundleUseCase) Apply(bundle *model.ConfigBundle, opts model.ConfigApplyOptions) (*model.ConfigPlan, error) {
+	if err := bundle.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config bundle: %w", err)
+	}
+
+	// Rules are stored per channel, so the rules of the bundle's channels
+	// are loaded even when the channels are not configured yet
+	var ruleChannels []string
+	for _, rule := range bundle.Rules {
+		ruleChannels = append(ruleChannels, rule.ChannelID)
+	}
+	state, err := bu.load(ruleChannels)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &model.ConfigPlan{DryRun: opts.DryRun, Changes: []model.ConfigChange{}}
+	if err := bu.planWorkspaces(plan, bundle.Workspaces); err != nil {
+		return nil, err
+	}
+	bu.planChannels(plan, state, bundle.Channels, opts.Prune)
+	bu.planRules(plan, state, bundle.Rules, opts.Prune)
+	bu.planPairs(plan, state, bundle.ChannelPairs, opts.Prune)
+
+	if !opts.DryRun {
+		for i := range plan.Changes {
+			bu.apply(&plan.Changes[i], state)
+		}
+	}
+	return plan, nil
+}
+
+// load reads the configuration of the deployment, including the rules of
+// extraChannels
+func (bu *ConfigBundleUseCase) load(extraChannels []string) (*configState, error) {
+	state := &configState{
+		channels: make(map[string]*model.ChannelConfig),
+		rules:    make(map[string]*model.FilterRule),
+		pairs:    make(map[string]*model.ChannelPair),
+	}
+
+	configs, err := bu.channels.ListAllChannelConfigs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load channel configs: %w", err)
+	}
+	pairs, err := bu.pairs.ListPairs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load channel pairs: %w", err)
+	}
+
+	ruleChannels := make(map[string]bool)
+	for _, config := range configs {
+		state.channels[config.ChannelID] = config
+		ruleChannels[config.ChannelID] = true
+	}
+	for _, pair := range pairs {
+		state.pairs[model.BundleChannelPair{ChannelID: pair.ChannelID, PairedChannelID: pair.PairedChannelID}.Key()] = pair
+		ruleChannels[pair.ChannelID] = true
+		ruleChannels[pair.PairedChannelID] = true
+	}
+	for _, channelID := range extraChannels {
+		ruleChannels[channelID] = true
+	}
+
+	for channelID := range ruleChannels {
+		rules, err := bu.rules.ListRules(channelID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load filter rules: %w", err)
+		}
+		for _, rule := range rules {
+			state.rules[model.NewBundleRule(rule).Key()] = rule
+		}
+	}
+	return state, nil
+}
+
+// planWorkspaces updates the settings of the bundle's workspaces that are
+// installed and warns about the others. Workspaces are never installed or
+// uninstalled by a bundle, so those missing from it are left alone.
+func (bu *ConfigBundleUseCase) planWorkspaces(plan *model.ConfigPlan, workspaces []model.BundleWorkspace) error {
+	if bu.workspaces == nil || len(workspaces) == 0 {
+		return nil
+	}
+	installed, err := bu.workspaces.ListWorkspaces()
+	if err != nil {
+		return fmt.Errorf("failed to load workspaces: %w", err)
+	}
+	existing := make(map[string]*model.Workspace, len(installed))
+	for _, workspace := range installed {
+		existing[workspace.TeamID] = workspace
+	}
+
+	for _, workspace := range workspaces {
+		current, ok := existing[workspace.TeamID]
+		if !ok {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("workspace %s (%s) is not installed; install the app through /slack/install", workspace.TeamID, workspace.TeamName))
+			continue
+		}
+		before := model.NewBundleWorkspace(current)
+		if before.SettingsEqual(workspace) {
+			plan.Unchanged++
+			continue
+		}
+		plan.Changes = append(plan.Changes, plannedChange(model.ConfigResourceWorkspace, workspace.TeamID, model.BulkActionUpdate, before, workspace))
+	}
+	return nil
+}
+
+func (bu *ConfigBundleUseCase) planChannels(plan *model.ConfigPlan, state *configState, channels []model.BundleChannel, prune bool) {
+	wanted := make(map[string]bool, len(channels))
+	for _, channel := range channels {
+		wanted[channel.ChannelID] = true
+		existing, ok := state.channels[channel.ChannelID]
+		if !ok {
+			plan.Changes = append(plan.Changes, plannedChange(model.ConfigResourceChannel, channel.ChannelID, model.BulkActionCreate, nil, channel))
+			continue
+		}
+		before := model.NewBundleChannel(existing)
+		if before.Equal(channel) {
+			plan.Unchanged++
+			continue
+		}
+		plan.Changes = append(plan.Changes, plannedChange(model.ConfigResourceChannel, channel.ChannelID, model.BulkActionUpdate, before, channel))
+	}
+
+	for _, channelID := range slices.Sorted(maps.Keys(state.channels)) {
+		if wanted[channelID] {
+			continue
+		}
+		if !prune {
+			plan.Unchanged++
+			continue
+		}
+		plan.Changes = append(plan.Changes, plannedChange(model.ConfigResourceChannel, channelID, model.ConfigActionDelete, model.NewBundleChannel(state.channels[channelID]), nil))
+	}
+}
+
+func (bu *ConfigBundleUseCase) planRules(plan *model.ConfigPlan, state *configState, rules []model.BundleRule, prune bool) {
+	wanted := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		key := rule.Key()
+		wanted[key] = true
+		existing, ok := state.rules[key]
+		if !ok {
+			plan.Changes = append(plan.Changes, plannedChange(model.ConfigResourceRule, key, model.BulkActionCreate, nil, rule))
+			continue
+		}
+		if existing.Enabled == rule.Enabled {
+			plan.Unchanged++
+			continue
+		}
+		plan.Changes = append(plan.Changes, plannedChange(model.ConfigResourceRule, key, model.BulkActionUpdate, model.NewBundleRule(existing), rule))
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(state.rules)) {
+		if wanted[key] {
+			continue
+		}
+		if !prune {
+			plan.Unchanged++
+			continue
+		}
+		plan.Changes = append(plan.Changes, plannedChange(model.ConfigResourceRule, key, model.ConfigActionDelete, model.NewBundleRule(state.rules[key]), nil))
+	}
+}
+
+// planPairs lists deleted pairs first, so a channel can move to another pair
+func (bu *ConfigBundleUseCase) planPairs(plan *model.ConfigPlan, state *configState, pairs []model.BundleChannelPair, prune bool) {
+	wanted := make(map[string]bool, len(pairs))
+	for _, pair := range pairs {
+		wanted[pair.Key()] = true
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(state.pairs)) {
+		if wanted[key] {
+			continue
+		}
+		if !prune {
+			plan.Unchanged++
+			continue
+		}
+		existing := state.pairs[key]
+		plan.Changes = append(plan.Changes, plannedChange(model.ConfigResourceChannelPair, key, model.ConfigActionDelete,
+			model.BundleChannelPair{ChannelID: existing.ChannelID, PairedChannelID: existing.PairedChannelID}, nil))
+	}
+
+	for _, pair := range pairs {
+		if _, ok := state.pairs[pair.Key()]; ok {
+			plan.Unchanged++
+			continue
+		}
+		plan.Changes = append(plan.Changes, plannedChange(model.ConfigResourceChannelPair, pair.Key(), model.BulkActionCreate, nil, pair))
+	}
+}
+
+func plannedChange(resource, key, action string, before, after interface{}) model.ConfigChange {
+	return model.ConfigChange{
+		Resource: resource,
+		Key:      key,
+		Action:   action,
+		Status:   model.BulkStatusPlanned,
+		Before:   before,
+		After:    after,
+	}
+}
+
+// apply applies a planned change and records its outcome
+func (bu *ConfigBundleUseCase) apply(change *model.ConfigChange, state *configState) {
+	var err error
+	switch change.Resource {
+	case model.ConfigResourceChannel:
+		switch change.Action {
+		case model.BulkActionCreate:
+			err = bu.channels.CreateChannelConfig(change.After.(model.BundleChannel).ToConfig())
+		case model.BulkActionUpdate:
+			err = bu.channels.UpdateChannelConfig(change.After.(model.BundleChannel).ToConfig())
+		case model.ConfigActionDelete:
+			err = bu.channels.DeleteChannelConfig(change.Key)
+		}
+	case model.ConfigResourceRule:
+		switch change.Action {
+		case model.BulkActionCreate:
+			err = bu.rules.CreateRule(change.After.(model.BundleRule).ToRule())
+		case model.BulkActionUpdate:
+			rule := change.After.(model.BundleRule).ToRule()
+			rule.ID = state.rules[change.Key].ID
+			err = bu.rules.UpdateRule(rule)
+		case model.ConfigActionDelete:
+			err = bu.rules.DeleteRule(state.rules[change.Key].ID)
+		}
+	case model.ConfigResourceWorkspace:
+		workspace := change.After.(model.BundleWorkspace)
+		if _, err = bu.workspaces.SetGeneration(workspace.TeamID, workspace.Generation); err == nil {
+			_, err = bu.workspaces.SetMonthlyBudget(workspace.TeamID, workspace.MonthlyBudget)
+		}
+	case model.ConfigResourceChannelPair:
+		switch change.Action {
+		case model.BulkActionCreate:
+			pair := change.After.(model.BundleChannelPair)
+			err = bu.pairs.CreatePair(&model.ChannelPair{ChannelID: pair.ChannelID, PairedChannelID: pair.PairedChannelID})
+		case model.ConfigActionDelete:
+			err = bu.pairs.DeletePair(state.pairs[change.Key].ID)
+		}
+	}
+
+	if err != nil {
+		bu.logger.Warn("Failed to apply configuration change",
+			zap.String("resource", change.Resource),
+			zap.String("key", change.Key),
+			zap.String("action", change.Action),
+			zap.Error(err))
+		change.Status = model.BulkStatusFailed
+		change.Error = err.Error()
+		return
+	}
+	change.Status = model.BulkStatusApplied
+}
@@ -0,0 +1,338 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeChannelService struct {
+	ChannelService
+	configs map[string]*model.ChannelConfig
+}
+
+func (f *fakeChannelService) ListAllChannelConfigs() ([]*model.ChannelConfig, error) {
+	configs := make([]*model.ChannelConfig, 0, len(f.configs))
+	for _, config := range f.configs {
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+func (f *fakeChannelService) CreateChannelConfig(config *model.ChannelConfig) error {
+	f.configs[config.ChannelID] = config
+	return nil
+}
+
+func (f *fakeChannelService) UpdateChannelConfig(config *model.ChannelConfig) error {
+	f.configs[config.ChannelID] = config
+	return nil
+}
+
+func (f *fakeChannelService) DeleteChannelConfig(channelID string) error {
+	delete(f.configs, channelID)
+	return nil
+}
+
+type fakeFilterRuleService struct {
+	FilterRuleService
+	rules map[string]*model.FilterRule
+}
+
+func (f *fakeFilterRuleService) ListRules(channelID string) ([]*model.FilterRule, error) {
+	var rules []*model.FilterRule
+	for _, rule := range f.rules {
+		if rule.ChannelID == channelID {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (f *fakeFilterRuleService) CreateRule(rule *model.FilterRule) error {
+	rule.ID = "new-" + rule.ChannelID
+	f.rules[rule.ID] = rule
+	return nil
+}
+
+func (f *fakeFilterRuleService) UpdateRule(rule *model.FilterRule) error {
+	f.rules[rule.ID] = rule
+	return nil
+}
+
+func (f *fakeFilterRuleService) DeleteRule(id string) error {
+	delete(f.rules, id)
+	return nil
+}
+
+type fakeChannelPairService struct {
+	ChannelPairService
+	pairs     map[string]*model.ChannelPair
+	createErr error
+}
+
+func (f *fakeChannelPairService) ListPairs() ([]*model.ChannelPair, error) {
+	pairs := make([]*model.ChannelPair, 0, len(f.pairs))
+	for _, pair := range f.pairs {
+		pairs = append(pairs, pair)
+	}
+	return pairs, nil
+}
+
+func (f *fakeChannelPairService) CreatePair(pair *model.ChannelPair) error {
+	if f.createErr != nil {
+		return f.createErr
+	}
+	pair.ID = "new-" + pair.ChannelID
+	f.pairs[pair.ID] = pair
+	return nil
+}
+
+func (f *fakeChannelPairService) DeletePair(id string) error {
+	delete(f.pairs, id)
+	return nil
+}
+
+type fakeWorkspaceService struct {
+	WorkspaceService
+	workspaces map[string]*model.Workspace
+}
+
+func (f *fakeWorkspaceService) ListWorkspaces() ([]*model.Workspace, error) {
+	workspaces := make([]*model.Workspace, 0, len(f.workspaces))
+	for _, workspace := range f.workspaces {
+		workspaces = append(workspaces, workspace)
+	}
+	return workspaces, nil
+}
+
+func (f *fakeWorkspaceService) SetGeneration(teamID string, generation model.GenerationSettings) (*model.Workspace, error) {
+	f.workspaces[teamID].Generation = generation
+	return f.workspaces[teamID], nil
+}
+
+func (f *fakeWorkspaceService) SetMonthlyBudget(teamID string, budget float64) (*model.Workspace, error) {
+	f.workspaces[teamID].MonthlyBudget = budget
+	return f.workspaces[teamID], nil
+}
+
+func newConfigBundleFixture() (*ConfigBundleUseCase, *fakeChannelService, *fakeFilterRuleService, *fakeChannelPairService) {
+	channels := &fakeChannelService{configs: map[string]*model.ChannelConfig{
+		"C1": {ID: "c1", ChannelID: "C1", AutoTranslate: true, TargetLanguage: "en", Enabled: true},
+		"C2": {ID: "c2", ChannelID: "C2", AutoTranslate: true, TargetLanguage: "vi", Enabled: true},
+	}}
+	rules := &fakeFilterRuleService{rules: map[string]*model.FilterRule{
+		"r1": {ID: "r1", ChannelID: "C1", Type: model.FilterRuleSkipRegex, Pattern: "^!", Enabled: true},
+	}}
+	pairs := &fakeChannelPairService{pairs: map[string]*model.ChannelPair{
+		"p1": {ID: "p1", ChannelID: "C1", PairedChannelID: "C2"},
+	}}
+	return NewConfigBundleUseCase(channels, rules, pairs, zap.NewNop()), channels, rules, pairs
+}
+
+func TestConfigBundleUseCase_ExportApplyRoundTrip(t *testing.T) {
+	uc, channels, rules, pairs := newConfigBundleFixture()
+
+	bundle, err := uc.Export()
+	require.NoError(t, err)
+	assert.Equal(t, model.ConfigBundleVersion, bundle.Version)
+	require.Len(t, bundle.Channels, 2)
+	assert.Equal(t, "C1", bundle.Channels[0].ChannelID)
+	assert.Len(t, bundle.Rules, 1)
+	assert.Len(t, bundle.ChannelPairs, 1)
+
+	plan, err := uc.Apply(bundle, model.ConfigApplyOptions{Prune: true})
+	require.NoError(t, err)
+	assert.Empty(t, plan.Changes)
+	assert.Equal(t, 4, plan.Unchanged)
+	assert.Len(t, channels.configs, 2)
+	assert.Len(t, rules.rules, 1)
+	assert.Len(t, pairs.pairs, 1)
+}
+
+func TestConfigBundleUseCase_Apply(t *testing.T) {
+	bundle := &model.ConfigBundle{
+		Version: model.ConfigBundleVersion,
+		Channels: []model.BundleChannel{
+			{ChannelID: "C1", AutoTranslate: true, TargetLanguage: "ja", Enabled: true},
+			{ChannelID: "C3", AutoTranslate: true, TargetLanguage: "en", Enabled: true},
+		},
+		Rules: []model.BundleRule{
+			{ChannelID: "C1", Type: model.FilterRuleSkipRegex, Pattern: "^!", Enabled: false},
+			{ChannelID: "C3", Type: model.FilterRuleRequireMention, Enabled: true},
+		},
+		ChannelPairs: []model.BundleChannelPair{{ChannelID: "C3", PairedChannelID: "C1"}},
+	}
+
+	t.Run("dry run only plans", func(t *testing.T) {
+		uc, channels, _, pairs := newConfigBundleFixture()
+
+		plan, err := uc.Apply(bundle, model.ConfigApplyOptions{DryRun: true, Prune: true})
+		require.NoError(t, err)
+		assert.True(t, plan.DryRun)
+
+		var summary []string
+		for _, change := range plan.Changes {
+			assert.Equal(t, model.BulkStatusPlanned, change.Status)
+			summary = append(summary, change.Action+" "+change.Resource+" "+change.Key)
+		}
+		assert.Equal(t, []string{
+			"update channel C1",
+			"create channel C3",
+			"delete channel C2",
+			"update rule C1/skip_regex/^!/",
+			"create rule C3/require_mention//",
+			"delete channel_pair C1/C2",
+			"create channel_pair C1/C3",
+		}, summary)
+		assert.Equal(t, "vi", channels.configs["C2"].TargetLanguage)
+		assert.Contains(t, pairs.pairs, "p1")
+	})
+
+	t.Run("applies changes", func(t *testing.T) {
+		uc, channels, rules, pairs := newConfigBundleFixture()
+
+		plan, err := uc.Apply(bundle, model.ConfigApplyOptions{Prune: true})
+		require.NoError(t, err)
+		assert.Zero(t, plan.Failed())
+		assert.Equal(t, "ja", channels.configs["C1"].TargetLanguage)
+		assert.Contains(t, channels.configs, "C3")
+		assert.NotContains(t, channels.configs, "C2")
+		assert.False(t, rules.rules["r1"].Enabled)
+		assert.Len(t, rules.rules, 2)
+		require.Len(t, pairs.pairs, 1)
+		assert.Equal(t, "C3", pairs.pairs["new-C3"].ChannelID)
+	})
+
+	t.Run("keeps missing resources without prune", func(t *testing.T) {
+		uc, channels, _, pairs := newConfigBundleFixture()
+
+		plan, err := uc.Apply(bundle, model.ConfigApplyOptions{})
+		require.NoError(t, err)
+		for _, change := range plan.Changes {
+			assert.NotEqual(t, model.ConfigActionDelete, change.Action)
+		}
+		assert.Contains(t, channels.configs, "C2")
+		assert.Contains(t, pairs.pairs, "p1")
+	})
+
+	t.Run("reports failed changes", func(t *testing.T) {
+		uc, channels, _, pairs := newConfigBundleFixture()
+		pairs.createErr = errors.New("channel C1 is already paired")
+
+		plan, err := uc.Apply(bundle, model.ConfigApplyOptions{Prune: true})
+		require.NoError(t, err)
+		assert.Equal(t, 1, plan.Failed())
+		assert.Equal(t, "ja", channels.configs["C1"].TargetLanguage)
+	})
+}
+
+func TestConfigBundleUseCase_Workspaces(t *testing.T) {
+	temperature := 0.7
+	newFixture := func() (*ConfigBundleUseCase, *fakeWorkspaceService) {
+		uc, _, _, _ := newConfigBundleFixture()
+		workspaces := &fakeWorkspaceService{workspaces: map[string]*model.Workspace{
+			"T1": {TeamID: "T1", TeamName: "Acme", BotToken: "xoxb-1", MonthlyBudget: 50},
+		}}
+		uc.SetWorkspaces(workspaces)
+		return uc, workspaces
+	}
+
+	t.Run("settings round-trip", func(t *testing.T) {
+		uc, workspaces := newFixture()
+		workspaces.workspaces["T1"].Generation = model.GenerationSettings{Temperature: &temperature}
+
+		bundle, err := uc.Export()
+		require.NoError(t, err)
+		require.Len(t, bundle.Workspaces, 1)
+		assert.Equal(t, 50.0, bundle.Workspaces[0].MonthlyBudget)
+		assert.Equal(t, &temperature, bundle.Workspaces[0].Generation.Temperature)
+
+		plan, err := uc.Apply(bundle, model.ConfigApplyOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, plan.Changes)
+		assert.Empty(t, plan.Warnings)
+	})
+
+	t.Run("updates installed workspaces", func(t *testing.T) {
+		uc, workspaces := newFixture()
+		bundle := &model.ConfigBundle{Version: model.ConfigBundleVersion, Workspaces: []model.BundleWorkspace{
+			{TeamID: "T1", TeamName: "Acme", Generation: model.GenerationSettings{Temperature: &temperature}, MonthlyBudget: 80},
+		}}
+
+		plan, err := uc.Apply(bundle, model.ConfigApplyOptions{})
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, 1)
+		assert.Equal(t, model.ConfigResourceWorkspace, plan.Changes[0].Resource)
+		assert.Equal(t, model.BulkStatusApplied, plan.Changes[0].Status)
+		assert.Equal(t, 80.0, workspaces.workspaces["T1"].MonthlyBudget)
+		assert.Equal(t, &temperature, workspaces.workspaces["T1"].Generation.Temperature)
+	})
+
+	t.Run("warns about uninstalled workspaces", func(t *testing.T) {
+		uc, _ := newFixture()
+		bundle := &model.ConfigBundle{Version: model.ConfigBundleVersion, Workspaces: []model.BundleWorkspace{
+			{TeamID: "T1", TeamName: "Acme", MonthlyBudget: 50},
+			{TeamID: "T2", TeamName: "Globex", MonthlyBudget: 20},
+		}}
+
+		plan, err := uc.Apply(bundle, model.ConfigApplyOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, plan.Changes)
+		assert.Equal(t, []string{"workspace T2 (Globex) is not installed; install the app through /slack/install"}, plan.Warnings)
+	})
+}
+
+func TestConfigBundleUseCase_ApplyRejectsInvalidBundle(t *testing.T) {
+	tests := []struct {
+		name   string
+		bundle *model.ConfigBundle
+	}{
+		{
+			name:   "unsupported version",
+			bundle: &model.ConfigBundle{Version: 99},
+		},
+		{
+			name: "invalid channel",
+			bundle: &model.ConfigBundle{Version: model.ConfigBundleVersion, Channels: []model.BundleChannel{
+				{ChannelID: "C1", TargetLanguage: "xx"},
+			}},
+		},
+		{
+			name: "duplicate channel",
+			bundle: &model.ConfigBundle{Version: model.ConfigBundleVersion, Channels: []model.BundleChannel{
+				{ChannelID: "C1", TargetLanguage: "en"},
+				{ChannelID: "C1", TargetLanguage: "vi"},
+			}},
+		},
+		{
+			name: "negative workspace budget",
+			bundle: &model.ConfigBundle{Version: model.ConfigBundleVersion, Workspaces: []model.BundleWorkspace{
+				{TeamID: "T1", MonthlyBudget: -1},
+			}},
+		},
+		{
+			name: "channel in two pairs",
+			bundle: &model.ConfigBundle{Version: model.ConfigBundleVersion, ChannelPairs: []model.BundleChannelPair{
+				{ChannelID: "C1", PairedChannelID: "C2"},
+				{ChannelID: "C3", PairedChannelID: "C1"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, channels, _, _ := newConfigBundleFixture()
+
+			_, err := uc.Apply(tt.bundle, model.ConfigApplyOptions{})
+			var domainErr *model.DomainError
+			require.ErrorAs(t, err, &domainErr)
+			assert.Equal(t, model.ErrorTypeValidation, domainErr.Type)
+			assert.Len(t, channels.configs, 2)
+		})
+	}
+}
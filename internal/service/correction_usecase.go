@@ -0,0 +1,197 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+// CorrectionRepository defines the interface for reviewer correction persistence.
+// This interface is owned by the CorrectionUseCase and defined where it's consumed.
+type CorrectionRepository interface {
+	Save(correction *model.TranslationCorrection) error
+	Find(query model.CorrectionQuery) ([]*model.TranslationCorrection, error)
+}
+
+const (
+	defaultCorrectionMinCount = 2
+	defaultCorrectionLimit    = 500
+	maxCorrectionLimit        = 5000
+	// maxGlossaryTermWords keeps rephrased sentences out of the glossary suggestions
+	maxGlossaryTermWords = 4
+	// maxDiffWords bounds the word diff of a single correction
+	maxDiffWords = 300
+)
+
+var _ CorrectionService = (*CorrectionUseCase)(nil)
+
+// CorrectionUseCase stores the corrections reviewers make before approving a
+// translation and turns them into glossary and translation memory suggestions
+type CorrectionUseCase struct {
+	repo CorrectionRepository
+}
+
+func NewCorrectionUseCase(repo CorrectionRepository) *CorrectionUseCase {
+	return &CorrectionUseCase{repo: repo}
+}
+
+func (cu *CorrectionUseCase) Record(correction *model.TranslationCorrection) error {
+	if correction.ID == "" {
+		correction.ID = generateID()
+	}
+	correction.CreatedAt = time.Now()
+
+	if err := cu.repo.Save(correction); err != nil {
+		return fmt.Errorf("failed to record translation correction: %w", err)
+	}
+	return nil
+}
+
+// Suggestions mines the most recent corrections. A glossary suggestion is a
+// term replaced the same way at least MinCount times; a translation memory
+// entry is the latest approved translation of a source text.
+func (cu *CorrectionUseCase) Suggestions(query model.CorrectionQuery) (*model.CorrectionSuggestions, error) {
+	if query.MinCount <= 0 {
+		query.MinCount = defaultCorrectionMinCount
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultCorrectionLimit
+	}
+	if query.Limit > maxCorrectionLimit {
+		query.Limit = maxCorrectionLimit
+	}
+
+	corrections, err := cu.repo.Find(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation corrections: %w", err)
+	}
+
+	return &model.CorrectionSuggestions{
+		Corrections:       len(corrections),
+		Glossary:          glossarySuggestions(corrections, query.MinCount),
+		TranslationMemory: translationMemory(corrections),
+	}, nil
+}
+
+func glossarySuggestions(corrections []*model.TranslationCorrection, minCount int) []model.GlossarySuggestion {
+	counts := make(map[model.GlossarySuggestion]int)
+	for _, c := range corrections {
+		for _, pair := range replacedTerms(c.MachineText, c.CorrectedText) {
+			key := model.GlossarySuggestion{
+				SourceLanguage: c.SourceLanguage,
+				TargetLanguage: c.TargetLanguage,
+				MachineTerm:    pair[0],
+				CorrectedTerm:  pair[1],
+			}
+			counts[key]++
+		}
+	}
+
+	suggestions := []model.GlossarySuggestion{}
+	for suggestion, count := range counts {
+		if count < minCount {
+			continue
+		}
+		suggestion.Count = count
+		suggestions = append(suggestions, suggestion)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Count != suggestions[j].Count {
+			return suggestions[i].Count > suggestions[j].Count
+		}
+		return suggestions[i].MachineTerm < suggestions[j].MachineTerm
+	})
+	return suggestions
+}
+
+// translationMemory expects corrections newest first
+func translationMemory(corrections []*model.TranslationCorrection) []model.TranslationMemoryEntry {
+	index := make(map[string]int)
+	entries := []model.TranslationMemoryEntry{}
+	for _, c := range corrections {
+		source := strings.TrimSpace(c.SourceText)
+		key := c.SourceLanguage + "\x00" + c.TargetLanguage + "\x00" + source
+		if i, ok := index[key]; ok {
+			entries[i].Corrections++
+			continue
+		}
+		index[key] = len(entries)
+		entries = append(entries, model.TranslationMemoryEntry{
+			SourceLanguage:  c.SourceLanguage,
+			TargetLanguage:  c.TargetLanguage,
+			SourceText:      source,
+			CorrectedText:   c.CorrectedText,
+			Corrections:     1,
+			LastCorrectedAt: c.CreatedAt,
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Corrections > entries[j].Corrections
+	})
+	return entries
+}
+
+// replacedTerms diffs two texts word by word and returns the short runs of
+// words that were replaced, as lower-cased [machine, corrected] pairs
+func replacedTerms(machine, corrected string) [][2]string {
+	a, b := strings.Fields(machine), strings.Fields(corrected)
+	if len(a) == 0 || len(b) == 0 || len(a) > maxDiffWords || len(b) > maxDiffWords {
+		return nil
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if normalizeTerm(a[i]) == normalizeTerm(b[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var pairs [][2]string
+	var removed, added []string
+	flush := func() {
+		if len(removed) > 0 && len(added) > 0 && len(removed) <= maxGlossaryTermWords && len(added) <= maxGlossaryTermWords {
+			from := normalizeTerm(strings.Join(removed, " "))
+			to := normalizeTerm(strings.Join(added, " "))
+			if from != "" && to != "" && from != to {
+				pairs = append(pairs, [2]string{from, to})
+			}
+		}
+		removed, added = nil, nil
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && normalizeTerm(a[i]) == normalizeTerm(b[j]):
+			flush()
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			removed = append(removed, a[i])
+			i++
+		default:
+			added = append(added, b[j])
+			j++
+		}
+	}
+	flush()
+
+	return pairs
+}
+
+func normalizeTerm(term string) string {
+	return strings.ToLower(strings.Trim(term, ".,!?;:\"'()[]"))
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplacedTerms(t *testing.T) {
+	tests := []struct {
+		name      string
+		machine   string
+		corrected string
+		expected  [][2]string
+	}{
+		{
+			name:      "single word replaced",
+			machine:   "Please contact the customer today.",
+			corrected: "Please contact the partner today.",
+			expected:  [][2]string{{"customer", "partner"}},
+		},
+		{
+			name:      "multi-word term replaced",
+			machine:   "Tôi sẽ gửi báo cáo cho khách hàng",
+			corrected: "Tôi sẽ gửi báo cáo cho đối tác",
+			expected:  [][2]string{{"khách hàng", "đối tác"}},
+		},
+		{
+			name:      "punctuation and case only",
+			machine:   "hello world",
+			corrected: "Hello world!",
+			expected:  nil,
+		},
+		{
+			name:      "rephrased sentence is not a term",
+			machine:   "the deployment will happen on the next business day",
+			corrected: "we are shipping it tomorrow morning after standup",
+			expected:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, replacedTerms(tt.machine, tt.corrected))
+		})
+	}
+}
+
+func TestCorrectionUseCase_Suggestions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	corrections := []*model.TranslationCorrection{
+		{SourceLanguage: "English", TargetLanguage: "Vietnamese", SourceText: "Call the customer", MachineText: "Gọi cho khách hàng", CorrectedText: "Gọi cho đối tác", CreatedAt: now},
+		{SourceLanguage: "English", TargetLanguage: "Vietnamese", SourceText: "Email the customer", MachineText: "Gửi email cho khách hàng", CorrectedText: "Gửi email cho đối tác", CreatedAt: now.Add(-time.Hour)},
+		{SourceLanguage: "English", TargetLanguage: "Vietnamese", SourceText: "Call the customer", MachineText: "Gọi cho khách hàng", CorrectedText: "Gọi điện cho khách hàng", CreatedAt: now.Add(-2 * time.Hour)},
+	}
+
+	repo := mocks.NewMockCorrectionRepository(ctrl)
+	repo.EXPECT().Find(model.CorrectionQuery{ChannelID: "C1", MinCount: defaultCorrectionMinCount, Limit: defaultCorrectionLimit}).Return(corrections, nil)
+
+	suggestions, err := NewCorrectionUseCase(repo).Suggestions(model.CorrectionQuery{ChannelID: "C1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, suggestions.Corrections)
+	assert.Equal(t, []model.GlossarySuggestion{{
+		SourceLanguage: "English",
+		TargetLanguage: "Vietnamese",
+		MachineTerm:    "khách hàng",
+		CorrectedTerm:  "đối tác",
+		Count:          2,
+	}}, suggestions.Glossary)
+
+	require.Len(t, suggestions.TranslationMemory, 2)
+	assert.Equal(t, "Call the customer", suggestions.TranslationMemory[0].SourceText)
+	assert.Equal(t, "Gọi cho đối tác", suggestions.TranslationMemory[0].CorrectedText, "latest correction wins")
+	assert.Equal(t, 2, suggestions.TranslationMemory[0].Corrections)
+}
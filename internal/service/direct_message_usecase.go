@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+// languagePrefixPattern matches a to:<lang> prefix choosing the language a
+// direct message is translated to, e.g. "to:ja" or "to: japanese"
+var languagePrefixPattern = regexp.MustCompile(`(?i)^to:\s*([\p{L}-]+)\s*`)
+
+var _ DirectMessageService = (*DirectMessageUseCase)(nil)
+
+// DirectMessageUseCase answers direct messages to the bot as an assistant:
+// it translates whatever the user sends, into the language of a to:<lang>
+// prefix, which sticks for the next messages, or else the user's preferred
+// language or the language pairs. The last few exchanges are kept in the
+// cache, so "to:<lang>" alone translates the previous message again.
+type DirectMessageUseCase struct {
+	translations TranslationService
+	cache        Cache
+	contextTTL   time.Duration
+	preferences  UserPreferenceService
+	languages    *LanguageRouter
+	logger       *zap.Logger
+}
+
+func NewDirectMessageUseCase(translations TranslationService, cache Cache, contextTTL time.Duration, logger *zap.Logger) *DirectMessageUseCase {
+	return &DirectMessageUseCase{
+		translations: translations,
+		cache:        cache,
+		contextTTL:   contextTTL,
+		languages:    DefaultLanguageRouter(),
+		logger:       logger,
+	}
+}
+
+// SetUserPreferences translates direct messages to the user's preferred
+// language when they did not pick one with a prefix
+func (du *DirectMessageUseCase) SetUserPreferences(preferences UserPreferenceService) {
+	du.preferences = preferences
+}
+
+// SetLanguageRouter translates direct messages along the configured language
+// pairs when neither a prefix nor a preference picks the language
+func (du *DirectMessageUseCase) SetLanguageRouter(languages *LanguageRouter) {
+	du.languages = languages
+}
+
+// Reply translates a direct message userID sent in channelID
+func (du *DirectMessageUseCase) Reply(ctx context.Context, channelID, userID, text string) (response.DirectMessage, error) {
+	conversation := du.conversation(ctx, userID)
+
+	code, text, hasPrefix := parseLanguagePrefix(text)
+	if hasPrefix {
+		if !model.IsSupportedLanguageCode(code) {
+			return response.DirectMessage{}, model.NewValidationError(fmt.Sprintf(
+				"I don't know the language %q. Try one of: %s", code, strings.Join(model.SupportedLanguageCodes(), ", ")))
+		}
+		conversation.TargetLanguage = model.CanonicalLanguageCode(code)
+	}
+
+	if text == "" {
+		// "to:<lang>" alone translates the previous message again, or picks
+		// the language of the next ones
+		last, ok := conversation.LastTurn()
+		if !ok {
+			du.saveConversation(ctx, conversation)
+			name, _ := model.LanguageName(conversation.TargetLanguage)
+			return response.DirectMessage{Notice: fmt.Sprintf("Got it, I'll translate your next messages into %s.", name)}, nil
+		}
+		text = last.Text
+	}
+
+	sourceLanguage, err := du.translations.DetectLanguage(text)
+	if err != nil {
+		return response.DirectMessage{}, fmt.Errorf("failed to detect language: %w", err)
+	}
+	targetLanguage, ok := du.targetLanguage(userID, conversation, sourceLanguage)
+	if !ok {
+		du.saveConversation(ctx, conversation)
+		return response.DirectMessage{Notice: fmt.Sprintf(
+			"This is already in %s. Start your message with to:<language>, e.g. to:ja, to translate it into another language.", sourceLanguage)}, nil
+	}
+
+	result, err := du.translations.TranslateContext(ctx, request.Translation{
+		Text:           text,
+		SourceLanguage: sourceLanguage,
+		TargetLanguage: targetLanguage,
+		UserID:         userID,
+		ChannelID:      channelID,
+	})
+	if err != nil {
+		return response.DirectMessage{}, err
+	}
+
+	conversation.AddTurn(model.ConversationTurn{
+		Text:           text,
+		SourceLanguage: sourceLanguage,
+		TargetLanguage: targetLanguage,
+		TranslatedText: result.TranslatedText,
+		CreatedAt:      time.Now(),
+	})
+	du.saveConversation(ctx, conversation)
+	return response.DirectMessage{Translation: &result}, nil
+}
+
+// targetLanguage returns the name of the language a message detected as
+// sourceLanguage is translated to, or false when it is already in it
+func (du *DirectMessageUseCase) targetLanguage(userID string, conversation *model.Conversation, sourceLanguage string) (string, bool) {
+	code := conversation.TargetLanguage
+	if code == "" && du.preferences != nil {
+		if preference, err := du.preferences.GetPreference(userID); err == nil {
+			code = preference.PreferredLanguage
+		}
+	}
+
+	var target string
+	if code != "" {
+		target, _ = model.LanguageName(code)
+	} else if routed, ok := du.languages.Route(nil, sourceLanguage); ok {
+		target = routed
+	} else {
+		target, _ = model.LanguageName("en")
+	}
+	return target, !strings.EqualFold(target, sourceLanguage)
+}
+
+// conversation returns the user's conversation, or a new one when it
+// expired or cannot be loaded
+func (du *DirectMessageUseCase) conversation(ctx context.Context, userID string) *model.Conversation {
+	conversation := &model.Conversation{UserID: userID}
+	cached, err := du.cache.Get(ctx, conversationKey(userID))
+	if err != nil || cached == "" {
+		return conversation
+	}
+	if err := json.Unmarshal([]byte(cached), conversation); err != nil {
+		du.logger.Warn("Failed to decode conversation, starting a new one",
+			zap.Error(err),
+			zap.String("user_id", userID))
+		return &model.Conversation{UserID: userID}
+	}
+	return conversation
+}
+
+func (du *DirectMessageUseCase) saveConversation(ctx context.Context, conversation *model.Conversation) {
+	encoded, err := json.Marshal(conversation)
+	if err != nil {
+		return
+	}
+	if err := du.cache.Set(ctx, conversationKey(conversation.UserID), string(encoded), int64(du.contextTTL.Seconds())); err != nil {
+		du.logger.Warn("Failed to save conversation",
+			zap.Error(err),
+			zap.String("user_id", conversation.UserID))
+	}
+}
+
+func conversationKey(userID string) string {
+	return fmt.Sprintf("dm_conversation:%s", userID)
+}
+
+// parseLanguagePrefix splits a to:<lang> prefix off text, returning the
+// language, by code or name, and the rest of the text
+func parseLanguagePrefix(text string) (string, string, bool) {
+	text = strings.TrimSpace(text)
+	match := languagePrefixPattern.FindStringSubmatchIndex(text)
+	if match == nil {
+		return "", text, false
+	}
+	language := text[match[2]:match[3]]
+	if code, ok := model.LanguageCode(language); ok {
+		language = code
+	}
+	return language, strings.TrimSpace(text[match[1]:]), true
+}
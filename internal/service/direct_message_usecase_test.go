@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeCache keeps values in memory, ignoring their TTL
+type fakeCache map[string]string
+
+func (f fakeCache) Get(_ context.Context, key string) (string, error) {
+	return f[key], nil
+}
+
+func (f fakeCache) Set(_ context.Context, key string, value string, _ int64) error {
+	f[key] = value
+	return nil
+}
+
+func (f fakeCache) Delete(_ context.Context, key string) error {
+	delete(f, key)
+	return nil
+}
+
+func (f fakeCache) Exists(_ context.Context, key string) (bool, error) {
+	_, ok := f[key]
+	return ok, nil
+}
+
+func newTestDirectMessageUseCase(translations *fakeTranslationService) *DirectMessageUseCase {
+	return NewDirectMessageUseCase(translations, fakeCache{}, 30*time.Minute, zap.NewNop())
+}
+
+func TestDirectMessageUseCase_Reply(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("translates along the language pairs", func(t *testing.T) {
+		translations := &fakeTranslationService{detected: "English", translated: "Xin chào"}
+		useCase := newTestDirectMessageUseCase(translations)
+
+		reply, err := useCase.Reply(ctx, "D1", "U1", "Hello")
+		require.NoError(t, err)
+		require.NotNil(t, reply.Translation)
+		assert.Equal(t, "Xin chào", reply.Translation.TranslatedText)
+		assert.Equal(t, "Vietnamese", translations.requests[0].TargetLanguage)
+		assert.Equal(t, "D1", translations.requests[0].ChannelID)
+	})
+
+	t.Run("prefix picks the language for the next messages", func(t *testing.T) {
+		translations := &fakeTranslationService{detected: "English", translated: "こんにちは"}
+		useCase := newTestDirectMessageUseCase(translations)
+
+		_, err := useCase.Reply(ctx, "D1", "U1", "to:ja Hello")
+		require.NoError(t, err)
+		_, err = useCase.Reply(ctx, "D1", "U1", "Good morning")
+		require.NoError(t, err)
+
+		require.Len(t, translations.requests, 2)
+		assert.Equal(t, "Hello", translations.requests[0].Text)
+		assert.Equal(t, "Japanese", translations.requests[0].TargetLanguage)
+		assert.Equal(t, "Japanese", translations.requests[1].TargetLanguage)
+	})
+
+	t.Run("prefix alone translates the previous message again", func(t *testing.T) {
+		translations := &fakeTranslationService{detected: "English", translated: "Bonjour"}
+		useCase := newTestDirectMessageUseCase(translations)
+
+		_, err := useCase.Reply(ctx, "D1", "U1", "Hello")
+		require.NoError(t, err)
+		reply, err := useCase.Reply(ctx, "D1", "U1", "to: French")
+		require.NoError(t, err)
+
+		require.NotNil(t, reply.Translation)
+		require.Len(t, translations.requests, 2)
+		assert.Equal(t, "Hello", translations.requests[1].Text)
+		assert.Equal(t, "French", translations.requests[1].TargetLanguage)
+	})
+
+	t.Run("prefix alone without a previous message", func(t *testing.T) {
+		translations := &fakeTranslationService{}
+		useCase := newTestDirectMessageUseCase(translations)
+
+		reply, err := useCase.Reply(ctx, "D1", "U1", "TO:ko")
+		require.NoError(t, err)
+		assert.Nil(t, reply.Translation)
+		assert.Contains(t, reply.Notice, "Korean")
+		assert.Empty(t, translations.requests)
+	})
+
+	t.Run("unknown language", func(t *testing.T) {
+		useCase := newTestDirectMessageUseCase(&fakeTranslationService{})
+
+		_, err := useCase.Reply(ctx, "D1", "U1", "to:klingon Hello")
+		var domainErr *model.DomainError
+		require.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, model.ErrorTypeValidation, domainErr.Type)
+	})
+
+	t.Run("already in the chosen language", func(t *testing.T) {
+		translations := &fakeTranslationService{detected: "Japanese"}
+		useCase := newTestDirectMessageUseCase(translations)
+
+		reply, err := useCase.Reply(ctx, "D1", "U1", "to:ja こんにちは")
+		require.NoError(t, err)
+		assert.Contains(t, reply.Notice, "already in Japanese")
+		assert.Empty(t, translations.requests)
+	})
+}
+
+func TestParseLanguagePrefix(t *testing.T) {
+	tests := []struct {
+		text     string
+		language string
+		rest     string
+		ok       bool
+	}{
+		{text: "to:ja Hello", language: "ja", rest: "Hello", ok: true},
+		{text: "  To: Korean  see you", language: "ko", rest: "see you", ok: true},
+		{text: "to:zh-TW", language: "zh-Hant", rest: "", ok: true},
+		{text: "Talk to:ja later", rest: "Talk to:ja later"},
+		{text: "Hello", rest: "Hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			language, rest, ok := parseLanguagePrefix(tt.text)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.language, language)
+			assert.Equal(t, tt.rest, rest)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+)
+
+const (
+	defaultAPIURL = "https://discord.com/api/v10"
+	cdnURL        = "https://cdn.discordapp.com"
+	userAgent     = "DiscordBot (https://github.com/ntttrang/go-genai-slack-assistant, 1.0)"
+	// maxContentLength is the longest message content Discord accepts
+	maxContentLength = 2000
+)
+
+// reactionEmoji maps the emoji names used by the translation core to unicode
+var reactionEmoji = map[string]string{
+	"eyes": "👀",
+}
+
+var _ service.ChatPlatform = (*Client)(nil)
+
+// Client calls the Discord REST API as the bot
+type Client struct {
+	token      string
+	apiURL     string
+	httpClient *http.Client
+}
+
+func NewClient(token string) *Client {
+	return &Client{
+		token:      token,
+		apiURL:     defaultAPIURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// PostReply replies to reply.ThreadID. Bots cannot change their name per
+// message, so the username is shown in bold above the text. Text longer than
+// Discord allows is posted as several replies.
+func (c *Client) PostReply(ctx context.Context, reply model.ChatReply) error {
+	text := reply.Text
+	if reply.AsQuote {
+		text = "> " + strings.ReplaceAll(text, "\n", "\n> ")
+	}
+	if reply.Username != "" {
+		text = fmt.Sprintf("**%s**\n%s", reply.Username, text)
+	}
+
+	for _, content := range splitContent(text, maxContentLength) {
+		body := map[string]interface{}{
+			"content": content,
+			// Never ping anyone mentioned in the translated text
+			"allowed_mentions": map[string]interface{}{"parse": []string{}},
+		}
+		if reply.ThreadID != "" {
+			body["message_reference"] = map[string]interface{}{
+				"message_id":         reply.ThreadID,
+				"fail_if_not_exists": false,
+			}
+		}
+		path := fmt.Sprintf("/channels/%s/messages", url.PathEscape(reply.ChannelID))
+		if err := c.do(ctx, http.MethodPost, path, body, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) AddReaction(ctx context.Context, channelID, messageID, emoji string) error {
+	if char, ok := reactionEmoji[emoji]; ok {
+		emoji = char
+	}
+	path := fmt.Sprintf("/channels/%s/messages/%s/reactions/%s/@me",
+		url.PathEscape(channelID), url.PathEscape(messageID), url.PathEscape(emoji))
+	return c.do(ctx, http.MethodPut, path, nil, nil)
+}
+
+func (c *Client) GetUser(ctx context.Context, _ string, userID string) (*model.ChatUser, error) {
+	var user User
+	if err := c.do(ctx, http.MethodGet, "/users/"+url.PathEscape(userID), nil, &user); err != nil {
+		return nil, err
+	}
+	avatarURL := ""
+	if user.Avatar != "" {
+		avatarURL = fmt.Sprintf("%s/avatars/%s/%s.png", cdnURL, user.ID, user.Avatar)
+	}
+	return &model.ChatUser{
+		ID:          user.ID,
+		Name:        user.Username,
+		DisplayName: user.GlobalName,
+		AvatarURL:   avatarURL,
+	}, nil
+}
+
+// do sends an authenticated REST request and decodes the response into out
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode discord request: %w", err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create discord request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bot "+c.token)
+	req.Header.Set("User-Agent", userAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("discord request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("discord request %s %s failed with status %d: %s", method, path, resp.StatusCode, message)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode discord response: %w", err)
+	}
+	return nil
+}
+
+// splitContent splits text into parts of at most limit characters,
+// preferring to break at a newline
+func splitContent(text string, limit int) []string {
+	var parts []string
+	for utf8.RuneCountInString(text) > limit {
+		runes := []rune(text)
+		cut := limit
+		if i := strings.LastIndex(string(runes[:limit]), "\n"); i > 0 {
+			cut = utf8.RuneCountInString(string(runes[:limit])[:i])
+		}
+		parts = append(parts, string(runes[:cut]))
+		text = strings.TrimLeft(string(runes[cut:]), "\n")
+	}
+	return append(parts, text)
+}
@@ -0,0 +1,93 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	var requests []string
+	var posted []map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bot bot-token", r.Header.Get("Authorization"))
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		switch {
+		case r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(User{ID: "42", Username: "alice", GlobalName: "Alice", Avatar: "abc"})
+		case r.Method == http.MethodPost:
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			posted = append(posted, body)
+			_, _ = w.Write([]byte(`{"id":"reply"}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("bot-token")
+	client.apiURL = server.URL
+
+	user, err := client.GetUser(context.Background(), "c1", "42")
+	require.NoError(t, err)
+	assert.Equal(t, &model.ChatUser{ID: "42", Name: "alice", DisplayName: "Alice", AvatarURL: "https://cdn.discordapp.com/avatars/42/abc.png"}, user)
+
+	require.NoError(t, client.AddReaction(context.Background(), "c1", "m1", "eyes"))
+
+	err = client.PostReply(context.Background(), model.ChatReply{ChannelID: "c1", ThreadID: "m1", Text: "Xin chào", Username: "Alice (Bot) 🇻🇳"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"GET /users/42",
+		"PUT /channels/c1/messages/m1/reactions/%F0%9F%91%80/@me",
+		"POST /channels/c1/messages",
+	}, requests)
+	require.Len(t, posted, 1)
+	assert.Equal(t, "**Alice (Bot) 🇻🇳**\nXin chào", posted[0]["content"])
+	assert.Equal(t, map[string]interface{}{"message_id": "m1", "fail_if_not_exists": false}, posted[0]["message_reference"])
+	assert.Equal(t, map[string]interface{}{"parse": []interface{}{}}, posted[0]["allowed_mentions"])
+}
+
+func TestClient_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Missing Permissions"}`, http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewClient("bot-token")
+	client.apiURL = server.URL
+
+	err := client.PostReply(context.Background(), model.ChatReply{ChannelID: "c1", Text: "hi"})
+
+	assert.ErrorContains(t, err, "status 403")
+}
+
+func TestSplitContent(t *testing.T) {
+	long := strings.Repeat("a", 15) + "\n" + strings.Repeat("b", 10)
+
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  []string
+	}{
+		{name: "short", text: "hello", limit: 20, want: []string{"hello"}},
+		{name: "breaks at newline", text: long, limit: 20, want: []string{strings.Repeat("a", 15), strings.Repeat("b", 10)}},
+		{name: "hard break", text: "ááááá", limit: 2, want: []string{"áá", "áá", "á"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, splitContent(tt.text, tt.limit))
+		})
+	}
+}
@@ -0,0 +1,355 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultGatewayURL = "wss://gateway.discord.gg"
+	gatewayQuery      = "?v=10&encoding=json"
+
+	// Gateway intents: guild and direct messages, including their content
+	intentGuildMessages  = 1 << 9
+	intentDirectMessages = 1 << 12
+	intentMessageContent = 1 << 15
+	defaultIntents       = intentGuildMessages | intentDirectMessages | intentMessageContent
+
+	maxReconnectBackoff = 2 * time.Minute
+)
+
+// Gateway opcodes
+const (
+	opDispatch       = 0
+	opHeartbeat      = 1
+	opIdentify       = 2
+	opResume         = 6
+	opReconnect      = 7
+	opInvalidSession = 9
+	opHello          = 10
+	opHeartbeatACK   = 11
+)
+
+// PayloadMessageKey is the queued event payload key holding the *Message
+const PayloadMessageKey = "message"
+
+// EventQueue receives the messages read from the gateway for ordered processing
+type EventQueue interface {
+	Enqueue(event *model.MessageEvent)
+}
+
+// Gateway keeps a websocket session with the Discord gateway open and queues
+// every message the bot can read
+type Gateway struct {
+	token      string
+	intents    int
+	gatewayURL string
+	queue      EventQueue
+	logger     *zap.Logger
+	seqCounter uint64
+
+	// Session state, kept across reconnects so the session can be resumed
+	sessionID string
+	resumeURL string
+	botUserID string
+	seq       atomic.Int64
+}
+
+func NewGateway(token string, queue EventQueue, logger *zap.Logger) *Gateway {
+	return &Gateway{
+		token:      token,
+		intents:    defaultIntents,
+		gatewayURL: defaultGatewayURL,
+		queue:      queue,
+		logger:     logger,
+	}
+}
+
+type gatewayPayload struct {
+	Op int             `json:"op"`
+	D  json.RawMessage `json:"d,omitempty"`
+	S  *int64          `json:"s,omitempty"`
+	T  string          `json:"t,omitempty"`
+}
+
+type outgoingPayload struct {
+	Op int         `json:"op"`
+	D  interface{} `json:"d"`
+}
+
+// fatalError is a gateway close that reconnecting cannot fix, such as a bad token
+type fatalError struct {
+	code int
+	text string
+}
+
+func (e *fatalError) Error() string {
+	return fmt.Sprintf("discord gateway closed the connection: %d %s", e.code, e.text)
+}
+
+// Close codes after which Discord does not accept a new session either
+var fatalCloseCodes = map[int]bool{
+	4004: true, // authentication failed
+	4010: true, // invalid shard
+	4011: true, // sharding required
+	4012: true, // invalid API version
+	4013: true, // invalid intents
+	4014: true, // disallowed intents: enable the message content intent for the bot
+}
+
+// Run connects to the gateway and reconnects, resuming the session when
+// possible, until ctx is done. It returns an error only when Discord rejects
+// the bot for good.
+func (g *Gateway) Run(ctx context.Context) error {
+	backoff := time.Second
+	for {
+		ready, err := g.connect(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		var fatal *fatalError
+		if errors.As(err, &fatal) {
+			return err
+		}
+		if ready {
+			backoff = time.Second
+		}
+
+		g.logger.Warn("Discord gateway disconnected, reconnecting",
+			zap.Error(err),
+			zap.Duration("backoff", backoff))
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxReconnectBackoff)
+	}
+}
+
+// connect runs one gateway connection and reports whether it got as far as a
+// ready or resumed session
+func (g *Gateway) connect(ctx context.Context) (bool, error) {
+	resuming := g.sessionID != "" && g.resumeURL != ""
+	endpoint := g.gatewayURL
+	if resuming {
+		endpoint = g.resumeURL
+	}
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, endpoint+gatewayQuery, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to connect to discord gateway: %w", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	var writeMu sync.Mutex
+	write := func(op int, d interface{}) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		return conn.WriteJSON(outgoingPayload{Op: op, D: d})
+	}
+
+	// Closing the connection unblocks the read loop when ctx is done
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			writeMu.Lock()
+			_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			writeMu.Unlock()
+			_ = conn.Close()
+		case <-done:
+		}
+	}()
+
+	var hello gatewayPayload
+	if err := conn.ReadJSON(&hello); err != nil {
+		return false, fmt.Errorf("failed to read discord gateway hello: %w", err)
+	}
+	if hello.Op != opHello {
+		return false, fmt.Errorf("expected discord gateway hello, got opcode %d", hello.Op)
+	}
+	var helloData struct {
+		HeartbeatInterval int64 `json:"heartbeat_interval"`
+	}
+	if err := json.Unmarshal(hello.D, &helloData); err != nil || helloData.HeartbeatInterval <= 0 {
+		return false, fmt.Errorf("invalid discord gateway hello: %s", hello.D)
+	}
+
+	if resuming {
+		err = write(opResume, map[string]interface{}{
+			"token":      g.token,
+			"session_id": g.sessionID,
+			"seq":        g.seq.Load(),
+		})
+	} else {
+		err = write(opIdentify, map[string]interface{}{
+			"token":   g.token,
+			"intents": g.intents,
+			"properties": map[string]string{
+				"os":      "linux",
+				"browser": "go-genai-slack-assistant",
+				"device":  "go-genai-slack-assistant",
+			},
+		})
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to send discord gateway handshake: %w", err)
+	}
+
+	var acked atomic.Bool
+	acked.Store(true)
+	go g.heartbeat(conn, time.Duration(helloData.HeartbeatInterval)*time.Millisecond, &acked, write, done)
+
+	ready := false
+	for {
+		var payload gatewayPayload
+		if err := conn.ReadJSON(&payload); err != nil {
+			return ready, g.closeError(err)
+		}
+		if payload.S != nil {
+			g.seq.Store(*payload.S)
+		}
+
+		switch payload.Op {
+		case opDispatch:
+			if g.dispatch(payload) {
+				ready = true
+			}
+		case opHeartbeat:
+			if err := write(opHeartbeat, g.lastSeq()); err != nil {
+				return ready, fmt.Errorf("failed to send discord heartbeat: %w", err)
+			}
+		case opHeartbeatACK:
+			acked.Store(true)
+		case opReconnect:
+			return ready, errors.New("discord gateway requested a reconnect")
+		case opInvalidSession:
+			var resumable bool
+			_ = json.Unmarshal(payload.D, &resumable)
+			if !resumable {
+				g.resetSession()
+			}
+			return ready, errors.New("discord gateway invalidated the session")
+		}
+	}
+}
+
+// heartbeat sends a heartbeat every interval and drops the connection when
+// the previous one was not acknowledged, as the connection is then dead
+func (g *Gateway) heartbeat(conn *websocket.Conn, interval time.Duration, acked *atomic.Bool, write func(int, interface{}) error, done <-chan struct{}) {
+	// The first heartbeat is jittered so reconnecting bots do not all beat at once
+	timer := time.NewTimer(time.Duration(rand.Int63n(int64(interval))))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-timer.C:
+		}
+		if !acked.Swap(false) {
+			g.logger.Warn("Discord heartbeat not acknowledged, reconnecting")
+			_ = conn.Close()
+			return
+		}
+		if err := write(opHeartbeat, g.lastSeq()); err != nil {
+			_ = conn.Close()
+			return
+		}
+		timer.Reset(interval)
+	}
+}
+
+// dispatch handles a gateway event and reports whether the session is ready
+func (g *Gateway) dispatch(payload gatewayPayload) bool {
+	switch payload.T {
+	case "READY":
+		var ready struct {
+			SessionID        string `json:"session_id"`
+			ResumeGatewayURL string `json:"resume_gateway_url"`
+			User             User   `json:"user"`
+		}
+		if err := json.Unmarshal(payload.D, &ready); err != nil {
+			g.logger.Error("Failed to decode Discord ready event", zap.Error(err))
+			return false
+		}
+		g.sessionID = ready.SessionID
+		g.resumeURL = ready.ResumeGatewayURL
+		g.botUserID = ready.User.ID
+		g.logger.Info("Discord gateway session ready", zap.String("bot_user_id", g.botUserID))
+		return true
+	case "RESUMED":
+		g.logger.Info("Discord gateway session resumed")
+		return true
+	case "MESSAGE_CREATE":
+		var msg Message
+		if err := json.Unmarshal(payload.D, &msg); err != nil {
+			g.logger.Error("Failed to decode Discord message", zap.Error(err))
+			return false
+		}
+		g.enqueue(&msg)
+	}
+	return false
+}
+
+// enqueue queues a message for translation, skipping bots, webhooks and the
+// bot's own replies so translations are never translated again
+func (g *Gateway) enqueue(msg *Message) {
+	if msg.Author.Bot || msg.WebhookID != "" || msg.Author.ID == g.botUserID || msg.Content == "" {
+		return
+	}
+	g.queue.Enqueue(&model.MessageEvent{
+		EventID:    msg.ID,
+		ChannelID:  msg.ChannelID,
+		UserID:     msg.Author.ID,
+		MessageTS:  msg.ID,
+		Payload:    map[string]interface{}{PayloadMessageKey: msg},
+		ReceivedAt: time.Now(),
+		Sequence:   atomic.AddUint64(&g.seqCounter, 1),
+	})
+}
+
+// lastSeq is the sequence number sent with heartbeats, null before the first event
+func (g *Gateway) lastSeq() interface{} {
+	if seq := g.seq.Load(); seq > 0 {
+		return seq
+	}
+	return nil
+}
+
+func (g *Gateway) resetSession() {
+	g.sessionID = ""
+	g.resumeURL = ""
+	g.seq.Store(0)
+}
+
+// closeError turns a read error into a fatalError when Discord closed the
+// connection for good, and starts a new session when it cannot be resumed
+func (g *Gateway) closeError(err error) error {
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) {
+		return fmt.Errorf("discord gateway read failed: %w", err)
+	}
+	if fatalCloseCodes[closeErr.Code] {
+		return &fatalError{code: closeErr.Code, text: closeErr.Text}
+	}
+	// Invalid sequence or session timed out: identify again instead of resuming
+	if closeErr.Code == 4007 || closeErr.Code == 4009 {
+		g.resetSession()
+	}
+	return fmt.Errorf("discord gateway closed the connection: %w", err)
+}
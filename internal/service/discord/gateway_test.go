@@ -0,0 +1,110 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeQueue struct {
+	events chan *model.MessageEvent
+}
+
+func (q *fakeQueue) Enqueue(event *model.MessageEvent) {
+	q.events <- event
+}
+
+func TestGateway_QueuesMessages(t *testing.T) {
+	identified := make(chan map[string]interface{}, 1)
+	upgrader := websocket.Upgrader{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer func() {
+			_ = conn.Close()
+		}()
+
+		require.NoError(t, conn.WriteJSON(map[string]interface{}{"op": opHello, "d": map[string]int{"heartbeat_interval": 45000}}))
+
+		var identify struct {
+			Op int                    `json:"op"`
+			D  map[string]interface{} `json:"d"`
+		}
+		require.NoError(t, conn.ReadJSON(&identify))
+		assert.Equal(t, opIdentify, identify.Op)
+		identified <- identify.D
+
+		events := []map[string]interface{}{
+			{"op": opDispatch, "t": "READY", "s": 1, "d": map[string]interface{}{
+				"session_id": "session-1", "resume_gateway_url": "wss://resume", "user": map[string]string{"id": "bot"},
+			}},
+			{"op": opDispatch, "t": "MESSAGE_CREATE", "s": 2, "d": map[string]interface{}{
+				"id": "m0", "channel_id": "c1", "author": map[string]interface{}{"id": "other-bot", "bot": true}, "content": "Xin chào",
+			}},
+			{"op": opDispatch, "t": "MESSAGE_CREATE", "s": 3, "d": map[string]interface{}{
+				"id": "m1", "channel_id": "c1", "author": map[string]interface{}{"id": "u1"}, "content": "Hello team",
+			}},
+		}
+		for _, event := range events {
+			require.NoError(t, conn.WriteJSON(event))
+		}
+
+		// Keep the connection open until the gateway closes it
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	queue := &fakeQueue{events: make(chan *model.MessageEvent, 2)}
+	gateway := NewGateway("bot-token", queue, zap.NewNop())
+	gateway.gatewayURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() {
+		result <- gateway.Run(ctx)
+	}()
+
+	select {
+	case d := <-identified:
+		assert.Equal(t, "bot-token", d["token"])
+		assert.EqualValues(t, defaultIntents, d["intents"])
+	case <-time.After(5 * time.Second):
+		t.Fatal("gateway did not identify")
+	}
+
+	select {
+	case event := <-queue.events:
+		assert.Equal(t, "c1", event.ChannelID)
+		assert.Equal(t, "u1", event.UserID)
+		msg, ok := event.Payload[PayloadMessageKey].(*Message)
+		require.True(t, ok)
+		assert.Equal(t, "Hello team", msg.Content)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message was not queued")
+	}
+
+	cancel()
+	select {
+	case err := <-result:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("gateway did not stop")
+	}
+	assert.Empty(t, queue.events, "bot messages are not queued")
+	assert.Equal(t, "session-1", gateway.sessionID)
+	assert.EqualValues(t, 3, gateway.seq.Load())
+}
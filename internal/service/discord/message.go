@@ -0,0 +1,20 @@
+package discord
+
+// Message is the subset of a Discord message the bot reads
+type Message struct {
+	ID        string `json:"id"`
+	ChannelID string `json:"channel_id"`
+	GuildID   string `json:"guild_id,omitempty"`
+	Author    User   `json:"author"`
+	Content   string `json:"content"`
+	WebhookID string `json:"webhook_id,omitempty"`
+}
+
+// User is a Discord user or bot account
+type User struct {
+	ID         string `json:"id"`
+	Username   string `json:"username"`
+	GlobalName string `json:"global_name,omitempty"`
+	Avatar     string `json:"avatar,omitempty"`
+	Bot        bool   `json:"bot,omitempty"`
+}
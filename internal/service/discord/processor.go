@@ -0,0 +1,41 @@
+package discord
+
+import (
+	"context"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"go.uber.org/zap"
+)
+
+// Processor translates Discord messages taken from the worker pool with the
+// same translation core as Slack
+type Processor struct {
+	client     *Client
+	translator service.ChatTranslationService
+	logger     *zap.Logger
+}
+
+func NewProcessor(client *Client, translator service.ChatTranslationService, logger *zap.Logger) *Processor {
+	return &Processor{
+		client:     client,
+		translator: translator,
+		logger:     logger,
+	}
+}
+
+func (p *Processor) ProcessEvent(ctx context.Context, payload map[string]interface{}) {
+	msg, ok := payload[PayloadMessageKey].(*Message)
+	if !ok {
+		p.logger.Error("Queued Discord event has no message")
+		return
+	}
+
+	p.translator.HandleMessage(ctx, p.client, model.ChatMessage{
+		ChannelID: msg.ChannelID,
+		ThreadID:  msg.ID,
+		MessageID: msg.ID,
+		UserID:    msg.Author.ID,
+		Text:      msg.Content,
+	})
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/language"
+	"go.uber.org/zap"
+)
+
+// maxExplanationTextRunes bounds the message sent to the AI to be explained
+const maxExplanationTextRunes = 4000
+
+// defaultExplanationLanguage is the code of the language explanations are
+// written in for users without a preferred language
+const defaultExplanationLanguage = "en"
+
+// MessageExplanationRateLimited is shown to users asking for explanations
+// faster than their limit allows
+const MessageExplanationRateLimited = "You're asking for a lot of explanations, please try again in a minute"
+
+// Explainer explains the idioms, slang and cultural references of a message
+// in a language, e.g. ai.ChainedProvider
+type Explainer interface {
+	Explain(ctx context.Context, text, language string) (string, error)
+}
+
+// UserPreferenceLookup returns the preferences a user set on the App Home tab
+type UserPreferenceLookup interface {
+	GetPreference(userID string) (*model.UserPreference, error)
+}
+
+var _ ExplanationService = (*ExplanationUseCase)(nil)
+
+// ExplanationUseCase answers the "Explain this message" shortcut: instead of
+// translating a message, the AI explains what it means, including its
+// idioms, slang and cultural references, in the language of the user who
+// asked. Explanations have their own rate limit, separate from translations.
+type ExplanationUseCase struct {
+	explainer   Explainer
+	preferences UserPreferenceLookup
+	limiter     model.RateLimiter
+	logger      *zap.Logger
+}
+
+func NewExplanationUseCase(explainer Explainer, logger *zap.Logger) *ExplanationUseCase {
+	return &ExplanationUseCase{
+		explainer: explainer,
+		logger:    logger,
+	}
+}
+
+// SetUserPreferences writes explanations in the language users picked on the
+// App Home tab
+func (eu *ExplanationUseCase) SetUserPreferences(preferences UserPreferenceLookup) {
+	eu.preferences = preferences
+}
+
+// SetRateLimiter limits the explanations asked for per user and per channel
+func (eu *ExplanationUseCase) SetRateLimiter(limiter model.RateLimiter) {
+	eu.limiter = limiter
+}
+
+// Explain explains text, a message of channelID, to userID
+func (eu *ExplanationUseCase) Explain(ctx context.Context, userID, channelID, text string) (response.Explanation, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return response.Explanation{}, model.NewValidationError("This message has no text to explain")
+	}
+	if !eu.withinRateLimit(userID, channelID) {
+		return response.Explanation{}, model.NewRateLimitError(MessageExplanationRateLimited)
+	}
+
+	targetLanguage := eu.language(userID)
+	explanation, err := eu.explainer.Explain(ctx, language.Truncate(text, maxExplanationTextRunes), targetLanguage)
+	if err != nil {
+		return response.Explanation{}, fmt.Errorf("failed to explain message: %w", err)
+	}
+
+	eu.logger.Info("Message explained on request",
+		zap.String("channel_id", channelID),
+		zap.String("user_id", userID),
+		zap.String("language", targetLanguage))
+	return response.Explanation{Language: targetLanguage, Text: strings.TrimSpace(explanation)}, nil
+}
+
+// withinRateLimit reports whether an explanation fits the user's and the
+// channel's limits, counting it when it does. Limiter errors let it through.
+func (eu *ExplanationUseCase) withinRateLimit(userID, channelID string) bool {
+	if eu.limiter == nil {
+		return true
+	}
+
+	allowed, _, _, err := eu.limiter.CheckUserLimit(userID)
+	if err != nil {
+		eu.logger.Warn("Failed to check explanation rate limit", zap.Error(err), zap.String("user_id", userID))
+		allowed = true
+	}
+	if allowed {
+		allowed, _, _, err = eu.limiter.CheckChannelLimit(channelID)
+		if err != nil {
+			eu.logger.Warn("Failed to check explanation rate limit", zap.Error(err), zap.String("channel_id", channelID))
+			allowed = true
+		}
+	}
+	if !allowed {
+		eu.logger.Info("Explanation rate limit exceeded",
+			zap.String("channel_id", channelID),
+			zap.String("user_id", userID))
+		return false
+	}
+
+	if err := eu.limiter.IncrementUserLimit(userID); err != nil {
+		eu.logger.Warn("Failed to count explanation for user rate limit", zap.Error(err), zap.String("user_id", userID))
+	}
+	if err := eu.limiter.IncrementChannelLimit(channelID); err != nil {
+		eu.logger.Warn("Failed to count explanation for channel rate limit", zap.Error(err), zap.String("channel_id", channelID))
+	}
+	return true
+}
+
+// language returns the name of the language userID reads explanations in
+func (eu *ExplanationUseCase) language(userID string) string {
+	code := defaultExplanationLanguage
+	if eu.preferences != nil {
+		if preference, err := eu.preferences.GetPreference(userID); err == nil && preference.PreferredLanguage != "" {
+			code = preference.PreferredLanguage
+		}
+	}
+	if name, ok := model.LanguageName(code); ok {
+		return name
+	}
+	name, _ := model.LanguageName(defaultExplanationLanguage)
+	return name
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeExplainer struct {
+	explanation string
+	err         error
+	text        string
+	language    string
+	calls       int
+}
+
+func (f *fakeExplainer) Explain(ctx context.Context, text, language string) (string, error) {
+	f.calls++
+	f.text = text
+	f.language = language
+	return f.explanation, f.err
+}
+
+type fakeUserPreferenceLookup map[string]string
+
+func (f fakeUserPreferenceLookup) GetPreference(userID string) (*model.UserPreference, error) {
+	return &model.UserPreference{UserID: userID, PreferredLanguage: f[userID]}, nil
+}
+
+// fakeExplanationLimiter allows limit explanations per user and per channel
+type fakeExplanationLimiter struct {
+	limit    int
+	users    map[string]int
+	channels map[string]int
+}
+
+func (f *fakeExplanationLimiter) CheckUserLimit(userID string) (bool, int, int64, error) {
+	return f.users[userID] < f.limit, f.limit - f.users[userID], 0, nil
+}
+
+func (f *fakeExplanationLimiter) CheckChannelLimit(channelID string) (bool, int, int64, error) {
+	return f.channels[channelID] < f.limit, f.limit - f.channels[channelID], 0, nil
+}
+
+func (f *fakeExplanationLimiter) IncrementUserLimit(userID string) error {
+	f.users[userID]++
+	return nil
+}
+
+func (f *fakeExplanationLimiter) IncrementChannelLimit(channelID string) error {
+	f.channels[channelID]++
+	return nil
+}
+
+func TestExplanationUseCase_Explain(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("explains in the user's preferred language", func(t *testing.T) {
+		explainer := &fakeExplainer{explanation: "Câu này nghĩa là việc rất dễ\n"}
+		useCase := NewExplanationUseCase(explainer, zap.NewNop())
+		useCase.SetUserPreferences(fakeUserPreferenceLookup{"U1": "vi"})
+
+		explanation, err := useCase.Explain(ctx, "U1", "C1", "  It's a piece of cake ")
+		require.NoError(t, err)
+		assert.Equal(t, "It's a piece of cake", explainer.text)
+		assert.Equal(t, "Vietnamese", explainer.language)
+		assert.Equal(t, "Vietnamese", explanation.Language)
+		assert.Equal(t, "Câu này nghĩa là việc rất dễ", explanation.Text)
+	})
+
+	t.Run("explains in English without a preference", func(t *testing.T) {
+		explainer := &fakeExplainer{explanation: "It means the task is easy"}
+		useCase := NewExplanationUseCase(explainer, zap.NewNop())
+		useCase.SetUserPreferences(fakeUserPreferenceLookup{})
+
+		explanation, err := useCase.Explain(ctx, "U2", "C1", "Dễ như ăn bánh")
+		require.NoError(t, err)
+		assert.Equal(t, "English", explanation.Language)
+	})
+
+	t.Run("rejects messages without text", func(t *testing.T) {
+		explainer := &fakeExplainer{}
+		useCase := NewExplanationUseCase(explainer, zap.NewNop())
+
+		_, err := useCase.Explain(ctx, "U1", "C1", "  ")
+		var domainErr *model.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, model.ErrorTypeValidation, domainErr.Type)
+		assert.Zero(t, explainer.calls)
+	})
+
+	t.Run("limits explanations separately from translations", func(t *testing.T) {
+		explainer := &fakeExplainer{explanation: "It means the task is easy"}
+		useCase := NewExplanationUseCase(explainer, zap.NewNop())
+		useCase.SetRateLimiter(&fakeExplanationLimiter{limit: 1, users: map[string]int{}, channels: map[string]int{}})
+
+		_, err := useCase.Explain(ctx, "U1", "C1", "It's a piece of cake")
+		require.NoError(t, err)
+
+		_, err = useCase.Explain(ctx, "U1", "C1", "Break a leg")
+		var domainErr *model.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, model.ErrorTypeRateLimit, domainErr.Type)
+		assert.Equal(t, 1, explainer.calls)
+	})
+
+	t.Run("returns provider errors", func(t *testing.T) {
+		useCase := NewExplanationUseCase(&fakeExplainer{err: errors.New("quota exceeded")}, zap.NewNop())
+
+		_, err := useCase.Explain(ctx, "U1", "C1", "It's a piece of cake")
+		assert.ErrorContains(t, err, "quota exceeded")
+	})
+}
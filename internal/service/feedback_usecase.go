@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+// FeedbackRepository defines the interface for translation feedback persistence.
+// This interface is owned by the FeedbackUseCase and defined where it's consumed.
+type FeedbackRepository interface {
+	Save(feedback *model.TranslationFeedback) error
+	SummarizeByLanguagePair(query model.FeedbackQuery) ([]model.LanguagePairFeedback, error)
+}
+
+// translationReplyTTL is how long ratings of a reply are accepted after it is posted
+const translationReplyTTL = 7 * 24 * time.Hour
+
+var _ FeedbackService = (*FeedbackUseCase)(nil)
+
+// FeedbackUseCase records how users rate the translations the bot posts.
+// Replies are remembered in the cache (Redis), so a rating of a reply can be
+// traced back to its translation on any instance until the reply expires.
+type FeedbackUseCase struct {
+	repo   FeedbackRepository
+	cache  Cache
+	logger *zap.Logger
+}
+
+func NewFeedbackUseCase(repo FeedbackRepository, cache Cache, logger *zap.Logger) *FeedbackUseCase {
+	return &FeedbackUseCase{
+		repo:   repo,
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// RememberReply keeps a posted translation so it can be rated
+func (fu *FeedbackUseCase) RememberReply(reply *model.TranslationReply) error {
+	encoded, err := json.Marshal(reply)
+	if err != nil {
+		return fmt.Errorf("failed to encode translation reply: %w", err)
+	}
+	ttl := int64(translationReplyTTL / time.Second)
+	if err := fu.cache.Set(context.Background(), translationReplyKey(reply.ChannelID, reply.MessageTS), string(encoded), ttl); err != nil {
+		return fmt.Errorf("failed to remember translation reply: %w", err)
+	}
+	return nil
+}
+
+// Rate records a user's rating, model.RatingPositive or model.RatingNegative,
+// of the message messageTS. It returns false when the message is not a
+// remembered translation reply, e.g. a user's own message or an expired reply.
+func (fu *FeedbackUseCase) Rate(channelID, messageTS, userID string, rating int) (bool, error) {
+	if rating != model.RatingPositive && rating != model.RatingNegative {
+		return false, model.NewValidationError(fmt.Sprintf("invalid rating: %d", rating))
+	}
+
+	key := translationReplyKey(channelID, messageTS)
+	exists, err := fu.cache.Exists(context.Background(), key)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up translation reply: %w", err)
+	}
+	if !exists {
+		return false, nil
+	}
+	cached, err := fu.cache.Get(context.Background(), key)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up translation reply: %w", err)
+	}
+	reply := &model.TranslationReply{}
+	if err := json.Unmarshal([]byte(cached), reply); err != nil {
+		return false, fmt.Errorf("failed to decode translation reply: %w", err)
+	}
+
+	now := time.Now()
+	feedback := &model.TranslationFeedback{
+		ID:             generateID(),
+		TranslationID:  reply.TranslationID,
+		ChannelID:      channelID,
+		MessageTS:      messageTS,
+		UserID:         userID,
+		SourceLanguage: reply.SourceLanguage,
+		TargetLanguage: reply.TargetLanguage,
+		Rating:         rating,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := fu.repo.Save(feedback); err != nil {
+		return false, fmt.Errorf("failed to record translation feedback: %w", err)
+	}
+
+	fu.logger.Info("Translation feedback recorded",
+		zap.String("channel_id", channelID),
+		zap.String("translation_id", reply.TranslationID),
+		zap.Int("rating", rating))
+	return true, nil
+}
+
+// Summary sums up ratings per language pair
+func (fu *FeedbackUseCase) Summary(query model.FeedbackQuery) ([]model.LanguagePairFeedback, error) {
+	pairs, err := fu.repo.SummarizeByLanguagePair(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize translation feedback: %w", err)
+	}
+	for i := range pairs {
+		if total := pairs[i].Positive + pairs[i].Negative; total > 0 {
+			pairs[i].Satisfaction = float64(pairs[i].Positive) / float64(total)
+		}
+	}
+	return pairs, nil
+}
+
+func translationReplyKey(channelID, messageTS string) string {
+	return fmt.Sprintf("translation_reply:%s:%s", channelID, messageTS)
+}
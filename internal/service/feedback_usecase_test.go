@@ -0,0 +1,130 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeFeedbackRepository struct {
+	saved   []*model.TranslationFeedback
+	pairs   []model.LanguagePairFeedback
+	saveErr error
+}
+
+func (f *fakeFeedbackRepository) Save(feedback *model.TranslationFeedback) error {
+	if f.saveErr != nil {
+		return f.saveErr
+	}
+	f.saved = append(f.saved, feedback)
+	return nil
+}
+
+func (f *fakeFeedbackRepository) SummarizeByLanguagePair(model.FeedbackQuery) ([]model.LanguagePairFeedback, error) {
+	return f.pairs, nil
+}
+
+func TestFeedbackUseCase_RememberReply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCache := mocks.NewMockCache(ctrl)
+	useCase := NewFeedbackUseCase(&fakeFeedbackRepository{}, mockCache, zap.NewNop())
+
+	mockCache.EXPECT().Set(gomock.Any(), "translation_reply:C1:1700000000.000200",
+		`{"translation_id":"T1","channel_id":"C1","message_ts":"1700000000.000200","source_language":"English","target_language":"Vietnamese"}`,
+		int64(7*24*3600)).Return(nil)
+
+	err := useCase.RememberReply(&model.TranslationReply{
+		TranslationID:  "T1",
+		ChannelID:      "C1",
+		MessageTS:      "1700000000.000200",
+		SourceLanguage: "English",
+		TargetLanguage: "Vietnamese",
+	})
+	assert.NoError(t, err)
+}
+
+func TestFeedbackUseCase_Rate(t *testing.T) {
+	const key = "translation_reply:C1:1700000000.000200"
+	reply := `{"translation_id":"T1","channel_id":"C1","message_ts":"1700000000.000200","source_language":"English","target_language":"Vietnamese"}`
+
+	t.Run("translation reply", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCache := mocks.NewMockCache(ctrl)
+		repo := &fakeFeedbackRepository{}
+		useCase := NewFeedbackUseCase(repo, mockCache, zap.NewNop())
+		mockCache.EXPECT().Exists(gomock.Any(), key).Return(true, nil)
+		mockCache.EXPECT().Get(gomock.Any(), key).Return(reply, nil)
+
+		recorded, err := useCase.Rate("C1", "1700000000.000200", "U1", model.RatingNegative)
+		require.NoError(t, err)
+		assert.True(t, recorded)
+		require.Len(t, repo.saved, 1)
+		assert.Equal(t, "T1", repo.saved[0].TranslationID)
+		assert.Equal(t, "U1", repo.saved[0].UserID)
+		assert.Equal(t, "English", repo.saved[0].SourceLanguage)
+		assert.Equal(t, "Vietnamese", repo.saved[0].TargetLanguage)
+		assert.Equal(t, model.RatingNegative, repo.saved[0].Rating)
+	})
+
+	t.Run("not a translation reply", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCache := mocks.NewMockCache(ctrl)
+		repo := &fakeFeedbackRepository{}
+		useCase := NewFeedbackUseCase(repo, mockCache, zap.NewNop())
+		mockCache.EXPECT().Exists(gomock.Any(), key).Return(false, nil)
+
+		recorded, err := useCase.Rate("C1", "1700000000.000200", "U1", model.RatingPositive)
+		require.NoError(t, err)
+		assert.False(t, recorded)
+		assert.Empty(t, repo.saved)
+	})
+
+	t.Run("save failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCache := mocks.NewMockCache(ctrl)
+		useCase := NewFeedbackUseCase(&fakeFeedbackRepository{saveErr: errors.New("connection refused")}, mockCache, zap.NewNop())
+		mockCache.EXPECT().Exists(gomock.Any(), key).Return(true, nil)
+		mockCache.EXPECT().Get(gomock.Any(), key).Return(reply, nil)
+
+		recorded, err := useCase.Rate("C1", "1700000000.000200", "U1", model.RatingPositive)
+		assert.Error(t, err)
+		assert.False(t, recorded)
+	})
+
+	t.Run("invalid rating", func(t *testing.T) {
+		useCase := NewFeedbackUseCase(&fakeFeedbackRepository{}, nil, zap.NewNop())
+
+		_, err := useCase.Rate("C1", "1700000000.000200", "U1", 5)
+		var domainErr *model.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, model.ErrorTypeValidation, domainErr.Type)
+	})
+}
+
+func TestFeedbackUseCase_Summary(t *testing.T) {
+	repo := &fakeFeedbackRepository{pairs: []model.LanguagePairFeedback{
+		{SourceLanguage: "English", TargetLanguage: "Vietnamese", Positive: 3, Negative: 1},
+		{SourceLanguage: "Japanese", TargetLanguage: "English"},
+	}}
+	useCase := NewFeedbackUseCase(repo, nil, zap.NewNop())
+
+	pairs, err := useCase.Summary(model.FeedbackQuery{})
+	require.NoError(t, err)
+	require.Len(t, pairs, 2)
+	assert.Equal(t, 0.75, pairs[0].Satisfaction)
+	assert.Equal(t, 0.0, pairs[1].Satisfaction)
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+// FilterRuleRepository defines the interface for filter rule persistence.
+// This interface is owned by the FilterRuleUseCase and defined where it's consumed.
+type FilterRuleRepository interface {
+	Save(rule *model.FilterRule) error
+	GetByID(id string) (*model.FilterRule, error)
+	GetByChannelID(channelID string) ([]*model.FilterRule, error)
+	Update(rule *model.FilterRule) error
+	Delete(id string) error
+}
+
+// UserGroupResolver resolves the members of a Slack user group
+type UserGroupResolver interface {
+	GetUserGroupMembers(groupID string) ([]string, error)
+}
+
+var _ FilterRuleService = (*FilterRuleUseCase)(nil)
+
+var mentionPattern = regexp.MustCompile(`<@([A-Z0-9]+)(\|[^>]*)?>`)
+
+type cachedRules struct {
+	rules     []*model.FilterRule
+	compiled  map[string]*regexp.Regexp
+	expiresAt time.Time
+}
+
+type cachedMembers struct {
+	members   map[string]bool
+	expiresAt time.Time
+}
+
+// FilterRuleUseCase manages per-channel filter rules and evaluates them
+// against incoming messages. Rules and group memberships are cached in
+// memory for cacheTTL; mutations invalidate the channel's cached rules.
+type FilterRuleUseCase struct {
+	repo     FilterRuleRepository
+	groups   UserGroupResolver
+	logger   *zap.Logger
+	cacheTTL time.Duration
+
+	mu      sync.Mutex
+	rules   map[string]cachedRules
+	members map[string]cachedMembers
+}
+
+func NewFilterRuleUseCase(repo FilterRuleRepository, groups UserGroupResolver, cacheTTL time.Duration, logger *zap.Logger) *FilterRuleUseCase {
+	return &FilterRuleUseCase{
+		repo:     repo,
+		groups:   groups,
+		logger:   logger,
+		cacheTTL: cacheTTL,
+		rules:    make(map[string]cachedRules),
+		members:  make(map[string]cachedMembers),
+	}
+}
+
+func (fu *FilterRuleUseCase) CreateRule(rule *model.FilterRule) error {
+	if err := rule.Validate(); err != nil {
+		return fmt.Errorf("invalid filter rule: %w", err)
+	}
+
+	now := time.Now()
+	if rule.ID == "" {
+		rule.ID = generateID()
+	}
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+
+	if err := fu.repo.Save(rule); err != nil {
+		return fmt.Errorf("failed to create filter rule: %w", err)
+	}
+
+	fu.invalidate(rule.ChannelID)
+	return nil
+}
+
+func (fu *FilterRuleUseCase) GetRule(id string) (*model.FilterRule, error) {
+	rule, err := fu.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get filter rule: %w", err)
+	}
+	return rule, nil
+}
+
+func (fu *FilterRuleUseCase) UpdateRule(rule *model.FilterRule) error {
+	existing, err := fu.repo.GetByID(rule.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get filter rule: %w", err)
+	}
+
+	rule.ChannelID = existing.ChannelID
+	rule.CreatedAt = existing.CreatedAt
+	rule.UpdatedAt = time.Now()
+
+	if err := rule.Validate(); err != nil {
+		return fmt.Errorf("invalid filter rule: %w", err)
+	}
+
+	if err := fu.repo.Update(rule); err != nil {
+		return fmt.Errorf("failed to update filter rule: %w", err)
+	}
+
+	fu.invalidate(rule.ChannelID)
+	return nil
+}
+
+func (fu *FilterRuleUseCase) DeleteRule(id string) error {
+	existing, err := fu.repo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to get filter rule: %w", err)
+	}
+
+	if err := fu.repo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete filter rule: %w", err)
+	}
+
+	fu.invalidate(existing.ChannelID)
+	return nil
+}
+
+func (fu *FilterRuleUseCase) ListRules(channelID string) ([]*model.FilterRule, error) {
+	rules, err := fu.repo.GetByChannelID(channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list filter rules: %w", err)
+	}
+	return rules, nil
+}
+
+// ShouldTranslate evaluates the channel's enabled rules against the message.
+// It returns false and the reason of the first failing rule. Lookup errors
+// fail open so a database hiccup never silences translation.
+func (fu *FilterRuleUseCase) ShouldTranslate(input model.FilterInput) (bool, string) {
+	entry, err := fu.loadRules(input.ChannelID)
+	if err != nil {
+		fu.logger.Warn("Failed to load filter rules, allowing message",
+			zap.Error(err),
+			zap.String("channel_id", input.ChannelID))
+		return true, ""
+	}
+
+	for _, rule := range entry.rules {
+		if !rule.Enabled {
+			continue
+		}
+
+		switch rule.Type {
+		case model.FilterRuleSkipRegex:
+			if re := entry.compiled[rule.ID]; re != nil && re.MatchString(input.Text) {
+				return false, fmt.Sprintf("message matches skip pattern (rule %s)", rule.ID)
+			}
+		case model.FilterRuleAllowUsers:
+			if !rule.Values.Contains(input.UserID) {
+				return false, fmt.Sprintf("user is not in the allowed list (rule %s)", rule.ID)
+			}
+		case model.FilterRuleAllowUserGroups:
+			if !fu.isInAnyGroup(input.UserID, rule.Values) {
+				return false, fmt.Sprintf("user is not in an allowed user group (rule %s)", rule.ID)
+			}
+		case model.FilterRuleRequireMention:
+			if !mentionsAny(input.Text, rule.Values) {
+				return false, fmt.Sprintf("message has no required mention (rule %s)", rule.ID)
+			}
+		}
+	}
+
+	return true, ""
+}
+
+func (fu *FilterRuleUseCase) loadRules(channelID string) (cachedRules, error) {
+	fu.mu.Lock()
+	entry, ok := fu.rules[channelID]
+	fu.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry, nil
+	}
+
+	rules, err := fu.repo.GetByChannelID(channelID)
+	if err != nil {
+		return cachedRules{}, err
+	}
+
+	entry = cachedRules{
+		rules:     rules,
+		compiled:  make(map[string]*regexp.Regexp),
+		expiresAt: time.Now().Add(fu.cacheTTL),
+	}
+	for _, rule := range rules {
+		if rule.Type != model.FilterRuleSkipRegex {
+			continue
+		}
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			fu.logger.Warn("Ignoring filter rule with invalid pattern",
+				zap.String("rule_id", rule.ID),
+				zap.Error(err))
+			continue
+		}
+		entry.compiled[rule.ID] = re
+	}
+
+	fu.mu.Lock()
+	fu.rules[channelID] = entry
+	fu.mu.Unlock()
+
+	return entry, nil
+}
+
+func (fu *FilterRuleUseCase) isInAnyGroup(userID string, groupIDs []string) bool {
+	for _, groupID := range groupIDs {
+		members, err := fu.groupMembers(groupID)
+		if err != nil {
+			fu.logger.Warn("Failed to resolve user group, treating as non-member",
+				zap.Error(err),
+				zap.String("group_id", groupID))
+			continue
+		}
+		if members[userID] {
+			return true
+		}
+	}
+	return false
+}
+
+func (fu *FilterRuleUseCase) groupMembers(groupID string) (map[string]bool, error) {
+	fu.mu.Lock()
+	entry, ok := fu.members[groupID]
+	fu.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.members, nil
+	}
+
+	if fu.groups == nil {
+		return nil, fmt.Errorf("user group resolver is not configured")
+	}
+
+	userIDs, err := fu.groups.GetUserGroupMembers(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		members[id] = true
+	}
+
+	fu.mu.Lock()
+	fu.members[groupID] = cachedMembers{members: members, expiresAt: time.Now().Add(fu.cacheTTL)}
+	fu.mu.Unlock()
+
+	return members, nil
+}
+
+func (fu *FilterRuleUseCase) invalidate(channelID string) {
+	fu.mu.Lock()
+	delete(fu.rules, channelID)
+	fu.mu.Unlock()
+}
+
+// mentionsAny reports whether text mentions any user, or one of userIDs when given
+func mentionsAny(text string, userIDs []string) bool {
+	matches := mentionPattern.FindAllStringSubmatch(text, -1)
+	if len(userIDs) == 0 {
+		return len(matches) > 0
+	}
+	for _, match := range matches {
+		for _, id := range userIDs {
+			if match[1] == id {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type fakeUserGroupResolver struct {
+	members map[string][]string
+	calls   int
+}
+
+func (f *fakeUserGroupResolver) GetUserGroupMembers(groupID string) ([]string, error) {
+	f.calls++
+	members, ok := f.members[groupID]
+	if !ok {
+		return nil, errors.New("group not found")
+	}
+	return members, nil
+}
+
+func TestFilterRuleUseCase_ShouldTranslate(t *testing.T) {
+	tests := []struct {
+		name          string
+		rules         []*model.FilterRule
+		repoErr       error
+		input         model.FilterInput
+		expectAllowed bool
+	}{
+		{
+			name:          "no rules allows message",
+			rules:         nil,
+			input:         model.FilterInput{ChannelID: "C1", UserID: "U1", Text: "hello"},
+			expectAllowed: true,
+		},
+		{
+			name: "skip regex matches",
+			rules: []*model.FilterRule{
+				{ID: "r1", ChannelID: "C1", Type: model.FilterRuleSkipRegex, Pattern: `^!`, Enabled: true},
+			},
+			input:         model.FilterInput{ChannelID: "C1", UserID: "U1", Text: "!deploy prod"},
+			expectAllowed: false,
+		},
+		{
+			name: "disabled rule is ignored",
+			rules: []*model.FilterRule{
+				{ID: "r1", ChannelID: "C1", Type: model.FilterRuleSkipRegex, Pattern: `^!`, Enabled: false},
+			},
+			input:         model.FilterInput{ChannelID: "C1", UserID: "U1", Text: "!deploy prod"},
+			expectAllowed: true,
+		},
+		{
+			name: "user not in allow list",
+			rules: []*model.FilterRule{
+				{ID: "r1", ChannelID: "C1", Type: model.FilterRuleAllowUsers, Values: model.StringList{"U2"}, Enabled: true},
+			},
+			input:         model.FilterInput{ChannelID: "C1", UserID: "U1", Text: "hello"},
+			expectAllowed: false,
+		},
+		{
+			name: "user in allowed group",
+			rules: []*model.FilterRule{
+				{ID: "r1", ChannelID: "C1", Type: model.FilterRuleAllowUserGroups, Values: model.StringList{"S1"}, Enabled: true},
+			},
+			input:         model.FilterInput{ChannelID: "C1", UserID: "U1", Text: "hello"},
+			expectAllowed: true,
+		},
+		{
+			name: "unknown group treated as non-member",
+			rules: []*model.FilterRule{
+				{ID: "r1", ChannelID: "C1", Type: model.FilterRuleAllowUserGroups, Values: model.StringList{"S9"}, Enabled: true},
+			},
+			input:         model.FilterInput{ChannelID: "C1", UserID: "U1", Text: "hello"},
+			expectAllowed: false,
+		},
+		{
+			name: "required mention present",
+			rules: []*model.FilterRule{
+				{ID: "r1", ChannelID: "C1", Type: model.FilterRuleRequireMention, Values: model.StringList{"UBOT"}, Enabled: true},
+			},
+			input:         model.FilterInput{ChannelID: "C1", UserID: "U1", Text: "<@UBOT> please translate"},
+			expectAllowed: true,
+		},
+		{
+			name: "required mention missing",
+			rules: []*model.FilterRule{
+				{ID: "r1", ChannelID: "C1", Type: model.FilterRuleRequireMention, Enabled: true},
+			},
+			input:         model.FilterInput{ChannelID: "C1", UserID: "U1", Text: "no mention here"},
+			expectAllowed: false,
+		},
+		{
+			name:          "repository error fails open",
+			repoErr:       errors.New("db down"),
+			input:         model.FilterInput{ChannelID: "C1", UserID: "U1", Text: "hello"},
+			expectAllowed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockFilterRuleRepository(ctrl)
+			mockRepo.EXPECT().GetByChannelID("C1").Return(tt.rules, tt.repoErr)
+
+			groups := &fakeUserGroupResolver{members: map[string][]string{"S1": {"U1", "U3"}}}
+			useCase := NewFilterRuleUseCase(mockRepo, groups, time.Minute, zap.NewNop())
+
+			allowed, reason := useCase.ShouldTranslate(tt.input)
+
+			assert.Equal(t, tt.expectAllowed, allowed)
+			if tt.expectAllowed {
+				assert.Empty(t, reason)
+			} else {
+				assert.NotEmpty(t, reason)
+			}
+		})
+	}
+}
+
+func TestFilterRuleUseCase_CachesRulesUntilMutation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockFilterRuleRepository(ctrl)
+	skip := &model.FilterRule{ID: "r1", ChannelID: "C1", Type: model.FilterRuleSkipRegex, Pattern: `^!`, Enabled: true}
+
+	mockRepo.EXPECT().GetByChannelID("C1").Return(nil, nil).Times(1)
+	mockRepo.EXPECT().Save(gomock.Any()).Return(nil)
+	mockRepo.EXPECT().GetByChannelID("C1").Return([]*model.FilterRule{skip}, nil).Times(1)
+
+	useCase := NewFilterRuleUseCase(mockRepo, nil, time.Minute, zap.NewNop())
+	input := model.FilterInput{ChannelID: "C1", UserID: "U1", Text: "!skip me"}
+
+	allowed, _ := useCase.ShouldTranslate(input)
+	assert.True(t, allowed)
+	allowed, _ = useCase.ShouldTranslate(input)
+	assert.True(t, allowed)
+
+	err := useCase.CreateRule(&model.FilterRule{ChannelID: "C1", Type: model.FilterRuleSkipRegex, Pattern: `^!`, Enabled: true})
+	assert.NoError(t, err)
+
+	allowed, _ = useCase.ShouldTranslate(input)
+	assert.False(t, allowed)
+}
+
+func TestFilterRuleUseCase_CreateRuleValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockFilterRuleRepository(ctrl)
+	useCase := NewFilterRuleUseCase(mockRepo, nil, time.Minute, zap.NewNop())
+
+	err := useCase.CreateRule(&model.FilterRule{ChannelID: "C1", Type: model.FilterRuleSkipRegex, Pattern: "(", Enabled: true})
+
+	var domainErr *model.DomainError
+	assert.ErrorAs(t, err, &domainErr)
+}
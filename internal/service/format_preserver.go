@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/language"
 )
 
 type FormatPreserver struct {
@@ -12,6 +14,8 @@ type FormatPreserver struct {
 	links      map[string]string
 	lists      map[string]string // stores list markers with indentation
 	usernames  map[string]string // stores user ID to username mapping for mention conversion
+	// rightToLeft lays restored text out for a right-to-left target language
+	rightToLeft bool
 }
 
 func NewFormatPreserver() *FormatPreserver {
@@ -49,10 +53,27 @@ func (fp *FormatPreserver) Restore(text string) string {
 	return fp.RestoreWithOptions(text, false)
 }
 
+// SetRightToLeft makes Restore lay the text out for a right-to-left language:
+// directional marks the translation came with are dropped, so they cannot
+// break placeholders, each line starts with a right-to-left mark, and
+// restored links and mentions are isolated as left-to-right runs. Code and
+// emoji codes are left as they are, since Slack would no longer format them.
+func (fp *FormatPreserver) SetRightToLeft(enabled bool) {
+	fp.rightToLeft = enabled
+}
+
 // RestoreWithOptions applies all formatting back with option to convert user mentions to plain text
 func (fp *FormatPreserver) RestoreWithOptions(text string, convertUserMentions bool) string {
+	if fp.rightToLeft {
+		text = language.StripBidiControls(text)
+	}
+
 	// 1. Restore line breaks
 	text = fp.restoreLineBreaks(text)
+	if fp.rightToLeft {
+		// Placeholders are still in place, so lines inside code blocks are left alone
+		text = language.MarkRightToLeft(text)
+	}
 	
 	// 2. Restore emoji codes
 	text = fp.restoreEmojis(text)
@@ -70,9 +91,9 @@ func (fp *FormatPreserver) RestoreWithOptions(text string, convertUserMentions b
 }
 
 func (fp *FormatPreserver) extractLists(text string) string {
-	// Match bullet points (* or -) and numbered lists with optional indentation
-	// Pattern: optional spaces, then (* or - or digit.), then space, then content
-	listPattern := regexp.MustCompile(`^(\s*)([*\-]\s|\d+\.\s)(.*)$`)
+	// Match bullet points (* or -, or the Japanese ・) and numbered lists with optional indentation
+	// Pattern: optional spaces (also full-width), then (* or - or digit.), then space, then content
+	listPattern := regexp.MustCompile(`^([\s　]*)([*\-]\s|・\s?|\d+\.\s)(.*)$`)
 	
 	lines := strings.Split(text, "\n")
 	for i, line := range lines {
@@ -163,12 +184,12 @@ func (fp *FormatPreserver) restoreLinksWithOptions(text string, convertUserMenti
 				if username == "" {
 					username = userID
 				}
-				result = strings.ReplaceAll(result, placeholder, username)
+				result = strings.ReplaceAll(result, placeholder, fp.leftToRight(username))
 			} else {
-				result = strings.ReplaceAll(result, placeholder, link)
+				result = strings.ReplaceAll(result, placeholder, fp.leftToRight(link))
 			}
 		} else {
-			result = strings.ReplaceAll(result, placeholder, link)
+			result = strings.ReplaceAll(result, placeholder, fp.leftToRight(link))
 		}
 	}
 	
@@ -191,6 +212,15 @@ func (fp *FormatPreserver) restoreLists(text string) string {
 	return result
 }
 
+// leftToRight isolates a restored Slack link or mention in right-to-left
+// text. Bare URLs are not isolated, since Slack would link the isolate too.
+func (fp *FormatPreserver) leftToRight(link string) string {
+	if !fp.rightToLeft || strings.HasPrefix(link, "http") {
+		return link
+	}
+	return language.IsolateLeftToRight(link)
+}
+
 // SetUsernameMappings stores the user ID to username mapping for mention conversion
 func (fp *FormatPreserver) SetUsernameMappings(mappings map[string]string) {
 	fp.usernames = mappings
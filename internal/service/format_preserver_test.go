@@ -1,7 +1,9 @@
 package service
 
 import (
+	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func TestFormatPreserver_Emojis(t *testing.T) {
@@ -184,6 +186,16 @@ func TestFormatPreserver_BulletPoints(t *testing.T) {
 			input:    "* Main\n  1. Sub one\n  2. Sub two",
 			expected: "* Main\n  1. Sub one\n  2. Sub two",
 		},
+		{
+			name:     "Japanese bullet list",
+			input:    "・会議の議事録\n・来週の予定",
+			expected: "・会議の議事録\n・来週の予定",
+		},
+		{
+			name:     "Japanese bullet list with full-width indentation",
+			input:    "・資料\n　・スライド\n　・デモ",
+			expected: "・資料\n　・スライド\n　・デモ",
+		},
 	}
 
 	for _, tt := range tests {
@@ -212,6 +224,53 @@ func TestFormatPreserver_Combined(t *testing.T) {
 	}
 }
 
+func TestFormatPreserver_Japanese(t *testing.T) {
+	input := "<@U123ABC> さん、:tada: 新しい版を `make deploy` で公開しました！\n詳細は https://example.com/リリース を見てください。\n・変更点\n・既知の問題"
+
+	preserver := NewFormatPreserver()
+	cleaned := preserver.Extract(input)
+	for _, placeholder := range []string{"LINK0", "LINK1", "EMOJI0", "CODEBLOCK0", "LIST0", "LIST1", "LINEBREAK"} {
+		if !strings.Contains(cleaned, placeholder) {
+			t.Fatalf("expected %s in %q", placeholder, cleaned)
+		}
+	}
+	if !utf8.ValidString(cleaned) {
+		t.Fatalf("extracted text is not valid UTF-8: %q", cleaned)
+	}
+
+	if restored := preserver.Restore(cleaned); restored != input {
+		t.Errorf("expected %q, got %q", input, restored)
+	}
+
+	// Placeholders right next to kana and kanji are restored in the translation
+	translated := "LINK0 さん、EMOJI0 新版をCODEBLOCK0で公開。LINEBREAK詳細はLINK1へ。LINEBREAKLIST0変更点LINEBREAKLIST1既知の問題"
+	expected := "<@U123ABC> さん、:tada: 新版を`make deploy`で公開。\n詳細はhttps://example.com/リリースへ。\n・変更点\n・既知の問題"
+	if restored := preserver.Restore(translated); restored != expected {
+		t.Errorf("expected %q, got %q", expected, restored)
+	}
+}
+
+func TestFormatPreserver_RightToLeft(t *testing.T) {
+	input := "<@U123ABC> the release is out :tada:\nRun `make deploy`.\n- Notes"
+
+	preserver := NewFormatPreserver()
+	preserver.SetRightToLeft(true)
+	cleaned := preserver.Extract(input)
+
+	// The translation came back with a direction mark inside a placeholder
+	translated := "LINK0 השחרור יצא EMOJI0LINEBREAKהריצו CODEBLOCK0.LINEBREAKLIST0הערות\u200f LIN\u200eK0"
+	expected := "\u200f\u2066<@U123ABC>\u2069 השחרור יצא :tada:\n\u200fהריצו `make deploy`.\n\u200f- הערות \u2066<@U123ABC>\u2069"
+	if restored := preserver.Restore(translated); restored != expected {
+		t.Errorf("expected %q, got %q", expected, restored)
+	}
+
+	// Left-to-right targets are restored without marks
+	preserver.SetRightToLeft(false)
+	if restored := preserver.Restore(cleaned); restored != input {
+		t.Errorf("expected %q, got %q", input, restored)
+	}
+}
+
 func TestFormatPreserver_BulletPointsWithOtherFormats(t *testing.T) {
 	input := " * Test 1\n  * Test 2 with :smile:\n  * Test 3 with `code`"
 	expected := input
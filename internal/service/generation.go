@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/ai"
+	"go.uber.org/zap"
+)
+
+// ChannelConfigLookup reports a channel's configuration, e.g. ChannelUseCase
+type ChannelConfigLookup interface {
+	GetChannelConfig(channelID string) (*model.ChannelConfig, error)
+}
+
+// WorkspaceLookup returns the workspace of a team, or nil when the app was
+// not installed there through OAuth, e.g. WorkspaceUseCase
+type WorkspaceLookup interface {
+	GetWorkspace(teamID string) (*model.Workspace, error)
+}
+
+type teamKey struct{}
+
+// WithTeam returns a copy of ctx carrying the chat workspace, e.g. the Slack
+// team, a translation is made for
+func WithTeam(ctx context.Context, teamID string) context.Context {
+	return context.WithValue(ctx, teamKey{}, teamID)
+}
+
+// TeamFrom returns the team carried by ctx, or ""
+func TeamFrom(ctx context.Context) string {
+	teamID, _ := ctx.Value(teamKey{}).(string)
+	return teamID
+}
+
+// SetGeneration sets the AI generation parameters translations use unless
+// their workspace or channel overrides them
+func (tu *TranslationUseCase) SetGeneration(defaults ai.GenerationConfig) {
+	tu.generation = defaults
+}
+
+// SetGenerationOverrides applies the generation settings of the workspace
+// carried by the context of a translation, then those of its channel
+func (tu *TranslationUseCase) SetGenerationOverrides(workspaces WorkspaceLookup, channels ChannelConfigLookup) {
+	tu.workspaces = workspaces
+	tu.channels = channels
+}
+
+// generationFor returns the generation parameters of a translation made in
+// channelID for the team carried by ctx. Lookup errors keep the parameters
+// resolved so far.
+func (tu *TranslationUseCase) generationFor(ctx context.Context, channelID string) ai.GenerationConfig {
+	generation := tu.generation
+	if teamID := TeamFrom(ctx); tu.workspaces != nil && teamID != "" {
+		workspace, err := tu.workspaces.GetWorkspace(teamID)
+		if err != nil {
+			tu.logger.Warn("Failed to load workspace generation settings",
+				zap.Error(err),
+				zap.String("team_id", teamID))
+		} else if workspace != nil {
+			generation = workspace.Generation.Apply(generation)
+		}
+	}
+	if tu.channels != nil && channelID != "" {
+		config, err := tu.channels.GetChannelConfig(channelID)
+		if err == nil && config != nil {
+			generation = config.Generation.Apply(generation)
+		}
+	}
+	return generation
+}
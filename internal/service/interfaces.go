@@ -2,15 +2,19 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
 	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
 	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/secrets"
 )
 
 // TranslationService defines the interface for translation use cases
 type TranslationService interface {
 	Translate(req request.Translation) (response.Translation, error)
+	TranslateContext(ctx context.Context, req request.Translation) (response.Translation, error)
+	GetTranslation(ctx context.Context, id string) (response.Translation, error)
 	DetectLanguage(text string) (string, error)
 }
 
@@ -22,6 +26,160 @@ type ChannelService interface {
 	DeleteChannelConfig(channelID string) error
 	ListAllChannelConfigs() ([]*model.ChannelConfig, error)
 	IsChannelEnabled(channelID string) (bool, error)
+	ApplyChannelTemplate(template *model.ChannelConfig, selector model.ChannelSelector, dryRun bool) ([]model.BulkChannelResult, error)
+}
+
+// FilterRuleService defines the interface for message filter rules
+type FilterRuleService interface {
+	CreateRule(rule *model.FilterRule) error
+	GetRule(id string) (*model.FilterRule, error)
+	UpdateRule(rule *model.FilterRule) error
+	DeleteRule(id string) error
+	ListRules(channelID string) ([]*model.FilterRule, error)
+	ShouldTranslate(input model.FilterInput) (bool, string)
+}
+
+// ChannelPairService defines the interface for channels whose translations are cross-posted to each other
+type ChannelPairService interface {
+	CreatePair(pair *model.ChannelPair) error
+	GetPair(id string) (*model.ChannelPair, error)
+	DeletePair(id string) error
+	ListPairs() ([]*model.ChannelPair, error)
+	PairedChannel(channelID string) (string, bool)
+}
+
+// WorkspaceService defines the interface for the Slack workspaces the app is installed in
+type WorkspaceService interface {
+	Install(workspace *model.Workspace) error
+	GetWorkspace(teamID string) (*model.Workspace, error)
+	ListWorkspaces() ([]*model.Workspace, error)
+	SetSigningSecret(teamID string, signingSecret secrets.Secret) (*model.Workspace, error)
+	SetGeneration(teamID string, generation model.GenerationSettings) (*model.Workspace, error)
+	SetMonthlyBudget(teamID string, budget float64) (*model.Workspace, error)
+	Uninstall(teamID string) error
+	RotateKeys(ctx context.Context) (*model.KeyRotation, error)
+}
+
+// ConfigBundleService defines the interface for exporting the bot configuration and applying it elsewhere
+type ConfigBundleService interface {
+	Export() (*model.ConfigBundle, error)
+	Apply(bundle *model.ConfigBundle, opts model.ConfigApplyOptions) (*model.ConfigPlan, error)
+}
+
+// MaintenanceService defines the interface for the global maintenance switch
+type MaintenanceService interface {
+	Enable(enabledBy, notice string) (*model.Maintenance, error)
+	Disable() error
+	Status() (*model.Maintenance, error)
+	IsActive() bool
+	ClaimNotice(channelID string) (string, bool)
+}
+
+// PromptDeploymentService defines the interface for switching the translation prompt version
+type PromptDeploymentService interface {
+	Status() (*model.PromptDeployment, error)
+	Activate(version, switchedBy string) (*model.PromptDeployment, error)
+	Rollback(switchedBy string) (*model.PromptDeployment, error)
+	ActivePromptVersion() string
+}
+
+// ChannelPauseService defines the interface for temporarily stopping translation in a channel
+type ChannelPauseService interface {
+	Pause(channelID, pausedBy string, duration time.Duration) (*model.ChannelPause, error)
+	Resume(channelID string) error
+	GetPause(channelID string) (*model.ChannelPause, error)
+	IsPaused(channelID string) bool
+}
+
+// AuditService defines the interface for the admin audit trail
+type AuditService interface {
+	Record(entry model.AuditEntry) error
+	List(query model.AuditQuery) ([]*model.AuditRecord, error)
+}
+
+// ComplianceService defines the interface for the record of bot posts and its signed exports
+type ComplianceService interface {
+	RecordPost(ctx context.Context, post *model.BotPost)
+	Export(ctx context.Context, teamID string, from, to time.Time, requestedBy string) (*model.ComplianceExport, error)
+	ListExports(limit int) ([]*model.ComplianceExport, error)
+	PublicKey() string
+}
+
+// TranslationReviewService defines the interface for the translation review workflow
+type TranslationReviewService interface {
+	Submit(draft *model.PendingTranslation) (bool, error)
+	Approve(id, reviewerID string) (*model.PendingTranslation, error)
+	Reject(id, reviewerID string) (*model.PendingTranslation, error)
+	StartCorrection(id, triggerID string) error
+	ApproveWithCorrection(id, reviewerID, correctedText string) (*model.PendingTranslation, error)
+}
+
+// CorrectionService defines the interface for reviewer corrections and the suggestions built from them
+type CorrectionService interface {
+	Record(correction *model.TranslationCorrection) error
+	Suggestions(query model.CorrectionQuery) (*model.CorrectionSuggestions, error)
+}
+
+// FeedbackService defines the interface for user ratings of posted translations
+type FeedbackService interface {
+	RememberReply(reply *model.TranslationReply) error
+	Rate(channelID, messageTS, userID string, rating int) (bool, error)
+	Summary(query model.FeedbackQuery) ([]model.LanguagePairFeedback, error)
+}
+
+// UserPreferenceService defines the interface for the preferences users set on the App Home tab
+type UserPreferenceService interface {
+	GetPreference(userID string) (*model.UserPreference, error)
+	SetPreferredLanguage(userID, code string) (*model.UserPreference, error)
+	SetTranslateOwnMessages(userID string, enabled bool) (*model.UserPreference, error)
+	PublishHome(userID string) error
+}
+
+// MessageActionService defines the interface for the buttons below posted translations
+type MessageActionService interface {
+	Original(teamID, channelID, messageTS string) (string, error)
+	Retranslate(ctx context.Context, teamID, channelID, messageTS, userID, code string) (response.Translation, error)
+}
+
+// DirectMessageService defines the interface for the assistant answering direct messages to the bot
+type DirectMessageService interface {
+	Reply(ctx context.Context, channelID, userID, text string) (response.DirectMessage, error)
+}
+
+// SummarizationService defines the interface for summaries of long threads
+type SummarizationService interface {
+	SummarizeThread(ctx context.Context, channelID, threadTS string) (response.ThreadSummary, error)
+}
+
+// ExplanationService defines the interface for the "Explain this message" shortcut
+type ExplanationService interface {
+	Explain(ctx context.Context, userID, channelID, text string) (response.Explanation, error)
+}
+
+// OnboardingService defines the interface for the setup wizard sent to the
+// user who installed the app in a workspace
+type OnboardingService interface {
+	Start(ctx context.Context, teamID, userID string) error
+	ChooseLanguage(ctx context.Context, teamID, userID, languageCode string) error
+	ChooseChannels(ctx context.Context, teamID, userID string, channelIDs []string) error
+	ChooseBudget(ctx context.Context, teamID, userID string, budget float64) error
+}
+
+// ChannelOnboardingService defines the interface for setting up the channels the bot is invited to
+type ChannelOnboardingService interface {
+	BotJoined(ctx context.Context, channelID, inviterID string) error
+	ChooseLanguage(ctx context.Context, channelID, messageTS, userID, languageCode string) error
+}
+
+// AnalyticsService defines the interface for per-channel usage statistics
+type AnalyticsService interface {
+	RecordTranslation(channelID string, result response.Translation) error
+	ChannelStats(channelID string, since time.Time) (*model.ChannelStats, error)
+}
+
+// ChatTranslationService defines the interface for translating messages on any chat platform
+type ChatTranslationService interface {
+	HandleMessage(ctx context.Context, platform ChatPlatform, msg model.ChatMessage)
 }
 
 // EventProcessorService defines the interface for event processing
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+// defaultLanguagePairs translates English and Vietnamese into each other
+var defaultLanguagePairs = map[string]string{
+	"en": "vi",
+	"vi": "en",
+}
+
+// LanguageRouter picks the language a message is translated to from the
+// language it was detected in. Configured channels translate their source
+// languages into their target language; other channels use the pair matrix,
+// which maps source language codes to target language codes.
+type LanguageRouter struct {
+	pairs map[string]string
+}
+
+// NewLanguageRouter creates a router for the given source -> target pairs
+// of language codes, e.g. {"ja": "en", "en": "ja"}. Without pairs, English
+// and Vietnamese are translated into each other.
+func NewLanguageRouter(pairs map[string]string) (*LanguageRouter, error) {
+	if len(pairs) == 0 {
+		pairs = defaultLanguagePairs
+	}
+	canonical := make(map[string]string, len(pairs))
+	for source, target := range pairs {
+		if !model.IsSupportedLanguageCode(source) {
+			return nil, fmt.Errorf("unsupported source language %q in language pairs", source)
+		}
+		if !model.IsSupportedLanguageCode(target) {
+			return nil, fmt.Errorf("unsupported target language %q in language pairs", target)
+		}
+		source, target = model.CanonicalLanguageCode(source), model.CanonicalLanguageCode(target)
+		if source == target {
+			return nil, fmt.Errorf("language %q cannot be translated to itself", source)
+		}
+		canonical[source] = target
+	}
+	return &LanguageRouter{pairs: canonical}, nil
+}
+
+// DefaultLanguageRouter translates English and Vietnamese into each other
+func DefaultLanguageRouter() *LanguageRouter {
+	return &LanguageRouter{pairs: defaultLanguagePairs}
+}
+
+// Route returns the name of the language a message detected as detected is
+// translated to, or false when it is not translated: config, if any, does not
+// list it as a source language or it is already in the target language, or
+// the pair matrix has no pair for it.
+func (r *LanguageRouter) Route(config *model.ChannelConfig, detected string) (string, bool) {
+	code, ok := model.LanguageCode(detected)
+	if !ok {
+		return "", false
+	}
+
+	if config != nil {
+		if code == model.CanonicalLanguageCode(config.TargetLanguage) {
+			return "", false
+		}
+		if len(config.SourceLanguages) > 0 && !config.SourceLanguages.Contains(code) {
+			return "", false
+		}
+		return model.LanguageName(config.TargetLanguage)
+	}
+
+	target, ok := r.pairs[code]
+	if !ok {
+		return "", false
+	}
+	return model.LanguageName(target)
+}
+
+// UnsupportedLanguageMessage is the reply to a message in a language the
+// pair matrix does not translate
+func (r *LanguageRouter) UnsupportedLanguageMessage() string {
+	names := make([]string, 0, len(r.pairs))
+	for code := range r.pairs {
+		name, _ := model.LanguageName(code)
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if len(names) == 1 {
+		return fmt.Sprintf("⚠️ Sorry! I only translate %s right now, not other languages, slang or numbers", names[0])
+	}
+	return fmt.Sprintf("⚠️ Sorry! I only translate %s and %s right now, not other languages, slang or numbers",
+		strings.Join(names[:len(names)-1], ", "), names[len(names)-1])
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLanguageRouter_RouteChannelConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *model.ChannelConfig
+		detected string
+		expected string
+		ok       bool
+	}{
+		{
+			name:     "source language translated to target",
+			config:   &model.ChannelConfig{SourceLanguages: model.LanguageList{"en", "ja"}, TargetLanguage: "es"},
+			detected: "Japanese",
+			expected: "Spanish",
+			ok:       true,
+		},
+		{
+			name:     "no source languages accepts any language",
+			config:   &model.ChannelConfig{TargetLanguage: "fr"},
+			detected: "english",
+			expected: "French",
+			ok:       true,
+		},
+		{
+			name:     "language outside source languages",
+			config:   &model.ChannelConfig{SourceLanguages: model.LanguageList{"en"}, TargetLanguage: "es"},
+			detected: "Vietnamese",
+		},
+		{
+			name:     "already in target language",
+			config:   &model.ChannelConfig{SourceLanguages: model.LanguageList{"en", "vi"}, TargetLanguage: "vi"},
+			detected: "Vietnamese",
+		},
+		{
+			name:     "unknown language",
+			config:   &model.ChannelConfig{TargetLanguage: "vi"},
+			detected: "Klingon",
+		},
+	}
+
+	router := DefaultLanguageRouter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, ok := router.Route(tt.config, tt.detected)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, target)
+		})
+	}
+}
+
+func TestLanguageRouter_RoutePairs(t *testing.T) {
+	router, err := NewLanguageRouter(map[string]string{"en": "ja", "ja": "en", "ko": "en"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		detected string
+		expected string
+		ok       bool
+	}{
+		{detected: "English", expected: "Japanese", ok: true},
+		{detected: "Japanese", expected: "English", ok: true},
+		{detected: "korean", expected: "English", ok: true},
+		{detected: "Vietnamese"},
+		{detected: "Klingon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.detected, func(t *testing.T) {
+			target, ok := router.Route(nil, tt.detected)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, target)
+		})
+	}
+}
+
+func TestLanguageRouter_DefaultPairs(t *testing.T) {
+	router, err := NewLanguageRouter(nil)
+	require.NoError(t, err)
+
+	target, ok := router.Route(nil, "English")
+	assert.True(t, ok)
+	assert.Equal(t, "Vietnamese", target)
+
+	target, ok = router.Route(nil, "Vietnamese")
+	assert.True(t, ok)
+	assert.Equal(t, "English", target)
+
+	assert.Equal(t, MessageUnsupportedLanguage, router.UnsupportedLanguageMessage())
+}
+
+func TestNewLanguageRouter_InvalidPairs(t *testing.T) {
+	tests := []struct {
+		name  string
+		pairs map[string]string
+	}{
+		{name: "unsupported source", pairs: map[string]string{"xx": "en"}},
+		{name: "unsupported target", pairs: map[string]string{"en": "xx"}},
+		{name: "same language", pairs: map[string]string{"ja": "ja"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, err := NewLanguageRouter(tt.pairs)
+			assert.Error(t, err)
+			assert.Nil(t, router)
+		})
+	}
+}
+
+func TestLanguageRouter_UnsupportedLanguageMessage(t *testing.T) {
+	router, err := NewLanguageRouter(map[string]string{"ja": "en", "ko": "en", "fr": "en"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "⚠️ Sorry! I only translate French, Japanese and Korean right now, not other languages, slang or numbers",
+		router.UnsupportedLanguageMessage())
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+const (
+	// sloFastWindow and sloSlowWindow are the multi-window burn-rate windows:
+	// an alert fires only while both are burning, so a short spike that has
+	// already recovered does not page anyone
+	sloFastWindow = 5 * time.Minute
+	sloSlowWindow = time.Hour
+	// sloBurnRateAlert is the burn rate that spends 2% of a 30-day error budget in an hour
+	sloBurnRateAlert = 14.4
+	// sloMinReplies avoids alerting on a handful of replies
+	sloMinReplies = 10
+)
+
+// LatencySLOMonitor alerts when bot replies use up the latency error budget
+// too fast. The objective is that a target share of replies (e.g. 95%) is
+// posted within threshold of Slack delivering the message.
+type LatencySLOMonitor struct {
+	metrics   *metrics.Metrics
+	alerter   Alerter
+	threshold time.Duration
+	target    float64
+	logger    *zap.Logger
+
+	burning bool
+}
+
+// NewLatencySLOMonitor creates a monitor for the objective "target of replies
+// within threshold". alerter may be nil, in which case breaches are only logged.
+func NewLatencySLOMonitor(m *metrics.Metrics, alerter Alerter, threshold time.Duration, target float64, logger *zap.Logger) *LatencySLOMonitor {
+	return &LatencySLOMonitor{
+		metrics:   m,
+		alerter:   alerter,
+		threshold: threshold,
+		target:    target,
+		logger:    logger,
+	}
+}
+
+// Run checks the burn rate every interval until ctx is done
+func (s *LatencySLOMonitor) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.Check(ctx, now)
+		}
+	}
+}
+
+// Check computes the burn rate over the fast and slow windows and alerts once
+// when both exceed the alert threshold. It alerts again only after the fast
+// window has recovered.
+func (s *LatencySLOMonitor) Check(ctx context.Context, now time.Time) {
+	fastReplies, fastBurn := s.burnRate(now, sloFastWindow)
+	_, slowBurn := s.burnRate(now, sloSlowWindow)
+
+	if fastReplies < sloMinReplies || fastBurn < sloBurnRateAlert || slowBurn < sloBurnRateAlert {
+		if s.burning && fastBurn < sloBurnRateAlert {
+			s.burning = false
+			s.logger.Info("Reply latency SLO burn rate recovered",
+				zap.Float64("fast_burn_rate", fastBurn),
+				zap.Float64("slow_burn_rate", slowBurn))
+		}
+		return
+	}
+	if s.burning {
+		return
+	}
+	s.burning = true
+
+	s.logger.Warn("Reply latency SLO burning error budget",
+		zap.Duration("threshold", s.threshold),
+		zap.Float64("target", s.target),
+		zap.Float64("fast_burn_rate", fastBurn),
+		zap.Float64("slow_burn_rate", slowBurn))
+
+	if s.alerter == nil {
+		return
+	}
+	err := s.alerter.Send(ctx, "latency_slo.burn_rate", map[string]interface{}{
+		"threshold_ms":   s.threshold.Milliseconds(),
+		"target":         s.target,
+		"fast_window":    sloFastWindow.String(),
+		"fast_burn_rate": fastBurn,
+		"slow_window":    sloSlowWindow.String(),
+		"slow_burn_rate": slowBurn,
+		"replies":        fastReplies,
+	})
+	if err != nil {
+		s.logger.Error("Failed to send latency SLO alert", zap.Error(err))
+	}
+}
+
+// burnRate returns the replies posted in the window before now and how fast
+// they spend the error budget: 1 means exactly on budget
+func (s *LatencySLOMonitor) burnRate(now time.Time, window time.Duration) (int64, float64) {
+	total, slow := s.metrics.ReplyLatenciesSince(now.Add(-window), s.threshold)
+	if total == 0 || s.target >= 1 {
+		return total, 0
+	}
+	return total, (float64(slow) / float64(total)) / (1 - s.target)
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func recordReplies(m *metrics.Metrics, now time.Time, count int, latency time.Duration) {
+	for i := 0; i < count; i++ {
+		m.RecordReplyLatency(metrics.NewLatencyTrace(now.Add(-latency)), now)
+	}
+}
+
+func TestLatencySLOMonitor_Check(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		fast      int
+		slow      int
+		wantAlert bool
+	}{
+		{name: "within budget", fast: 95, slow: 5, wantAlert: false},
+		{name: "burning budget", fast: 10, slow: 90, wantAlert: true},
+		{name: "too few replies", fast: 0, slow: 5, wantAlert: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := metrics.NewMetrics()
+			recordReplies(m, now, tt.fast, time.Second)
+			recordReplies(m, now, tt.slow, 8*time.Second)
+
+			alerter := &fakeAlerter{events: make(chan string, 2)}
+			monitor := NewLatencySLOMonitor(m, alerter, 5*time.Second, 0.95, zap.NewNop())
+
+			monitor.Check(context.Background(), now)
+			// A breach that is still burning is only alerted once
+			monitor.Check(context.Background(), now)
+
+			if tt.wantAlert {
+				assert.Len(t, alerter.events, 1)
+				assert.Equal(t, "latency_slo.burn_rate", <-alerter.events)
+			} else {
+				assert.Empty(t, alerter.events)
+			}
+		})
+	}
+}
+
+func TestLatencySLOMonitor_NilAlerter(t *testing.T) {
+	now := time.Now()
+	m := metrics.NewMetrics()
+	recordReplies(m, now, 20, 8*time.Second)
+
+	monitor := NewLatencySLOMonitor(m, nil, 5*time.Second, 0.95, zap.NewNop())
+
+	assert.NotPanics(t, func() { monitor.Check(context.Background(), now) })
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+const (
+	maintenanceKey = "maintenance_mode"
+	// maintenanceNoticeTTL bounds how long the channels notified during one
+	// maintenance window are remembered
+	maintenanceNoticeTTL = int64(7 * 24 * 60 * 60)
+)
+
+var _ MaintenanceService = (*MaintenanceUseCase)(nil)
+
+// MaintenanceUseCase is the global maintenance switch. The switch lives in the
+// cache (Redis), so every instance holds its queues while it is on.
+type MaintenanceUseCase struct {
+	cache         Cache
+	defaultNotice string
+	logger        *zap.Logger
+	now           func() time.Time
+}
+
+func NewMaintenanceUseCase(cache Cache, defaultNotice string, logger *zap.Logger) *MaintenanceUseCase {
+	return &MaintenanceUseCase{
+		cache:         cache,
+		defaultNotice: defaultNotice,
+		logger:        logger,
+		now:           time.Now,
+	}
+}
+
+// Enable turns maintenance on. An empty notice uses the configured one.
+// Enabling it again replaces the notice and notifies every channel again.
+func (mu *MaintenanceUseCase) Enable(enabledBy, notice string) (*model.Maintenance, error) {
+	if notice == "" {
+		notice = mu.defaultNotice
+	}
+	enabledAt := mu.now().UTC()
+	maintenance := &model.Maintenance{
+		Enabled:   true,
+		Notice:    notice,
+		EnabledBy: enabledBy,
+		EnabledAt: &enabledAt,
+	}
+
+	encoded, err := json.Marshal(maintenance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode maintenance: %w", err)
+	}
+	if err := mu.cache.Set(context.Background(), maintenanceKey, string(encoded), 0); err != nil {
+		return nil, fmt.Errorf("failed to enable maintenance: %w", err)
+	}
+
+	mu.logger.Warn("Maintenance mode enabled", zap.String("enabled_by", enabledBy))
+	return maintenance, nil
+}
+
+// Disable turns maintenance off; the queued events are then processed
+func (mu *MaintenanceUseCase) Disable() error {
+	if err := mu.cache.Delete(context.Background(), maintenanceKey); err != nil {
+		return fmt.Errorf("failed to disable maintenance: %w", err)
+	}
+
+	mu.logger.Info("Maintenance mode disabled")
+	return nil
+}
+
+// Status returns the maintenance switch, with Enabled false when it is off
+func (mu *MaintenanceUseCase) Status() (*model.Maintenance, error) {
+	exists, err := mu.cache.Exists(context.Background(), maintenanceKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance: %w", err)
+	}
+	if !exists {
+		return &model.Maintenance{}, nil
+	}
+
+	cached, err := mu.cache.Get(context.Background(), maintenanceKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance: %w", err)
+	}
+	maintenance := &model.Maintenance{}
+	if err := json.Unmarshal([]byte(cached), maintenance); err != nil {
+		return nil, fmt.Errorf("failed to decode maintenance: %w", err)
+	}
+	return maintenance, nil
+}
+
+// IsActive reports whether maintenance is on. When the cache cannot be
+// reached maintenance is treated as off.
+func (mu *MaintenanceUseCase) IsActive() bool {
+	active, err := mu.cache.Exists(context.Background(), maintenanceKey)
+	if err != nil {
+		mu.logger.Warn("Failed to check maintenance mode, processing", zap.Error(err))
+		return false
+	}
+	return active
+}
+
+// ClaimNotice returns the maintenance notice if channelID has not been sent
+// it during the current maintenance, and records that it has. Instances
+// racing on the same channel may both claim it.
+func (mu *MaintenanceUseCase) ClaimNotice(channelID string) (string, bool) {
+	maintenance, err := mu.Status()
+	if err != nil {
+		mu.logger.Warn("Failed to get maintenance notice", zap.Error(err), zap.String("channel_id", channelID))
+		return "", false
+	}
+	if !maintenance.Enabled || maintenance.EnabledAt == nil || maintenance.Notice == "" {
+		return "", false
+	}
+
+	key := fmt.Sprintf("maintenance_notice_sent:%d:%s", maintenance.EnabledAt.UnixNano(), channelID)
+	sent, err := mu.cache.Exists(context.Background(), key)
+	if err != nil || sent {
+		return "", false
+	}
+	if err := mu.cache.Set(context.Background(), key, "1", maintenanceNoticeTTL); err != nil {
+		mu.logger.Warn("Failed to record maintenance notice", zap.Error(err), zap.String("channel_id", channelID))
+		return "", false
+	}
+	return maintenance.Notice, true
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMaintenanceUseCase_Enable(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		notice         string
+		expectedNotice string
+	}{
+		{name: "configured notice", notice: "", expectedNotice: "Back soon"},
+		{name: "custom notice", notice: "Upgrading until 10:00 UTC", expectedNotice: "Upgrading until 10:00 UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCache := mocks.NewMockCache(ctrl)
+			useCase := NewMaintenanceUseCase(mockCache, "Back soon", zap.NewNop())
+			useCase.now = func() time.Time { return now }
+
+			var stored string
+			mockCache.EXPECT().Set(gomock.Any(), "maintenance_mode", gomock.Any(), int64(0)).
+				DoAndReturn(func(_ context.Context, key, value string, ttl int64) error {
+					stored = value
+					return nil
+				})
+
+			maintenance, err := useCase.Enable("admin:ab12", tt.notice)
+			require.NoError(t, err)
+			assert.True(t, maintenance.Enabled)
+			assert.Equal(t, tt.expectedNotice, maintenance.Notice)
+
+			var decoded model.Maintenance
+			require.NoError(t, json.Unmarshal([]byte(stored), &decoded))
+			assert.Equal(t, "admin:ab12", decoded.EnabledBy)
+			assert.True(t, now.Equal(*decoded.EnabledAt))
+		})
+	}
+}
+
+func TestMaintenanceUseCase_IsActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCache := mocks.NewMockCache(ctrl)
+	useCase := NewMaintenanceUseCase(mockCache, "Back soon", zap.NewNop())
+
+	gomock.InOrder(
+		mockCache.EXPECT().Exists(gomock.Any(), "maintenance_mode").Return(true, nil),
+		mockCache.EXPECT().Exists(gomock.Any(), "maintenance_mode").Return(false, nil),
+		mockCache.EXPECT().Exists(gomock.Any(), "maintenance_mode").Return(false, assert.AnError),
+	)
+
+	assert.True(t, useCase.IsActive())
+	assert.False(t, useCase.IsActive())
+	// Fails open: a Redis outage must not hold every queue
+	assert.False(t, useCase.IsActive())
+}
+
+func TestMaintenanceUseCase_StatusWhenOff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCache := mocks.NewMockCache(ctrl)
+	useCase := NewMaintenanceUseCase(mockCache, "Back soon", zap.NewNop())
+
+	mockCache.EXPECT().Exists(gomock.Any(), "maintenance_mode").Return(false, nil)
+
+	maintenance, err := useCase.Status()
+	require.NoError(t, err)
+	assert.False(t, maintenance.Enabled)
+}
+
+func TestMaintenanceUseCase_ClaimNotice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCache := mocks.NewMockCache(ctrl)
+	useCase := NewMaintenanceUseCase(mockCache, "Back soon", zap.NewNop())
+
+	stored := `{"enabled":true,"notice":"Back soon","enabled_by":"admin:ab12","enabled_at":"2024-05-01T09:00:00Z"}`
+	sentKey := "maintenance_notice_sent:1714554000000000000:C123"
+	mockCache.EXPECT().Exists(gomock.Any(), "maintenance_mode").Return(true, nil).Times(2)
+	mockCache.EXPECT().Get(gomock.Any(), "maintenance_mode").Return(stored, nil).Times(2)
+	gomock.InOrder(
+		mockCache.EXPECT().Exists(gomock.Any(), sentKey).Return(false, nil),
+		mockCache.EXPECT().Exists(gomock.Any(), sentKey).Return(true, nil),
+	)
+	mockCache.EXPECT().Set(gomock.Any(), sentKey, "1", maintenanceNoticeTTL).Return(nil)
+
+	notice, ok := useCase.ClaimNotice("C123")
+	assert.True(t, ok)
+	assert.Equal(t, "Back soon", notice)
+
+	// The channel was already notified during this maintenance
+	_, ok = useCase.ClaimNotice("C123")
+	assert.False(t, ok)
+}
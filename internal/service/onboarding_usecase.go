@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+// onboardingSessionTTL is how long, in seconds, the user who installed the
+// app has to finish the setup wizard
+const onboardingSessionTTL = 7 * 24 * 3600
+
+// OnboardingPublisher shows the current step of a setup wizard, posting its
+// message the first time and updating it afterwards, e.g. slack.OnboardingWizard.
+// It returns where the message is.
+type OnboardingPublisher interface {
+	PublishOnboarding(ctx context.Context, session *model.OnboardingSession) (channelID, messageTS string, err error)
+}
+
+// ChannelTemplateApplier writes a configuration to several channels, e.g. ChannelUseCase
+type ChannelTemplateApplier interface {
+	ApplyChannelTemplate(template *model.ChannelConfig, selector model.ChannelSelector, dryRun bool) ([]model.BulkChannelResult, error)
+}
+
+// BudgetSetter records the monthly AI budget of a workspace, e.g. WorkspaceUseCase
+type BudgetSetter interface {
+	SetMonthlyBudget(teamID string, budget float64) (*model.Workspace, error)
+}
+
+var _ OnboardingService = (*OnboardingUseCase)(nil)
+
+// OnboardingUseCase walks the user who installed the app through its setup
+// in a direct message: the language to translate to, the channels to
+// translate and a monthly budget. Progress is kept in the cache until the
+// last step writes the channel configurations and the workspace's budget.
+type OnboardingUseCase struct {
+	cache      Cache
+	channels   ChannelTemplateApplier
+	workspaces BudgetSetter
+	wizard     OnboardingPublisher
+	logger     *zap.Logger
+}
+
+func NewOnboardingUseCase(cache Cache, channels ChannelTemplateApplier, workspaces BudgetSetter, wizard OnboardingPublisher, logger *zap.Logger) *OnboardingUseCase {
+	return &OnboardingUseCase{
+		cache:      cache,
+		channels:   channels,
+		workspaces: workspaces,
+		wizard:     wizard,
+		logger:     logger,
+	}
+}
+
+// Start sends the setup wizard to userID, who installed the app in teamID
+func (ou *OnboardingUseCase) Start(ctx context.Context, teamID, userID string) error {
+	session := &model.OnboardingSession{TeamID: teamID, UserID: userID, Step: model.OnboardingStepLanguage}
+	if err := ou.publish(ctx, session); err != nil {
+		return err
+	}
+
+	ou.logger.Info("Setup wizard sent",
+		zap.String("team_id", teamID),
+		zap.String("user_id", userID))
+	return nil
+}
+
+// ChooseLanguage records the language the channels translate to and moves
+// on to picking the channels
+func (ou *OnboardingUseCase) ChooseLanguage(ctx context.Context, teamID, userID, languageCode string) error {
+	if !model.IsSupportedLanguageCode(languageCode) {
+		return model.NewValidationError(fmt.Sprintf("unsupported language: %s", languageCode))
+	}
+
+	session, err := ou.session(ctx, teamID, userID)
+	if err != nil {
+		return err
+	}
+	session.TargetLanguage = languageCode
+	session.Step = model.OnboardingStepChannels
+	return ou.publish(ctx, session)
+}
+
+// ChooseChannels records the channels to translate and moves on to the budget
+func (ou *OnboardingUseCase) ChooseChannels(ctx context.Context, teamID, userID string, channelIDs []string) error {
+	if len(channelIDs) == 0 {
+		return model.NewValidationError("Pick at least one channel to translate")
+	}
+
+	session, err := ou.session(ctx, teamID, userID)
+	if err != nil {
+		return err
+	}
+	if session.TargetLanguage == "" {
+		return model.NewValidationError("Pick a language first")
+	}
+	session.ChannelIDs = channelIDs
+	session.Step = model.OnboardingStepBudget
+	return ou.publish(ctx, session)
+}
+
+// ChooseBudget records the monthly budget and finishes the setup: the chosen
+// channels are configured to translate to the chosen language, replacing
+// their configuration, and the workspace gets the budget
+func (ou *OnboardingUseCase) ChooseBudget(ctx context.Context, teamID, userID string, budget float64) error {
+	if budget < 0 {
+		return model.NewValidationError("monthly_budget must not be negative")
+	}
+
+	session, err := ou.session(ctx, teamID, userID)
+	if err != nil {
+		return err
+	}
+	if session.TargetLanguage == "" || len(session.ChannelIDs) == 0 {
+		return model.NewValidationError("Pick a language and channels first")
+	}
+
+	template := &model.ChannelConfig{
+		TargetLanguage: session.TargetLanguage,
+		Enabled:        true,
+		AutoTranslate:  true,
+	}
+	results, err := ou.channels.ApplyChannelTemplate(template, model.ChannelSelector{ChannelIDs: session.ChannelIDs}, false)
+	if err != nil {
+		return fmt.Errorf("failed to configure channels: %w", err)
+	}
+	if _, err := ou.workspaces.SetMonthlyBudget(teamID, budget); err != nil {
+		return fmt.Errorf("failed to set monthly budget: %w", err)
+	}
+
+	session.MonthlyBudget = budget
+	session.Results = results
+	session.Step = model.OnboardingStepDone
+	if _, _, err := ou.wizard.PublishOnboarding(ctx, session); err != nil {
+		ou.logger.Warn("Failed to show finished setup wizard",
+			zap.Error(err),
+			zap.String("team_id", teamID))
+	}
+	if err := ou.cache.Delete(ctx, onboardingKey(teamID, userID)); err != nil {
+		ou.logger.Warn("Failed to delete setup wizard session",
+			zap.Error(err),
+			zap.String("team_id", teamID))
+	}
+
+	ou.logger.Info("Setup wizard finished",
+		zap.String("team_id", teamID),
+		zap.String("user_id", userID),
+		zap.String("target_language", session.TargetLanguage),
+		zap.Int("channels", len(session.ChannelIDs)),
+		zap.Float64("monthly_budget", budget))
+	return nil
+}
+
+// session returns the user's progress through the wizard of the workspace
+func (ou *OnboardingUseCase) session(ctx context.Context, teamID, userID string) (*model.OnboardingSession, error) {
+	// Misses are errors in some caches, so any failure ends the wizard
+	cached, err := ou.cache.Get(ctx, onboardingKey(teamID, userID))
+	if err != nil || cached == "" {
+		return nil, model.NewNotFoundError("This setup has expired. Configure channels through the admin API, or reinstall the app to start over")
+	}
+
+	session := &model.OnboardingSession{}
+	if err := json.Unmarshal([]byte(cached), session); err != nil {
+		return nil, fmt.Errorf("failed to decode setup wizard: %w", err)
+	}
+	return session, nil
+}
+
+// publish shows the session's step and saves the session
+func (ou *OnboardingUseCase) publish(ctx context.Context, session *model.OnboardingSession) error {
+	channelID, messageTS, err := ou.wizard.PublishOnboarding(ctx, session)
+	if err != nil {
+		return fmt.Errorf("failed to show setup wizard: %w", err)
+	}
+	session.ChannelID, session.MessageTS = channelID, messageTS
+
+	encoded, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to encode setup wizard: %w", err)
+	}
+	if err := ou.cache.Set(ctx, onboardingKey(session.TeamID, session.UserID), string(encoded), onboardingSessionTTL); err != nil {
+		return fmt.Errorf("failed to save setup wizard: %w", err)
+	}
+	return nil
+}
+
+func onboardingKey(teamID, userID string) string {
+	return fmt.Sprintf("onboarding:%s:%s", teamID, userID)
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeOnboardingPublisher struct {
+	steps []string
+}
+
+func (f *fakeOnboardingPublisher) PublishOnboarding(_ context.Context, session *model.OnboardingSession) (string, string, error) {
+	f.steps = append(f.steps, session.Step)
+	return "D1", "1.1", nil
+}
+
+type fakeTemplateApplier struct {
+	template *model.ChannelConfig
+	selector model.ChannelSelector
+}
+
+func (f *fakeTemplateApplier) ApplyChannelTemplate(template *model.ChannelConfig, selector model.ChannelSelector, _ bool) ([]model.BulkChannelResult, error) {
+	f.template, f.selector = template, selector
+	results := make([]model.BulkChannelResult, 0, len(selector.ChannelIDs))
+	for _, channelID := range selector.ChannelIDs {
+		results = append(results, model.BulkChannelResult{ChannelID: channelID, Status: model.BulkStatusApplied})
+	}
+	return results, nil
+}
+
+type fakeBudgetSetter map[string]float64
+
+func (f fakeBudgetSetter) SetMonthlyBudget(teamID string, budget float64) (*model.Workspace, error) {
+	f[teamID] = budget
+	return &model.Workspace{TeamID: teamID, MonthlyBudget: budget}, nil
+}
+
+func isValidationError(err error) bool {
+	var domainErr *model.DomainError
+	return errors.As(err, &domainErr) && domainErr.Type == model.ErrorTypeValidation
+}
+
+func TestOnboardingUseCase(t *testing.T) {
+	ctx := context.Background()
+	cache := fakeCache{}
+	wizard := &fakeOnboardingPublisher{}
+	channels := &fakeTemplateApplier{}
+	budgets := fakeBudgetSetter{}
+	useCase := NewOnboardingUseCase(cache, channels, budgets, wizard, zap.NewNop())
+
+	require.NoError(t, useCase.Start(ctx, "T1", "U1"))
+	assert.Contains(t, cache[onboardingKey("T1", "U1")], `"message_ts":"1.1"`)
+
+	err := useCase.ChooseChannels(ctx, "T1", "U1", []string{"C1"})
+	assert.True(t, isValidationError(err), "channels before a language")
+	assert.True(t, isValidationError(useCase.ChooseLanguage(ctx, "T1", "U1", "klingon")))
+
+	require.NoError(t, useCase.ChooseLanguage(ctx, "T1", "U1", "ja"))
+	assert.True(t, isValidationError(useCase.ChooseChannels(ctx, "T1", "U1", nil)))
+	require.NoError(t, useCase.ChooseChannels(ctx, "T1", "U1", []string{"C1", "C2"}))
+	require.NoError(t, useCase.ChooseBudget(ctx, "T1", "U1", 50))
+
+	assert.Equal(t, []string{
+		model.OnboardingStepLanguage,
+		model.OnboardingStepChannels,
+		model.OnboardingStepBudget,
+		model.OnboardingStepDone,
+	}, wizard.steps)
+	assert.Equal(t, &model.ChannelConfig{TargetLanguage: "ja", Enabled: true, AutoTranslate: true}, channels.template)
+	assert.Equal(t, []string{"C1", "C2"}, channels.selector.ChannelIDs)
+	assert.Equal(t, 50.0, budgets["T1"])
+	assert.Empty(t, cache, "the session ends with the wizard")
+
+	err = useCase.ChooseBudget(ctx, "T1", "U1", 10)
+	assert.True(t, model.IsNotFound(err), "finished wizards cannot be replayed")
+}
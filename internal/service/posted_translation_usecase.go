@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+// postedTranslationTTL is how long edits of a message still update its translation
+const postedTranslationTTL = 7 * 24 * time.Hour
+
+// PostedTranslationUseCase remembers which reply translates each message.
+// Entries are kept in the cache (Redis), so an edit of the message can be
+// handled by any instance until the entry expires.
+type PostedTranslationUseCase struct {
+	cache  Cache
+	logger *zap.Logger
+}
+
+func NewPostedTranslationUseCase(cache Cache, logger *zap.Logger) *PostedTranslationUseCase {
+	return &PostedTranslationUseCase{
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// Remember keeps the reply posted with the translation of a message
+func (pu *PostedTranslationUseCase) Remember(posted *model.PostedTranslation) error {
+	encoded, err := json.Marshal(posted)
+	if err != nil {
+		return fmt.Errorf("failed to encode posted translation: %w", err)
+	}
+	ttl := int64(postedTranslationTTL / time.Second)
+	if err := pu.cache.Set(context.Background(), postedTranslationKey(posted.ChannelID, posted.MessageTS), string(encoded), ttl); err != nil {
+		return fmt.Errorf("failed to remember posted translation: %w", err)
+	}
+	return nil
+}
+
+// Find returns the reply translating the message messageTS, or false when
+// none was posted or it expired
+func (pu *PostedTranslationUseCase) Find(channelID, messageTS string) (*model.PostedTranslation, bool, error) {
+	key := postedTranslationKey(channelID, messageTS)
+	exists, err := pu.cache.Exists(context.Background(), key)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to look up posted translation: %w", err)
+	}
+	if !exists {
+		return nil, false, nil
+	}
+	cached, err := pu.cache.Get(context.Background(), key)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to look up posted translation: %w", err)
+	}
+	posted := &model.PostedTranslation{}
+	if err := json.Unmarshal([]byte(cached), posted); err != nil {
+		return nil, false, fmt.Errorf("failed to decode posted translation: %w", err)
+	}
+	return posted, true, nil
+}
+
+// Forget drops the reply of a message, e.g. once it was deleted
+func (pu *PostedTranslationUseCase) Forget(channelID, messageTS string) error {
+	if err := pu.cache.Delete(context.Background(), postedTranslationKey(channelID, messageTS)); err != nil {
+		return fmt.Errorf("failed to forget posted translation: %w", err)
+	}
+	return nil
+}
+
+func postedTranslationKey(channelID, messageTS string) string {
+	return fmt.Sprintf("posted_translation:%s:%s", channelID, messageTS)
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPostedTranslationUseCase(t *testing.T) {
+	const key = "posted_translation:C1:1700000000.000100"
+	encoded := `{"channel_id":"C1","message_ts":"1700000000.000100","reply_channel_id":"C2","reply_ts":"1700000000.000200"}`
+
+	t.Run("remember", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCache := mocks.NewMockCache(ctrl)
+		useCase := NewPostedTranslationUseCase(mockCache, zap.NewNop())
+		mockCache.EXPECT().Set(gomock.Any(), key, encoded, int64(7*24*3600)).Return(nil)
+
+		err := useCase.Remember(&model.PostedTranslation{
+			ChannelID:      "C1",
+			MessageTS:      "1700000000.000100",
+			ReplyChannelID: "C2",
+			ReplyTS:        "1700000000.000200",
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("find", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCache := mocks.NewMockCache(ctrl)
+		useCase := NewPostedTranslationUseCase(mockCache, zap.NewNop())
+		mockCache.EXPECT().Exists(gomock.Any(), key).Return(true, nil)
+		mockCache.EXPECT().Get(gomock.Any(), key).Return(encoded, nil)
+
+		posted, found, err := useCase.Find("C1", "1700000000.000100")
+		require.NoError(t, err)
+		require.True(t, found)
+		assert.Equal(t, "C2", posted.ReplyChannelID)
+		assert.Equal(t, "1700000000.000200", posted.ReplyTS)
+	})
+
+	t.Run("not posted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCache := mocks.NewMockCache(ctrl)
+		useCase := NewPostedTranslationUseCase(mockCache, zap.NewNop())
+		mockCache.EXPECT().Exists(gomock.Any(), key).Return(false, nil)
+
+		_, found, err := useCase.Find("C1", "1700000000.000100")
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("forget", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCache := mocks.NewMockCache(ctrl)
+		useCase := NewPostedTranslationUseCase(mockCache, zap.NewNop())
+		mockCache.EXPECT().Delete(gomock.Any(), key).Return(nil)
+
+		assert.NoError(t, useCase.Forget("C1", "1700000000.000100"))
+	})
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/ai"
+	"go.uber.org/zap"
+)
+
+const promptDeploymentKey = "prompt_deployment"
+
+var _ PromptDeploymentService = (*PromptDeploymentUseCase)(nil)
+
+// PromptDeploymentUseCase switches the translation prompt served to regular
+// channels blue/green style. The pointer to the active version lives in the
+// cache (Redis) and is replaced with a single write, so every instance moves
+// to the new version with its next translation.
+type PromptDeploymentUseCase struct {
+	cache  Cache
+	logger *zap.Logger
+	now    func() time.Time
+}
+
+func NewPromptDeploymentUseCase(cache Cache, logger *zap.Logger) *PromptDeploymentUseCase {
+	return &PromptDeploymentUseCase{
+		cache:  cache,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Status returns the active prompt version. Until a version is activated,
+// the providers serve ai.StablePromptVersion.
+func (pu *PromptDeploymentUseCase) Status() (*model.PromptDeployment, error) {
+	deployment := &model.PromptDeployment{ActiveVersion: ai.StablePromptVersion}
+
+	exists, err := pu.cache.Exists(context.Background(), promptDeploymentKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prompt deployment: %w", err)
+	}
+	if exists {
+		cached, err := pu.cache.Get(context.Background(), promptDeploymentKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get prompt deployment: %w", err)
+		}
+		if err := json.Unmarshal([]byte(cached), deployment); err != nil {
+			return nil, fmt.Errorf("failed to decode prompt deployment: %w", err)
+		}
+	}
+
+	deployment.Versions = ai.PromptVersions()
+	return deployment, nil
+}
+
+// Activate serves version to regular channels, keeping the active version
+// for Rollback. Activating the active version changes nothing.
+func (pu *PromptDeploymentUseCase) Activate(version, switchedBy string) (*model.PromptDeployment, error) {
+	if !ai.IsPromptVersion(version) {
+		return nil, model.NewValidationError(fmt.Sprintf("unknown prompt version %q, expected one of %v", version, ai.PromptVersions()))
+	}
+
+	current, err := pu.Status()
+	if err != nil {
+		return nil, err
+	}
+	if current.ActiveVersion == version {
+		return current, nil
+	}
+	return pu.switchTo(version, current.ActiveVersion, switchedBy)
+}
+
+// Rollback serves the previous version again. Rolling back twice returns to
+// the version that was rolled back.
+func (pu *PromptDeploymentUseCase) Rollback(switchedBy string) (*model.PromptDeployment, error) {
+	current, err := pu.Status()
+	if err != nil {
+		return nil, err
+	}
+	if current.PreviousVersion == "" {
+		return nil, model.NewBadRequestError("no previous prompt version to roll back to")
+	}
+	return pu.switchTo(current.PreviousVersion, current.ActiveVersion, switchedBy)
+}
+
+func (pu *PromptDeploymentUseCase) switchTo(version, previous, switchedBy string) (*model.PromptDeployment, error) {
+	switchedAt := pu.now().UTC()
+	deployment := &model.PromptDeployment{
+		ActiveVersion:   version,
+		PreviousVersion: previous,
+		SwitchedBy:      switchedBy,
+		SwitchedAt:      &switchedAt,
+	}
+
+	encoded, err := json.Marshal(deployment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode prompt deployment: %w", err)
+	}
+	if err := pu.cache.Set(context.Background(), promptDeploymentKey, string(encoded), 0); err != nil {
+		return nil, fmt.Errorf("failed to switch prompt version: %w", err)
+	}
+
+	pu.logger.Warn("Prompt version switched",
+		zap.String("version", version),
+		zap.String("previous_version", previous),
+		zap.String("switched_by", switchedBy))
+	deployment.Versions = ai.PromptVersions()
+	return deployment, nil
+}
+
+// ActivePromptVersion returns the version to translate with, or "" to keep
+// the providers' own when none was activated or the cache cannot be reached
+func (pu *PromptDeploymentUseCase) ActivePromptVersion() string {
+	cached, err := pu.cache.Get(context.Background(), promptDeploymentKey)
+	if err != nil || cached == "" {
+		return ""
+	}
+	var deployment model.PromptDeployment
+	if err := json.Unmarshal([]byte(cached), &deployment); err != nil {
+		pu.logger.Warn("Failed to decode prompt deployment, using the default prompt", zap.Error(err))
+		return ""
+	}
+	return deployment.ActiveVersion
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/ai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newPromptDeploymentFixture backs the mock cache with a map, like Redis
+func newPromptDeploymentFixture(t *testing.T) *PromptDeploymentUseCase {
+	ctrl := gomock.NewController(t)
+	mockCache := mocks.NewMockCache(ctrl)
+	stored := map[string]string{}
+	mockCache.EXPECT().Exists(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key string) (bool, error) {
+		_, ok := stored[key]
+		return ok, nil
+	}).AnyTimes()
+	mockCache.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key string) (string, error) {
+		value, ok := stored[key]
+		if !ok {
+			return "", errors.New("redis: nil")
+		}
+		return value, nil
+	}).AnyTimes()
+	mockCache.EXPECT().Set(gomock.Any(), "prompt_deployment", gomock.Any(), int64(0)).DoAndReturn(func(_ context.Context, key, value string, ttl int64) error {
+		stored[key] = value
+		return nil
+	}).AnyTimes()
+
+	useCase := NewPromptDeploymentUseCase(mockCache, zap.NewNop())
+	useCase.now = func() time.Time { return time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC) }
+	return useCase
+}
+
+func TestPromptDeploymentUseCase_ActivateAndRollback(t *testing.T) {
+	useCase := newPromptDeploymentFixture(t)
+
+	status, err := useCase.Status()
+	require.NoError(t, err)
+	assert.Equal(t, ai.StablePromptVersion, status.ActiveVersion)
+	assert.Equal(t, ai.PromptVersions(), status.Versions)
+	assert.Empty(t, useCase.ActivePromptVersion())
+
+	_, err = useCase.Rollback("admin:ab12")
+	var domainErr *model.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, model.ErrorTypeBadRequest, domainErr.Type)
+
+	deployment, err := useCase.Activate(ai.LatestPromptVersion, "admin:ab12")
+	require.NoError(t, err)
+	assert.Equal(t, ai.LatestPromptVersion, deployment.ActiveVersion)
+	assert.Equal(t, ai.StablePromptVersion, deployment.PreviousVersion)
+	assert.Equal(t, "admin:ab12", deployment.SwitchedBy)
+	assert.Equal(t, ai.LatestPromptVersion, useCase.ActivePromptVersion())
+
+	deployment, err = useCase.Rollback("admin:cd34")
+	require.NoError(t, err)
+	assert.Equal(t, ai.StablePromptVersion, deployment.ActiveVersion)
+	assert.Equal(t, ai.LatestPromptVersion, deployment.PreviousVersion)
+	assert.Equal(t, ai.StablePromptVersion, useCase.ActivePromptVersion())
+
+	status, err = useCase.Status()
+	require.NoError(t, err)
+	assert.Equal(t, "admin:cd34", status.SwitchedBy)
+}
+
+func TestPromptDeploymentUseCase_Activate(t *testing.T) {
+	t.Run("unknown version", func(t *testing.T) {
+		useCase := newPromptDeploymentFixture(t)
+
+		_, err := useCase.Activate("v99", "admin:ab12")
+		var domainErr *model.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, model.ErrorTypeValidation, domainErr.Type)
+	})
+
+	t.Run("active version keeps the previous one", func(t *testing.T) {
+		useCase := newPromptDeploymentFixture(t)
+		_, err := useCase.Activate(ai.LatestPromptVersion, "admin:ab12")
+		require.NoError(t, err)
+
+		deployment, err := useCase.Activate(ai.LatestPromptVersion, "admin:cd34")
+		require.NoError(t, err)
+		assert.Equal(t, ai.StablePromptVersion, deployment.PreviousVersion)
+		assert.Equal(t, "admin:ab12", deployment.SwitchedBy)
+	})
+}
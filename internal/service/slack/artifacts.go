@@ -0,0 +1,82 @@
+package slack
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/storage"
+	"go.uber.org/zap"
+)
+
+// ArtifactStore holds the attachments downloaded from Slack and what is read
+// from them, e.g. a storage.Store whose objects expire after a day
+type ArtifactStore interface {
+	Create(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// WithArtifactStore keeps downloaded attachments and the text read from
+// images in store, so a file shared once is downloaded and read once
+func WithArtifactStore(store ArtifactStore) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.artifacts = store
+	}
+}
+
+// artifactKey returns the key of the kind of artifact of file in the
+// workspace of ctx, or "" when artifacts are not kept
+func (ep *eventProcessorImpl) artifactKey(ctx context.Context, kind string, file FileInfo) string {
+	if ep.artifacts == nil || file.ID == "" {
+		return ""
+	}
+	teamID := teamFrom(ctx)
+	if teamID == "" {
+		teamID = "default"
+	}
+	return kind + "/" + teamID + "/" + file.ID
+}
+
+// loadArtifact returns the artifact of key, or false when there is none.
+// Failures are logged: the artifact is then made again.
+func (ep *eventProcessorImpl) loadArtifact(ctx context.Context, key string) ([]byte, bool) {
+	if key == "" {
+		return nil, false
+	}
+	data, err := ep.artifacts.Get(ctx, key)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			ep.logger.Warn("Failed to read artifact", zap.Error(err), zap.String("key", key))
+		}
+		return nil, false
+	}
+	return data, true
+}
+
+// saveArtifact keeps data under key. Failures are logged: only later reuse
+// is lost.
+func (ep *eventProcessorImpl) saveArtifact(ctx context.Context, key string, data []byte, contentType string) {
+	if key == "" {
+		return
+	}
+	err := ep.artifacts.Create(ctx, key, data, contentType)
+	// Another event may have kept the same artifact meanwhile
+	if err != nil && !errors.Is(err, storage.ErrExists) {
+		ep.logger.Warn("Failed to store artifact", zap.Error(err), zap.String("key", key))
+	}
+}
+
+// downloadFile returns the content of file, of at most limit bytes, keeping
+// it as an artifact
+func (ep *eventProcessorImpl) downloadFile(ctx context.Context, downloader fileDownloader, file FileInfo, limit int) ([]byte, error) {
+	key := ep.artifactKey(ctx, "attachments", file)
+	if data, ok := ep.loadArtifact(ctx, key); ok {
+		return data, nil
+	}
+
+	buf := &limitedBuffer{limit: limit}
+	if err := downloader.DownloadFile(ctx, file.URL, buf); err != nil {
+		return nil, err
+	}
+	ep.saveArtifact(ctx, key, buf.Bytes(), file.Mimetype)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,83 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"go.uber.org/zap"
+)
+
+// WithDirectMessageAssistant answers direct messages to the bot with
+// assistant, e.g. service.DirectMessageUseCase, instead of translating them
+// like channel messages
+func WithDirectMessageAssistant(assistant service.DirectMessageService) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.assistant = assistant
+	}
+}
+
+// isDirectMessage reports whether a message event was sent in a direct
+// message with the bot
+func isDirectMessage(event map[string]interface{}) bool {
+	channelType, _ := event["channel_type"].(string)
+	return channelType == "im"
+}
+
+// handleDirectMessage answers a user's direct message with the assistant's
+// reply, posted in the conversation rather than in a thread
+func (ep *eventProcessorImpl) handleDirectMessage(ctx context.Context, channelID, userID, ts, text string) {
+	slackClient := ep.client(ctx)
+	if ep.rateLimiter != nil && !ep.withinRateLimit(slackClient, channelID, userID, ts) {
+		return
+	}
+
+	ep.addReaction(slackClient, channelID, ts, reactionReceived)
+	outcome := reactionFailed
+	defer func() {
+		ep.finishReactions(slackClient, channelID, ts, outcome)
+	}()
+
+	reply, err := ep.assistant.Reply(ctx, channelID, userID, text)
+	if err != nil {
+		ep.logger.Error("Failed to answer direct message",
+			zap.Error(err),
+			zap.String("channel_id", channelID),
+			zap.String("user_id", userID))
+		if _, _, postErr := slackClient.PostMessage(channelID, directMessageError(err), ""); postErr != nil {
+			ep.logger.Error("Failed to post direct message error",
+				zap.Error(postErr),
+				zap.String("channel_id", channelID))
+		}
+		return
+	}
+
+	text = reply.Notice
+	if reply.Translation != nil {
+		text = fmt.Sprintf("%s %s", languageFlag(reply.Translation.TargetLanguage), quoteMentions(reply.Translation.TranslatedText))
+	}
+	if _, _, err := slackClient.PostMessage(channelID, text, ""); err != nil {
+		ep.logger.Error("Failed to post direct message reply",
+			zap.Error(err),
+			zap.String("channel_id", channelID))
+		return
+	}
+	outcome = reactionTranslated
+}
+
+// directMessageError is the reply to a direct message that could not be answered
+func directMessageError(err error) string {
+	var domainErr *model.DomainError
+	if errors.As(err, &domainErr) && domainErr.Type == model.ErrorTypeValidation {
+		return "⚠️ " + domainErr.Message
+	}
+	if service.IsInputRejected(err) {
+		return service.MessageInvalidInput
+	}
+	if message, ok := service.ProviderErrorMessage(err); ok {
+		return message
+	}
+	return service.MessageTranslationFailed
+}
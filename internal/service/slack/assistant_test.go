@@ -0,0 +1,59 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// fakeAssistant records the direct messages it answers
+type fakeAssistant struct {
+	messages []string
+}
+
+func (f *fakeAssistant) Reply(_ context.Context, channelID, userID, text string) (response.DirectMessage, error) {
+	f.messages = append(f.messages, channelID+"/"+userID+": "+text)
+	return response.DirectMessage{Notice: "ok"}, nil
+}
+
+func TestEventProcessorHandleMessageEvent_DirectMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	assistant := &fakeAssistant{}
+	processor := NewEventProcessor(mocks.NewMockTranslationService(ctrl), &SlackClient{}, zap.NewNop(),
+		WithDirectMessageAssistant(assistant)).(*eventProcessorImpl)
+
+	// No expectations on the translation service: direct messages go to the assistant
+	processor.handleMessageEvent(context.Background(), map[string]interface{}{
+		"type":         "message",
+		"channel_type": "im",
+		"user":         "U1",
+		"channel":      "D1",
+		"text":         "to:ja Hello",
+		"ts":           "1234567890.123456",
+	})
+	processor.handleMessageEvent(context.Background(), map[string]interface{}{
+		"type":         "message",
+		"channel_type": "im",
+		"user":         "U1",
+		"channel":      "D1",
+		"text":         "  ",
+		"ts":           "1234567890.123457",
+	})
+
+	assert.Equal(t, []string{"D1/U1: to:ja Hello"}, assistant.messages)
+}
+
+func TestDirectMessageError(t *testing.T) {
+	assert.Equal(t, "⚠️ unknown language", directMessageError(model.NewValidationError("unknown language")))
+	assert.Equal(t, service.MessageTranslationFailed, directMessageError(errors.New("boom")))
+}
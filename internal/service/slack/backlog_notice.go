@@ -0,0 +1,29 @@
+package slack
+
+import "go.uber.org/zap"
+
+// backlogNoticeText is posted to a channel whose translations are queued up
+const backlogNoticeText = "⏳ I'm a bit behind, translations may be delayed."
+
+// BacklogNotice posts a notice to a channel whose translation queue has backed up
+type BacklogNotice struct {
+	poster MessagePoster
+	logger *zap.Logger
+}
+
+func NewBacklogNotice(poster MessagePoster, logger *zap.Logger) *BacklogNotice {
+	return &BacklogNotice{
+		poster: poster,
+		logger: logger,
+	}
+}
+
+// NotifyBacklog posts the delay notice to the channel
+func (n *BacklogNotice) NotifyBacklog(channelID string, depth int) {
+	if _, _, err := n.poster.PostMessage(channelID, backlogNoticeText, ""); err != nil {
+		n.logger.Error("Failed to post backlog notice",
+			zap.String("channel_id", channelID),
+			zap.Int("depth", depth),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,142 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+var _ service.ChannelSetupPublisher = (*ChannelSetupMessenger)(nil)
+
+// ChannelSetupMessenger posts the setup message of a channel the bot was
+// invited to, with a button for each language it can translate to
+type ChannelSetupMessenger struct {
+	slackClient *SlackClient
+	clients     *ClientPool
+}
+
+func NewChannelSetupMessenger(slackClient *SlackClient) *ChannelSetupMessenger {
+	return &ChannelSetupMessenger{slackClient: slackClient}
+}
+
+// SetWorkspaceClients posts with the client of the workspace the channel is in
+func (m *ChannelSetupMessenger) SetWorkspaceClients(clients *ClientPool) {
+	m.clients = clients
+}
+
+// PublishChannelSetup implements service.ChannelSetupPublisher
+func (m *ChannelSetupMessenger) PublishChannelSetup(ctx context.Context, config *model.ChannelConfig, messageTS, chosenBy string) (string, error) {
+	client := m.slackClient
+	if m.clients != nil {
+		client = m.clients.ForTeam(teamFrom(ctx))
+	}
+	if client.client == nil {
+		return "", fmt.Errorf("slack client is not initialized")
+	}
+
+	opts := []slack.MsgOption{
+		slack.MsgOptionText(fmt.Sprintf("Messages in this channel are translated to %s", onboardingLanguage(config.TargetLanguage)), false),
+		slack.MsgOptionBlocks(channelSetupBlocks(config, chosenBy)...),
+	}
+	if messageTS == "" {
+		_, ts, err := client.client.PostMessageContext(ctx, config.ChannelID, opts...)
+		return ts, err
+	}
+	_, ts, _, err := client.client.UpdateMessageContext(ctx, config.ChannelID, messageTS, opts...)
+	return ts, err
+}
+
+// channelSetupBlocks builds the setup message: the language the channel
+// translates to and a button for each language, the current one highlighted
+func channelSetupBlocks(config *model.ChannelConfig, chosenBy string) []slack.Block {
+	text := fmt.Sprintf("👋 Thanks for inviting me! Messages in this channel are translated to *%s*.",
+		onboardingLanguage(config.TargetLanguage))
+	if chosenBy != "" {
+		text = fmt.Sprintf("✅ <@%s> set this channel to translate to *%s*.", chosenBy, onboardingLanguage(config.TargetLanguage))
+	}
+
+	codes := model.SupportedLanguageCodes()
+	buttons := make([]slack.BlockElement, 0, len(codes))
+	for _, code := range codes {
+		name, _ := model.LanguageName(code)
+		button := slack.NewButtonBlockElement(model.ChannelSetupActionID(code), code,
+			slack.NewTextBlockObject("plain_text", fmt.Sprintf("%s %s", languageFlags[code], name), true, false))
+		if code == config.TargetLanguage {
+			button = button.WithStyle(slack.StylePrimary)
+		}
+		buttons = append(buttons, button)
+	}
+
+	return []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", text, false, false), nil, nil),
+		slack.NewActionBlock(model.ChannelSetupBlockID, buttons...),
+		slack.NewContextBlock("", slack.NewTextBlockObject("mrkdwn",
+			"Pick the language messages here are translated to. Source languages, reviews and digests can be set through the admin API.", false, false)),
+	}
+}
+
+// WithChannelOnboarding sets up the channels the bot is invited to, posting
+// a message to pick the language they translate to. A nil onboarding
+// leaves invites alone.
+func WithChannelOnboarding(onboarding service.ChannelOnboardingService) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		if onboarding == nil {
+			return
+		}
+		ep.channelOnboarding = onboarding
+		ep.handlers["member_joined_channel"] = EventHandlerFunc(ep.handleMemberJoinedChannel)
+	}
+}
+
+// handleMemberJoinedChannel sets up the channel when the member who joined
+// is the bot; other members are ignored
+func (ep *eventProcessorImpl) handleMemberJoinedChannel(ctx context.Context, event map[string]interface{}) {
+	userID, _ := event["user"].(string)
+	channelID, _ := event["channel"].(string)
+	inviterID, _ := event["inviter"].(string)
+	if userID == "" || channelID == "" {
+		ep.logger.Error("Failed to get member_joined_channel user or channel")
+		return
+	}
+
+	botUserID, err := ep.client(ctx).BotUserID(ctx)
+	if err != nil {
+		ep.logger.Error("Failed to get bot user ID",
+			zap.Error(err),
+			zap.String("channel_id", channelID))
+		return
+	}
+	if userID != botUserID {
+		return
+	}
+
+	if err := ep.channelOnboarding.BotJoined(ctx, channelID, inviterID); err != nil {
+		ep.logger.Error("Failed to set up channel",
+			zap.Error(err),
+			zap.String("channel_id", channelID))
+	}
+}
+
+// BotUserID returns the user of the bot the client's token belongs to,
+// asking Slack once
+func (sc *SlackClient) BotUserID(ctx context.Context) (string, error) {
+	sc.botUserMu.Lock()
+	defer sc.botUserMu.Unlock()
+	if sc.botUserID != "" {
+		return sc.botUserID, nil
+	}
+	if sc.client == nil {
+		return "", fmt.Errorf("slack client is not initialized")
+	}
+
+	resp, err := sc.client.AuthTestContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	sc.botUserID = resp.UserID
+	return sc.botUserID, nil
+}
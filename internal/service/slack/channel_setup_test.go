@@ -0,0 +1,60 @@
+package slack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type fakeChannelOnboarding struct {
+	joined []string
+}
+
+func (f *fakeChannelOnboarding) BotJoined(_ context.Context, channelID, inviterID string) error {
+	f.joined = append(f.joined, channelID+"/"+inviterID)
+	return nil
+}
+
+func (f *fakeChannelOnboarding) ChooseLanguage(_ context.Context, _, _, _, _ string) error {
+	return nil
+}
+
+func TestEventProcessor_MemberJoinedChannel(t *testing.T) {
+	onboarding := &fakeChannelOnboarding{}
+	processor := NewEventProcessor(nil, &SlackClient{botUserID: "UBOT"}, zap.NewNop(), WithChannelOnboarding(onboarding))
+
+	for _, userID := range []string{"U1", "UBOT"} {
+		processor.ProcessEvent(context.Background(), map[string]interface{}{
+			"type": "event_callback",
+			"event": map[string]interface{}{
+				"type":    "member_joined_channel",
+				"user":    userID,
+				"channel": "C1",
+				"inviter": "U1",
+			},
+		})
+	}
+
+	assert.Equal(t, []string{"C1/U1"}, onboarding.joined, "only the bot joining sets up the channel")
+}
+
+func TestChannelSetupBlocks(t *testing.T) {
+	blocks := channelSetupBlocks(&model.ChannelConfig{ChannelID: "C1", TargetLanguage: "ja"}, "U2")
+
+	section := blocks[0].(*slack.SectionBlock)
+	assert.Equal(t, "✅ <@U2> set this channel to translate to *🇯🇵 Japanese*.", section.Text.Text)
+
+	actions := blocks[1].(*slack.ActionBlock)
+	assert.Equal(t, model.ChannelSetupBlockID, actions.BlockID)
+	assert.Len(t, actions.Elements.ElementSet, len(model.SupportedLanguageCodes()))
+	for _, element := range actions.Elements.ElementSet {
+		button := element.(*slack.ButtonBlockElement)
+		code, ok := model.ChannelSetupLanguage(button.ActionID)
+		assert.True(t, ok)
+		assert.Equal(t, code == "ja", button.Style == slack.StylePrimary, code)
+	}
+}
@@ -1,19 +1,30 @@
 package slack
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
-	"strings"
+	"io"
+	"sync"
 
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
 	"github.com/slack-go/slack"
 )
 
 type SlackClient struct {
-	client *slack.Client
+	client   *slack.Client
+	token    string
+	recorder PostRecorder
+
+	botUserMu sync.Mutex
+	botUserID string
 }
 
 func NewSlackClient(token string) *SlackClient {
 	return &SlackClient{
 		client: slack.New(token),
+		token:  token,
 	}
 }
 
@@ -112,13 +123,7 @@ func (sc *SlackClient) PostMessageWithBotInfoAndFiles(channelID, text string, th
 		// Note: We use permalinks instead of url_private because Image Blocks
 		// cannot access Slack's private URLs (they require auth headers)
 		for _, file := range files {
-			if file.Permalink != "" {
-				// Use different emoji for images vs other files
-				emoji := "📎"
-				if strings.HasPrefix(file.Mimetype, "image/") {
-					emoji = "🖼️"
-				}
-				contextText := fmt.Sprintf("%s <%s|%s>", emoji, file.Permalink, file.Name)
+			if contextText, ok := fileContextText(file); ok {
 				contextBlock := slack.NewContextBlock("",
 					slack.NewTextBlockObject("mrkdwn", contextText, false, false),
 				)
@@ -198,13 +203,7 @@ func (sc *SlackClient) PostMessageWithBotInfoAsQuoteAndFiles(channelID, text str
 
 	// Add context blocks for all files (images and documents)
 	for _, file := range files {
-		if file.Permalink != "" {
-			// Use different emoji for images vs other files
-			emoji := "📎"
-			if strings.HasPrefix(file.Mimetype, "image/") {
-				emoji = "🖼️"
-			}
-			contextText := fmt.Sprintf("%s <%s|%s>", emoji, file.Permalink, file.Name)
+		if contextText, ok := fileContextText(file); ok {
 			contextBlock := slack.NewContextBlock("",
 				slack.NewTextBlockObject("mrkdwn", contextText, false, false),
 			)
@@ -239,12 +238,101 @@ func (sc *SlackClient) GetUserInfo(userID string) (*slack.User, error) {
 	return sc.client.GetUserInfo(userID)
 }
 
+// AddReaction reacts to a message as the bot. Reacting again with the same
+// emoji is not an error, so retries can add their reactions again.
 func (sc *SlackClient) AddReaction(emoji, channelID, timestamp string) error {
 	if sc.client == nil {
 		return nil // Silently return nil in test scenarios
 	}
-	return sc.client.AddReaction(emoji, slack.ItemRef{
+	err := sc.client.AddReaction(emoji, slack.ItemRef{
+		Channel:   channelID,
+		Timestamp: timestamp,
+	})
+	if isSlackError(err, "already_reacted") {
+		return nil
+	}
+	return err
+}
+
+// RemoveReaction removes the bot's reaction from a message. Removing a
+// reaction the bot did not add is not an error.
+func (sc *SlackClient) RemoveReaction(emoji, channelID, timestamp string) error {
+	if sc.client == nil {
+		return nil // Silently return nil in test scenarios
+	}
+	err := sc.client.RemoveReaction(emoji, slack.ItemRef{
 		Channel:   channelID,
 		Timestamp: timestamp,
 	})
+	if isSlackError(err, "no_reaction") {
+		return nil
+	}
+	return err
+}
+
+// isSlackError reports whether err is the Slack API error code, e.g. "no_reaction"
+func isSlackError(err error, code string) bool {
+	var slackErr slack.SlackErrorResponse
+	return errors.As(err, &slackErr) && slackErr.Err == code
+}
+
+// DownloadFile writes a file shared in Slack to w, fetching its private URL
+// with the bot token
+func (sc *SlackClient) DownloadFile(ctx context.Context, url string, w io.Writer) error {
+	if sc.client == nil {
+		return fmt.Errorf("slack client is not initialized")
+	}
+	return sc.client.GetFileContext(ctx, url, w)
+}
+
+// UploadFile shares data as a file from the bot in the thread threadTS of channelID
+func (sc *SlackClient) UploadFile(ctx context.Context, channelID, threadTS, filename string, data []byte) error {
+	if sc.client == nil {
+		return fmt.Errorf("slack client is not initialized")
+	}
+	_, err := sc.client.UploadFileV2Context(ctx, slack.UploadFileV2Parameters{
+		Reader:          bytes.NewReader(data),
+		FileSize:        len(data),
+		Filename:        filename,
+		Title:           filename,
+		Channel:         channelID,
+		ThreadTimestamp: threadTS,
+	})
+	return err
+}
+
+// ListChannels returns all public and private channels visible to the bot
+func (sc *SlackClient) ListChannels() ([]model.SlackChannel, error) {
+	if sc.client == nil {
+		return nil, fmt.Errorf("slack client is not initialized")
+	}
+
+	channels := []model.SlackChannel{}
+	params := &slack.GetConversationsParameters{
+		Types:           []string{"public_channel", "private_channel"},
+		ExcludeArchived: true,
+		Limit:           200,
+	}
+
+	for {
+		page, nextCursor, err := sc.client.GetConversations(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list conversations: %w", err)
+		}
+		for _, ch := range page {
+			channels = append(channels, model.SlackChannel{ID: ch.ID, Name: ch.Name})
+		}
+		if nextCursor == "" {
+			return channels, nil
+		}
+		params.Cursor = nextCursor
+	}
+}
+
+// GetUserGroupMembers returns the user IDs belonging to a Slack user group
+func (sc *SlackClient) GetUserGroupMembers(groupID string) ([]string, error) {
+	if sc.client == nil {
+		return nil, fmt.Errorf("slack client is not initialized")
+	}
+	return sc.client.GetUserGroupMembers(groupID)
 }
@@ -0,0 +1,73 @@
+package slack
+
+import (
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+// crossPostEventType marks cross-posted translations in their message
+// metadata, so a relay of a paired channel never translates them again
+const crossPostEventType = "translation_crosspost"
+
+// ChannelPairLookup returns the channel translations of a channel are cross-posted to
+type ChannelPairLookup interface {
+	PairedChannel(channelID string) (string, bool)
+}
+
+// PostCrossPost posts a translation as a top-level message in the paired
+// channel, with a link back to the channel it was translated from
+func (sc *SlackClient) PostCrossPost(channelID, sourceChannelID, text string, asQuote bool, username string, avatarURL string, files []FileInfo) (string, string, error) {
+	if sc.client == nil {
+		return "", "", fmt.Errorf("slack client is not initialized")
+	}
+
+	if asQuote {
+		text = "> " + text
+	}
+	blocks := []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", text, false, false), nil, nil),
+		slack.NewContextBlock("",
+			slack.NewTextBlockObject("mrkdwn", fmt.Sprintf("Translated from <#%s>", sourceChannelID), false, false),
+		),
+	}
+	for _, file := range files {
+		contextText, ok := fileContextText(file)
+		if !ok {
+			continue
+		}
+		blocks = append(blocks, slack.NewContextBlock("",
+			slack.NewTextBlockObject("mrkdwn", contextText, false, false),
+		))
+	}
+
+	opts := []slack.MsgOption{
+		slack.MsgOptionText(text, false),
+		slack.MsgOptionBlocks(blocks...),
+		slack.MsgOptionMetadata(slack.SlackMetadata{
+			EventType:    crossPostEventType,
+			EventPayload: map[string]interface{}{"source_channel_id": sourceChannelID},
+		}),
+	}
+
+	if username != "" {
+		opts = append(opts, slack.MsgOptionUsername(username))
+	}
+
+	if avatarURL != "" {
+		opts = append(opts, slack.MsgOptionIconURL(avatarURL))
+	}
+
+	channel, ts, err := sc.client.PostMessage(channelID, opts...)
+	return channel, ts, err
+}
+
+// isCrossPost reports whether a message event is a translation cross-posted from a paired channel
+func isCrossPost(event map[string]interface{}) bool {
+	metadata, ok := event["metadata"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	eventType, _ := metadata["event_type"].(string)
+	return eventType == crossPostEventType
+}
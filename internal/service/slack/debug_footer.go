@@ -0,0 +1,84 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/slack-go/slack"
+)
+
+// debugFooter describes how a translation was produced, for the replies of
+// debug channels, e.g. "🔍 gemini · gemini-1.5-flash · prompt v2 · AI · 850 ms"
+func debugFooter(result response.Translation) string {
+	parts := []string{}
+	if result.Provider != "" {
+		parts = append(parts, result.Provider)
+	}
+	if result.Model != "" {
+		parts = append(parts, result.Model)
+	}
+	if result.PromptVersion != "" {
+		parts = append(parts, "prompt "+result.PromptVersion)
+	}
+
+	switch result.Source {
+	case response.SourceCache:
+		parts = append(parts, "cache hit")
+	case response.SourceDatabase:
+		parts = append(parts, "database hit")
+	default:
+		parts = append(parts, fmt.Sprintf("AI (%d+%d tokens)", result.PromptTokens, result.OutputTokens))
+	}
+	parts = append(parts, fmt.Sprintf("%d ms", result.LatencyMS))
+
+	return "🔍 " + strings.Join(parts, " · ")
+}
+
+// PostMessageWithFooter posts a message like PostMessageWithBotInfoAndFiles,
+// or as a quote like PostMessageWithBotInfoAsQuoteAndFiles, followed by
+// footer in a context block
+func (sc *SlackClient) PostMessageWithFooter(channelID, text, threadTS string, asQuote bool, username, avatarURL string, files []FileInfo, footer string) (string, string, error) {
+	if sc.client == nil {
+		return "", "", fmt.Errorf("slack client is not initialized")
+	}
+
+	if asQuote {
+		text = "> " + text
+	}
+	blocks := []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", text, false, false), nil, nil),
+	}
+	for _, file := range files {
+		contextText, ok := fileContextText(file)
+		if !ok {
+			continue
+		}
+		blocks = append(blocks, slack.NewContextBlock("",
+			slack.NewTextBlockObject("mrkdwn", contextText, false, false),
+		))
+	}
+	blocks = append(blocks, slack.NewContextBlock("",
+		slack.NewTextBlockObject("mrkdwn", footer, false, false),
+	))
+
+	opts := []slack.MsgOption{
+		slack.MsgOptionText(text, false),
+		slack.MsgOptionBlocks(blocks...),
+	}
+
+	if threadTS != "" {
+		opts = append(opts, slack.MsgOptionTS(threadTS))
+	}
+
+	if username != "" {
+		opts = append(opts, slack.MsgOptionUsername(username))
+	}
+
+	if avatarURL != "" {
+		opts = append(opts, slack.MsgOptionIconURL(avatarURL))
+	}
+
+	channel, ts, err := sc.client.PostMessage(channelID, opts...)
+	return channel, ts, err
+}
@@ -0,0 +1,80 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// translationDeleter deletes a translation the bot posted, e.g. SlackClient
+type translationDeleter interface {
+	DeleteMessage(channelID, ts string) error
+}
+
+// DeleteMessage deletes a message the bot posted. Deleting a message that is
+// already gone is not an error.
+func (sc *SlackClient) DeleteMessage(channelID, ts string) error {
+	if sc.client == nil {
+		return fmt.Errorf("slack client is not initialized")
+	}
+	_, _, err := sc.client.DeleteMessage(channelID, ts)
+	if isSlackError(err, "message_not_found") {
+		return nil
+	}
+	return err
+}
+
+// handleMessageDeleted deletes the translation posted for a deleted message,
+// so it does not linger in the thread or the paired channel
+func (ep *eventProcessorImpl) handleMessageDeleted(ctx context.Context, event map[string]interface{}) {
+	if ep.posted == nil {
+		ep.logger.Debug("Skipping deleted message")
+		return
+	}
+	channelID, _ := event["channel"].(string)
+	ts, _ := event["deleted_ts"].(string)
+	if channelID == "" || ts == "" {
+		ep.logger.Error("Failed to get deleted message")
+		return
+	}
+	ep.deleteTranslation(ep.client(ctx), channelID, ts)
+}
+
+// deleteTranslation deletes the reply posted with the translation of the
+// message ts, if any
+func (ep *eventProcessorImpl) deleteTranslation(deleter translationDeleter, channelID, ts string) {
+	posted, found, err := ep.posted.Find(channelID, ts)
+	if err != nil {
+		ep.logger.Error("Failed to look up posted translation",
+			zap.Error(err),
+			zap.String("channel_id", channelID),
+			zap.String("timestamp", ts))
+		return
+	}
+	if !found {
+		ep.logger.Debug("Deleted message has no posted translation, skipping",
+			zap.String("channel_id", channelID),
+			zap.String("timestamp", ts))
+		return
+	}
+
+	if err := deleter.DeleteMessage(posted.ReplyChannelID, posted.ReplyTS); err != nil {
+		ep.logger.Error("Failed to delete translation of deleted message",
+			zap.Error(err),
+			zap.String("channel_id", posted.ReplyChannelID),
+			zap.String("reply_ts", posted.ReplyTS))
+		return
+	}
+	if err := ep.posted.Forget(channelID, ts); err != nil {
+		ep.logger.Warn("Failed to forget posted translation",
+			zap.Error(err),
+			zap.String("channel_id", channelID),
+			zap.String("timestamp", ts))
+	}
+
+	ep.logger.Info("Translation deleted with its message",
+		zap.String("channel_id", channelID),
+		zap.String("timestamp", ts),
+		zap.String("reply_ts", posted.ReplyTS))
+}
@@ -0,0 +1,63 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// fakeDeleter records deleted messages by channel and ts
+type fakeDeleter struct {
+	deleted []string
+	err     error
+}
+
+func (f *fakeDeleter) DeleteMessage(channelID, ts string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.deleted = append(f.deleted, channelID+"/"+ts)
+	return nil
+}
+
+func TestEventProcessorDeleteTranslation(t *testing.T) {
+	posted := fakePostedTranslations{}
+	processor := NewEventProcessor(nil, nil, zap.NewNop(), WithEditRetranslation(posted)).(*eventProcessorImpl)
+	processor.rememberTranslation("C1", "1700000000.000100", "C2", "1700000000.000200")
+
+	deleter := &fakeDeleter{}
+	processor.deleteTranslation(deleter, "C1", "1700000000.000100")
+	assert.Equal(t, []string{"C2/1700000000.000200"}, deleter.deleted)
+	assert.Empty(t, posted)
+
+	// Messages without a posted translation are left alone
+	processor.deleteTranslation(deleter, "C1", "1700000000.000300")
+	assert.Len(t, deleter.deleted, 1)
+}
+
+func TestEventProcessorDeleteTranslation_Fails(t *testing.T) {
+	posted := fakePostedTranslations{}
+	processor := NewEventProcessor(nil, nil, zap.NewNop(), WithEditRetranslation(posted)).(*eventProcessorImpl)
+	processor.rememberTranslation("C1", "1700000000.000100", "C1", "1700000000.000200")
+
+	processor.deleteTranslation(&fakeDeleter{err: errors.New("cant_delete_message")}, "C1", "1700000000.000100")
+
+	// The reply is remembered until it is deleted
+	assert.Contains(t, posted, "C1/1700000000.000100")
+}
+
+func TestEventProcessorHandleMessageDeleted_Disabled(t *testing.T) {
+	processor := NewEventProcessor(nil, nil, zap.NewNop()).(*eventProcessorImpl)
+
+	// Without WithEditRetranslation nothing is looked up
+	processor.handleMessageEvent(context.Background(), map[string]interface{}{
+		"type":       "message",
+		"subtype":    "message_deleted",
+		"channel":    "C1",
+		"deleted_ts": "1700000000.000100",
+		"hidden":     true,
+	})
+}
@@ -0,0 +1,97 @@
+package slack
+
+import (
+	"fmt"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/slack-go/slack"
+)
+
+// replyPoster posts the bot's replies to a message, e.g. SlackClient
+type replyPoster interface {
+	PostMessageWithBotInfo(channelID, text string, threadTS string, username string, avatarURL string) (string, string, error)
+	PostEphemeral(channelID, userID, text, threadTS, username, avatarURL string) error
+	PostDirectMessage(userID, sourceChannelID, text, username, avatarURL string) error
+}
+
+// PostEphemeral posts a message only userID sees, in the thread of threadTS
+// when set. Ephemeral messages are not stored, so they have no permanent ts.
+func (sc *SlackClient) PostEphemeral(channelID, userID, text, threadTS, username, avatarURL string) error {
+	if sc.client == nil {
+		return fmt.Errorf("slack client is not initialized")
+	}
+
+	opts := []slack.MsgOption{
+		slack.MsgOptionText(text, false),
+	}
+
+	if threadTS != "" {
+		opts = append(opts, slack.MsgOptionTS(threadTS))
+	}
+
+	if username != "" {
+		opts = append(opts, slack.MsgOptionUsername(username))
+	}
+
+	if avatarURL != "" {
+		opts = append(opts, slack.MsgOptionIconURL(avatarURL))
+	}
+
+	_, err := sc.client.PostEphemeral(channelID, userID, opts...)
+	return err
+}
+
+// PostDirectMessage sends a message to userID in a direct message with the
+// bot, with a link back to the channel it was translated from
+func (sc *SlackClient) PostDirectMessage(userID, sourceChannelID, text, username, avatarURL string) error {
+	if sc.client == nil {
+		return fmt.Errorf("slack client is not initialized")
+	}
+
+	channel, _, _, err := sc.client.OpenConversation(&slack.OpenConversationParameters{Users: []string{userID}})
+	if err != nil {
+		return fmt.Errorf("failed to open direct message: %w", err)
+	}
+
+	blocks := []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", text, false, false), nil, nil),
+		slack.NewContextBlock("",
+			slack.NewTextBlockObject("mrkdwn", fmt.Sprintf("Translated from <#%s>", sourceChannelID), false, false),
+		),
+	}
+
+	opts := []slack.MsgOption{
+		slack.MsgOptionText(text, false),
+		slack.MsgOptionBlocks(blocks...),
+	}
+
+	if username != "" {
+		opts = append(opts, slack.MsgOptionUsername(username))
+	}
+
+	if avatarURL != "" {
+		opts = append(opts, slack.MsgOptionIconURL(avatarURL))
+	}
+
+	_, _, err = sc.client.PostMessage(channel.ID, opts...)
+	return err
+}
+
+// postReply posts text in the thread of threadTS, or only to userID when the
+// channel delivers its translations privately
+func postReply(poster replyPoster, config *model.ChannelConfig, channelID, userID, threadTS, text, botName, botAvatar string) error {
+	if config == nil || userID == "" {
+		_, _, err := poster.PostMessageWithBotInfo(channelID, text, threadTS, botName, botAvatar)
+		return err
+	}
+
+	switch config.DeliveryMode {
+	case model.DeliveryModeEphemeral:
+		return poster.PostEphemeral(channelID, userID, text, threadTS, botName, botAvatar)
+	case model.DeliveryModeDM:
+		return poster.PostDirectMessage(userID, channelID, text, botName, botAvatar)
+	default:
+		_, _, err := poster.PostMessageWithBotInfo(channelID, text, threadTS, botName, botAvatar)
+		return err
+	}
+}
@@ -0,0 +1,57 @@
+package slack
+
+import (
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeReplyPoster records where replies were posted
+type fakeReplyPoster struct {
+	posted []string
+}
+
+func (f *fakeReplyPoster) PostMessageWithBotInfo(channelID, text, threadTS, _, _ string) (string, string, error) {
+	f.posted = append(f.posted, "thread "+channelID+"/"+threadTS+": "+text)
+	return channelID, "1700000000.000200", nil
+}
+
+func (f *fakeReplyPoster) PostEphemeral(channelID, userID, text, threadTS, _, _ string) error {
+	f.posted = append(f.posted, "ephemeral "+channelID+"/"+threadTS+" to "+userID+": "+text)
+	return nil
+}
+
+func (f *fakeReplyPoster) PostDirectMessage(userID, sourceChannelID, text, _, _ string) error {
+	f.posted = append(f.posted, "dm to "+userID+" from "+sourceChannelID+": "+text)
+	return nil
+}
+
+func TestPostReply(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *model.ChannelConfig
+		userID   string
+		expected string
+	}{
+		{name: "no configuration", userID: "U1", expected: "thread C1/1700000000.000100: Xin chào"},
+		{name: "thread", config: &model.ChannelConfig{DeliveryMode: model.DeliveryModeThread}, userID: "U1",
+			expected: "thread C1/1700000000.000100: Xin chào"},
+		{name: "ephemeral", config: &model.ChannelConfig{DeliveryMode: model.DeliveryModeEphemeral}, userID: "U1",
+			expected: "ephemeral C1/1700000000.000100 to U1: Xin chào"},
+		{name: "dm", config: &model.ChannelConfig{DeliveryMode: model.DeliveryModeDM}, userID: "U1",
+			expected: "dm to U1 from C1: Xin chào"},
+		{name: "unknown user", config: &model.ChannelConfig{DeliveryMode: model.DeliveryModeDM},
+			expected: "thread C1/1700000000.000100: Xin chào"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poster := &fakeReplyPoster{}
+			err := postReply(poster, tt.config, "C1", tt.userID, "1700000000.000100", "Xin chào", "Bot", "")
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.expected}, poster.posted)
+		})
+	}
+}
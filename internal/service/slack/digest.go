@@ -0,0 +1,161 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+// maxDigestMessageLength keeps each digest message under Slack's 40,000 character limit
+const maxDigestMessageLength = 35000
+
+// DigestChannelLookup reports a channel's configuration, including its digest settings
+type DigestChannelLookup interface {
+	GetChannelConfig(channelID string) (*model.ChannelConfig, error)
+}
+
+// DigestEntry is one translated message waiting in a channel digest
+type DigestEntry struct {
+	UserID         string
+	TranslatedText string
+	ReceivedAt     time.Time
+}
+
+type pendingDigest struct {
+	entries  []DigestEntry
+	deadline time.Time
+}
+
+// Digest batches the translations of chatty channels into one periodic
+// message instead of a thread reply per message. A channel's digest is posted
+// every digest_interval_minutes or once it holds digest_max_messages
+// translations, whichever comes first.
+type Digest struct {
+	channels DigestChannelLookup
+	poster   MessagePoster
+	logger   *zap.Logger
+
+	mu      sync.Mutex
+	pending map[string]*pendingDigest
+}
+
+func NewDigest(channels DigestChannelLookup, poster MessagePoster, logger *zap.Logger) *Digest {
+	return &Digest{
+		channels: channels,
+		poster:   poster,
+		logger:   logger,
+		pending:  make(map[string]*pendingDigest),
+	}
+}
+
+// Collect adds the translation to the channel's digest and reports whether
+// it did. It returns false for channels that are not in digest mode.
+func (d *Digest) Collect(channelID string, entry DigestEntry) bool {
+	config, err := d.channels.GetChannelConfig(channelID)
+	if err != nil || config == nil || !config.DigestEnabled() {
+		return false
+	}
+
+	d.mu.Lock()
+	digest, ok := d.pending[channelID]
+	if !ok {
+		digest = &pendingDigest{}
+		if config.DigestIntervalMinutes > 0 {
+			digest.deadline = entry.ReceivedAt.Add(time.Duration(config.DigestIntervalMinutes) * time.Minute)
+		}
+		d.pending[channelID] = digest
+	}
+	digest.entries = append(digest.entries, entry)
+
+	var full []DigestEntry
+	if config.DigestMaxMessages > 0 && len(digest.entries) >= config.DigestMaxMessages {
+		full = digest.entries
+		delete(d.pending, channelID)
+	}
+	d.mu.Unlock()
+
+	if full != nil {
+		d.post(channelID, full)
+	}
+	return true
+}
+
+// Run posts the digests that are due every interval until ctx is done, then
+// posts whatever is still pending
+func (d *Digest) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			d.flush(func(digest *pendingDigest) bool {
+				return !digest.deadline.IsZero() && !now.Before(digest.deadline)
+			})
+		case <-ctx.Done():
+			d.Flush()
+			return
+		}
+	}
+}
+
+// Flush posts every pending digest
+func (d *Digest) Flush() {
+	d.flush(func(*pendingDigest) bool { return true })
+}
+
+func (d *Digest) flush(due func(*pendingDigest) bool) {
+	d.mu.Lock()
+	ready := make(map[string][]DigestEntry)
+	for channelID, digest := range d.pending {
+		if due(digest) {
+			ready[channelID] = digest.entries
+			delete(d.pending, channelID)
+		}
+	}
+	d.mu.Unlock()
+
+	for channelID, entries := range ready {
+		d.post(channelID, entries)
+	}
+}
+
+func (d *Digest) post(channelID string, entries []DigestEntry) {
+	for _, text := range formatDigest(entries) {
+		if _, _, err := d.poster.PostMessage(channelID, text, ""); err != nil {
+			d.logger.Error("Failed to post translation digest",
+				zap.String("channel_id", channelID),
+				zap.Int("translations", len(entries)),
+				zap.Error(err))
+			return
+		}
+	}
+	d.logger.Info("Translation digest posted",
+		zap.String("channel_id", channelID),
+		zap.Int("translations", len(entries)))
+}
+
+// formatDigest renders the entries as one or more messages, splitting between
+// entries so no message exceeds maxDigestMessageLength
+func formatDigest(entries []DigestEntry) []string {
+	header := fmt.Sprintf("🗞️ *Translation digest* (%d messages)", len(entries))
+
+	var messages []string
+	var b strings.Builder
+	b.WriteString(header)
+	for _, entry := range entries {
+		line := fmt.Sprintf("\n• <@%s>: %s", entry.UserID, entry.TranslatedText)
+		if b.Len()+len(line) > maxDigestMessageLength && b.Len() > len(header) {
+			messages = append(messages, b.String())
+			b.Reset()
+			b.WriteString(header + " (continued)")
+		}
+		b.WriteString(line)
+	}
+	return append(messages, b.String())
+}
@@ -0,0 +1,82 @@
+package slack
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeDigestChannels map[string]*model.ChannelConfig
+
+func (f fakeDigestChannels) GetChannelConfig(channelID string) (*model.ChannelConfig, error) {
+	config, ok := f[channelID]
+	if !ok {
+		return nil, model.NewNotFoundError("channel config not found")
+	}
+	return config, nil
+}
+
+func TestDigest_Collect(t *testing.T) {
+	channels := fakeDigestChannels{
+		"CCHATTY": {ChannelID: "CCHATTY", DigestIntervalMinutes: 10, DigestMaxMessages: 3},
+		"CQUIET":  {ChannelID: "CQUIET"},
+	}
+	poster := &fakePoster{}
+	digest := NewDigest(channels, poster, zap.NewNop())
+	now := time.Now()
+
+	assert.False(t, digest.Collect("CQUIET", DigestEntry{UserID: "U1", TranslatedText: "Hello", ReceivedAt: now}))
+	assert.False(t, digest.Collect("CUNKNOWN", DigestEntry{UserID: "U1", TranslatedText: "Hello", ReceivedAt: now}))
+
+	assert.True(t, digest.Collect("CCHATTY", DigestEntry{UserID: "U1", TranslatedText: "Xin chào", ReceivedAt: now}))
+	assert.True(t, digest.Collect("CCHATTY", DigestEntry{UserID: "U2", TranslatedText: "Tạm biệt", ReceivedAt: now}))
+	assert.Empty(t, poster.messages)
+
+	// The third message fills the digest
+	assert.True(t, digest.Collect("CCHATTY", DigestEntry{UserID: "U1", TranslatedText: "Cảm ơn", ReceivedAt: now}))
+	require.Len(t, poster.messages, 1)
+	assert.Equal(t, "CCHATTY", poster.channelID)
+	assert.Contains(t, poster.messages[0], "(3 messages)")
+	assert.Contains(t, poster.messages[0], "• <@U2>: Tạm biệt")
+}
+
+func TestDigest_FlushDueDigests(t *testing.T) {
+	channels := fakeDigestChannels{
+		"C1": {ChannelID: "C1", DigestIntervalMinutes: 5},
+		"C2": {ChannelID: "C2", DigestIntervalMinutes: 30},
+	}
+	poster := &fakePoster{}
+	digest := NewDigest(channels, poster, zap.NewNop())
+	start := time.Now()
+
+	digest.Collect("C1", DigestEntry{UserID: "U1", TranslatedText: "one", ReceivedAt: start})
+	digest.Collect("C2", DigestEntry{UserID: "U1", TranslatedText: "two", ReceivedAt: start})
+
+	now := start.Add(6 * time.Minute)
+	digest.flush(func(d *pendingDigest) bool { return !now.Before(d.deadline) })
+	require.Len(t, poster.messages, 1)
+	assert.Equal(t, "C1", poster.channelID)
+
+	digest.Flush()
+	require.Len(t, poster.messages, 2)
+	assert.Equal(t, "C2", poster.channelID)
+}
+
+func TestFormatDigest_SplitsLongDigests(t *testing.T) {
+	long := strings.Repeat("a", 20000)
+	messages := formatDigest([]DigestEntry{
+		{UserID: "U1", TranslatedText: long},
+		{UserID: "U2", TranslatedText: long},
+	})
+
+	require.Len(t, messages, 2)
+	assert.Contains(t, messages[1], "(continued)")
+	for _, message := range messages {
+		assert.LessOrEqual(t, len(message), maxDigestMessageLength)
+	}
+}
@@ -0,0 +1,287 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// PostedTranslations remembers the reply posted with the translation of each
+// message, e.g. service.PostedTranslationUseCase
+type PostedTranslations interface {
+	Remember(posted *model.PostedTranslation) error
+	Find(channelID, messageTS string) (*model.PostedTranslation, bool, error)
+	Forget(channelID, messageTS string) error
+}
+
+// translationUpdater replaces the text of a translation the bot posted, e.g. SlackClient
+type translationUpdater interface {
+	UpdateTranslation(channelID, ts string, reply TranslationContent) error
+}
+
+// TranslationContent is what a translation reply shows, rendered as blocks
+// when the reply is updated
+type TranslationContent struct {
+	Text    string
+	AsQuote bool
+	Files   []FileInfo
+	// SourceChannelID links back to the channel a cross-posted translation
+	// was translated from
+	SourceChannelID string
+	// Footer is shown below debug channels' translations
+	Footer string
+	// MessageTS is the message translated; when set, the reply shows the
+	// buttons acting on it
+	MessageTS string
+}
+
+// blocks renders the reply like the Post* methods render new translations
+func (r TranslationContent) blocks() []slack.Block {
+	text := r.Text
+	if r.AsQuote {
+		text = "> " + text
+	}
+	blocks := []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", text, false, false), nil, nil),
+	}
+	if r.SourceChannelID != "" {
+		blocks = append(blocks, slack.NewContextBlock("",
+			slack.NewTextBlockObject("mrkdwn", fmt.Sprintf("Translated from <#%s>", r.SourceChannelID), false, false),
+		))
+	}
+	for _, file := range r.Files {
+		contextText, ok := fileContextText(file)
+		if !ok {
+			continue
+		}
+		blocks = append(blocks, slack.NewContextBlock("",
+			slack.NewTextBlockObject("mrkdwn", contextText, false, false),
+		))
+	}
+	if r.Footer != "" {
+		blocks = append(blocks, slack.NewContextBlock("",
+			slack.NewTextBlockObject("mrkdwn", r.Footer, false, false),
+		))
+	}
+	if r.MessageTS != "" {
+		blocks = append(blocks, translationActions(r.MessageTS))
+	}
+	return blocks
+}
+
+// UpdateTranslation replaces a translation the bot posted with reply
+func (sc *SlackClient) UpdateTranslation(channelID, ts string, reply TranslationContent) error {
+	if sc.client == nil {
+		return fmt.Errorf("slack client is not initialized")
+	}
+
+	text := reply.Text
+	if reply.AsQuote {
+		text = "> " + text
+	}
+	_, _, _, err := sc.client.UpdateMessage(channelID, ts,
+		slack.MsgOptionText(text, false),
+		slack.MsgOptionBlocks(reply.blocks()...),
+	)
+	return err
+}
+
+// rememberTranslation lets edits of the message ts update the reply replyTS
+// posted in replyChannelID with its translation
+func (ep *eventProcessorImpl) rememberTranslation(channelID, ts, replyChannelID, replyTS string) {
+	if ep.posted == nil || replyTS == "" {
+		return
+	}
+	err := ep.posted.Remember(&model.PostedTranslation{
+		ChannelID:      channelID,
+		MessageTS:      ts,
+		ReplyChannelID: replyChannelID,
+		ReplyTS:        replyTS,
+	})
+	if err != nil {
+		ep.logger.Warn("Failed to remember posted translation, edits will not update it",
+			zap.Error(err),
+			zap.String("channel_id", channelID),
+			zap.String("timestamp", ts))
+	}
+}
+
+// handleMessageChanged retranslates an edited message and updates the reply
+// posted with its earlier translation. Messages whose translation was held
+// for review, added to a digest or never posted are not retranslated.
+// A message deleted while it has thread replies is replaced by a tombstone,
+// reported as an edit; its translation is deleted.
+func (ep *eventProcessorImpl) handleMessageChanged(ctx context.Context, event map[string]interface{}) {
+	if ep.posted == nil {
+		ep.logger.Debug("Skipping edited message")
+		return
+	}
+	slackClient := ep.client(ctx)
+	if message, ok := event["message"].(map[string]interface{}); ok {
+		if subtype, _ := message["subtype"].(string); subtype == "tombstone" {
+			channelID, _ := event["channel"].(string)
+			ts, _ := message["ts"].(string)
+			ep.deleteTranslation(slackClient, channelID, ts)
+			return
+		}
+	}
+	ep.retranslateEdit(ctx, slackClient, event)
+}
+
+func (ep *eventProcessorImpl) retranslateEdit(ctx context.Context, updater translationUpdater, event map[string]interface{}) {
+	channelID, _ := event["channel"].(string)
+	message, ok := event["message"].(map[string]interface{})
+	if !ok || channelID == "" {
+		ep.logger.Error("Failed to get edited message")
+		return
+	}
+	ts, _ := message["ts"].(string)
+	userID, _ := message["user"].(string)
+	text, _ := message["text"].(string)
+
+	if _, ok := message["bot_id"].(string); ok || isCrossPost(message) {
+		ep.logger.Debug("Skipping edited bot message")
+		return
+	}
+	// Slack also reports link unfurls and other changes that leave the text as is
+	if previous, ok := event["previous_message"].(map[string]interface{}); ok {
+		if previousText, _ := previous["text"].(string); previousText == text {
+			ep.logger.Debug("Edited message text is unchanged, skipping",
+				zap.String("channel_id", channelID),
+				zap.String("timestamp", ts))
+			return
+		}
+	}
+
+	posted, found, err := ep.posted.Find(channelID, ts)
+	if err != nil {
+		ep.logger.Error("Failed to look up posted translation",
+			zap.Error(err),
+			zap.String("channel_id", channelID),
+			zap.String("timestamp", ts))
+		return
+	}
+	if !found {
+		ep.logger.Debug("Edited message has no posted translation, skipping",
+			zap.String("channel_id", channelID),
+			zap.String("timestamp", ts))
+		return
+	}
+
+	if ep.pauses != nil && ep.pauses.IsPaused(channelID) {
+		ep.logger.Info("Translation paused in channel, skipping edited message",
+			zap.String("channel_id", channelID),
+			zap.String("timestamp", ts))
+		return
+	}
+	channelConfig := ep.channelConfig(channelID)
+	if channelConfig != nil && (!channelConfig.Enabled || !channelConfig.AutoTranslate) {
+		ep.logger.Debug("Translation disabled for channel, skipping edited message",
+			zap.String("channel_id", channelID))
+		return
+	}
+
+	trimmedText := strings.TrimSpace(text)
+	if trimmedText == "" || isEmojiOnly(text) || isUserMentionOnly(text) {
+		ep.logger.Info("Edited message has nothing to translate, keeping its translation",
+			zap.String("channel_id", channelID),
+			zap.String("timestamp", ts))
+		return
+	}
+	if ep.messageFilter != nil {
+		if allowed, reason := ep.messageFilter.ShouldTranslate(model.FilterInput{
+			ChannelID: channelID,
+			UserID:    userID,
+			Text:      text,
+		}); !allowed {
+			ep.logger.Info("Edited message filtered by channel rules, keeping its translation",
+				zap.String("channel_id", channelID),
+				zap.String("reason", reason))
+			return
+		}
+	}
+
+	detectedLang, err := ep.detectLanguage(ctx, text)
+	if err != nil {
+		return
+	}
+	// Route like the original message, so the reply keeps its language
+	targetLang, ok := ep.routeForUser(ep.userPreference(userID), channelConfig, detectedLang)
+	if !ok {
+		ep.logger.Info("Edited message language is not translated, keeping its translation",
+			zap.String("channel_id", channelID),
+			zap.String("detected_language", detectedLang))
+		return
+	}
+
+	result, err := ep.translationUseCase.TranslateContext(ctx, request.Translation{
+		Text:           text,
+		SourceLanguage: detectedLang,
+		TargetLanguage: targetLang,
+		UserID:         userID,
+		ChannelID:      channelID,
+	})
+	if err != nil {
+		ep.logger.Error("Failed to translate edited message",
+			zap.Error(err),
+			zap.String("channel_id", channelID),
+			zap.String("timestamp", ts))
+		return
+	}
+
+	files := applyFilePrivacy(ep.extractFiles(message), ep.filePrivacy)
+	reply := TranslationContent{
+		Text:    quoteMentions(result.TranslatedText),
+		AsQuote: containsAtHereOrChannel(text),
+	}
+	if posted.ReplyChannelID != channelID {
+		reply.SourceChannelID = channelID
+		reply.Files = files
+	} else {
+		reply.Files = ep.withoutThumbnails(files)
+	}
+	if channelConfig != nil && channelConfig.Debug {
+		reply.Footer = debugFooter(result)
+	}
+	if ep.replyButtons && posted.ReplyChannelID == channelID {
+		reply.MessageTS = ts
+	}
+
+	if err := updater.UpdateTranslation(posted.ReplyChannelID, posted.ReplyTS, reply); err != nil {
+		ep.logger.Error("Failed to update translation of edited message",
+			zap.Error(err),
+			zap.String("channel_id", posted.ReplyChannelID),
+			zap.String("reply_ts", posted.ReplyTS))
+		return
+	}
+	// Ratings of the updated reply count for the new translation
+	ep.rememberReply(posted.ReplyChannelID, posted.ReplyTS, result)
+
+	ep.logger.Info("Translation updated for edited message",
+		zap.String("channel_id", channelID),
+		zap.String("timestamp", ts),
+		zap.String("reply_ts", posted.ReplyTS))
+}
+
+// withoutThumbnails returns the files linked below a thread reply, leaving
+// out the images previewed by thumbnails when the reply was posted
+func (ep *eventProcessorImpl) withoutThumbnails(files []FileInfo) []FileInfo {
+	if ep.thumbnailSize <= 0 {
+		return files
+	}
+	linked := make([]FileInfo, 0, len(files))
+	previewed := 0
+	for _, file := range files {
+		if previewed < maxThumbnails && ep.thumbnailCandidate(file) {
+			previewed++
+			continue
+		}
+		linked = append(linked, file)
+	}
+	return linked
+}
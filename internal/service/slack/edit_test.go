@@ -0,0 +1,223 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakePostedTranslations remembers posted translations in memory
+type fakePostedTranslations map[string]*model.PostedTranslation
+
+func (f fakePostedTranslations) Remember(posted *model.PostedTranslation) error {
+	f[posted.ChannelID+"/"+posted.MessageTS] = posted
+	return nil
+}
+
+func (f fakePostedTranslations) Find(channelID, messageTS string) (*model.PostedTranslation, bool, error) {
+	posted, ok := f[channelID+"/"+messageTS]
+	return posted, ok, nil
+}
+
+func (f fakePostedTranslations) Forget(channelID, messageTS string) error {
+	delete(f, channelID+"/"+messageTS)
+	return nil
+}
+
+// fakeUpdater records updated translations by reply ts
+type fakeUpdater struct {
+	updates map[string]TranslationContent
+	err     error
+}
+
+func (f *fakeUpdater) UpdateTranslation(_, ts string, content TranslationContent) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.updates[ts] = content
+	return nil
+}
+
+func editEvent(previousText, text string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":    "message",
+		"subtype": "message_changed",
+		"channel": "C1",
+		"message": map[string]interface{}{
+			"type": "message",
+			"user": "U1",
+			"text": text,
+			"ts":   "1700000000.000100",
+		},
+		"previous_message": map[string]interface{}{
+			"type": "message",
+			"user": "U1",
+			"text": previousText,
+			"ts":   "1700000000.000100",
+		},
+	}
+}
+
+func TestEventProcessorRetranslateEdit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTranslationService := mocks.NewMockTranslationService(ctrl)
+	posted := fakePostedTranslations{}
+	processor := NewEventProcessor(mockTranslationService, nil, zap.NewNop(), WithEditRetranslation(posted)).(*eventProcessorImpl)
+	processor.rememberTranslation("C1", "1700000000.000100", "C1", "1700000000.000200")
+
+	mockTranslationService.EXPECT().DetectLanguage("Hello <@U2>, meet at 5").Return("English", nil)
+	mockTranslationService.EXPECT().TranslateContext(gomock.Any(), request.Translation{
+		Text:           "Hello <@U2>, meet at 5",
+		SourceLanguage: "English",
+		TargetLanguage: "Vietnamese",
+		UserID:         "U1",
+		ChannelID:      "C1",
+	}).Return(response.Translation{TranslatedText: "Xin chào <@U2>, gặp lúc 5 giờ"}, nil)
+
+	updater := &fakeUpdater{updates: map[string]TranslationContent{}}
+	processor.retranslateEdit(context.Background(), updater, editEvent("Hello <@U2>, meet at 4", "Hello <@U2>, meet at 5"))
+
+	require.Contains(t, updater.updates, "1700000000.000200")
+	assert.Equal(t, "Xin chào `<@U2>`, gặp lúc 5 giờ", updater.updates["1700000000.000200"].Text)
+	assert.Empty(t, updater.updates["1700000000.000200"].SourceChannelID)
+}
+
+func TestEventProcessorRetranslateEdit_UserPreference(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTranslationService := mocks.NewMockTranslationService(ctrl)
+	preferences := fakeUserPreferences{"U1": {UserID: "U1", PreferredLanguage: "ja", TranslateOwnMessages: true}}
+	processor := NewEventProcessor(mockTranslationService, nil, zap.NewNop(),
+		WithEditRetranslation(fakePostedTranslations{}), WithUserPreferences(preferences)).(*eventProcessorImpl)
+	processor.rememberTranslation("C1", "1700000000.000100", "C1", "1700000000.000200")
+
+	// The edit is translated to the author's language, like the original
+	mockTranslationService.EXPECT().DetectLanguage("Meet at 5").Return("English", nil)
+	mockTranslationService.EXPECT().TranslateContext(gomock.Any(), request.Translation{
+		Text:           "Meet at 5",
+		SourceLanguage: "English",
+		TargetLanguage: "Japanese",
+		UserID:         "U1",
+		ChannelID:      "C1",
+	}).Return(response.Translation{TranslatedText: "5時に会いましょう"}, nil)
+
+	updater := &fakeUpdater{updates: map[string]TranslationContent{}}
+	processor.retranslateEdit(context.Background(), updater, editEvent("Meet at 4", "Meet at 5"))
+
+	assert.Equal(t, "5時に会いましょう", updater.updates["1700000000.000200"].Text)
+}
+
+func TestEventProcessorRetranslateEdit_Skipped(t *testing.T) {
+	tests := []struct {
+		name  string
+		event map[string]interface{}
+	}{
+		{
+			name:  "text unchanged",
+			event: editEvent("Hello", "Hello"),
+		},
+		{
+			name: "no posted translation",
+			event: func() map[string]interface{} {
+				event := editEvent("Hello", "Hello there")
+				event["message"].(map[string]interface{})["ts"] = "1700000000.000300"
+				return event
+			}(),
+		},
+		{
+			name: "bot message",
+			event: func() map[string]interface{} {
+				event := editEvent("Hello", "Hello there")
+				event["message"].(map[string]interface{})["bot_id"] = "B1"
+				return event
+			}(),
+		},
+		{
+			name:  "nothing to translate",
+			event: editEvent("Hello", ":wave:"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// No translation is requested
+			mockTranslationService := mocks.NewMockTranslationService(ctrl)
+			posted := fakePostedTranslations{}
+			processor := NewEventProcessor(mockTranslationService, nil, zap.NewNop(), WithEditRetranslation(posted)).(*eventProcessorImpl)
+			processor.rememberTranslation("C1", "1700000000.000100", "C1", "1700000000.000200")
+
+			updater := &fakeUpdater{updates: map[string]TranslationContent{}}
+			processor.retranslateEdit(context.Background(), updater, tt.event)
+			assert.Empty(t, updater.updates)
+		})
+	}
+}
+
+func TestEventProcessorRetranslateEdit_CrossPost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTranslationService := mocks.NewMockTranslationService(ctrl)
+	posted := fakePostedTranslations{}
+	processor := NewEventProcessor(mockTranslationService, nil, zap.NewNop(),
+		WithEditRetranslation(posted), WithThumbnails(300)).(*eventProcessorImpl)
+	processor.rememberTranslation("C1", "1700000000.000100", "C2", "1700000000.000200")
+
+	mockTranslationService.EXPECT().DetectLanguage(gomock.Any()).Return("Vietnamese", nil)
+	mockTranslationService.EXPECT().TranslateContext(gomock.Any(), gomock.Any()).
+		Return(response.Translation{TranslatedText: "See the chart"}, nil)
+
+	event := editEvent("Xem biểu đồ", "Xem biểu đồ này")
+	event["message"].(map[string]interface{})["files"] = []interface{}{
+		map[string]interface{}{
+			"name":        "chart.png",
+			"mimetype":    "image/png",
+			"url_private": "https://files.slack.com/chart.png",
+			"permalink":   "https://example.slack.com/files/chart.png",
+		},
+	}
+	updater := &fakeUpdater{updates: map[string]TranslationContent{}}
+	processor.retranslateEdit(context.Background(), updater, event)
+
+	// Cross-posts link images, which are only previewed in threads
+	content := updater.updates["1700000000.000200"]
+	assert.Equal(t, "C1", content.SourceChannelID)
+	require.Len(t, content.Files, 1)
+	assert.Equal(t, "chart.png", content.Files[0].Name)
+}
+
+func TestEventProcessorRetranslateEdit_UpdateFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTranslationService := mocks.NewMockTranslationService(ctrl)
+	feedback := newFakeFeedback()
+	posted := fakePostedTranslations{}
+	processor := NewEventProcessor(mockTranslationService, nil, zap.NewNop(),
+		WithEditRetranslation(posted), WithFeedback(feedback)).(*eventProcessorImpl)
+	processor.rememberTranslation("C1", "1700000000.000100", "C1", "1700000000.000200")
+
+	mockTranslationService.EXPECT().DetectLanguage(gomock.Any()).Return("English", nil)
+	mockTranslationService.EXPECT().TranslateContext(gomock.Any(), gomock.Any()).
+		Return(response.Translation{ID: "T2", TranslatedText: "Xin chào"}, nil)
+
+	processor.retranslateEdit(context.Background(), &fakeUpdater{err: errors.New("message_not_found")}, editEvent("Hi", "Hello"))
+
+	// The reply was not updated, so its ratings still count for the old translation
+	assert.Empty(t, feedback.replies)
+}
@@ -2,33 +2,228 @@ package slack
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
 	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/ai"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/language"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
 var _ EventProcessor = (*eventProcessorImpl)(nil)
 
+// EventHandlerFunc adapts a function to an EventHandler
+type EventHandlerFunc func(ctx context.Context, event map[string]interface{})
+
+// HandleEvent calls f(ctx, event)
+func (f EventHandlerFunc) HandleEvent(ctx context.Context, event map[string]interface{}) {
+	f(ctx, event)
+}
+
 type eventProcessorImpl struct {
 	translationUseCase service.TranslationService
 	slackClient        *SlackClient
 	logger             *zap.Logger
+	messageFilter      MessageFilter
+	metrics            *metrics.Metrics
+	review             DraftSubmitter
+	pairs              ChannelPairLookup
+	digest             DigestCollector
+	channels           ChannelConfigLookup
+	pauses             PauseChecker
+	clients            *ClientPool
+	ocr                ImageTextExtractor
+	languages          *service.LanguageRouter
+	filePrivacy        FilePrivacyPolicy
+	thumbnailSize      int
+	feedback           FeedbackRecorder
+	posted             PostedTranslations
+	rateLimiter        model.RateLimiter
+	rateNoticeMu       sync.Mutex
+	rateNotices        map[string]int64
+	handlers           map[string]EventHandler
+	outbox             *Outbox
+	preferences        UserPreferenceLookup
+	replyButtons       bool
+	assistant          service.DirectMessageService
+	summaries          *ThreadSummaryPoster
+	channelOnboarding  service.ChannelOnboardingService
+	artifacts          ArtifactStore
+}
+
+// EventProcessorOption configures optional event processor collaborators
+type EventProcessorOption func(*eventProcessorImpl)
+
+// WithMessageFilter evaluates per-channel filter rules before translating
+func WithMessageFilter(filter MessageFilter) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.messageFilter = filter
+	}
+}
+
+// WithMetrics records the end-to-end latency of every posted translation
+func WithMetrics(m *metrics.Metrics) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.metrics = m
+	}
+}
+
+// WithTranslationReview sends translations for channels that require review
+// to their review channel instead of posting them directly
+func WithTranslationReview(review DraftSubmitter) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.review = review
+	}
+}
+
+// WithChannelPairs cross-posts translations of paired channels to the other
+// channel of the pair instead of replying in a thread
+func WithChannelPairs(pairs ChannelPairLookup) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.pairs = pairs
+	}
+}
+
+// WithDigest batches translations of channels in digest mode into periodic
+// digest messages instead of thread replies
+func WithDigest(digest DigestCollector) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.digest = digest
+	}
+}
+
+// WithChannelConfigs applies each channel's configuration: disabled channels
+// and channels without auto-translate are skipped, and messages are
+// translated from the configured source languages to its target language.
+// Channels without a configuration translate between English and Vietnamese.
+func WithChannelConfigs(channels ChannelConfigLookup) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.channels = channels
+	}
+}
+
+// WithChannelPauses skips messages of channels where translation was paused
+// after they were queued
+func WithChannelPauses(pauses PauseChecker) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.pauses = pauses
+	}
+}
+
+// WithWorkspaceClients answers each event with the bot token of the
+// workspace it came from, for apps installed in several workspaces
+func WithWorkspaceClients(clients *ClientPool) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.clients = clients
+	}
+}
+
+// WithImageOCR translates the text of images shared without a message
+// instead of only acknowledging them
+func WithImageOCR(extractor ImageTextExtractor) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.ocr = extractor
+	}
+}
+
+// WithLanguageRouter translates messages of unconfigured channels along
+// the router's language pairs instead of English <-> Vietnamese
+func WithLanguageRouter(languages *service.LanguageRouter) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.languages = languages
+	}
+}
+
+// WithFilePrivacy sets how restricted files attached to a message are shown
+// below its translation; they are shown without links by default
+func WithFilePrivacy(policy FilePrivacyPolicy) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.filePrivacy = policy
+	}
+}
+
+// WithThumbnails uploads image attachments downscaled to at most maxSize
+// pixels into the thread next to their translation, instead of linking them
+func WithThumbnails(maxSize int) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.thumbnailSize = maxSize
+	}
+}
+
+// WithFeedback lets users rate translations by reacting to them with 👍 or 👎
+func WithFeedback(feedback FeedbackRecorder) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.feedback = feedback
+	}
+}
+
+// WithEditRetranslation retranslates edited messages and updates the reply
+// posted with their earlier translation, and deletes the reply when the
+// message is deleted
+func WithEditRetranslation(posted PostedTranslations) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.posted = posted
+	}
+}
+
+// WithEventHandler handles callback events of eventType with handler,
+// replacing the built-in handler of that type, if any
+func WithEventHandler(eventType string, handler EventHandler) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.handlers[eventType] = handler
+	}
+}
+
+// WithRateLimiter skips messages over their user's or channel's rate limit,
+// telling the channel once per window to slow down
+func WithRateLimiter(limiter model.RateLimiter) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.rateLimiter = limiter
+		ep.rateNotices = make(map[string]int64)
+	}
+}
+
+// WithOutbox posts translations through outbox, so the next message is
+// translated while a reply is still being posted
+func WithOutbox(outbox *Outbox) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.outbox = outbox
+	}
 }
 
 func NewEventProcessor(
 	translationUseCase service.TranslationService,
 	slackClient *SlackClient,
 	logger *zap.Logger,
+	opts ...EventProcessorOption,
 ) EventProcessor {
-	return &eventProcessorImpl{
+	ep := &eventProcessorImpl{
 		translationUseCase: translationUseCase,
 		slackClient:        slackClient,
 		logger:             logger,
+		languages:          service.DefaultLanguageRouter(),
+		filePrivacy:        FilePrivacyRedact,
+	}
+	ep.handlers = map[string]EventHandler{
+		"message":        EventHandlerFunc(ep.handleMessageEvent),
+		"reaction_added": EventHandlerFunc(ep.handleReactionEvent),
 	}
+	for _, opt := range opts {
+		opt(ep)
+	}
+	return ep
 }
 
 func (ep *eventProcessorImpl) ProcessEvent(ctx context.Context, payload map[string]interface{}) {
@@ -50,6 +245,10 @@ func (ep *eventProcessorImpl) ProcessEvent(ctx context.Context, payload map[stri
 }
 
 func (ep *eventProcessorImpl) handleEventCallback(ctx context.Context, payload map[string]interface{}) {
+	if teamID, ok := payload["team_id"].(string); ok {
+		ctx = withTeam(ctx, teamID)
+	}
+
 	event, ok := payload["event"].(map[string]interface{})
 	if !ok {
 		ep.logger.Error("Failed to get event data")
@@ -62,18 +261,28 @@ func (ep *eventProcessorImpl) handleEventCallback(ctx context.Context, payload m
 		return
 	}
 
-	switch eventType {
-	case "message":
-		ep.handleMessageEvent(ctx, event)
-	default:
+	handler, ok := ep.handlers[eventType]
+	if !ok {
 		ep.logger.Debug("Ignoring callback event type", zap.String("type", eventType))
+		return
 	}
+	handler.HandleEvent(ctx, event)
 }
 
 func (ep *eventProcessorImpl) handleMessageEvent(ctx context.Context, event map[string]interface{}) {
-	// Skip messages with certain subtypes (threaded replies, edits, etc.)
+	slackClient := ep.client(ctx)
+
+	// Skip messages with certain subtypes (threaded replies, deletions, etc.)
 	// But allow file_share subtype (messages with images/files)
 	if subtype, ok := event["subtype"].(string); ok && subtype != "" {
+		switch subtype {
+		case "message_changed":
+			ep.handleMessageChanged(ctx, event)
+			return
+		case "message_deleted":
+			ep.handleMessageDeleted(ctx, event)
+			return
+		}
 		// Allow file_share subtype to be processed
 		if subtype != "file_share" {
 			ep.logger.Debug("Skipping message with subtype", zap.String("subtype", subtype))
@@ -87,6 +296,13 @@ func (ep *eventProcessorImpl) handleMessageEvent(ctx context.Context, event map[
 		return
 	}
 
+	// Never translate a cross-posted translation back, even when it is relayed
+	// by another integration, so paired channels cannot loop
+	if isCrossPost(event) {
+		ep.logger.Debug("Skipping cross-posted translation")
+		return
+	}
+
 	channelID, ok := event["channel"].(string)
 	if !ok {
 		ep.logger.Error("Failed to get channel ID")
@@ -110,9 +326,73 @@ func (ep *eventProcessorImpl) handleMessageEvent(ctx context.Context, event map[
 		text = ""
 	}
 
+	// Direct messages to the bot are answered by the assistant
+	if ep.assistant != nil && isDirectMessage(event) {
+		if strings.TrimSpace(text) != "" {
+			ep.handleDirectMessage(ctx, channelID, userID, ts, text)
+		}
+		return
+	}
+
+	// Summary requests are answered by the app_mention handler
+	if ep.summaries != nil && isSummaryRequest(text) {
+		return
+	}
+
+	// Skip channels paused while this message waited in the queue
+	if ep.pauses != nil && ep.pauses.IsPaused(channelID) {
+		ep.logger.Info("Translation paused in channel, skipping message",
+			zap.String("channel_id", channelID),
+			zap.String("timestamp", ts))
+		return
+	}
+
+	// Skip channels where translation is turned off
+	channelConfig := ep.channelConfig(channelID)
+	if channelConfig != nil && (!channelConfig.Enabled || !channelConfig.AutoTranslate) {
+		ep.logger.Debug("Translation disabled for channel, skipping message",
+			zap.String("channel_id", channelID),
+			zap.Bool("enabled", channelConfig.Enabled),
+			zap.Bool("auto_translate", channelConfig.AutoTranslate))
+		return
+	}
+
+	// Skip users who turned off translation of their messages on the Home tab
+	preference := ep.userPreference(userID)
+	if !preference.TranslateOwnMessages {
+		ep.logger.Debug("User turned off translation of their messages, skipping message",
+			zap.String("channel_id", channelID),
+			zap.String("user_id", userID))
+		return
+	}
+
 	// Trim whitespace to check if there's actual text content
 	trimmedText := strings.TrimSpace(text)
 
+	// Images shared without a message are translated from the text they show
+	if trimmedText == "" && ep.ocr != nil {
+		if imageText := ep.imageText(ctx, slackClient, channelID, ep.extractFiles(event)); imageText != "" {
+			text = imageText
+			trimmedText = imageText
+		}
+	}
+
+	// Apply per-channel filter rules before doing any work on the message
+	if ep.messageFilter != nil && trimmedText != "" {
+		allowed, reason := ep.messageFilter.ShouldTranslate(model.FilterInput{
+			ChannelID: channelID,
+			UserID:    userID,
+			Text:      text,
+		})
+		if !allowed {
+			ep.logger.Info("Message filtered by channel rules, skipping translation",
+				zap.String("channel_id", channelID),
+				zap.String("user_id", userID),
+				zap.String("reason", reason))
+			return
+		}
+	}
+
 	// Check if message contains files
 	hasFiles := false
 	if filesInterface, ok := event["files"]; ok {
@@ -128,14 +408,7 @@ func (ep *eventProcessorImpl) handleMessageEvent(ctx context.Context, event map[
 			zap.String("user_id", userID),
 			zap.String("timestamp", ts))
 
-		if err := ep.slackClient.AddReaction("eyes", channelID, ts); err != nil {
-			ep.logger.Warn("Failed to add emoji reaction to message",
-				zap.Error(err),
-				zap.String("channel_id", channelID),
-				zap.String("timestamp", ts),
-				zap.String("emoji", "eyes"),
-				zap.String("troubleshooting", "Check if bot has reactions:write scope in Slack app OAuth settings"))
-		}
+		ep.addReaction(slackClient, channelID, ts, reactionReceived)
 		return
 	}
 
@@ -147,10 +420,7 @@ func (ep *eventProcessorImpl) handleMessageEvent(ctx context.Context, event map[
 		return
 	}
 
-	textPreview := text
-	if len(text) > 50 {
-		textPreview = text[:50]
-	}
+	textPreview := language.Truncate(text, 50)
 
 	ep.logger.Info("Processing message event",
 		zap.String("channel_id", channelID),
@@ -158,15 +428,13 @@ func (ep *eventProcessorImpl) handleMessageEvent(ctx context.Context, event map[
 		zap.String("text", textPreview),
 		zap.String("timestamp", ts))
 
-	// Add eye emoji reaction to the message
-	if err := ep.slackClient.AddReaction("eyes", channelID, ts); err != nil {
-		ep.logger.Warn("Failed to add emoji reaction to message",
-			zap.Error(err),
-			zap.String("channel_id", channelID),
-			zap.String("timestamp", ts),
-			zap.String("emoji", "eyes"),
-			zap.String("troubleshooting", "Check if bot has reactions:write scope in Slack app OAuth settings"))
-	}
+	// Add eye emoji reaction to the message, replaced by ✅ or ⚠️ once the
+	// message is translated or fails. Skipped messages keep 👀.
+	ep.addReaction(slackClient, channelID, ts, reactionReceived)
+	outcome := ""
+	defer func() {
+		ep.finishReactions(slackClient, channelID, ts, outcome)
+	}()
 
 	// Check if message contains only emoji codes
 	if isEmojiOnly(text) {
@@ -182,8 +450,12 @@ func (ep *eventProcessorImpl) handleMessageEvent(ctx context.Context, event map[
 		return
 	}
 
+	if ep.rateLimiter != nil && !ep.withinRateLimit(slackClient, channelID, userID, ts) {
+		return
+	}
+
 	// Get user info for custom bot name and avatar
-	userInfo, err := ep.slackClient.GetUserInfo(userID)
+	userInfo, err := slackClient.GetUserInfo(userID)
 	botName := "SlackBot"
 	botAvatar := ""
 	if err == nil && userInfo != nil {
@@ -206,16 +478,19 @@ func (ep *eventProcessorImpl) handleMessageEvent(ctx context.Context, event map[
 	}
 
 	// Detect message language using original text with emoji codes
+	detectStart := time.Now()
 	detectedLang, err := ep.detectLanguage(ctx, text)
+	metrics.LatencyTraceFrom(ctx).Add(metrics.StageAI, time.Since(detectStart))
 	if err != nil {
+		metrics.LatencyTraceFrom(ctx).Fail(metrics.StageAI)
 		ep.logger.Error("Failed to detect message language",
 			zap.Error(err),
 			zap.String("text", text))
+		outcome = reactionFailed
 
-		// Check if quota exceeded error
-		if strings.Contains(err.Error(), "googleapi: Error 429: Resource exhausted") {
-			errorMessage := "❌ Sorry, I can't translate because the current quota has been exceeded. Please try again later."
-			_, _, err = ep.slackClient.PostMessageWithBotInfo(channelID, errorMessage, ts, botName, botAvatar)
+		// Tell the user when the provider failed for a known reason
+		if errorMessage, ok := service.ProviderErrorMessage(err); ok {
+			_, _, err = slackClient.PostMessageWithBotInfo(channelID, errorMessage, ts, botName, botAvatar)
 			if err != nil {
 				ep.logger.Error("Failed to post error message",
 					zap.Error(err),
@@ -228,20 +503,27 @@ func (ep *eventProcessorImpl) handleMessageEvent(ctx context.Context, event map[
 
 	ep.logger.Info("Language detected",
 		zap.String("detected_language", detectedLang),
-		zap.String("text", text[:min(len(text), 30)]))
-
-	// Determine target language based on detected source language
-	targetLang := "Vietnamese"
-	if detectedLang == "Vietnamese" {
-		targetLang = "English"
-	} else if detectedLang != "English" {
-
-		ep.logger.Info("Unsupported language, only English and Vietnamese are supported",
+		zap.String("text", language.Truncate(text, 30)))
+
+	// Determine target language based on detected source language: the
+	// user's preferred language, the channel's configured languages, or the
+	// language pair matrix
+	targetLang, ok := ep.routeForUser(preference, channelConfig, detectedLang)
+	if !ok && channelConfig != nil {
+		ep.logger.Info("Message language is not translated in this channel, skipping translation",
+			zap.String("channel_id", channelID),
+			zap.String("detected_language", detectedLang),
+			zap.Strings("source_languages", channelConfig.SourceLanguages),
+			zap.String("target_language", channelConfig.TargetLanguage))
+		return
+	}
+	if !ok {
+		ep.logger.Info("Unsupported language, no language pair translates it",
 			zap.String("detected_language", detectedLang))
+		outcome = reactionFailed
 
 		// Post error message to thread
-		errorMsg := "⚠️ Sorry! I only translate English and Vietnamese right now, not other languages, slang or numbers"
-		_, _, err = ep.slackClient.PostMessageWithBotInfo(channelID, errorMsg, ts, botName, botAvatar)
+		err = postReply(slackClient, channelConfig, channelID, userID, ts, ep.languages.UnsupportedLanguageMessage(), botName, botAvatar)
 		if err != nil {
 			ep.logger.Error("Failed to post error message",
 				zap.Error(err),
@@ -258,16 +540,32 @@ func (ep *eventProcessorImpl) handleMessageEvent(ctx context.Context, event map[
 		ChannelID:      channelID,
 	}
 
-	result, err := ep.translationUseCase.Translate(translationReq)
+	// Failed until the translation is posted
+	outcome = reactionFailed
+	result, err := ep.translationUseCase.TranslateContext(ctx, translationReq)
 	if err != nil {
-		if strings.Contains(err.Error(), "Delimiter tag injection") || strings.Contains(err.Error(), "input validation failed") {
+		if errors.Is(err, context.DeadlineExceeded) {
+			ep.logger.Warn("Translation timed out",
+				zap.Error(err),
+				zap.String("channel_id", channelID),
+				zap.String("ts", ts))
+
+			postErr := postReply(slackClient, channelConfig, channelID, userID, ts, service.MessageTranslationTimeout, botName, botAvatar)
+			if postErr != nil {
+				ep.logger.Error("Failed to post timeout message",
+					zap.Error(postErr),
+					zap.String("channel_id", channelID))
+			}
+			return
+		}
+
+		if service.IsInputRejected(err) {
 			ep.logger.Warn("Security validation failed for message",
 				zap.Error(err),
 				zap.String("channel_id", channelID),
 				zap.String("user_id", userID))
 
-			errorMsg := "Sorry, there seems to be an error in your text. Please check the content and try again."
-			_, _, postErr := ep.slackClient.PostMessageWithBotInfo(channelID, errorMsg, ts, botName, botAvatar)
+			postErr := postReply(slackClient, channelConfig, channelID, userID, ts, service.MessageInvalidInput, botName, botAvatar)
 			if postErr != nil {
 				ep.logger.Error("Failed to post security error message",
 					zap.Error(postErr),
@@ -278,10 +576,14 @@ func (ep *eventProcessorImpl) handleMessageEvent(ctx context.Context, event map[
 
 		ep.logger.Error("Failed to translate message",
 			zap.Error(err),
+			zap.String("category", string(ai.CategoryOf(err))),
 			zap.String("text", text))
 
-		errorMsg := "❌ Sorry, I can't translate because the current quota has been exceeded. Please try again later."
-		_, _, postErr := ep.slackClient.PostMessageWithBotInfo(channelID, errorMsg, ts, botName, botAvatar)
+		errorMsg, ok := service.ProviderErrorMessage(err)
+		if !ok {
+			errorMsg = service.MessageTranslationFailed
+		}
+		postErr := postReply(slackClient, channelConfig, channelID, userID, ts, errorMsg, botName, botAvatar)
 		if postErr != nil {
 			ep.logger.Error("Failed to post translation error message",
 				zap.Error(postErr),
@@ -293,53 +595,506 @@ func (ep *eventProcessorImpl) handleMessageEvent(ctx context.Context, event map[
 	translatedText := result.TranslatedText
 
 	// Convert user mentions and @here/@channel to quoted format
-	translatedText = strings.ReplaceAll(translatedText, "<!here>", "`here`")
-	translatedText = strings.ReplaceAll(translatedText, "<!channel>", "`channel`")
-	// // Quote user mentions like <@USERID|username>
-	userMentionPattern := regexp.MustCompile(`<@[^>]+>`)
-	translatedText = userMentionPattern.ReplaceAllStringFunc(translatedText, func(match string) string {
-		return "`" + match + "`"
-	})
+	translatedText = quoteMentions(translatedText)
 
 	responseText := translatedText
 
 	// Customize botName
 	//Determine emoji flag based on target language
-	emoji := "🇻🇳"
-	if result.TargetLanguage == "English" {
-		emoji = "🇬🇧"
-	}
-	botName = fmt.Sprintf("%s %s", botName, emoji)
+	botName = fmt.Sprintf("%s %s", botName, languageFlag(result.TargetLanguage))
 
-	// Extract files from the original message event
-	files := ep.extractFiles(event)
+	// Extract files from the original message event, hiding restricted ones
+	// as the file privacy policy asks
+	files := applyFilePrivacy(ep.extractFiles(event), ep.filePrivacy)
 
 	// Check if message contains @here or @channel tags
 	isQuote := containsAtHereOrChannel(text)
 
+	// Channels that require review get the translation only once it is approved.
+	// Attached files are not re-shared with approved translations.
+	if ep.review != nil {
+		held, err := ep.review.Submit(&model.PendingTranslation{
+			ChannelID:      channelID,
+			ThreadTS:       ts,
+			UserID:         userID,
+			SourceLanguage: result.SourceLanguage,
+			TargetLanguage: result.TargetLanguage,
+			OriginalText:   text,
+			TranslatedText: responseText,
+			BotName:        botName,
+			BotAvatar:      botAvatar,
+			AsQuote:        isQuote,
+		})
+		if err != nil {
+			// Fail closed: never post an unreviewed translation to a regulated channel
+			ep.logger.Error("Failed to submit translation for review",
+				zap.Error(err),
+				zap.String("channel_id", channelID),
+				zap.String("ts", ts))
+			return
+		}
+		if held {
+			ep.logger.Info("Translation held for review",
+				zap.String("channel_id", channelID),
+				zap.String("ts", ts))
+			outcome = reactionTranslated
+			return
+		}
+	}
+
+	// Chatty channels get a periodic digest instead of a reply per message
+	if ep.digest != nil && ep.digest.Collect(channelID, DigestEntry{
+		UserID:         userID,
+		TranslatedText: translatedText,
+		ReceivedAt:     time.Now(),
+	}) {
+		ep.logger.Info("Translation added to channel digest",
+			zap.String("channel_id", channelID),
+			zap.String("ts", ts))
+		outcome = reactionTranslated
+		return
+	}
+
+	// Channels without public replies get the translation only to the author
+	if channelConfig != nil && channelConfig.PrivateDelivery() {
+		outcome = ep.deliverPrivately(ctx, slackClient, channelConfig, userID, ts, responseText, isQuote, botName, botAvatar)
+		return
+	}
+
+	reply := translationReply{
+		channelID:  channelID,
+		ts:         ts,
+		text:       text,
+		result:     result,
+		isQuote:    isQuote,
+		botName:    botName,
+		botAvatar:  botAvatar,
+		files:      files,
+		debug:      channelConfig != nil && channelConfig.Debug,
+		translated: responseText,
+	}
+	pairedChannelID, crossPost := ep.pairedChannel(channelID)
+	if crossPost {
+		reply.pairedChannelID = pairedChannelID
+	}
+
+	if ep.outbox == nil {
+		outcome, _ = ep.postTranslation(ctx, slackClient, reply)
+		return
+	}
+
+	// Post off the worker so the channel's next message is translated in the
+	// meantime, and again later while Slack is unavailable. The reactions are
+	// finished once the reply is posted or given up.
+	thread := threadKey(channelID, ts)
+	if crossPost {
+		thread = threadKey(pairedChannelID, "")
+	}
+	postCtx := context.WithoutCancel(ctx)
+	outcome = ""
+	ep.outbox.PostWithRetry(thread, threadKey(channelID, ts), func() error {
+		posted, err := ep.postTranslation(postCtx, slackClient, reply)
+		if err == nil {
+			ep.finishReactions(slackClient, channelID, ts, posted)
+		}
+		return err
+	}, func() {
+		ep.finishReactions(slackClient, channelID, ts, reactionFailed)
+	})
+}
+
+// translationReply is a translation ready to be posted in reply to a message
+type translationReply struct {
+	channelID string
+	ts        string
+	text      string
+	result    response.Translation
+	// translated is the translated text posted, with mentions quoted
+	translated string
+	isQuote    bool
+	botName    string
+	botAvatar  string
+	files      []FileInfo
+	debug      bool
+	// pairedChannelID receives the translation as a cross-post instead of a thread reply
+	pairedChannelID string
+}
+
+// postTranslation posts the translation, in the message's thread or to its
+// paired channel, and returns the reaction the message ends up with, and why
+// the post failed
+func (ep *eventProcessorImpl) postTranslation(ctx context.Context, slackClient *SlackClient, reply translationReply) (string, error) {
+	channelID, ts := reply.channelID, reply.ts
+
 	// Post message with appropriate format (quote or normal)
-	if isQuote {
+	postStart := time.Now()
+	_, postSpan := tracing.Tracer().Start(ctx, "slack.post", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("slack.channel_id", channelID)))
+	var replyTS string
+	var err error
+	if pairedChannelID := reply.pairedChannelID; pairedChannelID != "" {
+		_, replyTS, err = slackClient.PostCrossPost(pairedChannelID, channelID, reply.translated, reply.isQuote, reply.botName, reply.botAvatar, reply.files)
+		tracing.End(postSpan, err)
+		if err != nil {
+			metrics.LatencyTraceFrom(ctx).Fail(metrics.StageSlackPost)
+			ep.logger.Error("Failed to cross-post translated message",
+				zap.Error(err),
+				zap.String("channel_id", channelID),
+				zap.String("paired_channel_id", pairedChannelID))
+			return reactionFailed, err
+		}
+
+		ep.recordReplyLatency(ctx, channelID, postStart)
+		ep.rememberReply(pairedChannelID, replyTS, reply.result)
+		ep.rememberTranslation(channelID, ts, pairedChannelID, replyTS)
+
+		ep.logger.Info("Translation cross-posted to paired channel",
+			zap.String("channel_id", channelID),
+			zap.String("paired_channel_id", pairedChannelID))
+		return reactionTranslated, nil
+	}
+
+	// Images are previewed by thumbnails uploaded after the reply
+	files, thumbnails := ep.thumbnails(ctx, slackClient, channelID, reply.files)
+
+	// Replies get buttons acting on the message when enabled, and debug
+	// channels see how each translation was produced below its reply
+	if ep.replyButtons {
+		content := TranslationContent{Text: reply.translated, AsQuote: reply.isQuote, Files: files, MessageTS: ts}
+		if reply.debug {
+			content.Footer = debugFooter(reply.result)
+		}
+		_, replyTS, err = slackClient.PostTranslation(channelID, ts, reply.botName, reply.botAvatar, content)
+	} else if reply.debug {
+		_, replyTS, err = slackClient.PostMessageWithFooter(channelID, reply.translated, ts, reply.isQuote, reply.botName, reply.botAvatar, files, debugFooter(reply.result))
+	} else if reply.isQuote {
 		if len(files) > 0 {
-			_, _, err = ep.slackClient.PostMessageWithBotInfoAsQuoteAndFiles(channelID, responseText, ts, botName, botAvatar, files)
+			_, replyTS, err = slackClient.PostMessageWithBotInfoAsQuoteAndFiles(channelID, reply.translated, ts, reply.botName, reply.botAvatar, files)
 		} else {
-			_, _, err = ep.slackClient.PostMessageWithBotInfoAsQuote(channelID, responseText, ts, botName, botAvatar)
+			_, replyTS, err = slackClient.PostMessageWithBotInfoAsQuote(channelID, reply.translated, ts, reply.botName, reply.botAvatar)
 		}
 	} else {
-		_, _, err = ep.slackClient.PostMessageWithBotInfoAndFiles(channelID, responseText, ts, botName, botAvatar, files)
+		_, replyTS, err = slackClient.PostMessageWithBotInfoAndFiles(channelID, reply.translated, ts, reply.botName, reply.botAvatar, files)
 	}
+	tracing.End(postSpan, err)
 
 	if err != nil {
+		metrics.LatencyTraceFrom(ctx).Fail(metrics.StageSlackPost)
 		ep.logger.Error("Failed to post translated message",
 			zap.Error(err),
 			zap.String("channel_id", channelID))
-		return
+		return reactionFailed, err
 	}
 
+	ep.recordReplyLatency(ctx, channelID, postStart)
+	ep.rememberReply(channelID, replyTS, reply.result)
+	ep.rememberTranslation(channelID, ts, channelID, replyTS)
+
+	ep.uploadThumbnails(ctx, slackClient, channelID, ts, thumbnails)
+
 	ep.logger.Info("Translation posted successfully",
 		zap.String("channel_id", channelID),
-		zap.String("original", text[:min(len(text), 30)]),
-		zap.String("translated", translatedText[:min(len(translatedText), 30)]),
-		zap.Bool("is_quote", isQuote))
+		zap.String("original", language.Truncate(reply.text, 30)),
+		zap.String("translated", language.Truncate(reply.translated, 30)),
+		zap.Bool("is_quote", reply.isQuote))
+	return reactionTranslated, nil
+}
+
+// deliverPrivately shows the translation of the message ts only to userID,
+// as the channel's delivery mode asks, and returns the reaction the message
+// ends up with. Attached files are not re-shared.
+func (ep *eventProcessorImpl) deliverPrivately(ctx context.Context, poster replyPoster, config *model.ChannelConfig, userID, ts, translated string, isQuote bool, botName, botAvatar string) string {
+	if isQuote {
+		translated = "> " + translated
+	}
+
+	postStart := time.Now()
+	if err := postReply(poster, config, config.ChannelID, userID, ts, translated, botName, botAvatar); err != nil {
+		metrics.LatencyTraceFrom(ctx).Fail(metrics.StageSlackPost)
+		ep.logger.Error("Failed to deliver translation privately",
+			zap.Error(err),
+			zap.String("channel_id", config.ChannelID),
+			zap.String("user_id", userID),
+			zap.String("delivery_mode", config.DeliveryMode))
+		return reactionFailed
+	}
+	ep.recordReplyLatency(ctx, config.ChannelID, postStart)
+
+	ep.logger.Info("Translation delivered privately",
+		zap.String("channel_id", config.ChannelID),
+		zap.String("user_id", userID),
+		zap.String("delivery_mode", config.DeliveryMode))
+	return reactionTranslated
+}
+
+// reactionLanguages maps flag reactions to the language codes they request
+var reactionLanguages = map[string]string{
+	"flag-vn": "vi",
+	"flag-gb": "en",
+	"gb":      "en",
+	"uk":      "en",
+	"flag-jp": "ja",
+	"jp":      "ja",
+	"flag-kr": "ko",
+	"kr":      "ko",
+	"flag-th": "th",
+	"flag-cn": "zh-Hans",
+	"cn":      "zh-Hans",
+	"flag-tw": "zh-Hant",
+	"flag-sa": "ar",
+	"flag-il": "he",
+}
+
+// reactionLanguage returns the language name a reaction asks for, or false if
+// the reaction does not request a translation. Skin tone modifiers are ignored.
+func reactionLanguage(reaction string) (string, bool) {
+	reaction, _, _ = strings.Cut(reaction, "::")
+	code, ok := reactionLanguages[reaction]
+	if !ok {
+		return "", false
+	}
+	return model.LanguageName(code)
+}
+
+// reactedMessage returns the channel and timestamp of the message a
+// reaction_added event reacts to, or false when it reacts to something else
+func reactedMessage(event map[string]interface{}) (string, string, bool) {
+	item, _ := event["item"].(map[string]interface{})
+	if itemType, _ := item["type"].(string); itemType != "message" {
+		return "", "", false
+	}
+	channelID, _ := item["channel"].(string)
+	ts, _ := item["ts"].(string)
+	return channelID, ts, channelID != "" && ts != ""
+}
+
+// handleReactionEvent translates a message on demand when someone reacts to
+// it with a flag, e.g. :flag-vn: or :flag-gb:, and posts the translation into
+// the message's thread. It works in channels without auto-translate too.
+// 👍 and 👎 on a translation posted by the bot rate it instead.
+func (ep *eventProcessorImpl) handleReactionEvent(ctx context.Context, event map[string]interface{}) {
+	slackClient := ep.client(ctx)
+
+	reaction, _ := event["reaction"].(string)
+	if rating, ok := feedbackRating(reaction); ok {
+		if channelID, ts, ok := reactedMessage(event); ok {
+			userID, _ := event["user"].(string)
+			ep.rateReply(channelID, ts, userID, rating)
+		}
+		return
+	}
+
+	targetLang, ok := reactionLanguage(reaction)
+	if !ok {
+		ep.logger.Debug("Ignoring reaction", zap.String("reaction", reaction))
+		return
+	}
+
+	channelID, ts, ok := reactedMessage(event)
+	if !ok {
+		ep.logger.Debug("Ignoring reaction to non-message item")
+		return
+	}
+	userID, _ := event["user"].(string)
+
+	if ep.pauses != nil && ep.pauses.IsPaused(channelID) {
+		ep.logger.Info("Translation paused in channel, skipping reaction",
+			zap.String("channel_id", channelID),
+			zap.String("timestamp", ts))
+		return
+	}
+	channelConfig := ep.channelConfig(channelID)
+	if channelConfig != nil && !channelConfig.Enabled {
+		ep.logger.Debug("Translation disabled for channel, skipping reaction",
+			zap.String("channel_id", channelID))
+		return
+	}
+
+	message, err := slackClient.GetMessage(channelID, ts)
+	if err != nil {
+		ep.logger.Error("Failed to fetch reacted message",
+			zap.Error(err),
+			zap.String("channel_id", channelID),
+			zap.String("timestamp", ts),
+			zap.String("troubleshooting", "Check if bot has channels:history scope in Slack app OAuth settings"))
+		return
+	}
+	if message == nil {
+		// conversations.history only returns top-level messages
+		ep.logger.Info("Reacted message not found, skipping translation",
+			zap.String("channel_id", channelID),
+			zap.String("timestamp", ts))
+		return
+	}
+	if message.BotID != "" {
+		ep.logger.Debug("Skipping reaction to bot message")
+		return
+	}
+
+	text := strings.TrimSpace(message.Text)
+	if text == "" || isEmojiOnly(text) || isUserMentionOnly(text) {
+		ep.logger.Debug("Reacted message has nothing to translate",
+			zap.String("channel_id", channelID),
+			zap.String("timestamp", ts))
+		return
+	}
+
+	threadTS := message.ThreadTimestamp
+	if threadTS == "" {
+		threadTS = ts
+	}
+
+	detectedLang, err := ep.detectLanguage(ctx, text)
+	if err != nil {
+		return
+	}
+	if detectedLang == targetLang {
+		ep.logger.Info("Reacted message is already in the requested language",
+			zap.String("channel_id", channelID),
+			zap.String("language", targetLang))
+		return
+	}
+
+	ep.logger.Info("Translating message on reaction",
+		zap.String("channel_id", channelID),
+		zap.String("user_id", userID),
+		zap.String("reaction", reaction),
+		zap.String("timestamp", ts))
+
+	result, err := ep.translationUseCase.TranslateContext(ctx, request.Translation{
+		Text:           text,
+		SourceLanguage: detectedLang,
+		TargetLanguage: targetLang,
+		UserID:         userID,
+		ChannelID:      channelID,
+	})
+	if err != nil {
+		ep.logger.Error("Failed to translate reacted message",
+			zap.Error(err),
+			zap.String("channel_id", channelID),
+			zap.String("timestamp", ts))
+		errorMsg, ok := service.ProviderErrorMessage(err)
+		if !ok {
+			errorMsg = service.MessageTranslationFailed
+		}
+		if postErr := postReply(slackClient, channelConfig, channelID, userID, threadTS, errorMsg, "", ""); postErr != nil {
+			ep.logger.Error("Failed to post translation error message",
+				zap.Error(postErr),
+				zap.String("channel_id", channelID))
+		}
+		return
+	}
+
+	botName := "SlackBot"
+	botAvatar := ""
+	if userInfo, err := slackClient.GetUserInfo(message.User); err == nil && userInfo != nil {
+		displayName := userInfo.Profile.DisplayName
+		if displayName == "" {
+			displayName = userInfo.Name
+		}
+		botName = fmt.Sprintf("%s (Bot)", displayName)
+		botAvatar = userInfo.Profile.Image512
+	}
+	botName = fmt.Sprintf("%s %s", botName, languageFlag(result.TargetLanguage))
+
+	// The translation goes only to whoever reacted when the channel delivers privately
+	if err := postReply(slackClient, channelConfig, channelID, userID, threadTS, result.TranslatedText, botName, botAvatar); err != nil {
+		ep.logger.Error("Failed to post reaction translation",
+			zap.Error(err),
+			zap.String("channel_id", channelID))
+		return
+	}
+
+	ep.logger.Info("Reaction translation posted successfully",
+		zap.String("channel_id", channelID),
+		zap.String("thread_ts", threadTS))
+}
+
+// channelConfig returns the configuration of a channel, or nil when the
+// channel has none or it cannot be loaded
+// client returns the Slack client of the workspace the event in ctx came from
+func (ep *eventProcessorImpl) client(ctx context.Context) *SlackClient {
+	if ep.clients == nil {
+		return ep.slackClient
+	}
+	return ep.clients.ForTeam(teamFrom(ctx))
+}
+
+func (ep *eventProcessorImpl) channelConfig(channelID string) *model.ChannelConfig {
+	if ep.channels == nil {
+		return nil
+	}
+	config, err := ep.channels.GetChannelConfig(channelID)
+	if err != nil {
+		var domainErr *model.DomainError
+		if !errors.As(err, &domainErr) || domainErr.Type != model.ErrorTypeNotFound {
+			ep.logger.Warn("Failed to load channel config, using default languages",
+				zap.Error(err),
+				zap.String("channel_id", channelID))
+		}
+		return nil
+	}
+	return config
+}
+
+// languageFlags are the flags appended to the bot name by target language
+var languageFlags = map[string]string{
+	"en":      "🇬🇧",
+	"vi":      "🇻🇳",
+	"es":      "🇪🇸",
+	"fr":      "🇫🇷",
+	"de":      "🇩🇪",
+	"zh-Hans": "🇨🇳",
+	"zh-Hant": "🇹🇼",
+	"ja":      "🇯🇵",
+	"ko":      "🇰🇷",
+	"th":      "🇹🇭",
+	"ar":      "🇸🇦",
+	"he":      "🇮🇱",
+}
+
+// languageFlag returns the flag for a target language name
+func languageFlag(language string) string {
+	if code, ok := model.LanguageCode(language); ok {
+		if flag, ok := languageFlags[code]; ok {
+			return flag
+		}
+	}
+	return "🇻🇳"
+}
+
+// pairedChannel returns the channel a translation of channelID is cross-posted to
+func (ep *eventProcessorImpl) pairedChannel(channelID string) (string, bool) {
+	if ep.pairs == nil {
+		return "", false
+	}
+	return ep.pairs.PairedChannel(channelID)
+}
+
+// recordReplyLatency completes the latency trace in ctx once the reply is
+// posted and logs the end-to-end latency with its per-stage breakdown
+func (ep *eventProcessorImpl) recordReplyLatency(ctx context.Context, channelID string, postStart time.Time) {
+	trace := metrics.LatencyTraceFrom(ctx)
+	if trace == nil {
+		return
+	}
+	postedAt := time.Now()
+	trace.Add(metrics.StageSlackPost, postedAt.Sub(postStart))
+
+	latency := postedAt.Sub(trace.ReceivedAt)
+	if ep.metrics != nil {
+		latency = ep.metrics.RecordReplyLatency(trace, postedAt)
+	}
+
+	stages := trace.Stages()
+	ep.logger.Info("Translation reply latency",
+		zap.String("channel_id", channelID),
+		zap.Duration("total", latency),
+		zap.Duration("queue_wait", stages[metrics.StageQueueWait]),
+		zap.Duration("cache", stages[metrics.StageCache]),
+		zap.Duration("database", stages[metrics.StageDatabase]),
+		zap.Duration("ai", stages[metrics.StageAI]),
+		zap.Duration("slack_post", stages[metrics.StageSlackPost]))
 }
 
 func (ep *eventProcessorImpl) detectLanguage(ctx context.Context, text string) (string, error) {
@@ -389,6 +1144,18 @@ func isUserMentionOnly(text string) bool {
 	return strings.TrimSpace(withoutHereChannel) == ""
 }
 
+// quoteMentions converts user mentions and @here/@channel tags in a
+// translation to inline code, so posting it does not notify anyone again
+func quoteMentions(text string) string {
+	text = strings.ReplaceAll(text, "<!here>", "`here`")
+	text = strings.ReplaceAll(text, "<!channel>", "`channel`")
+	// Quote user mentions like <@USERID|username>
+	userMentionPattern := regexp.MustCompile(`<@[^>]+>`)
+	return userMentionPattern.ReplaceAllStringFunc(text, func(match string) string {
+		return "`" + match + "`"
+	})
+}
+
 // containsAtHereOrChannel checks if message contains @here or @channel tags
 func containsAtHereOrChannel(text string) bool {
 	return strings.Contains(text, "<!here>") || strings.Contains(text, "<!channel>") ||
@@ -472,10 +1239,16 @@ func restoreEmojis(text string, emojis map[string]string) string {
 
 // FileInfo represents file information from Slack
 type FileInfo struct {
+	// ID is Slack's file ID, e.g. F0123ABCD
+	ID        string
 	URL       string
 	Permalink string
 	Mimetype  string
 	Name      string
+	// Size in bytes, 0 when Slack did not report it
+	Size int64
+	// Restricted files may not be visible to everyone in the channel, see fileRestricted
+	Restricted bool
 }
 
 // extractFiles extracts file information from a Slack event
@@ -500,6 +1273,10 @@ func (ep *eventProcessorImpl) extractFiles(event map[string]interface{}) []FileI
 
 		fileInfo := FileInfo{}
 
+		if id, ok := fileMap["id"].(string); ok {
+			fileInfo.ID = id
+		}
+
 		// Extract URL and permalink - both are useful
 		if urlPrivate, ok := fileMap["url_private"].(string); ok {
 			fileInfo.URL = urlPrivate
@@ -517,8 +1294,15 @@ func (ep *eventProcessorImpl) extractFiles(event map[string]interface{}) []FileI
 			fileInfo.Name = name
 		}
 
-		// Only add if we have at least a URL or permalink
-		if fileInfo.URL != "" || fileInfo.Permalink != "" {
+		if size, ok := fileMap["size"].(float64); ok {
+			fileInfo.Size = int64(size)
+		}
+
+		fileInfo.Restricted = fileRestricted(fileMap)
+
+		// Only add if we have at least a URL or permalink, or the file is
+		// restricted and Slack left them out
+		if fileInfo.URL != "" || fileInfo.Permalink != "" || fileInfo.Restricted {
 			files = append(files, fileInfo)
 			ep.logger.Debug("Extracted file from event",
 				zap.String("name", fileInfo.Name),
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
 	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
@@ -79,6 +80,34 @@ func TestEventProcessorInvalidEventType(t *testing.T) {
 	processor.ProcessEvent(context.Background(), payload)
 }
 
+func TestEventProcessorEventHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var handled []string
+	record := func(name string) EventHandler {
+		return EventHandlerFunc(func(ctx context.Context, event map[string]interface{}) {
+			assert.Equal(t, "T123", teamFrom(ctx))
+			handled = append(handled, name+":"+event["channel"].(string))
+		})
+	}
+
+	// Handlers are added for new event types and replace built-in ones
+	processor := NewEventProcessor(mocks.NewMockTranslationService(ctrl), nil, zap.NewNop(),
+		WithEventHandler("member_joined_channel", record("joined")),
+		WithEventHandler("message", record("message")))
+
+	for _, eventType := range []string{"member_joined_channel", "message", "channel_archive"} {
+		processor.ProcessEvent(context.Background(), map[string]interface{}{
+			"type":    "event_callback",
+			"team_id": "T123",
+			"event":   map[string]interface{}{"type": eventType, "channel": "C123"},
+		})
+	}
+
+	assert.Equal(t, []string{"joined:C123", "message:C123"}, handled)
+}
+
 func TestEventProcessorHandleMessageEvent_EmptyText(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -119,6 +148,127 @@ func TestEventProcessorHandleMessageEvent_SkipBotMessage(t *testing.T) {
 	processor.handleMessageEvent(context.Background(), event)
 }
 
+func TestEventProcessorHandleMessageEvent_SkipCrossPost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTranslationService := mocks.NewMockTranslationService(ctrl)
+	logger, _ := zap.NewProduction()
+
+	processor := NewEventProcessor(mockTranslationService, nil, logger).(*eventProcessorImpl)
+
+	// A cross-posted translation relayed without a bot_id must not be translated back
+	event := map[string]interface{}{
+		"type":    "message",
+		"user":    "U123456",
+		"channel": "C123456",
+		"text":    "Xin chào",
+		"ts":      "1234567890.123456",
+		"metadata": map[string]interface{}{
+			"event_type":    "translation_crosspost",
+			"event_payload": map[string]interface{}{"source_channel_id": "C654321"},
+		},
+	}
+
+	processor.handleMessageEvent(context.Background(), event)
+}
+
+func TestEventProcessorHandleMessageEvent_SkipDisabledChannel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTranslationService := mocks.NewMockTranslationService(ctrl)
+	logger, _ := zap.NewProduction()
+
+	channels := fakeDigestChannels{
+		"COFF":    {ChannelID: "COFF", Enabled: false, AutoTranslate: true, TargetLanguage: "vi"},
+		"CMANUAL": {ChannelID: "CMANUAL", Enabled: true, AutoTranslate: false, TargetLanguage: "vi"},
+	}
+	processor := NewEventProcessor(mockTranslationService, nil, logger, WithChannelConfigs(channels)).(*eventProcessorImpl)
+
+	// No expectations on the translation service or Slack client: both channels are skipped
+	for _, channelID := range []string{"COFF", "CMANUAL"} {
+		processor.handleMessageEvent(context.Background(), map[string]interface{}{
+			"type":    "message",
+			"user":    "U123456",
+			"channel": channelID,
+			"text":    "Hello",
+			"ts":      "1234567890.123456",
+		})
+	}
+}
+
+func TestLanguageFlag(t *testing.T) {
+	assert.Equal(t, "🇬🇧", languageFlag("English"))
+	assert.Equal(t, "🇻🇳", languageFlag("Vietnamese"))
+	assert.Equal(t, "🇯🇵", languageFlag("Japanese"))
+}
+
+func TestDebugFooter(t *testing.T) {
+	assert.Equal(t, "🔍 gemini · gemini-1.5-flash · prompt v2 · AI (120+8 tokens) · 850 ms", debugFooter(response.Translation{
+		Provider:      "gemini",
+		Model:         "gemini-1.5-flash",
+		PromptVersion: "v2",
+		Source:        response.SourceAI,
+		PromptTokens:  120,
+		OutputTokens:  8,
+		LatencyMS:     850,
+	}))
+	assert.Equal(t, "🔍 cache hit · 3 ms", debugFooter(response.Translation{Source: response.SourceCache, LatencyMS: 3}))
+	assert.Equal(t, "🔍 openai · database hit · 12 ms", debugFooter(response.Translation{Provider: "openai", Source: response.SourceDatabase, LatencyMS: 12}))
+}
+
+func TestReactionLanguage(t *testing.T) {
+	tests := []struct {
+		reaction string
+		expected string
+		ok       bool
+	}{
+		{reaction: "flag-vn", expected: "Vietnamese", ok: true},
+		{reaction: "flag-gb", expected: "English", ok: true},
+		{reaction: "uk", expected: "English", ok: true},
+		{reaction: "gb::skin-tone-2", expected: "English", ok: true},
+		{reaction: "flag-jp", expected: "Japanese", ok: true},
+		{reaction: "jp", expected: "Japanese", ok: true},
+		{reaction: "flag-kr", expected: "Korean", ok: true},
+		{reaction: "flag-th", expected: "Thai", ok: true},
+		{reaction: "cn", expected: "Chinese (Simplified)", ok: true},
+		{reaction: "flag-tw", expected: "Chinese (Traditional)", ok: true},
+		{reaction: "eyes"},
+		{reaction: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reaction, func(t *testing.T) {
+			language, ok := reactionLanguage(tt.reaction)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, language)
+		})
+	}
+}
+
+func TestEventProcessorHandleReactionEvent_Skipped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTranslationService := mocks.NewMockTranslationService(ctrl)
+	channels := fakeDigestChannels{
+		"COFF": {ChannelID: "COFF", Enabled: false, TargetLanguage: "vi"},
+	}
+	processor := NewEventProcessor(mockTranslationService, nil, zap.NewNop(), WithChannelConfigs(channels)).(*eventProcessorImpl)
+
+	// No expectations on the translation service or Slack client: none of
+	// these reactions fetch the message
+	events := []map[string]interface{}{
+		{"type": "reaction_added", "reaction": "thumbsup", "item": map[string]interface{}{"type": "message", "channel": "C123", "ts": "1234567890.123456"}},
+		{"type": "reaction_added", "reaction": "flag-vn", "item": map[string]interface{}{"type": "file", "file": "F123"}},
+		{"type": "reaction_added", "reaction": "flag-vn", "item": map[string]interface{}{"type": "message", "channel": "COFF", "ts": "1234567890.123456"}},
+	}
+	for _, event := range events {
+		processor.handleEventCallback(context.Background(), map[string]interface{}{"event": event})
+	}
+}
+
 func TestEventProcessorHandleMessageEvent_SkipMessageWithSubtype(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,72 @@
+package slack
+
+import (
+	"strings"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+// FeedbackRecorder remembers the translations posted and records how users
+// rate them, e.g. service.FeedbackUseCase
+type FeedbackRecorder interface {
+	RememberReply(reply *model.TranslationReply) error
+	Rate(channelID, messageTS, userID string, rating int) (bool, error)
+}
+
+// feedbackReactions maps reactions to the ratings they give a translation
+var feedbackReactions = map[string]int{
+	"+1":         model.RatingPositive,
+	"thumbsup":   model.RatingPositive,
+	"-1":         model.RatingNegative,
+	"thumbsdown": model.RatingNegative,
+}
+
+// feedbackRating returns the rating a reaction gives, or false if the
+// reaction does not rate a translation. Skin tone modifiers are ignored.
+func feedbackRating(reaction string) (int, bool) {
+	reaction, _, _ = strings.Cut(reaction, "::")
+	rating, ok := feedbackReactions[reaction]
+	return rating, ok
+}
+
+// rememberReply lets users rate the translation result posted as replyTS
+func (ep *eventProcessorImpl) rememberReply(channelID, replyTS string, result response.Translation) {
+	if ep.feedback == nil || replyTS == "" {
+		return
+	}
+	err := ep.feedback.RememberReply(&model.TranslationReply{
+		TranslationID:  result.ID,
+		ChannelID:      channelID,
+		MessageTS:      replyTS,
+		SourceLanguage: result.SourceLanguage,
+		TargetLanguage: result.TargetLanguage,
+	})
+	if err != nil {
+		ep.logger.Warn("Failed to remember translation reply, it cannot be rated",
+			zap.Error(err),
+			zap.String("channel_id", channelID),
+			zap.String("reply_ts", replyTS))
+	}
+}
+
+// rateReply records a user's rating of the message ts, when it is a translation
+func (ep *eventProcessorImpl) rateReply(channelID, ts, userID string, rating int) {
+	if ep.feedback == nil {
+		return
+	}
+	recorded, err := ep.feedback.Rate(channelID, ts, userID, rating)
+	if err != nil {
+		ep.logger.Error("Failed to record translation feedback",
+			zap.Error(err),
+			zap.String("channel_id", channelID),
+			zap.String("timestamp", ts))
+		return
+	}
+	if !recorded {
+		ep.logger.Debug("Ignoring rating of a message that is not a translation",
+			zap.String("channel_id", channelID),
+			zap.String("timestamp", ts))
+	}
+}
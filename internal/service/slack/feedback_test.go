@@ -0,0 +1,86 @@
+package slack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeFeedback remembers replies and their ratings in memory
+type fakeFeedback struct {
+	replies map[string]*model.TranslationReply
+	ratings map[string]int
+}
+
+func newFakeFeedback() *fakeFeedback {
+	return &fakeFeedback{replies: map[string]*model.TranslationReply{}, ratings: map[string]int{}}
+}
+
+func (f *fakeFeedback) RememberReply(reply *model.TranslationReply) error {
+	f.replies[reply.ChannelID+"/"+reply.MessageTS] = reply
+	return nil
+}
+
+func (f *fakeFeedback) Rate(channelID, messageTS, userID string, rating int) (bool, error) {
+	if _, ok := f.replies[channelID+"/"+messageTS]; !ok {
+		return false, nil
+	}
+	f.ratings[userID] = rating
+	return true, nil
+}
+
+func TestFeedbackRating(t *testing.T) {
+	tests := []struct {
+		reaction string
+		rating   int
+		ok       bool
+	}{
+		{reaction: "+1", rating: model.RatingPositive, ok: true},
+		{reaction: "+1::skin-tone-3", rating: model.RatingPositive, ok: true},
+		{reaction: "thumbsdown", rating: model.RatingNegative, ok: true},
+		{reaction: "-1", rating: model.RatingNegative, ok: true},
+		{reaction: "flag-vn"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reaction, func(t *testing.T) {
+			rating, ok := feedbackRating(tt.reaction)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.rating, rating)
+		})
+	}
+}
+
+func TestEventProcessorFeedbackReaction(t *testing.T) {
+	feedback := newFakeFeedback()
+	processor := NewEventProcessor(nil, nil, zap.NewNop(), WithFeedback(feedback)).(*eventProcessorImpl)
+
+	processor.rememberReply("C1", "1700000000.000200", response.Translation{
+		ID:             "T1",
+		SourceLanguage: "English",
+		TargetLanguage: "Vietnamese",
+	})
+	require.Contains(t, feedback.replies, "C1/1700000000.000200")
+	assert.Equal(t, "T1", feedback.replies["C1/1700000000.000200"].TranslationID)
+
+	react := func(userID, reaction, ts string) {
+		processor.handleReactionEvent(context.Background(), map[string]interface{}{
+			"type":     "reaction_added",
+			"user":     userID,
+			"reaction": reaction,
+			"item":     map[string]interface{}{"type": "message", "channel": "C1", "ts": ts},
+		})
+	}
+
+	react("U1", "+1", "1700000000.000200")
+	react("U2", "-1::skin-tone-2", "1700000000.000200")
+	// A rating of a message that is not a translation is ignored
+	react("U3", "+1", "1700000000.000100")
+
+	assert.Equal(t, map[string]int{"U1": model.RatingPositive, "U2": model.RatingNegative}, feedback.ratings)
+}
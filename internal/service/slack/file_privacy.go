@@ -0,0 +1,81 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FilePrivacyPolicy decides how restricted files attached to a message are
+// shown below its translation
+type FilePrivacyPolicy string
+
+const (
+	// FilePrivacyRedact shows restricted files by name, without a link
+	FilePrivacyRedact FilePrivacyPolicy = "redact"
+	// FilePrivacyOmit leaves restricted files out of replies
+	FilePrivacyOmit FilePrivacyPolicy = "omit"
+	// FilePrivacyLink links every file, restricted or not
+	FilePrivacyLink FilePrivacyPolicy = "link"
+)
+
+// fileRestricted reports whether a file object from a Slack event may not be
+// visible to everyone reading the reply: Slack asks apps to check the file
+// before using it, denied access to it, or the file is hosted outside Slack
+func fileRestricted(fileMap map[string]interface{}) bool {
+	if access, ok := fileMap["file_access"].(string); ok && access != "" && access != "visible" {
+		return true
+	}
+	if mode, ok := fileMap["mode"].(string); ok && (mode == "hidden_by_limit" || mode == "tombstone") {
+		return true
+	}
+	external, _ := fileMap["is_external"].(bool)
+	return external
+}
+
+// applyFilePrivacy returns the files to show below a translation under policy
+func applyFilePrivacy(files []FileInfo, policy FilePrivacyPolicy) []FileInfo {
+	if policy == FilePrivacyLink {
+		return files
+	}
+
+	shown := make([]FileInfo, 0, len(files))
+	for _, file := range files {
+		if file.Restricted {
+			if policy == FilePrivacyOmit {
+				continue
+			}
+			file.Permalink = ""
+		}
+		shown = append(shown, file)
+	}
+	return shown
+}
+
+// mayShareContent reports whether the content of file, e.g. the text or a
+// thumbnail of an image, may be posted: restricted files are only shared
+// under FilePrivacyLink
+func (ep *eventProcessorImpl) mayShareContent(file FileInfo) bool {
+	return !file.Restricted || ep.filePrivacy == FilePrivacyLink
+}
+
+// fileContextText returns the context line shown for file below a
+// translation, or false when the file is not shown
+func fileContextText(file FileInfo) (string, bool) {
+	if file.Permalink == "" {
+		if !file.Restricted {
+			return "", false
+		}
+		name := file.Name
+		if name == "" {
+			name = "a file"
+		}
+		return fmt.Sprintf("🔒 %s (restricted)", name), true
+	}
+
+	// Use different emoji for images vs other files
+	emoji := "📎"
+	if strings.HasPrefix(file.Mimetype, "image/") {
+		emoji = "🖼️"
+	}
+	return fmt.Sprintf("%s <%s|%s>", emoji, file.Permalink, file.Name), true
+}
@@ -0,0 +1,93 @@
+package slack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestExtractFiles_Restricted(t *testing.T) {
+	processor := NewEventProcessor(nil, nil, zap.NewNop()).(*eventProcessorImpl)
+
+	files := processor.extractFiles(map[string]interface{}{
+		"files": []interface{}{
+			map[string]interface{}{
+				"name":        "public.png",
+				"url_private": "https://files.slack.com/public.png",
+				"permalink":   "https://example.slack.com/files/public.png",
+				"file_access": "visible",
+			},
+			map[string]interface{}{
+				"id":          "F2",
+				"file_access": "check_file_info",
+			},
+			map[string]interface{}{
+				"name":        "plan.gdoc",
+				"permalink":   "https://example.slack.com/files/plan.gdoc",
+				"is_external": true,
+			},
+			map[string]interface{}{
+				"name":      "old.pdf",
+				"permalink": "https://example.slack.com/files/old.pdf",
+				"mode":      "hidden_by_limit",
+			},
+		},
+	})
+
+	assert.Len(t, files, 4)
+	assert.False(t, files[0].Restricted)
+	assert.True(t, files[1].Restricted)
+	assert.True(t, files[2].Restricted)
+	assert.True(t, files[3].Restricted)
+}
+
+func TestApplyFilePrivacy(t *testing.T) {
+	files := []FileInfo{
+		{Name: "public.png", Permalink: "https://example.slack.com/files/public.png"},
+		{Name: "plan.gdoc", Permalink: "https://example.slack.com/files/plan.gdoc", Restricted: true},
+	}
+
+	redacted := applyFilePrivacy(files, FilePrivacyRedact)
+	assert.Len(t, redacted, 2)
+	assert.Equal(t, files[0], redacted[0])
+	assert.Empty(t, redacted[1].Permalink)
+	assert.Equal(t, "https://example.slack.com/files/plan.gdoc", files[1].Permalink, "input is not modified")
+
+	omitted := applyFilePrivacy(files, FilePrivacyOmit)
+	assert.Equal(t, files[:1], omitted)
+
+	assert.Equal(t, files, applyFilePrivacy(files, FilePrivacyLink))
+}
+
+func TestFileContextText(t *testing.T) {
+	text, ok := fileContextText(FileInfo{Name: "shot.png", Mimetype: "image/png", Permalink: "https://example.slack.com/files/shot.png"})
+	assert.True(t, ok)
+	assert.Equal(t, "🖼️ <https://example.slack.com/files/shot.png|shot.png>", text)
+
+	text, ok = fileContextText(FileInfo{Name: "report.pdf", Mimetype: "application/pdf", Permalink: "https://example.slack.com/files/report.pdf"})
+	assert.True(t, ok)
+	assert.Equal(t, "📎 <https://example.slack.com/files/report.pdf|report.pdf>", text)
+
+	text, ok = fileContextText(FileInfo{Name: "plan.gdoc", Restricted: true})
+	assert.True(t, ok)
+	assert.Equal(t, "🔒 plan.gdoc (restricted)", text)
+
+	text, ok = fileContextText(FileInfo{Restricted: true})
+	assert.True(t, ok)
+	assert.Equal(t, "🔒 a file (restricted)", text)
+
+	_, ok = fileContextText(FileInfo{Name: "orphan.txt"})
+	assert.False(t, ok)
+}
+
+func TestImageText_SkipsRestrictedImages(t *testing.T) {
+	processor := NewEventProcessor(nil, nil, zap.NewNop()).(*eventProcessorImpl)
+
+	// No downloader: a restricted image must not be fetched under the default policy
+	text := processor.imageText(context.Background(), nil, "C1", []FileInfo{
+		{Name: "secret.png", Mimetype: "image/png", URL: "https://files.slack.com/secret.png", Restricted: true},
+	})
+	assert.Empty(t, text)
+}
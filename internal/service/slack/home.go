@@ -0,0 +1,130 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+var _ service.HomeViewPublisher = (*SlackClient)(nil)
+
+// UserPreferenceLookup returns how a user wants their own messages
+// translated, e.g. service.UserPreferenceUseCase
+type UserPreferenceLookup interface {
+	GetPreference(userID string) (*model.UserPreference, error)
+}
+
+// WithUserPreferences shows users their preferences on the App Home tab and
+// translates their messages as the preferences ask
+func WithUserPreferences(preferences UserPreferenceLookup) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.preferences = preferences
+		ep.handlers["app_home_opened"] = EventHandlerFunc(ep.handleAppHomeOpened)
+	}
+}
+
+// PublishHomeView shows the user's preferences on the bot's App Home tab
+func (sc *SlackClient) PublishHomeView(userID string, preference *model.UserPreference) error {
+	if sc.client == nil {
+		return fmt.Errorf("slack client is not initialized")
+	}
+	_, err := sc.client.PublishView(userID, homeView(preference), "")
+	return err
+}
+
+// homeView builds the App Home tab: a menu of target languages and a
+// checkbox turning translation of the user's messages on or off
+func homeView(preference *model.UserPreference) slack.HomeTabViewRequest {
+	channelDefault := slack.NewOptionBlockObject(model.HomeLanguageChannelDefault,
+		slack.NewTextBlockObject("plain_text", "Channel default", false, false), nil)
+	options := []*slack.OptionBlockObject{channelDefault}
+	selected := channelDefault
+	for _, code := range model.SupportedLanguageCodes() {
+		name, _ := model.LanguageName(code)
+		option := slack.NewOptionBlockObject(code,
+			slack.NewTextBlockObject("plain_text", fmt.Sprintf("%s %s", languageFlags[code], name), true, false), nil)
+		options = append(options, option)
+		if code == preference.PreferredLanguage {
+			selected = option
+		}
+	}
+	languageMenu := slack.NewOptionsSelectBlockElement(slack.OptTypeStatic,
+		slack.NewTextBlockObject("plain_text", "Choose a language", false, false),
+		model.HomeActionPreferredLanguage, options...)
+	languageMenu.InitialOption = selected
+
+	translateOwn := slack.NewOptionBlockObject("enabled",
+		slack.NewTextBlockObject("mrkdwn", "*Translate my messages*", false, false),
+		slack.NewTextBlockObject("plain_text", "Reply to my messages with their translation", false, false))
+	checkbox := slack.NewCheckboxGroupsBlockElement(model.HomeActionTranslateOwnMessages, translateOwn)
+	if preference.TranslateOwnMessages {
+		checkbox.InitialOptions = []*slack.OptionBlockObject{translateOwn}
+	}
+
+	return slack.HomeTabViewRequest{
+		Type: slack.VTHomeTab,
+		Blocks: slack.Blocks{BlockSet: []slack.Block{
+			slack.NewHeaderBlock(slack.NewTextBlockObject("plain_text", "Translation preferences", false, false)),
+			slack.NewSectionBlock(
+				slack.NewTextBlockObject("mrkdwn", "*Translate my messages to*\nChannel default uses the channel's languages.", false, false),
+				nil, slack.NewAccessory(languageMenu), slack.SectionBlockOptionBlockID("home_language")),
+			slack.NewActionBlock("home_translate_own", checkbox),
+		}},
+	}
+}
+
+// handleAppHomeOpened publishes the preferences of a user opening the Home tab
+func (ep *eventProcessorImpl) handleAppHomeOpened(ctx context.Context, event map[string]interface{}) {
+	if tab, _ := event["tab"].(string); tab != "home" {
+		return
+	}
+	userID, ok := event["user"].(string)
+	if !ok {
+		ep.logger.Error("Failed to get user ID")
+		return
+	}
+
+	preference, err := ep.preferences.GetPreference(userID)
+	if err != nil {
+		ep.logger.Warn("Failed to load user preference, showing defaults",
+			zap.Error(err),
+			zap.String("user_id", userID))
+		preference = model.DefaultUserPreference(userID)
+	}
+	if err := ep.client(ctx).PublishHomeView(userID, preference); err != nil {
+		ep.logger.Error("Failed to publish home view",
+			zap.Error(err),
+			zap.String("user_id", userID))
+	}
+}
+
+// userPreference returns how the user wants their messages translated
+func (ep *eventProcessorImpl) userPreference(userID string) *model.UserPreference {
+	if ep.preferences == nil {
+		return model.DefaultUserPreference(userID)
+	}
+	preference, err := ep.preferences.GetPreference(userID)
+	if err != nil {
+		ep.logger.Warn("Failed to load user preference, using defaults",
+			zap.Error(err),
+			zap.String("user_id", userID))
+		return model.DefaultUserPreference(userID)
+	}
+	return preference
+}
+
+// routeForUser returns the language a message of the user detected as
+// detected is translated to: their preferred language, unless the message
+// is already in it, or else the channel's route
+func (ep *eventProcessorImpl) routeForUser(preference *model.UserPreference, config *model.ChannelConfig, detected string) (string, bool) {
+	if name, ok := model.LanguageName(preference.PreferredLanguage); ok {
+		if code, known := model.LanguageCode(detected); known && code != preference.PreferredLanguage {
+			return name, true
+		}
+	}
+	return ep.languages.Route(config, detected)
+}
@@ -0,0 +1,86 @@
+package slack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeUserPreferences map[string]*model.UserPreference
+
+func (f fakeUserPreferences) GetPreference(userID string) (*model.UserPreference, error) {
+	if preference, ok := f[userID]; ok {
+		return preference, nil
+	}
+	return model.DefaultUserPreference(userID), nil
+}
+
+func TestEventProcessorHandleMessageEvent_SkipOptedOutUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	preferences := fakeUserPreferences{"UOFF": {UserID: "UOFF", TranslateOwnMessages: false}}
+	processor := NewEventProcessor(mocks.NewMockTranslationService(ctrl), nil, zap.NewNop(),
+		WithUserPreferences(preferences)).(*eventProcessorImpl)
+
+	// No expectations on the translation service or Slack client: the message is skipped
+	processor.handleMessageEvent(context.Background(), map[string]interface{}{
+		"type":    "message",
+		"user":    "UOFF",
+		"channel": "C123456",
+		"text":    "Hello",
+		"ts":      "1234567890.123456",
+	})
+}
+
+func TestEventProcessorRouteForUser(t *testing.T) {
+	processor := NewEventProcessor(nil, nil, zap.NewNop()).(*eventProcessorImpl)
+	channel := &model.ChannelConfig{ChannelID: "C1", TargetLanguage: "vi"}
+
+	tests := []struct {
+		name       string
+		preferred  string
+		config     *model.ChannelConfig
+		detected   string
+		wantTarget string
+		wantOK     bool
+	}{
+		{name: "preferred language", preferred: "ja", config: channel, detected: "English", wantTarget: "Japanese", wantOK: true},
+		{name: "already in preferred language", preferred: "ja", config: channel, detected: "Japanese", wantTarget: "Vietnamese", wantOK: true},
+		{name: "channel default", config: channel, detected: "English", wantTarget: "Vietnamese", wantOK: true},
+		{name: "language pairs", detected: "Vietnamese", wantTarget: "English", wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preference := &model.UserPreference{UserID: "U1", PreferredLanguage: tt.preferred, TranslateOwnMessages: true}
+			target, ok := processor.routeForUser(preference, tt.config, tt.detected)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantTarget, target)
+		})
+	}
+}
+
+func TestHomeView(t *testing.T) {
+	view := homeView(&model.UserPreference{UserID: "U1", PreferredLanguage: "ja", TranslateOwnMessages: false})
+
+	require.Len(t, view.Blocks.BlockSet, 3)
+	section := view.Blocks.BlockSet[1].(*slack.SectionBlock)
+	menu := section.Accessory.SelectElement
+	require.NotNil(t, menu)
+	assert.Equal(t, model.HomeActionPreferredLanguage, menu.ActionID)
+	assert.Equal(t, "ja", menu.InitialOption.Value)
+	assert.Equal(t, model.HomeLanguageChannelDefault, menu.Options[0].Value)
+
+	actions := view.Blocks.BlockSet[2].(*slack.ActionBlock)
+	checkbox := actions.Elements.ElementSet[0].(*slack.CheckboxGroupsBlockElement)
+	assert.Equal(t, model.HomeActionTranslateOwnMessages, checkbox.ActionID)
+	assert.Empty(t, checkbox.InitialOptions)
+}
@@ -0,0 +1,109 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// maxOCRImages is the number of images read from one message
+	maxOCRImages = 4
+	// maxOCRImageBytes skips larger images rather than sending them to the AI
+	maxOCRImageBytes = 10 << 20
+)
+
+// ocrMimeTypes are the image formats the vision model reads
+var ocrMimeTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/webp": true,
+	"image/heic": true,
+	"image/heif": true,
+}
+
+var errImageTooLarge = errors.New("image is too large to read")
+
+// fileDownloader fetches files shared in Slack, e.g. SlackClient
+type fileDownloader interface {
+	DownloadFile(ctx context.Context, url string, w io.Writer) error
+}
+
+// imageText downloads the images among files and returns the text they show,
+// one image per paragraph. Images that cannot be read are logged and skipped.
+func (ep *eventProcessorImpl) imageText(ctx context.Context, downloader fileDownloader, channelID string, files []FileInfo) string {
+	var texts []string
+	read := 0
+	for _, file := range files {
+		if read == maxOCRImages {
+			break
+		}
+		if !ocrMimeTypes[file.Mimetype] || file.URL == "" || file.Size > maxOCRImageBytes {
+			continue
+		}
+		if !ep.mayShareContent(file) {
+			continue
+		}
+		read++
+
+		text, err := ep.readImage(ctx, downloader, file)
+		if err != nil {
+			ep.logger.Warn("Failed to read text from image",
+				zap.Error(err),
+				zap.String("channel_id", channelID),
+				zap.String("file", file.Name))
+			if ep.metrics != nil {
+				ep.metrics.RecordError("image_ocr_failed")
+			}
+			continue
+		}
+		if text != "" {
+			texts = append(texts, text)
+		}
+	}
+
+	if len(texts) > 0 {
+		ep.logger.Info("Read text from shared images",
+			zap.String("channel_id", channelID),
+			zap.Int("images", len(texts)))
+	}
+	return strings.Join(texts, "\n\n")
+}
+
+// readImage downloads one image with the bot token and extracts its text,
+// which is kept as an artifact
+func (ep *eventProcessorImpl) readImage(ctx context.Context, downloader fileDownloader, file FileInfo) (string, error) {
+	key := ep.artifactKey(ctx, "ocr", file)
+	if text, ok := ep.loadArtifact(ctx, key); ok {
+		return string(text), nil
+	}
+
+	data, err := ep.downloadFile(ctx, downloader, file, maxOCRImageBytes)
+	if err != nil {
+		return "", err
+	}
+	text, err := ep.ocr.ExtractImageText(ctx, file.Mimetype, data)
+	if err != nil {
+		return "", err
+	}
+	ep.saveArtifact(ctx, key, []byte(text), "text/plain; charset=utf-8")
+	return text, nil
+}
+
+// limitedBuffer is a bytes.Buffer refusing writes past limit bytes, for
+// files whose size Slack did not report
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, errImageTooLarge
+	}
+	return b.Buffer.Write(p)
+}
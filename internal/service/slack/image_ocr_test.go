@@ -0,0 +1,87 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeDownloader serves file contents by URL
+type fakeDownloader map[string]string
+
+func (f fakeDownloader) DownloadFile(_ context.Context, url string, w io.Writer) error {
+	content, ok := f[url]
+	if !ok {
+		return errors.New("file_not_found")
+	}
+	_, err := io.WriteString(w, content)
+	return err
+}
+
+// fakeOCR returns the downloaded content as the image text
+type fakeOCR struct {
+	calls int
+}
+
+func (f *fakeOCR) ExtractImageText(_ context.Context, _ string, data []byte) (string, error) {
+	f.calls++
+	return string(data), nil
+}
+
+func TestEventProcessorImageText(t *testing.T) {
+	downloader := fakeDownloader{
+		"https://files.slack.com/menu.png":  "Xin chào",
+		"https://files.slack.com/logo.png":  "",
+		"https://files.slack.com/photo.jpg": "Cảm ơn",
+	}
+	ocr := &fakeOCR{}
+	m := metrics.NewMetrics()
+	processor := NewEventProcessor(nil, nil, zap.NewNop(), WithImageOCR(ocr), WithMetrics(m)).(*eventProcessorImpl)
+
+	text := processor.imageText(context.Background(), downloader, "C1", []FileInfo{
+		{URL: "https://files.slack.com/menu.png", Mimetype: "image/png"},
+		{URL: "https://files.slack.com/report.pdf", Mimetype: "application/pdf"},
+		{URL: "https://files.slack.com/logo.png", Mimetype: "image/png"},
+		{URL: "https://files.slack.com/huge.png", Mimetype: "image/png", Size: maxOCRImageBytes + 1},
+		{URL: "https://files.slack.com/missing.png", Mimetype: "image/png"},
+		{URL: "https://files.slack.com/photo.jpg", Mimetype: "image/jpeg"},
+	})
+
+	assert.Equal(t, "Xin chào\n\nCảm ơn", text)
+	assert.Equal(t, 3, ocr.calls, "only downloaded images of a supported type and size are read")
+	assert.Equal(t, int64(1), m.ErrorsByType["image_ocr_failed"])
+}
+
+func TestEventProcessorImageText_Artifacts(t *testing.T) {
+	ctx := withTeam(context.Background(), "T1")
+	downloader := fakeDownloader{"https://files.slack.com/menu.png": "Xin chào"}
+	ocr := &fakeOCR{}
+	store := storage.NewLocalStore(t.TempDir())
+	processor := NewEventProcessor(nil, nil, zap.NewNop(), WithImageOCR(ocr), WithArtifactStore(store)).(*eventProcessorImpl)
+	files := []FileInfo{{ID: "F1", URL: "https://files.slack.com/menu.png", Mimetype: "image/png"}}
+
+	assert.Equal(t, "Xin chào", processor.imageText(ctx, downloader, "C1", files))
+	attachment, err := store.Get(ctx, "attachments/T1/F1")
+	require.NoError(t, err)
+	assert.Equal(t, "Xin chào", string(attachment))
+
+	delete(downloader, "https://files.slack.com/menu.png")
+	assert.Equal(t, "Xin chào", processor.imageText(ctx, downloader, "C1", files))
+	assert.Equal(t, 1, ocr.calls, "the text read before is reused without downloading the image again")
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	buf := &limitedBuffer{limit: 4}
+	_, err := buf.Write([]byte("abcd"))
+	assert.NoError(t, err)
+	_, err = buf.Write([]byte("e"))
+	assert.ErrorIs(t, err, errImageTooLarge)
+	assert.Equal(t, "abcd", buf.String())
+}
@@ -1,8 +1,48 @@
 package slack
 
-import "context"
+import (
+	"context"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
 
 // EventProcessor defines the interface for Slack event processing
 type EventProcessor interface {
 	ProcessEvent(ctx context.Context, payload map[string]interface{})
 }
+
+// EventHandler handles the Events API events of one type, e.g. "message" or
+// "reaction_added"
+type EventHandler interface {
+	HandleEvent(ctx context.Context, event map[string]interface{})
+}
+
+// MessageFilter decides whether a message should be translated
+type MessageFilter interface {
+	ShouldTranslate(input model.FilterInput) (bool, string)
+}
+
+// DraftSubmitter holds translations for channels that require review before posting
+type DraftSubmitter interface {
+	Submit(draft *model.PendingTranslation) (bool, error)
+}
+
+// DigestCollector batches translations of channels in digest mode
+type DigestCollector interface {
+	Collect(channelID string, entry DigestEntry) bool
+}
+
+// ChannelConfigLookup returns the translation settings of a channel
+type ChannelConfigLookup interface {
+	GetChannelConfig(channelID string) (*model.ChannelConfig, error)
+}
+
+// PauseChecker reports whether translation is paused in a channel
+type PauseChecker interface {
+	IsPaused(channelID string) bool
+}
+
+// ImageTextExtractor reads the text of an image, returning "" when it has none
+type ImageTextExtractor interface {
+	ExtractImageText(ctx context.Context, mimeType string, data []byte) (string, error)
+}
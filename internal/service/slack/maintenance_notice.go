@@ -0,0 +1,38 @@
+package slack
+
+import "go.uber.org/zap"
+
+// MaintenanceNoticeSource hands out the maintenance notice once per channel
+type MaintenanceNoticeSource interface {
+	ClaimNotice(channelID string) (string, bool)
+}
+
+// MaintenanceNotice posts the maintenance notice to a channel whose messages
+// are held during maintenance
+type MaintenanceNotice struct {
+	notices MaintenanceNoticeSource
+	poster  MessagePoster
+	logger  *zap.Logger
+}
+
+func NewMaintenanceNotice(notices MaintenanceNoticeSource, poster MessagePoster, logger *zap.Logger) *MaintenanceNotice {
+	return &MaintenanceNotice{
+		notices: notices,
+		poster:  poster,
+		logger:  logger,
+	}
+}
+
+// NotifyMaintenance posts the notice unless the channel already received it
+// during this maintenance
+func (n *MaintenanceNotice) NotifyMaintenance(channelID string) {
+	notice, ok := n.notices.ClaimNotice(channelID)
+	if !ok {
+		return
+	}
+	if _, _, err := n.poster.PostMessage(channelID, notice, ""); err != nil {
+		n.logger.Error("Failed to post maintenance notice",
+			zap.String("channel_id", channelID),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,164 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+var _ service.MessageActionService = (*MessageActions)(nil)
+
+// WithReplyButtons shows "Show original" and "Try another language" below
+// translations posted in threads
+func WithReplyButtons(enabled bool) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.replyButtons = enabled
+	}
+}
+
+// translationActions builds the buttons below the translation of messageTS
+func translationActions(messageTS string) *slack.ActionBlock {
+	showOriginal := slack.NewButtonBlockElement(model.TranslationActionShowOriginal, messageTS,
+		slack.NewTextBlockObject("plain_text", "Show original", false, false))
+
+	options := make([]*slack.OptionBlockObject, 0, len(languageFlags))
+	for _, code := range model.SupportedLanguageCodes() {
+		name, _ := model.LanguageName(code)
+		options = append(options, slack.NewOptionBlockObject(code,
+			slack.NewTextBlockObject("plain_text", fmt.Sprintf("%s %s", languageFlags[code], name), true, false), nil))
+	}
+	retranslate := slack.NewOptionsSelectBlockElement(slack.OptTypeStatic,
+		slack.NewTextBlockObject("plain_text", "Try another language", false, false),
+		model.TranslationActionRetranslate, options...)
+
+	return slack.NewActionBlock(model.TranslationActionsBlockID(messageTS), showOriginal, retranslate)
+}
+
+// PostTranslation posts a translation rendered like TranslationContent, in
+// the thread of threadTS
+func (sc *SlackClient) PostTranslation(channelID, threadTS, username, avatarURL string, reply TranslationContent) (string, string, error) {
+	if sc.client == nil {
+		return "", "", fmt.Errorf("slack client is not initialized")
+	}
+
+	text := reply.Text
+	if reply.AsQuote {
+		text = "> " + text
+	}
+	opts := []slack.MsgOption{
+		slack.MsgOptionText(text, false),
+		slack.MsgOptionBlocks(reply.blocks()...),
+	}
+
+	if threadTS != "" {
+		opts = append(opts, slack.MsgOptionTS(threadTS))
+	}
+
+	if username != "" {
+		opts = append(opts, slack.MsgOptionUsername(username))
+	}
+
+	if avatarURL != "" {
+		opts = append(opts, slack.MsgOptionIconURL(avatarURL))
+	}
+
+	channel, ts, err := sc.client.PostMessage(channelID, opts...)
+	return channel, ts, err
+}
+
+// messageFetcher returns a message of a channel, e.g. SlackClient
+type messageFetcher interface {
+	GetMessage(channelID, timestamp string) (*slack.Message, error)
+}
+
+// MessageActions answers the buttons below translations: it shows the
+// message a translation was made from, or translates it into another language
+type MessageActions struct {
+	translationUseCase service.TranslationService
+	slackClient        *SlackClient
+	clients            *ClientPool
+	logger             *zap.Logger
+	// fetcher overrides the team's client in tests
+	fetcher messageFetcher
+}
+
+func NewMessageActions(translationUseCase service.TranslationService, slackClient *SlackClient, logger *zap.Logger) *MessageActions {
+	return &MessageActions{
+		translationUseCase: translationUseCase,
+		slackClient:        slackClient,
+		logger:             logger,
+	}
+}
+
+// SetWorkspaceClients fetches messages with the client of the workspace a button was clicked in
+func (ma *MessageActions) SetWorkspaceClients(clients *ClientPool) {
+	ma.clients = clients
+}
+
+// Original returns the text of the message messageTS
+func (ma *MessageActions) Original(teamID, channelID, messageTS string) (string, error) {
+	var client messageFetcher = ma.slackClient
+	if ma.fetcher != nil {
+		client = ma.fetcher
+	} else if ma.clients != nil {
+		client = ma.clients.ForTeam(teamID)
+	}
+
+	message, err := client.GetMessage(channelID, messageTS)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch original message: %w", err)
+	}
+	// conversations.history only returns top-level messages, and deleted
+	// messages are gone
+	if message == nil || strings.TrimSpace(message.Text) == "" {
+		return "", model.NewNotFoundError("the original message is no longer available")
+	}
+	return message.Text, nil
+}
+
+// Retranslate translates the message messageTS into the language code for userID
+func (ma *MessageActions) Retranslate(ctx context.Context, teamID, channelID, messageTS, userID, code string) (response.Translation, error) {
+	targetLang, ok := model.LanguageName(code)
+	if !ok {
+		return response.Translation{}, model.NewValidationError(fmt.Sprintf("unsupported language: %s", code))
+	}
+
+	text, err := ma.Original(teamID, channelID, messageTS)
+	if err != nil {
+		return response.Translation{}, err
+	}
+
+	detectedLang, err := ma.translationUseCase.DetectLanguage(text)
+	if err != nil {
+		return response.Translation{}, fmt.Errorf("failed to detect language: %w", err)
+	}
+	if strings.EqualFold(detectedLang, targetLang) {
+		return response.Translation{}, model.NewValidationError(fmt.Sprintf("the message is already in %s", targetLang))
+	}
+
+	result, err := ma.translationUseCase.TranslateContext(ctx, request.Translation{
+		Text:           text,
+		SourceLanguage: detectedLang,
+		TargetLanguage: targetLang,
+		UserID:         userID,
+		ChannelID:      channelID,
+	})
+	if err != nil {
+		return response.Translation{}, err
+	}
+	result.TranslatedText = quoteMentions(result.TranslatedText)
+
+	ma.logger.Info("Message retranslated on request",
+		zap.String("channel_id", channelID),
+		zap.String("user_id", userID),
+		zap.String("timestamp", messageTS),
+		zap.String("target_language", targetLang))
+	return result, nil
+}
@@ -0,0 +1,86 @@
+package slack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeMessageFetcher map[string]string
+
+func (f fakeMessageFetcher) GetMessage(_, timestamp string) (*slack.Message, error) {
+	text, ok := f[timestamp]
+	if !ok {
+		return nil, nil
+	}
+	return &slack.Message{Msg: slack.Msg{Timestamp: timestamp, Text: text}}, nil
+}
+
+func TestMessageActions_Original(t *testing.T) {
+	actions := NewMessageActions(nil, nil, zap.NewNop())
+	actions.fetcher = fakeMessageFetcher{"1.1": "Hello team"}
+
+	original, err := actions.Original("T1", "C1", "1.1")
+	require.NoError(t, err)
+	assert.Equal(t, "Hello team", original)
+
+	_, err = actions.Original("T1", "C1", "2.2")
+	assert.True(t, model.IsNotFound(err))
+}
+
+func TestMessageActions_Retranslate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	translator := mocks.NewMockTranslationService(ctrl)
+	actions := NewMessageActions(translator, nil, zap.NewNop())
+	actions.fetcher = fakeMessageFetcher{"1.1": "Hello <!here>"}
+
+	translator.EXPECT().DetectLanguage("Hello <!here>").Return("English", nil).Times(2)
+	translator.EXPECT().TranslateContext(gomock.Any(), request.Translation{
+		Text:           "Hello <!here>",
+		SourceLanguage: "English",
+		TargetLanguage: "Japanese",
+		UserID:         "U1",
+		ChannelID:      "C1",
+	}).Return(response.Translation{TranslatedText: "こんにちは <!here>", TargetLanguage: "Japanese"}, nil)
+
+	result, err := actions.Retranslate(context.Background(), "T1", "C1", "1.1", "U1", "ja")
+	require.NoError(t, err)
+	assert.Equal(t, "Japanese", result.TargetLanguage)
+	assert.Equal(t, "こんにちは `here`", result.TranslatedText)
+
+	_, err = actions.Retranslate(context.Background(), "T1", "C1", "1.1", "U1", "en")
+	var domainErr *model.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "the message is already in English", domainErr.Message)
+
+	_, err = actions.Retranslate(context.Background(), "T1", "C1", "1.1", "U1", "xx")
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, model.ErrorTypeValidation, domainErr.Type)
+}
+
+func TestTranslationContent_Buttons(t *testing.T) {
+	blocks := TranslationContent{Text: "Xin chào", MessageTS: "1.1"}.blocks()
+	require.Len(t, blocks, 2)
+
+	actions, ok := blocks[1].(*slack.ActionBlock)
+	require.True(t, ok)
+	messageTS, ok := model.TranslatedMessageTS(actions.BlockID)
+	require.True(t, ok)
+	assert.Equal(t, "1.1", messageTS)
+	require.Len(t, actions.Elements.ElementSet, 2)
+	assert.Equal(t, model.TranslationActionShowOriginal, actions.Elements.ElementSet[0].(*slack.ButtonBlockElement).ActionID)
+	assert.Equal(t, model.TranslationActionRetranslate, actions.Elements.ElementSet[1].(*slack.SelectBlockElement).ActionID)
+
+	assert.Len(t, TranslationContent{Text: "Xin chào"}.blocks(), 1)
+}
@@ -0,0 +1,66 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/secrets"
+	"github.com/slack-go/slack"
+)
+
+// oauthAuthorizeURL is where users approve installing the app in their workspace
+const oauthAuthorizeURL = "https://slack.com/oauth/v2/authorize"
+
+// OAuthInstaller runs Slack's OAuth v2 flow that installs the app in a workspace
+type OAuthInstaller struct {
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	scopes       []string
+	httpClient   *http.Client
+}
+
+func NewOAuthInstaller(clientID, clientSecret, redirectURL string, scopes []string) *OAuthInstaller {
+	return &OAuthInstaller{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		scopes:       scopes,
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// AuthorizeURL returns the Slack page that asks the user to install the app;
+// Slack sends them back to the redirect URL with state and a code
+func (o *OAuthInstaller) AuthorizeURL(state string) string {
+	query := url.Values{}
+	query.Set("client_id", o.clientID)
+	query.Set("scope", strings.Join(o.scopes, ","))
+	query.Set("redirect_uri", o.redirectURL)
+	query.Set("state", state)
+	return oauthAuthorizeURL + "?" + query.Encode()
+}
+
+// Exchange trades the code Slack redirected with for the workspace's bot token
+func (o *OAuthInstaller) Exchange(ctx context.Context, code string) (*model.Workspace, error) {
+	resp, err := slack.GetOAuthV2ResponseContext(ctx, o.httpClient, o.clientID, o.clientSecret, code, o.redirectURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange OAuth code: %w", err)
+	}
+	if resp.AccessToken == "" || resp.Team.ID == "" {
+		return nil, fmt.Errorf("OAuth response has no bot token")
+	}
+
+	return &model.Workspace{
+		TeamID:      resp.Team.ID,
+		TeamName:    resp.Team.Name,
+		BotUserID:   resp.BotUserID,
+		BotToken:    secrets.Secret(resp.AccessToken),
+		InstalledBy: resp.AuthedUser.ID,
+	}, nil
+}
@@ -0,0 +1,142 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/slack-go/slack"
+)
+
+var _ service.OnboardingPublisher = (*OnboardingWizard)(nil)
+
+// OnboardingWizard shows the setup wizard in a direct message to the user
+// who installed the app, as one message updated at each step
+type OnboardingWizard struct {
+	slackClient *SlackClient
+	clients     *ClientPool
+}
+
+func NewOnboardingWizard(slackClient *SlackClient) *OnboardingWizard {
+	return &OnboardingWizard{slackClient: slackClient}
+}
+
+// SetWorkspaceClients sends the wizard with the client of the workspace it sets up
+func (w *OnboardingWizard) SetWorkspaceClients(clients *ClientPool) {
+	w.clients = clients
+}
+
+// PublishOnboarding implements service.OnboardingPublisher
+func (w *OnboardingWizard) PublishOnboarding(ctx context.Context, session *model.OnboardingSession) (string, string, error) {
+	client := w.slackClient
+	if w.clients != nil {
+		client = w.clients.ForTeam(session.TeamID)
+	}
+	if client.client == nil {
+		return "", "", fmt.Errorf("slack client is not initialized")
+	}
+
+	opts := []slack.MsgOption{
+		slack.MsgOptionText("Set up the translation bot", false),
+		slack.MsgOptionBlocks(onboardingBlocks(session)...),
+	}
+	if session.MessageTS == "" {
+		// Posting to a user ID opens the app's direct message with them
+		return client.client.PostMessageContext(ctx, session.UserID, opts...)
+	}
+	channelID, messageTS, _, err := client.client.UpdateMessageContext(ctx, session.ChannelID, session.MessageTS, opts...)
+	return channelID, messageTS, err
+}
+
+// onboardingBlocks builds the wizard's message at the session's step
+func onboardingBlocks(session *model.OnboardingSession) []slack.Block {
+	blocks := []slack.Block{
+		slack.NewHeaderBlock(slack.NewTextBlockObject("plain_text", "Set up the translation bot", false, false)),
+	}
+	text := func(mrkdwn string) *slack.TextBlockObject {
+		return slack.NewTextBlockObject("mrkdwn", mrkdwn, false, false)
+	}
+	step := func(n int) slack.Block {
+		return slack.NewContextBlock("", text(fmt.Sprintf("Step %d of 3", n)))
+	}
+
+	switch session.Step {
+	case model.OnboardingStepLanguage:
+		options := make([]*slack.OptionBlockObject, 0, len(languageFlags))
+		for _, code := range model.SupportedLanguageCodes() {
+			name, _ := model.LanguageName(code)
+			options = append(options, slack.NewOptionBlockObject(code,
+				slack.NewTextBlockObject("plain_text", fmt.Sprintf("%s %s", languageFlags[code], name), true, false), nil))
+		}
+		menu := slack.NewOptionsSelectBlockElement(slack.OptTypeStatic,
+			slack.NewTextBlockObject("plain_text", "Choose a language", false, false),
+			model.OnboardingActionLanguage, options...)
+		blocks = append(blocks, step(1),
+			slack.NewSectionBlock(text("Thanks for installing me! 👋\n*Which language should your channels be translated to?*"),
+				nil, slack.NewAccessory(menu)))
+
+	case model.OnboardingStepChannels:
+		menu := slack.NewOptionsMultiSelectBlockElement(slack.MultiOptTypeConversations,
+			slack.NewTextBlockObject("plain_text", "Choose channels", false, false),
+			model.OnboardingActionChannels)
+		menu.Filter = &slack.SelectBlockElementFilter{Include: []string{"public", "private"}, ExcludeBotUsers: true}
+		next := slack.NewButtonBlockElement(model.OnboardingActionChannelsNext, "next",
+			slack.NewTextBlockObject("plain_text", "Next", false, false)).WithStyle(slack.StylePrimary)
+		blocks = append(blocks, step(2),
+			slack.NewSectionBlock(text(fmt.Sprintf("Translating to %s.\n*Which channels should be translated?* "+
+				"Invite me to each of them with `/invite`.", onboardingLanguage(session.TargetLanguage))), nil, nil),
+			slack.NewActionBlock(model.OnboardingChannelsBlockID, menu, next))
+
+	case model.OnboardingStepBudget:
+		options := make([]*slack.OptionBlockObject, 0, len(model.OnboardingBudgets))
+		for _, budget := range model.OnboardingBudgets {
+			options = append(options, slack.NewOptionBlockObject(strconv.FormatFloat(budget, 'f', -1, 64),
+				slack.NewTextBlockObject("plain_text", formatBudget(budget), false, false), nil))
+		}
+		menu := slack.NewOptionsSelectBlockElement(slack.OptTypeStatic,
+			slack.NewTextBlockObject("plain_text", "Choose a budget", false, false),
+			model.OnboardingActionBudget, options...)
+		blocks = append(blocks, step(3),
+			slack.NewSectionBlock(text(fmt.Sprintf("Translating %d channels to %s.\n*How much AI spend do you plan per month?*",
+				len(session.ChannelIDs), onboardingLanguage(session.TargetLanguage))), nil, slack.NewAccessory(menu)))
+
+	case model.OnboardingStepDone:
+		var b strings.Builder
+		b.WriteString("✅ *You're all set!*")
+		var configured, failed []string
+		for _, result := range session.Results {
+			if result.Status == model.BulkStatusFailed {
+				failed = append(failed, fmt.Sprintf("<#%s> (%s)", result.ChannelID, result.Error))
+				continue
+			}
+			configured = append(configured, fmt.Sprintf("<#%s>", result.ChannelID))
+		}
+		if len(configured) > 0 {
+			fmt.Fprintf(&b, "\n• Translating to %s in %s", onboardingLanguage(session.TargetLanguage), strings.Join(configured, ", "))
+		}
+		if len(failed) > 0 {
+			fmt.Fprintf(&b, "\n• ⚠️ Could not configure %s", strings.Join(failed, ", "))
+		}
+		fmt.Fprintf(&b, "\n• Monthly budget: %s", formatBudget(session.MonthlyBudget))
+		blocks = append(blocks, slack.NewSectionBlock(text(b.String()), nil, nil),
+			slack.NewContextBlock("", text("Settings can be changed anytime through the admin API.")))
+	}
+	return blocks
+}
+
+// onboardingLanguage names a language code with its flag, e.g. "🇯🇵 Japanese"
+func onboardingLanguage(code string) string {
+	name, _ := model.LanguageName(code)
+	return fmt.Sprintf("%s %s", languageFlags[code], name)
+}
+
+// formatBudget describes a monthly budget in US dollars
+func formatBudget(budget float64) string {
+	if budget == 0 {
+		return "No budget"
+	}
+	return fmt.Sprintf("$%s a month", strconv.FormatFloat(budget, 'f', -1, 64))
+}
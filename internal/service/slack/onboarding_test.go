@@ -0,0 +1,36 @@
+package slack
+
+import (
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnboardingBlocks(t *testing.T) {
+	channels := onboardingBlocks(&model.OnboardingSession{Step: model.OnboardingStepChannels, TargetLanguage: "ja"})
+	require.Len(t, channels, 4)
+	actions, ok := channels[3].(*slack.ActionBlock)
+	require.True(t, ok)
+	assert.Equal(t, model.OnboardingChannelsBlockID, actions.BlockID, "Next reads the channels from this block")
+	menu, ok := actions.Elements.ElementSet[0].(*slack.MultiSelectBlockElement)
+	require.True(t, ok)
+	assert.Equal(t, model.OnboardingActionChannels, menu.ActionID)
+
+	done := onboardingBlocks(&model.OnboardingSession{
+		Step:           model.OnboardingStepDone,
+		TargetLanguage: "ja",
+		MonthlyBudget:  50,
+		Results: []model.BulkChannelResult{
+			{ChannelID: "C1", Status: model.BulkStatusApplied},
+			{ChannelID: "C2", Status: model.BulkStatusFailed, Error: "database unavailable"},
+		},
+	})
+	require.Len(t, done, 3)
+	summary, ok := done[1].(*slack.SectionBlock)
+	require.True(t, ok)
+	assert.Equal(t, "✅ *You're all set!*\n• Translating to 🇯🇵 Japanese in <#C1>\n"+
+		"• ⚠️ Could not configure <#C2> (database unavailable)\n• Monthly budget: $50 a month", summary.Text.Text)
+}
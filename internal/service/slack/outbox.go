@@ -0,0 +1,324 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// Waits before posting a buffered post again: the first retry waits
+// defaultRetryDelay, each next one twice as long, up to defaultMaxRetryDelay
+const (
+	defaultRetryDelay    = 30 * time.Second
+	defaultMaxRetryDelay = 30 * time.Minute
+)
+
+// slackOutageErrors are the Slack API error codes of a failure on Slack's side
+var slackOutageErrors = map[string]bool{
+	"internal_error":      true,
+	"fatal_error":         true,
+	"service_unavailable": true,
+	"request_timeout":     true,
+	"ratelimited":         true,
+}
+
+// Outbox posts replies to Slack off the event workers, so a slow
+// chat.postMessage does not hold up translating the channel's next message.
+// Posts for the same thread run one at a time, in the order they were
+// queued; posts for different threads run concurrently. At most maxPending
+// posts wait at once, after which Post blocks until one is done.
+type Outbox struct {
+	logger  *zap.Logger
+	metrics *metrics.Metrics
+	slots   chan struct{}
+	wg      sync.WaitGroup
+
+	// Posts failing while Slack is unavailable are retried for retryFor, at
+	// most maxBuffered at once; zero retryFor disables retries
+	retryFor      time.Duration
+	maxBuffered   int
+	retryDelay    time.Duration
+	maxRetryDelay time.Duration
+
+	mu       sync.Mutex
+	threads  map[string][]func() // queued posts by thread, present while the thread's poster runs
+	buffered map[string]*retryPost
+	sequence uint64
+	closed   bool
+}
+
+// retryPost is a post that may be retried, by the key collapsing its duplicates
+type retryPost struct {
+	thread   string
+	key      string
+	seq      uint64
+	post     func() error
+	giveUp   func()
+	since    time.Time
+	attempts int
+	timer    *time.Timer
+}
+
+func NewOutbox(maxPending int, logger *zap.Logger) *Outbox {
+	return &Outbox{
+		logger:        logger,
+		slots:         make(chan struct{}, maxPending),
+		threads:       make(map[string][]func()),
+		buffered:      make(map[string]*retryPost),
+		retryDelay:    defaultRetryDelay,
+		maxRetryDelay: defaultMaxRetryDelay,
+	}
+}
+
+// SetRetry buffers the posts failing while Slack is unavailable and posts
+// them again, waiting twice as long after each failure, from 30 seconds up to
+// 30 minutes, until they failed for retryFor. At most maxBuffered posts are
+// buffered at once.
+func (o *Outbox) SetRetry(retryFor time.Duration, maxBuffered int) {
+	o.retryFor = retryFor
+	o.maxBuffered = maxBuffered
+}
+
+// SetMetrics counts the posts buffered, recovered and given up in the error metrics
+func (o *Outbox) SetMetrics(m *metrics.Metrics) {
+	o.metrics = m
+}
+
+// threadKey identifies the Slack thread, or channel for top-level posts,
+// whose replies must be posted in order
+func threadKey(channelID, threadTS string) string {
+	return channelID + ":" + threadTS
+}
+
+// Post queues post behind the earlier posts of the thread. Once the outbox
+// is shut down, post runs right away instead.
+func (o *Outbox) Post(thread string, post func()) {
+	o.slots <- struct{}{}
+
+	o.mu.Lock()
+	if o.closed {
+		o.mu.Unlock()
+		<-o.slots
+		post()
+		return
+	}
+	queued, running := o.threads[thread]
+	o.threads[thread] = append(queued, post)
+	if !running {
+		o.wg.Add(1)
+		go o.run(thread)
+	}
+	o.mu.Unlock()
+}
+
+// PostWithRetry queues post like Post. When post fails because Slack is
+// unavailable, it is buffered and posted again later, in the thread's order
+// when it is retried. Posts share key when they are versions of the same
+// reply: a later version replaces a buffered one, so a reply is posted once.
+// giveUp runs when post fails for good: with another error, once retries are
+// exhausted or when the buffer is full.
+func (o *Outbox) PostWithRetry(thread, key string, post func() error, giveUp func()) {
+	o.mu.Lock()
+	o.sequence++
+	entry := &retryPost{thread: thread, key: key, seq: o.sequence, post: post, giveUp: giveUp, since: time.Now()}
+	if previous, ok := o.buffered[key]; ok {
+		// The new version is posted right away, and keeps the retries left
+		previous.timer.Stop()
+		delete(o.buffered, key)
+		entry.since = previous.since
+		entry.attempts = previous.attempts
+	}
+	o.mu.Unlock()
+
+	o.Post(thread, func() { o.attempt(entry) })
+}
+
+// attempt posts entry, buffering it for a retry when Slack is unavailable
+func (o *Outbox) attempt(entry *retryPost) {
+	err := entry.post()
+	if err == nil {
+		o.mu.Lock()
+		// An older version buffered meanwhile must not be posted as well
+		if buffered, ok := o.buffered[entry.key]; ok && buffered.seq < entry.seq {
+			buffered.timer.Stop()
+			delete(o.buffered, entry.key)
+		}
+		o.mu.Unlock()
+		if entry.attempts > 0 {
+			o.logger.Info("Posted buffered reply after Slack recovered",
+				zap.String("key", entry.key),
+				zap.Int("attempts", entry.attempts+1),
+				zap.Duration("delay", time.Since(entry.since)))
+			o.recordError("slack_post_recovered")
+		}
+		return
+	}
+
+	if !slackUnavailable(err) {
+		entry.giveUp()
+		return
+	}
+
+	o.mu.Lock()
+	buffered, ok := o.buffered[entry.key]
+	switch {
+	case ok && buffered.seq > entry.seq:
+		// A later version of the reply is buffered
+		o.mu.Unlock()
+		return
+	case o.closed || o.retryFor <= 0 || time.Since(entry.since) >= o.retryFor:
+		o.mu.Unlock()
+		o.logger.Warn("Giving up posting reply while Slack is unavailable",
+			zap.Error(err),
+			zap.String("key", entry.key),
+			zap.Int("attempts", entry.attempts+1))
+		o.recordError("slack_post_gave_up")
+		entry.giveUp()
+		return
+	case !ok && len(o.buffered) >= o.maxBuffered:
+		o.mu.Unlock()
+		o.logger.Warn("Too many replies buffered while Slack is unavailable, dropping",
+			zap.Error(err),
+			zap.String("key", entry.key))
+		o.recordError("slack_post_dropped")
+		entry.giveUp()
+		return
+	}
+	if ok {
+		buffered.timer.Stop()
+	}
+
+	delay := o.retryDelay << min(entry.attempts, 16)
+	if delay > o.maxRetryDelay || delay <= 0 {
+		delay = o.maxRetryDelay
+	}
+	entry.attempts++
+	o.buffered[entry.key] = entry
+	entry.timer = time.AfterFunc(delay, func() { o.retry(entry) })
+	o.mu.Unlock()
+
+	o.logger.Warn("Slack is unavailable, buffering reply",
+		zap.Error(err),
+		zap.String("key", entry.key),
+		zap.Int("attempt", entry.attempts),
+		zap.Duration("retry_in", delay))
+	o.recordError("slack_post_buffered")
+}
+
+// retry queues a buffered post again, unless a later version replaced it
+func (o *Outbox) retry(entry *retryPost) {
+	o.mu.Lock()
+	if o.buffered[entry.key] != entry || o.closed {
+		o.mu.Unlock()
+		return
+	}
+	delete(o.buffered, entry.key)
+	o.mu.Unlock()
+
+	o.Post(entry.thread, func() { o.attempt(entry) })
+}
+
+// Buffered returns the number of posts waiting to be retried
+func (o *Outbox) Buffered() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.buffered)
+}
+
+func (o *Outbox) recordError(errorType string) {
+	if o.metrics != nil {
+		o.metrics.RecordError(errorType)
+	}
+}
+
+// slackUnavailable reports whether a Web API call failed because Slack was
+// unreachable, overloaded or rate limiting, so that it may succeed later
+func slackUnavailable(err error) bool {
+	var retryable interface{ Retryable() bool }
+	if errors.As(err, &retryable) {
+		return retryable.Retryable()
+	}
+	var slackErr slack.SlackErrorResponse
+	if errors.As(err, &slackErr) {
+		return slackOutageErrors[slackErr.Err]
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// run posts the thread's queued posts until none are left
+func (o *Outbox) run(thread string) {
+	defer o.wg.Done()
+	for {
+		o.mu.Lock()
+		queued := o.threads[thread]
+		if len(queued) == 0 {
+			delete(o.threads, thread)
+			o.mu.Unlock()
+			return
+		}
+		post := queued[0]
+		o.threads[thread] = queued[1:]
+		o.mu.Unlock()
+
+		o.runPost(thread, post)
+		<-o.slots
+	}
+}
+
+// runPost runs one post, so a panicking post does not take the thread's
+// later posts down with it
+func (o *Outbox) runPost(thread string, post func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			o.logger.Error("Outbox post panicked",
+				zap.String("thread", thread),
+				zap.Any("panic", r))
+		}
+	}()
+	post()
+}
+
+// Pending returns the number of posts queued or being posted
+func (o *Outbox) Pending() int {
+	return len(o.slots)
+}
+
+// Shutdown waits up to timeout for the queued posts to be posted. Posts
+// queued afterwards run right away, and buffered posts are dropped.
+func (o *Outbox) Shutdown(timeout time.Duration) error {
+	o.mu.Lock()
+	o.closed = true
+	dropped := len(o.buffered)
+	for key, entry := range o.buffered {
+		entry.timer.Stop()
+		delete(o.buffered, key)
+	}
+	o.mu.Unlock()
+	if dropped > 0 {
+		o.logger.Warn("Dropping replies buffered while Slack was unavailable",
+			zap.Int("dropped", dropped))
+	}
+
+	done := make(chan struct{})
+	go func() {
+		o.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		o.logger.Warn("Outbox shutdown timeout reached, some replies may not be posted",
+			zap.Int("pending", o.Pending()))
+		return fmt.Errorf("outbox shutdown timeout after %v", timeout)
+	}
+}
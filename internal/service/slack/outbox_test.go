@@ -0,0 +1,200 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestOutbox_PostsInOrderPerThread(t *testing.T) {
+	outbox := NewOutbox(10, zap.NewNop())
+
+	var mu sync.Mutex
+	var posted []string
+	record := func(name string) func() {
+		return func() {
+			mu.Lock()
+			defer mu.Unlock()
+			posted = append(posted, name)
+		}
+	}
+
+	// The first post of thread A is slow; the others must wait behind it
+	release := make(chan struct{})
+	outbox.Post(threadKey("C1", "1.0"), func() {
+		<-release
+		record("a1")()
+	})
+	outbox.Post(threadKey("C1", "1.0"), record("a2"))
+
+	// Another thread is not held up by the slow post
+	otherDone := make(chan struct{})
+	outbox.Post(threadKey("C1", "2.0"), func() {
+		record("b1")()
+		close(otherDone)
+	})
+	select {
+	case <-otherDone:
+	case <-time.After(time.Second):
+		t.Fatal("post to another thread waited for the slow post")
+	}
+
+	close(release)
+	require.NoError(t, outbox.Shutdown(time.Second))
+	assert.Equal(t, []string{"b1", "a1", "a2"}, posted)
+	assert.Zero(t, outbox.Pending())
+}
+
+func TestOutbox_PanickingPostKeepsThreadGoing(t *testing.T) {
+	outbox := NewOutbox(10, zap.NewNop())
+
+	posted := false
+	outbox.Post("thread", func() { panic("boom") })
+	outbox.Post("thread", func() { posted = true })
+
+	require.NoError(t, outbox.Shutdown(time.Second))
+	assert.True(t, posted)
+}
+
+func TestOutbox_PostAfterShutdownRunsInline(t *testing.T) {
+	outbox := NewOutbox(1, zap.NewNop())
+	require.NoError(t, outbox.Shutdown(time.Second))
+
+	posted := false
+	outbox.Post("thread", func() { posted = true })
+	assert.True(t, posted)
+}
+
+func TestOutbox_ShutdownTimeout(t *testing.T) {
+	outbox := NewOutbox(1, zap.NewNop())
+	release := make(chan struct{})
+	defer close(release)
+	outbox.Post("thread", func() { <-release })
+
+	assert.Error(t, outbox.Shutdown(10*time.Millisecond))
+	assert.Equal(t, 1, outbox.Pending())
+}
+
+// flakyPost fails with err until it has been called failures times
+type flakyPost struct {
+	mu       sync.Mutex
+	failures int
+	err      error
+	calls    int
+	posted   chan string
+	text     string
+}
+
+func (f *flakyPost) post() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.calls <= f.failures {
+		return f.err
+	}
+	f.posted <- f.text
+	return nil
+}
+
+func newRetryingOutbox(retryFor time.Duration, m *metrics.Metrics) *Outbox {
+	outbox := NewOutbox(10, zap.NewNop())
+	outbox.SetRetry(retryFor, 10)
+	outbox.SetMetrics(m)
+	outbox.retryDelay = time.Millisecond
+	outbox.maxRetryDelay = 5 * time.Millisecond
+	return outbox
+}
+
+func TestOutbox_RetriesWhileSlackIsUnavailable(t *testing.T) {
+	m := metrics.NewMetrics()
+	outbox := newRetryingOutbox(time.Minute, m)
+	reply := &flakyPost{failures: 2, err: slack.StatusCodeError{Code: 503, Status: "503 Service Unavailable"}, posted: make(chan string, 1), text: "Xin chào"}
+
+	outbox.PostWithRetry("C1:1.0", "C1:1.0", reply.post, func() { t.Error("the reply was given up") })
+
+	select {
+	case text := <-reply.posted:
+		assert.Equal(t, "Xin chào", text)
+	case <-time.After(time.Second):
+		t.Fatal("the buffered reply was not posted once Slack recovered")
+	}
+	require.NoError(t, outbox.Shutdown(time.Second))
+	assert.Equal(t, 3, reply.calls)
+	assert.Zero(t, outbox.Buffered())
+	assert.Equal(t, int64(2), m.ErrorsByType["slack_post_buffered"])
+	assert.Equal(t, int64(1), m.ErrorsByType["slack_post_recovered"])
+}
+
+func TestOutbox_GivesUpOnOtherErrors(t *testing.T) {
+	outbox := newRetryingOutbox(time.Minute, nil)
+	reply := &flakyPost{failures: 1, err: slack.SlackErrorResponse{Err: "channel_not_found"}}
+
+	gaveUp := make(chan struct{})
+	outbox.PostWithRetry("C1:1.0", "C1:1.0", reply.post, func() { close(gaveUp) })
+
+	select {
+	case <-gaveUp:
+	case <-time.After(time.Second):
+		t.Fatal("the reply was not given up")
+	}
+	require.NoError(t, outbox.Shutdown(time.Second))
+	assert.Equal(t, 1, reply.calls)
+}
+
+func TestOutbox_GivesUpAfterRetryPeriod(t *testing.T) {
+	m := metrics.NewMetrics()
+	outbox := newRetryingOutbox(time.Nanosecond, m)
+	reply := &flakyPost{failures: 10, err: &slack.RateLimitedError{RetryAfter: time.Second}}
+
+	gaveUp := make(chan struct{})
+	outbox.PostWithRetry("C1:1.0", "C1:1.0", reply.post, func() { close(gaveUp) })
+
+	select {
+	case <-gaveUp:
+	case <-time.After(time.Second):
+		t.Fatal("the reply was not given up")
+	}
+	require.NoError(t, outbox.Shutdown(time.Second))
+	assert.Equal(t, int64(1), m.ErrorsByType["slack_post_gave_up"])
+}
+
+func TestOutbox_CollapsesBufferedDuplicates(t *testing.T) {
+	outbox := newRetryingOutbox(time.Minute, nil)
+	// The buffered version would only be retried after the test
+	outbox.retryDelay = time.Hour
+	outbox.maxRetryDelay = time.Hour
+	posted := make(chan string, 2)
+	first := &flakyPost{failures: 1, err: slack.StatusCodeError{Code: 502, Status: "502 Bad Gateway"}, posted: posted, text: "first"}
+	second := &flakyPost{posted: posted, text: "second"}
+
+	outbox.PostWithRetry("C1:1.0", "C1:1.0", first.post, func() {})
+	require.Eventually(t, func() bool { return outbox.Buffered() == 1 }, time.Second, time.Millisecond)
+
+	outbox.PostWithRetry("C1:1.0", "C1:1.0", second.post, func() {})
+	assert.Equal(t, "second", <-posted)
+	assert.Zero(t, outbox.Buffered(), "the buffered version is replaced")
+	require.NoError(t, outbox.Shutdown(time.Second))
+	assert.Equal(t, 1, first.calls)
+}
+
+func TestSlackUnavailable(t *testing.T) {
+	assert.True(t, slackUnavailable(slack.StatusCodeError{Code: 500}))
+	assert.True(t, slackUnavailable(&slack.RateLimitedError{RetryAfter: time.Second}))
+	assert.True(t, slackUnavailable(slack.SlackErrorResponse{Err: "service_unavailable"}))
+	assert.True(t, slackUnavailable(&net.OpError{Op: "dial", Err: errors.New("connection refused")}))
+	assert.True(t, slackUnavailable(fmt.Errorf("post: %w", context.DeadlineExceeded)))
+
+	assert.False(t, slackUnavailable(slack.StatusCodeError{Code: 404}))
+	assert.False(t, slackUnavailable(slack.SlackErrorResponse{Err: "not_in_channel"}))
+	assert.False(t, slackUnavailable(errors.New("slack client is not initialized")))
+}
@@ -0,0 +1,50 @@
+package slack
+
+import (
+	"context"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+)
+
+var _ service.ChatPlatform = (*SlackPlatform)(nil)
+
+// SlackPlatform adapts SlackClient to the platform-neutral service.ChatPlatform
+type SlackPlatform struct {
+	client *SlackClient
+}
+
+func NewSlackPlatform(client *SlackClient) *SlackPlatform {
+	return &SlackPlatform{client: client}
+}
+
+func (sp *SlackPlatform) PostReply(_ context.Context, reply model.ChatReply) error {
+	var err error
+	if reply.AsQuote {
+		_, _, err = sp.client.PostMessageWithBotInfoAsQuote(reply.ChannelID, reply.Text, reply.ThreadID, reply.Username, reply.AvatarURL)
+	} else {
+		_, _, err = sp.client.PostMessageWithBotInfo(reply.ChannelID, reply.Text, reply.ThreadID, reply.Username, reply.AvatarURL)
+	}
+	return err
+}
+
+func (sp *SlackPlatform) AddReaction(_ context.Context, channelID, messageID, emoji string) error {
+	return sp.client.AddReaction(emoji, channelID, messageID)
+}
+
+func (sp *SlackPlatform) GetUser(_ context.Context, _ string, userID string) (*model.ChatUser, error) {
+	user, err := sp.client.GetUserInfo(userID)
+	if err != nil {
+		return nil, err
+	}
+	avatarURL := user.Profile.Image512
+	if avatarURL == "" {
+		avatarURL = user.Profile.Image48
+	}
+	return &model.ChatUser{
+		ID:          user.ID,
+		Name:        user.Name,
+		DisplayName: user.Profile.DisplayName,
+		AvatarURL:   avatarURL,
+	}, nil
+}
@@ -0,0 +1,116 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/slack-go/slack"
+)
+
+// PostRecorder keeps what the bot posts, e.g. service.ComplianceUseCase
+type PostRecorder interface {
+	RecordPost(ctx context.Context, post *model.BotPost)
+}
+
+// recordedMethods are the Web API methods whose messages are recorded
+var recordedMethods = map[string]string{
+	"chat.postMessage":   model.BotPostActionPost,
+	"chat.update":        model.BotPostActionUpdate,
+	"chat.postEphemeral": model.BotPostActionEphemeral,
+	"chat.delete":        model.BotPostActionDelete,
+}
+
+// SetPostRecorder records every message the client posts, edits or deletes
+// through the Web API, once Slack accepted it
+func (sc *SlackClient) SetPostRecorder(recorder PostRecorder) {
+	sc.setPostRecorder(recorder, "")
+}
+
+// setPostRecorder rebuilds the client on a transport that records posts as
+// teamID's when the request's context carries no team
+func (sc *SlackClient) setPostRecorder(recorder PostRecorder, teamID string) {
+	sc.recorder = recorder
+	sc.client = slack.New(sc.token, slack.OptionHTTPClient(&http.Client{
+		Transport: &recordingTransport{base: http.DefaultTransport, recorder: recorder, teamID: teamID},
+	}))
+}
+
+// recordingTransport passes requests through and records the chat messages
+// the Web API accepted
+type recordingTransport struct {
+	base     http.RoundTripper
+	recorder PostRecorder
+	teamID   string
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	action, ok := recordedMethods[path.Base(req.URL.Path)]
+	if !ok || req.Body == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	forwarded := req.Clone(req.Context())
+	forwarded.Body = io.NopCloser(bytes.NewReader(body))
+
+	resp, err := t.base.RoundTrip(forwarded)
+	if err != nil {
+		return resp, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	if err != nil {
+		return resp, nil
+	}
+
+	var result struct {
+		OK        bool   `json:"ok"`
+		Channel   string `json:"channel"`
+		TS        string `json:"ts"`
+		MessageTS string `json:"message_ts"`
+	}
+	if json.Unmarshal(respBody, &result) != nil || !result.OK {
+		return resp, nil
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return resp, nil
+	}
+
+	post := &model.BotPost{
+		TeamID:      teamFrom(req.Context()),
+		ChannelID:   result.Channel,
+		MessageTS:   result.TS,
+		ThreadTS:    form.Get("thread_ts"),
+		Action:      action,
+		UserID:      form.Get("user"),
+		Text:        form.Get("text"),
+		Blocks:      form.Get("blocks"),
+		Attachments: form.Get("attachments"),
+		PostedAt:    time.Now(),
+	}
+	if post.TeamID == "" {
+		post.TeamID = t.teamID
+	}
+	if post.ChannelID == "" {
+		post.ChannelID = form.Get("channel")
+	}
+	if post.MessageTS == "" {
+		// Ephemeral messages only have a message_ts
+		post.MessageTS = result.MessageTS
+	}
+	t.recorder.RecordPost(req.Context(), post)
+	return resp, nil
+}
@@ -0,0 +1,70 @@
+package slack
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePostRecorder []*model.BotPost
+
+func (f *fakePostRecorder) RecordPost(_ context.Context, post *model.BotPost) {
+	*f = append(*f, post)
+}
+
+func TestRecordingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/chat.postMessage":
+			_, _ = io.WriteString(w, `{"ok":true,"channel":"C1","ts":"1.2"}`)
+		case "/chat.postEphemeral":
+			_, _ = io.WriteString(w, `{"ok":true,"message_ts":"1.3"}`)
+		case "/chat.delete":
+			_, _ = io.WriteString(w, `{"ok":false,"error":"message_not_found"}`)
+		default:
+			_, _ = io.WriteString(w, `{"ok":true}`)
+		}
+	}))
+	defer server.Close()
+
+	recorder := &fakePostRecorder{}
+	client := slack.New("xoxb-test",
+		slack.OptionAPIURL(server.URL+"/"),
+		slack.OptionHTTPClient(&http.Client{Transport: &recordingTransport{base: http.DefaultTransport, recorder: recorder, teamID: "T1"}}))
+	ctx := withTeam(context.Background(), "T2")
+
+	_, ts, err := client.PostMessageContext(ctx, "C1", slack.MsgOptionText("Xin chào", false), slack.MsgOptionTS("1.1"))
+	require.NoError(t, err)
+	assert.Equal(t, "1.2", ts, "responses reach the caller")
+	_, err = client.PostEphemeral("C1", "U1", slack.MsgOptionText("Only you can see this", false))
+	require.NoError(t, err)
+	_, _, err = client.DeleteMessage("C1", "9.9")
+	assert.Error(t, err)
+	require.NoError(t, client.AddReaction("white_check_mark", slack.NewRefToMessage("C1", "1.1")))
+
+	require.Len(t, *recorder, 2, "only accepted chat messages are recorded")
+	post := (*recorder)[0]
+	assert.Equal(t, model.BotPost{
+		TeamID:    "T2",
+		ChannelID: "C1",
+		MessageTS: "1.2",
+		ThreadTS:  "1.1",
+		Action:    model.BotPostActionPost,
+		Text:      "Xin chào",
+		PostedAt:  post.PostedAt,
+	}, *post)
+	ephemeral := (*recorder)[1]
+	assert.Equal(t, "T1", ephemeral.TeamID, "the client's team without one in the context")
+	assert.Equal(t, "C1", ephemeral.ChannelID)
+	assert.Equal(t, "1.3", ephemeral.MessageTS)
+	assert.Equal(t, "U1", ephemeral.UserID)
+	assert.Equal(t, model.BotPostActionEphemeral, ephemeral.Action)
+}
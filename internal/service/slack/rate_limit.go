@@ -0,0 +1,73 @@
+package slack
+
+import (
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"go.uber.org/zap"
+)
+
+// withinRateLimit reports whether a message fits its user's and channel's
+// rate limits, counting it when it does. The first message over a limit gets
+// a "slow down" reply in its thread; later ones are dropped quietly until the
+// window resets. Limiter errors let the message through.
+func (ep *eventProcessorImpl) withinRateLimit(slackClient *SlackClient, channelID, userID, ts string) bool {
+	allowed, _, resetTime, err := ep.rateLimiter.CheckUserLimit(userID)
+	if err != nil {
+		ep.logger.Warn("Failed to check user rate limit", zap.Error(err), zap.String("user_id", userID))
+		allowed = true
+	}
+	scope := "user:" + userID
+	if allowed {
+		allowed, _, resetTime, err = ep.rateLimiter.CheckChannelLimit(channelID)
+		if err != nil {
+			ep.logger.Warn("Failed to check channel rate limit", zap.Error(err), zap.String("channel_id", channelID))
+			allowed = true
+		}
+		scope = "channel:" + channelID
+	}
+
+	if !allowed {
+		ep.logger.Info("Rate limit exceeded, skipping translation",
+			zap.String("channel_id", channelID),
+			zap.String("user_id", userID),
+			zap.String("limit", scope))
+		if ep.metrics != nil {
+			ep.metrics.RecordError("rate_limited")
+		}
+		if ep.claimRateLimitNotice(scope, resetTime) {
+			if _, _, err := slackClient.PostMessage(channelID, service.MessageRateLimited, ts); err != nil {
+				ep.logger.Error("Failed to post rate limit message",
+					zap.Error(err),
+					zap.String("channel_id", channelID))
+			}
+		}
+		return false
+	}
+
+	if err := ep.rateLimiter.IncrementUserLimit(userID); err != nil {
+		ep.logger.Warn("Failed to count message for user rate limit", zap.Error(err), zap.String("user_id", userID))
+	}
+	if err := ep.rateLimiter.IncrementChannelLimit(channelID); err != nil {
+		ep.logger.Warn("Failed to count message for channel rate limit", zap.Error(err), zap.String("channel_id", channelID))
+	}
+	return true
+}
+
+// claimRateLimitNotice reports whether the "slow down" reply for scope is
+// due, i.e. none was posted in the window ending at resetTime
+func (ep *eventProcessorImpl) claimRateLimitNotice(scope string, resetTime int64) bool {
+	ep.rateNoticeMu.Lock()
+	defer ep.rateNoticeMu.Unlock()
+
+	if until, ok := ep.rateNotices[scope]; ok && time.Now().Unix() < until {
+		return false
+	}
+	for key, until := range ep.rateNotices {
+		if time.Now().Unix() >= until {
+			delete(ep.rateNotices, key)
+		}
+	}
+	ep.rateNotices[scope] = resetTime
+	return true
+}
@@ -0,0 +1,77 @@
+package slack
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// fakeRateLimiter allows limit messages per user and per channel
+type fakeRateLimiter struct {
+	limit    int
+	users    map[string]int
+	channels map[string]int
+	err      error
+}
+
+func newFakeRateLimiter(limit int) *fakeRateLimiter {
+	return &fakeRateLimiter{limit: limit, users: map[string]int{}, channels: map[string]int{}}
+}
+
+func (f *fakeRateLimiter) CheckUserLimit(userID string) (bool, int, int64, error) {
+	return f.users[userID] < f.limit, f.limit - f.users[userID], time.Now().Add(time.Minute).Unix(), f.err
+}
+
+func (f *fakeRateLimiter) CheckChannelLimit(channelID string) (bool, int, int64, error) {
+	return f.channels[channelID] < f.limit, f.limit - f.channels[channelID], time.Now().Add(time.Minute).Unix(), f.err
+}
+
+func (f *fakeRateLimiter) IncrementUserLimit(userID string) error {
+	f.users[userID]++
+	return f.err
+}
+
+func (f *fakeRateLimiter) IncrementChannelLimit(channelID string) error {
+	f.channels[channelID]++
+	return f.err
+}
+
+func TestEventProcessorWithinRateLimit(t *testing.T) {
+	limiter := newFakeRateLimiter(2)
+	m := metrics.NewMetrics()
+	processor := NewEventProcessor(nil, &SlackClient{}, zap.NewNop(), WithRateLimiter(limiter), WithMetrics(m)).(*eventProcessorImpl)
+
+	assert.True(t, processor.withinRateLimit(processor.slackClient, "C1", "U1", "1.1"))
+	assert.True(t, processor.withinRateLimit(processor.slackClient, "C1", "U1", "1.2"))
+	assert.False(t, processor.withinRateLimit(processor.slackClient, "C1", "U1", "1.3"), "user over the limit")
+	assert.False(t, processor.withinRateLimit(processor.slackClient, "C1", "U2", "1.4"), "channel over the limit")
+	assert.True(t, processor.withinRateLimit(processor.slackClient, "C2", "U2", "1.5"))
+
+	assert.Equal(t, 2, limiter.users["U1"], "rejected messages are not counted")
+	assert.Equal(t, int64(2), m.ErrorsByType["rate_limited"])
+}
+
+func TestEventProcessorWithinRateLimit_LimiterError(t *testing.T) {
+	limiter := newFakeRateLimiter(0)
+	limiter.err = errors.New("redis down")
+	processor := NewEventProcessor(nil, &SlackClient{}, zap.NewNop(), WithRateLimiter(limiter)).(*eventProcessorImpl)
+
+	assert.True(t, processor.withinRateLimit(processor.slackClient, "C1", "U1", "1.1"), "limiter errors let messages through")
+}
+
+func TestEventProcessorClaimRateLimitNotice(t *testing.T) {
+	processor := NewEventProcessor(nil, nil, zap.NewNop(), WithRateLimiter(newFakeRateLimiter(1))).(*eventProcessorImpl)
+	reset := time.Now().Add(time.Minute).Unix()
+
+	assert.True(t, processor.claimRateLimitNotice("user:U1", reset))
+	assert.False(t, processor.claimRateLimitNotice("user:U1", reset), "one notice per window")
+	assert.True(t, processor.claimRateLimitNotice("channel:C1", reset))
+
+	// The window has reset
+	processor.rateNotices["user:U1"] = time.Now().Add(-time.Second).Unix()
+	assert.True(t, processor.claimRateLimitNotice("user:U1", reset))
+}
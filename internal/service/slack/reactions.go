@@ -0,0 +1,55 @@
+package slack
+
+import "go.uber.org/zap"
+
+// Reactions on a message showing how far its translation got
+const (
+	reactionReceived   = "eyes"
+	reactionTranslated = "white_check_mark"
+	reactionFailed     = "warning"
+)
+
+// reactionClient adds and removes the bot's reactions, e.g. SlackClient
+type reactionClient interface {
+	AddReaction(emoji, channelID, timestamp string) error
+	RemoveReaction(emoji, channelID, timestamp string) error
+}
+
+// addReaction reacts to a message. Failures are only logged: reactions are
+// informational.
+func (ep *eventProcessorImpl) addReaction(client reactionClient, channelID, ts, emoji string) {
+	if err := client.AddReaction(emoji, channelID, ts); err != nil {
+		ep.logger.Warn("Failed to add emoji reaction to message",
+			zap.Error(err),
+			zap.String("channel_id", channelID),
+			zap.String("timestamp", ts),
+			zap.String("emoji", emoji),
+			zap.String("troubleshooting", "Check if bot has reactions:write scope in Slack app OAuth settings"))
+	}
+}
+
+// finishReactions replaces 👀 with outcome, reactionTranslated or
+// reactionFailed, once a message is done; without an outcome 👀 stays, for
+// messages left untranslated on purpose. A message processed again, e.g.
+// after a failed attempt, loses the reaction of the other outcome.
+func (ep *eventProcessorImpl) finishReactions(client reactionClient, channelID, ts, outcome string) {
+	if outcome == "" {
+		return
+	}
+	stale := reactionFailed
+	if outcome == reactionFailed {
+		stale = reactionTranslated
+	}
+
+	// Add before removing so the message always shows where it is
+	ep.addReaction(client, channelID, ts, outcome)
+	for _, emoji := range []string{reactionReceived, stale} {
+		if err := client.RemoveReaction(emoji, channelID, ts); err != nil {
+			ep.logger.Warn("Failed to remove emoji reaction from message",
+				zap.Error(err),
+				zap.String("channel_id", channelID),
+				zap.String("timestamp", ts),
+				zap.String("emoji", emoji))
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package slack
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// fakeReactions keeps the bot's reactions on a single message
+type fakeReactions struct {
+	emojis    map[string]bool
+	calls     []string
+	removeErr error
+}
+
+func newFakeReactions(emojis ...string) *fakeReactions {
+	f := &fakeReactions{emojis: map[string]bool{}}
+	for _, emoji := range emojis {
+		f.emojis[emoji] = true
+	}
+	return f
+}
+
+func (f *fakeReactions) AddReaction(emoji, _, _ string) error {
+	f.calls = append(f.calls, "+"+emoji)
+	f.emojis[emoji] = true
+	return nil
+}
+
+func (f *fakeReactions) RemoveReaction(emoji, _, _ string) error {
+	f.calls = append(f.calls, "-"+emoji)
+	if f.removeErr != nil {
+		return f.removeErr
+	}
+	delete(f.emojis, emoji)
+	return nil
+}
+
+func TestFinishReactions(t *testing.T) {
+	processor := NewEventProcessor(nil, nil, zap.NewNop()).(*eventProcessorImpl)
+
+	tests := []struct {
+		name     string
+		existing []string
+		outcome  string
+		expected map[string]bool
+	}{
+		{
+			name:     "translated",
+			existing: []string{reactionReceived},
+			outcome:  reactionTranslated,
+			expected: map[string]bool{reactionTranslated: true},
+		},
+		{
+			name:     "failed",
+			existing: []string{reactionReceived},
+			outcome:  reactionFailed,
+			expected: map[string]bool{reactionFailed: true},
+		},
+		{
+			name:     "retry succeeds after a failed attempt",
+			existing: []string{reactionReceived, reactionFailed},
+			outcome:  reactionTranslated,
+			expected: map[string]bool{reactionTranslated: true},
+		},
+		{
+			name:     "skipped message keeps eyes",
+			existing: []string{reactionReceived},
+			expected: map[string]bool{reactionReceived: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reactions := newFakeReactions(tt.existing...)
+			processor.finishReactions(reactions, "C1", "1234.5678", tt.outcome)
+			assert.Equal(t, tt.expected, reactions.emojis)
+		})
+	}
+}
+
+func TestFinishReactions_AddsBeforeRemoving(t *testing.T) {
+	processor := NewEventProcessor(nil, nil, zap.NewNop()).(*eventProcessorImpl)
+	reactions := newFakeReactions(reactionReceived)
+	reactions.removeErr = errors.New("ratelimited")
+
+	processor.finishReactions(reactions, "C1", "1234.5678", reactionTranslated)
+
+	// Failed removals are logged; the outcome is shown anyway
+	assert.Equal(t, []string{"+white_check_mark", "-eyes", "-warning"}, reactions.calls)
+	assert.True(t, reactions.emojis[reactionTranslated])
+}
@@ -0,0 +1,109 @@
+package slack
+
+import (
+	"fmt"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/slack-go/slack"
+)
+
+var _ service.ReviewMessenger = (*SlackClient)(nil)
+
+// PostReviewRequest posts the draft to its review channel with Approve/Reject buttons
+func (sc *SlackClient) PostReviewRequest(draft *model.PendingTranslation) (string, error) {
+	if sc.client == nil {
+		return "", fmt.Errorf("slack client is not initialized")
+	}
+
+	approve := slack.NewButtonBlockElement(model.ReviewActionApprove, draft.ID,
+		slack.NewTextBlockObject("plain_text", "Approve", false, false))
+	approve.Style = slack.StylePrimary
+	edit := slack.NewButtonBlockElement(model.ReviewActionEdit, draft.ID,
+		slack.NewTextBlockObject("plain_text", "Edit & approve", false, false))
+	reject := slack.NewButtonBlockElement(model.ReviewActionReject, draft.ID,
+		slack.NewTextBlockObject("plain_text", "Reject", false, false))
+	reject.Style = slack.StyleDanger
+
+	blocks := append(reviewSummaryBlocks(draft), slack.NewActionBlock("translation_review", approve, edit, reject))
+
+	_, ts, err := sc.client.PostMessage(draft.ReviewChannelID,
+		slack.MsgOptionText(reviewFallbackText(draft), false),
+		slack.MsgOptionBlocks(blocks...),
+	)
+	return ts, err
+}
+
+// UpdateReviewRequest replaces the review buttons with the reviewer's decision
+func (sc *SlackClient) UpdateReviewRequest(draft *model.PendingTranslation) error {
+	if sc.client == nil {
+		return fmt.Errorf("slack client is not initialized")
+	}
+
+	decision := "✅ Approved"
+	if draft.Status == model.ReviewStatusRejected {
+		decision = "🚫 Rejected"
+	}
+	blocks := append(reviewSummaryBlocks(draft), slack.NewContextBlock("translation_review_decision",
+		slack.NewTextBlockObject("mrkdwn", fmt.Sprintf("%s by <@%s>", decision, draft.ReviewerID), false, false)))
+
+	_, _, _, err := sc.client.UpdateMessage(draft.ReviewChannelID, draft.ReviewMessageTS,
+		slack.MsgOptionText(reviewFallbackText(draft), false),
+		slack.MsgOptionBlocks(blocks...),
+	)
+	return err
+}
+
+// PostApprovedTranslation posts the approved translation to the original thread
+func (sc *SlackClient) PostApprovedTranslation(draft *model.PendingTranslation) error {
+	var err error
+	if draft.AsQuote {
+		_, _, err = sc.PostMessageWithBotInfoAsQuote(draft.ChannelID, draft.TranslatedText, draft.ThreadTS, draft.BotName, draft.BotAvatar)
+	} else {
+		_, _, err = sc.PostMessageWithBotInfo(draft.ChannelID, draft.TranslatedText, draft.ThreadTS, draft.BotName, draft.BotAvatar)
+	}
+	return err
+}
+
+// OpenCorrectionModal opens a modal with the translation in an editable text
+// field. Submitting it approves the edited text.
+func (sc *SlackClient) OpenCorrectionModal(triggerID string, draft *model.PendingTranslation) error {
+	if sc.client == nil {
+		return fmt.Errorf("slack client is not initialized")
+	}
+
+	input := slack.NewPlainTextInputBlockElement(nil, model.ReviewCorrectionActionID)
+	input.Multiline = true
+	input.InitialValue = draft.TranslatedText
+
+	modal := slack.ModalViewRequest{
+		Type:            slack.VTModal,
+		CallbackID:      model.ReviewCorrectionCallbackID,
+		PrivateMetadata: draft.ID,
+		Title:           slack.NewTextBlockObject("plain_text", "Edit translation", false, false),
+		Submit:          slack.NewTextBlockObject("plain_text", "Approve", false, false),
+		Close:           slack.NewTextBlockObject("plain_text", "Cancel", false, false),
+		Blocks: slack.Blocks{BlockSet: []slack.Block{
+			slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", "*Original*\n"+draft.OriginalText, false, false), nil, nil),
+			slack.NewInputBlock(model.ReviewCorrectionBlockID,
+				slack.NewTextBlockObject("plain_text", "Translation", false, false), nil, input),
+		}},
+	}
+
+	_, err := sc.client.OpenView(triggerID, modal)
+	return err
+}
+
+// reviewSummaryBlocks shows where the message came from, the original and the translation
+func reviewSummaryBlocks(draft *model.PendingTranslation) []slack.Block {
+	return []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn",
+			fmt.Sprintf("Translation awaiting review for a message from <@%s> in <#%s>", draft.UserID, draft.ChannelID), false, false), nil, nil),
+		slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", "*Original*\n"+draft.OriginalText, false, false), nil, nil),
+		slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", "*Translation*\n"+draft.TranslatedText, false, false), nil, nil),
+	}
+}
+
+func reviewFallbackText(draft *model.PendingTranslation) string {
+	return fmt.Sprintf("Translation awaiting review: %s", draft.TranslatedText)
+}
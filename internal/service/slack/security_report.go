@@ -0,0 +1,163 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+// reportTopN is how many patterns and channels a security summary lists
+const reportTopN = 5
+
+// threatLevelOrder lists threat levels from most to least severe
+var threatLevelOrder = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "NONE"}
+
+// MessagePoster posts a message to a Slack channel
+type MessagePoster interface {
+	PostMessage(channelID, text string, threadTS string) (string, string, error)
+}
+
+// SecurityReporter periodically posts a summary of input security activity
+// (threat levels, blocked messages, top patterns and channels) to an ops channel
+type SecurityReporter struct {
+	metrics   *metrics.Metrics
+	poster    MessagePoster
+	channelID string
+	logger    *zap.Logger
+
+	last metrics.SecurityCounters
+}
+
+func NewSecurityReporter(m *metrics.Metrics, poster MessagePoster, channelID string, logger *zap.Logger) *SecurityReporter {
+	return &SecurityReporter{
+		metrics:   m,
+		poster:    poster,
+		channelID: channelID,
+		logger:    logger,
+		last:      m.SecurityCounters(),
+	}
+}
+
+// Run posts a summary every interval until ctx is cancelled
+func (r *SecurityReporter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.Report(interval); err != nil {
+				r.logger.Error("Failed to post security summary",
+					zap.String("channel_id", r.channelID),
+					zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Report posts the activity since the previous report
+func (r *SecurityReporter) Report(period time.Duration) error {
+	current := r.metrics.SecurityCounters()
+	text := formatSecuritySummary(period, diffCounters(current, r.last))
+
+	if _, _, err := r.poster.PostMessage(r.channelID, text, ""); err != nil {
+		return fmt.Errorf("failed to post security summary: %w", err)
+	}
+	r.last = current
+	return nil
+}
+
+func diffCounters(current, previous metrics.SecurityCounters) metrics.SecurityCounters {
+	return metrics.SecurityCounters{
+		ThreatLevels: diffCounts(current.ThreatLevels, previous.ThreatLevels),
+		Patterns:     diffCounts(current.Patterns, previous.Patterns),
+		Channels:     diffCounts(current.Channels, previous.Channels),
+		Verdicts:     diffCounts(current.Verdicts, previous.Verdicts),
+	}
+}
+
+func diffCounts(current, previous map[string]int64) map[string]int64 {
+	diff := make(map[string]int64)
+	for k, v := range current {
+		if d := v - previous[k]; d > 0 {
+			diff[k] = d
+		}
+	}
+	return diff
+}
+
+func formatSecuritySummary(period time.Duration, c metrics.SecurityCounters) string {
+	var total int64
+	for _, count := range c.ThreatLevels {
+		total += count
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "🛡️ *Security summary (last %s)*\n", shortDuration(period))
+	fmt.Fprintf(&b, "Messages checked: %d\n", total)
+
+	levels := []string{}
+	for _, level := range threatLevelOrder {
+		if count := c.ThreatLevels[level]; count > 0 && level != "NONE" {
+			levels = append(levels, fmt.Sprintf("%s %d", level, count))
+		}
+	}
+	if len(levels) == 0 {
+		b.WriteString("No suspicious input detected.")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "By threat level: %s\n", strings.Join(levels, ", "))
+	fmt.Fprintf(&b, "Blocked: %d, would block in shadow mode: %d\n",
+		c.Verdicts["blocked"], c.Verdicts["shadow_blocked"])
+
+	if patterns := topCounts(c.Patterns, reportTopN, func(k string) string { return "`" + k + "`" }); patterns != "" {
+		fmt.Fprintf(&b, "Top patterns: %s\n", patterns)
+	}
+	if channels := topCounts(c.Channels, reportTopN, func(k string) string { return "<#" + k + ">" }); channels != "" {
+		fmt.Fprintf(&b, "Top channels: %s\n", channels)
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// topCounts formats the n largest counts, highest first
+func topCounts(counts map[string]int64, n int, label func(string) string) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s (%d)", label(k), counts[k])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// shortDuration formats d without trailing zero units, e.g. "24h" instead of "24h0m0s"
+func shortDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = s[:len(s)-2]
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = s[:len(s)-2]
+	}
+	return s
+}
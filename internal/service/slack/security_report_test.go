@@ -0,0 +1,65 @@
+package slack
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type fakePoster struct {
+	channelID string
+	messages  []string
+	err       error
+}
+
+func (f *fakePoster) PostMessage(channelID, text string, threadTS string) (string, string, error) {
+	if f.err != nil {
+		return "", "", f.err
+	}
+	f.channelID = channelID
+	f.messages = append(f.messages, text)
+	return channelID, "1700000000.000100", nil
+}
+
+func TestSecurityReporter_ReportsActivitySinceLastReport(t *testing.T) {
+	m := metrics.NewMetrics()
+	m.RecordInputThreat("C1", "NONE", nil, false)
+	poster := &fakePoster{}
+	reporter := NewSecurityReporter(m, poster, "COPS", zap.NewNop())
+
+	m.RecordInputThreat("C2", "CRITICAL", []string{"instruction_override"}, true)
+	m.RecordInjectionVerdict("blocked")
+	m.RecordInputThreat("C3", "MEDIUM", []string{"chat_role"}, true)
+	m.RecordInputThreat("C3", "NONE", nil, false)
+
+	assert.NoError(t, reporter.Report(24*time.Hour))
+	assert.Equal(t, "COPS", poster.channelID)
+	summary := poster.messages[0]
+	assert.Contains(t, summary, "last 24h")
+	assert.Contains(t, summary, "Messages checked: 3")
+	assert.Contains(t, summary, "CRITICAL 1, MEDIUM 1")
+	assert.Contains(t, summary, "Blocked: 1")
+	assert.Contains(t, summary, "`instruction_override` (1)")
+	assert.Contains(t, summary, "<#C2> (1)")
+	assert.NotContains(t, summary, "<#C1>")
+
+	assert.NoError(t, reporter.Report(24*time.Hour))
+	assert.Contains(t, poster.messages[1], "No suspicious input detected.")
+}
+
+func TestSecurityReporter_RetriesPeriodAfterPostFailure(t *testing.T) {
+	m := metrics.NewMetrics()
+	poster := &fakePoster{err: errors.New("channel_not_found")}
+	reporter := NewSecurityReporter(m, poster, "COPS", zap.NewNop())
+
+	m.RecordInputThreat("C1", "HIGH", []string{"respond_with"}, true)
+	assert.Error(t, reporter.Report(time.Hour))
+
+	poster.err = nil
+	assert.NoError(t, reporter.Report(time.Hour))
+	assert.Contains(t, poster.messages[0], "HIGH 1")
+}
@@ -0,0 +1,229 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// maxThreadReplies caps how many messages of a thread are read
+const maxThreadReplies = 1000
+
+var _ service.ThreadReader = (*ThreadReader)(nil)
+
+// GetThreadReplies returns the messages of the thread of threadTS, oldest
+// first and starting with its parent, reading at most maxThreadReplies
+func (sc *SlackClient) GetThreadReplies(channelID, threadTS string) ([]slack.Message, error) {
+	if sc.client == nil {
+		return nil, fmt.Errorf("slack client is not initialized")
+	}
+
+	var messages []slack.Message
+	params := &slack.GetConversationRepliesParameters{
+		ChannelID: channelID,
+		Timestamp: threadTS,
+		Limit:     200,
+	}
+	for {
+		page, hasMore, cursor, err := sc.client.GetConversationReplies(params)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, page...)
+		if !hasMore || cursor == "" || len(messages) >= maxThreadReplies {
+			break
+		}
+		params.Cursor = cursor
+	}
+	if len(messages) > maxThreadReplies {
+		messages = messages[:maxThreadReplies]
+	}
+	return messages, nil
+}
+
+// threadFetcher returns the messages of a thread, e.g. SlackClient
+type threadFetcher interface {
+	GetThreadReplies(channelID, threadTS string) ([]slack.Message, error)
+}
+
+// ThreadReader reads the messages people posted in a Slack thread, leaving
+// out the bot's translations and other bots
+type ThreadReader struct {
+	slackClient *SlackClient
+	clients     *ClientPool
+	// fetcher overrides the team's client in tests
+	fetcher threadFetcher
+}
+
+func NewThreadReader(slackClient *SlackClient) *ThreadReader {
+	return &ThreadReader{slackClient: slackClient}
+}
+
+// SetWorkspaceClients reads threads with the client of the workspace carried by the context
+func (tr *ThreadReader) SetWorkspaceClients(clients *ClientPool) {
+	tr.clients = clients
+}
+
+// ThreadMessages implements service.ThreadReader
+func (tr *ThreadReader) ThreadMessages(ctx context.Context, channelID, threadTS string) ([]model.Message, error) {
+	var client threadFetcher = tr.slackClient
+	if tr.fetcher != nil {
+		client = tr.fetcher
+	} else if tr.clients != nil {
+		client = tr.clients.ForTeam(teamFrom(ctx))
+	}
+
+	replies, err := client.GetThreadReplies(channelID, threadTS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch thread replies: %w", err)
+	}
+
+	messages := make([]model.Message, 0, len(replies))
+	for _, reply := range replies {
+		if reply.BotID != "" || reply.User == "" {
+			continue
+		}
+		switch reply.SubType {
+		case "", "file_share", "thread_broadcast":
+		default:
+			continue
+		}
+		messages = append(messages, model.Message{
+			UserID:    reply.User,
+			ChannelID: channelID,
+			Text:      reply.Text,
+			Timestamp: reply.Timestamp,
+			ThreadTs:  reply.ThreadTimestamp,
+		})
+	}
+	return messages, nil
+}
+
+// ThreadSummaryPoster posts the summary of a thread as a reply in the thread
+type ThreadSummaryPoster struct {
+	summaries   service.SummarizationService
+	slackClient *SlackClient
+	clients     *ClientPool
+	logger      *zap.Logger
+}
+
+func NewThreadSummaryPoster(summaries service.SummarizationService, slackClient *SlackClient, logger *zap.Logger) *ThreadSummaryPoster {
+	return &ThreadSummaryPoster{
+		summaries:   summaries,
+		slackClient: slackClient,
+		logger:      logger,
+	}
+}
+
+// SetWorkspaceClients posts with the client of the workspace carried by the context
+func (p *ThreadSummaryPoster) SetWorkspaceClients(clients *ClientPool) {
+	p.clients = clients
+}
+
+// Post summarizes the thread of threadTS and posts the summary in it. When
+// the thread cannot be summarized, the reason is posted instead and returned.
+func (p *ThreadSummaryPoster) Post(ctx context.Context, channelID, threadTS string) error {
+	slackClient := p.slackClient
+	if p.clients != nil {
+		slackClient = p.clients.ForTeam(teamFrom(ctx))
+	}
+
+	text := ""
+	summary, err := p.summaries.SummarizeThread(ctx, channelID, threadTS)
+	if err != nil {
+		p.logger.Error("Failed to summarize thread",
+			zap.Error(err),
+			zap.String("channel_id", channelID),
+			zap.String("thread_ts", threadTS))
+		text = directMessageError(err)
+	} else {
+		text = formatThreadSummary(summary)
+	}
+
+	if _, _, postErr := slackClient.PostMessage(channelID, text, threadTS); postErr != nil {
+		p.logger.Error("Failed to post thread summary",
+			zap.Error(postErr),
+			zap.String("channel_id", channelID),
+			zap.String("thread_ts", threadTS))
+		if err == nil {
+			err = postErr
+		}
+	}
+	return err
+}
+
+// formatThreadSummary renders a summary with one section per language. The
+// mentions it keeps are quoted so posting it does not notify anyone.
+func formatThreadSummary(summary response.ThreadSummary) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "🧾 *Thread summary* (%d messages)", summary.Messages)
+	for _, section := range summary.Summaries {
+		fmt.Fprintf(&b, "\n\n%s *%s*\n%s", languageFlag(section.Language), section.Language, quoteMentions(section.Text))
+	}
+	return b.String()
+}
+
+// WithThreadSummaries answers mentions of the bot asking for a summary, e.g.
+// "@bot summarize", by posting the summary of the thread they were sent in
+func WithThreadSummaries(poster *ThreadSummaryPoster) EventProcessorOption {
+	return func(ep *eventProcessorImpl) {
+		ep.summaries = poster
+		ep.handlers["app_mention"] = EventHandlerFunc(ep.handleAppMention)
+	}
+}
+
+// summaryRequestPattern matches a mention followed by a request for a summary
+var summaryRequestPattern = regexp.MustCompile(`(?i)^\s*<@[^>]+>\s*(summari[sz]e|summary|tl;?dr)\b`)
+
+// isSummaryRequest reports whether a message mentioning the bot asks for a summary
+func isSummaryRequest(text string) bool {
+	return summaryRequestPattern.MatchString(text)
+}
+
+// handleAppMention summarizes the thread a mention asking for a summary was
+// sent in. Other mentions are left to the message handler.
+func (ep *eventProcessorImpl) handleAppMention(ctx context.Context, event map[string]interface{}) {
+	text, _ := event["text"].(string)
+	if !isSummaryRequest(text) {
+		return
+	}
+	channelID, _ := event["channel"].(string)
+	userID, _ := event["user"].(string)
+	ts, _ := event["ts"].(string)
+	if channelID == "" || ts == "" {
+		ep.logger.Error("Failed to get app mention channel or timestamp")
+		return
+	}
+
+	slackClient := ep.client(ctx)
+	threadTS, _ := event["thread_ts"].(string)
+	if threadTS == "" {
+		if _, _, err := slackClient.PostMessage(channelID, "💡 Mention me with `summarize` inside a thread to summarize it", ts); err != nil {
+			ep.logger.Error("Failed to post summary hint",
+				zap.Error(err),
+				zap.String("channel_id", channelID))
+		}
+		return
+	}
+	if ep.rateLimiter != nil && !ep.withinRateLimit(slackClient, channelID, userID, ts) {
+		return
+	}
+
+	ep.addReaction(slackClient, channelID, ts, reactionReceived)
+	outcome := reactionFailed
+	defer func() {
+		ep.finishReactions(slackClient, channelID, ts, outcome)
+	}()
+
+	if err := ep.summaries.Post(ctx, channelID, threadTS); err != nil {
+		return
+	}
+	outcome = reactionTranslated
+}
@@ -0,0 +1,58 @@
+package slack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeThreadFetcher []slack.Message
+
+func (f fakeThreadFetcher) GetThreadReplies(_, _ string) ([]slack.Message, error) {
+	return f, nil
+}
+
+func TestThreadReader_ThreadMessages(t *testing.T) {
+	reader := NewThreadReader(nil)
+	reader.fetcher = fakeThreadFetcher{
+		{Msg: slack.Msg{User: "U1", Text: "Can we ship on Friday?", Timestamp: "1.1"}},
+		{Msg: slack.Msg{User: "UBOT", BotID: "B1", Text: "Chúng ta có thể phát hành vào thứ Sáu không?", Timestamp: "1.2", ThreadTimestamp: "1.1"}},
+		{Msg: slack.Msg{User: "U2", SubType: "channel_join", Text: "<@U2> has joined the channel", Timestamp: "1.3", ThreadTimestamp: "1.1"}},
+		{Msg: slack.Msg{User: "U2", SubType: "thread_broadcast", Text: "Yes, once QA signs off", Timestamp: "1.4", ThreadTimestamp: "1.1"}},
+	}
+
+	messages, err := reader.ThreadMessages(context.Background(), "C1", "1.1")
+	require.NoError(t, err)
+	assert.Equal(t, []model.Message{
+		{UserID: "U1", ChannelID: "C1", Text: "Can we ship on Friday?", Timestamp: "1.1"},
+		{UserID: "U2", ChannelID: "C1", Text: "Yes, once QA signs off", Timestamp: "1.4", ThreadTs: "1.1"},
+	}, messages)
+}
+
+func TestFormatThreadSummary(t *testing.T) {
+	text := formatThreadSummary(response.ThreadSummary{
+		Messages: 12,
+		Summaries: []response.Summary{
+			{Language: "English", Text: "• <@U1> ships on Friday"},
+			{Language: "Vietnamese", Text: "• <@U1> phát hành vào thứ Sáu"},
+		},
+	})
+
+	assert.Equal(t, "🧾 *Thread summary* (12 messages)\n\n"+
+		"🇬🇧 *English*\n• `<@U1>` ships on Friday\n\n"+
+		"🇻🇳 *Vietnamese*\n• `<@U1>` phát hành vào thứ Sáu", text)
+}
+
+func TestIsSummaryRequest(t *testing.T) {
+	assert.True(t, isSummaryRequest("<@UBOT> summarize"))
+	assert.True(t, isSummaryRequest("<@UBOT> Summarise this please"))
+	assert.True(t, isSummaryRequest(" <@UBOT>  tl;dr"))
+	assert.False(t, isSummaryRequest("<@UBOT> can you translate this?"))
+	assert.False(t, isSummaryRequest("summarize <@U1>'s points"))
+	assert.False(t, isSummaryRequest("<@UBOT> summaries of meetings are in the wiki"))
+}
@@ -0,0 +1,108 @@
+package slack
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/image"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxThumbnails is the number of images previewed below one translation
+	maxThumbnails = 4
+	// maxThumbnailImageBytes links larger images rather than downloading them
+	maxThumbnailImageBytes = 10 << 20
+)
+
+// thumbnailMimeTypes are the image formats pkg/image can downscale
+var thumbnailMimeTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+}
+
+// fileUploader shares files from the bot, e.g. SlackClient
+type fileUploader interface {
+	UploadFile(ctx context.Context, channelID, threadTS, filename string, data []byte) error
+}
+
+// pendingThumbnail is a downscaled image waiting to be uploaded next to its translation
+type pendingThumbnail struct {
+	name string
+	data []byte
+}
+
+// thumbnails downscales the images among files, when thumbnails are enabled,
+// and returns the files still to be linked below the translation along with
+// the thumbnails replacing the others. Images that cannot be downscaled are
+// logged and linked as before.
+func (ep *eventProcessorImpl) thumbnails(ctx context.Context, downloader fileDownloader, channelID string, files []FileInfo) ([]FileInfo, []pendingThumbnail) {
+	if ep.thumbnailSize <= 0 {
+		return files, nil
+	}
+
+	var linked []FileInfo
+	var thumbnails []pendingThumbnail
+	for _, file := range files {
+		if len(thumbnails) == maxThumbnails || !ep.thumbnailCandidate(file) {
+			linked = append(linked, file)
+			continue
+		}
+
+		thumbnail, err := ep.thumbnail(ctx, downloader, file)
+		if err != nil {
+			ep.logger.Warn("Failed to create image thumbnail",
+				zap.Error(err),
+				zap.String("channel_id", channelID),
+				zap.String("file", file.Name))
+			if ep.metrics != nil {
+				ep.metrics.RecordError("thumbnail_failed")
+			}
+			linked = append(linked, file)
+			continue
+		}
+		thumbnails = append(thumbnails, thumbnail)
+	}
+	return linked, thumbnails
+}
+
+// thumbnailCandidate reports whether file is an image that may be previewed by a thumbnail
+func (ep *eventProcessorImpl) thumbnailCandidate(file FileInfo) bool {
+	return thumbnailMimeTypes[file.Mimetype] && file.URL != "" &&
+		file.Size <= maxThumbnailImageBytes && ep.mayShareContent(file)
+}
+
+// thumbnail downloads one image with the bot token and downscales it
+func (ep *eventProcessorImpl) thumbnail(ctx context.Context, downloader fileDownloader, file FileInfo) (pendingThumbnail, error) {
+	data, err := ep.downloadFile(ctx, downloader, file, maxThumbnailImageBytes)
+	if err != nil {
+		return pendingThumbnail{}, err
+	}
+	thumbnail, err := image.NewThumbnail(data, ep.thumbnailSize)
+	if err != nil {
+		return pendingThumbnail{}, err
+	}
+
+	name := strings.TrimSuffix(file.Name, path.Ext(file.Name))
+	if name == "" {
+		name = "image"
+	}
+	return pendingThumbnail{name: name + "_thumb." + thumbnail.Extension, data: thumbnail.Data}, nil
+}
+
+// uploadThumbnails shares thumbnails from the bot in the thread threadTS
+func (ep *eventProcessorImpl) uploadThumbnails(ctx context.Context, uploader fileUploader, channelID, threadTS string, thumbnails []pendingThumbnail) {
+	for _, thumbnail := range thumbnails {
+		if err := uploader.UploadFile(ctx, channelID, threadTS, thumbnail.name, thumbnail.data); err != nil {
+			ep.logger.Warn("Failed to upload image thumbnail",
+				zap.Error(err),
+				zap.String("channel_id", channelID),
+				zap.String("file", thumbnail.name))
+			if ep.metrics != nil {
+				ep.metrics.RecordError("thumbnail_failed")
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	stdimage "image"
+	"image/png"
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeUploader records uploaded files by name
+type fakeUploader struct {
+	files map[string][]byte
+	err   error
+}
+
+func (f *fakeUploader) UploadFile(_ context.Context, _, _, filename string, data []byte) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.files[filename] = data
+	return nil
+}
+
+func pngContent(t *testing.T, width, height int) string {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, stdimage.NewNRGBA(stdimage.Rect(0, 0, width, height))))
+	return buf.String()
+}
+
+func TestEventProcessorThumbnails(t *testing.T) {
+	downloader := fakeDownloader{
+		"https://files.slack.com/chart.png":  pngContent(t, 1200, 600),
+		"https://files.slack.com/broken.png": "not an image",
+	}
+	m := metrics.NewMetrics()
+	processor := NewEventProcessor(nil, nil, zap.NewNop(), WithThumbnails(300), WithMetrics(m)).(*eventProcessorImpl)
+
+	files := []FileInfo{
+		{Name: "chart.png", Mimetype: "image/png", URL: "https://files.slack.com/chart.png", Permalink: "https://example.slack.com/files/chart.png"},
+		{Name: "broken.png", Mimetype: "image/png", URL: "https://files.slack.com/broken.png", Permalink: "https://example.slack.com/files/broken.png"},
+		{Name: "report.pdf", Mimetype: "application/pdf", URL: "https://files.slack.com/report.pdf", Permalink: "https://example.slack.com/files/report.pdf"},
+		{Name: "secret.png", Mimetype: "image/png", URL: "https://files.slack.com/secret.png", Restricted: true},
+	}
+
+	linked, thumbnails := processor.thumbnails(context.Background(), downloader, "C1", files)
+
+	// The chart is previewed; the broken image, the document and the restricted image stay links
+	assert.Equal(t, files[1:], linked)
+	require.Len(t, thumbnails, 1)
+	assert.Equal(t, "chart_thumb.png", thumbnails[0].name)
+
+	decoded, err := png.Decode(bytes.NewReader(thumbnails[0].data))
+	require.NoError(t, err)
+	assert.Equal(t, stdimage.Rect(0, 0, 300, 150), decoded.Bounds())
+	assert.Equal(t, int64(1), m.ErrorsByType["thumbnail_failed"])
+
+	uploader := &fakeUploader{files: map[string][]byte{}}
+	processor.uploadThumbnails(context.Background(), uploader, "C1", "1234.5678", thumbnails)
+	assert.Equal(t, thumbnails[0].data, uploader.files["chart_thumb.png"])
+
+	processor.uploadThumbnails(context.Background(), &fakeUploader{err: errors.New("missing_scope")}, "C1", "1234.5678", thumbnails)
+	assert.Equal(t, int64(2), m.ErrorsByType["thumbnail_failed"])
+}
+
+func TestEventProcessorThumbnails_Disabled(t *testing.T) {
+	processor := NewEventProcessor(nil, nil, zap.NewNop()).(*eventProcessorImpl)
+	files := []FileInfo{{Name: "chart.png", Mimetype: "image/png", URL: "https://files.slack.com/chart.png"}}
+
+	// No downloader: nothing is fetched without WithThumbnails
+	linked, thumbnails := processor.thumbnails(context.Background(), nil, "C1", files)
+	assert.Equal(t, files, linked)
+	assert.Empty(t, thumbnails)
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
 	svc "github.com/ntttrang/go-genai-slack-assistant/internal/service"
 	"github.com/ntttrang/go-genai-slack-assistant/pkg/language"
 	"go.uber.org/zap"
@@ -92,18 +93,7 @@ func (th *TranslationHandler) TranslateAndPostReply(
 }
 
 func (th *TranslationHandler) getLanguageName(code string) string {
-	langMap := map[string]string{
-		"en": "English",
-		"vi": "Vietnamese",
-		"es": "Spanish",
-		"fr": "French",
-		"de": "German",
-		"zh": "Chinese",
-		"ja": "Japanese",
-		"ko": "Korean",
-	}
-
-	if name, ok := langMap[code]; ok {
+	if name, ok := model.LanguageName(code); ok {
 		return name
 	}
 	return "Unknown"
@@ -111,8 +101,5 @@ func (th *TranslationHandler) getLanguageName(code string) string {
 
 func (th *TranslationHandler) SanitizeText(text string) string {
 	text = strings.TrimSpace(text)
-	if len(text) > 10240 {
-		text = text[:10240]
-	}
-	return text
+	return language.TruncateBytes(text, 10240)
 }
@@ -0,0 +1,89 @@
+package slack
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"go.uber.org/zap"
+)
+
+// WorkspaceLookup returns the workspace a team installed the app in, or nil
+// when the team has no OAuth installation
+type WorkspaceLookup interface {
+	GetWorkspace(teamID string) (*model.Workspace, error)
+}
+
+// ClientPool hands out the SlackClient of each workspace. Teams without an
+// OAuth installation, and events without a team, use the default client
+// built from SLACK_BOT_TOKEN.
+type ClientPool struct {
+	fallback   *SlackClient
+	workspaces WorkspaceLookup
+	logger     *zap.Logger
+
+	mu      sync.Mutex
+	clients map[string]pooledClient
+}
+
+// pooledClient is a team's client with the token it was built with, so a
+// reinstall with a new token builds a new client
+type pooledClient struct {
+	token  string
+	client *SlackClient
+}
+
+func NewClientPool(fallback *SlackClient, workspaces WorkspaceLookup, logger *zap.Logger) *ClientPool {
+	return &ClientPool{
+		fallback:   fallback,
+		workspaces: workspaces,
+		logger:     logger,
+		clients:    make(map[string]pooledClient),
+	}
+}
+
+// ForTeam returns the client of the team's workspace. Lookup errors fall
+// back to the default client.
+func (p *ClientPool) ForTeam(teamID string) *SlackClient {
+	if teamID == "" {
+		return p.fallback
+	}
+
+	workspace, err := p.workspaces.GetWorkspace(teamID)
+	if err != nil {
+		p.logger.Warn("Failed to load workspace, using the default Slack client",
+			zap.Error(err),
+			zap.String("team_id", teamID))
+		return p.fallback
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if workspace == nil {
+		delete(p.clients, teamID)
+		return p.fallback
+	}
+
+	token := workspace.BotToken.Reveal()
+	if pooled, ok := p.clients[teamID]; ok && pooled.token == token {
+		return pooled.client
+	}
+	client := NewSlackClient(token)
+	if p.fallback != nil && p.fallback.recorder != nil {
+		client.setPostRecorder(p.fallback.recorder, teamID)
+	}
+	p.clients[teamID] = pooledClient{token: token, client: client}
+	return client
+}
+
+// withTeam returns a copy of ctx carrying the team an event came from, which
+// the translation use case also reads to apply the workspace's settings
+func withTeam(ctx context.Context, teamID string) context.Context {
+	return service.WithTeam(ctx, teamID)
+}
+
+// teamFrom returns the team carried by ctx, or ""
+func teamFrom(ctx context.Context) string {
+	return service.TeamFrom(ctx)
+}
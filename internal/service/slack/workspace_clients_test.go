@@ -0,0 +1,42 @@
+package slack
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/secrets"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type fakeWorkspaces struct {
+	workspaces map[string]*model.Workspace
+	err        error
+}
+
+func (f *fakeWorkspaces) GetWorkspace(teamID string) (*model.Workspace, error) {
+	return f.workspaces[teamID], f.err
+}
+
+func TestClientPool_ForTeam(t *testing.T) {
+	fallback := NewSlackClient("xoxb-default")
+	workspaces := &fakeWorkspaces{workspaces: map[string]*model.Workspace{
+		"T1": {TeamID: "T1", BotToken: secrets.Secret("xoxb-t1")},
+	}}
+	pool := NewClientPool(fallback, workspaces, zap.NewNop())
+
+	assert.Same(t, fallback, pool.ForTeam(""), "events without a team use the default client")
+	assert.Same(t, fallback, pool.ForTeam("T404"), "teams without an installation use the default client")
+
+	client := pool.ForTeam("T1")
+	assert.NotSame(t, fallback, client)
+	assert.Same(t, client, pool.ForTeam("T1"), "a team's client is reused")
+
+	// A reinstall with a new token builds a new client
+	workspaces.workspaces["T1"] = &model.Workspace{TeamID: "T1", BotToken: secrets.Secret("xoxb-t1-new")}
+	assert.NotSame(t, client, pool.ForTeam("T1"))
+
+	workspaces.err = errors.New("database down")
+	assert.Same(t, fallback, pool.ForTeam("T1"), "lookup errors fall back to the default client")
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/language"
+	"go.uber.org/zap"
+)
+
+// maxSummaryTranscriptTokens bounds the transcript sent to the AI; the
+// oldest messages of longer threads are left out
+const maxSummaryTranscriptTokens = 8000
+
+// detectSampleRunes is how much of a transcript its language is detected from
+const detectSampleRunes = 2000
+
+// ThreadReader returns the messages people posted in a thread, oldest
+// first, e.g. through Slack's conversations.replies
+type ThreadReader interface {
+	ThreadMessages(ctx context.Context, channelID, threadTS string) ([]model.Message, error)
+}
+
+// Summarizer summarizes a chat transcript in a language, e.g. ai.ChainedProvider
+type Summarizer interface {
+	Summarize(ctx context.Context, transcript, language string) (string, error)
+}
+
+var _ SummarizationService = (*SummarizationUseCase)(nil)
+
+// SummarizationUseCase summarizes long threads: the AI summarizes the thread
+// in its own language, and the summary is translated like a message of the
+// channel, so both sides of a bilingual channel can read it.
+type SummarizationUseCase struct {
+	threads      ThreadReader
+	summarizer   Summarizer
+	translations TranslationService
+	channels     ChannelConfigLookup
+	languages    *LanguageRouter
+	logger       *zap.Logger
+}
+
+func NewSummarizationUseCase(threads ThreadReader, summarizer Summarizer, translations TranslationService, logger *zap.Logger) *SummarizationUseCase {
+	return &SummarizationUseCase{
+		threads:      threads,
+		summarizer:   summarizer,
+		translations: translations,
+		languages:    DefaultLanguageRouter(),
+		logger:       logger,
+	}
+}
+
+// SetChannels translates summaries into the target language of the
+// channel's configuration
+func (su *SummarizationUseCase) SetChannels(channels ChannelConfigLookup) {
+	su.channels = channels
+}
+
+// SetLanguageRouter translates summaries along the configured language pairs
+// in channels without a configuration
+func (su *SummarizationUseCase) SetLanguageRouter(languages *LanguageRouter) {
+	su.languages = languages
+}
+
+// SummarizeThread summarizes the thread of threadTS in channelID
+func (su *SummarizationUseCase) SummarizeThread(ctx context.Context, channelID, threadTS string) (response.ThreadSummary, error) {
+	messages, err := su.threads.ThreadMessages(ctx, channelID, threadTS)
+	if err != nil {
+		return response.ThreadSummary{}, fmt.Errorf("failed to read thread: %w", err)
+	}
+
+	transcript, included := buildTranscript(messages)
+	if included < 2 {
+		return response.ThreadSummary{}, model.NewValidationError("This thread is too short to summarize")
+	}
+
+	sourceLanguage, err := su.translations.DetectLanguage(language.Truncate(transcriptText(messages), detectSampleRunes))
+	if err != nil {
+		return response.ThreadSummary{}, fmt.Errorf("failed to detect language: %w", err)
+	}
+
+	summary, err := su.summarizer.Summarize(ctx, transcript, sourceLanguage)
+	if err != nil {
+		return response.ThreadSummary{}, fmt.Errorf("failed to summarize thread: %w", err)
+	}
+
+	result := response.ThreadSummary{
+		ChannelID: channelID,
+		ThreadTS:  threadTS,
+		Messages:  included,
+		Summaries: []response.Summary{{Language: sourceLanguage, Text: strings.TrimSpace(summary)}},
+	}
+
+	targetLanguage, ok := su.languages.Route(su.channelConfig(channelID), sourceLanguage)
+	if !ok {
+		return result, nil
+	}
+	translated, err := su.translations.TranslateContext(ctx, request.Translation{
+		Text:           summary,
+		SourceLanguage: sourceLanguage,
+		TargetLanguage: targetLanguage,
+		ChannelID:      channelID,
+	})
+	if err != nil {
+		// The summary is still worth posting in one language
+		su.logger.Warn("Failed to translate thread summary",
+			zap.Error(err),
+			zap.String("channel_id", channelID),
+			zap.String("thread_ts", threadTS))
+		return result, nil
+	}
+	result.Summaries = append(result.Summaries, response.Summary{Language: targetLanguage, Text: strings.TrimSpace(translated.TranslatedText)})
+	return result, nil
+}
+
+func (su *SummarizationUseCase) channelConfig(channelID string) *model.ChannelConfig {
+	if su.channels == nil {
+		return nil
+	}
+	config, err := su.channels.GetChannelConfig(channelID)
+	if err != nil {
+		return nil
+	}
+	return config
+}
+
+// buildTranscript renders messages as "<@user>: text" lines, keeping the
+// newest ones that fit maxSummaryTranscriptTokens, and returns how many it kept
+func buildTranscript(messages []model.Message) (string, int) {
+	var lines []string
+	tokens := 0
+	for i := len(messages) - 1; i >= 0; i-- {
+		text := strings.TrimSpace(messages[i].Text)
+		if text == "" {
+			continue
+		}
+		line := fmt.Sprintf("<@%s>: %s", messages[i].UserID, text)
+		tokens += language.EstimateTokens(line)
+		if tokens > maxSummaryTranscriptTokens && len(lines) > 0 {
+			break
+		}
+		lines = append(lines, line)
+	}
+
+	for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+		lines[i], lines[j] = lines[j], lines[i]
+	}
+	return strings.Join(lines, "\n"), len(lines)
+}
+
+// transcriptText joins the text of messages, without their authors
+func transcriptText(messages []model.Message) string {
+	texts := make([]string, 0, len(messages))
+	for _, message := range messages {
+		texts = append(texts, message.Text)
+	}
+	return strings.Join(texts, "\n")
+}
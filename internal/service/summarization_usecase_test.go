@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeThreadReader struct {
+	messages []model.Message
+	err      error
+}
+
+func (f fakeThreadReader) ThreadMessages(ctx context.Context, channelID, threadTS string) ([]model.Message, error) {
+	return f.messages, f.err
+}
+
+type fakeSummarizer struct {
+	summary    string
+	err        error
+	transcript string
+	language   string
+}
+
+func (f *fakeSummarizer) Summarize(ctx context.Context, transcript, language string) (string, error) {
+	f.transcript = transcript
+	f.language = language
+	return f.summary, f.err
+}
+
+func TestSummarizationUseCase_SummarizeThread(t *testing.T) {
+	ctx := context.Background()
+	thread := fakeThreadReader{messages: []model.Message{
+		{UserID: "U1", Text: "Can we ship the release on Friday?"},
+		{UserID: "U2", Text: ""},
+		{UserID: "U2", Text: "Yes, once QA signs off"},
+	}}
+
+	t.Run("summarizes in both languages of the channel", func(t *testing.T) {
+		translations := &fakeTranslationService{detected: "English", translated: "• Phát hành vào thứ Sáu"}
+		summarizer := &fakeSummarizer{summary: "• Release ships on Friday\n"}
+		useCase := NewSummarizationUseCase(thread, summarizer, translations, zap.NewNop())
+
+		summary, err := useCase.SummarizeThread(ctx, "C1", "1700000000.000100")
+		require.NoError(t, err)
+
+		assert.Equal(t, "<@U1>: Can we ship the release on Friday?\n<@U2>: Yes, once QA signs off", summarizer.transcript)
+		assert.Equal(t, "English", summarizer.language)
+		assert.Equal(t, 2, summary.Messages)
+		require.Len(t, summary.Summaries, 2)
+		assert.Equal(t, "English", summary.Summaries[0].Language)
+		assert.Equal(t, "• Release ships on Friday", summary.Summaries[0].Text)
+		assert.Equal(t, "Vietnamese", summary.Summaries[1].Language)
+		assert.Equal(t, "• Phát hành vào thứ Sáu", summary.Summaries[1].Text)
+		assert.Equal(t, "C1", translations.requests[0].ChannelID)
+	})
+
+	t.Run("channel configuration picks the second language", func(t *testing.T) {
+		translations := &fakeTranslationService{detected: "English", translated: "• 金曜日にリリース"}
+		useCase := NewSummarizationUseCase(thread, &fakeSummarizer{summary: "• Release ships on Friday"}, translations, zap.NewNop())
+		useCase.SetChannels(fakeChannelConfigLookup{config: &model.ChannelConfig{ChannelID: "C1", TargetLanguage: "ja", Enabled: true}})
+
+		summary, err := useCase.SummarizeThread(ctx, "C1", "1700000000.000100")
+		require.NoError(t, err)
+		require.Len(t, summary.Summaries, 2)
+		assert.Equal(t, "Japanese", summary.Summaries[1].Language)
+	})
+
+	t.Run("failed translation keeps the summary", func(t *testing.T) {
+		translations := &fakeTranslationService{detected: "English", translateErr: errors.New("quota exceeded")}
+		useCase := NewSummarizationUseCase(thread, &fakeSummarizer{summary: "• Release ships on Friday"}, translations, zap.NewNop())
+
+		summary, err := useCase.SummarizeThread(ctx, "C1", "1700000000.000100")
+		require.NoError(t, err)
+		assert.Len(t, summary.Summaries, 1)
+	})
+
+	t.Run("too short", func(t *testing.T) {
+		short := fakeThreadReader{messages: []model.Message{{UserID: "U1", Text: "Hello"}}}
+		useCase := NewSummarizationUseCase(short, &fakeSummarizer{}, &fakeTranslationService{}, zap.NewNop())
+
+		_, err := useCase.SummarizeThread(ctx, "C1", "1700000000.000100")
+		var domainErr *model.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, model.ErrorTypeValidation, domainErr.Type)
+	})
+
+	t.Run("summarizer failure", func(t *testing.T) {
+		useCase := NewSummarizationUseCase(thread, &fakeSummarizer{err: errors.New("timeout")}, &fakeTranslationService{detected: "English"}, zap.NewNop())
+
+		_, err := useCase.SummarizeThread(ctx, "C1", "1700000000.000100")
+		assert.Error(t, err)
+	})
+}
+
+func TestBuildTranscript_KeepsNewestMessages(t *testing.T) {
+	long := strings.Repeat("word ", maxSummaryTranscriptTokens)
+	transcript, included := buildTranscript([]model.Message{
+		{UserID: "U1", Text: long},
+		{UserID: "U2", Text: "latest"},
+	})
+
+	assert.Equal(t, 1, included)
+	assert.Equal(t, "<@U2>: latest", transcript)
+}
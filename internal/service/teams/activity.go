@@ -0,0 +1,46 @@
+package teams
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ActivityTypeMessage is the Bot Framework activity type of a chat message
+const ActivityTypeMessage = "message"
+
+// Activity is the subset of a Bot Framework activity the bot reads and sends
+type Activity struct {
+	Type         string              `json:"type"`
+	ID           string              `json:"id,omitempty"`
+	ServiceURL   string              `json:"serviceUrl,omitempty"`
+	ChannelID    string              `json:"channelId,omitempty"`
+	From         *ChannelAccount     `json:"from,omitempty"`
+	Conversation ConversationAccount `json:"conversation"`
+	Recipient    *ChannelAccount     `json:"recipient,omitempty"`
+	Text         string              `json:"text"`
+	TextFormat   string              `json:"textFormat,omitempty"`
+	ReplyToID    string              `json:"replyToId,omitempty"`
+}
+
+// ChannelAccount is a user or bot in a conversation
+type ChannelAccount struct {
+	ID          string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	AADObjectID string `json:"aadObjectId,omitempty"`
+}
+
+// ConversationAccount is a Teams chat or channel thread
+type ConversationAccount struct {
+	ID               string `json:"id"`
+	Name             string `json:"name,omitempty"`
+	ConversationType string `json:"conversationType,omitempty"`
+	IsGroup          bool   `json:"isGroup,omitempty"`
+}
+
+var mentionPattern = regexp.MustCompile(`<at>[^<]*</at>`)
+
+// PlainText returns the message text without the <at>…</at> mentions Teams
+// adds when the bot is mentioned
+func (a *Activity) PlainText() string {
+	return strings.TrimSpace(mentionPattern.ReplaceAllString(a.Text, ""))
+}
@@ -0,0 +1,250 @@
+package teams
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultOpenIDConfigURL = "https://login.botframework.com/v1/.well-known/openidconfiguration"
+	botFrameworkIssuer     = "https://api.botframework.com"
+	// clockSkew tolerates small clock differences with Bot Framework
+	clockSkew = 5 * time.Minute
+	// keyRefreshInterval is how long signing keys are cached, as recommended by Bot Framework
+	keyRefreshInterval = 24 * time.Hour
+	// keyRefreshCooldown limits refreshes triggered by unknown key IDs
+	keyRefreshCooldown = 5 * time.Minute
+)
+
+// ErrInvalidToken is returned for requests that do not carry a valid Bot Framework token
+var ErrInvalidToken = errors.New("invalid bot framework token")
+
+// Claims are the token claims checked for incoming Bot Framework requests
+type Claims struct {
+	Issuer     string   `json:"iss"`
+	Audience   audience `json:"aud"`
+	ExpiresAt  int64    `json:"exp"`
+	NotBefore  int64    `json:"nbf"`
+	ServiceURL string   `json:"serviceurl"`
+}
+
+// audience accepts the aud claim as a single string or a list
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+func (a audience) contains(value string) bool {
+	for _, v := range a {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// TokenVerifier authenticates requests sent by Bot Framework to the bot's
+// messaging endpoint by checking the RS256 token in the Authorization header
+type TokenVerifier struct {
+	appID           string
+	openIDConfigURL string
+	httpClient      *http.Client
+	now             func() time.Time
+
+	mu          sync.Mutex
+	keys        map[string]*rsa.PublicKey
+	keysFetched time.Time
+}
+
+func NewTokenVerifier(appID string) *TokenVerifier {
+	return &TokenVerifier{
+		appID:           appID,
+		openIDConfigURL: defaultOpenIDConfigURL,
+		httpClient:      &http.Client{Timeout: 10 * time.Second},
+		now:             time.Now,
+	}
+}
+
+// VerifyBotToken checks the Authorization header of a Bot Framework request
+// and returns the connector service URL the token was issued for
+func (v *TokenVerifier) VerifyBotToken(ctx context.Context, authorization string) (string, error) {
+	claims, err := v.Verify(ctx, authorization)
+	if err != nil {
+		return "", err
+	}
+	return claims.ServiceURL, nil
+}
+
+// Verify checks the signature, issuer, audience and lifetime of the bearer
+// token in authorization
+func (v *TokenVerifier) Verify(ctx context.Context, authorization string) (*Claims, error) {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok || token == "" {
+		return nil, fmt.Errorf("%w: missing bearer token", ErrInvalidToken)
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: malformed header: %v", ErrInvalidToken, err)
+	}
+	if header.Alg != "RS256" {
+		return nil, fmt.Errorf("%w: unexpected algorithm %q", ErrInvalidToken, header.Alg)
+	}
+
+	key, err := v.signingKey(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidToken)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, fmt.Errorf("%w: bad signature", ErrInvalidToken)
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims: %v", ErrInvalidToken, err)
+	}
+	now := v.now()
+	switch {
+	case claims.Issuer != botFrameworkIssuer:
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, claims.Issuer)
+	case !claims.Audience.contains(v.appID):
+		return nil, fmt.Errorf("%w: token not issued for this bot", ErrInvalidToken)
+	case claims.ExpiresAt == 0 || now.After(time.Unix(claims.ExpiresAt, 0).Add(clockSkew)):
+		return nil, fmt.Errorf("%w: token expired", ErrInvalidToken)
+	case claims.NotBefore != 0 && now.Add(clockSkew).Before(time.Unix(claims.NotBefore, 0)):
+		return nil, fmt.Errorf("%w: token not valid yet", ErrInvalidToken)
+	}
+	return &claims, nil
+}
+
+// signingKey returns the public key with the given ID, refreshing the cached
+// keys once they are stale or when an unknown key ID shows up
+func (v *TokenVerifier) signingKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := v.now()
+	key, ok := v.keys[kid]
+	stale := now.Sub(v.keysFetched) > keyRefreshInterval
+	if ok && !stale {
+		return key, nil
+	}
+	if stale || now.Sub(v.keysFetched) > keyRefreshCooldown {
+		keys, err := v.fetchKeys(ctx)
+		if err != nil {
+			if ok {
+				// Keep using the cached key while Bot Framework is unreachable
+				return key, nil
+			}
+			return nil, err
+		}
+		v.keys = keys
+		v.keysFetched = now
+		key, ok = keys[kid]
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidToken, kid)
+	}
+	return key, nil
+}
+
+func (v *TokenVerifier) fetchKeys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	var config struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := v.getJSON(ctx, v.openIDConfigURL, &config); err != nil {
+		return nil, fmt.Errorf("failed to fetch bot framework openid configuration: %w", err)
+	}
+
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := v.getJSON(ctx, config.JWKSURI, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to fetch bot framework signing keys: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, nil
+}
+
+func (v *TokenVerifier) getJSON(ctx context.Context, endpoint string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, endpoint)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func decodeSegment(segment string, out interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,157 @@
+package teams
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signToken(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	t.Helper()
+	encode := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signed := encode(map[string]string{"alg": "RS256", "kid": kid, "typ": "JWT"}) + "." + encode(claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func newKeyServer(t *testing.T, kid string, key *rsa.PublicKey) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	mux.HandleFunc("/openid", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"jwks_uri": server.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": kid,
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTokenVerifier_Verify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	server := newKeyServer(t, "key-1", &key.PublicKey)
+	now := time.Now()
+	validClaims := func() map[string]interface{} {
+		return map[string]interface{}{
+			"iss":        botFrameworkIssuer,
+			"aud":        "app-id",
+			"exp":        now.Add(time.Hour).Unix(),
+			"nbf":        now.Add(-time.Minute).Unix(),
+			"serviceurl": "https://smba.trafficmanager.net/emea/",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		token   func() string
+		wantErr bool
+	}{
+		{
+			name:  "valid token",
+			token: func() string { return signToken(t, key, "key-1", validClaims()) },
+		},
+		{
+			name: "audience list",
+			token: func() string {
+				claims := validClaims()
+				claims["aud"] = []string{"other", "app-id"}
+				return signToken(t, key, "key-1", claims)
+			},
+		},
+		{
+			name:    "signed with another key",
+			token:   func() string { return signToken(t, otherKey, "key-1", validClaims()) },
+			wantErr: true,
+		},
+		{
+			name:    "unknown key id",
+			token:   func() string { return signToken(t, key, "key-2", validClaims()) },
+			wantErr: true,
+		},
+		{
+			name: "wrong audience",
+			token: func() string {
+				claims := validClaims()
+				claims["aud"] = "another-bot"
+				return signToken(t, key, "key-1", claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong issuer",
+			token: func() string {
+				claims := validClaims()
+				claims["iss"] = "https://sts.windows.net/tenant/"
+				return signToken(t, key, "key-1", claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "expired",
+			token: func() string {
+				claims := validClaims()
+				claims["exp"] = now.Add(-time.Hour).Unix()
+				return signToken(t, key, "key-1", claims)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			token:   func() string { return "not-a-token" },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := NewTokenVerifier("app-id")
+			verifier.openIDConfigURL = server.URL + "/openid"
+
+			serviceURL, err := verifier.VerifyBotToken(context.Background(), "Bearer "+tt.token())
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidToken)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "https://smba.trafficmanager.net/emea/", serviceURL)
+		})
+	}
+}
+
+func TestTokenVerifier_MissingBearer(t *testing.T) {
+	verifier := NewTokenVerifier("app-id")
+
+	_, err := verifier.Verify(context.Background(), "")
+
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
@@ -0,0 +1,198 @@
+package teams
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+)
+
+const (
+	defaultTokenURL   = "https://login.microsoftonline.com/botframework.com/oauth2/v2.0/token"
+	botFrameworkScope = "https://api.botframework.com/.default"
+	// tokenExpiryMargin renews the access token before Bot Framework rejects it
+	tokenExpiryMargin = 5 * time.Minute
+)
+
+// Client calls the Bot Framework connector API on behalf of the bot
+type Client struct {
+	appID       string
+	appPassword string
+	tokenURL    string
+	httpClient  *http.Client
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+func NewClient(appID, appPassword string) *Client {
+	return &Client{
+		appID:       appID,
+		appPassword: appPassword,
+		tokenURL:    defaultTokenURL,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Platform returns the service.ChatPlatform for conversations served by
+// serviceURL, the connector endpoint sent with every incoming activity
+func (c *Client) Platform(serviceURL string) service.ChatPlatform {
+	return &conversationPlatform{client: c, serviceURL: strings.TrimSuffix(serviceURL, "/")}
+}
+
+type conversationPlatform struct {
+	client     *Client
+	serviceURL string
+}
+
+// PostReply replies to reply.ThreadID. Teams bots cannot change their name
+// per message, so the username is shown in bold above the text.
+func (p *conversationPlatform) PostReply(ctx context.Context, reply model.ChatReply) error {
+	text := reply.Text
+	if reply.AsQuote {
+		text = "> " + strings.ReplaceAll(text, "\n", "\n> ")
+	}
+	if reply.Username != "" {
+		text = fmt.Sprintf("**%s**\n\n%s", reply.Username, text)
+	}
+
+	activity := Activity{
+		Type:         ActivityTypeMessage,
+		Conversation: ConversationAccount{ID: reply.ChannelID},
+		Text:         text,
+		TextFormat:   "markdown",
+		ReplyToID:    reply.ThreadID,
+	}
+	path := fmt.Sprintf("/v3/conversations/%s/activities", url.PathEscape(reply.ChannelID))
+	if reply.ThreadID != "" {
+		path += "/" + url.PathEscape(reply.ThreadID)
+	}
+	return p.client.do(ctx, http.MethodPost, p.serviceURL+path, activity, nil)
+}
+
+// AddReaction is a no-op: Teams bots cannot react to messages
+func (p *conversationPlatform) AddReaction(_ context.Context, _, _, _ string) error {
+	return nil
+}
+
+func (p *conversationPlatform) GetUser(ctx context.Context, channelID, userID string) (*model.ChatUser, error) {
+	var member struct {
+		ID        string `json:"id"`
+		Name      string `json:"name"`
+		GivenName string `json:"givenName"`
+	}
+	path := fmt.Sprintf("/v3/conversations/%s/members/%s", url.PathEscape(channelID), url.PathEscape(userID))
+	if err := p.client.do(ctx, http.MethodGet, p.serviceURL+path, nil, &member); err != nil {
+		return nil, err
+	}
+	return &model.ChatUser{
+		ID:          member.ID,
+		Name:        member.Name,
+		DisplayName: member.GivenName,
+	}, nil
+}
+
+// do sends an authenticated connector request and decodes the response into out
+func (c *Client) do(ctx context.Context, method, endpoint string, body, out interface{}) error {
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode teams request: %w", err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create teams request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("teams request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("teams request %s %s failed with status %d: %s", method, endpoint, resp.StatusCode, message)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode teams response: %w", err)
+	}
+	return nil
+}
+
+// accessToken returns a cached Bot Framework access token, requesting a new
+// one with the app credentials when it is about to expire
+func (c *Client) accessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && time.Now().Before(c.tokenExpiry) {
+		return c.token, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {c.appID},
+		"client_secret": {c.appPassword},
+		"scope":         {botFrameworkScope},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request teams access token: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("teams access token request failed with status %d", resp.StatusCode)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode teams access token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("teams access token response has no access_token")
+	}
+
+	c.token = token.AccessToken
+	c.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - tokenExpiryMargin)
+	return c.token, nil
+}
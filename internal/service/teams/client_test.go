@@ -0,0 +1,90 @@
+package teams
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_PostReplyAndGetUser(t *testing.T) {
+	tokenRequests := 0
+	var posted Activity
+	var postedPath string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "app-id", r.PostForm.Get("client_id"))
+		assert.Equal(t, botFrameworkScope, r.PostForm.Get("scope"))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "bot-token", "expires_in": 3600})
+	})
+	mux.HandleFunc("/v3/conversations/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer bot-token", r.Header.Get("Authorization"))
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": "29:user", "name": "Alice Nguyen", "givenName": "Alice"})
+			return
+		}
+		postedPath = r.URL.EscapedPath()
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&posted))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"reply-1"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient("app-id", "secret")
+	client.tokenURL = server.URL + "/token"
+	platform := client.Platform(server.URL + "/")
+
+	user, err := platform.GetUser(context.Background(), "19:conv@thread.tacv2", "29:user")
+	require.NoError(t, err)
+	assert.Equal(t, &model.ChatUser{ID: "29:user", Name: "Alice Nguyen", DisplayName: "Alice"}, user)
+
+	err = platform.PostReply(context.Background(), model.ChatReply{
+		ChannelID: "19:conv@thread.tacv2",
+		ThreadID:  "1700000000000",
+		Text:      "Xin chào\nnhóm",
+		Username:  "Alice (Bot) 🇻🇳",
+		AsQuote:   true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, tokenRequests, "access token is cached")
+	assert.Equal(t, "/v3/conversations/19:conv@thread.tacv2/activities/1700000000000", postedPath)
+	assert.Equal(t, ActivityTypeMessage, posted.Type)
+	assert.Equal(t, "1700000000000", posted.ReplyToID)
+	assert.Equal(t, "**Alice (Bot) 🇻🇳**\n\n> Xin chào\n> nhóm", posted.Text)
+}
+
+func TestClient_PostReplyError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "bot-token", "expires_in": 3600})
+	})
+	mux.HandleFunc("/v3/conversations/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient("app-id", "secret")
+	client.tokenURL = server.URL + "/token"
+
+	err := client.Platform(server.URL).PostReply(context.Background(), model.ChatReply{ChannelID: "conv", ThreadID: "1", Text: "hi"})
+
+	assert.ErrorContains(t, err, "status 403")
+}
+
+func TestActivity_PlainText(t *testing.T) {
+	activity := Activity{Text: "<at>Translator</at> Hello <at>Bob</at> team "}
+
+	assert.Equal(t, "Hello  team", activity.PlainText())
+}
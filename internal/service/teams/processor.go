@@ -0,0 +1,52 @@
+package teams
+
+import (
+	"context"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"go.uber.org/zap"
+)
+
+// PayloadActivityKey is the queued event payload key holding the *Activity
+const PayloadActivityKey = "activity"
+
+// Processor translates Teams messages taken from the worker pool with the
+// same translation core as Slack
+type Processor struct {
+	client     *Client
+	translator service.ChatTranslationService
+	logger     *zap.Logger
+}
+
+func NewProcessor(client *Client, translator service.ChatTranslationService, logger *zap.Logger) *Processor {
+	return &Processor{
+		client:     client,
+		translator: translator,
+		logger:     logger,
+	}
+}
+
+func (p *Processor) ProcessEvent(ctx context.Context, payload map[string]interface{}) {
+	activity, ok := payload[PayloadActivityKey].(*Activity)
+	if !ok {
+		p.logger.Error("Queued Teams event has no activity")
+		return
+	}
+	if activity.Type != ActivityTypeMessage || activity.From == nil {
+		p.logger.Debug("Skipping non-message Teams activity", zap.String("type", activity.Type))
+		return
+	}
+	if activity.Recipient != nil && activity.From.ID == activity.Recipient.ID {
+		p.logger.Debug("Skipping the bot's own Teams message")
+		return
+	}
+
+	p.translator.HandleMessage(ctx, p.client.Platform(activity.ServiceURL), model.ChatMessage{
+		ChannelID: activity.Conversation.ID,
+		ThreadID:  activity.ID,
+		MessageID: activity.ID,
+		UserID:    activity.From.ID,
+		Text:      activity.PlainText(),
+	})
+}
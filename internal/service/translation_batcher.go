@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/ai"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+// BatchTranslator is implemented by translators that translate several texts
+// in one AI call, e.g. ai.ChainedProvider. Translations come back in the
+// order of texts.
+type BatchTranslator interface {
+	TranslateBatch(ctx context.Context, texts []string, sourceLanguage, targetLanguage string) ([]string, error)
+}
+
+// ProviderBatchTranslator is implemented by batch translators that report
+// which AI provider served a batch, e.g. ai.ChainedProvider
+type ProviderBatchTranslator interface {
+	TranslateBatchWithProvider(ctx context.Context, texts []string, sourceLanguage, targetLanguage string) (translated []string, provider string, err error)
+}
+
+// batchCallTimeout bounds a batched AI call, which outlives the callers that
+// stop waiting for it
+const batchCallTimeout = time.Minute
+
+// errTranslateAlone tells a waiting translation to call the AI on its own
+var errTranslateAlone = errors.New("translate alone")
+
+// batchKey groups the translations that can share an AI call
+type batchKey struct {
+	sourceLanguage string
+	targetLanguage string
+	promptVersion  string
+	generation     ai.GenerationConfig
+}
+
+// pendingBatch collects translations until its window ends or it is full
+type pendingBatch struct {
+	key     batchKey
+	ctx     context.Context
+	texts   []string
+	results []chan batchResult
+	flushed bool
+}
+
+type batchResult struct {
+	translated string
+	provider   string
+	usage      ai.Usage
+	err        error
+}
+
+// translationBatcher groups the AI calls of translations arriving within
+// window for the same language pair, prompt version and generation
+// parameters into one call of at most maxSize texts. A batch that fails, e.g.
+// because one text is blocked or the answer does not split, is translated
+// text by text instead, so no translation fails because of another.
+type translationBatcher struct {
+	translator Translator
+	batcher    BatchTranslator
+	window     time.Duration
+	maxSize    int
+	logger     *zap.Logger
+	metrics    *metrics.Metrics
+
+	mu      sync.Mutex
+	pending map[batchKey]*pendingBatch
+}
+
+func newTranslationBatcher(translator Translator, batcher BatchTranslator, window time.Duration, maxSize int, logger *zap.Logger, m *metrics.Metrics) *translationBatcher {
+	return &translationBatcher{
+		translator: translator,
+		batcher:    batcher,
+		window:     window,
+		maxSize:    maxSize,
+		logger:     logger,
+		metrics:    m,
+		pending:    make(map[batchKey]*pendingBatch),
+	}
+}
+
+// translate translates text in the next batch of its kind and returns the
+// provider that served it, recording the text's share of the batch's usage
+// on the ai.Usage of ctx
+func (b *translationBatcher) translate(ctx context.Context, text, sourceLanguage, targetLanguage string) (string, string, error) {
+	result := b.add(ctx, text, sourceLanguage, targetLanguage)
+
+	select {
+	case r := <-result:
+		if r.err != nil {
+			return translateWithContext(ctx, b.translator, text, sourceLanguage, targetLanguage)
+		}
+		ai.UsageFrom(ctx).Record(r.usage.Model, r.usage.PromptVersion, r.usage.PromptTokens, r.usage.OutputTokens)
+		return r.translated, r.provider, nil
+	case <-ctx.Done():
+		return "", "", ctx.Err()
+	}
+}
+
+// add queues text in the pending batch of its kind, starting one when there
+// is none, and returns the channel its result is sent on
+func (b *translationBatcher) add(ctx context.Context, text, sourceLanguage, targetLanguage string) chan batchResult {
+	key := batchKey{
+		sourceLanguage: sourceLanguage,
+		targetLanguage: targetLanguage,
+		promptVersion:  ai.PromptVersionFrom(ctx, ""),
+		generation:     ai.GenerationFrom(ctx),
+	}
+	result := make(chan batchResult, 1)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	batch, ok := b.pending[key]
+	if !ok {
+		batch = &pendingBatch{key: key, ctx: ctx}
+		b.pending[key] = batch
+		time.AfterFunc(b.window, func() { b.flush(batch) })
+	}
+	batch.texts = append(batch.texts, text)
+	batch.results = append(batch.results, result)
+	if len(batch.texts) >= b.maxSize {
+		go b.flush(batch)
+	}
+	return result
+}
+
+// flush translates batch once, when its window ends or it is full
+func (b *translationBatcher) flush(batch *pendingBatch) {
+	b.mu.Lock()
+	if batch.flushed {
+		b.mu.Unlock()
+		return
+	}
+	batch.flushed = true
+	if b.pending[batch.key] == batch {
+		delete(b.pending, batch.key)
+	}
+	b.mu.Unlock()
+
+	if len(batch.texts) == 1 {
+		batch.results[0] <- batchResult{err: errTranslateAlone}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(batch.ctx), batchCallTimeout)
+	defer cancel()
+	usage := &ai.Usage{}
+	translations, provider, err := translateBatchWithContext(ai.WithUsage(ctx, usage), b.batcher, batch.texts, batch.key.sourceLanguage, batch.key.targetLanguage)
+	if err != nil {
+		b.logger.Warn("Batch translation failed, translating its texts one by one",
+			zap.Error(err),
+			zap.Int("batch_size", len(batch.texts)),
+			zap.String("source_language", batch.key.sourceLanguage),
+			zap.String("target_language", batch.key.targetLanguage))
+		if b.metrics != nil {
+			b.metrics.RecordError("translation_batch_failed")
+		}
+		for _, result := range batch.results {
+			result <- batchResult{err: err}
+		}
+		return
+	}
+
+	b.logger.Debug("Translated batch",
+		zap.Int("batch_size", len(batch.texts)),
+		zap.String("provider", provider),
+		zap.Int64("prompt_tokens", usage.PromptTokens),
+		zap.Int64("output_tokens", usage.OutputTokens))
+	promptTokens := shareTokens(usage.PromptTokens, batch.texts)
+	outputTokens := shareTokens(usage.OutputTokens, translations)
+	for i, result := range batch.results {
+		result <- batchResult{
+			translated: translations[i],
+			provider:   provider,
+			usage: ai.Usage{
+				Model:         usage.Model,
+				PromptVersion: usage.PromptVersion,
+				PromptTokens:  promptTokens[i],
+				OutputTokens:  outputTokens[i],
+			},
+		}
+	}
+}
+
+// shareTokens splits the tokens of a batch between its texts by their
+// length, the last text taking what rounding leaves
+func shareTokens(tokens int64, texts []string) []int64 {
+	shares := make([]int64, len(texts))
+	var total int64
+	for _, text := range texts {
+		total += int64(len(text))
+	}
+	var given int64
+	for i, text := range texts {
+		if i == len(texts)-1 {
+			shares[i] = tokens - given
+			break
+		}
+		if total > 0 {
+			shares[i] = tokens * int64(len(text)) / total
+		}
+		given += shares[i]
+	}
+	return shares
+}
+
+// translateBatchWithContext reports the provider that served a batch when
+// the translator does
+func translateBatchWithContext(ctx context.Context, translator BatchTranslator, texts []string, sourceLanguage, targetLanguage string) ([]string, string, error) {
+	if pt, ok := translator.(ProviderBatchTranslator); ok {
+		return pt.TranslateBatchWithProvider(ctx, texts, sourceLanguage, targetLanguage)
+	}
+	translated, err := translator.TranslateBatch(ctx, texts, sourceLanguage, targetLanguage)
+	return translated, "", err
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/ai"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeBatchTranslator tags every text with its target language, and records
+// its calls
+type fakeBatchTranslator struct {
+	mu       sync.Mutex
+	batchErr error
+	batches  [][]string
+	singles  []string
+}
+
+func (f *fakeBatchTranslator) Translate(text, _, targetLanguage string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.singles = append(f.singles, text)
+	return targetLanguage + ":" + text, nil
+}
+
+func (f *fakeBatchTranslator) DetectLanguage(string) (string, error) {
+	return "English", nil
+}
+
+func (f *fakeBatchTranslator) TranslateBatch(ctx context.Context, texts []string, _, targetLanguage string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.batches = append(f.batches, texts)
+	if f.batchErr != nil {
+		return nil, f.batchErr
+	}
+	ai.UsageFrom(ctx).Record("gemini-test", ai.BatchPromptVersion, 30, 10)
+	translations := make([]string, len(texts))
+	for i, text := range texts {
+		translations[i] = targetLanguage + ":" + text
+	}
+	return translations, nil
+}
+
+// translateAll translates texts concurrently into target and returns the
+// translations and usages in the order of texts
+func translateAll(t *testing.T, batcher *translationBatcher, target string, texts ...string) ([]string, []*ai.Usage) {
+	translations := make([]string, len(texts))
+	usages := make([]*ai.Usage, len(texts))
+	var wg sync.WaitGroup
+	for i, text := range texts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			usages[i] = &ai.Usage{}
+			translated, _, err := batcher.translate(ai.WithUsage(context.Background(), usages[i]), text, "English", target)
+			assert.NoError(t, err)
+			translations[i] = translated
+		}()
+	}
+	wg.Wait()
+	return translations, usages
+}
+
+func TestTranslationBatcher_Batches(t *testing.T) {
+	translator := &fakeBatchTranslator{}
+	// The window outlasts the test: batches are sent once full
+	batcher := newTranslationBatcher(translator, translator, time.Hour, 2, zap.NewNop(), nil)
+
+	translations, usages := translateAll(t, batcher, "Vietnamese", "Hello", "Good morning")
+
+	assert.Equal(t, []string{"Vietnamese:Hello", "Vietnamese:Good morning"}, translations)
+	require.Len(t, translator.batches, 1)
+	assert.ElementsMatch(t, []string{"Hello", "Good morning"}, translator.batches[0])
+	assert.Empty(t, translator.singles)
+
+	for _, usage := range usages {
+		assert.Equal(t, "gemini-test", usage.Model)
+		assert.Equal(t, ai.BatchPromptVersion, usage.PromptVersion)
+	}
+	assert.Equal(t, int64(30), usages[0].PromptTokens+usages[1].PromptTokens, "the batch's tokens are shared")
+	assert.Equal(t, int64(10), usages[0].OutputTokens+usages[1].OutputTokens)
+}
+
+func TestTranslationBatcher_Alone(t *testing.T) {
+	translator := &fakeBatchTranslator{}
+	batcher := newTranslationBatcher(translator, translator, 10*time.Millisecond, 10, zap.NewNop(), nil)
+
+	translations, _ := translateAll(t, batcher, "Japanese", "Hello")
+
+	assert.Equal(t, []string{"Japanese:Hello"}, translations)
+	assert.Empty(t, translator.batches, "a text alone in its window is translated on its own")
+	assert.Equal(t, []string{"Hello"}, translator.singles)
+}
+
+func TestTranslationBatcher_SeparatesLanguagePairs(t *testing.T) {
+	translator := &fakeBatchTranslator{}
+	batcher := newTranslationBatcher(translator, translator, 20*time.Millisecond, 10, zap.NewNop(), nil)
+
+	var wg sync.WaitGroup
+	for _, target := range []string{"Vietnamese", "Japanese"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			translateAll(t, batcher, target, "Hello", "Bye")
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, translator.batches, 2)
+	assert.Empty(t, translator.singles)
+}
+
+func TestTranslationBatcher_FallsBackOnFailure(t *testing.T) {
+	translator := &fakeBatchTranslator{batchErr: &ai.ProviderError{Category: ai.CategoryInvalidResponse, Err: errors.New("2 messages, expected 3")}}
+	m := metrics.NewMetrics()
+	batcher := newTranslationBatcher(translator, translator, time.Hour, 3, zap.NewNop(), m)
+
+	translations, _ := translateAll(t, batcher, "Thai", "a", "b", "c")
+
+	assert.Equal(t, []string{"Thai:a", "Thai:b", "Thai:c"}, translations)
+	assert.Len(t, translator.batches, 1)
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, translator.singles, "each text is translated on its own")
+	assert.Equal(t, int64(1), m.ErrorsByType["translation_batch_failed"])
+}
+
+func TestTranslationBatcher_CallerGivesUp(t *testing.T) {
+	translator := &fakeBatchTranslator{}
+	batcher := newTranslationBatcher(translator, translator, time.Hour, 10, zap.NewNop(), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, _, err := batcher.translate(ctx, "Hello", "English", "Korean")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestShareTokens(t *testing.T) {
+	assert.Equal(t, []int64{10, 20}, shareTokens(30, []string{"ab", "abcd"}))
+	assert.Equal(t, []int64{3, 3, 4}, shareTokens(10, []string{"a", "b", "c"}))
+	assert.Equal(t, []int64{0, 7}, shareTokens(7, []string{"", ""}))
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/ai"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/eventbus"
+	"go.uber.org/zap"
+)
+
+// TranslationCompleted is published on the translation events bus for every
+// translation served, from the cache, the database or the AI provider
+type TranslationCompleted struct {
+	Request  request.Translation
+	Result   response.Translation
+	Duration time.Duration
+}
+
+// TranslationFailed is published for every translation that was not served,
+// including those rejected by the security checks
+type TranslationFailed struct {
+	Request  request.Translation
+	Err      error
+	Category ai.ErrorCategory
+	Duration time.Duration
+}
+
+// Reasons a SecurityBlocked event was published
+const (
+	SecurityReasonInputRejected = "input_rejected"
+	SecurityReasonCanaryLeaked  = "canary_leaked"
+)
+
+// SecurityBlocked is published when a translation is refused for security:
+// its input was rejected, or the model output leaked the prompt canary
+type SecurityBlocked struct {
+	Request request.Translation
+	Reason  string
+	// Input is the sanitized text sent to the model, for canary leaks
+	Input   string
+	Variant string
+}
+
+// Events returns the bus translation events are published on, for
+// subscribers such as analytics, outbound webhooks or metrics
+func (tu *TranslationUseCase) Events() *eventbus.Bus {
+	return tu.events
+}
+
+// publishResult publishes the outcome of a translation
+func (tu *TranslationUseCase) publishResult(req request.Translation, result response.Translation, err error, duration time.Duration) {
+	if err != nil {
+		tu.events.Publish(TranslationFailed{Request: req, Err: err, Category: ai.CategoryOf(err), Duration: duration})
+		return
+	}
+	tu.events.Publish(TranslationCompleted{Request: req, Result: result, Duration: duration})
+}
+
+// subscribeMetrics counts every translation request in m
+func (tu *TranslationUseCase) subscribeMetrics() {
+	eventbus.Subscribe(tu.events, func(e TranslationCompleted) {
+		tu.metrics.RecordTranslationRequest(e.Request.UserID, e.Request.ChannelID, e.Duration, true)
+	})
+	eventbus.Subscribe(tu.events, func(e TranslationFailed) {
+		tu.metrics.RecordTranslationRequest(e.Request.UserID, e.Request.ChannelID, e.Duration, false)
+	})
+}
+
+// SetUsageRecorder counts every translation served in a channel with usage.
+// Usage is not counted while the primary database is read-only.
+func (tu *TranslationUseCase) SetUsageRecorder(usage UsageRecorder) {
+	eventbus.Subscribe(tu.events, func(e TranslationCompleted) {
+		channelID := e.Request.ChannelID
+		if channelID == "" || !tu.persistenceAvailable() {
+			return
+		}
+		if err := usage.RecordTranslation(channelID, e.Result); err != nil {
+			tu.logger.Warn("Failed to record translation usage",
+				zap.Error(err),
+				zap.String("channel_id", channelID))
+		}
+	})
+}
+
+// SetAlerter sends an alert whenever a translation is compromised
+func (tu *TranslationUseCase) SetAlerter(alerter Alerter) {
+	eventbus.Subscribe(tu.events, func(e SecurityBlocked) {
+		if e.Reason != SecurityReasonCanaryLeaked {
+			return
+		}
+		incident := compromisedIncident(e)
+		go func() {
+			if err := alerter.Send(context.Background(), "translation.compromised", incident); err != nil {
+				tu.logger.Warn("Failed to send compromised translation alert", zap.Error(err))
+			}
+		}()
+	})
+}
+
+// compromisedIncident describes a canary leak in the audit log and alerts
+func compromisedIncident(e SecurityBlocked) map[string]interface{} {
+	return map[string]interface{}{
+		"channel_id":      e.Request.ChannelID,
+		"user_id":         e.Request.UserID,
+		"source_language": e.Request.SourceLanguage,
+		"target_language": e.Request.TargetLanguage,
+		"variant":         e.Variant,
+		"input":           e.Input,
+	}
+}
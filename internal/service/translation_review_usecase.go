@@ -0,0 +1,244 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+// PendingTranslationRepository defines the interface for persisting translations awaiting review.
+// This interface is owned by the TranslationReviewUseCase and defined where it's consumed.
+type PendingTranslationRepository interface {
+	Save(draft *model.PendingTranslation) error
+	GetByID(id string) (*model.PendingTranslation, error)
+	SetReviewMessage(id, reviewMessageTS string) error
+	UpdateStatus(id, from, to, reviewerID string, reviewedAt *time.Time) error
+}
+
+// ReviewChannelLookup reports a channel's configuration, including its review channel
+type ReviewChannelLookup interface {
+	GetChannelConfig(channelID string) (*model.ChannelConfig, error)
+}
+
+// ReviewMessenger posts review requests and approved translations to Slack
+type ReviewMessenger interface {
+	// PostReviewRequest posts the draft with Approve/Reject buttons to its review channel
+	PostReviewRequest(draft *model.PendingTranslation) (string, error)
+	// UpdateReviewRequest replaces the buttons with the review decision
+	UpdateReviewRequest(draft *model.PendingTranslation) error
+	// PostApprovedTranslation posts the translation to the original thread
+	PostApprovedTranslation(draft *model.PendingTranslation) error
+	// OpenCorrectionModal opens a modal in which the reviewer can edit the translation before approving it
+	OpenCorrectionModal(triggerID string, draft *model.PendingTranslation) error
+}
+
+// CorrectionRecorder stores translations a reviewer corrected before approving them
+type CorrectionRecorder interface {
+	Record(correction *model.TranslationCorrection) error
+}
+
+var _ TranslationReviewService = (*TranslationReviewUseCase)(nil)
+
+// TranslationReviewUseCase holds translations for channels that require review.
+// A draft is posted to the channel's review channel and only reaches the
+// original thread once a reviewer approves it.
+type TranslationReviewUseCase struct {
+	repo        PendingTranslationRepository
+	channels    ReviewChannelLookup
+	messenger   ReviewMessenger
+	corrections CorrectionRecorder
+	logger      *zap.Logger
+}
+
+func NewTranslationReviewUseCase(repo PendingTranslationRepository, channels ReviewChannelLookup, messenger ReviewMessenger, logger *zap.Logger) *TranslationReviewUseCase {
+	return &TranslationReviewUseCase{
+		repo:      repo,
+		channels:  channels,
+		messenger: messenger,
+		logger:    logger,
+	}
+}
+
+// SetCorrectionRecorder stores every translation a reviewer corrects, so
+// corrections can feed glossary and translation memory suggestions
+func (ru *TranslationReviewUseCase) SetCorrectionRecorder(corrections CorrectionRecorder) {
+	ru.corrections = corrections
+}
+
+// Submit holds the draft for review if its channel has a review channel and
+// reports whether it did. On error the caller must not post the translation.
+func (ru *TranslationReviewUseCase) Submit(draft *model.PendingTranslation) (bool, error) {
+	config, err := ru.channels.GetChannelConfig(draft.ChannelID)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get channel config: %w", err)
+	}
+	if config == nil || config.ReviewChannelID == "" {
+		return false, nil
+	}
+
+	draft.ID = generateID()
+	draft.ReviewChannelID = config.ReviewChannelID
+	draft.Status = model.ReviewStatusPending
+	draft.CreatedAt = time.Now()
+
+	if err := ru.repo.Save(draft); err != nil {
+		return false, fmt.Errorf("failed to save pending translation: %w", err)
+	}
+
+	ts, err := ru.messenger.PostReviewRequest(draft)
+	if err != nil {
+		return false, fmt.Errorf("failed to post review request: %w", err)
+	}
+	draft.ReviewMessageTS = ts
+
+	if err := ru.repo.SetReviewMessage(draft.ID, ts); err != nil {
+		return false, fmt.Errorf("failed to save review message: %w", err)
+	}
+
+	ru.logger.Info("Translation held for review",
+		zap.String("id", draft.ID),
+		zap.String("channel_id", draft.ChannelID),
+		zap.String("review_channel_id", draft.ReviewChannelID))
+
+	return true, nil
+}
+
+// Approve posts the translation to the original thread
+func (ru *TranslationReviewUseCase) Approve(id, reviewerID string) (*model.PendingTranslation, error) {
+	return ru.approve(id, reviewerID, "")
+}
+
+// StartCorrection opens the correction modal for a pending translation
+func (ru *TranslationReviewUseCase) StartCorrection(id, triggerID string) error {
+	draft, err := ru.repo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to get pending translation: %w", err)
+	}
+	if draft.Status != model.ReviewStatusPending {
+		return model.NewBadRequestError("translation has already been reviewed")
+	}
+
+	if err := ru.messenger.OpenCorrectionModal(triggerID, draft); err != nil {
+		return fmt.Errorf("failed to open correction modal: %w", err)
+	}
+	return nil
+}
+
+// ApproveWithCorrection posts the reviewer's corrected text instead of the
+// machine translation and records the correction
+func (ru *TranslationReviewUseCase) ApproveWithCorrection(id, reviewerID, correctedText string) (*model.PendingTranslation, error) {
+	correctedText = strings.TrimSpace(correctedText)
+	if correctedText == "" {
+		return nil, model.NewValidationError("translation must not be empty")
+	}
+	return ru.approve(id, reviewerID, correctedText)
+}
+
+// approve posts the translation, or correctedText when set, to the original thread
+func (ru *TranslationReviewUseCase) approve(id, reviewerID, correctedText string) (*model.PendingTranslation, error) {
+	draft, err := ru.decide(id, reviewerID, model.ReviewStatusApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	machineText := draft.TranslatedText
+	if correctedText != "" {
+		draft.TranslatedText = correctedText
+	}
+
+	if err := ru.messenger.PostApprovedTranslation(draft); err != nil {
+		// Put the draft back up for review so it can be approved again
+		if revertErr := ru.repo.UpdateStatus(id, model.ReviewStatusApproved, model.ReviewStatusPending, "", nil); revertErr != nil {
+			ru.logger.Error("Failed to revert pending translation",
+				zap.String("id", id),
+				zap.Error(revertErr))
+		}
+		return nil, fmt.Errorf("failed to post approved translation: %w", err)
+	}
+
+	if correctedText != "" && correctedText != strings.TrimSpace(machineText) {
+		ru.recordCorrection(draft, machineText)
+	}
+
+	ru.updateReviewRequest(draft)
+	return draft, nil
+}
+
+// Reject discards the translation
+func (ru *TranslationReviewUseCase) Reject(id, reviewerID string) (*model.PendingTranslation, error) {
+	draft, err := ru.decide(id, reviewerID, model.ReviewStatusRejected)
+	if err != nil {
+		return nil, err
+	}
+
+	ru.updateReviewRequest(draft)
+	return draft, nil
+}
+
+// decide records a reviewer's decision on a pending translation
+func (ru *TranslationReviewUseCase) decide(id, reviewerID, status string) (*model.PendingTranslation, error) {
+	draft, err := ru.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending translation: %w", err)
+	}
+	if draft.Status != model.ReviewStatusPending {
+		return nil, model.NewBadRequestError("translation has already been reviewed")
+	}
+
+	reviewedAt := time.Now()
+	if err := ru.repo.UpdateStatus(id, model.ReviewStatusPending, status, reviewerID, &reviewedAt); err != nil {
+		return nil, fmt.Errorf("failed to update pending translation: %w", err)
+	}
+
+	draft.Status = status
+	draft.ReviewerID = reviewerID
+	draft.ReviewedAt = &reviewedAt
+
+	ru.logger.Info("Translation reviewed",
+		zap.String("id", id),
+		zap.String("channel_id", draft.ChannelID),
+		zap.String("reviewer_id", reviewerID),
+		zap.String("status", status))
+
+	return draft, nil
+}
+
+// recordCorrection stores the corrected pair. The translation is already
+// posted, so a failure here is only logged.
+func (ru *TranslationReviewUseCase) recordCorrection(draft *model.PendingTranslation, machineText string) {
+	if ru.corrections == nil {
+		return
+	}
+	err := ru.corrections.Record(&model.TranslationCorrection{
+		PendingTranslationID: draft.ID,
+		ChannelID:            draft.ChannelID,
+		SourceLanguage:       draft.SourceLanguage,
+		TargetLanguage:       draft.TargetLanguage,
+		SourceText:           draft.OriginalText,
+		MachineText:          machineText,
+		CorrectedText:        draft.TranslatedText,
+		ReviewerID:           draft.ReviewerID,
+	})
+	if err != nil {
+		ru.logger.Error("Failed to record translation correction",
+			zap.String("id", draft.ID),
+			zap.Error(err))
+	}
+}
+
+// updateReviewRequest shows the decision on the review message. The decision
+// is already stored, so a failure here is only logged.
+func (ru *TranslationReviewUseCase) updateReviewRequest(draft *model.PendingTranslation) {
+	if err := ru.messenger.UpdateReviewRequest(draft); err != nil {
+		ru.logger.Warn("Failed to update review message",
+			zap.String("id", draft.ID),
+			zap.String("review_channel_id", draft.ReviewChannelID),
+			zap.Error(err))
+	}
+}
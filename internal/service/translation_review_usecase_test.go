@@ -0,0 +1,219 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestTranslationReviewUseCase_Submit(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *model.ChannelConfig
+		configErr   error
+		mockSetup   func(*mocks.MockPendingTranslationRepository, *mocks.MockReviewMessenger)
+		expectHeld  bool
+		expectError bool
+	}{
+		{
+			name:       "channel without review channel",
+			config:     &model.ChannelConfig{ChannelID: "C1"},
+			mockSetup:  func(*mocks.MockPendingTranslationRepository, *mocks.MockReviewMessenger) {},
+			expectHeld: false,
+		},
+		{
+			name:       "channel not configured",
+			configErr:  model.NewNotFoundError("channel config not found"),
+			mockSetup:  func(*mocks.MockPendingTranslationRepository, *mocks.MockReviewMessenger) {},
+			expectHeld: false,
+		},
+		{
+			name:        "config lookup fails closed",
+			configErr:   errors.New("database down"),
+			mockSetup:   func(*mocks.MockPendingTranslationRepository, *mocks.MockReviewMessenger) {},
+			expectError: true,
+		},
+		{
+			name:   "held for review",
+			config: &model.ChannelConfig{ChannelID: "C1", ReviewChannelID: "CREVIEW"},
+			mockSetup: func(repo *mocks.MockPendingTranslationRepository, messenger *mocks.MockReviewMessenger) {
+				repo.EXPECT().Save(gomock.Any()).DoAndReturn(func(draft *model.PendingTranslation) error {
+					assert.Equal(t, "CREVIEW", draft.ReviewChannelID)
+					assert.Equal(t, model.ReviewStatusPending, draft.Status)
+					return nil
+				})
+				messenger.EXPECT().PostReviewRequest(gomock.Any()).Return("1700000000.000200", nil)
+				repo.EXPECT().SetReviewMessage(gomock.Any(), "1700000000.000200").Return(nil)
+			},
+			expectHeld: true,
+		},
+		{
+			name:   "review post fails",
+			config: &model.ChannelConfig{ChannelID: "C1", ReviewChannelID: "CREVIEW"},
+			mockSetup: func(repo *mocks.MockPendingTranslationRepository, messenger *mocks.MockReviewMessenger) {
+				repo.EXPECT().Save(gomock.Any()).Return(nil)
+				messenger.EXPECT().PostReviewRequest(gomock.Any()).Return("", errors.New("channel_not_found"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockPendingTranslationRepository(ctrl)
+			messenger := mocks.NewMockReviewMessenger(ctrl)
+			channels := mocks.NewMockChannelService(ctrl)
+			channels.EXPECT().GetChannelConfig("C1").Return(tt.config, tt.configErr)
+			tt.mockSetup(repo, messenger)
+
+			uc := NewTranslationReviewUseCase(repo, channels, messenger, zap.NewNop())
+			held, err := uc.Submit(&model.PendingTranslation{ChannelID: "C1", ThreadTS: "1700000000.000100", TranslatedText: "Xin chào"})
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectHeld, held)
+		})
+	}
+}
+
+func TestTranslationReviewUseCase_Approve(t *testing.T) {
+	pending := func() *model.PendingTranslation {
+		return &model.PendingTranslation{ID: "p1", ChannelID: "C1", ReviewChannelID: "CREVIEW", Status: model.ReviewStatusPending}
+	}
+
+	tests := []struct {
+		name        string
+		mockSetup   func(*mocks.MockPendingTranslationRepository, *mocks.MockReviewMessenger)
+		expectError bool
+	}{
+		{
+			name: "approved and posted",
+			mockSetup: func(repo *mocks.MockPendingTranslationRepository, messenger *mocks.MockReviewMessenger) {
+				repo.EXPECT().GetByID("p1").Return(pending(), nil)
+				repo.EXPECT().UpdateStatus("p1", model.ReviewStatusPending, model.ReviewStatusApproved, "U9", gomock.Any()).Return(nil)
+				messenger.EXPECT().PostApprovedTranslation(gomock.Any()).Return(nil)
+				messenger.EXPECT().UpdateReviewRequest(gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "already reviewed",
+			mockSetup: func(repo *mocks.MockPendingTranslationRepository, messenger *mocks.MockReviewMessenger) {
+				draft := pending()
+				draft.Status = model.ReviewStatusRejected
+				repo.EXPECT().GetByID("p1").Return(draft, nil)
+			},
+			expectError: true,
+		},
+		{
+			name: "concurrent review loses",
+			mockSetup: func(repo *mocks.MockPendingTranslationRepository, messenger *mocks.MockReviewMessenger) {
+				repo.EXPECT().GetByID("p1").Return(pending(), nil)
+				repo.EXPECT().UpdateStatus("p1", model.ReviewStatusPending, model.ReviewStatusApproved, "U9", gomock.Any()).
+					Return(model.NewBadRequestError("translation has already been reviewed"))
+			},
+			expectError: true,
+		},
+		{
+			name: "post fails reverts to pending",
+			mockSetup: func(repo *mocks.MockPendingTranslationRepository, messenger *mocks.MockReviewMessenger) {
+				repo.EXPECT().GetByID("p1").Return(pending(), nil)
+				repo.EXPECT().UpdateStatus("p1", model.ReviewStatusPending, model.ReviewStatusApproved, "U9", gomock.Any()).Return(nil)
+				messenger.EXPECT().PostApprovedTranslation(gomock.Any()).Return(errors.New("thread_not_found"))
+				repo.EXPECT().UpdateStatus("p1", model.ReviewStatusApproved, model.ReviewStatusPending, "", nil).Return(nil)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockPendingTranslationRepository(ctrl)
+			messenger := mocks.NewMockReviewMessenger(ctrl)
+			tt.mockSetup(repo, messenger)
+
+			uc := NewTranslationReviewUseCase(repo, mocks.NewMockChannelService(ctrl), messenger, zap.NewNop())
+			draft, err := uc.Approve("p1", "U9")
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, model.ReviewStatusApproved, draft.Status)
+			assert.Equal(t, "U9", draft.ReviewerID)
+		})
+	}
+}
+
+func TestTranslationReviewUseCase_Reject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockPendingTranslationRepository(ctrl)
+	messenger := mocks.NewMockReviewMessenger(ctrl)
+	repo.EXPECT().GetByID("p1").Return(&model.PendingTranslation{ID: "p1", Status: model.ReviewStatusPending}, nil)
+	repo.EXPECT().UpdateStatus("p1", model.ReviewStatusPending, model.ReviewStatusRejected, "U9", gomock.Any()).Return(nil)
+	// A failed review message update does not undo the decision
+	messenger.EXPECT().UpdateReviewRequest(gomock.Any()).Return(errors.New("message_not_found"))
+
+	uc := NewTranslationReviewUseCase(repo, mocks.NewMockChannelService(ctrl), messenger, zap.NewNop())
+	draft, err := uc.Reject("p1", "U9")
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.ReviewStatusRejected, draft.Status)
+}
+
+func TestTranslationReviewUseCase_ApproveWithCorrection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockPendingTranslationRepository(ctrl)
+	messenger := mocks.NewMockReviewMessenger(ctrl)
+	corrections := mocks.NewMockCorrectionRepository(ctrl)
+
+	repo.EXPECT().GetByID("p1").Return(&model.PendingTranslation{
+		ID:             "p1",
+		ChannelID:      "C1",
+		SourceLanguage: "English",
+		TargetLanguage: "Vietnamese",
+		OriginalText:   "Call the customer",
+		TranslatedText: "Gọi cho khách hàng",
+		Status:         model.ReviewStatusPending,
+	}, nil)
+	repo.EXPECT().UpdateStatus("p1", model.ReviewStatusPending, model.ReviewStatusApproved, "U9", gomock.Any()).Return(nil)
+	messenger.EXPECT().PostApprovedTranslation(gomock.Any()).DoAndReturn(func(draft *model.PendingTranslation) error {
+		assert.Equal(t, "Gọi cho đối tác", draft.TranslatedText)
+		return nil
+	})
+	corrections.EXPECT().Save(gomock.Any()).DoAndReturn(func(correction *model.TranslationCorrection) error {
+		assert.Equal(t, "p1", correction.PendingTranslationID)
+		assert.Equal(t, "Gọi cho khách hàng", correction.MachineText)
+		assert.Equal(t, "Gọi cho đối tác", correction.CorrectedText)
+		assert.Equal(t, "U9", correction.ReviewerID)
+		return nil
+	})
+	messenger.EXPECT().UpdateReviewRequest(gomock.Any()).Return(nil)
+
+	uc := NewTranslationReviewUseCase(repo, mocks.NewMockChannelService(ctrl), messenger, zap.NewNop())
+	uc.SetCorrectionRecorder(NewCorrectionUseCase(corrections))
+
+	_, err := uc.ApproveWithCorrection("p1", "U9", "  Gọi cho đối tác\n")
+	assert.NoError(t, err)
+
+	_, err = uc.ApproveWithCorrection("p1", "U9", "   ")
+	assert.Error(t, err)
+}
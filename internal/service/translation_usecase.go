@@ -1,17 +1,28 @@
 package service
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"math"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
 	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
 	"github.com/ntttrang/go-genai-slack-assistant/internal/middleware"
 	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/ai"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/eventbus"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/language"
 	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/security"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -20,23 +31,83 @@ type Translator interface {
 	DetectLanguage(text string) (string, error)
 }
 
+// ContextTranslator is implemented by translators whose calls can be aborted
+// through a context, e.g. when a per-message processing deadline expires.
+type ContextTranslator interface {
+	TranslateContext(ctx context.Context, text, sourceLanguage, targetLanguage string) (string, error)
+}
+
+// ProviderTranslator is implemented by translators that serve a call from one
+// of several AI providers, e.g. ai.ChainedProvider, and report which one did
+type ProviderTranslator interface {
+	TranslateWithProvider(ctx context.Context, text, sourceLanguage, targetLanguage string) (translated, provider string, err error)
+}
+
 // TranslationRepository defines the interface for translation persistence.
 // This interface is owned by the TranslationUseCase and defined where it's consumed.
 type TranslationRepository interface {
-	Save(translation *model.Translation) error
-	GetByHash(hash string) (*model.Translation, error)
-	GetByID(id string) (*model.Translation, error)
-	GetByChannelID(channelID string, limit int) ([]*model.Translation, error)
+	Save(ctx context.Context, translation *model.Translation) error
+	// GetByHash returns model.ErrNotFound, or nil and no error, when no
+	// translation has the hash
+	GetByHash(ctx context.Context, hash string) (*model.Translation, error)
+	GetByID(ctx context.Context, id string) (*model.Translation, error)
+	GetByChannelID(ctx context.Context, channelID string, limit int) ([]*model.Translation, error)
+}
+
+// PersistenceGuard reports whether the primary database accepts writes,
+// e.g. database.Failover
+type PersistenceGuard interface {
+	Writable() bool
+}
+
+// CanaryChannelLookup reports a channel's configuration, including its canary flag
+type CanaryChannelLookup interface {
+	GetChannelConfig(channelID string) (*model.ChannelConfig, error)
+}
+
+// Alerter delivers security alerts to an external receiver
+type Alerter interface {
+	Send(ctx context.Context, event string, data interface{}) error
 }
 
+// saveRetryDelays are the waits before each retry of a translation that
+// failed to save; the translation is served meanwhile and only cached
+var saveRetryDelays = []time.Duration{time.Second, 5 * time.Second, 30 * time.Second}
+
+// maxPendingSaveRetries bounds the translations waiting for a save retry, so
+// a long database outage cannot pile up goroutines
+const maxPendingSaveRetries = 100
+
+// Rollout variants reported in comparison metrics
+const (
+	VariantStable = "stable"
+	VariantCanary = "canary"
+)
+
 type TranslationUseCase struct {
 	logger             *zap.Logger
 	repo               TranslationRepository
+	uow                UnitOfWork
+	persistence        PersistenceGuard
+	replica            TranslationRepository
 	cache              Cache
 	translator         Translator
+	canaryTranslator   Translator
+	canaryChannels     CanaryChannelLookup
 	cacheTTL           int64
 	securityMiddleware *middleware.SecurityMiddleware
 	metrics            *metrics.Metrics
+	auditor            AuditService
+	events             *eventbus.Bus
+	prompts            PromptVersionSelector
+	generation         ai.GenerationConfig
+	workspaces         WorkspaceLookup
+	channels           ChannelConfigLookup
+	rightToLeft        bool
+	saveRetrySlots     chan struct{}
+	saveRetries        sync.WaitGroup
+	saveRetryDelays    []time.Duration
+	batcher            *translationBatcher
 }
 
 func NewTranslationUseCase(
@@ -48,7 +119,7 @@ func NewTranslationUseCase(
 	securityMiddleware *middleware.SecurityMiddleware,
 	metrics *metrics.Metrics,
 ) *TranslationUseCase {
-	return &TranslationUseCase{
+	tu := &TranslationUseCase{
 		logger:             logger,
 		repo:               repo,
 		cache:              cache,
@@ -56,82 +127,213 @@ func NewTranslationUseCase(
 		cacheTTL:           cacheTTL,
 		securityMiddleware: securityMiddleware,
 		metrics:            metrics,
+		events:             eventbus.New(logger),
+		generation:         ai.DefaultGenerationConfig(),
+		saveRetrySlots:     make(chan struct{}, maxPendingSaveRetries),
+		saveRetryDelays:    saveRetryDelays,
+	}
+	if metrics != nil {
+		tu.subscribeMetrics()
+	}
+	return tu
+}
+
+// SetUnitOfWork makes translation persistence run inside a database transaction.
+// Without it, writes go straight to the repository.
+func (tu *TranslationUseCase) SetUnitOfWork(uow UnitOfWork) {
+	tu.uow = uow
+}
+
+// SetFailover skips persistence while guard reports the primary database
+// read-only, and looks stored translations up in replica meanwhile, or not
+// at all when replica is nil. Translations are then only cached.
+func (tu *TranslationUseCase) SetFailover(guard PersistenceGuard, replica TranslationRepository) {
+	tu.persistence = guard
+	tu.replica = replica
+}
+
+// SetCanary routes channels flagged as canary to translator, which serves the
+// newest prompt/provider version. Other channels keep the stable translator.
+func (tu *TranslationUseCase) SetCanary(translator Translator, channels CanaryChannelLookup) {
+	tu.canaryTranslator = translator
+	tu.canaryChannels = channels
+}
+
+// SetAuditor records compromised translations, with the offending input,
+// in the audit log
+func (tu *TranslationUseCase) SetAuditor(auditor AuditService) {
+	tu.auditor = auditor
+}
+
+// UsageRecorder counts the translations served per channel, e.g. AnalyticsUseCase
+type UsageRecorder interface {
+	RecordTranslation(channelID string, result response.Translation) error
+}
+
+// PromptVersionSelector picks the translation prompt version, e.g. PromptDeploymentUseCase
+type PromptVersionSelector interface {
+	ActivePromptVersion() string
+}
+
+// SetPromptVersions translates regular channels with the prompt version
+// prompts selects; canary channels keep the canary provider's
+func (tu *TranslationUseCase) SetPromptVersions(prompts PromptVersionSelector) {
+	tu.prompts = prompts
+}
+
+// SetRightToLeftFormatting lays translations into right-to-left languages,
+// e.g. Arabic or Hebrew, out with Unicode directional marks, so mentions,
+// links and list markers do not flip the direction of their lines
+func (tu *TranslationUseCase) SetRightToLeftFormatting(enabled bool) {
+	tu.rightToLeft = enabled
+}
+
+// SetBatching translates the texts sent to the AI within window for the
+// same language pair in one call of at most maxSize texts, when the
+// translator can batch. Canary channels and activated prompt versions other
+// than the stable one are translated one at a time.
+func (tu *TranslationUseCase) SetBatching(window time.Duration, maxSize int) {
+	batcher, ok := tu.translator.(BatchTranslator)
+	if !ok {
+		tu.logger.Warn("The AI translator cannot batch translations, translating one at a time")
+		return
 	}
+	tu.batcher = newTranslationBatcher(tu.translator, batcher, window, maxSize, tu.logger, tu.metrics)
 }
 
 func (tu *TranslationUseCase) Translate(req request.Translation) (response.Translation, error) {
-	startTime := time.Now()
-	var success bool
-	var userID, channelID string
+	return tu.TranslateContext(context.Background(), req)
+}
 
-	// Record metrics at the end
-	defer func() {
-		duration := time.Since(startTime)
-		if tu.metrics != nil {
-			tu.metrics.RecordTranslationRequest(userID, channelID, duration, success)
-		}
-	}()
+// TranslateContext translates like Translate, aborting the AI call when ctx is done
+func (tu *TranslationUseCase) TranslateContext(ctx context.Context, req request.Translation) (response.Translation, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "translation.translate", trace.WithAttributes(
+		attribute.String("translation.source_language", req.SourceLanguage),
+		attribute.String("translation.target_language", req.TargetLanguage),
+		attribute.String("slack.channel_id", req.ChannelID),
+	))
+	start := time.Now()
+	result, err := tu.translate(ctx, req)
+	tu.publishResult(req, result, err, time.Since(start))
+	if err == nil {
+		span.SetAttributes(
+			attribute.String("translation.source", result.Source),
+			attribute.String("translation.provider", result.Provider),
+			attribute.String("translation.model", result.Model),
+		)
+	}
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tu *TranslationUseCase) translate(ctx context.Context, req request.Translation) (response.Translation, error) {
+	startTime := time.Now()
 
 	fmt.Println("Slack sent: ", req.Text)
 
-	// Extract user and channel IDs if available
-	userID = req.UserID
-	channelID = req.ChannelID
+	channelID := req.ChannelID
+
+	// Time each stage, in the reply's trace when there is one
+	trace := metrics.LatencyTraceFrom(ctx)
+	if trace == nil {
+		trace = metrics.NewLatencyTrace(startTime)
+		ctx = metrics.WithLatencyTrace(ctx, trace)
+	}
 
 	// 1. Extract and preserve formatting before validation
 	preserver := NewFormatPreserver()
 	textWithoutFormat := preserver.Extract(req.Text)
+	preserver.SetRightToLeft(tu.rightToLeft && model.IsRightToLeft(req.TargetLanguage))
 
-	// 2. Validate input
-	inputValidation, err := tu.securityMiddleware.ValidateInput(textWithoutFormat)
+	// 2. Validate input. Role markers only count at the start of a line, so
+	// detection sees the real line breaks rather than their placeholders.
+	inputValidation, err := tu.securityMiddleware.ValidateInput(channelID, preserver.restoreLineBreaks(textWithoutFormat))
 	if err != nil {
 		if tu.metrics != nil {
 			tu.metrics.RecordError("input_validation_failed")
 		}
+		tu.events.Publish(SecurityBlocked{Request: req, Reason: SecurityReasonInputRejected})
 		return response.Translation{}, fmt.Errorf("input validation failed: %w", err)
 	}
+	warnings := len(inputValidation.Warnings)
 
-	sanitizedText := inputValidation.SanitizedText
+	// done completes a translation with the metadata every result carries
+	done := func(result response.Translation) (response.Translation, error) {
+		result.OriginalText = req.Text
+		result.SourceLanguage = req.SourceLanguage
+		result.TargetLanguage = req.TargetLanguage
+		result.LatencyMS = time.Since(startTime).Milliseconds()
+		result.Confidence = translationConfidence(warnings)
+		tu.logger.Info("Translation served",
+			zap.String("translation_id", result.ID),
+			zap.String("provider", result.Provider),
+			zap.String("model", result.Model),
+			zap.String("prompt_version", result.PromptVersion),
+			zap.Any("generation", result.Generation),
+			zap.String("source", result.Source),
+			zap.Int64("latency_ms", result.LatencyMS),
+			zap.Int64("prompt_tokens", result.PromptTokens),
+			zap.Int64("output_tokens", result.OutputTokens),
+			zap.Float64("confidence", result.Confidence))
+		return result, nil
+	}
+
+	sanitizedText := tu.securityMiddleware.Sanitize(textWithoutFormat)
 
-	// 3. Generate hash with sanitized text (for caching)
-	hash := tu.generateHash(sanitizedText, req.SourceLanguage, req.TargetLanguage)
+	// 3. Generate hash with sanitized text (for caching). Canary results and
+	// those of an activated prompt version are cached separately, so a
+	// rollback never serves the translations of the version rolled back.
+	// Likewise for the results of overridden generation parameters.
+	translator, variant := tu.selectTranslator(channelID)
+	hashTarget := req.TargetLanguage
+	if variant == VariantCanary {
+		hashTarget += ":" + VariantCanary
+	} else if version := tu.activePromptVersion(); version != "" {
+		ctx = ai.WithPromptVersion(ctx, version)
+		if version != ai.StablePromptVersion {
+			hashTarget += ":" + version
+		}
+	}
+	generation := tu.generationFor(ctx, channelID)
+	ctx = ai.WithGeneration(ctx, generation)
+	if generation != ai.DefaultGenerationConfig() {
+		hashTarget += ":" + generation.String()
+	}
+	hash := tu.generateHash(sanitizedText, req.SourceLanguage, hashTarget)
 	cacheKey := fmt.Sprintf("translation:%s", hash)
 
 	// 4. Try to get from cache
-	cachedResult, err := tu.cache.Get(cacheKey)
+	cachedResult, err := tu.cacheGet(ctx, trace, cacheKey)
 	if err == nil && cachedResult != "" {
 		// Record cache hit
 		if tu.metrics != nil {
 			tu.metrics.RecordCacheHit()
 		}
 		// Restore formatting to cached result
-		restoredResult := preserver.Restore(cachedResult)
-		success = true
-		return response.Translation{
-			OriginalText:   req.Text,
-			TranslatedText: restoredResult,
-			SourceLanguage: req.SourceLanguage,
-			TargetLanguage: req.TargetLanguage,
-		}, nil
+		return done(response.Translation{
+			TranslatedText: preserver.Restore(cachedResult),
+			Source:         response.SourceCache,
+			Cached:         true,
+		})
 	}
 
 	// 5. Try to get from database
-	existingTranslation, err := tu.repo.GetByHash(hash)
-	if (err == nil && existingTranslation != nil) || (err != nil && err.Error() != "record not found") {
+	if existingTranslation := tu.lookupTranslation(ctx, trace, hash); existingTranslation != nil {
 		// Record cache hit (from DB)
 		if tu.metrics != nil {
 			tu.metrics.RecordCacheHit()
 		}
 		cachedTranslated := existingTranslation.TranslatedText
-		_ = tu.cache.Set(cacheKey, cachedTranslated, tu.cacheTTL)
-		restoredResult := preserver.Restore(cachedTranslated)
-		success = true
-		return response.Translation{
-			OriginalText:   req.Text,
-			TranslatedText: restoredResult,
-			SourceLanguage: req.SourceLanguage,
-			TargetLanguage: req.TargetLanguage,
-		}, nil
+		tu.cacheSet(ctx, trace, cacheKey, cachedTranslated)
+		return done(response.Translation{
+			ID:             existingTranslation.ID,
+			TranslatedText: preserver.Restore(cachedTranslated),
+			Provider:       existingTranslation.Provider,
+			PromptVersion:  existingTranslation.PromptVersion,
+			Generation:     existingTranslation.Generation,
+			Source:         response.SourceDatabase,
+			Cached:         true,
+		})
 	}
 
 	// Record cache miss - need to call AI
@@ -140,19 +342,49 @@ func (tu *TranslationUseCase) Translate(req request.Translation) (response.Trans
 	}
 
 	// 6. Call AI to translate with cleaned text (no formatting)
-	tu.logger.Info("[Start] Call to AI provider to translate")
-	translatedText, err := tu.translator.Translate(sanitizedText, req.SourceLanguage, req.TargetLanguage)
+	tu.logger.Info("[Start] Call to AI provider to translate", zap.String("variant", variant))
+	aiStart := time.Now()
+	usage := &ai.Usage{}
+	translatedText, provider, err := tu.callTranslator(ai.WithUsage(ctx, usage), translator, variant, sanitizedText, req.SourceLanguage, req.TargetLanguage)
+	trace.Add(metrics.StageAI, time.Since(aiStart))
+	if err != nil {
+		trace.Fail(metrics.StageAI)
+	}
+	if errors.Is(err, security.ErrCanaryLeaked) {
+		if tu.metrics != nil {
+			tu.metrics.RecordVariantTranslation(variant, time.Since(aiStart), false, true)
+		}
+		tu.reportCompromised(req, sanitizedText, variant)
+		return response.Translation{}, fmt.Errorf("translation failed: %w", err)
+	}
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = ctxErr
+		}
+		blocked := errors.Is(err, ai.ErrSafetyBlocked)
+		if blocked {
+			tu.logger.Warn("Translation blocked by AI safety filters",
+				zap.String("channel_id", channelID),
+				zap.String("variant", variant))
+		}
 		if tu.metrics != nil {
-			tu.metrics.RecordError("translation_failed")
+			tu.metrics.RecordError(translationErrorType(err))
+			if blocked {
+				tu.metrics.RecordSafetyBlock(channelID)
+			}
+			tu.metrics.RecordVariantTranslation(variant, time.Since(aiStart), false, false)
 		}
 		return response.Translation{}, fmt.Errorf("translation failed: %w", err)
 	}
-	tu.logger.Info("[End] Call to AI provider to translate")
+	tu.logger.Info("[End] Call to AI provider to translate", zap.String("provider", provider))
 
 	// 7. Validate output
 	outputValidation, err := tu.securityMiddleware.ValidateOutput(translatedText, sanitizedText)
+	if tu.metrics != nil {
+		tu.metrics.RecordVariantTranslation(variant, time.Since(aiStart), err == nil, err != nil)
+	}
 	if err != nil {
+		trace.Fail(metrics.StageAI)
 		if tu.metrics != nil {
 			tu.metrics.RecordError("output_validation_failed")
 		}
@@ -160,6 +392,7 @@ func (tu *TranslationUseCase) Translate(req request.Translation) (response.Trans
 	}
 
 	translatedText = outputValidation.CleanedText
+	warnings += len(outputValidation.Issues)
 
 	// 8. Restore formatting to translated text
 	restoredTranslatedText := preserver.Restore(translatedText)
@@ -172,28 +405,299 @@ func (tu *TranslationUseCase) Translate(req request.Translation) (response.Trans
 		TargetLanguage: req.TargetLanguage,
 		TranslatedText: translatedText,
 		Hash:           hash,
+		Provider:       provider,
+		PromptVersion:  usage.PromptVersion,
+		StageTimings:   stageTimings(trace),
+		FailedStage:    trace.FailedStage(),
+		Generation:     model.GenerationSettingsOf(generation),
 		CreatedAt:      time.Now(),
 		TTL:            tu.cacheTTL,
 	}
 
-	if err := tu.repo.Save(translation); err != nil {
-		return response.Translation{}, fmt.Errorf("failed to save translation: %w", err)
+	result := response.Translation{
+		TranslatedText: restoredTranslatedText,
+		Provider:       provider,
+		Model:          usage.Model,
+		PromptVersion:  usage.PromptVersion,
+		Generation:     model.GenerationSettingsOf(generation),
+		Source:         response.SourceAI,
+		PromptTokens:   usage.PromptTokens,
+		OutputTokens:   usage.OutputTokens,
+	}
+	if tu.persistenceAvailable() {
+		saveStart := time.Now()
+		err := tu.saveTranslation(ctx, translation)
+		trace.Add(metrics.StageDatabase, time.Since(saveStart))
+		if err != nil {
+			trace.Fail(metrics.StageDatabase)
+			translation.FailedStage = trace.FailedStage()
+			// The translation succeeded, so the user still gets it; the save
+			// is retried in the background and the result carries no ID
+			tu.logger.Error("Failed to save translation, retrying in the background",
+				zap.Error(err),
+				zap.String("translation_id", translation.ID))
+			if tu.metrics != nil {
+				tu.metrics.RecordError("persistence_failed")
+			}
+			tu.retrySave(translation)
+		} else {
+			result.ID = translation.ID
+		}
+	} else if tu.metrics != nil {
+		tu.metrics.RecordError("persistence_skipped")
 	}
 
 	// 10. Store in cache (without formatting)
-	_ = tu.cache.Set(cacheKey, translatedText, tu.cacheTTL)
+	tu.cacheSet(ctx, trace, cacheKey, translatedText)
 
-	// Mark as successful
-	success = true
+	return done(result)
+}
+
+// confidencePenalty is how much each security warning lowers a translation's confidence
+const confidencePenalty = 0.25
+
+// translationConfidence rates a translation from the number of warnings its
+// input and output raised in the security checks
+func translationConfidence(warnings int) float64 {
+	return math.Max(0, 1-confidencePenalty*float64(warnings))
+}
+
+// GetTranslation returns a stored translation by ID, with the metadata
+// recorded with it
+func (tu *TranslationUseCase) GetTranslation(ctx context.Context, id string) (response.Translation, error) {
+	translation, err := tu.repo.GetByID(ctx, id)
+	if err != nil {
+		return response.Translation{}, fmt.Errorf("failed to get translation: %w", err)
+	}
+	if translation == nil {
+		return response.Translation{}, model.NewNotFoundError("translation not found")
+	}
 
 	return response.Translation{
-		OriginalText:   req.Text,
-		TranslatedText: restoredTranslatedText,
-		SourceLanguage: req.SourceLanguage,
-		TargetLanguage: req.TargetLanguage,
+		ID:             translation.ID,
+		OriginalText:   translation.SourceText,
+		TranslatedText: translation.TranslatedText,
+		SourceLanguage: translation.SourceLanguage,
+		TargetLanguage: translation.TargetLanguage,
+		Provider:       translation.Provider,
+		PromptVersion:  translation.PromptVersion,
+		Source:         response.SourceDatabase,
+		Cached:         true,
 	}, nil
 }
 
+// reportCompromised handles a translation whose output leaked the prompt
+// canary: the reply is already blocked by the caller; here the incident is
+// logged, counted, written to the audit log with the offending input and
+// published for the alerter.
+func (tu *TranslationUseCase) reportCompromised(req request.Translation, input, variant string) {
+	tu.logger.Error("Translation compromised: model output contained the prompt canary token",
+		zap.String("channel_id", req.ChannelID),
+		zap.String("user_id", req.UserID),
+		zap.String("variant", variant))
+	if tu.metrics != nil {
+		tu.metrics.RecordError("translation_compromised")
+	}
+
+	blocked := SecurityBlocked{Request: req, Reason: SecurityReasonCanaryLeaked, Input: input, Variant: variant}
+
+	if tu.auditor != nil {
+		err := tu.auditor.Record(model.AuditEntry{
+			Actor:        model.AuditActorSystem,
+			Action:       model.AuditActionCompromised,
+			ResourceType: model.AuditResourceTranslation,
+			ResourceID:   req.ChannelID,
+			After:        compromisedIncident(blocked),
+		})
+		if err != nil {
+			tu.logger.Error("Failed to record compromised translation", zap.Error(err))
+		}
+	}
+
+	tu.events.Publish(blocked)
+}
+
+// persistenceAvailable reports whether translations can be saved
+func (tu *TranslationUseCase) persistenceAvailable() bool {
+	return tu.persistence == nil || tu.persistence.Writable()
+}
+
+// lookupTranslation returns the stored translation with hash, from the
+// replica while the primary database is read-only, or nil on a miss. A failed
+// lookup is logged and treated as a miss, so the translation is served by the
+// AI instead.
+func (tu *TranslationUseCase) lookupTranslation(ctx context.Context, trace *metrics.LatencyTrace, hash string) *model.Translation {
+	repo := tu.repo
+	if !tu.persistenceAvailable() {
+		if tu.replica == nil {
+			return nil
+		}
+		repo = tu.replica
+	}
+
+	start := time.Now()
+	translation, err := repo.GetByHash(ctx, hash)
+	trace.Add(metrics.StageDatabase, time.Since(start))
+	if model.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		trace.Fail(metrics.StageDatabase)
+		tu.logger.Warn("Failed to look up stored translation", zap.Error(err))
+		if tu.metrics != nil {
+			tu.metrics.RecordError("translation_lookup_failed")
+		}
+		return nil
+	}
+	return translation
+}
+
+// cacheGet reads key from the cache, timing it in trace
+func (tu *TranslationUseCase) cacheGet(ctx context.Context, trace *metrics.LatencyTrace, key string) (string, error) {
+	start := time.Now()
+	value, err := tu.cache.Get(ctx, key)
+	trace.Add(metrics.StageCache, time.Since(start))
+	return value, err
+}
+
+// cacheSet caches a translation, timing it in trace. A failed write only
+// costs a later cache miss, so it is recorded in trace but not returned.
+func (tu *TranslationUseCase) cacheSet(ctx context.Context, trace *metrics.LatencyTrace, key, value string) {
+	start := time.Now()
+	if err := tu.cache.Set(ctx, key, value, tu.cacheTTL); err != nil {
+		trace.Fail(metrics.StageCache)
+	}
+	trace.Add(metrics.StageCache, time.Since(start))
+}
+
+// stageTimings returns the time trace spent per stage, in milliseconds
+func stageTimings(trace *metrics.LatencyTrace) model.StageTimings {
+	stages := trace.Stages()
+	timings := make(model.StageTimings, len(stages))
+	for stage, d := range stages {
+		timings[stage] = d.Milliseconds()
+	}
+	return timings
+}
+
+// saveTranslation persists the translation, atomically with any other writes
+// composed into the same unit of work.
+func (tu *TranslationUseCase) saveTranslation(ctx context.Context, translation *model.Translation) error {
+	if tu.uow == nil {
+		return tu.repo.Save(ctx, translation)
+	}
+	return tu.uow.Do(ctx, func(repos TxRepositories) error {
+		return repos.Translations().Save(ctx, translation)
+	})
+}
+
+// retrySave saves translation again in the background, waiting
+// saveRetryDelays between attempts. It gives up when the retries are
+// exhausted, the primary database turns read-only or too many saves are
+// already waiting.
+func (tu *TranslationUseCase) retrySave(translation *model.Translation) {
+	select {
+	case tu.saveRetrySlots <- struct{}{}:
+	default:
+		tu.logger.Warn("Too many translations waiting to be saved, dropping",
+			zap.String("translation_id", translation.ID))
+		if tu.metrics != nil {
+			tu.metrics.RecordError("persistence_retry_dropped")
+		}
+		return
+	}
+
+	tu.saveRetries.Add(1)
+	go func() {
+		defer tu.saveRetries.Done()
+		defer func() { <-tu.saveRetrySlots }()
+
+		var err error
+		for attempt, delay := range tu.saveRetryDelays {
+			time.Sleep(delay)
+			if !tu.persistenceAvailable() {
+				if tu.metrics != nil {
+					tu.metrics.RecordError("persistence_skipped")
+				}
+				return
+			}
+			if err = tu.saveTranslation(context.Background(), translation); err == nil {
+				tu.logger.Info("Saved translation on retry",
+					zap.String("translation_id", translation.ID),
+					zap.Int("attempt", attempt+1))
+				return
+			}
+		}
+
+		tu.logger.Error("Giving up saving translation",
+			zap.Error(err),
+			zap.String("translation_id", translation.ID))
+		if tu.metrics != nil {
+			tu.metrics.RecordError("persistence_retry_failed")
+		}
+	}()
+}
+
+// translationErrorType names a failed AI call in the error metrics by its
+// provider error category, e.g. "quota_exceeded" or "safety_blocked"
+func translationErrorType(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return string(ai.CategoryTimeout)
+	}
+	if category := ai.CategoryOf(err); category != "" && category != ai.CategoryUnknown {
+		return string(category)
+	}
+	return "translation_failed"
+}
+
+// callTranslator translates text with translator, in a batch when batching
+// is enabled for the stable translator and prompt
+func (tu *TranslationUseCase) callTranslator(ctx context.Context, translator Translator, variant, text, sourceLanguage, targetLanguage string) (string, string, error) {
+	if tu.batcher != nil && variant == VariantStable {
+		if version := ai.PromptVersionFrom(ctx, ""); version == "" || version == ai.StablePromptVersion {
+			return tu.batcher.translate(ctx, text, sourceLanguage, targetLanguage)
+		}
+	}
+	return translateWithContext(ctx, translator, text, sourceLanguage, targetLanguage)
+}
+
+// translateWithContext uses the context-aware call when the translator
+// supports it. The provider that served the call is empty unless the
+// translator reports it.
+func translateWithContext(ctx context.Context, translator Translator, text, sourceLanguage, targetLanguage string) (string, string, error) {
+	if pt, ok := translator.(ProviderTranslator); ok {
+		return pt.TranslateWithProvider(ctx, text, sourceLanguage, targetLanguage)
+	}
+	if ct, ok := translator.(ContextTranslator); ok {
+		translated, err := ct.TranslateContext(ctx, text, sourceLanguage, targetLanguage)
+		return translated, "", err
+	}
+	translated, err := translator.Translate(text, sourceLanguage, targetLanguage)
+	return translated, "", err
+}
+
+func (tu *TranslationUseCase) activePromptVersion() string {
+	if tu.prompts == nil {
+		return ""
+	}
+	return tu.prompts.ActivePromptVersion()
+}
+
+// selectTranslator picks the canary translator for channels flagged as canary.
+// Any lookup failure falls back to the stable translator.
+func (tu *TranslationUseCase) selectTranslator(channelID string) (Translator, string) {
+	if tu.canaryTranslator == nil || tu.canaryChannels == nil || channelID == "" {
+		return tu.translator, VariantStable
+	}
+
+	config, err := tu.canaryChannels.GetChannelConfig(channelID)
+	if err != nil || config == nil || !config.Canary {
+		return tu.translator, VariantStable
+	}
+
+	return tu.canaryTranslator, VariantCanary
+}
+
 func (tu *TranslationUseCase) generateHash(text, sourceLang, targetLang string) string {
 	h := sha256.New()
 	h.Write([]byte(text + sourceLang + targetLang))
@@ -204,7 +708,16 @@ func generateID() string {
 	return fmt.Sprintf("%d", time.Now().UnixNano())
 }
 
+// DetectLanguage returns the name of the language of text. Text in a script
+// that tells its language, e.g. Hangul or Thai, is detected locally without
+// asking the AI.
 func (tu *TranslationUseCase) DetectLanguage(text string) (string, error) {
+	if code, ok := language.DetectScript(text); ok {
+		if name, ok := model.LanguageName(code); ok {
+			return name, nil
+		}
+	}
+
 	langCode, err := tu.translator.DetectLanguage(text)
 	if err != nil {
 		return "", fmt.Errorf("language detection failed: %w", err)
@@ -219,10 +732,21 @@ func normalizeLanguageCode(code string) string {
 		return "English"
 	case "vi", "VI", "vietnamese", "vie":
 		return "Vietnamese"
+	case "ja", "JA", "japanese", "jpn", "日本語":
+		return "Japanese"
+	case "ko", "KO", "korean", "kor", "한국어":
+		return "Korean"
+	case "th", "TH", "thai", "tha", "ไทย":
+		return "Thai"
+	case "zh", "ZH", "zh-Hans", "zh-CN", "zh-SG", "chinese", "zho", "中文":
+		return "Chinese (Simplified)"
+	case "zh-Hant", "zh-TW", "zh-HK", "zh-MO":
+		return "Chinese (Traditional)"
+	case "ar", "AR", "arabic", "ara", "العربية":
+		return "Arabic"
+	case "he", "HE", "iw", "hebrew", "heb", "עברית":
+		return "Hebrew"
 	default:
-		if code == "English" || code == "Vietnamese" {
-			return code
-		}
 		return code
 	}
 }
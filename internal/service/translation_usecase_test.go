@@ -1,16 +1,24 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
 	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/response"
 	"github.com/ntttrang/go-genai-slack-assistant/internal/middleware"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
 	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/ai"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/eventbus"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
 	"github.com/ntttrang/go-genai-slack-assistant/pkg/security"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -40,7 +48,7 @@ func TestTranslationUseCase_Translate(t *testing.T) {
 			},
 			cacheTTL: 86400,
 			setupMocks: func(cache *mocks.MockCache, repo *mocks.MockTranslationRepository, translator *mocks.MockTranslator) {
-				cache.EXPECT().Get(gomock.Any()).Return("Hola", nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("Hola", nil)
 			},
 			expectedTranslated: "Hola",
 			expectedError:      false,
@@ -49,6 +57,10 @@ func TestTranslationUseCase_Translate(t *testing.T) {
 				assert.Equal(t, "Hello", resp.OriginalText)
 				assert.Equal(t, "en", resp.SourceLanguage)
 				assert.Equal(t, "es", resp.TargetLanguage)
+				assert.True(t, resp.Cached)
+				assert.Equal(t, response.SourceCache, resp.Source)
+				assert.Empty(t, resp.ID)
+				assert.Equal(t, 1.0, resp.Confidence)
 			},
 		},
 		{
@@ -60,11 +72,11 @@ func TestTranslationUseCase_Translate(t *testing.T) {
 			},
 			cacheTTL: 3600,
 			setupMocks: func(cache *mocks.MockCache, repo *mocks.MockTranslationRepository, translator *mocks.MockTranslator) {
-				cache.EXPECT().Get(gomock.Any()).Return("", errors.New("cache miss"))
-				repo.EXPECT().GetByHash(gomock.Any()).Return(nil, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", errors.New("cache miss"))
+				repo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, nil)
 				translator.EXPECT().Translate("Hello", "en", "es").Return("Hola", nil)
-				repo.EXPECT().Save(gomock.Any()).Return(nil)
-				cache.EXPECT().Set(gomock.Any(), "Hola", int64(3600)).Return(nil)
+				repo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+				cache.EXPECT().Set(gomock.Any(), gomock.Any(), "Hola", int64(3600)).Return(nil)
 			},
 			expectedTranslated: "Hola",
 			expectedError:      false,
@@ -73,6 +85,9 @@ func TestTranslationUseCase_Translate(t *testing.T) {
 				assert.Equal(t, "Hello", resp.OriginalText)
 				assert.Equal(t, "en", resp.SourceLanguage)
 				assert.Equal(t, "es", resp.TargetLanguage)
+				assert.False(t, resp.Cached)
+				assert.Equal(t, response.SourceAI, resp.Source)
+				assert.NotEmpty(t, resp.ID)
 			},
 		},
 		{
@@ -84,11 +99,11 @@ func TestTranslationUseCase_Translate(t *testing.T) {
 			},
 			cacheTTL: 86400,
 			setupMocks: func(cache *mocks.MockCache, repo *mocks.MockTranslationRepository, translator *mocks.MockTranslator) {
-				cache.EXPECT().Get(gomock.Any()).Return("", errors.New("record not found"))
-				repo.EXPECT().GetByHash(gomock.Any()).Return(nil, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", errors.New("record not found"))
+				repo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, nil)
 				translator.EXPECT().Translate("Hello", "en", "vi").Return("Xin chào", nil)
-				repo.EXPECT().Save(gomock.Any()).Return(nil)
-				cache.EXPECT().Set(gomock.Any(), "Xin chào", int64(86400)).Return(nil)
+				repo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+				cache.EXPECT().Set(gomock.Any(), gomock.Any(), "Xin chào", int64(86400)).Return(nil)
 			},
 			expectedTranslated: "Xin chào",
 			expectedError:      false,
@@ -105,8 +120,8 @@ func TestTranslationUseCase_Translate(t *testing.T) {
 			},
 			cacheTTL: 86400,
 			setupMocks: func(cache *mocks.MockCache, repo *mocks.MockTranslationRepository, translator *mocks.MockTranslator) {
-				cache.EXPECT().Get(gomock.Any()).Return("", errors.New("record not found"))
-				repo.EXPECT().GetByHash(gomock.Any()).Return(nil, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", errors.New("record not found"))
+				repo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, nil)
 				translator.EXPECT().Translate(gomock.Any(), gomock.Any(), gomock.Any()).Return("", errors.New("API error"))
 			},
 			expectedError: true,
@@ -120,7 +135,7 @@ func TestTranslationUseCase_Translate(t *testing.T) {
 			},
 			cacheTTL: 86400,
 			setupMocks: func(cache *mocks.MockCache, repo *mocks.MockTranslationRepository, translator *mocks.MockTranslator) {
-				cache.EXPECT().Get(gomock.Any()).Return("Adiós", nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("Adiós", nil)
 			},
 			expectedTranslated: "Adiós",
 			expectedError:      false,
@@ -170,6 +185,8 @@ func TestTranslationUseCase_DetectLanguage(t *testing.T) {
 		inputText        string
 		mockDetectedCode string
 		mockError        error
+		// local is detected from its script, without asking the translator
+		local            bool
 		expectedLanguage string
 		expectError      bool
 	}{
@@ -189,6 +206,50 @@ func TestTranslationUseCase_DetectLanguage(t *testing.T) {
 			expectedLanguage: "Vietnamese",
 			expectError:      false,
 		},
+		{
+			name:             "detect Japanese by script",
+			inputText:        "こんにちは、元気ですか",
+			local:            true,
+			expectedLanguage: "Japanese",
+		},
+		{
+			name:             "detect Japanese by ISO 639-2 code",
+			inputText:        "Konnichiwa, ogenki desu ka",
+			mockDetectedCode: "jpn",
+			mockError:        nil,
+			expectedLanguage: "Japanese",
+			expectError:      false,
+		},
+		{
+			name:             "detect Korean by script",
+			inputText:        "회의는 3시에 시작합니다",
+			local:            true,
+			expectedLanguage: "Korean",
+		},
+		{
+			name:             "detect Thai by script",
+			inputText:        "ประชุมเริ่มบ่ายสามโมง",
+			local:            true,
+			expectedLanguage: "Thai",
+		},
+		{
+			name:             "detect Simplified Chinese by script",
+			inputText:        "我们下午三点开会",
+			local:            true,
+			expectedLanguage: "Chinese (Simplified)",
+		},
+		{
+			name:             "detect Traditional Chinese by script",
+			inputText:        "我們下午三點開會",
+			local:            true,
+			expectedLanguage: "Chinese (Traditional)",
+		},
+		{
+			name:             "detect Traditional Chinese by code",
+			inputText:        "Women xiawu sandian kaihui",
+			mockDetectedCode: "zh-TW",
+			expectedLanguage: "Chinese (Traditional)",
+		},
 		{
 			name:             "detect Spanish",
 			inputText:        "Hola mundo",
@@ -224,7 +285,9 @@ func TestTranslationUseCase_DetectLanguage(t *testing.T) {
 			mockCache := mocks.NewMockCache(ctrl)
 			mockTranslator := mocks.NewMockTranslator(ctrl)
 
-			mockTranslator.EXPECT().DetectLanguage(tt.inputText).Return(tt.mockDetectedCode, tt.mockError)
+			if !tt.local {
+				mockTranslator.EXPECT().DetectLanguage(tt.inputText).Return(tt.mockDetectedCode, tt.mockError)
+			}
 
 			securityMiddleware := setupSecurityMiddleware()
 			logger := zap.NewNop()
@@ -260,3 +323,817 @@ func TestTranslationUseCase_ImplementsInterface(t *testing.T) {
 	var _ TranslationService = useCase
 	assert.NotNil(t, useCase)
 }
+
+// fakeUnitOfWork runs fn against the given repository and records commits
+type fakeUnitOfWork struct {
+	repo      TranslationRepository
+	committed int
+}
+
+func (f *fakeUnitOfWork) Do(ctx context.Context, fn func(repos TxRepositories) error) error {
+	if err := fn(f); err != nil {
+		return err
+	}
+	f.committed++
+	return nil
+}
+
+func (f *fakeUnitOfWork) Translations() TranslationRepository {
+	return f.repo
+}
+
+func (f *fakeUnitOfWork) Channels() ChannelRepository {
+	return nil
+}
+
+func TestTranslationUseCase_TranslateWithUnitOfWork(t *testing.T) {
+	tests := []struct {
+		name            string
+		saveErrs        []error
+		expectID        bool
+		expectCommitted int
+		expectMetrics   map[string]int64
+	}{
+		{
+			name:            "save committed in transaction",
+			saveErrs:        []error{nil},
+			expectID:        true,
+			expectCommitted: 1,
+			expectMetrics:   map[string]int64{},
+		},
+		{
+			name:            "failed save is retried in the background",
+			saveErrs:        []error{errors.New("insert failed"), nil},
+			expectID:        false,
+			expectCommitted: 1,
+			expectMetrics:   map[string]int64{"persistence_failed": 1},
+		},
+		{
+			name:            "retries give up after the last attempt",
+			saveErrs:        []error{errors.New("insert failed"), errors.New("insert failed"), errors.New("insert failed")},
+			expectID:        false,
+			expectCommitted: 0,
+			expectMetrics:   map[string]int64{"persistence_failed": 1, "persistence_retry_failed": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockTranslationRepository(ctrl)
+			mockCache := mocks.NewMockCache(ctrl)
+			mockTranslator := mocks.NewMockTranslator(ctrl)
+			txRepo := mocks.NewMockTranslationRepository(ctrl)
+
+			mockCache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", errors.New("cache miss"))
+			mockRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, nil)
+			mockTranslator.EXPECT().Translate("Hello", "en", "vi").Return("Xin chào", nil)
+			for _, saveErr := range tt.saveErrs {
+				txRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(saveErr)
+			}
+			mockCache.EXPECT().Set(gomock.Any(), gomock.Any(), "Xin chào", int64(3600)).Return(nil)
+
+			uow := &fakeUnitOfWork{repo: txRepo}
+			m := metrics.NewMetrics()
+			useCase := NewTranslationUseCase(zap.NewNop(), mockRepo, mockCache, mockTranslator, 3600, setupSecurityMiddleware(), m)
+			useCase.SetUnitOfWork(uow)
+			useCase.saveRetryDelays = []time.Duration{0, 0}
+
+			result, err := useCase.Translate(request.Translation{
+				Text:           "Hello",
+				SourceLanguage: "en",
+				TargetLanguage: "vi",
+			})
+			useCase.saveRetries.Wait()
+
+			require.NoError(t, err, "a failed save still serves the translation")
+			assert.Equal(t, "Xin chào", result.TranslatedText)
+			assert.Equal(t, tt.expectID, result.ID != "")
+			assert.Equal(t, tt.expectCommitted, uow.committed)
+			for errorType, count := range tt.expectMetrics {
+				assert.Equal(t, count, m.ErrorsByType[errorType], errorType)
+			}
+		})
+	}
+}
+
+func TestTranslationUseCase_TranslateCanaryRouting(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        *model.ChannelConfig
+		lookupErr     error
+		expectVariant string
+	}{
+		{
+			name:          "canary channel uses canary translator",
+			config:        &model.ChannelConfig{ChannelID: "C1", Canary: true},
+			expectVariant: VariantCanary,
+		},
+		{
+			name:          "regular channel stays on stable",
+			config:        &model.ChannelConfig{ChannelID: "C1", Canary: false},
+			expectVariant: VariantStable,
+		},
+		{
+			name:          "lookup failure falls back to stable",
+			lookupErr:     errors.New("db down"),
+			expectVariant: VariantStable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockTranslationRepository(ctrl)
+			mockCache := mocks.NewMockCache(ctrl)
+			stable := mocks.NewMockTranslator(ctrl)
+			canary := mocks.NewMockTranslator(ctrl)
+			channels := mocks.NewMockChannelService(ctrl)
+
+			channels.EXPECT().GetChannelConfig("C1").Return(tt.config, tt.lookupErr)
+			mockCache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", errors.New("cache miss"))
+			mockRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, nil)
+			if tt.expectVariant == VariantCanary {
+				canary.EXPECT().Translate("Hello", "en", "vi").Return("Xin chào", nil)
+			} else {
+				stable.EXPECT().Translate("Hello", "en", "vi").Return("Xin chào", nil)
+			}
+			mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+			mockCache.EXPECT().Set(gomock.Any(), gomock.Any(), "Xin chào", int64(3600)).Return(nil)
+
+			m := metrics.NewMetrics()
+			useCase := NewTranslationUseCase(zap.NewNop(), mockRepo, mockCache, stable, 3600, setupSecurityMiddleware(), m)
+			useCase.SetCanary(canary, channels)
+
+			resp, err := useCase.Translate(request.Translation{
+				Text:           "Hello",
+				SourceLanguage: "en",
+				TargetLanguage: "vi",
+				ChannelID:      "C1",
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, "Xin chào", resp.TranslatedText)
+			assert.Equal(t, int64(1), m.Variants[tt.expectVariant].Requests)
+			assert.Len(t, m.Variants, 1)
+		})
+	}
+}
+
+// versionTranslator translates with the prompt version selected by its context
+type versionTranslator struct {
+	*mocks.MockTranslator
+}
+
+func (v versionTranslator) TranslateContext(ctx context.Context, text, sourceLanguage, targetLanguage string) (string, error) {
+	ai.UsageFrom(ctx).Record("test-model", ai.PromptVersionFrom(ctx, ai.StablePromptVersion), 10, 5)
+	return "Xin chào", nil
+}
+
+type fixedPromptVersion string
+
+func (f fixedPromptVersion) ActivePromptVersion() string {
+	return string(f)
+}
+
+func TestTranslationUseCase_TranslateActivePromptVersion(t *testing.T) {
+	var cacheKeys []string
+	for _, version := range []string{"", ai.StablePromptVersion, ai.LatestPromptVersion} {
+		ctrl := gomock.NewController(t)
+
+		mockRepo := mocks.NewMockTranslationRepository(ctrl)
+		mockCache := mocks.NewMockCache(ctrl)
+		translator := versionTranslator{MockTranslator: mocks.NewMockTranslator(ctrl)}
+
+		mockCache.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key string) (string, error) {
+			cacheKeys = append(cacheKeys, key)
+			return "", errors.New("cache miss")
+		})
+		mockRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, nil)
+		var saved *model.Translation
+		mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, translation *model.Translation) error {
+			saved = translation
+			return nil
+		})
+		mockCache.EXPECT().Set(gomock.Any(), gomock.Any(), "Xin chào", int64(3600)).Return(nil)
+
+		useCase := NewTranslationUseCase(zap.NewNop(), mockRepo, mockCache, translator, 3600, setupSecurityMiddleware(), nil)
+		useCase.SetPromptVersions(fixedPromptVersion(version))
+
+		resp, err := useCase.Translate(request.Translation{
+			Text:           "Hello",
+			SourceLanguage: "en",
+			TargetLanguage: "vi",
+			ChannelID:      "C1",
+		})
+		require.NoError(t, err)
+
+		expected := version
+		if expected == "" {
+			expected = ai.StablePromptVersion
+		}
+		assert.Equal(t, expected, resp.PromptVersion)
+		require.NotNil(t, saved)
+		assert.Equal(t, expected, saved.PromptVersion)
+		ctrl.Finish()
+	}
+
+	// Only translations of an activated version other than the stable one
+	// are cached apart
+	assert.Equal(t, cacheKeys[0], cacheKeys[1])
+	assert.NotEqual(t, cacheKeys[0], cacheKeys[2])
+}
+
+// generationTranslator records the generation parameters selected by its context
+type generationTranslator struct {
+	*mocks.MockTranslator
+	generation *ai.GenerationConfig
+}
+
+func (g generationTranslator) TranslateContext(ctx context.Context, text, sourceLanguage, targetLanguage string) (string, error) {
+	*g.generation = ai.GenerationFrom(ctx)
+	return "Xin chào", nil
+}
+
+type fakeWorkspaceLookup struct {
+	workspace *model.Workspace
+	err       error
+}
+
+func (f fakeWorkspaceLookup) GetWorkspace(teamID string) (*model.Workspace, error) {
+	return f.workspace, f.err
+}
+
+type fakeChannelConfigLookup struct {
+	config *model.ChannelConfig
+}
+
+func (f fakeChannelConfigLookup) GetChannelConfig(channelID string) (*model.ChannelConfig, error) {
+	return f.config, nil
+}
+
+func TestTranslationUseCase_TranslateGenerationOverrides(t *testing.T) {
+	warm, creative, short := 0.7, 1.2, 256
+	flash := "gemini-2.5-flash"
+	tests := []struct {
+		name       string
+		workspaces fakeWorkspaceLookup
+		channel    *model.ChannelConfig
+		want       ai.GenerationConfig
+	}{
+		{
+			name: "defaults",
+			want: ai.DefaultGenerationConfig(),
+		},
+		{
+			name:       "workspace overrides the defaults",
+			workspaces: fakeWorkspaceLookup{workspace: &model.Workspace{Generation: model.GenerationSettings{Temperature: &warm, MaxOutputTokens: &short}}},
+			want:       ai.GenerationConfig{Temperature: warm, TopP: ai.DefaultTopP, MaxOutputTokens: short},
+		},
+		{
+			name:       "channel overrides the workspace",
+			workspaces: fakeWorkspaceLookup{workspace: &model.Workspace{Generation: model.GenerationSettings{Temperature: &warm, MaxOutputTokens: &short}}},
+			channel:    &model.ChannelConfig{ChannelID: "C1", Generation: model.GenerationSettings{Temperature: &creative}},
+			want:       ai.GenerationConfig{Temperature: creative, TopP: ai.DefaultTopP, MaxOutputTokens: short},
+		},
+		{
+			name:       "channel picks a model",
+			workspaces: fakeWorkspaceLookup{workspace: &model.Workspace{Generation: model.GenerationSettings{Temperature: &warm}}},
+			channel:    &model.ChannelConfig{ChannelID: "C1", Generation: model.GenerationSettings{Model: &flash}},
+			want:       ai.GenerationConfig{Temperature: warm, TopP: ai.DefaultTopP, MaxOutputTokens: ai.DefaultGenerationConfig().MaxOutputTokens, Model: flash},
+		},
+		{
+			name:       "workspace lookup failure keeps the defaults",
+			workspaces: fakeWorkspaceLookup{err: errors.New("db down")},
+			want:       ai.DefaultGenerationConfig(),
+		},
+	}
+
+	var defaultKey string
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockTranslationRepository(ctrl)
+			mockCache := mocks.NewMockCache(ctrl)
+			var used ai.GenerationConfig
+			translator := generationTranslator{MockTranslator: mocks.NewMockTranslator(ctrl), generation: &used}
+
+			var cacheKey string
+			mockCache.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key string) (string, error) {
+				cacheKey = key
+				return "", errors.New("cache miss")
+			})
+			mockRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, nil)
+			var saved *model.Translation
+			mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, translation *model.Translation) error {
+				saved = translation
+				return nil
+			})
+			mockCache.EXPECT().Set(gomock.Any(), gomock.Any(), "Xin chào", int64(3600)).Return(nil)
+
+			useCase := NewTranslationUseCase(zap.NewNop(), mockRepo, mockCache, translator, 3600, setupSecurityMiddleware(), nil)
+			useCase.SetGenerationOverrides(tt.workspaces, fakeChannelConfigLookup{config: tt.channel})
+
+			resp, err := useCase.TranslateContext(WithTeam(context.Background(), "T1"), request.Translation{
+				Text:           "Hello",
+				SourceLanguage: "en",
+				TargetLanguage: "vi",
+				ChannelID:      "C1",
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, used)
+			assert.Equal(t, model.GenerationSettingsOf(tt.want), resp.Generation)
+			require.NotNil(t, saved)
+			assert.Equal(t, model.GenerationSettingsOf(tt.want), saved.Generation)
+
+			// Only translations of overridden parameters are cached apart
+			if defaultKey == "" {
+				defaultKey = cacheKey
+			}
+			assert.Equal(t, tt.want == ai.DefaultGenerationConfig(), cacheKey == defaultKey)
+		})
+	}
+}
+
+// contextTranslator blocks until its context is done, like a hung provider call
+type contextTranslator struct {
+	*mocks.MockTranslator
+}
+
+func (c contextTranslator) TranslateContext(ctx context.Context, text, sourceLanguage, targetLanguage string) (string, error) {
+	<-ctx.Done()
+	return "", errors.New("request aborted")
+}
+
+func TestTranslationUseCase_TranslateContextDeadline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTranslationRepository(ctrl)
+	mockCache := mocks.NewMockCache(ctrl)
+	translator := contextTranslator{MockTranslator: mocks.NewMockTranslator(ctrl)}
+
+	mockCache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", errors.New("cache miss"))
+	mockRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	m := metrics.NewMetrics()
+	useCase := NewTranslationUseCase(zap.NewNop(), mockRepo, mockCache, translator, 3600, setupSecurityMiddleware(), m)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := useCase.TranslateContext(ctx, request.Translation{
+		Text:           "Hello",
+		SourceLanguage: "en",
+		TargetLanguage: "vi",
+	})
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int64(1), m.ErrorsByType["timeout"])
+}
+
+type requestIDKey struct{}
+
+func TestTranslationUseCase_TranslatePropagatesContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTranslationRepository(ctrl)
+	mockCache := mocks.NewMockCache(ctrl)
+	mockTranslator := mocks.NewMockTranslator(ctrl)
+
+	// The cache and the database are called with the caller's context, so
+	// its deadline and cancellation reach Redis and GORM
+	checkContext := func(ctx context.Context) {
+		assert.Equal(t, "req-1", ctx.Value(requestIDKey{}))
+	}
+	mockCache.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, key string) (string, error) {
+		checkContext(ctx)
+		return "", errors.New("cache miss")
+	})
+	mockRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, hash string) (*model.Translation, error) {
+		checkContext(ctx)
+		return nil, model.ErrNotFound
+	})
+	mockTranslator.EXPECT().Translate("Hello", "en", "vi").Return("Xin chào", nil)
+	mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, translation *model.Translation) error {
+		checkContext(ctx)
+		return nil
+	})
+	mockCache.EXPECT().Set(gomock.Any(), gomock.Any(), "Xin chào", int64(3600)).DoAndReturn(func(ctx context.Context, key, value string, ttl int64) error {
+		checkContext(ctx)
+		return nil
+	})
+
+	useCase := NewTranslationUseCase(zap.NewNop(), mockRepo, mockCache, mockTranslator, 3600, setupSecurityMiddleware(), nil)
+
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "req-1")
+	_, err := useCase.TranslateContext(ctx, request.Translation{
+		Text:           "Hello",
+		SourceLanguage: "en",
+		TargetLanguage: "vi",
+	})
+
+	require.NoError(t, err)
+}
+
+func TestTranslationUseCase_TranslateStoredLookup(t *testing.T) {
+	stored := &model.Translation{ID: "tr-1", TranslatedText: "Xin chào", Provider: "gemini"}
+	tests := []struct {
+		name             string
+		lookup           *model.Translation
+		lookupErr        error
+		expectSource     string
+		expectLookupFail int64
+	}{
+		{
+			name:         "stored translation is served",
+			lookup:       stored,
+			expectSource: response.SourceDatabase,
+		},
+		{
+			name:         "not found falls through to the AI",
+			lookupErr:    model.ErrNotFound,
+			expectSource: response.SourceAI,
+		},
+		{
+			name:         "wrapped not found falls through to the AI",
+			lookupErr:    fmt.Errorf("lookup: %w", model.ErrNotFound),
+			expectSource: response.SourceAI,
+		},
+		{
+			name:         "no translation and no error falls through to the AI",
+			expectSource: response.SourceAI,
+		},
+		{
+			name:             "database error is treated as a miss",
+			lookupErr:        errors.New("connection reset"),
+			expectSource:     response.SourceAI,
+			expectLookupFail: 1,
+		},
+		{
+			name:             "translation returned with an error is ignored",
+			lookup:           stored,
+			lookupErr:        errors.New("scan failed"),
+			expectSource:     response.SourceAI,
+			expectLookupFail: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockTranslationRepository(ctrl)
+			mockCache := mocks.NewMockCache(ctrl)
+			mockTranslator := mocks.NewMockTranslator(ctrl)
+
+			mockCache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", errors.New("cache miss"))
+			mockRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(tt.lookup, tt.lookupErr)
+			if tt.expectSource == response.SourceAI {
+				mockTranslator.EXPECT().Translate("Hello", "en", "vi").Return("Xin chào", nil)
+				mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+			}
+			mockCache.EXPECT().Set(gomock.Any(), gomock.Any(), "Xin chào", int64(3600)).Return(nil)
+
+			m := metrics.NewMetrics()
+			useCase := NewTranslationUseCase(zap.NewNop(), mockRepo, mockCache, mockTranslator, 3600, setupSecurityMiddleware(), m)
+
+			resp, err := useCase.Translate(request.Translation{
+				Text:           "Hello",
+				SourceLanguage: "en",
+				TargetLanguage: "vi",
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, "Xin chào", resp.TranslatedText)
+			assert.Equal(t, tt.expectSource, resp.Source)
+			assert.Equal(t, tt.expectLookupFail, m.ErrorsByType["translation_lookup_failed"])
+		})
+	}
+}
+
+type readOnlyDatabase struct{}
+
+func (readOnlyDatabase) Writable() bool { return false }
+
+func TestTranslationUseCase_TranslateWhileDatabaseReadOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTranslationRepository(ctrl)
+	mockReplica := mocks.NewMockTranslationRepository(ctrl)
+	mockCache := mocks.NewMockCache(ctrl)
+	mockTranslator := mocks.NewMockTranslator(ctrl)
+
+	// The primary is neither read nor written; the lookup goes to the replica
+	mockCache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", errors.New("cache miss"))
+	mockReplica.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, errors.New("replica lagging"))
+	mockTranslator.EXPECT().Translate("Hello", "en", "vi").Return("Xin chào", nil)
+	mockCache.EXPECT().Set(gomock.Any(), gomock.Any(), "Xin chào", int64(3600)).Return(nil)
+
+	m := metrics.NewMetrics()
+	useCase := NewTranslationUseCase(zap.NewNop(), mockRepo, mockCache, mockTranslator, 3600, setupSecurityMiddleware(), m)
+	useCase.SetFailover(readOnlyDatabase{}, mockReplica)
+
+	resp, err := useCase.Translate(request.Translation{
+		Text:           "Hello",
+		SourceLanguage: "en",
+		TargetLanguage: "vi",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Xin chào", resp.TranslatedText)
+	assert.Equal(t, int64(1), m.ErrorsByType["persistence_skipped"])
+	assert.Equal(t, int64(1), m.ErrorsByType["translation_lookup_failed"])
+}
+
+type fakeUsageRecorder struct {
+	channels []string
+	results  []response.Translation
+}
+
+func (f *fakeUsageRecorder) RecordTranslation(channelID string, result response.Translation) error {
+	f.channels = append(f.channels, channelID)
+	f.results = append(f.results, result)
+	return nil
+}
+
+func TestTranslationUseCase_TranslateRecordsUsage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTranslationRepository(ctrl)
+	mockCache := mocks.NewMockCache(ctrl)
+	mockTranslator := mocks.NewMockTranslator(ctrl)
+
+	mockCache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("Xin chào", nil).Times(2)
+
+	usage := &fakeUsageRecorder{}
+	useCase := NewTranslationUseCase(zap.NewNop(), mockRepo, mockCache, mockTranslator, 3600, setupSecurityMiddleware(), nil)
+	useCase.SetUsageRecorder(usage)
+
+	for _, channelID := range []string{"C1", ""} {
+		_, err := useCase.Translate(request.Translation{
+			Text:           "Hello",
+			SourceLanguage: "en",
+			TargetLanguage: "vi",
+			ChannelID:      channelID,
+		})
+		require.NoError(t, err)
+	}
+
+	// Translations outside a channel, e.g. from the HTTP API, are not counted
+	assert.Equal(t, []string{"C1"}, usage.channels)
+	assert.True(t, usage.results[0].Cached)
+	assert.Equal(t, "vi", usage.results[0].TargetLanguage)
+}
+
+type fakeAlerter struct {
+	events chan string
+}
+
+func (f *fakeAlerter) Send(ctx context.Context, event string, data interface{}) error {
+	f.events <- event
+	return nil
+}
+
+func TestTranslationUseCase_TranslateCompromised(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTranslationRepository(ctrl)
+	mockCache := mocks.NewMockCache(ctrl)
+	mockTranslator := mocks.NewMockTranslator(ctrl)
+	mockAuditor := mocks.NewMockAuditService(ctrl)
+
+	mockCache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", errors.New("cache miss"))
+	mockRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockTranslator.EXPECT().Translate("Hello", "en", "vi").
+		Return("", fmt.Errorf("translation compromised: %w", security.ErrCanaryLeaked))
+	mockAuditor.EXPECT().Record(gomock.Any()).DoAndReturn(func(entry model.AuditEntry) error {
+		assert.Equal(t, model.AuditActorSystem, entry.Actor)
+		assert.Equal(t, model.AuditActionCompromised, entry.Action)
+		assert.Equal(t, "C123", entry.ResourceID)
+		assert.Equal(t, "Hello", entry.After.(map[string]interface{})["input"])
+		return nil
+	})
+
+	m := metrics.NewMetrics()
+	alerter := &fakeAlerter{events: make(chan string, 1)}
+	useCase := NewTranslationUseCase(zap.NewNop(), mockRepo, mockCache, mockTranslator, 3600, setupSecurityMiddleware(), m)
+	useCase.SetAuditor(mockAuditor)
+	useCase.SetAlerter(alerter)
+
+	result, err := useCase.Translate(request.Translation{
+		Text:           "Hello",
+		SourceLanguage: "en",
+		TargetLanguage: "vi",
+		UserID:         "U123",
+		ChannelID:      "C123",
+	})
+
+	assert.ErrorIs(t, err, security.ErrCanaryLeaked)
+	assert.Empty(t, result.TranslatedText)
+	assert.Equal(t, int64(1), m.ErrorsByType["translation_compromised"])
+
+	select {
+	case event := <-alerter.events:
+		assert.Equal(t, "translation.compromised", event)
+	case <-time.After(time.Second):
+		t.Fatal("expected a compromised translation alert")
+	}
+}
+
+func TestTranslationUseCase_TranslateSafetyBlocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTranslationRepository(ctrl)
+	mockCache := mocks.NewMockCache(ctrl)
+	mockTranslator := mocks.NewMockTranslator(ctrl)
+
+	mockCache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", errors.New("cache miss"))
+	mockRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockTranslator.EXPECT().Translate("Hello", "en", "vi").
+		Return("", fmt.Errorf("failed to generate translation: %w", ai.ErrSafetyBlocked))
+
+	m := metrics.NewMetrics()
+	useCase := NewTranslationUseCase(zap.NewNop(), mockRepo, mockCache, mockTranslator, 3600, setupSecurityMiddleware(), m)
+
+	_, err := useCase.Translate(request.Translation{
+		Text:           "Hello",
+		SourceLanguage: "en",
+		TargetLanguage: "vi",
+		ChannelID:      "C123",
+	})
+
+	assert.ErrorIs(t, err, ai.ErrSafetyBlocked)
+	assert.Equal(t, int64(1), m.ErrorsByType["safety_blocked"])
+	assert.Equal(t, int64(1), m.SafetyBlocks["C123"])
+	assert.Zero(t, m.ErrorsByType["translation_failed"])
+}
+
+// providerTranslator serves every call from a fixed provider, like a chain whose primary is healthy
+type providerTranslator struct {
+	*mocks.MockTranslator
+	provider string
+}
+
+func (p providerTranslator) TranslateWithProvider(ctx context.Context, text, sourceLanguage, targetLanguage string) (string, string, error) {
+	return "Xin chào", p.provider, nil
+}
+
+func TestTranslationUseCase_TranslateRecordsProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTranslationRepository(ctrl)
+	mockCache := mocks.NewMockCache(ctrl)
+	translator := providerTranslator{MockTranslator: mocks.NewMockTranslator(ctrl), provider: "openai"}
+
+	mockCache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", errors.New("cache miss"))
+	mockRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, translation *model.Translation) error {
+		assert.Equal(t, "openai", translation.Provider)
+		return nil
+	})
+	mockCache.EXPECT().Set(gomock.Any(), gomock.Any(), "Xin chào", int64(3600)).Return(nil)
+
+	useCase := NewTranslationUseCase(zap.NewNop(), mockRepo, mockCache, translator, 3600, setupSecurityMiddleware(), nil)
+
+	result, err := useCase.Translate(request.Translation{
+		Text:           "Hello",
+		SourceLanguage: "en",
+		TargetLanguage: "vi",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Xin chào", result.TranslatedText)
+}
+
+func TestTranslationUseCase_TranslateRecordsStages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTranslationRepository(ctrl)
+	mockCache := mocks.NewMockCache(ctrl)
+	mockTranslator := mocks.NewMockTranslator(ctrl)
+
+	mockCache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", errors.New("cache miss"))
+	mockRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection reset"))
+	mockTranslator.EXPECT().Translate("Hello", "en", "vi").Return("Xin chào", nil)
+	mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, translation *model.Translation) error {
+		// The row says where the time went and that the lookup failed
+		assert.Contains(t, translation.StageTimings, metrics.StageQueueWait)
+		assert.Contains(t, translation.StageTimings, metrics.StageCache)
+		assert.Contains(t, translation.StageTimings, metrics.StageDatabase)
+		assert.Contains(t, translation.StageTimings, metrics.StageAI)
+		assert.Equal(t, metrics.StageDatabase, translation.FailedStage)
+		return nil
+	})
+	mockCache.EXPECT().Set(gomock.Any(), gomock.Any(), "Xin chào", int64(3600)).Return(errors.New("connection refused"))
+
+	useCase := NewTranslationUseCase(zap.NewNop(), mockRepo, mockCache, mockTranslator, 3600, setupSecurityMiddleware(), nil)
+
+	trace := metrics.NewLatencyTrace(time.Now())
+	trace.Add(metrics.StageQueueWait, time.Millisecond)
+	_, err := useCase.TranslateContext(metrics.WithLatencyTrace(context.Background(), trace), request.Translation{
+		Text:           "Hello",
+		SourceLanguage: "en",
+		TargetLanguage: "vi",
+	})
+
+	require.NoError(t, err)
+	// The first failure is kept when the cache write fails too
+	assert.Equal(t, metrics.StageDatabase, trace.FailedStage())
+}
+
+func TestTranslationUseCase_PublishesEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTranslationRepository(ctrl)
+	mockCache := mocks.NewMockCache(ctrl)
+	mockTranslator := mocks.NewMockTranslator(ctrl)
+
+	gomock.InOrder(
+		mockCache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("Xin chào", nil),
+		mockCache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", errors.New("cache miss")),
+	)
+	mockRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockTranslator.EXPECT().Translate("Hello", "en", "vi").
+		Return("", fmt.Errorf("translation compromised: %w", security.ErrCanaryLeaked))
+
+	m := metrics.NewMetrics()
+	useCase := NewTranslationUseCase(zap.NewNop(), mockRepo, mockCache, mockTranslator, 3600, setupSecurityMiddleware(), m)
+
+	var completed []TranslationCompleted
+	var failed []TranslationFailed
+	var blocked []SecurityBlocked
+	eventbus.Subscribe(useCase.Events(), func(e TranslationCompleted) { completed = append(completed, e) })
+	eventbus.Subscribe(useCase.Events(), func(e TranslationFailed) { failed = append(failed, e) })
+	eventbus.Subscribe(useCase.Events(), func(e SecurityBlocked) { blocked = append(blocked, e) })
+
+	req := request.Translation{Text: "Hello", SourceLanguage: "en", TargetLanguage: "vi", UserID: "U1", ChannelID: "C1"}
+	_, err := useCase.Translate(req)
+	require.NoError(t, err)
+	_, err = useCase.Translate(req)
+	require.Error(t, err)
+
+	require.Len(t, completed, 1)
+	assert.Equal(t, "Xin chào", completed[0].Result.TranslatedText)
+	assert.Equal(t, "C1", completed[0].Request.ChannelID)
+
+	require.Len(t, failed, 1)
+	assert.ErrorIs(t, failed[0].Err, security.ErrCanaryLeaked)
+
+	require.Len(t, blocked, 1)
+	assert.Equal(t, SecurityReasonCanaryLeaked, blocked[0].Reason)
+	assert.Equal(t, "Hello", blocked[0].Input)
+
+	// Metrics count requests from the same events
+	assert.Equal(t, int64(1), m.SuccessCount)
+	assert.Equal(t, int64(1), m.FailureCount)
+}
+
+func TestTranslationUseCase_TranslateBatched(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTranslationRepository(ctrl)
+	mockCache := mocks.NewMockCache(ctrl)
+	mockCache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", errors.New("cache miss")).Times(2)
+	mockRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, translation *model.Translation) error {
+		assert.Equal(t, ai.BatchPromptVersion, translation.PromptVersion)
+		return nil
+	}).Times(2)
+	mockCache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), int64(3600)).Return(nil).Times(2)
+
+	translator := &fakeBatchTranslator{}
+	useCase := NewTranslationUseCase(zap.NewNop(), mockRepo, mockCache, translator, 3600, setupSecurityMiddleware(), nil)
+	useCase.SetBatching(time.Hour, 2)
+
+	results := make(chan response.Translation, 2)
+	for _, text := range []string{"Hello", "Thanks"} {
+		go func() {
+			result, err := useCase.Translate(request.Translation{Text: text, SourceLanguage: "en", TargetLanguage: "vi", ChannelID: "C1"})
+			assert.NoError(t, err)
+			results <- result
+		}()
+	}
+	translated := []string{(<-results).TranslatedText, (<-results).TranslatedText}
+
+	assert.ElementsMatch(t, []string{"vi:Hello", "vi:Thanks"}, translated)
+	assert.Len(t, translator.batches, 1)
+	assert.Empty(t, translator.singles)
+}
@@ -0,0 +1,16 @@
+package service
+
+import "context"
+
+// TxRepositories exposes repositories bound to a single database transaction.
+type TxRepositories interface {
+	Translations() TranslationRepository
+	Channels() ChannelRepository
+}
+
+// UnitOfWork runs several repository writes atomically.
+// All writes performed through the repositories handed to fn are committed
+// together when fn returns nil, and rolled back otherwise.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(repos TxRepositories) error) error
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"go.uber.org/zap"
+)
+
+// UserPreferenceRepository defines the interface for user preference persistence.
+// This interface is owned by the UserPreferenceUseCase and defined where it's consumed.
+type UserPreferenceRepository interface {
+	GetByUserID(userID string) (*model.UserPreference, error)
+	Save(preference *model.UserPreference) error
+}
+
+// HomeViewPublisher shows a user's preferences on the bot's App Home tab
+type HomeViewPublisher interface {
+	PublishHomeView(userID string, preference *model.UserPreference) error
+}
+
+// userPreferenceCacheTTL is how long user preferences are cached, in seconds
+const userPreferenceCacheTTL = 3600
+
+var _ UserPreferenceService = (*UserPreferenceUseCase)(nil)
+
+// UserPreferenceUseCase manages the preferences users set on the App Home
+// tab. Preferences are cached, since they are looked up for every message.
+type UserPreferenceUseCase struct {
+	repo      UserPreferenceRepository
+	cache     Cache
+	publisher HomeViewPublisher
+	logger    *zap.Logger
+}
+
+func NewUserPreferenceUseCase(repo UserPreferenceRepository, cache Cache, logger *zap.Logger) *UserPreferenceUseCase {
+	return &UserPreferenceUseCase{
+		repo:   repo,
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// SetHomeViewPublisher republishes a user's App Home tab when they change a preference
+func (uu *UserPreferenceUseCase) SetHomeViewPublisher(publisher HomeViewPublisher) {
+	uu.publisher = publisher
+}
+
+// GetPreference returns the user's preferences, or the defaults when they set none
+func (uu *UserPreferenceUseCase) GetPreference(userID string) (*model.UserPreference, error) {
+	cacheKey := userPreferenceKey(userID)
+	if cached, err := uu.cache.Get(context.Background(), cacheKey); err == nil && cached != "" {
+		preference := &model.UserPreference{}
+		if err := json.Unmarshal([]byte(cached), preference); err == nil {
+			return preference, nil
+		}
+	}
+
+	preference, err := uu.repo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user preference: %w", err)
+	}
+	if preference == nil {
+		preference = model.DefaultUserPreference(userID)
+	}
+
+	if encoded, err := json.Marshal(preference); err == nil {
+		_ = uu.cache.Set(context.Background(), cacheKey, string(encoded), userPreferenceCacheTTL)
+	}
+	return preference, nil
+}
+
+// SetPreferredLanguage translates the user's messages to the language code,
+// or with the channel's languages when code is empty
+func (uu *UserPreferenceUseCase) SetPreferredLanguage(userID, code string) (*model.UserPreference, error) {
+	if code != "" && !model.IsSupportedLanguageCode(code) {
+		return nil, model.NewValidationError(fmt.Sprintf("unsupported language: %s", code))
+	}
+	return uu.update(userID, func(preference *model.UserPreference) {
+		preference.PreferredLanguage = model.CanonicalLanguageCode(code)
+	})
+}
+
+// SetTranslateOwnMessages turns translation of the user's messages on or off
+func (uu *UserPreferenceUseCase) SetTranslateOwnMessages(userID string, enabled bool) (*model.UserPreference, error) {
+	return uu.update(userID, func(preference *model.UserPreference) {
+		preference.TranslateOwnMessages = enabled
+	})
+}
+
+// PublishHome shows the user's preferences on their App Home tab
+func (uu *UserPreferenceUseCase) PublishHome(userID string) error {
+	if uu.publisher == nil {
+		return nil
+	}
+	preference, err := uu.GetPreference(userID)
+	if err != nil {
+		return err
+	}
+	if err := uu.publisher.PublishHomeView(userID, preference); err != nil {
+		return fmt.Errorf("failed to publish home view: %w", err)
+	}
+	return nil
+}
+
+func (uu *UserPreferenceUseCase) update(userID string, change func(*model.UserPreference)) (*model.UserPreference, error) {
+	preference, err := uu.repo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user preference: %w", err)
+	}
+	now := time.Now()
+	if preference == nil {
+		preference = model.DefaultUserPreference(userID)
+		preference.CreatedAt = now
+	}
+	change(preference)
+	preference.UpdatedAt = now
+
+	if err := uu.repo.Save(preference); err != nil {
+		return nil, fmt.Errorf("failed to save user preference: %w", err)
+	}
+	_ = uu.cache.Delete(context.Background(), userPreferenceKey(userID))
+
+	uu.logger.Info("User preference updated",
+		zap.String("user_id", userID),
+		zap.String("preferred_language", preference.PreferredLanguage),
+		zap.Bool("translate_own_messages", preference.TranslateOwnMessages))
+
+	if uu.publisher != nil {
+		if err := uu.publisher.PublishHomeView(userID, preference); err != nil {
+			uu.logger.Warn("Failed to republish home view",
+				zap.String("user_id", userID),
+				zap.Error(err))
+		}
+	}
+	return preference, nil
+}
+
+func userPreferenceKey(userID string) string {
+	return fmt.Sprintf("user_preference:%s", userID)
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeUserPreferenceRepository struct {
+	preferences map[string]*model.UserPreference
+}
+
+func (f *fakeUserPreferenceRepository) GetByUserID(userID string) (*model.UserPreference, error) {
+	preference, ok := f.preferences[userID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *preference
+	return &copied, nil
+}
+
+func (f *fakeUserPreferenceRepository) Save(preference *model.UserPreference) error {
+	copied := *preference
+	f.preferences[preference.UserID] = &copied
+	return nil
+}
+
+type fakeHomeViewPublisher struct {
+	published []model.UserPreference
+}
+
+func (f *fakeHomeViewPublisher) PublishHomeView(_ string, preference *model.UserPreference) error {
+	f.published = append(f.published, *preference)
+	return nil
+}
+
+func TestUserPreferenceUseCase_GetPreference(t *testing.T) {
+	t.Run("defaults without preferences", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCache := mocks.NewMockCache(ctrl)
+		repo := &fakeUserPreferenceRepository{preferences: map[string]*model.UserPreference{}}
+		useCase := NewUserPreferenceUseCase(repo, mockCache, zap.NewNop())
+		mockCache.EXPECT().Get(gomock.Any(), "user_preference:U1").Return("", nil)
+		mockCache.EXPECT().Set(gomock.Any(), "user_preference:U1", gomock.Any(), int64(3600)).Return(nil)
+
+		preference, err := useCase.GetPreference("U1")
+		require.NoError(t, err)
+		assert.Equal(t, "", preference.PreferredLanguage)
+		assert.True(t, preference.TranslateOwnMessages)
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCache := mocks.NewMockCache(ctrl)
+		useCase := NewUserPreferenceUseCase(&fakeUserPreferenceRepository{}, mockCache, zap.NewNop())
+		mockCache.EXPECT().Get(gomock.Any(), "user_preference:U1").
+			Return(`{"user_id":"U1","preferred_language":"ja","translate_own_messages":false}`, nil)
+
+		preference, err := useCase.GetPreference("U1")
+		require.NoError(t, err)
+		assert.Equal(t, "ja", preference.PreferredLanguage)
+		assert.False(t, preference.TranslateOwnMessages)
+	})
+}
+
+func TestUserPreferenceUseCase_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCache := mocks.NewMockCache(ctrl)
+	repo := &fakeUserPreferenceRepository{preferences: map[string]*model.UserPreference{}}
+	publisher := &fakeHomeViewPublisher{}
+	useCase := NewUserPreferenceUseCase(repo, mockCache, zap.NewNop())
+	useCase.SetHomeViewPublisher(publisher)
+	mockCache.EXPECT().Delete(gomock.Any(), "user_preference:U1").Return(nil).Times(2)
+
+	_, err := useCase.SetPreferredLanguage("U1", "ja")
+	require.NoError(t, err)
+	preference, err := useCase.SetTranslateOwnMessages("U1", false)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ja", preference.PreferredLanguage)
+	assert.False(t, preference.TranslateOwnMessages)
+	assert.Equal(t, "ja", repo.preferences["U1"].PreferredLanguage)
+	assert.False(t, repo.preferences["U1"].TranslateOwnMessages)
+	require.Len(t, publisher.published, 2)
+	assert.False(t, publisher.published[1].TranslateOwnMessages)
+
+	_, err = useCase.SetPreferredLanguage("U1", "xx")
+	var domainErr *model.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, model.ErrorTypeValidation, domainErr.Type)
+}
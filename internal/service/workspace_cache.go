@@ -0,0 +1,70 @@
+package service
+
+import (
+	"container/list"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+type cachedWorkspace struct {
+	teamID    string
+	workspace *model.Workspace
+	expiresAt time.Time
+}
+
+// workspaceCache is an LRU of workspace lookups holding at most maxEntries
+// teams. Expired entries are kept until they are replaced or reach the back
+// of the list, where they are evicted before any live entry. It is not safe
+// for concurrent use.
+type workspaceCache struct {
+	entries    map[string]*list.Element
+	order      *list.List // of *cachedWorkspace, most recently used first
+	maxEntries int
+}
+
+func newWorkspaceCache(maxEntries int) *workspaceCache {
+	return &workspaceCache{
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		maxEntries: maxEntries,
+	}
+}
+
+// get returns the entry of teamID, expired or not, and marks it used
+func (c *workspaceCache) get(teamID string) (*cachedWorkspace, bool) {
+	element, ok := c.entries[teamID]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*cachedWorkspace), true
+}
+
+func (c *workspaceCache) set(teamID string, workspace *model.Workspace, now time.Time) {
+	entry := &cachedWorkspace{teamID: teamID, workspace: workspace, expiresAt: now.Add(workspaceCacheTTL)}
+	if element, ok := c.entries[teamID]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+
+	for back := c.order.Back(); back != nil && !now.Before(back.Value.(*cachedWorkspace).expiresAt); back = c.order.Back() {
+		c.remove(back)
+	}
+	for len(c.entries) >= c.maxEntries && c.order.Len() > 0 {
+		c.remove(c.order.Back())
+	}
+	c.entries[teamID] = c.order.PushFront(entry)
+}
+
+func (c *workspaceCache) delete(teamID string) {
+	if element, ok := c.entries[teamID]; ok {
+		c.remove(element)
+	}
+}
+
+func (c *workspaceCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*cachedWorkspace).teamID)
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/secrets"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// WorkspaceRepository defines the interface for workspace persistence.
+// This interface is owned by the WorkspaceUseCase and defined where it's consumed.
+type WorkspaceRepository interface {
+	Save(workspace *model.Workspace) error
+	GetByTeamID(teamID string) (*model.Workspace, error)
+	GetAll() ([]*model.Workspace, error)
+	Delete(teamID string) error
+	RotateKeys(ctx context.Context) (*model.KeyRotation, error)
+}
+
+const (
+	// workspaceCacheTTL bounds how long another instance keeps using the bot
+	// token of a reinstalled or uninstalled workspace
+	workspaceCacheTTL = time.Minute
+	// workspaceCacheSize bounds the installed workspaces kept in memory
+	workspaceCacheSize = 10000
+	// workspaceMissCacheSize bounds the teams without an installation kept in
+	// memory, which any request can add by naming a team ID
+	workspaceMissCacheSize = 1000
+	// unknownTeamLookups and unknownTeamBurst rate limit the database lookups
+	// of teams not cached as installed, per second
+	unknownTeamLookups = 5
+	unknownTeamBurst   = 20
+)
+
+var _ WorkspaceService = (*WorkspaceUseCase)(nil)
+
+// WorkspaceUseCase manages the Slack workspaces the app is installed in.
+// Lookups happen for every event, with the team ID read from the request
+// before its signature is verified, so they are cached in bounded LRUs:
+// installed workspaces in one and misses in a smaller one. Database lookups
+// of teams not cached as installed are rate limited; past the limit such
+// teams are treated as not installed.
+type WorkspaceUseCase struct {
+	repo   WorkspaceRepository
+	logger *zap.Logger
+	now    func() time.Time
+
+	mu      sync.Mutex
+	cache   *workspaceCache
+	misses  *workspaceCache
+	lookups *rate.Limiter
+}
+
+func NewWorkspaceUseCase(repo WorkspaceRepository, logger *zap.Logger) *WorkspaceUseCase {
+	return &WorkspaceUseCase{
+		repo:    repo,
+		logger:  logger,
+		now:     time.Now,
+		cache:   newWorkspaceCache(workspaceCacheSize),
+		misses:  newWorkspaceCache(workspaceMissCacheSize),
+		lookups: rate.NewLimiter(unknownTeamLookups, unknownTeamBurst),
+	}
+}
+
+// Install stores a workspace the app was installed in. Reinstalling replaces
+// the bot token and keeps the signing secret, generation settings, monthly
+// budget and installation time.
+func (wu *WorkspaceUseCase) Install(workspace *model.Workspace) error {
+	if err := workspace.Validate(); err != nil {
+		return fmt.Errorf("invalid workspace: %w", err)
+	}
+
+	existing, err := wu.repo.GetByTeamID(workspace.TeamID)
+	if err != nil {
+		return fmt.Errorf("failed to check workspace: %w", err)
+	}
+
+	now := time.Now()
+	workspace.InstalledAt = now
+	workspace.UpdatedAt = now
+	if existing != nil {
+		workspace.InstalledAt = existing.InstalledAt
+		if workspace.SigningSecret == "" {
+			workspace.SigningSecret = existing.SigningSecret
+		}
+		if workspace.Generation.IsZero() {
+			workspace.Generation = existing.Generation
+		}
+		if workspace.MonthlyBudget == 0 {
+			workspace.MonthlyBudget = existing.MonthlyBudget
+		}
+	}
+
+	if err := wu.repo.Save(workspace); err != nil {
+		return fmt.Errorf("failed to install workspace: %w", err)
+	}
+
+	wu.invalidate(workspace.TeamID)
+	wu.logger.Info("Workspace installed",
+		zap.String("team_id", workspace.TeamID),
+		zap.String("team_name", workspace.TeamName),
+		zap.Bool("reinstall", existing != nil))
+	return nil
+}
+
+// GetWorkspace returns the workspace of teamID, or nil if the app was not
+// installed there through OAuth
+func (wu *WorkspaceUseCase) GetWorkspace(teamID string) (*model.Workspace, error) {
+	now := wu.now()
+	wu.mu.Lock()
+	cached, installed := wu.cache.get(teamID)
+	ok := installed
+	if !ok {
+		cached, ok = wu.misses.get(teamID)
+	}
+	if ok && now.Before(cached.expiresAt) {
+		wu.mu.Unlock()
+		return cached.workspace, nil
+	}
+	// An installed workspace whose entry expired is refreshed regardless of the limit
+	allowed := installed || wu.lookups.AllowN(now, 1)
+	wu.mu.Unlock()
+	if !allowed {
+		return nil, nil
+	}
+
+	workspace, err := wu.repo.GetByTeamID(teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	wu.mu.Lock()
+	if workspace != nil {
+		wu.misses.delete(teamID)
+		wu.cache.set(teamID, workspace, now)
+	} else {
+		wu.cache.delete(teamID)
+		wu.misses.set(teamID, nil, now)
+	}
+	wu.mu.Unlock()
+	return workspace, nil
+}
+
+func (wu *WorkspaceUseCase) ListWorkspaces() ([]*model.Workspace, error) {
+	workspaces, err := wu.repo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+	return workspaces, nil
+}
+
+// SetSigningSecret sets the secret requests from the workspace are signed
+// with; an empty secret restores SLACK_SIGNING_SECRET
+func (wu *WorkspaceUseCase) SetSigningSecret(teamID string, signingSecret secrets.Secret) (*model.Workspace, error) {
+	workspace, err := wu.repo.GetByTeamID(teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	if workspace == nil {
+		return nil, model.NewNotFoundError("workspace not found")
+	}
+
+	workspace.SigningSecret = signingSecret
+	workspace.UpdatedAt = time.Now()
+	if err := wu.repo.Save(workspace); err != nil {
+		return nil, fmt.Errorf("failed to update workspace: %w", err)
+	}
+
+	wu.invalidate(teamID)
+	return workspace, nil
+}
+
+// SetGeneration sets the AI generation parameters of the workspace's
+// translations; unset parameters keep the defaults
+func (wu *WorkspaceUseCase) SetGeneration(teamID string, generation model.GenerationSettings) (*model.Workspace, error) {
+	if err := generation.Validate(); err != nil {
+		return nil, err
+	}
+
+	workspace, err := wu.repo.GetByTeamID(teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	if workspace == nil {
+		return nil, model.NewNotFoundError("workspace not found")
+	}
+
+	workspace.Generation = generation
+	workspace.UpdatedAt = time.Now()
+	if err := wu.repo.Save(workspace); err != nil {
+		return nil, fmt.Errorf("failed to update workspace: %w", err)
+	}
+
+	wu.invalidate(teamID)
+	return workspace, nil
+}
+
+// SetMonthlyBudget sets the AI spend the workspace plans per month, in US
+// dollars; 0 sets none
+func (wu *WorkspaceUseCase) SetMonthlyBudget(teamID string, budget float64) (*model.Workspace, error) {
+	if budget < 0 {
+		return nil, model.NewValidationError("monthly_budget must not be negative")
+	}
+
+	workspace, err := wu.repo.GetByTeamID(teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	if workspace == nil {
+		return nil, model.NewNotFoundError("workspace not found")
+	}
+
+	workspace.MonthlyBudget = budget
+	workspace.UpdatedAt = time.Now()
+	if err := wu.repo.Save(workspace); err != nil {
+		return nil, fmt.Errorf("failed to update workspace: %w", err)
+	}
+
+	wu.invalidate(teamID)
+	return workspace, nil
+}
+
+// Uninstall removes the workspace and its credentials
+func (wu *WorkspaceUseCase) Uninstall(teamID string) error {
+	if err := wu.repo.Delete(teamID); err != nil {
+		return fmt.Errorf("failed to uninstall workspace: %w", err)
+	}
+
+	wu.invalidate(teamID)
+	return nil
+}
+
+// RotateKeys re-wraps the stored bot tokens and signing secrets with the
+// current master key. The credentials themselves do not change, so cached
+// workspaces stay valid.
+func (wu *WorkspaceUseCase) RotateKeys(ctx context.Context) (*model.KeyRotation, error) {
+	rotation, err := wu.repo.RotateKeys(ctx)
+	if err != nil {
+		return rotation, fmt.Errorf("failed to rotate workspace keys: %w", err)
+	}
+	wu.logger.Info("Workspace keys rotated",
+		zap.Int("rotated", rotation.Rotated),
+		zap.Int("skipped", rotation.Skipped))
+	return rotation, nil
+}
+
+// SigningSecret returns the signing secret of the workspace, or "" to verify
+// its requests with SLACK_SIGNING_SECRET. Lookup errors fall back to it too.
+func (wu *WorkspaceUseCase) SigningSecret(teamID string) string {
+	if teamID == "" {
+		return ""
+	}
+	workspace, err := wu.GetWorkspace(teamID)
+	if err != nil {
+		wu.logger.Warn("Failed to load workspace, verifying with the default signing secret",
+			zap.Error(err),
+			zap.String("team_id", teamID))
+		return ""
+	}
+	if workspace == nil {
+		return ""
+	}
+	return workspace.SigningSecret.Reveal()
+}
+
+func (wu *WorkspaceUseCase) invalidate(teamID string) {
+	wu.mu.Lock()
+	wu.cache.delete(teamID)
+	wu.misses.delete(teamID)
+	wu.mu.Unlock()
+}
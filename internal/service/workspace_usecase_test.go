@@ -0,0 +1,193 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestWorkspaceUseCase_Install(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockWorkspaceRepository(ctrl)
+	useCase := NewWorkspaceUseCase(repo, zap.NewNop())
+
+	t.Run("new workspace", func(t *testing.T) {
+		repo.EXPECT().GetByTeamID("T1").Return(nil, nil)
+		repo.EXPECT().Save(gomock.Any()).Return(nil)
+
+		workspace := &model.Workspace{TeamID: "T1", BotToken: "xoxb-1"}
+		require.NoError(t, useCase.Install(workspace))
+		assert.False(t, workspace.InstalledAt.IsZero())
+	})
+
+	t.Run("reinstall keeps signing secret and installation time", func(t *testing.T) {
+		installedAt := time.Now().Add(-24 * time.Hour)
+		repo.EXPECT().GetByTeamID("T2").Return(&model.Workspace{
+			TeamID:        "T2",
+			BotToken:      "xoxb-old",
+			SigningSecret: "team-secret",
+			InstalledAt:   installedAt,
+		}, nil)
+		repo.EXPECT().Save(gomock.Any()).Return(nil)
+
+		workspace := &model.Workspace{TeamID: "T2", BotToken: "xoxb-new"}
+		require.NoError(t, useCase.Install(workspace))
+		assert.Equal(t, "xoxb-new", workspace.BotToken.Reveal())
+		assert.Equal(t, "team-secret", workspace.SigningSecret.Reveal())
+		assert.Equal(t, installedAt, workspace.InstalledAt)
+	})
+
+	t.Run("missing bot token", func(t *testing.T) {
+		err := useCase.Install(&model.Workspace{TeamID: "T3"})
+		assert.Error(t, err)
+	})
+}
+
+func TestWorkspaceUseCase_GetWorkspaceIsCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockWorkspaceRepository(ctrl)
+	useCase := NewWorkspaceUseCase(repo, zap.NewNop())
+
+	// Misses are cached too, so teams without an installation do not hit the database per event
+	repo.EXPECT().GetByTeamID("T1").Return(nil, nil).Times(1)
+	for i := 0; i < 3; i++ {
+		workspace, err := useCase.GetWorkspace("T1")
+		require.NoError(t, err)
+		assert.Nil(t, workspace)
+	}
+
+	// Installing replaces the cached entry
+	repo.EXPECT().GetByTeamID("T1").Return(nil, nil)
+	repo.EXPECT().Save(gomock.Any()).Return(nil)
+	require.NoError(t, useCase.Install(&model.Workspace{TeamID: "T1", BotToken: "xoxb-1", SigningSecret: "team-secret"}))
+
+	repo.EXPECT().GetByTeamID("T1").Return(&model.Workspace{TeamID: "T1", BotToken: "xoxb-1", SigningSecret: "team-secret"}, nil)
+	assert.Equal(t, "team-secret", useCase.SigningSecret("T1"))
+	assert.Empty(t, useCase.SigningSecret(""))
+}
+
+func TestWorkspaceUseCase_UnknownTeamLookupsAreLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockWorkspaceRepository(ctrl)
+	useCase := NewWorkspaceUseCase(repo, zap.NewNop())
+	now := time.Now()
+	useCase.now = func() time.Time { return now }
+
+	repo.EXPECT().GetByTeamID("T1").Return(&model.Workspace{TeamID: "T1", SigningSecret: "team-secret"}, nil).Times(2)
+	assert.Equal(t, "team-secret", useCase.SigningSecret("T1"))
+
+	// Forged team IDs are looked up up to the burst, then treated as not installed
+	repo.EXPECT().GetByTeamID(gomock.Any()).Return(nil, nil).Times(unknownTeamBurst - 1)
+	for i := 0; i < 2*unknownTeamBurst; i++ {
+		assert.Empty(t, useCase.SigningSecret(fmt.Sprintf("TFORGED%d", i)))
+	}
+
+	// An installed workspace is refreshed once its entry expired, whatever the
+	// limit; expired misses are not
+	now = now.Add(workspaceCacheTTL)
+	assert.Equal(t, "team-secret", useCase.SigningSecret("T1"))
+	repo.EXPECT().GetByTeamID(gomock.Any()).Return(nil, nil).Times(unknownTeamBurst)
+	for i := 0; i < unknownTeamBurst; i++ {
+		assert.Empty(t, useCase.SigningSecret(fmt.Sprintf("TNEW%d", i)))
+	}
+	assert.Empty(t, useCase.SigningSecret("TFORGED0"))
+}
+
+func TestWorkspaceCache_Bounded(t *testing.T) {
+	now := time.Now()
+	cache := newWorkspaceCache(2)
+	cache.set("T1", &model.Workspace{TeamID: "T1"}, now)
+	cache.set("T2", &model.Workspace{TeamID: "T2"}, now)
+
+	// The least recently used team is evicted
+	_, ok := cache.get("T1")
+	require.True(t, ok)
+	cache.set("T3", &model.Workspace{TeamID: "T3"}, now)
+	_, ok = cache.get("T2")
+	assert.False(t, ok)
+	assert.Len(t, cache.entries, 2)
+
+	// Expired entries are evicted before live ones
+	cache.set("T4", &model.Workspace{TeamID: "T4"}, now.Add(workspaceCacheTTL))
+	_, ok = cache.get("T1")
+	assert.False(t, ok)
+	_, ok = cache.get("T3")
+	assert.False(t, ok)
+	assert.Len(t, cache.entries, 1)
+}
+
+func TestWorkspaceUseCase_SetSigningSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockWorkspaceRepository(ctrl)
+	useCase := NewWorkspaceUseCase(repo, zap.NewNop())
+
+	repo.EXPECT().GetByTeamID("T404").Return(nil, nil)
+	_, err := useCase.SetSigningSecret("T404", "secret")
+	var domainErr *model.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, model.ErrorTypeNotFound, domainErr.Type)
+
+	repo.EXPECT().GetByTeamID("T1").Return(&model.Workspace{TeamID: "T1", BotToken: "xoxb-1"}, nil)
+	repo.EXPECT().Save(gomock.Any()).Return(nil)
+	workspace, err := useCase.SetSigningSecret("T1", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", workspace.SigningSecret.Reveal())
+}
+
+func TestWorkspaceUseCase_SetGeneration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockWorkspaceRepository(ctrl)
+	useCase := NewWorkspaceUseCase(repo, zap.NewNop())
+
+	tooHot := 3.0
+	_, err := useCase.SetGeneration("T1", model.GenerationSettings{Temperature: &tooHot})
+	var domainErr *model.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, model.ErrorTypeValidation, domainErr.Type)
+
+	warm := 0.7
+	repo.EXPECT().GetByTeamID("T1").Return(&model.Workspace{TeamID: "T1", BotToken: "xoxb-1"}, nil)
+	repo.EXPECT().Save(gomock.Any()).Return(nil)
+	workspace, err := useCase.SetGeneration("T1", model.GenerationSettings{Temperature: &warm})
+	require.NoError(t, err)
+	assert.Equal(t, warm, *workspace.Generation.Temperature)
+	assert.Nil(t, workspace.Generation.TopP)
+}
+
+func TestWorkspaceUseCase_SetMonthlyBudget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockWorkspaceRepository(ctrl)
+	useCase := NewWorkspaceUseCase(repo, zap.NewNop())
+
+	_, err := useCase.SetMonthlyBudget("T1", -5)
+	assert.True(t, isValidationError(err))
+
+	repo.EXPECT().GetByTeamID("T1").Return(&model.Workspace{TeamID: "T1", BotToken: "xoxb-1"}, nil)
+	repo.EXPECT().Save(gomock.Any()).Return(nil)
+	workspace, err := useCase.SetMonthlyBudget("T1", 50)
+	require.NoError(t, err)
+	assert.Equal(t, 50.0, workspace.MonthlyBudget)
+
+	repo.EXPECT().GetByTeamID("T2").Return(nil, nil)
+	_, err = useCase.SetMonthlyBudget("T2", 50)
+	assert.True(t, model.IsNotFound(err))
+}
@@ -0,0 +1,13 @@
+package testutils
+
+import (
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+)
+
+// The hand-written mocks must keep implementing the interfaces they stand in for
+var (
+	_ service.TranslationRepository = (*MockTranslationRepository)(nil)
+	_ service.ChannelRepository     = (*MockChannelRepository)(nil)
+	_ service.Cache                 = (*MockCache)(nil)
+	_ service.Translator            = (*MockTranslator)(nil)
+)
@@ -1,10 +1,25 @@
 package testutils
 
-//go:generate mockgen -destination=mocks/mock_translation_repository.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service TranslationRepository
-//go:generate mockgen -destination=mocks/mock_channel_repository.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service ChannelRepository
-//go:generate mockgen -destination=mocks/mock_cache.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service Cache
-//go:generate mockgen -destination=mocks/mock_translation_service.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service TranslationService
-//go:generate mockgen -destination=mocks/mock_channel_service.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service ChannelService
-//go:generate mockgen -destination=mocks/mock_event_processor_service.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service EventProcessorService
-//go:generate mockgen -destination=mocks/mock_event_processor.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service/slack EventProcessor
-//go:generate mockgen -destination=mocks/mock_translator.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/translator Translator
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_translation_repository.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service TranslationRepository
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_channel_repository.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service ChannelRepository
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_cache.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service Cache
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_translation_service.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service TranslationService
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_channel_service.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service ChannelService
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_filter_rule_repository.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service FilterRuleRepository
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_filter_rule_service.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service FilterRuleService
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_audit_repository.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service AuditRepository
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_audit_service.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service AuditService
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_event_processor_service.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service EventProcessorService
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_event_processor.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service/slack EventProcessor
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_translator.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service Translator
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_pending_translation_repository.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service PendingTranslationRepository
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_review_messenger.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service ReviewMessenger
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_translation_review_service.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service TranslationReviewService
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_correction_repository.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service CorrectionRepository
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_channel_pair_repository.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service ChannelPairRepository
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_workspace_repository.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service WorkspaceRepository
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_feedback_repository.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service FeedbackRepository
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_analytics_repository.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service AnalyticsRepository
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_user_preference_repository.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service UserPreferenceRepository
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_bot_post_repository.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service BotPostRepository
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_compliance_export_repository.go -package=mocks github.com/ntttrang/go-genai-slack-assistant/internal/service ComplianceExportRepository
@@ -1,6 +1,8 @@
 package testutils
 
 import (
+	"context"
+
 	"github.com/ntttrang/go-genai-slack-assistant/internal/model"
 	"github.com/stretchr/testify/mock"
 )
@@ -10,12 +12,12 @@ type MockTranslationRepository struct {
 	mock.Mock
 }
 
-func (m *MockTranslationRepository) Save(translation *model.Translation) error {
+func (m *MockTranslationRepository) Save(ctx context.Context, translation *model.Translation) error {
 	args := m.Called(translation)
 	return args.Error(0)
 }
 
-func (m *MockTranslationRepository) GetByHash(hash string) (*model.Translation, error) {
+func (m *MockTranslationRepository) GetByHash(ctx context.Context, hash string) (*model.Translation, error) {
 	args := m.Called(hash)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -23,7 +25,7 @@ func (m *MockTranslationRepository) GetByHash(hash string) (*model.Translation,
 	return args.Get(0).(*model.Translation), args.Error(1)
 }
 
-func (m *MockTranslationRepository) GetByID(id string) (*model.Translation, error) {
+func (m *MockTranslationRepository) GetByID(ctx context.Context, id string) (*model.Translation, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -31,7 +33,7 @@ func (m *MockTranslationRepository) GetByID(id string) (*model.Translation, erro
 	return args.Get(0).(*model.Translation), args.Error(1)
 }
 
-func (m *MockTranslationRepository) GetByChannelID(channelID string, limit int) ([]*model.Translation, error) {
+func (m *MockTranslationRepository) GetByChannelID(ctx context.Context, channelID string, limit int) ([]*model.Translation, error) {
 	args := m.Called(channelID, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -80,22 +82,22 @@ type MockCache struct {
 	mock.Mock
 }
 
-func (m *MockCache) Get(key string) (string, error) {
+func (m *MockCache) Get(ctx context.Context, key string) (string, error) {
 	args := m.Called(key)
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockCache) Set(key string, value string, ttl int64) error {
+func (m *MockCache) Set(ctx context.Context, key string, value string, ttl int64) error {
 	args := m.Called(key, value, ttl)
 	return args.Error(0)
 }
 
-func (m *MockCache) Delete(key string) error {
+func (m *MockCache) Delete(ctx context.Context, key string) error {
 	args := m.Called(key)
 	return args.Error(0)
 }
 
-func (m *MockCache) Exists(key string) (bool, error) {
+func (m *MockCache) Exists(ctx context.Context, key string) (bool, error) {
 	args := m.Called(key)
 	return args.Bool(0), args.Error(1)
 }
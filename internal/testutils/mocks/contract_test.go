@@ -0,0 +1,92 @@
+package mocks
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	slackservice "github.com/ntttrang/go-genai-slack-assistant/internal/service/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Every mock must implement the interface it was generated from. When an
+// interface changes, these fail to compile until the mocks are regenerated
+// with make mocks
+var (
+	_ service.TranslationRepository        = (*MockTranslationRepository)(nil)
+	_ service.ChannelRepository            = (*MockChannelRepository)(nil)
+	_ service.Cache                        = (*MockCache)(nil)
+	_ service.TranslationService           = (*MockTranslationService)(nil)
+	_ service.ChannelService               = (*MockChannelService)(nil)
+	_ service.FilterRuleRepository         = (*MockFilterRuleRepository)(nil)
+	_ service.FilterRuleService            = (*MockFilterRuleService)(nil)
+	_ service.AuditRepository              = (*MockAuditRepository)(nil)
+	_ service.AuditService                 = (*MockAuditService)(nil)
+	_ service.EventProcessorService        = (*MockEventProcessorService)(nil)
+	_ slackservice.EventProcessor          = (*MockEventProcessor)(nil)
+	_ service.Translator                   = (*MockTranslator)(nil)
+	_ service.PendingTranslationRepository = (*MockPendingTranslationRepository)(nil)
+	_ service.ReviewMessenger              = (*MockReviewMessenger)(nil)
+	_ service.TranslationReviewService     = (*MockTranslationReviewService)(nil)
+	_ service.CorrectionRepository         = (*MockCorrectionRepository)(nil)
+	_ service.ChannelPairRepository        = (*MockChannelPairRepository)(nil)
+	_ service.WorkspaceRepository          = (*MockWorkspaceRepository)(nil)
+	_ service.FeedbackRepository           = (*MockFeedbackRepository)(nil)
+	_ service.AnalyticsRepository          = (*MockAnalyticsRepository)(nil)
+	_ service.UserPreferenceRepository     = (*MockUserPreferenceRepository)(nil)
+	_ service.BotPostRepository            = (*MockBotPostRepository)(nil)
+	_ service.ComplianceExportRepository   = (*MockComplianceExportRepository)(nil)
+)
+
+var (
+	mockTypePattern  = regexp.MustCompile(`(?m)^type (Mock\w+) struct`)
+	sourcePattern    = regexp.MustCompile(`(?m)^// Source: (\S+) \(interfaces: (\w+)\)$`)
+	directivePattern = regexp.MustCompile(`(?m)^//go:generate go run github.com/golang/mock/mockgen -destination=mocks/(\S+) -package=mocks (\S+) (\w+)$`)
+	contractPattern  = regexp.MustCompile(`\(\*(Mock\w+)\)\(nil\)`)
+)
+
+// TestMocksMatchDirectives checks that every mock has a contract above and
+// a go:generate directive naming the interface it was generated from, so a
+// mock cannot be added or kept without being regenerated with the rest
+func TestMocksMatchDirectives(t *testing.T) {
+	generate, err := os.ReadFile(filepath.Join("..", "generate.go"))
+	require.NoError(t, err)
+	directives := make(map[string]string)
+	for _, match := range directivePattern.FindAllStringSubmatch(string(generate), -1) {
+		directives[match[1]] = match[2] + " " + match[3]
+	}
+
+	self, err := os.ReadFile("contract_test.go")
+	require.NoError(t, err)
+	contracts := make(map[string]bool)
+	for _, match := range contractPattern.FindAllStringSubmatch(string(self), -1) {
+		contracts[match[1]] = true
+	}
+
+	files, err := filepath.Glob("mock_*.go")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, file := range files {
+		source, err := os.ReadFile(file)
+		require.NoError(t, err)
+
+		header := sourcePattern.FindStringSubmatch(string(source))
+		if !assert.NotNil(t, header, "%s is not a generated mock", file) {
+			continue
+		}
+		assert.Equal(t, header[1]+" "+header[2], directives[file], "%s has no matching go:generate directive", file)
+		delete(directives, file)
+
+		for _, match := range mockTypePattern.FindAllStringSubmatch(string(source), -1) {
+			if strings.HasSuffix(match[1], "MockRecorder") {
+				continue
+			}
+			assert.True(t, contracts[match[1]], "%s has no contract in contract_test.go", match[1])
+		}
+	}
+	assert.Empty(t, directives, "go:generate directives without a mock")
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ntttrang/go-genai-slack-assistant/internal/service (interfaces: AnalyticsRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+// MockAnalyticsRepository is a mock of AnalyticsRepository interface.
+type MockAnalyticsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsRepositoryMockRecorder
+}
+
+// MockAnalyticsRepositoryMockRecorder is the mock recorder for MockAnalyticsRepository.
+type MockAnalyticsRepositoryMockRecorder struct {
+	mock *MockAnalyticsRepository
+}
+
+// NewMockAnalyticsRepository creates a new mock instance.
+func NewMockAnalyticsRepository(ctrl *gomock.Controller) *MockAnalyticsRepository {
+	mock := &MockAnalyticsRepository{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsRepository) EXPECT() *MockAnalyticsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockAnalyticsRepository) Record(arg0 *model.ChannelUsage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAnalyticsRepositoryMockRecorder) Record(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAnalyticsRepository)(nil).Record), arg0)
+}
+
+// SumByLanguagePair mocks base method.
+func (m *MockAnalyticsRepository) SumByLanguagePair(arg0 string, arg1 time.Time) ([]model.ChannelUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumByLanguagePair", arg0, arg1)
+	ret0, _ := ret[0].([]model.ChannelUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumByLanguagePair indicates an expected call of SumByLanguagePair.
+func (mr *MockAnalyticsRepositoryMockRecorder) SumByLanguagePair(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumByLanguagePair", reflect.TypeOf((*MockAnalyticsRepository)(nil).SumByLanguagePair), arg0, arg1)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ntttrang/go-genai-slack-assistant/internal/service (interfaces: BotPostRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+// MockBotPostRepository is a mock of BotPostRepository interface.
+type MockBotPostRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBotPostRepositoryMockRecorder
+}
+
+// MockBotPostRepositoryMockRecorder is the mock recorder for MockBotPostRepository.
+type MockBotPostRepositoryMockRecorder struct {
+	mock *MockBotPostRepository
+}
+
+// NewMockBotPostRepository creates a new mock instance.
+func NewMockBotPostRepository(ctrl *gomock.Controller) *MockBotPostRepository {
+	mock := &MockBotPostRepository{ctrl: ctrl}
+	mock.recorder = &MockBotPostRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBotPostRepository) EXPECT() *MockBotPostRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindRange mocks base method.
+func (m *MockBotPostRepository) FindRange(arg0 string, arg1, arg2 time.Time, arg3 *model.BotPost, arg4 int) ([]*model.BotPost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRange", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*model.BotPost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRange indicates an expected call of FindRange.
+func (mr *MockBotPostRepositoryMockRecorder) FindRange(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRange", reflect.TypeOf((*MockBotPostRepository)(nil).FindRange), arg0, arg1, arg2, arg3, arg4)
+}
+
+// Save mocks base method.
+func (m *MockBotPostRepository) Save(arg0 *model.BotPost) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockBotPostRepositoryMockRecorder) Save(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockBotPostRepository)(nil).Save), arg0)
+}
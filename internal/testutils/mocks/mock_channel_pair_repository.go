@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ntttrang/go-genai-slack-assistant/internal/service (interfaces: ChannelPairRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+// MockChannelPairRepository is a mock of ChannelPairRepository interface.
+type MockChannelPairRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChannelPairRepositoryMockRecorder
+}
+
+// MockChannelPairRepositoryMockRecorder is the mock recorder for MockChannelPairRepository.
+type MockChannelPairRepositoryMockRecorder struct {
+	mock *MockChannelPairRepository
+}
+
+// NewMockChannelPairRepository creates a new mock instance.
+func NewMockChannelPairRepository(ctrl *gomock.Controller) *MockChannelPairRepository {
+	mock := &MockChannelPairRepository{ctrl: ctrl}
+	mock.recorder = &MockChannelPairRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChannelPairRepository) EXPECT() *MockChannelPairRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockChannelPairRepository) Delete(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockChannelPairRepositoryMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockChannelPairRepository)(nil).Delete), arg0)
+}
+
+// GetAll mocks base method.
+func (m *MockChannelPairRepository) GetAll() ([]*model.ChannelPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]*model.ChannelPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockChannelPairRepositoryMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockChannelPairRepository)(nil).GetAll))
+}
+
+// GetByChannelID mocks base method.
+func (m *MockChannelPairRepository) GetByChannelID(arg0 string) (*model.ChannelPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByChannelID", arg0)
+	ret0, _ := ret[0].(*model.ChannelPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByChannelID indicates an expected call of GetByChannelID.
+func (mr *MockChannelPairRepositoryMockRecorder) GetByChannelID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChannelID", reflect.TypeOf((*MockChannelPairRepository)(nil).GetByChannelID), arg0)
+}
+
+// GetByID mocks base method.
+func (m *MockChannelPairRepository) GetByID(arg0 string) (*model.ChannelPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0)
+	ret0, _ := ret[0].(*model.ChannelPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockChannelPairRepositoryMockRecorder) GetByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockChannelPairRepository)(nil).GetByID), arg0)
+}
+
+// Save mocks base method.
+func (m *MockChannelPairRepository) Save(arg0 *model.ChannelPair) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockChannelPairRepositoryMockRecorder) Save(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockChannelPairRepository)(nil).Save), arg0)
+}
@@ -34,6 +34,21 @@ func (m *MockChannelService) EXPECT() *MockChannelServiceMockRecorder {
 	return m.recorder
 }
 
+// ApplyChannelTemplate mocks base method.
+func (m *MockChannelService) ApplyChannelTemplate(arg0 *model.ChannelConfig, arg1 model.ChannelSelector, arg2 bool) ([]model.BulkChannelResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyChannelTemplate", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.BulkChannelResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyChannelTemplate indicates an expected call of ApplyChannelTemplate.
+func (mr *MockChannelServiceMockRecorder) ApplyChannelTemplate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyChannelTemplate", reflect.TypeOf((*MockChannelService)(nil).ApplyChannelTemplate), arg0, arg1, arg2)
+}
+
 // CreateChannelConfig mocks base method.
 func (m *MockChannelService) CreateChannelConfig(arg0 *model.ChannelConfig) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ntttrang/go-genai-slack-assistant/internal/service (interfaces: ComplianceExportRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+// MockComplianceExportRepository is a mock of ComplianceExportRepository interface.
+type MockComplianceExportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockComplianceExportRepositoryMockRecorder
+}
+
+// MockComplianceExportRepositoryMockRecorder is the mock recorder for MockComplianceExportRepository.
+type MockComplianceExportRepositoryMockRecorder struct {
+	mock *MockComplianceExportRepository
+}
+
+// NewMockComplianceExportRepository creates a new mock instance.
+func NewMockComplianceExportRepository(ctrl *gomock.Controller) *MockComplianceExportRepository {
+	mock := &MockComplianceExportRepository{ctrl: ctrl}
+	mock.recorder = &MockComplianceExportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComplianceExportRepository) EXPECT() *MockComplianceExportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Latest mocks base method.
+func (m *MockComplianceExportRepository) Latest() (*model.ComplianceExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Latest")
+	ret0, _ := ret[0].(*model.ComplianceExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Latest indicates an expected call of Latest.
+func (mr *MockComplianceExportRepositoryMockRecorder) Latest() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Latest", reflect.TypeOf((*MockComplianceExportRepository)(nil).Latest))
+}
+
+// List mocks base method.
+func (m *MockComplianceExportRepository) List(arg0 int) ([]*model.ComplianceExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*model.ComplianceExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockComplianceExportRepositoryMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockComplianceExportRepository)(nil).List), arg0)
+}
+
+// Save mocks base method.
+func (m *MockComplianceExportRepository) Save(arg0 *model.ComplianceExport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockComplianceExportRepositoryMockRecorder) Save(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockComplianceExportRepository)(nil).Save), arg0)
+}
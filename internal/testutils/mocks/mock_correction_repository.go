@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ntttrang/go-genai-slack-assistant/internal/service (interfaces: CorrectionRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+// MockCorrectionRepository is a mock of CorrectionRepository interface.
+type MockCorrectionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCorrectionRepositoryMockRecorder
+}
+
+// MockCorrectionRepositoryMockRecorder is the mock recorder for MockCorrectionRepository.
+type MockCorrectionRepositoryMockRecorder struct {
+	mock *MockCorrectionRepository
+}
+
+// NewMockCorrectionRepository creates a new mock instance.
+func NewMockCorrectionRepository(ctrl *gomock.Controller) *MockCorrectionRepository {
+	mock := &MockCorrectionRepository{ctrl: ctrl}
+	mock.recorder = &MockCorrectionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCorrectionRepository) EXPECT() *MockCorrectionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockCorrectionRepository) Find(arg0 model.CorrectionQuery) ([]*model.TranslationCorrection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", arg0)
+	ret0, _ := ret[0].([]*model.TranslationCorrection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockCorrectionRepositoryMockRecorder) Find(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockCorrectionRepository)(nil).Find), arg0)
+}
+
+// Save mocks base method.
+func (m *MockCorrectionRepository) Save(arg0 *model.TranslationCorrection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockCorrectionRepositoryMockRecorder) Save(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockCorrectionRepository)(nil).Save), arg0)
+}
@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ntttrang/go-genai-slack-assistant/internal/service (interfaces: FeedbackRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+// MockFeedbackRepository is a mock of FeedbackRepository interface.
+type MockFeedbackRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedbackRepositoryMockRecorder
+}
+
+// MockFeedbackRepositoryMockRecorder is the mock recorder for MockFeedbackRepository.
+type MockFeedbackRepositoryMockRecorder struct {
+	mock *MockFeedbackRepository
+}
+
+// NewMockFeedbackRepository creates a new mock instance.
+func NewMockFeedbackRepository(ctrl *gomock.Controller) *MockFeedbackRepository {
+	mock := &MockFeedbackRepository{ctrl: ctrl}
+	mock.recorder = &MockFeedbackRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedbackRepository) EXPECT() *MockFeedbackRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Save mocks base method.
+func (m *MockFeedbackRepository) Save(arg0 *model.TranslationFeedback) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockFeedbackRepositoryMockRecorder) Save(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockFeedbackRepository)(nil).Save), arg0)
+}
+
+// SummarizeByLanguagePair mocks base method.
+func (m *MockFeedbackRepository) SummarizeByLanguagePair(arg0 model.FeedbackQuery) ([]model.LanguagePairFeedback, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeByLanguagePair", arg0)
+	ret0, _ := ret[0].([]model.LanguagePairFeedback)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummarizeByLanguagePair indicates an expected call of SummarizeByLanguagePair.
+func (mr *MockFeedbackRepositoryMockRecorder) SummarizeByLanguagePair(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeByLanguagePair", reflect.TypeOf((*MockFeedbackRepository)(nil).SummarizeByLanguagePair), arg0)
+}
@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ntttrang/go-genai-slack-assistant/internal/service (interfaces: FilterRuleRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+// MockFilterRuleRepository is a mock of FilterRuleRepository interface.
+type MockFilterRuleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFilterRuleRepositoryMockRecorder
+}
+
+// MockFilterRuleRepositoryMockRecorder is the mock recorder for MockFilterRuleRepository.
+type MockFilterRuleRepositoryMockRecorder struct {
+	mock *MockFilterRuleRepository
+}
+
+// NewMockFilterRuleRepository creates a new mock instance.
+func NewMockFilterRuleRepository(ctrl *gomock.Controller) *MockFilterRuleRepository {
+	mock := &MockFilterRuleRepository{ctrl: ctrl}
+	mock.recorder = &MockFilterRuleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFilterRuleRepository) EXPECT() *MockFilterRuleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockFilterRuleRepository) Delete(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFilterRuleRepositoryMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFilterRuleRepository)(nil).Delete), arg0)
+}
+
+// GetByChannelID mocks base method.
+func (m *MockFilterRuleRepository) GetByChannelID(arg0 string) ([]*model.FilterRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByChannelID", arg0)
+	ret0, _ := ret[0].([]*model.FilterRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByChannelID indicates an expected call of GetByChannelID.
+func (mr *MockFilterRuleRepositoryMockRecorder) GetByChannelID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChannelID", reflect.TypeOf((*MockFilterRuleRepository)(nil).GetByChannelID), arg0)
+}
+
+// GetByID mocks base method.
+func (m *MockFilterRuleRepository) GetByID(arg0 string) (*model.FilterRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0)
+	ret0, _ := ret[0].(*model.FilterRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockFilterRuleRepositoryMockRecorder) GetByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockFilterRuleRepository)(nil).GetByID), arg0)
+}
+
+// Save mocks base method.
+func (m *MockFilterRuleRepository) Save(arg0 *model.FilterRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockFilterRuleRepositoryMockRecorder) Save(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockFilterRuleRepository)(nil).Save), arg0)
+}
+
+// Update mocks base method.
+func (m *MockFilterRuleRepository) Update(arg0 *model.FilterRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockFilterRuleRepositoryMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFilterRuleRepository)(nil).Update), arg0)
+}
@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ntttrang/go-genai-slack-assistant/internal/service (interfaces: FilterRuleService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+// MockFilterRuleService is a mock of FilterRuleService interface.
+type MockFilterRuleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFilterRuleServiceMockRecorder
+}
+
+// MockFilterRuleServiceMockRecorder is the mock recorder for MockFilterRuleService.
+type MockFilterRuleServiceMockRecorder struct {
+	mock *MockFilterRuleService
+}
+
+// NewMockFilterRuleService creates a new mock instance.
+func NewMockFilterRuleService(ctrl *gomock.Controller) *MockFilterRuleService {
+	mock := &MockFilterRuleService{ctrl: ctrl}
+	mock.recorder = &MockFilterRuleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFilterRuleService) EXPECT() *MockFilterRuleServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateRule mocks base method.
+func (m *MockFilterRuleService) CreateRule(arg0 *model.FilterRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRule", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRule indicates an expected call of CreateRule.
+func (mr *MockFilterRuleServiceMockRecorder) CreateRule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockFilterRuleService)(nil).CreateRule), arg0)
+}
+
+// DeleteRule mocks base method.
+func (m *MockFilterRuleService) DeleteRule(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockFilterRuleServiceMockRecorder) DeleteRule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockFilterRuleService)(nil).DeleteRule), arg0)
+}
+
+// GetRule mocks base method.
+func (m *MockFilterRuleService) GetRule(arg0 string) (*model.FilterRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRule", arg0)
+	ret0, _ := ret[0].(*model.FilterRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRule indicates an expected call of GetRule.
+func (mr *MockFilterRuleServiceMockRecorder) GetRule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRule", reflect.TypeOf((*MockFilterRuleService)(nil).GetRule), arg0)
+}
+
+// ListRules mocks base method.
+func (m *MockFilterRuleService) ListRules(arg0 string) ([]*model.FilterRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRules", arg0)
+	ret0, _ := ret[0].([]*model.FilterRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRules indicates an expected call of ListRules.
+func (mr *MockFilterRuleServiceMockRecorder) ListRules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockFilterRuleService)(nil).ListRules), arg0)
+}
+
+// ShouldTranslate mocks base method.
+func (m *MockFilterRuleService) ShouldTranslate(arg0 model.FilterInput) (bool, string) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShouldTranslate", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(string)
+	return ret0, ret1
+}
+
+// ShouldTranslate indicates an expected call of ShouldTranslate.
+func (mr *MockFilterRuleServiceMockRecorder) ShouldTranslate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShouldTranslate", reflect.TypeOf((*MockFilterRuleService)(nil).ShouldTranslate), arg0)
+}
+
+// UpdateRule mocks base method.
+func (m *MockFilterRuleService) UpdateRule(arg0 *model.FilterRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRule", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRule indicates an expected call of UpdateRule.
+func (mr *MockFilterRuleServiceMockRecorder) UpdateRule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRule", reflect.TypeOf((*MockFilterRuleService)(nil).UpdateRule), arg0)
+}
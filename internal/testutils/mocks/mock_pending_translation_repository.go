@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ntttrang/go-genai-slack-assistant/internal/service (interfaces: PendingTranslationRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+// MockPendingTranslationRepository is a mock of PendingTranslationRepository interface.
+type MockPendingTranslationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPendingTranslationRepositoryMockRecorder
+}
+
+// MockPendingTranslationRepositoryMockRecorder is the mock recorder for MockPendingTranslationRepository.
+type MockPendingTranslationRepositoryMockRecorder struct {
+	mock *MockPendingTranslationRepository
+}
+
+// NewMockPendingTranslationRepository creates a new mock instance.
+func NewMockPendingTranslationRepository(ctrl *gomock.Controller) *MockPendingTranslationRepository {
+	mock := &MockPendingTranslationRepository{ctrl: ctrl}
+	mock.recorder = &MockPendingTranslationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPendingTranslationRepository) EXPECT() *MockPendingTranslationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockPendingTranslationRepository) GetByID(arg0 string) (*model.PendingTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0)
+	ret0, _ := ret[0].(*model.PendingTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPendingTranslationRepositoryMockRecorder) GetByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPendingTranslationRepository)(nil).GetByID), arg0)
+}
+
+// Save mocks base method.
+func (m *MockPendingTranslationRepository) Save(arg0 *model.PendingTranslation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPendingTranslationRepositoryMockRecorder) Save(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPendingTranslationRepository)(nil).Save), arg0)
+}
+
+// SetReviewMessage mocks base method.
+func (m *MockPendingTranslationRepository) SetReviewMessage(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReviewMessage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReviewMessage indicates an expected call of SetReviewMessage.
+func (mr *MockPendingTranslationRepositoryMockRecorder) SetReviewMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReviewMessage", reflect.TypeOf((*MockPendingTranslationRepository)(nil).SetReviewMessage), arg0, arg1)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockPendingTranslationRepository) UpdateStatus(arg0, arg1, arg2, arg3 string, arg4 *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockPendingTranslationRepositoryMockRecorder) UpdateStatus(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockPendingTranslationRepository)(nil).UpdateStatus), arg0, arg1, arg2, arg3, arg4)
+}
@@ -0,0 +1,92 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ntttrang/go-genai-slack-assistant/internal/service (interfaces: ReviewMessenger)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+// MockReviewMessenger is a mock of ReviewMessenger interface.
+type MockReviewMessenger struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewMessengerMockRecorder
+}
+
+// MockReviewMessengerMockRecorder is the mock recorder for MockReviewMessenger.
+type MockReviewMessengerMockRecorder struct {
+	mock *MockReviewMessenger
+}
+
+// NewMockReviewMessenger creates a new mock instance.
+func NewMockReviewMessenger(ctrl *gomock.Controller) *MockReviewMessenger {
+	mock := &MockReviewMessenger{ctrl: ctrl}
+	mock.recorder = &MockReviewMessengerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewMessenger) EXPECT() *MockReviewMessengerMockRecorder {
+	return m.recorder
+}
+
+// OpenCorrectionModal mocks base method.
+func (m *MockReviewMessenger) OpenCorrectionModal(arg0 string, arg1 *model.PendingTranslation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenCorrectionModal", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OpenCorrectionModal indicates an expected call of OpenCorrectionModal.
+func (mr *MockReviewMessengerMockRecorder) OpenCorrectionModal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenCorrectionModal", reflect.TypeOf((*MockReviewMessenger)(nil).OpenCorrectionModal), arg0, arg1)
+}
+
+// PostApprovedTranslation mocks base method.
+func (m *MockReviewMessenger) PostApprovedTranslation(arg0 *model.PendingTranslation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostApprovedTranslation", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostApprovedTranslation indicates an expected call of PostApprovedTranslation.
+func (mr *MockReviewMessengerMockRecorder) PostApprovedTranslation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostApprovedTranslation", reflect.TypeOf((*MockReviewMessenger)(nil).PostApprovedTranslation), arg0)
+}
+
+// PostReviewRequest mocks base method.
+func (m *MockReviewMessenger) PostReviewRequest(arg0 *model.PendingTranslation) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostReviewRequest", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostReviewRequest indicates an expected call of PostReviewRequest.
+func (mr *MockReviewMessengerMockRecorder) PostReviewRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostReviewRequest", reflect.TypeOf((*MockReviewMessenger)(nil).PostReviewRequest), arg0)
+}
+
+// UpdateReviewRequest mocks base method.
+func (m *MockReviewMessenger) UpdateReviewRequest(arg0 *model.PendingTranslation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReviewRequest", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateReviewRequest indicates an expected call of UpdateReviewRequest.
+func (mr *MockReviewMessengerMockRecorder) UpdateReviewRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReviewRequest", reflect.TypeOf((*MockReviewMessenger)(nil).UpdateReviewRequest), arg0)
+}
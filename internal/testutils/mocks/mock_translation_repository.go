@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,60 +36,60 @@ func (m *MockTranslationRepository) EXPECT() *MockTranslationRepositoryMockRecor
 }
 
 // GetByChannelID mocks base method.
-func (m *MockTranslationRepository) GetByChannelID(arg0 string, arg1 int) ([]*model.Translation, error) {
+func (m *MockTranslationRepository) GetByChannelID(arg0 context.Context, arg1 string, arg2 int) ([]*model.Translation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByChannelID", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetByChannelID", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]*model.Translation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByChannelID indicates an expected call of GetByChannelID.
-func (mr *MockTranslationRepositoryMockRecorder) GetByChannelID(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockTranslationRepositoryMockRecorder) GetByChannelID(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChannelID", reflect.TypeOf((*MockTranslationRepository)(nil).GetByChannelID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChannelID", reflect.TypeOf((*MockTranslationRepository)(nil).GetByChannelID), arg0, arg1, arg2)
 }
 
 // GetByHash mocks base method.
-func (m *MockTranslationRepository) GetByHash(arg0 string) (*model.Translation, error) {
+func (m *MockTranslationRepository) GetByHash(arg0 context.Context, arg1 string) (*model.Translation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByHash", arg0)
+	ret := m.ctrl.Call(m, "GetByHash", arg0, arg1)
 	ret0, _ := ret[0].(*model.Translation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByHash indicates an expected call of GetByHash.
-func (mr *MockTranslationRepositoryMockRecorder) GetByHash(arg0 interface{}) *gomock.Call {
+func (mr *MockTranslationRepositoryMockRecorder) GetByHash(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockTranslationRepository)(nil).GetByHash), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockTranslationRepository)(nil).GetByHash), arg0, arg1)
 }
 
 // GetByID mocks base method.
-func (m *MockTranslationRepository) GetByID(arg0 string) (*model.Translation, error) {
+func (m *MockTranslationRepository) GetByID(arg0 context.Context, arg1 string) (*model.Translation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", arg0)
+	ret := m.ctrl.Call(m, "GetByID", arg0, arg1)
 	ret0, _ := ret[0].(*model.Translation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockTranslationRepositoryMockRecorder) GetByID(arg0 interface{}) *gomock.Call {
+func (mr *MockTranslationRepositoryMockRecorder) GetByID(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTranslationRepository)(nil).GetByID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTranslationRepository)(nil).GetByID), arg0, arg1)
 }
 
 // Save mocks base method.
-func (m *MockTranslationRepository) Save(arg0 *model.Translation) error {
+func (m *MockTranslationRepository) Save(arg0 context.Context, arg1 *model.Translation) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Save", arg0)
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Save indicates an expected call of Save.
-func (mr *MockTranslationRepositoryMockRecorder) Save(arg0 interface{}) *gomock.Call {
+func (mr *MockTranslationRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockTranslationRepository)(nil).Save), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockTranslationRepository)(nil).Save), arg0, arg1)
 }
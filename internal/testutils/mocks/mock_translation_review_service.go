@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ntttrang/go-genai-slack-assistant/internal/service (interfaces: TranslationReviewService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+// MockTranslationReviewService is a mock of TranslationReviewService interface.
+type MockTranslationReviewService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTranslationReviewServiceMockRecorder
+}
+
+// MockTranslationReviewServiceMockRecorder is the mock recorder for MockTranslationReviewService.
+type MockTranslationReviewServiceMockRecorder struct {
+	mock *MockTranslationReviewService
+}
+
+// NewMockTranslationReviewService creates a new mock instance.
+func NewMockTranslationReviewService(ctrl *gomock.Controller) *MockTranslationReviewService {
+	mock := &MockTranslationReviewService{ctrl: ctrl}
+	mock.recorder = &MockTranslationReviewServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTranslationReviewService) EXPECT() *MockTranslationReviewServiceMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockTranslationReviewService) Approve(arg0, arg1 string) (*model.PendingTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", arg0, arg1)
+	ret0, _ := ret[0].(*model.PendingTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockTranslationReviewServiceMockRecorder) Approve(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockTranslationReviewService)(nil).Approve), arg0, arg1)
+}
+
+// ApproveWithCorrection mocks base method.
+func (m *MockTranslationReviewService) ApproveWithCorrection(arg0, arg1, arg2 string) (*model.PendingTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveWithCorrection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.PendingTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveWithCorrection indicates an expected call of ApproveWithCorrection.
+func (mr *MockTranslationReviewServiceMockRecorder) ApproveWithCorrection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveWithCorrection", reflect.TypeOf((*MockTranslationReviewService)(nil).ApproveWithCorrection), arg0, arg1, arg2)
+}
+
+// Reject mocks base method.
+func (m *MockTranslationReviewService) Reject(arg0, arg1 string) (*model.PendingTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", arg0, arg1)
+	ret0, _ := ret[0].(*model.PendingTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockTranslationReviewServiceMockRecorder) Reject(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockTranslationReviewService)(nil).Reject), arg0, arg1)
+}
+
+// StartCorrection mocks base method.
+func (m *MockTranslationReviewService) StartCorrection(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartCorrection", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartCorrection indicates an expected call of StartCorrection.
+func (mr *MockTranslationReviewServiceMockRecorder) StartCorrection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCorrection", reflect.TypeOf((*MockTranslationReviewService)(nil).StartCorrection), arg0, arg1)
+}
+
+// Submit mocks base method.
+func (m *MockTranslationReviewService) Submit(arg0 *model.PendingTranslation) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Submit", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Submit indicates an expected call of Submit.
+func (mr *MockTranslationReviewServiceMockRecorder) Submit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockTranslationReviewService)(nil).Submit), arg0)
+}
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -50,6 +51,21 @@ func (mr *MockTranslationServiceMockRecorder) DetectLanguage(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetectLanguage", reflect.TypeOf((*MockTranslationService)(nil).DetectLanguage), arg0)
 }
 
+// GetTranslation mocks base method.
+func (m *MockTranslationService) GetTranslation(arg0 context.Context, arg1 string) (response.Translation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTranslation", arg0, arg1)
+	ret0, _ := ret[0].(response.Translation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTranslation indicates an expected call of GetTranslation.
+func (mr *MockTranslationServiceMockRecorder) GetTranslation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTranslation", reflect.TypeOf((*MockTranslationService)(nil).GetTranslation), arg0, arg1)
+}
+
 // Translate mocks base method.
 func (m *MockTranslationService) Translate(arg0 request.Translation) (response.Translation, error) {
 	m.ctrl.T.Helper()
@@ -64,3 +80,18 @@ func (mr *MockTranslationServiceMockRecorder) Translate(arg0 interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Translate", reflect.TypeOf((*MockTranslationService)(nil).Translate), arg0)
 }
+
+// TranslateContext mocks base method.
+func (m *MockTranslationService) TranslateContext(arg0 context.Context, arg1 request.Translation) (response.Translation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TranslateContext", arg0, arg1)
+	ret0, _ := ret[0].(response.Translation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TranslateContext indicates an expected call of TranslateContext.
+func (mr *MockTranslationServiceMockRecorder) TranslateContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TranslateContext", reflect.TypeOf((*MockTranslationService)(nil).TranslateContext), arg0, arg1)
+}
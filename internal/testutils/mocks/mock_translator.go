@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/ntttrang/go-genai-slack-assistant/internal/translator (interfaces: Translator)
+// Source: github.com/ntttrang/go-genai-slack-assistant/internal/service (interfaces: Translator)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ntttrang/go-genai-slack-assistant/internal/service (interfaces: UserPreferenceRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ntttrang/go-genai-slack-assistant/internal/model"
+)
+
+// MockUserPreferenceRepository is a mock of UserPreferenceRepository interface.
+type MockUserPreferenceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserPreferenceRepositoryMockRecorder
+}
+
+// MockUserPreferenceRepositoryMockRecorder is the mock recorder for MockUserPreferenceRepository.
+type MockUserPreferenceRepositoryMockRecorder struct {
+	mock *MockUserPreferenceRepository
+}
+
+// NewMockUserPreferenceRepository creates a new mock instance.
+func NewMockUserPreferenceRepository(ctrl *gomock.Controller) *MockUserPreferenceRepository {
+	mock := &MockUserPreferenceRepository{ctrl: ctrl}
+	mock.recorder = &MockUserPreferenceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserPreferenceRepository) EXPECT() *MockUserPreferenceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByUserID mocks base method.
+func (m *MockUserPreferenceRepository) GetByUserID(arg0 string) (*model.UserPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", arg0)
+	ret0, _ := ret[0].(*model.UserPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockUserPreferenceRepositoryMockRecorder) GetByUserID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockUserPreferenceRepository)(nil).GetByUserID), arg0)
+}
+
+// Save mocks base method.
+func (m *MockUserPreferenceRepository) Save(arg0 *model.UserPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockUserPreferenceRepositoryMockRecorder) Save(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUserPreferenceRepository)(nil).Save), arg0)
+}
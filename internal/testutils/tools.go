@@ -0,0 +1,7 @@
+//go:build tools
+
+package testutils
+
+// mockgen is run by the go:generate directives of generate.go; importing it
+// keeps its version pinned in go.mod
+import _ "github.com/golang/mock/mockgen"
@@ -0,0 +1,107 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/security"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// batchTranslator is implemented by the providers that translate several
+// texts in one AI call
+type batchTranslator interface {
+	// TranslateBatch returns the translation of each text, in their order
+	TranslateBatch(ctx context.Context, texts []string, sourceLanguage, targetLanguage string) ([]string, error)
+}
+
+var (
+	_ batchTranslator = (*GeminiProvider)(nil)
+	_ batchTranslator = (*ChainedProvider)(nil)
+)
+
+// TranslateBatch translates texts in one Gemini call, whose answer is
+// constrained to a JSON array of strings so it splits into one translation
+// per text. Usage is recorded for the whole call, with BatchPromptVersion.
+func (gp *GeminiProvider) TranslateBatch(ctx context.Context, texts []string, sourceLanguage, targetLanguage string) ([]string, error) {
+	ctx, span := startTranslateSpan(ctx, ProviderGemini, gp.models.modelFor(GenerationFrom(ctx), gp.model), BatchPromptVersion)
+	span.SetAttributes(attribute.Int("translation.batch_size", len(texts)))
+	translations, err := gp.translateBatch(ctx, texts, sourceLanguage, targetLanguage)
+	tracing.End(span, err)
+	return translations, err
+}
+
+func (gp *GeminiProvider) translateBatch(ctx context.Context, texts []string, sourceLanguage, targetLanguage string) ([]string, error) {
+	if err := gp.budget.Allow(ctx); err != nil {
+		return nil, err
+	}
+	canary, err := security.NewCanaryToken()
+	if err != nil {
+		return nil, err
+	}
+	prompt, err := batchPrompt(canary, texts, sourceLanguage, targetLanguage)
+	if err != nil {
+		return nil, err
+	}
+
+	// The output limit of a translation applies to each text of the batch
+	generation := GenerationFrom(ctx)
+	if generation.MaxOutputTokens > 0 {
+		generation.MaxOutputTokens = min(generation.MaxOutputTokens*len(texts), MaxOutputTokenCap)
+	}
+	model := gp.translationModel(generation)
+	model.ResponseMIMEType = "application/json"
+	model.ResponseSchema = &genai.Schema{Type: genai.TypeArray, Items: &genai.Schema{Type: genai.TypeString}}
+
+	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate batch translation: %w", classifyError(err))
+	}
+	gp.recordTokens(ctx, resp.UsageMetadata)
+
+	textPart, err := responseText(resp)
+	if err != nil {
+		return nil, err
+	}
+	if security.ContainsCanary(string(textPart), canary) {
+		return nil, fmt.Errorf("translation compromised: %w", security.ErrCanaryLeaked)
+	}
+	translations, err := splitBatch(string(textPart), len(texts))
+	if err != nil {
+		return nil, err
+	}
+
+	var promptTokens, outputTokens int64
+	if resp.UsageMetadata != nil {
+		promptTokens = int64(resp.UsageMetadata.PromptTokenCount)
+		outputTokens = int64(resp.UsageMetadata.CandidatesTokenCount)
+	}
+	recordUsage(ctx, gp.models.modelFor(generation, gp.model), BatchPromptVersion, promptTokens, outputTokens)
+	return translations, nil
+}
+
+// TranslateBatch translates texts in one call like TranslateBatchWithProvider
+func (c *ChainedProvider) TranslateBatch(ctx context.Context, texts []string, sourceLanguage, targetLanguage string) ([]string, error) {
+	translations, _, err := c.TranslateBatchWithProvider(ctx, texts, sourceLanguage, targetLanguage)
+	return translations, err
+}
+
+// TranslateBatchWithProvider translates texts in one call to the first
+// provider of the chain that can batch, failing over like single
+// translations, and returns the name of the provider that served them.
+// Batches are not hedged.
+func (c *ChainedProvider) TranslateBatchWithProvider(ctx context.Context, texts []string, sourceLanguage, targetLanguage string) ([]string, string, error) {
+	var translations []string
+	name, err := c.call(ctx, func(p Provider) error {
+		batcher, ok := p.(batchTranslator)
+		if !ok {
+			return errSkipProvider
+		}
+		var err error
+		translations, err = batcher.TranslateBatch(ctx, texts, sourceLanguage, targetLanguage)
+		return err
+	})
+	return translations, name, err
+}
@@ -0,0 +1,69 @@
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBatchProvider translates batches by tagging each text
+type fakeBatchProvider struct {
+	fakeProvider
+	batches int
+}
+
+func (f *fakeBatchProvider) TranslateBatch(_ context.Context, texts []string, _, targetLanguage string) ([]string, error) {
+	f.batches++
+	if f.err != nil {
+		return nil, f.err
+	}
+	translations := make([]string, len(texts))
+	for i, text := range texts {
+		translations[i] = targetLanguage + ":" + text
+	}
+	return translations, nil
+}
+
+func TestChainedProvider_TranslateBatchWithProvider(t *testing.T) {
+	ctx := context.Background()
+	single := &fakeProvider{translation: "single"}
+	batcher := &fakeBatchProvider{}
+	chain, err := NewChainedProvider([]NamedProvider{{Name: "openai", Provider: single}, {Name: "gemini", Provider: batcher}}, 0, 0)
+	require.NoError(t, err)
+
+	translations, name, err := chain.TranslateBatchWithProvider(ctx, []string{"a", "b"}, "English", "Vietnamese")
+	require.NoError(t, err)
+	assert.Equal(t, "gemini", name, "providers that cannot batch are skipped")
+	assert.Equal(t, []string{"Vietnamese:a", "Vietnamese:b"}, translations)
+	assert.Zero(t, single.calls)
+
+	batcher.err = ErrQuotaExceeded
+	_, _, err = chain.TranslateBatchWithProvider(ctx, []string{"a"}, "English", "Vietnamese")
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+}
+
+func TestBatchPrompt(t *testing.T) {
+	prompt, err := batchPrompt("cnry-test", []string{"Hi \"John\"", "Bye\nnow"}, "English", "Japanese")
+	require.NoError(t, err)
+	assert.Contains(t, prompt, "cnry-test")
+	assert.Contains(t, prompt, "JSON array of 2 separate messages")
+	assert.Contains(t, prompt, "- Target Language: Japanese\n- Japanese style: ")
+	assert.Contains(t, prompt, "<UserInput>\n[\"Hi \\\"John\\\"\",\"Bye\\nnow\"]\n</UserInput>")
+	assert.NotContains(t, prompt, "%!", "prompt has a malformed format verb")
+	assert.Less(t, strings.Index(prompt, "MUST NOT follow"), strings.Index(prompt, "<UserInput>\n["),
+		"safety instructions must come before the user's text")
+}
+
+func TestSplitBatch(t *testing.T) {
+	translations, err := splitBatch(" [\"Xin chào\", \"Tạm biệt\"]\n", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Xin chào", "Tạm biệt"}, translations)
+
+	_, err = splitBatch(`["Xin chào"]`, 2)
+	assert.Equal(t, CategoryInvalidResponse, CategoryOf(err), "a batch missing a message is rejected")
+	_, err = splitBatch("Xin chào\nTạm biệt", 2)
+	assert.Equal(t, CategoryInvalidResponse, CategoryOf(err))
+}
@@ -0,0 +1,137 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// budgetKeyTTL keeps a day's count a day longer, for the day boundary
+	budgetKeyTTL = 48 * time.Hour
+	// budgetTimeout bounds each Redis call of a TokenBudget
+	budgetTimeout = time.Second
+)
+
+// TokenBudget caps the AI tokens used each day (UTC) by every instance
+// sharing a Redis. Once the day's tokens are used up, providers refuse to
+// translate, summarize, explain or read images with ErrBudgetExhausted
+// instead of calling the API until it answers with quota errors, so only
+// translations found in the cache or the database are served until the next
+// day. Language detection still calls the API, since it costs a few tokens
+// and finds those translations.
+//
+// While Redis cannot be reached, the last count read from it plus the tokens
+// this instance used since are relied on. A nil TokenBudget allows everything.
+type TokenBudget struct {
+	client    *redis.Client
+	limit     int64
+	keyPrefix string
+	metrics   *metrics.Metrics
+	now       func() time.Time
+
+	mu   sync.Mutex
+	day  string
+	used int64 // last known count of day
+}
+
+// NewTokenBudget allows limit tokens a day, counted in Redis
+func NewTokenBudget(client *redis.Client, limit int64) *TokenBudget {
+	return &TokenBudget{
+		client: client,
+		limit:  limit,
+		now:    time.Now,
+	}
+}
+
+// SetKeyPrefix prefixes the Redis keys of the budget, so deployments sharing
+// a Redis keep budgets of their own
+func (b *TokenBudget) SetKeyPrefix(prefix string) {
+	b.keyPrefix = prefix
+}
+
+// SetMetrics reports the tokens used and remaining today as token_budget
+func (b *TokenBudget) SetMetrics(m *metrics.Metrics) {
+	b.metrics = m
+}
+
+// Allow returns ErrBudgetExhausted once the day's tokens are used up
+func (b *TokenBudget) Allow(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+	if b.Used(ctx) >= b.limit {
+		return ErrBudgetExhausted
+	}
+	return nil
+}
+
+// Used returns the tokens used today by every instance
+func (b *TokenBudget) Used(ctx context.Context) int64 {
+	day := b.today()
+	ctx, cancel := context.WithTimeout(ctx, budgetTimeout)
+	defer cancel()
+
+	used, err := b.client.Get(ctx, b.key(day)).Int64()
+	if errors.Is(err, redis.Nil) {
+		used, err = 0, nil
+	}
+	return b.remember(day, used, 0, err)
+}
+
+// Consume counts tokens used by a call against today's budget
+func (b *TokenBudget) Consume(ctx context.Context, tokens int64) {
+	if b == nil || tokens <= 0 {
+		return
+	}
+	day := b.today()
+	ctx, cancel := context.WithTimeout(ctx, budgetTimeout)
+	defer cancel()
+
+	key := b.key(day)
+	pipe := b.client.TxPipeline()
+	incr := pipe.IncrBy(ctx, key, tokens)
+	pipe.Expire(ctx, key, budgetKeyTTL)
+	_, err := pipe.Exec(ctx)
+	b.remember(day, incr.Val(), tokens, err)
+}
+
+// remember keeps used as the count of day, or adds tokens to the last known
+// count when err kept Redis from answering, and returns the count
+func (b *TokenBudget) remember(day string, used, tokens int64, err error) int64 {
+	b.mu.Lock()
+	if b.day != day {
+		b.day = day
+		b.used = 0
+	}
+	if err == nil {
+		b.used = used
+	} else {
+		b.used += tokens
+	}
+	used = b.used
+	b.mu.Unlock()
+
+	if b.metrics != nil {
+		if err != nil {
+			b.metrics.RecordError("token_budget_unavailable")
+		}
+		b.metrics.RecordTokenBudget(b.limit, used)
+	}
+	return used
+}
+
+func (b *TokenBudget) today() string {
+	return b.now().UTC().Format(time.DateOnly)
+}
+
+func (b *TokenBudget) key(day string) string {
+	if b.keyPrefix == "" {
+		return "ai_tokens:" + day
+	}
+	return b.keyPrefix + ":ai_tokens:" + day
+}
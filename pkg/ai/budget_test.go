@@ -0,0 +1,124 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBudget(t *testing.T, limit int64) (*TokenBudget, *miniredis.Miniredis) {
+	t.Helper()
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return NewTokenBudget(client, limit), mr
+}
+
+func TestTokenBudget_SharedDailyCount(t *testing.T) {
+	ctx := context.Background()
+	budget, mr := newTestBudget(t, 100)
+	m := metrics.NewMetrics()
+	budget.SetKeyPrefix("staging")
+	budget.SetMetrics(m)
+	now := time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC)
+	budget.now = func() time.Time { return now }
+
+	// Another instance sharing the Redis counts against the same budget
+	other := NewTokenBudget(budget.client, 100)
+	other.SetKeyPrefix("staging")
+	other.now = budget.now
+
+	require.NoError(t, budget.Allow(ctx))
+	budget.Consume(ctx, 60)
+	other.Consume(ctx, 30)
+	require.NoError(t, budget.Allow(ctx))
+	assert.Equal(t, int64(90), budget.Used(ctx))
+	assert.Equal(t, "90", mustGet(t, mr, "staging:ai_tokens:2026-03-01"))
+	assert.Equal(t, metrics.TokenBudgetGauges{Limit: 100, Used: 90, Remaining: 10}, m.TokenBudget)
+
+	other.Consume(ctx, 15)
+	assert.ErrorIs(t, budget.Allow(ctx), ErrBudgetExhausted)
+	assert.Equal(t, CategoryBudgetExhausted, CategoryOf(budget.Allow(ctx)))
+	assert.Equal(t, metrics.TokenBudgetGauges{Limit: 100, Used: 105, Exhausted: true}, m.TokenBudget)
+
+	// The budget is renewed at midnight UTC
+	now = now.Add(time.Hour)
+	require.NoError(t, budget.Allow(ctx))
+	assert.Equal(t, int64(0), budget.Used(ctx))
+}
+
+func TestTokenBudget_RedisDown(t *testing.T) {
+	ctx := context.Background()
+	budget, mr := newTestBudget(t, 100)
+	m := metrics.NewMetrics()
+	budget.SetMetrics(m)
+
+	budget.Consume(ctx, 70)
+	mr.Close()
+
+	// The last count read from Redis and the tokens used since are relied on
+	require.NoError(t, budget.Allow(ctx))
+	budget.Consume(ctx, 40)
+	assert.ErrorIs(t, budget.Allow(ctx), ErrBudgetExhausted)
+	assert.Positive(t, m.ErrorsByType["token_budget_unavailable"])
+}
+
+func TestTokenBudget_Nil(t *testing.T) {
+	var budget *TokenBudget
+	assert.NoError(t, budget.Allow(context.Background()))
+	budget.Consume(context.Background(), 10)
+}
+
+func TestOpenAIProvider_Budget(t *testing.T) {
+	ctx := context.Background()
+	budget, _ := newTestBudget(t, 20)
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{{
+				"message":       map[string]string{"role": "assistant", "content": "Xin chào"},
+				"finish_reason": "stop",
+			}},
+			"usage": map[string]int64{"prompt_tokens": 20, "completion_tokens": 5, "total_tokens": 25},
+		})
+	}))
+	defer server.Close()
+
+	provider, err := NewOpenAIProvider(ProviderConfig{APIKey: "sk-test", Model: "gpt-test", BaseURL: server.URL, Budget: budget})
+	require.NoError(t, err)
+
+	_, err = provider.TranslateContext(ctx, "Hello", "English", "Vietnamese")
+	require.NoError(t, err)
+	assert.Equal(t, int64(25), budget.Used(ctx))
+
+	// Once the budget is used up the API is not called
+	_, err = provider.TranslateContext(ctx, "Hello", "English", "Vietnamese")
+	assert.ErrorIs(t, err, ErrBudgetExhausted)
+	_, err = provider.Summarize(ctx, "Alice: Hello", "English")
+	assert.ErrorIs(t, err, ErrBudgetExhausted)
+	assert.Equal(t, 1, calls)
+
+	// Language detection still is, so stored translations are found
+	_, err = provider.DetectLanguage("Hello")
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func mustGet(t *testing.T, mr *miniredis.Miniredis, key string) string {
+	t.Helper()
+	value, err := mr.Get(key)
+	require.NoError(t, err)
+	return value
+}
@@ -0,0 +1,302 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+)
+
+// Default circuit breaker settings of a ChainedProvider
+const (
+	DefaultBreakerFailures = 3
+	DefaultBreakerCooldown = 30 * time.Second
+)
+
+// ErrProvidersUnavailable is returned when the circuit of every provider in a
+// chain is open, so no provider was tried
+var ErrProvidersUnavailable = errors.New("all AI providers are unavailable")
+
+var _ Provider = (*ChainedProvider)(nil)
+
+// NamedProvider is a provider in a chain, named for logs, metrics and the
+// provider recorded with each translation
+type NamedProvider struct {
+	Name     string
+	Provider Provider
+}
+
+// ChainedProvider calls an ordered list of providers, failing over to the
+// next one when a provider is over quota, times out or fails. Each provider
+// has a circuit breaker: after failureThreshold consecutive failures it is
+// skipped for cooldown, then a single trial call decides whether it is used again.
+//
+// Safety blocks and leaked canaries are returned as-is: another provider
+// would see the same content, and they are not a sign of an unhealthy provider.
+type ChainedProvider struct {
+	providers []NamedProvider
+	breakers  []*circuitBreaker
+	metrics   *metrics.Metrics
+	hedging   *hedgePolicy
+	now       func() time.Time
+}
+
+// NewChainedProvider chains providers in order of preference. A
+// failureThreshold or cooldown of zero uses the defaults.
+func NewChainedProvider(providers []NamedProvider, failureThreshold int, cooldown time.Duration) (*ChainedProvider, error) {
+	if len(providers) == 0 {
+		return nil, errors.New("at least one AI provider is required")
+	}
+	if failureThreshold <= 0 {
+		failureThreshold = DefaultBreakerFailures
+	}
+	if cooldown <= 0 {
+		cooldown = DefaultBreakerCooldown
+	}
+
+	breakers := make([]*circuitBreaker, len(providers))
+	for i := range providers {
+		breakers[i] = &circuitBreaker{threshold: failureThreshold, cooldown: cooldown}
+	}
+	return &ChainedProvider{
+		providers: providers,
+		breakers:  breakers,
+		now:       time.Now,
+	}, nil
+}
+
+// SetMetrics counts failovers and opened circuits in the error metrics
+func (c *ChainedProvider) SetMetrics(m *metrics.Metrics) {
+	c.metrics = m
+}
+
+func (c *ChainedProvider) Translate(text, sourceLanguage, targetLanguage string) (string, error) {
+	return c.TranslateContext(context.Background(), text, sourceLanguage, targetLanguage)
+}
+
+func (c *ChainedProvider) TranslateContext(ctx context.Context, text, sourceLanguage, targetLanguage string) (string, error) {
+	translated, _, err := c.TranslateWithProvider(ctx, text, sourceLanguage, targetLanguage)
+	return translated, err
+}
+
+// TranslateWithProvider translates like TranslateContext and also returns the
+// name of the provider that served the translation
+func (c *ChainedProvider) TranslateWithProvider(ctx context.Context, text, sourceLanguage, targetLanguage string) (string, string, error) {
+	if c.hedging == nil {
+		return c.translateFrom(ctx, 0, text, sourceLanguage, targetLanguage)
+	}
+
+	start := c.now()
+	var translated, name string
+	var err error
+	if delay, ok := c.hedging.delay(); ok {
+		translated, name, err = c.translateHedged(ctx, delay, text, sourceLanguage, targetLanguage)
+	} else {
+		translated, name, err = c.translateFrom(ctx, 0, text, sourceLanguage, targetLanguage)
+	}
+	if err == nil {
+		c.hedging.observe(c.now().Sub(start))
+	}
+	return translated, name, err
+}
+
+// translateFrom translates with the providers of the chain from index start
+func (c *ChainedProvider) translateFrom(ctx context.Context, start int, text, sourceLanguage, targetLanguage string) (string, string, error) {
+	var translated string
+	name, err := c.callFrom(ctx, start, func(p Provider) error {
+		var err error
+		translated, err = p.TranslateContext(ctx, text, sourceLanguage, targetLanguage)
+		return err
+	})
+	return translated, name, err
+}
+
+func (c *ChainedProvider) DetectLanguage(text string) (string, error) {
+	var language string
+	_, err := c.call(context.Background(), func(p Provider) error {
+		var err error
+		language, err = p.DetectLanguage(text)
+		return err
+	})
+	return language, err
+}
+
+// Close closes every provider in the chain
+func (c *ChainedProvider) Close() error {
+	var errs []error
+	for _, p := range c.providers {
+		if err := p.Provider.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// errSkipProvider is returned by a call's fn for a provider that cannot
+// serve the call, which is then skipped without counting as a failure
+var errSkipProvider = errors.New("provider skipped")
+
+// call runs fn against each provider whose circuit allows it, in order, until
+// one succeeds or fails with an error another provider would not fix. It
+// returns the name of the provider that produced the result.
+func (c *ChainedProvider) call(ctx context.Context, fn func(Provider) error) (string, error) {
+	return c.callFrom(ctx, 0, fn)
+}
+
+// callFrom runs fn like call, skipping the providers before index start
+func (c *ChainedProvider) callFrom(ctx context.Context, start int, fn func(Provider) error) (string, error) {
+	var lastErr error
+	for i := start; i < len(c.providers); i++ {
+		p := c.providers[i]
+		breaker := c.breakers[i]
+		if !breaker.allow(c.now()) {
+			continue
+		}
+
+		err := fn(p.Provider)
+		switch {
+		case errors.Is(err, errSkipProvider):
+			breaker.release()
+			continue
+		case err == nil:
+			breaker.success()
+			return p.Name, nil
+		case ctx.Err() != nil:
+			// The caller gave up; that says nothing about the provider
+			breaker.release()
+			return p.Name, err
+		case !shouldFailover(err):
+			breaker.success()
+			return p.Name, err
+		}
+
+		if breaker.failure(c.now()) {
+			c.recordError("ai_circuit_open")
+		}
+		lastErr = fmt.Errorf("%s: %w", p.Name, err)
+		if i < len(c.providers)-1 {
+			c.recordError("ai_failover")
+		}
+	}
+
+	if lastErr == nil {
+		return "", ErrProvidersUnavailable
+	}
+	return "", lastErr
+}
+
+// Saturation returns the share of the providers of the chain that refuse
+// calls because their circuit is open, from 0 to 1
+func (c *ChainedProvider) Saturation() float64 {
+	now := c.now()
+	unavailable := 0
+	for _, breaker := range c.breakers {
+		if breaker.unavailable(now) {
+			unavailable++
+		}
+	}
+	return float64(unavailable) / float64(len(c.breakers))
+}
+
+func (c *ChainedProvider) recordError(errorType string) {
+	if c.metrics != nil {
+		c.metrics.RecordError(errorType)
+	}
+}
+
+// shouldFailover reports whether err is a provider failure worth retrying on
+// the next provider. Uncategorized errors, such as a leaked canary, are not.
+func shouldFailover(err error) bool {
+	switch CategoryOf(err) {
+	case CategoryQuotaExceeded, CategoryTimeout, CategoryInvalidResponse, CategoryAuthFailed, CategoryUnknown:
+		return true
+	}
+	return false
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// circuitBreaker stops calls to a provider after threshold consecutive
+// failures. Once cooldown has passed, one trial call is let through: success
+// closes the circuit, failure opens it for another cooldown.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+// allow reports whether a call may be made now
+func (b *circuitBreaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if now.Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		// A trial call is already in flight
+		return false
+	}
+	return true
+}
+
+// unavailable reports whether calls are refused now, without starting a trial
+func (b *circuitBreaker) unavailable(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case breakerOpen:
+		return now.Sub(b.openedAt) < b.cooldown
+	case breakerHalfOpen:
+		return true
+	}
+	return false
+}
+
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = breakerClosed
+	b.failures = 0
+}
+
+// failure records a failed call and reports whether it opened the circuit
+func (b *circuitBreaker) failure(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		opened := b.state != breakerOpen
+		b.state = breakerOpen
+		b.openedAt = now
+		return opened
+	}
+	return false
+}
+
+// release ends a call without a verdict, letting the next call be the trial
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == breakerHalfOpen {
+		b.state = breakerOpen
+	}
+}
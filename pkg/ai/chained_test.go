@@ -0,0 +1,254 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProvider struct {
+	translation string
+	err         error
+	calls       int
+}
+
+func (f *fakeProvider) Translate(text, sourceLanguage, targetLanguage string) (string, error) {
+	return f.TranslateContext(context.Background(), text, sourceLanguage, targetLanguage)
+}
+
+func (f *fakeProvider) TranslateContext(ctx context.Context, text, sourceLanguage, targetLanguage string) (string, error) {
+	f.calls++
+	if f.err != nil {
+		return "", f.err
+	}
+	return f.translation, nil
+}
+
+func (f *fakeProvider) DetectLanguage(text string) (string, error) {
+	f.calls++
+	if f.err != nil {
+		return "", f.err
+	}
+	return "English", nil
+}
+
+func (f *fakeProvider) Close() error {
+	return nil
+}
+
+func TestChainedProvider_TranslateWithProvider(t *testing.T) {
+	tests := []struct {
+		name         string
+		primaryErr   error
+		wantProvider string
+		wantText     string
+		wantErr      error
+		fallbackUsed bool
+	}{
+		{
+			name:         "primary succeeds",
+			wantProvider: "gemini",
+			wantText:     "Xin chào (gemini)",
+		},
+		{
+			name:         "quota exceeded fails over",
+			primaryErr:   fmt.Errorf("failed to generate translation: %w", ErrQuotaExceeded),
+			wantProvider: "openai",
+			wantText:     "Xin chào (openai)",
+			fallbackUsed: true,
+		},
+		{
+			name:         "server error fails over",
+			primaryErr:   &ProviderError{Category: CategoryUnknown, Err: errors.New("503 unavailable")},
+			wantProvider: "openai",
+			wantText:     "Xin chào (openai)",
+			fallbackUsed: true,
+		},
+		{
+			name:         "safety block is returned",
+			primaryErr:   ErrSafetyBlocked,
+			wantProvider: "gemini",
+			wantErr:      ErrSafetyBlocked,
+		},
+		{
+			name:         "leaked canary is returned",
+			primaryErr:   fmt.Errorf("translation compromised: %w", security.ErrCanaryLeaked),
+			wantProvider: "gemini",
+			wantErr:      security.ErrCanaryLeaked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := &fakeProvider{translation: "Xin chào (gemini)", err: tt.primaryErr}
+			fallback := &fakeProvider{translation: "Xin chào (openai)"}
+			chain, err := NewChainedProvider([]NamedProvider{
+				{Name: "gemini", Provider: primary},
+				{Name: "openai", Provider: fallback},
+			}, 0, 0)
+			require.NoError(t, err)
+
+			text, provider, err := chain.TranslateWithProvider(context.Background(), "Hello", "English", "Vietnamese")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantText, text)
+			assert.Equal(t, tt.wantProvider, provider)
+			assert.Equal(t, tt.fallbackUsed, fallback.calls > 0)
+		})
+	}
+}
+
+func TestChainedProvider_AllProvidersFail(t *testing.T) {
+	chain, err := NewChainedProvider([]NamedProvider{
+		{Name: "gemini", Provider: &fakeProvider{err: ErrQuotaExceeded}},
+		{Name: "openai", Provider: &fakeProvider{err: ErrTimeout}},
+	}, 0, 0)
+	require.NoError(t, err)
+
+	_, provider, err := chain.TranslateWithProvider(context.Background(), "Hello", "English", "Vietnamese")
+
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.Equal(t, CategoryTimeout, CategoryOf(err))
+	assert.Empty(t, provider)
+}
+
+func TestChainedProvider_CircuitBreaker(t *testing.T) {
+	primary := &fakeProvider{translation: "Xin chào (gemini)", err: ErrQuotaExceeded}
+	fallback := &fakeProvider{translation: "Xin chào (openai)"}
+	chain, err := NewChainedProvider([]NamedProvider{
+		{Name: "gemini", Provider: primary},
+		{Name: "openai", Provider: fallback},
+	}, 2, time.Minute)
+	require.NoError(t, err)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	chain.now = func() time.Time { return now }
+
+	translate := func() string {
+		_, provider, err := chain.TranslateWithProvider(context.Background(), "Hello", "English", "Vietnamese")
+		require.NoError(t, err)
+		return provider
+	}
+
+	// Two failures open the circuit, so the third call skips the primary
+	assert.Equal(t, "openai", translate())
+	assert.Equal(t, "openai", translate())
+	assert.Equal(t, "openai", translate())
+	assert.Equal(t, 2, primary.calls)
+	assert.Equal(t, 0.5, chain.Saturation())
+
+	// After the cooldown a failed trial call opens the circuit again
+	now = now.Add(time.Minute)
+	assert.Equal(t, "openai", translate())
+	assert.Equal(t, 3, primary.calls)
+	assert.Equal(t, "openai", translate())
+	assert.Equal(t, 3, primary.calls)
+
+	// A successful trial call closes it
+	now = now.Add(time.Minute)
+	primary.err = nil
+	assert.Equal(t, "gemini", translate())
+	assert.Equal(t, "gemini", translate())
+	assert.Equal(t, 5, primary.calls)
+	assert.Zero(t, chain.Saturation())
+}
+
+func TestChainedProvider_AllCircuitsOpen(t *testing.T) {
+	chain, err := NewChainedProvider([]NamedProvider{
+		{Name: "gemini", Provider: &fakeProvider{err: ErrQuotaExceeded}},
+	}, 1, time.Minute)
+	require.NoError(t, err)
+
+	_, _, err = chain.TranslateWithProvider(context.Background(), "Hello", "English", "Vietnamese")
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+
+	_, _, err = chain.TranslateWithProvider(context.Background(), "Hello", "English", "Vietnamese")
+	assert.ErrorIs(t, err, ErrProvidersUnavailable)
+}
+
+// fakeSummarizer is a fakeProvider that also summarizes
+type fakeSummarizer struct {
+	fakeProvider
+	summary string
+}
+
+func (f *fakeSummarizer) Summarize(ctx context.Context, transcript, language string) (string, error) {
+	f.calls++
+	if f.err != nil {
+		return "", f.err
+	}
+	return f.summary, nil
+}
+
+func TestChainedProvider_Summarize(t *testing.T) {
+	translator := &fakeProvider{translation: "Xin chào"}
+	summarizer := &fakeSummarizer{summary: "• Launch moved to Friday"}
+	chain, err := NewChainedProvider([]NamedProvider{
+		{Name: "custom", Provider: translator},
+		{Name: "openai", Provider: summarizer},
+	}, 1, time.Minute)
+	require.NoError(t, err)
+
+	// Providers that cannot summarize are skipped, without opening their circuit
+	summary, err := chain.Summarize(context.Background(), "<@U1>: launch is on Friday", "English")
+	require.NoError(t, err)
+	assert.Equal(t, "• Launch moved to Friday", summary)
+	assert.Equal(t, 1, summarizer.calls)
+
+	_, provider, err := chain.TranslateWithProvider(context.Background(), "Hello", "English", "Vietnamese")
+	require.NoError(t, err)
+	assert.Equal(t, "custom", provider)
+
+	summarizer.err = ErrQuotaExceeded
+	_, err = chain.Summarize(context.Background(), "<@U1>: launch is on Friday", "English")
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+}
+
+// fakeExplainer is a fakeProvider that also explains
+type fakeExplainer struct {
+	fakeProvider
+	explanation string
+}
+
+func (f *fakeExplainer) Explain(ctx context.Context, text, language string) (string, error) {
+	f.calls++
+	if f.err != nil {
+		return "", f.err
+	}
+	return f.explanation, nil
+}
+
+func TestChainedProvider_Explain(t *testing.T) {
+	translator := &fakeProvider{translation: "Xin chào"}
+	explainer := &fakeExplainer{explanation: "A casual way to say the task is easy"}
+	chain, err := NewChainedProvider([]NamedProvider{
+		{Name: "custom", Provider: translator},
+		{Name: "openai", Provider: explainer},
+	}, 1, time.Minute)
+	require.NoError(t, err)
+
+	// Providers that cannot explain are skipped, without opening their circuit
+	explanation, err := chain.Explain(context.Background(), "It's a piece of cake", "Vietnamese")
+	require.NoError(t, err)
+	assert.Equal(t, "A casual way to say the task is easy", explanation)
+	assert.Equal(t, 1, explainer.calls)
+
+	_, provider, err := chain.TranslateWithProvider(context.Background(), "Hello", "English", "Vietnamese")
+	require.NoError(t, err)
+	assert.Equal(t, "custom", provider)
+}
+
+func TestNewChainedProvider_RequiresProvider(t *testing.T) {
+	_, err := NewChainedProvider(nil, 0, 0)
+
+	assert.Error(t, err)
+}
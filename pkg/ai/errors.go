@@ -0,0 +1,106 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/api/googleapi"
+)
+
+// ErrorCategory classifies why an AI provider call failed
+type ErrorCategory string
+
+const (
+	CategoryQuotaExceeded   ErrorCategory = "quota_exceeded"
+	CategorySafetyBlocked   ErrorCategory = "safety_blocked"
+	CategoryTimeout         ErrorCategory = "timeout"
+	CategoryInvalidResponse ErrorCategory = "invalid_response"
+	CategoryAuthFailed      ErrorCategory = "auth_failed"
+	CategoryBudgetExhausted ErrorCategory = "budget_exhausted"
+	CategoryUnknown         ErrorCategory = "provider_error"
+)
+
+// ProviderError is returned by GeminiProvider for every failed call. Match a
+// category with errors.Is against the Err* values below, or read it with CategoryOf.
+type ProviderError struct {
+	Category ErrorCategory
+	Err      error
+}
+
+var (
+	ErrQuotaExceeded = &ProviderError{Category: CategoryQuotaExceeded}
+	// ErrSafetyBlocked is returned when Gemini's safety filters block the prompt or the response
+	ErrSafetyBlocked   = &ProviderError{Category: CategorySafetyBlocked}
+	ErrTimeout         = &ProviderError{Category: CategoryTimeout}
+	ErrInvalidResponse = &ProviderError{Category: CategoryInvalidResponse}
+	ErrAuthFailed      = &ProviderError{Category: CategoryAuthFailed}
+	// ErrBudgetExhausted is returned without calling the provider once the
+	// daily token budget is used up
+	ErrBudgetExhausted = &ProviderError{Category: CategoryBudgetExhausted}
+)
+
+func (e *ProviderError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("ai provider: %s", e.Category)
+	}
+	return fmt.Sprintf("ai provider: %s: %v", e.Category, e.Err)
+}
+
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the Err* value for the same category
+func (e *ProviderError) Is(target error) bool {
+	t, ok := target.(*ProviderError)
+	return ok && t.Err == nil && t.Category == e.Category
+}
+
+// CategoryOf returns the category of the provider error in err's chain, or "" if there is none
+func CategoryOf(err error) ErrorCategory {
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) {
+		return providerErr.Category
+	}
+	return ""
+}
+
+// classifyError wraps an error from a Gemini call in a ProviderError
+func classifyError(err error) error {
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) {
+		return err
+	}
+
+	var blocked *genai.BlockedError
+	if errors.As(err, &blocked) {
+		if (blocked.Candidate != nil && blocked.Candidate.FinishReason == genai.FinishReasonSafety) ||
+			(blocked.PromptFeedback != nil && blocked.PromptFeedback.BlockReason == genai.BlockReasonSafety) {
+			return &ProviderError{Category: CategorySafetyBlocked, Err: err}
+		}
+		return &ProviderError{Category: CategoryInvalidResponse, Err: err}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &ProviderError{Category: CategoryTimeout, Err: err}
+	}
+
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.Code == http.StatusTooManyRequests:
+			return &ProviderError{Category: CategoryQuotaExceeded, Err: err}
+		case apiErr.Code == http.StatusUnauthorized || apiErr.Code == http.StatusForbidden,
+			apiErr.Code == http.StatusBadRequest && strings.Contains(apiErr.Message, "API key"):
+			return &ProviderError{Category: CategoryAuthFailed, Err: err}
+		case apiErr.Code == http.StatusGatewayTimeout || apiErr.Code == http.StatusRequestTimeout:
+			return &ProviderError{Category: CategoryTimeout, Err: err}
+		}
+	}
+
+	return &ProviderError{Category: CategoryUnknown, Err: err}
+}
@@ -0,0 +1,84 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/googleapi"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		category ErrorCategory
+		sentinel error
+	}{
+		{
+			name:     "quota exceeded",
+			err:      &googleapi.Error{Code: 429, Message: "Resource exhausted"},
+			category: CategoryQuotaExceeded,
+			sentinel: ErrQuotaExceeded,
+		},
+		{
+			name:     "invalid API key",
+			err:      &googleapi.Error{Code: 400, Message: "API key not valid. Please pass a valid API key."},
+			category: CategoryAuthFailed,
+			sentinel: ErrAuthFailed,
+		},
+		{
+			name:     "permission denied",
+			err:      &googleapi.Error{Code: 403},
+			category: CategoryAuthFailed,
+			sentinel: ErrAuthFailed,
+		},
+		{
+			name:     "deadline exceeded",
+			err:      fmt.Errorf("rpc: %w", context.DeadlineExceeded),
+			category: CategoryTimeout,
+			sentinel: ErrTimeout,
+		},
+		{
+			name:     "response blocked by safety settings",
+			err:      &genai.BlockedError{Candidate: &genai.Candidate{FinishReason: genai.FinishReasonSafety}},
+			category: CategorySafetyBlocked,
+			sentinel: ErrSafetyBlocked,
+		},
+		{
+			name:     "prompt blocked by safety settings",
+			err:      &genai.BlockedError{PromptFeedback: &genai.PromptFeedback{BlockReason: genai.BlockReasonSafety}},
+			category: CategorySafetyBlocked,
+			sentinel: ErrSafetyBlocked,
+		},
+		{
+			name:     "response blocked for recitation",
+			err:      &genai.BlockedError{Candidate: &genai.Candidate{FinishReason: genai.FinishReasonRecitation}},
+			category: CategoryInvalidResponse,
+			sentinel: ErrInvalidResponse,
+		},
+		{
+			name:     "other error",
+			err:      errors.New("connection reset"),
+			category: CategoryUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("failed to generate translation: %w", classifyError(tt.err))
+
+			assert.Equal(t, tt.category, CategoryOf(err))
+			assert.ErrorIs(t, err, tt.err)
+			if tt.sentinel != nil {
+				assert.ErrorIs(t, err, tt.sentinel)
+			}
+			if tt.category != CategoryQuotaExceeded {
+				assert.NotErrorIs(t, err, ErrQuotaExceeded)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/security"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Explainer is implemented by providers that explain the idioms, slang and
+// cultural references of a message
+type Explainer interface {
+	Explain(ctx context.Context, text, language string) (string, error)
+}
+
+var (
+	_ Explainer = (*GeminiProvider)(nil)
+	_ Explainer = (*OpenAIProvider)(nil)
+	_ Explainer = (*ChainedProvider)(nil)
+)
+
+// explanationPromptFor builds the explanation prompt of text, preceded by the
+// canary preamble carrying canary
+func explanationPromptFor(canary, text, language string) string {
+	data := ExplanationPromptData{Language: language, Text: text}
+	return fmt.Sprintf(canaryPreamble, canary) + renderPrompt(PromptTemplateExplain, data, func() string {
+		return fmt.Sprintf(explanationPrompt, language, text)
+	})
+}
+
+// startExplainSpan starts the span of a provider's explanation call
+func startExplainSpan(ctx context.Context, provider, model string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, provider+".explain", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("gen_ai.system", provider),
+		attribute.String("gen_ai.request.model", model),
+	))
+}
+
+// Explain explains the idioms, slang and cultural references of text in
+// language. Like translations, the prompt carries a canary token; an output
+// containing it is rejected with security.ErrCanaryLeaked.
+func (gp *GeminiProvider) Explain(ctx context.Context, text, language string) (string, error) {
+	ctx, span := startExplainSpan(ctx, ProviderGemini, gp.model)
+	explanation, err := gp.explain(ctx, text, language)
+	tracing.End(span, err)
+	return explanation, err
+}
+
+func (gp *GeminiProvider) explain(ctx context.Context, text, language string) (string, error) {
+	if err := gp.budget.Allow(ctx); err != nil {
+		return "", err
+	}
+	canary, err := security.NewCanaryToken()
+	if err != nil {
+		return "", err
+	}
+
+	generation := GenerationFrom(ctx)
+	model := gp.client.GenerativeModel(gp.model)
+	model.SetTemperature(float32(generation.Temperature))
+	model.SetTopP(float32(generation.TopP))
+	model.SafetySettings = []*genai.SafetySetting{
+		{
+			Category:  genai.HarmCategoryDangerousContent,
+			Threshold: genai.HarmBlockLowAndAbove,
+		},
+	}
+
+	resp, err := model.GenerateContent(ctx, genai.Text(explanationPromptFor(canary, text, language)))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate explanation: %w", classifyError(err))
+	}
+	gp.recordTokens(ctx, resp.UsageMetadata)
+
+	textPart, err := responseText(resp)
+	if err != nil {
+		return "", err
+	}
+	if security.ContainsCanary(string(textPart), canary) {
+		return "", fmt.Errorf("explanation compromised: %w", security.ErrCanaryLeaked)
+	}
+	return string(textPart), nil
+}
+
+// Explain explains the idioms, slang and cultural references of text in
+// language, rejecting outputs that leak the prompt's canary token with
+// security.ErrCanaryLeaked
+func (op *OpenAIProvider) Explain(ctx context.Context, text, language string) (string, error) {
+	ctx, span := startExplainSpan(ctx, ProviderOpenAI, op.model)
+	explanation, err := op.explain(ctx, text, language)
+	tracing.End(span, err)
+	return explanation, err
+}
+
+func (op *OpenAIProvider) explain(ctx context.Context, text, language string) (string, error) {
+	if err := op.budget.Allow(ctx); err != nil {
+		return "", err
+	}
+	canary, err := security.NewCanaryToken()
+	if err != nil {
+		return "", err
+	}
+
+	output, _, err := op.complete(ctx, explanationPromptFor(canary, text, language), GenerationFrom(ctx))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate explanation: %w", err)
+	}
+	if security.ContainsCanary(output, canary) {
+		return "", fmt.Errorf("explanation compromised: %w", security.ErrCanaryLeaked)
+	}
+	return output, nil
+}
+
+// Explain explains with the providers of the chain, failing over like
+// translations. Providers that cannot explain are skipped.
+func (c *ChainedProvider) Explain(ctx context.Context, text, language string) (string, error) {
+	var explanation string
+	_, err := c.call(ctx, func(p Provider) error {
+		explainer, ok := p.(Explainer)
+		if !ok {
+			return errSkipProvider
+		}
+		var err error
+		explanation, err = explainer.Explain(ctx, text, language)
+		return err
+	})
+	return explanation, err
+}
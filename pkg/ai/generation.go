@@ -0,0 +1,105 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+// Ranges of the generation parameters accepted by every provider
+const (
+	MaxTemperature     = 2.0
+	MaxOutputTokenCap  = 65536
+	DefaultTemperature = 0.1
+	DefaultTopP        = 0.9
+)
+
+// GenerationConfig holds the sampling parameters of a translation call
+type GenerationConfig struct {
+	// Temperature is between 0 and MaxTemperature; higher is more creative
+	Temperature float64 `json:"temperature"`
+	// TopP is the nucleus sampling probability, above 0 and at most 1
+	TopP float64 `json:"top_p"`
+	// MaxOutputTokens caps the reply, at most MaxOutputTokenCap; 0 leaves the
+	// provider's limit
+	MaxOutputTokens int `json:"max_output_tokens"`
+	// Model replaces the provider's model for the call when the provider
+	// offers it (see ProviderConfig.Models); empty keeps the provider's model
+	Model string `json:"model,omitempty"`
+}
+
+// modelNamePattern matches the names of models, e.g. gemini-1.5-pro or
+// models/gemini-1.5-pro-002
+var modelNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:/-]{0,99}$`)
+
+// ValidModelName reports whether name can be the name of a model
+func ValidModelName(name string) bool {
+	return modelNamePattern.MatchString(name)
+}
+
+// DefaultGenerationConfig returns the near-deterministic parameters
+// translations use unless configured otherwise
+func DefaultGenerationConfig() GenerationConfig {
+	return GenerationConfig{Temperature: DefaultTemperature, TopP: DefaultTopP}
+}
+
+// Validate checks that every parameter is in its range
+func (c GenerationConfig) Validate() error {
+	if c.Temperature < 0 || c.Temperature > MaxTemperature {
+		return fmt.Errorf("temperature must be between 0 and %g, got %g", MaxTemperature, c.Temperature)
+	}
+	if c.TopP <= 0 || c.TopP > 1 {
+		return fmt.Errorf("top_p must be above 0 and at most 1, got %g", c.TopP)
+	}
+	if c.MaxOutputTokens < 0 || c.MaxOutputTokens > MaxOutputTokenCap {
+		return fmt.Errorf("max_output_tokens must be between 0 and %d, got %d", MaxOutputTokenCap, c.MaxOutputTokens)
+	}
+	if c.Model != "" && !ValidModelName(c.Model) {
+		return fmt.Errorf("invalid model name %q", c.Model)
+	}
+	return nil
+}
+
+// String identifies the parameters, e.g. in cache keys
+func (c GenerationConfig) String() string {
+	if c.Model != "" {
+		return fmt.Sprintf("t%g-p%g-m%d-%s", c.Temperature, c.TopP, c.MaxOutputTokens, c.Model)
+	}
+	return fmt.Sprintf("t%g-p%g-m%d", c.Temperature, c.TopP, c.MaxOutputTokens)
+}
+
+// offeredModels is the set of models a provider may use in place of its own
+type offeredModels map[string]bool
+
+func newOfferedModels(models []string) offeredModels {
+	offered := make(offeredModels, len(models))
+	for _, model := range models {
+		offered[model] = true
+	}
+	return offered
+}
+
+// modelFor returns the model selected by generation when it is offered, or fallback
+func (o offeredModels) modelFor(generation GenerationConfig, fallback string) string {
+	if generation.Model != "" && o[generation.Model] {
+		return generation.Model
+	}
+	return fallback
+}
+
+type generationKey struct{}
+
+// WithGeneration returns a copy of ctx selecting the generation parameters
+// of TranslateContext calls, in place of DefaultGenerationConfig
+func WithGeneration(ctx context.Context, generation GenerationConfig) context.Context {
+	return context.WithValue(ctx, generationKey{}, generation)
+}
+
+// GenerationFrom returns the generation parameters selected by ctx, or
+// DefaultGenerationConfig when none or invalid ones are
+func GenerationFrom(ctx context.Context) GenerationConfig {
+	if generation, ok := ctx.Value(generationKey{}).(GenerationConfig); ok && generation.Validate() == nil {
+		return generation
+	}
+	return DefaultGenerationConfig()
+}
@@ -0,0 +1,146 @@
+package ai
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// hedgeWindowSize is the number of recent translation latencies the
+	// hedge delay is computed from
+	hedgeWindowSize = 200
+	// hedgeMinSamples avoids hedging on the latencies of a handful of calls
+	hedgeMinSamples = 20
+)
+
+// SetHedging fires a second, hedged translation request when the first one
+// takes longer than the given percentile (e.g. 95) of recent translation
+// latencies, but at least minDelay. The hedge goes to the first fallback
+// provider, or to the same provider when there is none; the first successful
+// response is used and the other request is cancelled. A percentile of zero
+// disables hedging.
+func (c *ChainedProvider) SetHedging(percentile float64, minDelay time.Duration) {
+	if percentile <= 0 {
+		c.hedging = nil
+		return
+	}
+	c.hedging = &hedgePolicy{percentile: percentile, minDelay: minDelay}
+}
+
+// hedgePolicy tracks recent latencies to decide when to hedge
+type hedgePolicy struct {
+	percentile float64
+	minDelay   time.Duration
+
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+}
+
+// observe records the latency of a successful translation
+func (h *hedgePolicy) observe(latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.samples) < hedgeWindowSize {
+		h.samples = append(h.samples, latency)
+		return
+	}
+	h.samples[h.next] = latency
+	h.next = (h.next + 1) % hedgeWindowSize
+}
+
+// delay returns how long to wait for the first request before hedging, and
+// false while there are too few latencies to tell a slow call
+func (h *hedgePolicy) delay() (time.Duration, bool) {
+	h.mu.Lock()
+	if len(h.samples) < hedgeMinSamples {
+		h.mu.Unlock()
+		return 0, false
+	}
+	sorted := append([]time.Duration(nil), h.samples...)
+	h.mu.Unlock()
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	index := int(float64(len(sorted))*h.percentile/100+0.5) - 1
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(sorted) {
+		index = len(sorted) - 1
+	}
+	if sorted[index] < h.minDelay {
+		return h.minDelay, true
+	}
+	return sorted[index], true
+}
+
+// hedgeResult is the outcome of one of the requests of a hedged translation
+type hedgeResult struct {
+	translated string
+	provider   string
+	usage      *Usage
+	err        error
+	hedge      bool
+}
+
+// translateHedged translates starting with the first provider and, once
+// delay has passed without an answer, also with the hedge provider
+func (c *ChainedProvider) translateHedged(ctx context.Context, delay time.Duration, text, sourceLanguage, targetLanguage string) (string, string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	// Cancels the request that lost
+	defer cancel()
+
+	results := make(chan hedgeResult, 2)
+	attempt := func(start int, hedge bool) {
+		// Each request records its own usage; only the winner's is kept
+		usage := &Usage{}
+		go func() {
+			translated, name, err := c.translateFrom(WithUsage(ctx, usage), start, text, sourceLanguage, targetLanguage)
+			results <- hedgeResult{translated: translated, provider: name, usage: usage, err: err, hedge: hedge}
+		}()
+	}
+	attempt(0, false)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	hedged := false
+	pending := 1
+	var failed *hedgeResult
+	for {
+		select {
+		case <-timer.C:
+			hedged = true
+			pending++
+			c.recordError("ai_hedged")
+			start := 0
+			if len(c.providers) > 1 {
+				start = 1
+			}
+			attempt(start, true)
+		case result := <-results:
+			pending--
+			if result.err == nil {
+				if result.hedge {
+					c.recordError("ai_hedge_won")
+				}
+				if usage := UsageFrom(ctx); usage != nil {
+					*usage = *result.usage
+				}
+				return result.translated, result.provider, nil
+			}
+			// When both fail, the first request's error is reported: it went
+			// through every provider of the chain
+			if failed == nil || !result.hedge {
+				failed = &result
+			}
+			// A first request failing before the hedge delay has already
+			// been through the chain's failover
+			if !hedged || pending == 0 {
+				return failed.translated, failed.provider, failed.err
+			}
+		}
+	}
+}
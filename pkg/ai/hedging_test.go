@@ -0,0 +1,122 @@
+package ai
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// delayedProvider answers after delay unless its context is done first
+type delayedProvider struct {
+	fakeProvider
+	delay     time.Duration
+	model     string
+	cancelled atomic.Bool
+}
+
+func (d *delayedProvider) TranslateContext(ctx context.Context, text, sourceLanguage, targetLanguage string) (string, error) {
+	select {
+	case <-time.After(d.delay):
+		recordUsage(ctx, d.model, StablePromptVersion, 10, 5)
+		return d.translation, nil
+	case <-ctx.Done():
+		d.cancelled.Store(true)
+		return "", ctx.Err()
+	}
+}
+
+func newHedgedChain(t *testing.T, providers ...NamedProvider) (*ChainedProvider, *metrics.Metrics) {
+	chain, err := NewChainedProvider(providers, 0, 0)
+	require.NoError(t, err)
+	m := metrics.NewMetrics()
+	chain.SetMetrics(m)
+	chain.SetHedging(95, 20*time.Millisecond)
+	for i := 0; i < hedgeMinSamples; i++ {
+		chain.hedging.observe(time.Millisecond)
+	}
+	return chain, m
+}
+
+func TestChainedProvider_Hedging(t *testing.T) {
+	t.Run("hedge to the fallback wins", func(t *testing.T) {
+		primary := &delayedProvider{fakeProvider: fakeProvider{translation: "Xin chào (gemini)"}, delay: time.Second, model: "gemini-test"}
+		fallback := &delayedProvider{fakeProvider: fakeProvider{translation: "Xin chào (openai)"}, model: "gpt-test"}
+		chain, m := newHedgedChain(t, NamedProvider{Name: "gemini", Provider: primary}, NamedProvider{Name: "openai", Provider: fallback})
+
+		usage := &Usage{}
+		translated, provider, err := chain.TranslateWithProvider(WithUsage(context.Background(), usage), "Hello", "English", "Vietnamese")
+		require.NoError(t, err)
+		assert.Equal(t, "Xin chào (openai)", translated)
+		assert.Equal(t, "openai", provider)
+		assert.Equal(t, "gpt-test", usage.Model)
+		assert.Equal(t, int64(1), m.ErrorsByType["ai_hedged"])
+		assert.Equal(t, int64(1), m.ErrorsByType["ai_hedge_won"])
+		assert.Eventually(t, primary.cancelled.Load, time.Second, time.Millisecond)
+	})
+
+	t.Run("single provider is hedged to itself", func(t *testing.T) {
+		primary := &delayedProvider{fakeProvider: fakeProvider{translation: "Xin chào"}, delay: 60 * time.Millisecond}
+		chain, m := newHedgedChain(t, NamedProvider{Name: "gemini", Provider: primary})
+
+		translated, provider, err := chain.TranslateWithProvider(context.Background(), "Hello", "English", "Vietnamese")
+		require.NoError(t, err)
+		assert.Equal(t, "Xin chào", translated)
+		assert.Equal(t, "gemini", provider)
+		assert.Equal(t, int64(1), m.ErrorsByType["ai_hedged"])
+	})
+
+	t.Run("fast answer is not hedged", func(t *testing.T) {
+		primary := &delayedProvider{fakeProvider: fakeProvider{translation: "Xin chào (gemini)"}}
+		fallback := &delayedProvider{fakeProvider: fakeProvider{translation: "Xin chào (openai)"}}
+		chain, m := newHedgedChain(t, NamedProvider{Name: "gemini", Provider: primary}, NamedProvider{Name: "openai", Provider: fallback})
+
+		_, provider, err := chain.TranslateWithProvider(context.Background(), "Hello", "English", "Vietnamese")
+		require.NoError(t, err)
+		assert.Equal(t, "gemini", provider)
+		assert.Zero(t, m.ErrorsByType["ai_hedged"])
+	})
+
+	t.Run("first failure waits for the hedge", func(t *testing.T) {
+		primary := &delayedProvider{fakeProvider: fakeProvider{translation: "Xin chào (gemini)"}, delay: time.Second}
+		fallback := &fakeProvider{err: ErrQuotaExceeded}
+		chain, _ := newHedgedChain(t, NamedProvider{Name: "gemini", Provider: primary}, NamedProvider{Name: "openai", Provider: fallback})
+		chain.hedging.minDelay = 10 * time.Millisecond
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, _, err := chain.TranslateWithProvider(ctx, "Hello", "English", "Vietnamese")
+		// The hedge fails at once; the first request runs until the deadline
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestHedgePolicy_Delay(t *testing.T) {
+	policy := &hedgePolicy{percentile: 90, minDelay: 5 * time.Millisecond}
+
+	_, ok := policy.delay()
+	assert.False(t, ok, "too few samples")
+
+	for i := 1; i <= 100; i++ {
+		policy.observe(time.Duration(i) * time.Millisecond)
+	}
+	delay, ok := policy.delay()
+	require.True(t, ok)
+	assert.Equal(t, 90*time.Millisecond, delay)
+
+	policy.minDelay = time.Second
+	delay, _ = policy.delay()
+	assert.Equal(t, time.Second, delay)
+
+	// Only the latest hedgeWindowSize latencies count
+	for i := 0; i < hedgeWindowSize; i++ {
+		policy.observe(time.Millisecond)
+	}
+	policy.minDelay = 0
+	delay, _ = policy.delay()
+	assert.Equal(t, time.Millisecond, delay)
+}
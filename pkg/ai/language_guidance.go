@@ -0,0 +1,93 @@
+package ai
+
+import "strings"
+
+// languageGuidance holds the extra prompt instructions of a language: into
+// applies to translations into it, from to translations out of it
+type languageGuidance struct {
+	name string
+	into string
+	from string
+}
+
+// languageGuidances holds the guidance of each language by lower-case name
+var languageGuidances = map[string]languageGuidance{
+	"japanese": {
+		name: "Japanese",
+		into: "Write polite Japanese in the です/ます form unless the original is casual, then use plain casual Japanese; do not use honorific or humble keigo the original does not call for. " +
+			"Write non-Japanese personal names in katakana (e.g. John → ジョン), keeping names already in Japanese as they are.",
+		from: "Romanize Japanese personal names with Hepburn romanization in the order they are written (e.g. 田中太郎 → Tanaka Taro), dropping honorifics such as さん or 様 unless the target language has an equivalent.",
+	},
+	"korean": {
+		name: "Korean",
+		into: "Write polite Korean in the 해요체 form unless the original is formal, then use 합니다체, or casual, then use 반말. " +
+			"Write non-Korean personal names in Hangul (e.g. John → 존).",
+		from: "Romanize Korean personal names with the Revised Romanization of Korean, family name first (e.g. 김민수 → Kim Minsu), dropping honorifics such as 님 or 씨 unless the target language has an equivalent.",
+	},
+	"thai": {
+		name: "Thai",
+		into: "Write Thai without spaces between words, using a space only between phrases or sentences. " +
+			"Keep the polite particles ครับ or ค่ะ only where the original is polite, and transliterate non-Thai personal names into Thai script.",
+		from: "Romanize Thai personal names with the Royal Thai General System of Transcription, dropping the title คุณ unless the target language has an equivalent.",
+	},
+	"chinese (simplified)": {
+		name: "Chinese (Simplified)",
+		into: "Write only Simplified Chinese characters as used in mainland China, never Traditional ones, with full-width Chinese punctuation.",
+		from: "Romanize Chinese personal names with Hanyu Pinyin without tone marks, family name first (e.g. 王小明 → Wang Xiaoming).",
+	},
+	"chinese (traditional)": {
+		name: "Chinese (Traditional)",
+		into: "Write only Traditional Chinese characters and the vocabulary used in Taiwan (e.g. 軟體, not 软件 or 軟件), never Simplified characters, with full-width Chinese punctuation.",
+		from: "Romanize Chinese personal names with Hanyu Pinyin without tone marks, family name first, unless the name has a customary spelling (e.g. 蔡英文 → Tsai Ing-wen).",
+	},
+	"arabic": {
+		name: "Arabic",
+		into: "Write Modern Standard Arabic with Arabic punctuation (، ؛ ؟) and Western digits. " +
+			"Do not add Unicode direction marks, and keep Latin words such as product names and placeholders written left to right as they are.",
+		from: "Romanize Arabic personal names with their customary English spelling (e.g. محمد → Mohammed), without diacritics.",
+	},
+	"hebrew": {
+		name: "Hebrew",
+		into: "Write Modern Hebrew without niqqud, with Western digits. " +
+			"Do not add Unicode direction marks, and keep Latin words such as product names and placeholders written left to right as they are.",
+		from: "Romanize Hebrew personal names with their customary English spelling (e.g. יוסי → Yossi).",
+	},
+}
+
+// guidanceFor returns the guidance lines for a translation between the given
+// languages, formatted as prompt bullets, or "" when there is none
+func guidanceFor(sourceLanguage, targetLanguage string) string {
+	var lines []string
+	if guidance, ok := languageGuidances[languageKey(targetLanguage)]; ok && guidance.into != "" {
+		lines = append(lines, "- "+guidance.name+" style: "+guidance.into)
+	}
+	if guidance, ok := languageGuidances[languageKey(sourceLanguage)]; ok && guidance.from != "" {
+		lines = append(lines, "- "+guidance.name+" names: "+guidance.from)
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "\n" + strings.Join(lines, "\n")
+}
+
+// languageKey maps a language name or code to its guidance key
+func languageKey(language string) string {
+	switch key := strings.ToLower(strings.TrimSpace(language)); key {
+	case "ja", "jpn":
+		return "japanese"
+	case "ko", "kor":
+		return "korean"
+	case "th", "tha":
+		return "thai"
+	case "zh", "zh-hans", "zh-cn", "chinese":
+		return "chinese (simplified)"
+	case "zh-hant", "zh-tw", "zh-hk":
+		return "chinese (traditional)"
+	case "ar", "ara":
+		return "arabic"
+	case "he", "iw", "heb":
+		return "hebrew"
+	default:
+		return key
+	}
+}
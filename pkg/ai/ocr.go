@@ -0,0 +1,67 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ExtractImageText transcribes the text of an image with the provider's
+// vision model. It returns "" when the image holds no readable text.
+func (gp *GeminiProvider) ExtractImageText(ctx context.Context, mimeType string, data []byte) (string, error) {
+	ctx, span := tracing.Tracer().Start(ctx, ProviderGemini+".extract_image_text", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("gen_ai.system", ProviderGemini),
+		attribute.String("gen_ai.request.model", gp.model),
+		attribute.String("image.mime_type", mimeType),
+		attribute.Int("image.size", len(data)),
+	))
+	text, err := gp.extractImageText(ctx, mimeType, data)
+	tracing.End(span, err)
+	return text, err
+}
+
+func (gp *GeminiProvider) extractImageText(ctx context.Context, mimeType string, data []byte) (string, error) {
+	if err := gp.budget.Allow(ctx); err != nil {
+		return "", err
+	}
+	model := gp.client.GenerativeModel(gp.model)
+	temp := float32(0)
+	model.Temperature = &temp
+
+	model.SafetySettings = []*genai.SafetySetting{
+		{
+			Category:  genai.HarmCategoryDangerousContent,
+			Threshold: genai.HarmBlockLowAndAbove,
+		},
+	}
+
+	resp, err := model.GenerateContent(ctx, genai.Blob{MIMEType: mimeType, Data: data}, genai.Text(imageTextPrompt))
+	if err != nil {
+		return "", fmt.Errorf("failed to extract image text: %w", classifyError(err))
+	}
+
+	// Record token usage
+	gp.recordTokens(ctx, resp.UsageMetadata)
+	if resp.UsageMetadata != nil {
+		trace.SpanFromContext(ctx).SetAttributes(
+			attribute.Int64("gen_ai.usage.input_tokens", int64(resp.UsageMetadata.PromptTokenCount)),
+			attribute.Int64("gen_ai.usage.output_tokens", int64(resp.UsageMetadata.CandidatesTokenCount)),
+		)
+	}
+
+	textPart, err := responseText(resp)
+	if err != nil {
+		return "", err
+	}
+
+	text := strings.TrimSpace(string(textPart))
+	if text == NoImageText {
+		return "", nil
+	}
+	return text, nil
+}
@@ -0,0 +1,219 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/security"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/tracing"
+)
+
+// DefaultOpenAIBaseURL is the OpenAI API endpoint used when no base URL is configured
+const DefaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// OpenAIProvider translates with OpenAI chat completions, or any API
+// compatible with them
+type OpenAIProvider struct {
+	apiKey        string
+	model         string
+	models        offeredModels
+	baseURL       string
+	promptVersion string
+	httpClient    *http.Client
+	metrics       *metrics.Metrics
+	budget        *TokenBudget
+}
+
+func NewOpenAIProvider(cfg ProviderConfig) (*OpenAIProvider, error) {
+	if cfg.APIKey == "" {
+		return nil, errors.New("OpenAI API key is required")
+	}
+	if cfg.Model == "" {
+		return nil, errors.New("OpenAI model is required")
+	}
+	promptVersion := cfg.PromptVersion
+	if promptVersion == "" {
+		promptVersion = StablePromptVersion
+	}
+	if _, ok := translationPrompts[promptVersion]; !ok {
+		return nil, fmt.Errorf("unknown prompt version: %s", promptVersion)
+	}
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultOpenAIBaseURL
+	}
+
+	return &OpenAIProvider{
+		apiKey:        cfg.APIKey,
+		model:         cfg.Model,
+		models:        newOfferedModels(cfg.Models),
+		baseURL:       strings.TrimSuffix(baseURL, "/"),
+		promptVersion: promptVersion,
+		// Calls are bounded by the caller's context; this only guards against a hung connection
+		httpClient: &http.Client{Timeout: 2 * time.Minute},
+		metrics:    cfg.Metrics,
+		budget:     cfg.Budget,
+	}, nil
+}
+
+func (op *OpenAIProvider) Translate(text, sourceLanguage, targetLanguage string) (string, error) {
+	return op.TranslateContext(context.Background(), text, sourceLanguage, targetLanguage)
+}
+
+// TranslateContext translates text, aborting the OpenAI call when ctx is done.
+// Every prompt carries a fresh canary token; an output containing it is
+// rejected with security.ErrCanaryLeaked.
+func (op *OpenAIProvider) TranslateContext(ctx context.Context, text, sourceLanguage, targetLanguage string) (string, error) {
+	version := PromptVersionFrom(ctx, op.promptVersion)
+	ctx, span := startTranslateSpan(ctx, ProviderOpenAI, op.models.modelFor(GenerationFrom(ctx), op.model), version)
+	translated, err := op.translate(ctx, version, text, sourceLanguage, targetLanguage)
+	tracing.End(span, err)
+	return translated, err
+}
+
+func (op *OpenAIProvider) translate(ctx context.Context, version, text, sourceLanguage, targetLanguage string) (string, error) {
+	if err := op.budget.Allow(ctx); err != nil {
+		return "", err
+	}
+	canary, err := security.NewCanaryToken()
+	if err != nil {
+		return "", err
+	}
+
+	generation := GenerationFrom(ctx)
+	output, usage, err := op.complete(ctx, translationPrompt(version, canary, text, sourceLanguage, targetLanguage), generation)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate translation: %w", err)
+	}
+
+	if security.ContainsCanary(output, canary) {
+		return "", fmt.Errorf("translation compromised: %w", security.ErrCanaryLeaked)
+	}
+	recordUsage(ctx, op.models.modelFor(generation, op.model), version, usage.PromptTokens, usage.CompletionTokens)
+	return output, nil
+}
+
+func (op *OpenAIProvider) DetectLanguage(text string) (string, error) {
+	output, _, err := op.complete(context.Background(), detectionPrompt(text), DefaultGenerationConfig())
+	if err != nil {
+		return "", fmt.Errorf("failed to detect language: %w", err)
+	}
+	return output, nil
+}
+
+func (op *OpenAIProvider) Close() error {
+	op.httpClient.CloseIdleConnections()
+	return nil
+}
+
+type chatCompletionRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature float64       `json:"temperature"`
+	TopP        float64       `json:"top_p"`
+	MaxTokens   int           `json:"max_tokens,omitempty"`
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message      chatMessage `json:"message"`
+		FinishReason string      `json:"finish_reason"`
+	} `json:"choices"`
+	Usage chatCompletionUsage `json:"usage"`
+}
+
+type chatCompletionUsage struct {
+	PromptTokens     int64 `json:"prompt_tokens"`
+	CompletionTokens int64 `json:"completion_tokens"`
+	TotalTokens      int64 `json:"total_tokens"`
+}
+
+// complete sends prompt as a single user message to the model selected by
+// generation, sampled with it, and returns the reply and the tokens used.
+// Every error is a ProviderError.
+func (op *OpenAIProvider) complete(ctx context.Context, prompt string, generation GenerationConfig) (string, chatCompletionUsage, error) {
+	body, err := json.Marshal(chatCompletionRequest{
+		Model:       op.models.modelFor(generation, op.model),
+		Messages:    []chatMessage{{Role: "user", Content: prompt}},
+		Temperature: generation.Temperature,
+		TopP:        generation.TopP,
+		MaxTokens:   generation.MaxOutputTokens,
+	})
+	if err != nil {
+		return "", chatCompletionUsage{}, &ProviderError{Category: CategoryUnknown, Err: err}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, op.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", chatCompletionUsage{}, &ProviderError{Category: CategoryUnknown, Err: err}
+	}
+	req.Header.Set("Authorization", "Bearer "+op.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := op.httpClient.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return "", chatCompletionUsage{}, &ProviderError{Category: CategoryTimeout, Err: err}
+		}
+		return "", chatCompletionUsage{}, &ProviderError{Category: CategoryUnknown, Err: err}
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", chatCompletionUsage{}, classifyOpenAIStatus(resp.StatusCode, message)
+	}
+
+	var completion chatCompletionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		return "", chatCompletionUsage{}, &ProviderError{Category: CategoryInvalidResponse, Err: fmt.Errorf("failed to decode OpenAI response: %w", err)}
+	}
+
+	if op.metrics != nil && completion.Usage.TotalTokens > 0 {
+		op.metrics.RecordProviderTokens(ProviderOpenAI, completion.Usage.TotalTokens)
+	}
+	op.budget.Consume(ctx, completion.Usage.TotalTokens)
+
+	if len(completion.Choices) == 0 {
+		return "", chatCompletionUsage{}, &ProviderError{Category: CategoryInvalidResponse, Err: errors.New("no response from OpenAI")}
+	}
+	choice := completion.Choices[0]
+	if choice.FinishReason == "content_filter" {
+		return "", chatCompletionUsage{}, ErrSafetyBlocked
+	}
+	if choice.Message.Content == "" {
+		return "", chatCompletionUsage{}, &ProviderError{Category: CategoryInvalidResponse, Err: errors.New("empty response from OpenAI")}
+	}
+	return choice.Message.Content, completion.Usage, nil
+}
+
+// classifyOpenAIStatus wraps a failed OpenAI response in a ProviderError
+func classifyOpenAIStatus(status int, body []byte) error {
+	err := fmt.Errorf("OpenAI returned status %d: %s", status, body)
+	switch {
+	case status == http.StatusTooManyRequests:
+		return &ProviderError{Category: CategoryQuotaExceeded, Err: err}
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return &ProviderError{Category: CategoryAuthFailed, Err: err}
+	case status == http.StatusRequestTimeout || status == http.StatusGatewayTimeout:
+		return &ProviderError{Category: CategoryTimeout, Err: err}
+	case status == http.StatusBadRequest && strings.Contains(string(body), "content_filter"):
+		return &ProviderError{Category: CategorySafetyBlocked, Err: err}
+	}
+	return &ProviderError{Category: CategoryUnknown, Err: err}
+}
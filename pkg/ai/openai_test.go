@@ -0,0 +1,269 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var canaryPattern = regexp.MustCompile(`CNRY-[0-9A-F]+`)
+
+func TestOpenAIProvider_TranslateContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		reply    func(prompt string) string
+		finish   string
+		want     string
+		sentinel error
+	}{
+		{
+			name:   "translated",
+			status: http.StatusOK,
+			reply:  func(string) string { return "Xin chào" },
+			finish: "stop",
+			want:   "Xin chào",
+		},
+		{
+			name:     "quota exceeded",
+			status:   http.StatusTooManyRequests,
+			sentinel: ErrQuotaExceeded,
+		},
+		{
+			name:     "invalid API key",
+			status:   http.StatusUnauthorized,
+			sentinel: ErrAuthFailed,
+		},
+		{
+			name:     "content filtered",
+			status:   http.StatusOK,
+			reply:    func(string) string { return "" },
+			finish:   "content_filter",
+			sentinel: ErrSafetyBlocked,
+		},
+		{
+			name:     "canary leaked",
+			status:   http.StatusOK,
+			reply:    func(prompt string) string { return "Marker: " + canaryPattern.FindString(prompt) },
+			finish:   "stop",
+			sentinel: security.ErrCanaryLeaked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/chat/completions", r.URL.Path)
+				assert.Equal(t, "Bearer sk-test", r.Header.Get("Authorization"))
+
+				var req chatCompletionRequest
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, "gpt-test", req.Model)
+				require.Len(t, req.Messages, 1)
+				assert.Contains(t, req.Messages[0].Content, "<UserInput>")
+
+				if tt.status != http.StatusOK {
+					w.WriteHeader(tt.status)
+					_, _ = w.Write([]byte(`{"error": {"message": "nope"}}`))
+					return
+				}
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"choices": []map[string]interface{}{{
+						"message":       map[string]string{"role": "assistant", "content": tt.reply(req.Messages[0].Content)},
+						"finish_reason": tt.finish,
+					}},
+					"usage": map[string]int{"prompt_tokens": 30, "completion_tokens": 12, "total_tokens": 42},
+				})
+			}))
+			defer server.Close()
+
+			m := metrics.NewMetrics()
+			provider, err := NewOpenAIProvider(ProviderConfig{APIKey: "sk-test", Model: "gpt-test", BaseURL: server.URL + "/", Metrics: m})
+			require.NoError(t, err)
+
+			usage := &Usage{}
+			got, err := provider.TranslateContext(WithUsage(context.Background(), usage), "Hello", "English", "Vietnamese")
+			if tt.sentinel != nil {
+				assert.ErrorIs(t, err, tt.sentinel)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, int64(42), m.ProviderTokens[ProviderOpenAI])
+			assert.Equal(t, Usage{Model: "gpt-test", PromptVersion: StablePromptVersion, PromptTokens: 30, OutputTokens: 12}, *usage)
+		})
+	}
+}
+
+func TestOpenAIProvider_TranslateContextPromptVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		want    string
+	}{
+		{name: "selected by context", version: LatestPromptVersion, want: LatestPromptVersion},
+		{name: "unknown version keeps the provider's", version: "v99", want: StablePromptVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var prompt string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req chatCompletionRequest
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				prompt = req.Messages[0].Content
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"choices": []map[string]interface{}{{
+						"message":       map[string]string{"role": "assistant", "content": "Xin chào"},
+						"finish_reason": "stop",
+					}},
+				})
+			}))
+			defer server.Close()
+
+			provider, err := NewOpenAIProvider(ProviderConfig{APIKey: "sk-test", Model: "gpt-test", BaseURL: server.URL + "/"})
+			require.NoError(t, err)
+
+			usage := &Usage{}
+			ctx := WithPromptVersion(WithUsage(context.Background(), usage), tt.version)
+			_, err = provider.TranslateContext(ctx, "Hello", "English", "Vietnamese")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, usage.PromptVersion)
+			assert.Contains(t, prompt, fmt.Sprintf(translationPrompts[tt.want], "English", "Vietnamese", "Hello"))
+		})
+	}
+}
+
+func TestOpenAIProvider_TranslateContextGeneration(t *testing.T) {
+	tests := []struct {
+		name       string
+		generation *GenerationConfig
+		want       GenerationConfig
+	}{
+		{name: "defaults", want: DefaultGenerationConfig()},
+		{
+			name:       "selected by context",
+			generation: &GenerationConfig{Temperature: 0.7, TopP: 0.95, MaxOutputTokens: 256},
+			want:       GenerationConfig{Temperature: 0.7, TopP: 0.95, MaxOutputTokens: 256},
+		},
+		{
+			name:       "invalid parameters keep the defaults",
+			generation: &GenerationConfig{Temperature: 3, TopP: 0.9},
+			want:       DefaultGenerationConfig(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req chatCompletionRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"choices": []map[string]interface{}{{
+						"message":       map[string]string{"role": "assistant", "content": "Xin chào"},
+						"finish_reason": "stop",
+					}},
+				})
+			}))
+			defer server.Close()
+
+			provider, err := NewOpenAIProvider(ProviderConfig{APIKey: "sk-test", Model: "gpt-test", BaseURL: server.URL})
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			if tt.generation != nil {
+				ctx = WithGeneration(ctx, *tt.generation)
+			}
+			_, err = provider.TranslateContext(ctx, "Hello", "English", "Vietnamese")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, GenerationConfig{Temperature: req.Temperature, TopP: req.TopP, MaxOutputTokens: req.MaxTokens})
+		})
+	}
+}
+
+func TestOpenAIProvider_TranslateContextModel(t *testing.T) {
+	var req chatCompletionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{{
+				"message":       map[string]string{"role": "assistant", "content": "Xin chào"},
+				"finish_reason": "stop",
+			}},
+		})
+	}))
+	defer server.Close()
+
+	provider, err := NewOpenAIProvider(ProviderConfig{APIKey: "sk-test", Model: "gpt-test", BaseURL: server.URL, Models: []string{"gpt-strong"}})
+	require.NoError(t, err)
+
+	generation := DefaultGenerationConfig()
+	generation.Model = "gpt-strong"
+	usage := &Usage{}
+	_, err = provider.TranslateContext(WithUsage(WithGeneration(context.Background(), generation), usage), "Hello", "English", "Vietnamese")
+	require.NoError(t, err)
+	assert.Equal(t, "gpt-strong", req.Model)
+	assert.Equal(t, "gpt-strong", usage.Model)
+
+	// Models the provider does not offer, e.g. those of another provider, are ignored
+	generation.Model = "gemini-1.5-pro"
+	_, err = provider.TranslateContext(WithGeneration(context.Background(), generation), "Hello", "English", "Vietnamese")
+	require.NoError(t, err)
+	assert.Equal(t, "gpt-test", req.Model)
+}
+
+func TestGenerationConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		generation GenerationConfig
+		wantErr    bool
+	}{
+		{name: "defaults", generation: DefaultGenerationConfig()},
+		{name: "bounds", generation: GenerationConfig{Temperature: MaxTemperature, TopP: 1, MaxOutputTokens: MaxOutputTokenCap}},
+		{name: "negative temperature", generation: GenerationConfig{Temperature: -0.1, TopP: 0.9}, wantErr: true},
+		{name: "temperature too high", generation: GenerationConfig{Temperature: 2.1, TopP: 0.9}, wantErr: true},
+		{name: "zero top_p", generation: GenerationConfig{Temperature: 0.1}, wantErr: true},
+		{name: "top_p above 1", generation: GenerationConfig{Temperature: 0.1, TopP: 1.1}, wantErr: true},
+		{name: "negative max_output_tokens", generation: GenerationConfig{Temperature: 0.1, TopP: 0.9, MaxOutputTokens: -1}, wantErr: true},
+		{name: "model", generation: GenerationConfig{Temperature: 0.1, TopP: 0.9, Model: "models/gemini-1.5-pro-002"}},
+		{name: "invalid model", generation: GenerationConfig{Temperature: 0.1, TopP: 0.9, Model: "gemini pro\n"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.generation.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	provider, err := NewProvider(ProviderOpenAI, ProviderConfig{APIKey: "sk-test", Model: "gpt-test"})
+	require.NoError(t, err)
+	assert.IsType(t, &OpenAIProvider{}, provider)
+
+	_, err = NewProvider(ProviderOpenAI, ProviderConfig{Model: "gpt-test"})
+	assert.Error(t, err, "API key is required")
+
+	_, err = NewProvider(ProviderOpenAI, ProviderConfig{APIKey: "sk-test", Model: "gpt-test", PromptVersion: "v99"})
+	assert.Error(t, err, "prompt version must exist")
+
+	_, err = NewProvider("unknown", ProviderConfig{})
+	assert.ErrorContains(t, err, `unknown AI provider "unknown"`)
+
+	RegisterProvider("test", newOpenAIFromConfig)
+	assert.Contains(t, Providers(), "test")
+}
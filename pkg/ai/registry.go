@@ -0,0 +1,113 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+)
+
+// Built-in provider names, as set in AI_PROVIDER
+const (
+	ProviderGemini = "gemini"
+	ProviderOpenAI = "openai"
+)
+
+// Provider is an AI backend that translates text and detects its language
+type Provider interface {
+	Translate(text, sourceLanguage, targetLanguage string) (string, error)
+	TranslateContext(ctx context.Context, text, sourceLanguage, targetLanguage string) (string, error)
+	DetectLanguage(text string) (string, error)
+	Close() error
+}
+
+var (
+	_ Provider = (*GeminiProvider)(nil)
+	_ Provider = (*OpenAIProvider)(nil)
+)
+
+// ProviderConfig holds the settings every provider is created from
+type ProviderConfig struct {
+	APIKey string
+	Model  string
+	// BaseURL overrides the API endpoint, e.g. for an OpenAI-compatible gateway
+	BaseURL string
+	// PromptVersion selects the translation prompt; empty means StablePromptVersion
+	PromptVersion string
+	Metrics       *metrics.Metrics
+	// Models are the models translations may select in place of Model
+	// through GenerationConfig.Model, e.g. a stronger model for some channels
+	Models []string
+	// Budget caps the tokens used each day by every provider sharing it; nil
+	// leaves them uncapped
+	Budget *TokenBudget
+}
+
+// ProviderFactory creates a provider from its configuration
+type ProviderFactory func(cfg ProviderConfig) (Provider, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]ProviderFactory{
+		ProviderGemini: newGeminiFromConfig,
+		ProviderOpenAI: newOpenAIFromConfig,
+	}
+)
+
+// RegisterProvider makes a provider available to NewProvider under name,
+// replacing any provider already registered with that name
+func RegisterProvider(name string, factory ProviderFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// NewProvider creates the provider registered under name
+func NewProvider(name string, cfg ProviderConfig) (Provider, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown AI provider %q, expected one of %v", name, Providers())
+	}
+	return factory(cfg)
+}
+
+// Providers returns the names of the registered providers
+func Providers() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func newGeminiFromConfig(cfg ProviderConfig) (Provider, error) {
+	provider, err := NewGeminiProvider(cfg.APIKey, cfg.Model, cfg.Metrics)
+	if err != nil {
+		return nil, err
+	}
+	provider.SetModels(cfg.Models)
+	provider.SetBudget(cfg.Budget)
+	if cfg.PromptVersion != "" {
+		if err := provider.SetPromptVersion(cfg.PromptVersion); err != nil {
+			_ = provider.Close()
+			return nil, err
+		}
+	}
+	return provider, nil
+}
+
+func newOpenAIFromConfig(cfg ProviderConfig) (Provider, error) {
+	provider, err := NewOpenAIProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
@@ -0,0 +1,130 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/security"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Summarizer is implemented by providers that summarize chat transcripts
+type Summarizer interface {
+	Summarize(ctx context.Context, transcript, language string) (string, error)
+}
+
+var (
+	_ Summarizer = (*GeminiProvider)(nil)
+	_ Summarizer = (*OpenAIProvider)(nil)
+	_ Summarizer = (*ChainedProvider)(nil)
+)
+
+// summaryPromptFor builds the summary prompt of transcript, preceded by the
+// canary preamble carrying canary
+func summaryPromptFor(canary, transcript, language string) string {
+	data := SummaryPromptData{Language: language, Transcript: transcript}
+	return fmt.Sprintf(canaryPreamble, canary) + renderPrompt(PromptTemplateSummarize, data, func() string {
+		return fmt.Sprintf(summaryPrompt, language, transcript)
+	})
+}
+
+// startSummarizeSpan starts the span of a provider's summarization call
+func startSummarizeSpan(ctx context.Context, provider, model string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, provider+".summarize", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("gen_ai.system", provider),
+		attribute.String("gen_ai.request.model", model),
+	))
+}
+
+// Summarize summarizes a chat transcript in language. Like translations, the
+// prompt carries a canary token; an output containing it is rejected with
+// security.ErrCanaryLeaked.
+func (gp *GeminiProvider) Summarize(ctx context.Context, transcript, language string) (string, error) {
+	ctx, span := startSummarizeSpan(ctx, ProviderGemini, gp.model)
+	summary, err := gp.summarize(ctx, transcript, language)
+	tracing.End(span, err)
+	return summary, err
+}
+
+func (gp *GeminiProvider) summarize(ctx context.Context, transcript, language string) (string, error) {
+	if err := gp.budget.Allow(ctx); err != nil {
+		return "", err
+	}
+	canary, err := security.NewCanaryToken()
+	if err != nil {
+		return "", err
+	}
+
+	generation := GenerationFrom(ctx)
+	model := gp.client.GenerativeModel(gp.model)
+	model.SetTemperature(float32(generation.Temperature))
+	model.SetTopP(float32(generation.TopP))
+	model.SafetySettings = []*genai.SafetySetting{
+		{
+			Category:  genai.HarmCategoryDangerousContent,
+			Threshold: genai.HarmBlockLowAndAbove,
+		},
+	}
+
+	resp, err := model.GenerateContent(ctx, genai.Text(summaryPromptFor(canary, transcript, language)))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate summary: %w", classifyError(err))
+	}
+	gp.recordTokens(ctx, resp.UsageMetadata)
+
+	textPart, err := responseText(resp)
+	if err != nil {
+		return "", err
+	}
+	if security.ContainsCanary(string(textPart), canary) {
+		return "", fmt.Errorf("summary compromised: %w", security.ErrCanaryLeaked)
+	}
+	return string(textPart), nil
+}
+
+// Summarize summarizes a chat transcript in language, rejecting outputs that
+// leak the prompt's canary token with security.ErrCanaryLeaked
+func (op *OpenAIProvider) Summarize(ctx context.Context, transcript, language string) (string, error) {
+	ctx, span := startSummarizeSpan(ctx, ProviderOpenAI, op.model)
+	summary, err := op.summarize(ctx, transcript, language)
+	tracing.End(span, err)
+	return summary, err
+}
+
+func (op *OpenAIProvider) summarize(ctx context.Context, transcript, language string) (string, error) {
+	if err := op.budget.Allow(ctx); err != nil {
+		return "", err
+	}
+	canary, err := security.NewCanaryToken()
+	if err != nil {
+		return "", err
+	}
+
+	output, _, err := op.complete(ctx, summaryPromptFor(canary, transcript, language), GenerationFrom(ctx))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate summary: %w", err)
+	}
+	if security.ContainsCanary(output, canary) {
+		return "", fmt.Errorf("summary compromised: %w", security.ErrCanaryLeaked)
+	}
+	return output, nil
+}
+
+// Summarize summarizes with the providers of the chain, failing over like
+// translations. Providers that cannot summarize are skipped.
+func (c *ChainedProvider) Summarize(ctx context.Context, transcript, language string) (string, error) {
+	var summary string
+	_, err := c.call(ctx, func(p Provider) error {
+		summarizer, ok := p.(Summarizer)
+		if !ok {
+			return errSkipProvider
+		}
+		var err error
+		summary, err = summarizer.Summarize(ctx, transcript, language)
+		return err
+	})
+	return summary, err
+}
@@ -0,0 +1,143 @@
+package ai
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"text/template"
+)
+
+// promptTemplateExt is the extension of the files of a prompt template directory
+const promptTemplateExt = ".tmpl"
+
+// Names of the prompt templates that can be overridden. A template directory
+// holds one file per prompt, named after it with the .tmpl extension, e.g.
+// translate_v2.tmpl for the v2 translation prompt.
+const (
+	PromptTemplateDetect    = "detect"
+	PromptTemplateSummarize = "summarize"
+	PromptTemplateExplain   = "explain"
+	// promptTemplateTranslate prefixes the names of translation prompts,
+	// followed by their version
+	promptTemplateTranslate = "translate_"
+)
+
+// TranslationPromptData is passed to translation prompt templates. Guidance
+// holds lines on the style of the languages, e.g. the register of Japanese,
+// each starting with a line break; the built-in prompts follow the target
+// language with it.
+type TranslationPromptData struct {
+	SourceLanguage string
+	TargetLanguage string
+	Guidance       string
+	Text           string
+}
+
+// DetectionPromptData is passed to the language detection prompt template
+type DetectionPromptData struct {
+	Text string
+}
+
+// SummaryPromptData is passed to the thread summary prompt template
+type SummaryPromptData struct {
+	Language   string
+	Transcript string
+}
+
+// ExplanationPromptData is passed to the message explanation prompt template
+type ExplanationPromptData struct {
+	Language string
+	Text     string
+}
+
+// promptTemplates holds the templates loaded by LoadPromptTemplates by name;
+// prompts without one are built from the built-in strings
+var promptTemplates atomic.Pointer[map[string]*template.Template]
+
+// promptSample returns the data a template named name is checked with, and
+// the text of that data the rendered prompt must contain
+func promptSample(name string) (any, string, bool) {
+	const text = "<<sample message>>"
+	switch name {
+	case PromptTemplateDetect:
+		return DetectionPromptData{Text: text}, text, true
+	case PromptTemplateSummarize:
+		return SummaryPromptData{Language: "English", Transcript: text}, text, true
+	case PromptTemplateExplain:
+		return ExplanationPromptData{Language: "English", Text: text}, text, true
+	}
+	if version, ok := strings.CutPrefix(name, promptTemplateTranslate); ok && IsPromptVersion(version) {
+		return TranslationPromptData{SourceLanguage: "English", TargetLanguage: "Vietnamese", Text: text}, text, true
+	}
+	return nil, "", false
+}
+
+// LoadPromptTemplates replaces the built-in prompts with the text/template
+// files of dir and returns the names of the prompts it replaced. Every file
+// must be named after a known prompt and render the text it is given, so a
+// template that would drop the user's message fails at startup rather than
+// on every call. The canary preamble is always added ahead of the prompt.
+func LoadPromptTemplates(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompt template directory: %w", err)
+	}
+
+	templates := make(map[string]*template.Template)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != promptTemplateExt {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), promptTemplateExt)
+		sample, text, ok := promptSample(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown prompt template %s", entry.Name())
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prompt template %s: %w", entry.Name(), err)
+		}
+		tmpl, err := template.New(name).Option("missingkey=error").Parse(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("invalid prompt template %s: %w", entry.Name(), err)
+		}
+		var rendered strings.Builder
+		if err := tmpl.Execute(&rendered, sample); err != nil {
+			return nil, fmt.Errorf("invalid prompt template %s: %w", entry.Name(), err)
+		}
+		if !strings.Contains(rendered.String(), text) {
+			return nil, fmt.Errorf("prompt template %s does not include the text to process", entry.Name())
+		}
+		templates[name] = tmpl
+	}
+
+	promptTemplates.Store(&templates)
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// renderPrompt renders the template loaded for name with data, or returns
+// the built-in prompt when there is none or it fails
+func renderPrompt(name string, data any, builtin func() string) string {
+	templates := promptTemplates.Load()
+	if templates == nil {
+		return builtin()
+	}
+	tmpl, ok := (*templates)[name]
+	if !ok {
+		return builtin()
+	}
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return builtin()
+	}
+	return rendered.String()
+}
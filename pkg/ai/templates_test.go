@@ -0,0 +1,90 @@
+package ai
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePromptTemplates writes files to a new directory and restores the
+// built-in prompts once the test is over
+func writePromptTemplates(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	t.Cleanup(func() { promptTemplates.Store(nil) })
+	return dir
+}
+
+func TestLoadPromptTemplates(t *testing.T) {
+	dir := writePromptTemplates(t, map[string]string{
+		"translate_v2.tmpl": "Translate from {{.SourceLanguage}} to {{.TargetLanguage}}{{.Guidance}}:\n<UserInput>\n{{.Text}}\n</UserInput>",
+		"detect.tmpl":       "Language code of: {{.Text}}",
+		"summarize.tmpl":    "Summarize in {{.Language}}:\n{{.Transcript}}",
+		"README.md":         "not a template",
+	})
+
+	names, err := LoadPromptTemplates(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"detect", "summarize", "translate_v2"}, names)
+
+	prompt := translationPrompt(LatestPromptVersion, "cnry-test", "Hi John", "English", "Japanese")
+	assert.Contains(t, prompt, "cnry-test", "the canary preamble is kept")
+	assert.Contains(t, prompt, "Translate from English to Japanese\n- Japanese style: ")
+	assert.Contains(t, prompt, "<UserInput>\nHi John\n</UserInput>")
+
+	assert.Equal(t, "Language code of: Xin chào", detectionPrompt("Xin chào"))
+	assert.Contains(t, summaryPromptFor("cnry-test", "<@U1>: hi", "English"), "Summarize in English:\n<@U1>: hi")
+
+	// Prompts without a template are built in
+	assert.Contains(t, translationPrompt(StablePromptVersion, "cnry-test", "Hello", "English", "Vietnamese"),
+		"You are a professional translation system")
+	assert.Contains(t, explanationPromptFor("cnry-test", "Break a leg", "English"), "You are a language and culture guide")
+}
+
+func TestLoadPromptTemplates_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name:    "unknown prompt",
+			files:   map[string]string{"translate_v9.tmpl": "{{.Text}}"},
+			wantErr: "unknown prompt template translate_v9.tmpl",
+		},
+		{
+			name:    "syntax error",
+			files:   map[string]string{"detect.tmpl": "{{.Text"},
+			wantErr: "invalid prompt template detect.tmpl",
+		},
+		{
+			name:    "unknown field",
+			files:   map[string]string{"summarize.tmpl": "{{.Text}}"},
+			wantErr: "invalid prompt template summarize.tmpl",
+		},
+		{
+			name:    "text left out",
+			files:   map[string]string{"explain.tmpl": "Explain this in {{.Language}}"},
+			wantErr: "prompt template explain.tmpl does not include the text to process",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writePromptTemplates(t, tt.files)
+
+			_, err := LoadPromptTemplates(dir)
+			assert.ErrorContains(t, err, tt.wantErr)
+			assert.Contains(t, detectionPrompt("Hello"), "You are a language detection system", "the built-in prompts are kept")
+		})
+	}
+
+	_, err := LoadPromptTemplates(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
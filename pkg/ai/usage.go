@@ -0,0 +1,63 @@
+package ai
+
+import (
+	"context"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Usage describes the AI call that served a translation: the model that
+// answered, the prompt version it was sent and the tokens it used. Providers fill the Usage carried by the
+// context of a TranslateContext call; a nil Usage ignores Record.
+type Usage struct {
+	Model         string
+	PromptVersion string
+	PromptTokens  int64
+	OutputTokens  int64
+}
+
+// Record sets the usage of a successful call, replacing that of an earlier
+// call, e.g. to a provider that failed before the chain fell back
+func (u *Usage) Record(model, promptVersion string, promptTokens, outputTokens int64) {
+	if u == nil {
+		return
+	}
+	u.Model = model
+	u.PromptVersion = promptVersion
+	u.PromptTokens = promptTokens
+	u.OutputTokens = outputTokens
+}
+
+// startTranslateSpan starts the span of a provider's translation call
+func startTranslateSpan(ctx context.Context, provider, model, promptVersion string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, provider+".translate", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("gen_ai.system", provider),
+		attribute.String("gen_ai.request.model", model),
+		attribute.String("translation.prompt_version", promptVersion),
+	))
+}
+
+// recordUsage records the usage of a successful translation call on the
+// Usage and the span carried by ctx
+func recordUsage(ctx context.Context, model, promptVersion string, promptTokens, outputTokens int64) {
+	UsageFrom(ctx).Record(model, promptVersion, promptTokens, outputTokens)
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.Int64("gen_ai.usage.input_tokens", promptTokens),
+		attribute.Int64("gen_ai.usage.output_tokens", outputTokens),
+	)
+}
+
+type usageKey struct{}
+
+// WithUsage returns a copy of ctx carrying usage
+func WithUsage(ctx context.Context, usage *Usage) context.Context {
+	return context.WithValue(ctx, usageKey{}, usage)
+}
+
+// UsageFrom returns the usage carried by ctx, or nil
+func UsageFrom(ctx context.Context) *Usage {
+	usage, _ := ctx.Value(usageKey{}).(*Usage)
+	return usage
+}
@@ -0,0 +1,234 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+// Backend names reported in logs and metrics
+const (
+	BackendPrimary   = "primary"
+	BackendSecondary = "secondary"
+	BackendMemory    = "memory"
+)
+
+// FailoverCache serves the cache from the primary Redis and, while it fails,
+// from the secondary Redis, if any, then from memory. A failed backend is left
+// alone for retryAfter before it is tried again, so an outage costs one
+// timeout per backend and retry period rather than one per call.
+//
+// Backends are not synchronised: keys written during an outage stay on the
+// backend that served them, and the primary serves the keys it held before
+// once it recovers.
+type FailoverCache struct {
+	backends   []failoverBackend
+	retryAfter time.Duration
+	logger     *zap.Logger
+	metrics    *metrics.Metrics
+	now        func() time.Time
+
+	mu        sync.Mutex
+	downUntil map[string]time.Time
+	active    string
+}
+
+// Pinger is a backend whose reachability can be checked, e.g. RedisCache
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+const failoverPingTimeout = 2 * time.Second
+
+type failoverBackend struct {
+	name  string
+	cache service.Cache
+}
+
+// NewFailoverCache creates a cache failing over from primary to secondary,
+// which may be nil, then to fallback, which should not fail, e.g. a MemoryCache
+func NewFailoverCache(primary, secondary, fallback service.Cache, retryAfter time.Duration, logger *zap.Logger) *FailoverCache {
+	backends := []failoverBackend{{name: BackendPrimary, cache: primary}}
+	if secondary != nil {
+		backends = append(backends, failoverBackend{name: BackendSecondary, cache: secondary})
+	}
+	backends = append(backends, failoverBackend{name: BackendMemory, cache: fallback})
+
+	return &FailoverCache{
+		backends:   backends,
+		retryAfter: retryAfter,
+		logger:     logger,
+		now:        time.Now,
+		downUntil:  make(map[string]time.Time),
+		active:     BackendPrimary,
+	}
+}
+
+// SetMetrics records failovers and the backend in use
+func (f *FailoverCache) SetMetrics(m *metrics.Metrics) {
+	f.metrics = m
+	if m != nil {
+		m.RecordBackend("cache", BackendPrimary)
+	}
+}
+
+// Active returns the name of the backend that served the last call
+func (f *FailoverCache) Active() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.active
+}
+
+func (f *FailoverCache) Get(ctx context.Context, key string) (string, error) {
+	var value string
+	err := f.do(ctx, func(c service.Cache) error {
+		var err error
+		value, err = c.Get(ctx, key)
+		return err
+	})
+	return value, err
+}
+
+func (f *FailoverCache) Set(ctx context.Context, key string, value string, ttl int64) error {
+	return f.do(ctx, func(c service.Cache) error {
+		return c.Set(ctx, key, value, ttl)
+	})
+}
+
+func (f *FailoverCache) Delete(ctx context.Context, key string) error {
+	return f.do(ctx, func(c service.Cache) error {
+		return c.Delete(ctx, key)
+	})
+}
+
+func (f *FailoverCache) Exists(ctx context.Context, key string) (bool, error) {
+	var exists bool
+	err := f.do(ctx, func(c service.Cache) error {
+		var err error
+		exists, err = c.Exists(ctx, key)
+		return err
+	})
+	return exists, err
+}
+
+// Run pings the backends every interval until ctx is done, starting now, so
+// an unreachable backend is skipped before a call waits for it and is used
+// again as soon as it answers
+func (f *FailoverCache) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		f.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check pings each backend that is a Pinger, except the fallback, marking it
+// down when the ping fails and up when it answers again
+func (f *FailoverCache) Check(ctx context.Context) {
+	for _, backend := range f.backends[:len(f.backends)-1] {
+		pinger, ok := backend.cache.(Pinger)
+		if !ok {
+			continue
+		}
+
+		pingCtx, cancel := context.WithTimeout(ctx, failoverPingTimeout)
+		err := pinger.Ping(pingCtx)
+		cancel()
+		if err != nil {
+			f.markDown(backend.name, err)
+		} else {
+			f.markRecovered(backend.name)
+		}
+	}
+}
+
+// do runs op on the first backend that is not known to be down and succeeds.
+// A miss counts as success. The last backend is always tried, and its error
+// returned when every backend failed. A failure because ctx is done is
+// returned as is, without blaming the backend.
+func (f *FailoverCache) do(ctx context.Context, op func(service.Cache) error) error {
+	var err error
+	for i, backend := range f.backends {
+		last := i == len(f.backends)-1
+		if !last && f.isDown(backend.name) {
+			continue
+		}
+
+		err = op(backend.cache)
+		if err == nil || errors.Is(err, ErrKeyNotFound) {
+			f.markUp(backend.name)
+			return err
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		if !last {
+			f.markDown(backend.name, err)
+		}
+	}
+	return err
+}
+
+func (f *FailoverCache) isDown(name string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	until, down := f.downUntil[name]
+	return down && f.now().Before(until)
+}
+
+func (f *FailoverCache) markDown(name string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, down := f.downUntil[name]; !down {
+		f.logger.Warn("Cache backend failed, failing over",
+			zap.String("backend", name),
+			zap.Duration("retry_after", f.retryAfter),
+			zap.Error(err))
+		if f.metrics != nil {
+			f.metrics.RecordError("cache_failover")
+		}
+	}
+	f.downUntil[name] = f.now().Add(f.retryAfter)
+}
+
+// markUp records that name served a call
+func (f *FailoverCache) markUp(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.recovered(name)
+	if f.active != name {
+		f.active = name
+		if f.metrics != nil {
+			f.metrics.RecordBackend("cache", name)
+		}
+	}
+}
+
+// markRecovered records that name answered a ping, so the next call tries it
+func (f *FailoverCache) markRecovered(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.recovered(name)
+}
+
+func (f *FailoverCache) recovered(name string) {
+	if _, down := f.downUntil[name]; down {
+		f.logger.Info("Cache backend recovered", zap.String("backend", name))
+		delete(f.downUntil, name)
+	}
+}
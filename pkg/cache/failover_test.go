@@ -0,0 +1,172 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// flakyCache is a MemoryCache that fails every call while down is set
+type flakyCache struct {
+	*MemoryCache
+	down  bool
+	calls int
+}
+
+var errUnreachable = errors.New("connection refused")
+
+func newFlakyCache() *flakyCache {
+	return &flakyCache{MemoryCache: NewMemoryCache(100)}
+}
+
+func (f *flakyCache) Get(ctx context.Context, key string) (string, error) {
+	f.calls++
+	if f.down {
+		return "", errUnreachable
+	}
+	return f.MemoryCache.Get(ctx, key)
+}
+
+func (f *flakyCache) Set(ctx context.Context, key string, value string, ttl int64) error {
+	f.calls++
+	if f.down {
+		return errUnreachable
+	}
+	return f.MemoryCache.Set(ctx, key, value, ttl)
+}
+
+func TestFailoverCache_FailsOverToSecondaryThenMemory(t *testing.T) {
+	ctx := context.Background()
+	primary, secondary := newFlakyCache(), newFlakyCache()
+	cache := NewFailoverCache(primary, secondary, NewMemoryCache(100), time.Minute, zap.NewNop())
+
+	require.NoError(t, cache.Set(ctx, "key", "primary", 0))
+	assert.Equal(t, BackendPrimary, cache.Active())
+
+	primary.down = true
+	require.NoError(t, cache.Set(ctx, "key", "secondary", 0))
+	assert.Equal(t, BackendSecondary, cache.Active())
+	value, err := secondary.MemoryCache.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "secondary", value)
+
+	secondary.down = true
+	require.NoError(t, cache.Set(ctx, "key", "memory", 0))
+	assert.Equal(t, BackendMemory, cache.Active())
+	value, err = cache.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "memory", value)
+}
+
+func TestFailoverCache_RetriesPrimaryAfterCooldown(t *testing.T) {
+	ctx := context.Background()
+	primary := newFlakyCache()
+	cache := NewFailoverCache(primary, nil, NewMemoryCache(100), time.Minute, zap.NewNop())
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	primary.down = true
+	_, err := cache.Get(ctx, "key")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	assert.Equal(t, BackendMemory, cache.Active())
+
+	// The primary is not called again until the cooldown has passed
+	_, _ = cache.Get(ctx, "key")
+	assert.Equal(t, 1, primary.calls)
+
+	primary.down = false
+	now = now.Add(time.Minute)
+	_, err = cache.Get(ctx, "key")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	assert.Equal(t, 2, primary.calls)
+	assert.Equal(t, BackendPrimary, cache.Active())
+}
+
+func TestMemoryCache_ExpiresAndEvicts(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryCache(2)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	require.NoError(t, cache.Set(ctx, "short", "1", 10))
+	require.NoError(t, cache.Set(ctx, "long", "2", 0))
+
+	now = now.Add(11 * time.Second)
+	exists, err := cache.Exists(ctx, "short")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// A full cache still takes new keys
+	require.NoError(t, cache.Set(ctx, "a", "3", 0))
+	require.NoError(t, cache.Set(ctx, "b", "4", 0))
+	value, err := cache.Get(ctx, "b")
+	require.NoError(t, err)
+	assert.Equal(t, "4", value)
+	assert.LessOrEqual(t, len(cache.entries), 2)
+}
+
+func TestFailoverCache_CancelledCallDoesNotFailOver(t *testing.T) {
+	primary := newFlakyCache()
+	cache := NewFailoverCache(primary, nil, NewMemoryCache(100), time.Minute, zap.NewNop())
+
+	// A call given up by its caller is not blamed on the backend
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	primary.down = true
+	_, err := cache.Get(ctx, "key")
+	assert.ErrorIs(t, err, errUnreachable)
+	assert.False(t, cache.isDown(BackendPrimary))
+	assert.Equal(t, 1, primary.calls)
+}
+
+func TestFailoverCache_CheckMarksDownAndRecovers(t *testing.T) {
+	ctx := context.Background()
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	// Redis is down when the cache is created
+	port := mr.Server().Addr().Port
+	mr.Close()
+	primary := NewUncheckedRedisCache("127.0.0.1", port, "")
+	cache := NewFailoverCache(primary, nil, NewMemoryCache(100), time.Minute, zap.NewNop())
+
+	cache.Check(ctx)
+	require.NoError(t, cache.Set(ctx, "key", "memory", 0))
+	assert.Equal(t, BackendMemory, cache.Active())
+
+	// It is used again once a ping answers, without waiting for the cooldown
+	require.NoError(t, mr.Restart())
+	cache.Check(ctx)
+	require.NoError(t, cache.Set(ctx, "key", "primary", 0))
+	assert.Equal(t, BackendPrimary, cache.Active())
+	value, err := primary.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "primary", value)
+}
+
+func TestMemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryCache(2)
+
+	require.NoError(t, cache.Set(ctx, "a", "1", 0))
+	require.NoError(t, cache.Set(ctx, "b", "2", 0))
+	_, err := cache.Get(ctx, "a")
+	require.NoError(t, err)
+	require.NoError(t, cache.Set(ctx, "c", "3", 0))
+
+	_, err = cache.Get(ctx, "b")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	value, err := cache.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "1", value)
+	exists, err := cache.Exists(ctx, "c")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
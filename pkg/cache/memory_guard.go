@@ -0,0 +1,181 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	memoryGuardTimeout = 3 * time.Second
+	// memoryWarnRatio of maxmemory in use logs a warning
+	memoryWarnRatio = 0.9
+	// hitRateDropWarn is the drop of the translation cache hit rate, in
+	// percentage points, that is blamed on evictions happening meanwhile
+	hitRateDropWarn = 10.0
+	// minHitRateLookups is the number of cache lookups a check needs for its
+	// hit rate to be compared
+	minHitRateLookups = 20
+)
+
+// MemoryGuard samples the memory use and evictions of a Redis and warns when
+// evicted keys start lowering the translation cache hit rate, i.e. when
+// Redis is too small for the cache.
+type MemoryGuard struct {
+	info    func(ctx context.Context, section string) (string, error)
+	logger  *zap.Logger
+	metrics *metrics.Metrics
+
+	sampled        bool
+	last           metrics.RedisMemoryStats
+	lastHits       int64
+	lastMisses     int64
+	baseline       float64
+	hasBaseline    bool
+	policyReported bool
+}
+
+// NewMemoryGuard creates a guard of the Redis behind client
+func NewMemoryGuard(client *redis.Client, logger *zap.Logger) *MemoryGuard {
+	return &MemoryGuard{
+		info: func(ctx context.Context, section string) (string, error) {
+			return client.Info(ctx, section).Result()
+		},
+		logger: logger,
+	}
+}
+
+// SetMetrics reports the samples in m and compares them with its cache hit rate
+func (g *MemoryGuard) SetMetrics(m *metrics.Metrics) {
+	g.metrics = m
+}
+
+// Run samples Redis every interval until ctx is done
+func (g *MemoryGuard) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			g.Check(ctx)
+		}
+	}
+}
+
+// Check samples Redis once and logs the warnings it calls for
+func (g *MemoryGuard) Check(ctx context.Context) {
+	stats, err := g.sample(ctx)
+	if err != nil {
+		g.logger.Warn("Failed to sample Redis memory", zap.Error(err))
+		return
+	}
+
+	if !g.policyReported && stats.MaxBytes > 0 && stats.Policy == "noeviction" {
+		g.policyReported = true
+		g.logger.Warn("Redis evicts nothing when full, cache writes will fail once maxmemory is reached",
+			zap.Int64("max_bytes", stats.MaxBytes))
+	}
+	if stats.MaxBytes > 0 && float64(stats.UsedBytes) >= memoryWarnRatio*float64(stats.MaxBytes) {
+		g.logger.Warn("Redis memory nearly full",
+			zap.Int64("used_bytes", stats.UsedBytes),
+			zap.Int64("max_bytes", stats.MaxBytes),
+			zap.String("policy", stats.Policy))
+	}
+
+	if g.metrics != nil {
+		g.metrics.RecordRedisMemory(stats)
+		g.checkEvictions(stats)
+	}
+	g.last = stats
+	g.sampled = true
+}
+
+// checkEvictions compares the cache hit rate since the last check with the
+// one before evictions started
+func (g *MemoryGuard) checkEvictions(stats metrics.RedisMemoryStats) {
+	hits, misses := g.metrics.CacheCounts()
+	lookups := (hits - g.lastHits) + (misses - g.lastMisses)
+	rate := 0.0
+	if lookups > 0 {
+		rate = float64(hits-g.lastHits) / float64(lookups) * 100
+	}
+	g.lastHits, g.lastMisses = hits, misses
+
+	var evicted int64
+	if g.sampled {
+		// A Redis restart resets its counters
+		evicted = max(stats.EvictedKeys-g.last.EvictedKeys, 0)
+	}
+	if lookups < minHitRateLookups {
+		return
+	}
+
+	if evicted == 0 {
+		g.baseline = rate
+		g.hasBaseline = true
+		return
+	}
+	if g.hasBaseline && rate <= g.baseline-hitRateDropWarn {
+		g.logger.Warn("Redis evictions are lowering the translation cache hit rate, consider more memory or a lower CACHE_TTL_TRANSLATION_SECONDS",
+			zap.Int64("evicted_keys", evicted),
+			zap.Float64("hit_rate", rate),
+			zap.Float64("hit_rate_before_evictions", g.baseline),
+			zap.String("policy", stats.Policy))
+		g.metrics.RecordError("cache_eviction_pressure")
+	}
+}
+
+// sample reads the memory and stats sections of Redis INFO
+func (g *MemoryGuard) sample(ctx context.Context) (metrics.RedisMemoryStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, memoryGuardTimeout)
+	defer cancel()
+
+	memory, err := g.info(ctx, "memory")
+	if err != nil {
+		return metrics.RedisMemoryStats{}, fmt.Errorf("failed to read Redis memory info: %w", err)
+	}
+	stats, err := g.info(ctx, "stats")
+	if err != nil {
+		return metrics.RedisMemoryStats{}, fmt.Errorf("failed to read Redis stats: %w", err)
+	}
+
+	fields := parseInfo(memory + "\n" + stats)
+	return metrics.RedisMemoryStats{
+		UsedBytes:   infoInt(fields, "used_memory"),
+		MaxBytes:    infoInt(fields, "maxmemory"),
+		Policy:      fields["maxmemory_policy"],
+		EvictedKeys: infoInt(fields, "evicted_keys"),
+		ExpiredKeys: infoInt(fields, "expired_keys"),
+	}, nil
+}
+
+// parseInfo reads the "field:value" lines of a Redis INFO reply
+func parseInfo(info string) map[string]string {
+	fields := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(info))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			fields[key] = value
+		}
+	}
+	return fields
+}
+
+func infoInt(fields map[string]string, key string) int64 {
+	value, _ := strconv.ParseInt(fields[key], 10, 64)
+	return value
+}
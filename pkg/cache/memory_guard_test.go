@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// fakeRedisInfo answers INFO memory and stats with the given counters
+type fakeRedisInfo struct {
+	usedMemory  int64
+	evictedKeys int64
+	err         error
+}
+
+func (f *fakeRedisInfo) info(_ context.Context, section string) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	if section == "memory" {
+		return fmt.Sprintf("# Memory\r\nused_memory:%d\r\nmaxmemory:1000\r\nmaxmemory_policy:allkeys-lru\r\n", f.usedMemory), nil
+	}
+	return fmt.Sprintf("# Stats\r\nexpired_keys:3\r\nevicted_keys:%d\r\n", f.evictedKeys), nil
+}
+
+func recordLookups(m *metrics.Metrics, hits, misses int) {
+	for range hits {
+		m.RecordCacheHit()
+	}
+	for range misses {
+		m.RecordCacheMiss()
+	}
+}
+
+func TestMemoryGuard_Check(t *testing.T) {
+	redisInfo := &fakeRedisInfo{usedMemory: 500}
+	m := metrics.NewMetrics()
+	guard := NewMemoryGuard(nil, zap.NewNop())
+	guard.info = redisInfo.info
+	guard.SetMetrics(m)
+
+	// No evictions: the hit rate becomes the baseline
+	recordLookups(m, 90, 10)
+	guard.Check(context.Background())
+	assert.Equal(t, metrics.RedisMemoryStats{
+		UsedBytes:   500,
+		MaxBytes:    1000,
+		Policy:      "allkeys-lru",
+		ExpiredKeys: 3,
+	}, m.RedisMemory)
+
+	// Evictions while the hit rate holds up are fine
+	redisInfo.evictedKeys = 5
+	recordLookups(m, 85, 15)
+	guard.Check(context.Background())
+	assert.Zero(t, m.ErrorsByType["cache_eviction_pressure"])
+
+	// Evictions with the hit rate collapsing are reported
+	redisInfo.evictedKeys = 50
+	redisInfo.usedMemory = 990
+	recordLookups(m, 10, 30)
+	guard.Check(context.Background())
+	assert.Equal(t, int64(1), m.ErrorsByType["cache_eviction_pressure"])
+	assert.Equal(t, int64(990), m.RedisMemory.UsedBytes)
+
+	// Too few lookups to judge
+	redisInfo.evictedKeys = 80
+	recordLookups(m, 0, 5)
+	guard.Check(context.Background())
+	assert.Equal(t, int64(1), m.ErrorsByType["cache_eviction_pressure"])
+
+	// A failed sample keeps the last one
+	redisInfo.err = errors.New("connection refused")
+	guard.Check(context.Background())
+	assert.Equal(t, int64(80), m.RedisMemory.EvictedKeys)
+}
+
+func TestParseInfo(t *testing.T) {
+	fields := parseInfo("# Memory\r\nused_memory:1024\r\nused_memory_human:1.00K\r\n\r\n# Stats\r\nevicted_keys:7\r\n")
+	assert.Equal(t, "1024", fields["used_memory"])
+	assert.Equal(t, "7", fields["evicted_keys"])
+	assert.Equal(t, int64(0), infoInt(fields, "maxmemory"))
+}
@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+)
+
+// SchemaVersion is the version of the values the application caches. Bump
+// it whenever a cached format changes, so a deployment never reads values
+// written by an older one.
+const SchemaVersion = 1
+
+// NamespacedCache prefixes every key with a namespace and the schema version,
+// e.g. "prod:v1:translation:<hash>", so environments or workspaces sharing a
+// Redis never read each other's keys.
+type NamespacedCache struct {
+	cache  service.Cache
+	prefix string
+	maxTTL int64
+}
+
+// NewNamespacedCache wraps cache, prefixing its keys with namespace, if any,
+// and SchemaVersion
+func NewNamespacedCache(cache service.Cache, namespace string) *NamespacedCache {
+	prefix := fmt.Sprintf("v%d:", SchemaVersion)
+	if namespace != "" {
+		prefix = namespace + ":" + prefix
+	}
+	return &NamespacedCache{cache: cache, prefix: prefix}
+}
+
+// SetMaxTTL caps the TTL of expiring keys at maxTTL seconds, bounding how long
+// cached translations hold Redis memory. Keys set without a TTL, e.g.
+// maintenance mode, are left alone. 0 disables the cap.
+func (n *NamespacedCache) SetMaxTTL(maxTTL int64) {
+	n.maxTTL = maxTTL
+}
+
+// Key returns the key under which key is stored in the wrapped cache
+func (n *NamespacedCache) Key(key string) string {
+	return n.prefix + key
+}
+
+func (n *NamespacedCache) Get(ctx context.Context, key string) (string, error) {
+	return n.cache.Get(ctx, n.Key(key))
+}
+
+func (n *NamespacedCache) Set(ctx context.Context, key string, value string, ttl int64) error {
+	if n.maxTTL > 0 && ttl > n.maxTTL {
+		ttl = n.maxTTL
+	}
+	return n.cache.Set(ctx, n.Key(key), value, ttl)
+}
+
+func (n *NamespacedCache) Delete(ctx context.Context, key string) error {
+	return n.cache.Delete(ctx, n.Key(key))
+}
+
+func (n *NamespacedCache) Exists(ctx context.Context, key string) (bool, error) {
+	return n.cache.Exists(ctx, n.Key(key))
+}
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespacedCache(t *testing.T) {
+	ctx := context.Background()
+	shared := NewMemoryCache(100)
+	prod := NewNamespacedCache(shared, "prod")
+	staging := NewNamespacedCache(shared, "staging")
+
+	require.NoError(t, prod.Set(ctx, "translation:abc", "Xin chào", 0))
+
+	value, err := prod.Get(ctx, "translation:abc")
+	require.NoError(t, err)
+	assert.Equal(t, "Xin chào", value)
+
+	_, err = staging.Get(ctx, "translation:abc")
+	assert.ErrorIs(t, err, ErrKeyNotFound, "namespaces sharing a cache do not see each other's keys")
+
+	value, err = shared.Get(ctx, "prod:v1:translation:abc")
+	require.NoError(t, err)
+	assert.Equal(t, "Xin chào", value)
+
+	exists, err := prod.Exists(ctx, "translation:abc")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	require.NoError(t, prod.Delete(ctx, "translation:abc"))
+	exists, err = shared.Exists(ctx, "prod:v1:translation:abc")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestNamespacedCache_WithoutNamespace(t *testing.T) {
+	assert.Equal(t, "v1:channel_config:C1", NewNamespacedCache(NewMemoryCache(1), "").Key("channel_config:C1"))
+}
+
+func TestNamespacedCache_MaxTTL(t *testing.T) {
+	ctx := context.Background()
+	shared := NewMemoryCache(100)
+	now := time.Now()
+	shared.now = func() time.Time { return now }
+	namespaced := NewNamespacedCache(shared, "")
+	namespaced.SetMaxTTL(60)
+
+	require.NoError(t, namespaced.Set(ctx, "translation:abc", "Xin chào", 86400))
+	require.NoError(t, namespaced.Set(ctx, "maintenance", "on", 0))
+
+	now = now.Add(2 * time.Minute)
+	_, err := namespaced.Get(ctx, "translation:abc")
+	assert.ErrorIs(t, err, ErrKeyNotFound, "the TTL was capped")
+	_, err = namespaced.Get(ctx, "maintenance")
+	assert.NoError(t, err, "keys without a TTL are not capped")
+}
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,34 +10,62 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// ErrKeyNotFound is returned by Get when the key is not cached
+var ErrKeyNotFound = errors.New("key not found")
+
+// defaultRedisTimeout bounds each call unless SetTimeout changes it
+const defaultRedisTimeout = 5 * time.Second
+
 type RedisCache struct {
-	client *redis.Client
+	client  *redis.Client
+	timeout time.Duration
 }
 
 func NewRedisCache(host string, port int, password string) (service.Cache, error) {
+	cache := NewUncheckedRedisCache(host, port, password)
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultRedisTimeout)
+	defer cancel()
+
+	if err := cache.Ping(ctx); err != nil {
+		return nil, err
+	}
+
+	return cache, nil
+}
+
+// NewUncheckedRedisCache creates a cache on the Redis at host:port without
+// checking that it can be reached, e.g. to start while it is down and use it
+// once it is back
+func NewUncheckedRedisCache(host string, port int, password string) *RedisCache {
 	client := redis.NewClient(&redis.Options{
 		Addr:     fmt.Sprintf("%s:%d", host, port),
 		Password: password,
 		DB:       0,
 	})
+	return &RedisCache{client: client, timeout: defaultRedisTimeout}
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+// SetTimeout bounds each call, within the deadline of the caller's context
+func (r *RedisCache) SetTimeout(timeout time.Duration) {
+	r.timeout = timeout
+}
 
-	if err := client.Ping(ctx).Err(); err != nil {
-		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+// Ping checks that Redis can be reached
+func (r *RedisCache) Ping(ctx context.Context) error {
+	if err := r.client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("failed to connect to Redis: %w", err)
 	}
-
-	return &RedisCache{client: client}, nil
+	return nil
 }
 
-func (r *RedisCache) Get(key string) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *RedisCache) Get(ctx context.Context, key string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	val, err := r.client.Get(ctx, key).Result()
 	if err == redis.Nil {
-		return "", fmt.Errorf("key not found")
+		return "", ErrKeyNotFound
 	}
 	if err != nil {
 		return "", err
@@ -44,23 +73,23 @@ func (r *RedisCache) Get(key string) (string, error) {
 	return val, nil
 }
 
-func (r *RedisCache) Set(key string, value string, ttl int64) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *RedisCache) Set(ctx context.Context, key string, value string, ttl int64) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	duration := time.Duration(ttl) * time.Second
 	return r.client.Set(ctx, key, value, duration).Err()
 }
 
-func (r *RedisCache) Delete(key string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *RedisCache) Delete(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	return r.client.Del(ctx, key).Err()
 }
 
-func (r *RedisCache) Exists(key string) (bool, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *RedisCache) Exists(ctx context.Context, key string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	val, err := r.client.Exists(ctx, key).Result()
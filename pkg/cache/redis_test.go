@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 )
 
 func TestRedisCache_SetAndGet(t *testing.T) {
+	ctx := context.Background()
 	mr, err := miniredis.Run()
 	assert.NoError(t, err)
 	defer mr.Close()
@@ -16,15 +18,16 @@ func TestRedisCache_SetAndGet(t *testing.T) {
 	cache, err := NewRedisCache("127.0.0.1", mr.Server().Addr().Port, "")
 	assert.NoError(t, err)
 
-	err = cache.Set("test-key", "test-value", 3600)
+	err = cache.Set(ctx, "test-key", "test-value", 3600)
 	assert.NoError(t, err)
 
-	val, err := cache.Get("test-key")
+	val, err := cache.Get(ctx, "test-key")
 	assert.NoError(t, err)
 	assert.Equal(t, "test-value", val)
 }
 
 func TestRedisCache_GetNonExistent(t *testing.T) {
+	ctx := context.Background()
 	mr, err := miniredis.Run()
 	assert.NoError(t, err)
 	defer mr.Close()
@@ -32,12 +35,13 @@ func TestRedisCache_GetNonExistent(t *testing.T) {
 	cache, err := NewRedisCache("127.0.0.1", mr.Server().Addr().Port, "")
 	assert.NoError(t, err)
 
-	_, err = cache.Get("nonexistent")
+	_, err = cache.Get(ctx, "nonexistent")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "key not found")
 }
 
 func TestRedisCache_Delete(t *testing.T) {
+	ctx := context.Background()
 	mr, err := miniredis.Run()
 	assert.NoError(t, err)
 	defer mr.Close()
@@ -45,15 +49,16 @@ func TestRedisCache_Delete(t *testing.T) {
 	cache, err := NewRedisCache("127.0.0.1", mr.Server().Addr().Port, "")
 	assert.NoError(t, err)
 
-	_ = cache.Set("test-key", "test-value", 3600)
-	err = cache.Delete("test-key")
+	_ = cache.Set(ctx, "test-key", "test-value", 3600)
+	err = cache.Delete(ctx, "test-key")
 	assert.NoError(t, err)
 
-	_, err = cache.Get("test-key")
+	_, err = cache.Get(ctx, "test-key")
 	assert.Error(t, err)
 }
 
 func TestRedisCache_Exists_True(t *testing.T) {
+	ctx := context.Background()
 	mr, err := miniredis.Run()
 	assert.NoError(t, err)
 	defer mr.Close()
@@ -61,13 +66,14 @@ func TestRedisCache_Exists_True(t *testing.T) {
 	cache, err := NewRedisCache("127.0.0.1", mr.Server().Addr().Port, "")
 	assert.NoError(t, err)
 
-	_ = cache.Set("test-key", "test-value", 3600)
-	exists, err := cache.Exists("test-key")
+	_ = cache.Set(ctx, "test-key", "test-value", 3600)
+	exists, err := cache.Exists(ctx, "test-key")
 	assert.NoError(t, err)
 	assert.True(t, exists)
 }
 
 func TestRedisCache_Exists_False(t *testing.T) {
+	ctx := context.Background()
 	mr, err := miniredis.Run()
 	assert.NoError(t, err)
 	defer mr.Close()
@@ -75,12 +81,13 @@ func TestRedisCache_Exists_False(t *testing.T) {
 	cache, err := NewRedisCache("127.0.0.1", mr.Server().Addr().Port, "")
 	assert.NoError(t, err)
 
-	exists, err := cache.Exists("nonexistent")
+	exists, err := cache.Exists(ctx, "nonexistent")
 	assert.NoError(t, err)
 	assert.False(t, exists)
 }
 
 func TestRedisCache_TTL(t *testing.T) {
+	ctx := context.Background()
 	mr, err := miniredis.Run()
 	assert.NoError(t, err)
 	defer mr.Close()
@@ -88,20 +95,21 @@ func TestRedisCache_TTL(t *testing.T) {
 	cache, err := NewRedisCache("127.0.0.1", mr.Server().Addr().Port, "")
 	assert.NoError(t, err)
 
-	err = cache.Set("test-key", "test-value", 1)
+	err = cache.Set(ctx, "test-key", "test-value", 1)
 	assert.NoError(t, err)
 
-	exists, err := cache.Exists("test-key")
+	exists, err := cache.Exists(ctx, "test-key")
 	assert.NoError(t, err)
 	assert.True(t, exists)
 
 	mr.FastForward(2 * time.Second)
 
-	_, err = cache.Get("test-key")
+	_, err = cache.Get(ctx, "test-key")
 	assert.Error(t, err)
 }
 
 func TestRedisCache_MultipleOperations(t *testing.T) {
+	ctx := context.Background()
 	mr, err := miniredis.Run()
 	assert.NoError(t, err)
 	defer mr.Close()
@@ -109,30 +117,31 @@ func TestRedisCache_MultipleOperations(t *testing.T) {
 	cache, err := NewRedisCache("127.0.0.1", mr.Server().Addr().Port, "")
 	assert.NoError(t, err)
 
-	_ = cache.Set("key1", "value1", 3600)
-	_ = cache.Set("key2", "value2", 3600)
-	_ = cache.Set("key3", "value3", 3600)
+	_ = cache.Set(ctx, "key1", "value1", 3600)
+	_ = cache.Set(ctx, "key2", "value2", 3600)
+	_ = cache.Set(ctx, "key3", "value3", 3600)
 
-	val1, _ := cache.Get("key1")
-	val2, _ := cache.Get("key2")
-	val3, _ := cache.Get("key3")
+	val1, _ := cache.Get(ctx, "key1")
+	val2, _ := cache.Get(ctx, "key2")
+	val3, _ := cache.Get(ctx, "key3")
 
 	assert.Equal(t, "value1", val1)
 	assert.Equal(t, "value2", val2)
 	assert.Equal(t, "value3", val3)
 
-	_ = cache.Delete("key2")
+	_ = cache.Delete(ctx, "key2")
 
-	_, err = cache.Get("key2")
+	_, err = cache.Get(ctx, "key2")
 	assert.Error(t, err)
 
-	exists1, _ := cache.Exists("key1")
-	exists3, _ := cache.Exists("key3")
+	exists1, _ := cache.Exists(ctx, "key1")
+	exists3, _ := cache.Exists(ctx, "key3")
 	assert.True(t, exists1)
 	assert.True(t, exists3)
 }
 
 func TestRedisCache_UpdateValue(t *testing.T) {
+	ctx := context.Background()
 	mr, err := miniredis.Run()
 	assert.NoError(t, err)
 	defer mr.Close()
@@ -140,16 +149,17 @@ func TestRedisCache_UpdateValue(t *testing.T) {
 	cache, err := NewRedisCache("127.0.0.1", mr.Server().Addr().Port, "")
 	assert.NoError(t, err)
 
-	_ = cache.Set("test-key", "initial-value", 3600)
-	val, _ := cache.Get("test-key")
+	_ = cache.Set(ctx, "test-key", "initial-value", 3600)
+	val, _ := cache.Get(ctx, "test-key")
 	assert.Equal(t, "initial-value", val)
 
-	_ = cache.Set("test-key", "updated-value", 3600)
-	val, _ = cache.Get("test-key")
+	_ = cache.Set(ctx, "test-key", "updated-value", 3600)
+	val, _ = cache.Get(ctx, "test-key")
 	assert.Equal(t, "updated-value", val)
 }
 
 func TestRedisCache_EmptyValue(t *testing.T) {
+	ctx := context.Background()
 	mr, err := miniredis.Run()
 	assert.NoError(t, err)
 	defer mr.Close()
@@ -157,15 +167,16 @@ func TestRedisCache_EmptyValue(t *testing.T) {
 	cache, err := NewRedisCache("127.0.0.1", mr.Server().Addr().Port, "")
 	assert.NoError(t, err)
 
-	err = cache.Set("test-key", "", 3600)
+	err = cache.Set(ctx, "test-key", "", 3600)
 	assert.NoError(t, err)
 
-	val, err := cache.Get("test-key")
+	val, err := cache.Get(ctx, "test-key")
 	assert.NoError(t, err)
 	assert.Equal(t, "", val)
 }
 
 func TestRedisCache_LongValue(t *testing.T) {
+	ctx := context.Background()
 	mr, err := miniredis.Run()
 	assert.NoError(t, err)
 	defer mr.Close()
@@ -178,10 +189,28 @@ func TestRedisCache_LongValue(t *testing.T) {
 		longValue += "x"
 	}
 
-	err = cache.Set("test-key", longValue, 3600)
+	err = cache.Set(ctx, "test-key", longValue, 3600)
 	assert.NoError(t, err)
 
-	val, err := cache.Get("test-key")
+	val, err := cache.Get(ctx, "test-key")
 	assert.NoError(t, err)
 	assert.Equal(t, longValue, val)
 }
+
+func TestRedisCache_GivesUpWhenContextIsDone(t *testing.T) {
+	mr, err := miniredis.Run()
+	assert.NoError(t, err)
+	defer mr.Close()
+
+	cache := NewUncheckedRedisCache("127.0.0.1", mr.Server().Addr().Port, "")
+	cache.SetTimeout(time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = cache.Set(ctx, "test-key", "test-value", 3600)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	exists, err := cache.Exists(context.Background(), "test-key")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
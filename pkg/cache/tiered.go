@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+)
+
+// Cache tiers reported in metrics
+const (
+	TierLocal  = "l1"
+	TierShared = "l2"
+)
+
+// TieredCache keeps the keys it reads and writes in a local LRU in front of a
+// shared cache, e.g. Redis, so hot keys skip the round trip. Writes go through
+// to the shared cache. Local copies live at most ttl, and a key changed by
+// another instance is seen here only once its local copy expires, so it suits
+// keys whose value does not change, such as translations.
+type TieredCache struct {
+	local   *MemoryCache
+	shared  service.Cache
+	ttl     int64
+	metrics *metrics.Metrics
+}
+
+// NewTieredCache creates a cache holding at most maxEntries keys locally, for
+// at most ttl, in front of shared
+func NewTieredCache(shared service.Cache, maxEntries int, ttl time.Duration) *TieredCache {
+	return &TieredCache{
+		local:  NewMemoryCache(maxEntries),
+		shared: shared,
+		ttl:    int64(ttl.Seconds()),
+	}
+}
+
+// SetMetrics records the hits and misses of each tier
+func (t *TieredCache) SetMetrics(m *metrics.Metrics) {
+	t.metrics = m
+}
+
+func (t *TieredCache) Get(ctx context.Context, key string) (string, error) {
+	if value, err := t.local.Get(ctx, key); err == nil {
+		t.record(TierLocal, true)
+		return value, nil
+	}
+	t.record(TierLocal, false)
+
+	value, err := t.shared.Get(ctx, key)
+	t.record(TierShared, err == nil)
+	if err != nil {
+		return "", err
+	}
+	_ = t.local.Set(ctx, key, value, t.ttl)
+	return value, nil
+}
+
+// Set writes the key to the shared cache and keeps it locally, even when the
+// shared write fails, whose error is returned
+func (t *TieredCache) Set(ctx context.Context, key string, value string, ttl int64) error {
+	localTTL := t.ttl
+	if ttl > 0 && ttl < localTTL {
+		localTTL = ttl
+	}
+	_ = t.local.Set(ctx, key, value, localTTL)
+	return t.shared.Set(ctx, key, value, ttl)
+}
+
+func (t *TieredCache) Delete(ctx context.Context, key string) error {
+	_ = t.local.Delete(ctx, key)
+	return t.shared.Delete(ctx, key)
+}
+
+func (t *TieredCache) Exists(ctx context.Context, key string) (bool, error) {
+	if exists, _ := t.local.Exists(ctx, key); exists {
+		return true, nil
+	}
+	return t.shared.Exists(ctx, key)
+}
+
+func (t *TieredCache) record(tier string, hit bool) {
+	if t.metrics != nil {
+		t.metrics.RecordCacheTier(tier, hit)
+	}
+}
@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTieredCache_ServesHotKeysLocally(t *testing.T) {
+	ctx := context.Background()
+	shared := newFlakyCache()
+	cache := NewTieredCache(shared, 100, time.Minute)
+	m := metrics.NewMetrics()
+	cache.SetMetrics(m)
+
+	// Writes go through to the shared cache
+	require.NoError(t, cache.Set(ctx, "key", "value", 3600))
+	value, err := shared.MemoryCache.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+
+	calls := shared.calls
+	value, err = cache.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+	assert.Equal(t, calls, shared.calls)
+	assert.Equal(t, int64(1), m.CacheTierHits[TierLocal])
+}
+
+func TestTieredCache_FillsLocalFromShared(t *testing.T) {
+	ctx := context.Background()
+	shared := newFlakyCache()
+	require.NoError(t, shared.MemoryCache.Set(ctx, "key", "value", 0))
+	cache := NewTieredCache(shared, 100, time.Minute)
+	m := metrics.NewMetrics()
+	cache.SetMetrics(m)
+
+	_, err := cache.Get(ctx, "missing")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+
+	for i := 0; i < 2; i++ {
+		value, err := cache.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, "value", value)
+	}
+
+	assert.Equal(t, int64(1), m.CacheTierHits[TierLocal])
+	assert.Equal(t, int64(2), m.CacheTierMisses[TierLocal])
+	assert.Equal(t, int64(1), m.CacheTierHits[TierShared])
+	assert.Equal(t, int64(1), m.CacheTierMisses[TierShared])
+}
+
+func TestTieredCache_LocalCopiesExpire(t *testing.T) {
+	ctx := context.Background()
+	shared := newFlakyCache()
+	cache := NewTieredCache(shared, 100, time.Minute)
+	now := time.Now()
+	cache.local.now = func() time.Time { return now }
+
+	require.NoError(t, cache.Set(ctx, "key", "old", 0))
+	require.NoError(t, shared.MemoryCache.Set(ctx, "key", "new", 0))
+
+	value, err := cache.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "old", value)
+
+	now = now.Add(time.Minute)
+	value, err = cache.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "new", value)
+}
+
+func TestTieredCache_DeleteRemovesBothTiers(t *testing.T) {
+	ctx := context.Background()
+	shared := newFlakyCache()
+	cache := NewTieredCache(shared, 100, time.Minute)
+
+	require.NoError(t, cache.Set(ctx, "key", "value", 0))
+	require.NoError(t, cache.Delete(ctx, "key"))
+
+	exists, err := cache.Exists(ctx, "key")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
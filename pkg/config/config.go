@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -14,24 +15,112 @@ type Config struct {
 	Database    DatabaseConfig
 	Redis       RedisConfig
 	Slack       SlackConfig
+	Teams       TeamsConfig
+	Discord     DiscordConfig
+	AI          AIConfig
 	Gemini      GeminiConfig
+	OpenAI      OpenAIConfig
 	Application ApplicationConfig
+	Queue       QueueConfig
 	Security    SecurityConfig
+	Admin       AdminConfig
+	Tracing     TracingConfig
+	Secrets     SecretsConfig
+	Storage     StorageConfig
+	Compliance  ComplianceConfig
+	// Platforms lists the chat platforms this process runs. A listed
+	// platform is skipped when it is not configured, except Slack.
+	Platforms []string
+	// RunMode is RunModeAll to receive and process events, or RunModeWorker
+	// to only process the events of the durable queue, without HTTP listener
+	RunMode string
+	// Report describes the environment the configuration was loaded from
+	Report Report
 }
 
-// ServerConfig holds HTTP server configuration
+// Chat platforms that can be listed in PLATFORMS or the -platforms flag
+const (
+	PlatformSlack   = "slack"
+	PlatformTeams   = "teams"
+	PlatformDiscord = "discord"
+)
+
+// How the Slack bot receives events, set in SLACK_MODE or the -slack-mode flag
+const (
+	SlackModeHTTP   = "http"
+	SlackModeSocket = "socket"
+)
+
+// How replies show restricted files attached to a message, set in SLACK_FILE_PRIVACY
+const (
+	FilePrivacyRedact = "redact"
+	FilePrivacyOmit   = "omit"
+	FilePrivacyLink   = "link"
+)
+
+// What a process does, set in RUN_MODE or the -run-mode flag
+const (
+	RunModeAll    = "all"
+	RunModeWorker = "worker"
+)
+
+// Where Slack events wait to be processed, set in QUEUE_BACKEND
+const (
+	QueueBackendMemory = "memory"
+	QueueBackendRedis  = "redis"
+)
+
+// Which database stores translations and settings, set in DATABASE_DRIVER
+const (
+	DatabaseDriverMySQL  = "mysql"
+	DatabaseDriverSQLite = "sqlite"
+)
+
+// defaultOAuthScopes are the bot scopes requested when the app is installed in a workspace
+var defaultOAuthScopes = []string{
+	"channels:history", "channels:read", "chat:write", "chat:write.customize", "commands", "files:read",
+	"groups:history", "groups:read", "im:write", "reactions:read", "reactions:write", "usergroups:read", "users:read",
+}
+
+// defaultPlatforms keeps the Slack bot running and starts Teams and Discord once configured
+var defaultPlatforms = []string{PlatformSlack, PlatformTeams, PlatformDiscord}
+
+// ServerConfig holds HTTP server configuration. The server speaks HTTP/1.1;
+// with HTTP2Enabled it also accepts unencrypted HTTP/2 (h2c), for load
+// balancers that forward HTTP/2 to the backend.
 type ServerConfig struct {
-	Port    string
-	Address string
+	Port                      string
+	Address                   string
+	ReadTimeout               time.Duration
+	WriteTimeout              time.Duration
+	IdleTimeout               time.Duration
+	MaxHeaderBytes            int
+	HTTP2Enabled              bool
+	HTTP2MaxConcurrentStreams int
 }
 
 // DatabaseConfig holds MySQL database configuration
 type DatabaseConfig struct {
+	// Driver is mysql, or sqlite to keep everything in the file SQLitePath
+	// when developing locally without MySQL
+	Driver     string
+	SQLitePath string
+
 	Host     string
 	Port     int
 	User     string
 	Password string
 	Database string
+	// ReplicaHost is a read-only replica, e.g. in another region, that serves
+	// translation lookups while the primary is not writable; empty disables it.
+	// It shares the primary's credentials and database name.
+	ReplicaHost string
+	ReplicaPort int
+	// FailoverCheckInterval is how often the primary is checked for writes
+	FailoverCheckInterval time.Duration
+	// Timeout bounds each query, so a slow database cannot hold a message
+	// past its processing deadline
+	Timeout time.Duration
 }
 
 // RedisConfig holds Redis configuration
@@ -39,6 +128,29 @@ type RedisConfig struct {
 	Host     string
 	Port     int
 	Password string
+	// SecondaryHost is a Redis, e.g. in another region, that serves the cache
+	// while the primary fails; empty fails over to memory directly
+	SecondaryHost     string
+	SecondaryPort     int
+	SecondaryPassword string
+	// FailoverRetry is how long a failed Redis is skipped before it is tried again
+	FailoverRetry time.Duration
+	// MemoryMaxEntries caps the in-memory cache used when no Redis is reachable
+	MemoryMaxEntries int
+	// LocalMaxEntries caps the local cache of translations kept in front of
+	// Redis for LocalTTL, 0 to disable it
+	LocalMaxEntries int
+	LocalTTL        time.Duration
+	// KeyPrefix namespaces cache keys, e.g. by environment, so deployments
+	// sharing a Redis do not collide
+	KeyPrefix string
+	// MaxTTL caps the TTL of cached keys, 0 for no cap
+	MaxTTL time.Duration
+	// MemoryCheckInterval is how often Redis memory use and evictions are
+	// sampled, 0 to disable
+	MemoryCheckInterval time.Duration
+	// Timeout bounds each Redis call
+	Timeout time.Duration
 }
 
 // SlackConfig holds Slack API configuration
@@ -46,25 +158,215 @@ type SlackConfig struct {
 	BotToken      string
 	SigningSecret string
 	WebhookPath   string
+	// Mode is SlackModeHTTP to receive events on the webhook, or
+	// SlackModeSocket to receive them over Socket Mode with AppToken
+	Mode     string
+	AppToken string
+	// OpsChannelID receives periodic security summaries; empty disables them
+	OpsChannelID           string
+	SecurityReportInterval time.Duration
+	// ClientID and ClientSecret enable installing the app in other
+	// workspaces through OAuth at /slack/install
+	ClientID         string
+	ClientSecret     string
+	OAuthRedirectURL string
+	OAuthScopes      []string
+	// FilePrivacy is FilePrivacyRedact to show restricted or external files
+	// without links, FilePrivacyOmit to leave them out of replies, or
+	// FilePrivacyLink to link them like any other file
+	FilePrivacy string
+	// ThumbnailSize is the longest side, in pixels, of image thumbnails
+	// uploaded next to translations; 0 links images instead
+	ThumbnailSize int
+	// OutboxMaxPending is how many translations may wait to be posted while
+	// the workers translate the next messages; 0 posts them from the workers
+	OutboxMaxPending int
+	// OutboxRetryFor is how long translations that fail to post because
+	// Slack is unavailable are retried before they are given up; 0 gives
+	// them up at once
+	OutboxRetryFor time.Duration
+	// OutboxRetryMaxBuffered is how many translations may wait for Slack to
+	// recover before further ones are given up
+	OutboxRetryMaxBuffered int
+	// ReplyButtons attaches "Show original" and "Try another language" to
+	// translations posted in threads
+	ReplyButtons bool
+	// DirectMessageAssistant answers direct messages to the bot as an
+	// assistant, remembering each conversation for DirectMessageContextTTL
+	DirectMessageAssistant  bool
+	DirectMessageContextTTL time.Duration
+	// OnboardingWizard sends the user who installs the app in a new
+	// workspace a setup wizard in a direct message
+	OnboardingWizard bool
+	// ChannelOnboarding configures channels the bot is invited to, translating
+	// to ChannelDefaultLanguage until someone picks another in the channel
+	ChannelOnboarding      bool
+	ChannelDefaultLanguage string
+}
+
+// MultiWorkspace reports whether the app can be installed in several workspaces through OAuth
+func (s SlackConfig) MultiWorkspace() bool {
+	return s.ClientID != ""
+}
+
+// TeamsConfig holds Microsoft Teams (Bot Framework) configuration.
+// Teams is disabled when AppID is empty.
+type TeamsConfig struct {
+	AppID       string
+	AppPassword string
+}
+
+// Enabled reports whether the Teams adapter is configured
+func (t TeamsConfig) Enabled() bool {
+	return t.AppID != ""
+}
+
+// DiscordConfig holds Discord bot configuration.
+// Discord is disabled when BotToken is empty.
+type DiscordConfig struct {
+	BotToken string
+}
+
+// Enabled reports whether the Discord adapter is configured
+func (d DiscordConfig) Enabled() bool {
+	return d.BotToken != ""
+}
+
+// AIConfig selects the AI providers used for translations
+type AIConfig struct {
+	// Provider is a provider name registered in pkg/ai, e.g. "gemini" or "openai"
+	Provider string
+	// Fallbacks are tried in order when Provider is over quota or failing
+	Fallbacks []string
+	// BreakerFailures consecutive failures stop calls to a provider for BreakerCooldown
+	BreakerFailures int
+	BreakerCooldown time.Duration
+	// HedgePercentile of recent translation latencies after which a second,
+	// hedged request is sent; zero disables hedging
+	HedgePercentile float64
+	// HedgeMinDelay is the shortest wait before hedging
+	HedgeMinDelay time.Duration
+	// BatchWindow groups the translations sent to the AI within it for the
+	// same language pair into one call of at most BatchMaxSize texts; zero
+	// disables batching
+	BatchWindow  time.Duration
+	BatchMaxSize int
+	// Temperature, TopP and MaxOutputTokens are the generation parameters of
+	// translations, unless a workspace or channel overrides them;
+	// MaxOutputTokens 0 leaves the provider's limit
+	Temperature     float64
+	TopP            float64
+	MaxOutputTokens int
+	// PromptDir holds text/template files replacing the built-in
+	// translation, detection, summary and explanation prompts; empty keeps them
+	PromptDir string
+	// DailyTokens caps the AI tokens used each day (UTC) by every instance;
+	// once used up, only cached and stored translations are served. Zero
+	// leaves them uncapped.
+	DailyTokens int64
+}
+
+// Chain returns the primary provider followed by its fallbacks, without duplicates
+func (a AIConfig) Chain() []string {
+	chain := []string{a.Provider}
+	for _, name := range a.Fallbacks {
+		if !slices.Contains(chain, name) {
+			chain = append(chain, name)
+		}
+	}
+	return chain
 }
 
 // GeminiConfig holds Google Gemini AI configuration
 type GeminiConfig struct {
+	APIKey              string
+	Model               string
+	CanaryEnabled       bool
+	CanaryModel         string
+	CanaryPromptVersion string
+	// OCREnabled translates the text of images shared without a message,
+	// read by the vision model OCRModel
+	OCREnabled bool
+	OCRModel   string
+	// ChannelModels are the models a workspace or channel may select in
+	// place of Model through the "model" of its generation settings
+	ChannelModels []string
+}
+
+// OpenAIConfig holds OpenAI configuration, used when AI_PROVIDER is openai
+type OpenAIConfig struct {
 	APIKey string
 	Model  string
+	// BaseURL points at the OpenAI API or a compatible endpoint
+	BaseURL string
+	// ChannelModels are the models a workspace or channel may select in
+	// place of Model through the "model" of its generation settings
+	ChannelModels []string
 }
 
 // ApplicationConfig holds general application configuration
 type ApplicationConfig struct {
-	LogLevel                  string
-	Environment               string
-	CacheTTLTranslation       time.Duration
-	CacheTTLChannelConfig     time.Duration
-	RateLimitPerUser          int
-	RateLimitPerChannel       int
-	MaxMessageLength          int
-	QueueBufferSize           int
-	QueueIdleTimeout          time.Duration
+	LogLevel              string
+	Environment           string
+	CacheTTLTranslation   time.Duration
+	CacheTTLChannelConfig time.Duration
+	RateLimitPerUser      int
+	RateLimitPerChannel   int
+	MaxMessageLength      int
+	QueueBufferSize       int
+	QueueIdleTimeout      time.Duration
+	// ExplainLimitPerUser and ExplainLimitPerChannel cap the "Explain this
+	// message" shortcuts answered each minute, apart from translations
+	ExplainLimitPerUser    int
+	ExplainLimitPerChannel int
+	// QueueMaxWorkers caps the channel queues each worker pool processes at
+	// once; 0 starts a worker for every channel
+	QueueMaxWorkers        int
+	FilterRuleCacheTTL     time.Duration
+	MessageReorderWindow   time.Duration
+	QueueWatchdogMaxAge    time.Duration
+	QueueBacklogThreshold  int
+	EventProcessingTimeout time.Duration
+	// Queued payloads over QueuePayloadCompressBytes are trimmed and compressed;
+	// events still over QueueMaxPayloadBytes are dropped
+	QueuePayloadCompressBytes int
+	QueueMaxPayloadBytes      int
+	// LatencySLOThreshold and LatencySLOTarget define the reply latency objective,
+	// e.g. 95% of replies posted within 5s of Slack delivering the message
+	LatencySLOThreshold time.Duration
+	LatencySLOTarget    float64
+	// MaintenanceNotice is posted once per channel while maintenance mode is on
+	MaintenanceNotice string
+	// LanguagePairs maps detected language codes to the codes messages are
+	// translated to in channels without a channel configuration
+	LanguagePairs map[string]string
+	// RTLFormatting lays translations into right-to-left languages out with
+	// Unicode directional marks
+	RTLFormatting bool
+	// TokenPricePrompt and TokenPriceOutput are the AI prices in USD per
+	// million tokens, used to estimate each channel's cost; 0 leaves it out
+	TokenPricePrompt float64
+	TokenPriceOutput float64
+	// Event IDs are remembered for EventDedupTTL to drop Slack's retries;
+	// in memory, at most EventDedupMaxEntries of them are kept
+	EventDedupTTL        time.Duration
+	EventDedupMaxEntries int
+}
+
+// QueueConfig selects where Slack events wait to be processed: in memory, or
+// in a Redis stream that survives restarts and is shared by every instance
+// through a consumer group
+type QueueConfig struct {
+	Backend       string
+	Stream        string
+	ConsumerGroup string
+	// ConsumerName must stay the same across restarts of an instance, so it
+	// picks up the events it read but did not finish
+	ConsumerName string
+	MaxLen       int64
+	// ClaimIdle is how long an event may stay unacknowledged before another
+	// consumer takes it over
+	ClaimIdle time.Duration
 }
 
 // SecurityConfig holds security configuration
@@ -74,46 +376,223 @@ type SecurityConfig struct {
 	BlockHighThreat       bool `env:"BLOCK_HIGH_THREAT"`
 	LogSuspiciousActivity bool `env:"LOG_SUSPICIOUS_ACTIVITY"`
 	MaxOutputLength       int  `env:"MAX_OUTPUT_LENGTH"`
+	// InjectionShadowMode logs inputs that would be blocked instead of blocking them
+	InjectionShadowMode bool `env:"INJECTION_SHADOW_MODE"`
+	// InjectionAllowlist lists phrases ignored by injection detection
+	InjectionAllowlist []string `env:"INJECTION_ALLOWLIST"`
+}
+
+// AdminConfig holds admin API configuration
+type AdminConfig struct {
+	APIToken      string
+	APIKeys       map[string]string // API key -> role name
+	WebhookURL    string
+	WebhookSecret string
+}
+
+// TracingConfig holds OpenTelemetry tracing configuration. Spans are exported
+// over OTLP/HTTP when Endpoint is set.
+type TracingConfig struct {
+	Endpoint    string
+	ServiceName string
+	// SampleRatio is the fraction of new traces recorded, from 0 to 1
+	SampleRatio float64
+}
+
+// SecretsConfig holds the master keys that encrypt stored credentials such as
+// workspace bot tokens, as key ID to base64-encoded 32-byte key.
+// CurrentKeyID selects the key new credentials are encrypted with; the
+// others only decrypt credentials stored before a rotation.
+type SecretsConfig struct {
+	MasterKeys   map[string]string
+	CurrentKeyID string
+}
+
+// StorageConfig holds the object storage files such as compliance exports are
+// written to. Backend is local, s3 or gcs; Cloud Storage uses the application
+// default credentials. S3Endpoint selects an S3-compatible service instead of AWS.
+type StorageConfig struct {
+	Backend     string
+	Bucket      string
+	Prefix      string
+	LocalDir    string
+	S3Endpoint  string
+	S3Region    string
+	S3AccessKey string
+	S3SecretKey string
+	// ArtifactRetentionDays keeps downloaded attachments and the text read
+	// from them under artifacts/ for that many days; 0 keeps them in memory only
+	ArtifactRetentionDays int
+}
+
+// ComplianceConfig holds the record of what the bot posts and its exports.
+// SigningKey is the base64-encoded 32-byte Ed25519 seed export manifests are
+// signed with; exports are disabled without it.
+type ComplianceConfig struct {
+	RecordPosts   bool
+	SigningKey    string
+	MaxExportDays int
 }
 
 // Load reads configuration from environment variables with default values
 func Load() (*Config, error) {
+	return LoadPlatforms(nil)
+}
+
+// LoadPlatforms is Load with the PLATFORMS list replaced by platforms, as set
+// by the -platforms flag. An empty list keeps PLATFORMS.
+func LoadPlatforms(platforms []string) (*Config, error) {
+	loading.reset()
+	if len(platforms) == 0 {
+		platforms = getEnvList("PLATFORMS")
+	}
+	if len(platforms) == 0 {
+		platforms = defaultPlatforms
+	}
+	oauthScopes := getEnvList("SLACK_OAUTH_SCOPES")
+	if len(oauthScopes) == 0 {
+		oauthScopes = defaultOAuthScopes
+	}
+
 	config := &Config{
 		Server: ServerConfig{
-			Port:    getEnv("SERVER_PORT", "8080"),
-			Address: getEnv("SERVER_ADDRESS", "0.0.0.0"),
+			Port:                      getEnv("SERVER_PORT", "8080"),
+			Address:                   getEnv("SERVER_ADDRESS", "0.0.0.0"),
+			ReadTimeout:               time.Duration(getEnvInt("SERVER_READ_TIMEOUT_SECONDS", 15)) * time.Second,
+			WriteTimeout:              time.Duration(getEnvInt("SERVER_WRITE_TIMEOUT_SECONDS", 15)) * time.Second,
+			IdleTimeout:               time.Duration(getEnvInt("SERVER_IDLE_TIMEOUT_SECONDS", 60)) * time.Second,
+			MaxHeaderBytes:            getEnvInt("SERVER_MAX_HEADER_BYTES", 1<<20),
+			HTTP2Enabled:              getEnvBool("SERVER_HTTP2_ENABLED", false),
+			HTTP2MaxConcurrentStreams: getEnvInt("SERVER_HTTP2_MAX_CONCURRENT_STREAMS", 250),
 		},
 		Database: DatabaseConfig{
-			Host:     getEnv("MYSQL_HOST", "localhost"),
-			Port:     getEnvInt("MYSQL_PORT", 3306),
-			User:     getEnv("MYSQL_USER", "root"),
-			Password: getEnv("MYSQL_PASSWORD", ""),
-			Database: getEnv("MYSQL_DATABASE", "translation_bot"),
+			Driver:                getEnv("DATABASE_DRIVER", DatabaseDriverMySQL),
+			SQLitePath:            getEnv("SQLITE_PATH", "assistant.db"),
+			Host:                  getEnv("MYSQL_HOST", "localhost"),
+			Port:                  getEnvInt("MYSQL_PORT", 3306),
+			User:                  getEnv("MYSQL_USER", "root"),
+			Password:              getEnv("MYSQL_PASSWORD", ""),
+			Database:              getEnv("MYSQL_DATABASE", "translation_bot"),
+			ReplicaHost:           getEnv("MYSQL_REPLICA_HOST", ""),
+			ReplicaPort:           getEnvInt("MYSQL_REPLICA_PORT", getEnvInt("MYSQL_PORT", 3306)),
+			FailoverCheckInterval: time.Duration(getEnvInt("MYSQL_FAILOVER_CHECK_SECONDS", 10)) * time.Second,
+			Timeout:               time.Duration(getEnvInt("DATABASE_TIMEOUT_MS", 5000)) * time.Millisecond,
 		},
 		Redis: RedisConfig{
-			Host:     getEnv("REDIS_HOST", "localhost"),
-			Port:     getEnvInt("REDIS_PORT", 6379),
-			Password: getEnv("REDIS_PASSWORD", ""),
+			Host:                getEnv("REDIS_HOST", "localhost"),
+			Port:                getEnvInt("REDIS_PORT", 6379),
+			Password:            getEnv("REDIS_PASSWORD", ""),
+			SecondaryHost:       getEnv("REDIS_SECONDARY_HOST", ""),
+			SecondaryPort:       getEnvInt("REDIS_SECONDARY_PORT", getEnvInt("REDIS_PORT", 6379)),
+			SecondaryPassword:   getEnv("REDIS_SECONDARY_PASSWORD", getEnv("REDIS_PASSWORD", "")),
+			FailoverRetry:       time.Duration(getEnvInt("REDIS_FAILOVER_RETRY_SECONDS", 30)) * time.Second,
+			MemoryMaxEntries:    getEnvInt("CACHE_MEMORY_MAX_ENTRIES", 10000),
+			LocalMaxEntries:     getEnvInt("CACHE_LOCAL_MAX_ENTRIES", 0),
+			LocalTTL:            time.Duration(getEnvInt("CACHE_LOCAL_TTL_SECONDS", 300)) * time.Second,
+			KeyPrefix:           getEnv("CACHE_KEY_PREFIX", ""),
+			MaxTTL:              time.Duration(getEnvInt("CACHE_MAX_TTL_SECONDS", 0)) * time.Second,
+			MemoryCheckInterval: time.Duration(getEnvInt("REDIS_MEMORY_CHECK_SECONDS", 60)) * time.Second,
+			Timeout:             time.Duration(getEnvInt("REDIS_TIMEOUT_MS", 5000)) * time.Millisecond,
 		},
 		Slack: SlackConfig{
-			BotToken:      getEnv("SLACK_BOT_TOKEN", ""),
-			SigningSecret: getEnv("SLACK_SIGNING_SECRET", ""),
-			WebhookPath:   getEnv("SLACK_WEBHOOK_PATH", "/slack/events"),
+			BotToken:                getEnv("SLACK_BOT_TOKEN", ""),
+			SigningSecret:           getEnv("SLACK_SIGNING_SECRET", ""),
+			WebhookPath:             getEnv("SLACK_WEBHOOK_PATH", "/slack/events"),
+			Mode:                    getEnv("SLACK_MODE", SlackModeHTTP),
+			AppToken:                getEnv("SLACK_APP_TOKEN", ""),
+			OpsChannelID:            getEnv("SLACK_OPS_CHANNEL_ID", ""),
+			SecurityReportInterval:  time.Duration(getEnvInt("SECURITY_REPORT_INTERVAL_HOURS", 24)) * time.Hour,
+			ClientID:                getEnv("SLACK_CLIENT_ID", ""),
+			ClientSecret:            getEnv("SLACK_CLIENT_SECRET", ""),
+			OAuthRedirectURL:        getEnv("SLACK_OAUTH_REDIRECT_URL", ""),
+			OAuthScopes:             oauthScopes,
+			FilePrivacy:             getEnv("SLACK_FILE_PRIVACY", FilePrivacyRedact),
+			ThumbnailSize:           getEnvInt("SLACK_THUMBNAIL_SIZE", 0),
+			OutboxMaxPending:        getEnvInt("SLACK_OUTBOX_MAX_PENDING", 500),
+			OutboxRetryFor:          time.Duration(getEnvInt("SLACK_OUTBOX_RETRY_MINUTES", 360)) * time.Minute,
+			OutboxRetryMaxBuffered:  getEnvInt("SLACK_OUTBOX_RETRY_MAX_BUFFERED", 1000),
+			ReplyButtons:            getEnvBool("SLACK_REPLY_BUTTONS", true),
+			DirectMessageAssistant:  getEnvBool("SLACK_DM_ASSISTANT", true),
+			DirectMessageContextTTL: time.Duration(getEnvInt("SLACK_DM_CONTEXT_TTL_SECONDS", 1800)) * time.Second,
+			OnboardingWizard:        getEnvBool("SLACK_ONBOARDING_WIZARD", true),
+			ChannelOnboarding:       getEnvBool("SLACK_CHANNEL_ONBOARDING", true),
+			ChannelDefaultLanguage:  getEnv("SLACK_CHANNEL_DEFAULT_LANGUAGE", "en"),
+		},
+		Teams: TeamsConfig{
+			AppID:       getEnv("TEAMS_APP_ID", ""),
+			AppPassword: getEnv("TEAMS_APP_PASSWORD", ""),
+		},
+		Discord: DiscordConfig{
+			BotToken: getEnv("DISCORD_BOT_TOKEN", ""),
+		},
+		AI: AIConfig{
+			Provider:        getEnv("AI_PROVIDER", "gemini"),
+			Fallbacks:       getEnvList("AI_FALLBACK_PROVIDERS"),
+			BreakerFailures: getEnvInt("AI_BREAKER_FAILURES", 3),
+			BreakerCooldown: time.Duration(getEnvInt("AI_BREAKER_COOLDOWN_SECONDS", 30)) * time.Second,
+			HedgePercentile: getEnvFloat("AI_HEDGE_PERCENTILE", 0),
+			HedgeMinDelay:   time.Duration(getEnvInt("AI_HEDGE_MIN_DELAY_MS", 1000)) * time.Millisecond,
+			BatchWindow:     time.Duration(getEnvInt("AI_BATCH_WINDOW_MS", 0)) * time.Millisecond,
+			BatchMaxSize:    getEnvInt("AI_BATCH_MAX_SIZE", 10),
+			Temperature:     getEnvFloat("AI_TEMPERATURE", 0.1),
+			TopP:            getEnvFloat("AI_TOP_P", 0.9),
+			MaxOutputTokens: getEnvInt("AI_MAX_OUTPUT_TOKENS", 0),
+			PromptDir:       getEnv("AI_PROMPT_TEMPLATE_DIR", ""),
+			DailyTokens:     int64(getEnvInt("AI_DAILY_TOKEN_BUDGET", 0)),
 		},
 		Gemini: GeminiConfig{
-			APIKey: getEnv("GEMINI_API_KEY", ""),
-			Model:  getEnv("GEMINI_MODEL", "gemini-1.5-flash"),
+			APIKey:              getEnv("GEMINI_API_KEY", ""),
+			Model:               getEnv("GEMINI_MODEL", "gemini-1.5-flash"),
+			CanaryEnabled:       getEnvBool("GEMINI_CANARY_ENABLED", false),
+			CanaryModel:         getEnv("GEMINI_CANARY_MODEL", getEnv("GEMINI_MODEL", "gemini-1.5-flash")),
+			CanaryPromptVersion: getEnv("GEMINI_CANARY_PROMPT_VERSION", "v2"),
+			OCREnabled:          getEnvBool("GEMINI_OCR_ENABLED", false),
+			OCRModel:            getEnv("GEMINI_OCR_MODEL", getEnv("GEMINI_MODEL", "gemini-1.5-flash")),
+			ChannelModels:       getEnvList("GEMINI_CHANNEL_MODELS"),
+		},
+		OpenAI: OpenAIConfig{
+			APIKey:        getEnv("OPENAI_API_KEY", ""),
+			Model:         getEnv("OPENAI_MODEL", "gpt-4o-mini"),
+			BaseURL:       getEnv("OPENAI_BASE_URL", "https://api.openai.com/v1"),
+			ChannelModels: getEnvList("OPENAI_CHANNEL_MODELS"),
 		},
 		Application: ApplicationConfig{
 			LogLevel:                  getEnv("LOG_LEVEL", "info"),
 			Environment:               getEnv("ENVIRONMENT", "development"),
-			CacheTTLTranslation:       time.Duration(getEnvInt("CACHE_TTL_TRANSLATION", 86400)) * time.Second,
-			CacheTTLChannelConfig:     time.Duration(getEnvInt("CACHE_TTL_CHANNEL_CONFIG", 3600)) * time.Second,
+			CacheTTLTranslation:       time.Duration(getEnvInt("CACHE_TTL_TRANSLATION_SECONDS", 86400)) * time.Second,
+			CacheTTLChannelConfig:     time.Duration(getEnvInt("CACHE_TTL_CHANNEL_CONFIG_SECONDS", 3600)) * time.Second,
 			RateLimitPerUser:          getEnvInt("RATE_LIMIT_PER_USER", 10),
 			RateLimitPerChannel:       getEnvInt("RATE_LIMIT_PER_CHANNEL", 30),
+			ExplainLimitPerUser:       getEnvInt("EXPLAIN_RATE_LIMIT_PER_USER", 5),
+			ExplainLimitPerChannel:    getEnvInt("EXPLAIN_RATE_LIMIT_PER_CHANNEL", 20),
 			MaxMessageLength:          getEnvInt("MAX_MESSAGE_LENGTH", 10240),
 			QueueBufferSize:           getEnvInt("QUEUE_BUFFER_SIZE", 100),
-			QueueIdleTimeout:          time.Duration(getEnvInt("QUEUE_IDLE_TIMEOUT", 300)) * time.Second,
+			QueueIdleTimeout:          time.Duration(getEnvInt("QUEUE_IDLE_TIMEOUT_SECONDS", 300)) * time.Second,
+			QueueMaxWorkers:           getEnvInt("QUEUE_MAX_WORKERS", 256),
+			FilterRuleCacheTTL:        time.Duration(getEnvInt("FILTER_RULE_CACHE_TTL_SECONDS", 30)) * time.Second,
+			MessageReorderWindow:      time.Duration(getEnvInt("MESSAGE_REORDER_WINDOW_MS", 0)) * time.Millisecond,
+			QueueWatchdogMaxAge:       time.Duration(getEnvInt("QUEUE_WATCHDOG_MAX_AGE_SECONDS", 120)) * time.Second,
+			QueueBacklogThreshold:     getEnvInt("QUEUE_BACKLOG_THRESHOLD", 20),
+			EventProcessingTimeout:    time.Duration(getEnvInt("EVENT_PROCESSING_TIMEOUT_SECONDS", 60)) * time.Second,
+			QueuePayloadCompressBytes: getEnvInt("QUEUE_PAYLOAD_COMPRESS_BYTES", 8192),
+			QueueMaxPayloadBytes:      getEnvInt("QUEUE_MAX_PAYLOAD_BYTES", 262144),
+			LatencySLOThreshold:       time.Duration(getEnvInt("LATENCY_SLO_MS", 5000)) * time.Millisecond,
+			LatencySLOTarget:          float64(getEnvInt("LATENCY_SLO_TARGET_PERCENT", 95)) / 100,
+			MaintenanceNotice:         getEnv("MAINTENANCE_NOTICE", "🛠️ Translation is paused for maintenance. Messages sent now will be translated once it is over."),
+			LanguagePairs:             getEnvMap("LANGUAGE_PAIRS"),
+			RTLFormatting:             getEnvBool("RTL_FORMATTING", true),
+			TokenPricePrompt:          getEnvFloat("TOKEN_PRICE_PROMPT_PER_MILLION", 0),
+			TokenPriceOutput:          getEnvFloat("TOKEN_PRICE_OUTPUT_PER_MILLION", 0),
+			EventDedupTTL:             time.Duration(getEnvInt("EVENT_DEDUP_TTL_SECONDS", 600)) * time.Second,
+			EventDedupMaxEntries:      getEnvInt("EVENT_DEDUP_MAX_ENTRIES", 100000),
+		},
+		Queue: QueueConfig{
+			Backend:       getEnv("QUEUE_BACKEND", QueueBackendMemory),
+			Stream:        getEnv("QUEUE_REDIS_STREAM", "slack_events"),
+			ConsumerGroup: getEnv("QUEUE_REDIS_GROUP", "translators"),
+			ConsumerName:  getEnv("QUEUE_REDIS_CONSUMER", defaultConsumerName()),
+			MaxLen:        int64(getEnvInt("QUEUE_REDIS_MAX_LEN", 100000)),
+			ClaimIdle:     time.Duration(getEnvInt("QUEUE_REDIS_CLAIM_IDLE_SECONDS", 600)) * time.Second,
 		},
 		Security: SecurityConfig{
 			MaxInputLength:        getEnvInt("MAX_INPUT_LENGTH", 5000),
@@ -121,8 +600,44 @@ func Load() (*Config, error) {
 			BlockHighThreat:       getEnvBool("BLOCK_HIGH_THREAT", true),
 			LogSuspiciousActivity: getEnvBool("LOG_SUSPICIOUS_ACTIVITY", true),
 			MaxOutputLength:       getEnvInt("MAX_OUTPUT_LENGTH", 10000),
+			InjectionShadowMode:   getEnvBool("INJECTION_SHADOW_MODE", false),
+			InjectionAllowlist:    getEnvList("INJECTION_ALLOWLIST"),
+		},
+		Admin: AdminConfig{
+			APIToken:      getEnv("ADMIN_API_TOKEN", ""),
+			APIKeys:       getEnvMap("ADMIN_API_KEYS"),
+			WebhookURL:    getEnv("ADMIN_WEBHOOK_URL", ""),
+			WebhookSecret: getEnv("ADMIN_WEBHOOK_SECRET", ""),
+		},
+		Tracing: TracingConfig{
+			Endpoint:    getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+			ServiceName: getEnv("OTEL_SERVICE_NAME", "slack-translation-bot"),
+			SampleRatio: float64(getEnvInt("TRACING_SAMPLE_PERCENT", 100)) / 100,
 		},
+		Secrets: SecretsConfig{
+			MasterKeys:   getEnvMap("SECRETS_MASTER_KEYS"),
+			CurrentKeyID: getEnv("SECRETS_CURRENT_KEY_ID", ""),
+		},
+		Storage: StorageConfig{
+			Backend:               getEnv("STORAGE_BACKEND", ""),
+			Bucket:                getEnv("STORAGE_BUCKET", ""),
+			Prefix:                getEnv("STORAGE_PREFIX", ""),
+			LocalDir:              getEnv("STORAGE_LOCAL_DIR", "./data/storage"),
+			S3Endpoint:            getEnv("STORAGE_S3_ENDPOINT", ""),
+			S3Region:              getEnv("STORAGE_S3_REGION", ""),
+			S3AccessKey:           getEnv("STORAGE_S3_ACCESS_KEY", ""),
+			S3SecretKey:           getEnv("STORAGE_S3_SECRET_KEY", ""),
+			ArtifactRetentionDays: getEnvInt("STORAGE_ARTIFACT_RETENTION_DAYS", 0),
+		},
+		Compliance: ComplianceConfig{
+			RecordPosts:   getEnvBool("COMPLIANCE_RECORD_POSTS", false),
+			SigningKey:    getEnv("COMPLIANCE_SIGNING_KEY", ""),
+			MaxExportDays: getEnvInt("COMPLIANCE_MAX_EXPORT_DAYS", 31),
+		},
+		Platforms: platforms,
+		RunMode:   getEnv("RUN_MODE", RunModeAll),
 	}
+	config.Report = loading.build(os.Environ())
 
 	// Validate required configuration
 	if err := config.Validate(); err != nil {
@@ -134,48 +649,373 @@ func Load() (*Config, error) {
 
 // Validate checks if required configuration values are set
 func (c *Config) Validate() error {
-	if c.Slack.SigningSecret == "" {
-		return fmt.Errorf("SLACK_SIGNING_SECRET is required")
+	if len(c.Platforms) == 0 {
+		return fmt.Errorf("at least one platform is required")
+	}
+	for _, platform := range c.Platforms {
+		switch platform {
+		case PlatformSlack, PlatformTeams, PlatformDiscord:
+		default:
+			return fmt.Errorf("unknown platform %q, expected %s, %s or %s", platform, PlatformSlack, PlatformTeams, PlatformDiscord)
+		}
+	}
+
+	switch c.RunMode {
+	case RunModeAll:
+	case RunModeWorker:
+		if c.Queue.Backend != QueueBackendRedis {
+			return fmt.Errorf("RUN_MODE=%s requires QUEUE_BACKEND=%s", RunModeWorker, QueueBackendRedis)
+		}
+	default:
+		return fmt.Errorf("unknown RUN_MODE %q, expected %s or %s", c.RunMode, RunModeAll, RunModeWorker)
 	}
 
-	if c.Database.Host == "" {
-		return fmt.Errorf("MYSQL_HOST is required")
+	// Workers do not receive Slack events, so need neither signing secret nor app token
+	if c.listsPlatform(PlatformSlack) && c.RunMode != RunModeWorker {
+		switch c.Slack.Mode {
+		case SlackModeHTTP:
+			if c.Slack.SigningSecret == "" {
+				return fmt.Errorf("SLACK_SIGNING_SECRET is required")
+			}
+		case SlackModeSocket:
+			if c.Slack.AppToken == "" {
+				return fmt.Errorf("SLACK_APP_TOKEN is required when SLACK_MODE is socket")
+			}
+		default:
+			return fmt.Errorf("unknown SLACK_MODE %q, expected %s or %s", c.Slack.Mode, SlackModeHTTP, SlackModeSocket)
+		}
+	}
+	if c.listsPlatform(PlatformSlack) {
+		switch c.Slack.FilePrivacy {
+		case FilePrivacyRedact, FilePrivacyOmit, FilePrivacyLink:
+		default:
+			return fmt.Errorf("unknown SLACK_FILE_PRIVACY %q, expected %s, %s or %s", c.Slack.FilePrivacy, FilePrivacyRedact, FilePrivacyOmit, FilePrivacyLink)
+		}
+	}
+
+	if err := c.Server.validate(); err != nil {
+		return err
+	}
+
+	if err := c.Queue.validate(); err != nil {
+		return err
+	}
+
+	switch c.Database.Driver {
+	case DatabaseDriverMySQL:
+		if c.Database.Host == "" {
+			return fmt.Errorf("MYSQL_HOST is required")
+		}
+	case DatabaseDriverSQLite:
+		if c.Application.Environment != "development" {
+			return fmt.Errorf("DATABASE_DRIVER=%s is only supported when ENVIRONMENT=development", DatabaseDriverSQLite)
+		}
+		if c.Database.SQLitePath == "" {
+			return fmt.Errorf("SQLITE_PATH is required")
+		}
+	default:
+		return fmt.Errorf("unknown DATABASE_DRIVER %q, expected %s or %s", c.Database.Driver, DatabaseDriverMySQL, DatabaseDriverSQLite)
+	}
+
+	if c.Database.FailoverCheckInterval <= 0 {
+		return fmt.Errorf("MYSQL_FAILOVER_CHECK_SECONDS must be positive")
+	}
+
+	if c.Database.Timeout <= 0 {
+		return fmt.Errorf("DATABASE_TIMEOUT_MS must be positive")
 	}
 
 	if c.Redis.Host == "" {
 		return fmt.Errorf("REDIS_HOST is required")
 	}
 
+	if c.Redis.Timeout <= 0 {
+		return fmt.Errorf("REDIS_TIMEOUT_MS must be positive")
+	}
+
+	if c.Redis.MemoryMaxEntries <= 0 {
+		return fmt.Errorf("CACHE_MEMORY_MAX_ENTRIES must be positive")
+	}
+
+	if c.Redis.LocalMaxEntries < 0 {
+		return fmt.Errorf("CACHE_LOCAL_MAX_ENTRIES must not be negative")
+	}
+
+	if c.Redis.LocalMaxEntries > 0 && c.Redis.LocalTTL <= 0 {
+		return fmt.Errorf("CACHE_LOCAL_TTL_SECONDS must be positive")
+	}
+
+	if slices.Contains(c.AI.Chain(), "openai") && c.OpenAI.APIKey == "" {
+		return fmt.Errorf("OPENAI_API_KEY is required when AI_PROVIDER or AI_FALLBACK_PROVIDERS lists openai")
+	}
+
+	if c.AI.HedgePercentile < 0 || c.AI.HedgePercentile >= 100 {
+		return fmt.Errorf("AI_HEDGE_PERCENTILE must be between 0 and 100")
+	}
+
+	if c.AI.BatchWindow < 0 {
+		return fmt.Errorf("AI_BATCH_WINDOW_MS must not be negative")
+	}
+
+	if c.AI.BatchWindow > 0 && c.AI.BatchMaxSize < 2 {
+		return fmt.Errorf("AI_BATCH_MAX_SIZE must be at least 2 when AI_BATCH_WINDOW_MS is set")
+	}
+
+	if c.AI.Temperature < 0 || c.AI.Temperature > 2 {
+		return fmt.Errorf("AI_TEMPERATURE must be between 0 and 2")
+	}
+
+	if c.AI.TopP <= 0 || c.AI.TopP > 1 {
+		return fmt.Errorf("AI_TOP_P must be above 0 and at most 1")
+	}
+
+	if c.AI.MaxOutputTokens < 0 || c.AI.MaxOutputTokens > 65536 {
+		return fmt.Errorf("AI_MAX_OUTPUT_TOKENS must be between 0 and 65536")
+	}
+
+	if c.AI.DailyTokens < 0 {
+		return fmt.Errorf("AI_DAILY_TOKEN_BUDGET must not be negative")
+	}
+
+	if c.Slack.ThumbnailSize < 0 {
+		return fmt.Errorf("SLACK_THUMBNAIL_SIZE must not be negative")
+	}
+
+	if c.Application.QueueMaxWorkers < 0 {
+		return fmt.Errorf("QUEUE_MAX_WORKERS must not be negative")
+	}
+
+	if c.Slack.OutboxMaxPending < 0 {
+		return fmt.Errorf("SLACK_OUTBOX_MAX_PENDING must not be negative")
+	}
+
+	if c.Slack.OutboxRetryFor < 0 {
+		return fmt.Errorf("SLACK_OUTBOX_RETRY_MINUTES must not be negative")
+	}
+
+	if c.Slack.OutboxRetryFor > 0 && c.Slack.OutboxRetryMaxBuffered <= 0 {
+		return fmt.Errorf("SLACK_OUTBOX_RETRY_MAX_BUFFERED must be positive when SLACK_OUTBOX_RETRY_MINUTES is set")
+	}
+
+	if c.Application.TokenPricePrompt < 0 || c.Application.TokenPriceOutput < 0 {
+		return fmt.Errorf("TOKEN_PRICE_PROMPT_PER_MILLION and TOKEN_PRICE_OUTPUT_PER_MILLION must not be negative")
+	}
+
+	if c.Application.EventDedupTTL <= 0 {
+		return fmt.Errorf("EVENT_DEDUP_TTL_SECONDS must be positive")
+	}
+
+	if c.Application.EventDedupMaxEntries <= 0 {
+		return fmt.Errorf("EVENT_DEDUP_MAX_ENTRIES must be positive")
+	}
+
+	if c.Gemini.OCREnabled && c.Gemini.APIKey == "" {
+		return fmt.Errorf("GEMINI_API_KEY is required when GEMINI_OCR_ENABLED is set")
+	}
+
+	if c.Slack.MultiWorkspace() {
+		if c.Slack.ClientSecret == "" || c.Slack.OAuthRedirectURL == "" {
+			return fmt.Errorf("SLACK_CLIENT_SECRET and SLACK_OAUTH_REDIRECT_URL are required when SLACK_CLIENT_ID is set")
+		}
+		if c.Secrets.CurrentKeyID == "" {
+			return fmt.Errorf("SECRETS_MASTER_KEYS and SECRETS_CURRENT_KEY_ID are required to store workspace tokens when SLACK_CLIENT_ID is set")
+		}
+	}
+
+	if c.Teams.Enabled() && c.Teams.AppPassword == "" {
+		return fmt.Errorf("TEAMS_APP_PASSWORD is required when TEAMS_APP_ID is set")
+	}
+
+	if c.Admin.WebhookURL != "" && c.Admin.WebhookSecret == "" {
+		return fmt.Errorf("ADMIN_WEBHOOK_SECRET is required when ADMIN_WEBHOOK_URL is set")
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		return fmt.Errorf("TRACING_SAMPLE_PERCENT must be between 0 and 100")
+	}
+
+	if c.Compliance.RecordPosts && c.Compliance.SigningKey == "" {
+		return fmt.Errorf("COMPLIANCE_SIGNING_KEY is required when COMPLIANCE_RECORD_POSTS is set")
+	}
+
+	if c.Compliance.SigningKey != "" && c.Storage.Backend == "" {
+		return fmt.Errorf("STORAGE_BACKEND is required when COMPLIANCE_SIGNING_KEY is set")
+	}
+
+	if c.Storage.ArtifactRetentionDays < 0 {
+		return fmt.Errorf("STORAGE_ARTIFACT_RETENTION_DAYS must not be negative")
+	}
+
+	if c.Storage.ArtifactRetentionDays > 0 && c.Storage.Backend == "" {
+		return fmt.Errorf("STORAGE_BACKEND is required when STORAGE_ARTIFACT_RETENTION_DAYS is set")
+	}
+
+	if c.Compliance.MaxExportDays <= 0 {
+		return fmt.Errorf("COMPLIANCE_MAX_EXPORT_DAYS must be positive")
+	}
+
+	return nil
+}
+
+func (s ServerConfig) validate() error {
+	if s.ReadTimeout <= 0 {
+		return fmt.Errorf("SERVER_READ_TIMEOUT_SECONDS must be positive")
+	}
+	if s.WriteTimeout <= 0 {
+		return fmt.Errorf("SERVER_WRITE_TIMEOUT_SECONDS must be positive")
+	}
+	if s.IdleTimeout <= 0 {
+		return fmt.Errorf("SERVER_IDLE_TIMEOUT_SECONDS must be positive")
+	}
+	if s.MaxHeaderBytes < 4096 {
+		return fmt.Errorf("SERVER_MAX_HEADER_BYTES must be at least 4096")
+	}
+	if s.HTTP2Enabled && s.HTTP2MaxConcurrentStreams <= 0 {
+		return fmt.Errorf("SERVER_HTTP2_MAX_CONCURRENT_STREAMS must be positive when SERVER_HTTP2_ENABLED is set")
+	}
 	return nil
 }
 
+// PlatformEnabled reports whether this process runs the given chat platform:
+// it must be listed in Platforms and, for Teams and Discord, configured.
+// Workers only run Slack, the only platform whose events are queued in Redis.
+func (c *Config) PlatformEnabled(platform string) bool {
+	if !c.listsPlatform(platform) {
+		return false
+	}
+	switch platform {
+	case PlatformTeams:
+		return c.Teams.Enabled() && c.RunMode != RunModeWorker
+	case PlatformDiscord:
+		return c.Discord.Enabled() && c.RunMode != RunModeWorker
+	}
+	return true
+}
+
+func (c *Config) listsPlatform(platform string) bool {
+	for _, p := range c.Platforms {
+		if p == platform {
+			return true
+		}
+	}
+	return false
+}
+
 // getEnv retrieves an environment variable or returns a default value
+// validate checks the durable queue settings
+func (q QueueConfig) validate() error {
+	switch q.Backend {
+	case QueueBackendMemory:
+		return nil
+	case QueueBackendRedis:
+	default:
+		return fmt.Errorf("unknown QUEUE_BACKEND %q, expected %s or %s", q.Backend, QueueBackendMemory, QueueBackendRedis)
+	}
+	if q.Stream == "" || q.ConsumerGroup == "" || q.ConsumerName == "" {
+		return fmt.Errorf("QUEUE_REDIS_STREAM, QUEUE_REDIS_GROUP and QUEUE_REDIS_CONSUMER are required when QUEUE_BACKEND is redis")
+	}
+	if q.ClaimIdle <= 0 {
+		return fmt.Errorf("QUEUE_REDIS_CLAIM_IDLE_SECONDS must be positive")
+	}
+	return nil
+}
+
+// defaultConsumerName names this instance in the queue consumer group
+func defaultConsumerName() string {
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		return hostname
+	}
+	return "translator"
+}
+
 func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
+	if value := loading.lookup(key); value != "" {
+		loading.record(key, value, defaultValue, SourceEnv)
 		return value
 	}
+	loading.record(key, defaultValue, defaultValue, SourceDefault)
 	return defaultValue
 }
 
 // getEnvInt retrieves an integer environment variable or returns a default value
 func getEnvInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
+	defaultString := strconv.Itoa(defaultValue)
+	if value := loading.lookup(key); value != "" {
 		if intVal, err := strconv.Atoi(value); err == nil {
+			loading.record(key, value, defaultString, SourceEnv)
 			return intVal
 		}
+		loading.record(key, value, defaultString, SourceInvalid)
+		return defaultValue
+	}
+	loading.record(key, defaultString, defaultString, SourceDefault)
+	return defaultValue
+}
+
+// getEnvFloat retrieves a float environment variable or returns a default value
+func getEnvFloat(key string, defaultValue float64) float64 {
+	defaultString := strconv.FormatFloat(defaultValue, 'g', -1, 64)
+	if value := loading.lookup(key); value != "" {
+		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+			loading.record(key, value, defaultString, SourceEnv)
+			return floatVal
+		}
+		loading.record(key, value, defaultString, SourceInvalid)
+		return defaultValue
 	}
+	loading.record(key, defaultString, defaultString, SourceDefault)
 	return defaultValue
 }
 
+// getEnvRaw retrieves an environment variable without a default, e.g. a list
+func getEnvRaw(key string) string {
+	value := loading.lookup(key)
+	source := SourceDefault
+	if value != "" {
+		source = SourceEnv
+	}
+	loading.record(key, value, "", source)
+	return value
+}
+
+// getEnvMap parses a "key:value,key:value" environment variable
+func getEnvMap(key string) map[string]string {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(getEnvRaw(key), ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if ok && k != "" {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// getEnvList parses a comma-separated environment variable, skipping empty items
+func getEnvList(key string) []string {
+	var result []string
+	for _, item := range strings.Split(getEnvRaw(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
 // getEnvBool retrieves a boolean environment variable or returns a default value
 func getEnvBool(key string, defaultValue bool) bool {
-	if value := os.Getenv(key); value != "" {
+	defaultString := strconv.FormatBool(defaultValue)
+	if value := loading.lookup(key); value != "" {
 		switch strings.ToLower(value) {
 		case "true", "1", "yes":
+			loading.record(key, value, defaultString, SourceEnv)
 			return true
 		case "false", "0", "no":
+			loading.record(key, value, defaultString, SourceEnv)
 			return false
 		}
+		loading.record(key, value, defaultString, SourceInvalid)
+		return defaultValue
 	}
+	loading.record(key, defaultString, defaultString, SourceDefault)
 	return defaultValue
 }
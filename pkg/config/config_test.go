@@ -0,0 +1,29 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_WorkerMode(t *testing.T) {
+	t.Setenv("SLACK_BOT_TOKEN", "xoxb-1")
+	t.Setenv("GEMINI_API_KEY", "key")
+	t.Setenv("DISCORD_BOT_TOKEN", "discord")
+	t.Setenv("RUN_MODE", RunModeWorker)
+
+	_, err := Load()
+	require.Error(t, err, "workers need the Redis queue")
+
+	// Workers receive no events, so the signing secret is not required
+	t.Setenv("QUEUE_BACKEND", QueueBackendRedis)
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.PlatformEnabled(PlatformSlack))
+	assert.False(t, cfg.PlatformEnabled(PlatformDiscord), "Discord events are not queued in Redis")
+
+	t.Setenv("RUN_MODE", "ingest")
+	_, err = Load()
+	assert.Error(t, err)
+}
@@ -0,0 +1,236 @@
+package config
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Report describes how the configuration was loaded: the effective value of
+// every setting next to its default, environment variables that look like
+// settings but are not read, e.g. SLACK_SIGINING_SECRET, and deprecated
+// variables that are still set. Secret values are redacted.
+type Report struct {
+	Settings   []Setting       `json:"settings"`
+	Unknown    []UnknownEnv    `json:"unknown"`
+	Deprecated []DeprecatedEnv `json:"deprecated"`
+}
+
+// Setting sources
+const (
+	SourceDefault = "default"
+	SourceEnv     = "env"
+	// SourceInvalid is a variable whose value cannot be parsed, so the default applies
+	SourceInvalid = "invalid"
+)
+
+// Setting is the effective value of an environment variable
+type Setting struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Default string `json:"default"`
+	Source  string `json:"source"`
+}
+
+// UnknownEnv is an environment variable with a settings prefix that is not
+// read, with the closest setting when it looks like a typo of one
+type UnknownEnv struct {
+	Key        string `json:"key"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// DeprecatedEnv is a set environment variable that was renamed. Its value
+// applies only while Replacement is not set.
+type DeprecatedEnv struct {
+	Key         string `json:"key"`
+	Replacement string `json:"replacement"`
+}
+
+// Overridden returns the settings that do not have their default value
+func (r Report) Overridden() []Setting {
+	var overridden []Setting
+	for _, setting := range r.Settings {
+		if setting.Source != SourceDefault {
+			overridden = append(overridden, setting)
+		}
+	}
+	return overridden
+}
+
+// renamedEnv maps each environment variable to the name it replaced
+var renamedEnv = map[string]string{
+	"CACHE_TTL_TRANSLATION_SECONDS":    "CACHE_TTL_TRANSLATION",
+	"CACHE_TTL_CHANNEL_CONFIG_SECONDS": "CACHE_TTL_CHANNEL_CONFIG",
+	"QUEUE_IDLE_TIMEOUT_SECONDS":       "QUEUE_IDLE_TIMEOUT",
+	"FILTER_RULE_CACHE_TTL_SECONDS":    "FILTER_RULE_CACHE_TTL",
+	"QUEUE_WATCHDOG_MAX_AGE_SECONDS":   "QUEUE_WATCHDOG_MAX_AGE",
+	"EVENT_PROCESSING_TIMEOUT_SECONDS": "EVENT_PROCESSING_TIMEOUT",
+}
+
+// settingPrefixes are the prefixes of the environment variables read by the
+// bot; unknown variables are only reported under these, so the variables of
+// the shell and of other programs are not
+var settingPrefixes = []string{
+	"ADMIN_", "AI_", "CACHE_", "COMPLIANCE_", "DATABASE_", "DISCORD_", "GEMINI_", "MYSQL_", "OPENAI_",
+	"QUEUE_", "REDIS_", "SECRETS_", "SERVER_", "SLACK_", "SQLITE_", "STORAGE_", "TEAMS_", "TRACING_",
+}
+
+// secretSuffixes mark the environment variables whose values are redacted
+var secretSuffixes = []string{"_TOKEN", "_SECRET", "_PASSWORD", "_API_KEY", "_KEYS", "_SECRET_KEY", "_SIGNING_KEY"}
+
+const redacted = "[redacted]"
+
+// reportBuilder records the environment variables read while loading
+type reportBuilder struct {
+	mu         sync.Mutex
+	settings   map[string]Setting
+	deprecated map[string]DeprecatedEnv
+}
+
+// loading records the configuration being loaded
+var loading = &reportBuilder{}
+
+func (b *reportBuilder) reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.settings = make(map[string]Setting)
+	b.deprecated = make(map[string]DeprecatedEnv)
+}
+
+// lookup returns the value of an environment variable, falling back to the
+// deprecated name it replaced
+func (b *reportBuilder) lookup(key string) string {
+	value := os.Getenv(key)
+	old, renamed := renamedEnv[key]
+	if !renamed || os.Getenv(old) == "" {
+		return value
+	}
+
+	b.mu.Lock()
+	if b.deprecated != nil {
+		b.deprecated[old] = DeprecatedEnv{Key: old, Replacement: key}
+	}
+	b.mu.Unlock()
+	if value == "" {
+		return os.Getenv(old)
+	}
+	return value
+}
+
+// record notes the effective value of a setting. The first lookup of a key
+// wins, since fallbacks read a key again as the default of another.
+func (b *reportBuilder) record(key, value, defaultValue, source string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.settings == nil {
+		return
+	}
+	if _, ok := b.settings[key]; ok {
+		return
+	}
+	if isSecretEnv(key) {
+		value, defaultValue = redact(value), redact(defaultValue)
+	}
+	b.settings[key] = Setting{Key: key, Value: value, Default: defaultValue, Source: source}
+}
+
+// build reports the recorded settings and the unknown variables of environ
+func (b *reportBuilder) build(environ []string) Report {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	report := Report{Settings: []Setting{}, Unknown: []UnknownEnv{}, Deprecated: []DeprecatedEnv{}}
+	known := make([]string, 0, len(b.settings))
+	for key, setting := range b.settings {
+		report.Settings = append(report.Settings, setting)
+		known = append(known, key)
+	}
+	for _, deprecated := range b.deprecated {
+		report.Deprecated = append(report.Deprecated, deprecated)
+	}
+
+	for _, entry := range environ {
+		key, _, _ := strings.Cut(entry, "=")
+		if _, ok := b.settings[key]; ok || !hasSettingPrefix(key) || isRenamedEnv(key) {
+			continue
+		}
+		report.Unknown = append(report.Unknown, UnknownEnv{Key: key, Suggestion: closestKey(key, known)})
+	}
+
+	sort.Slice(report.Settings, func(i, j int) bool { return report.Settings[i].Key < report.Settings[j].Key })
+	sort.Slice(report.Unknown, func(i, j int) bool { return report.Unknown[i].Key < report.Unknown[j].Key })
+	sort.Slice(report.Deprecated, func(i, j int) bool { return report.Deprecated[i].Key < report.Deprecated[j].Key })
+	return report
+}
+
+func hasSettingPrefix(key string) bool {
+	for _, prefix := range settingPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isRenamedEnv(key string) bool {
+	for _, old := range renamedEnv {
+		if old == key {
+			return true
+		}
+	}
+	return false
+}
+
+func isSecretEnv(key string) bool {
+	for _, suffix := range secretSuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return redacted
+}
+
+// closestKey returns the known key within a few edits of key, if any
+func closestKey(key string, known []string) string {
+	const maxDistance = 3
+	best, bestDistance := "", maxDistance+1
+	for _, candidate := range known {
+		if distance := editDistance(key, candidate); distance < bestDistance ||
+			(distance == bestDistance && candidate < best) {
+			best, bestDistance = candidate, distance
+		}
+	}
+	if bestDistance > maxDistance {
+		return ""
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReport_SettingsAndTypos(t *testing.T) {
+	t.Setenv("SLACK_WEBHOOK_PATH", "/events")
+	t.Setenv("SLACK_SIGNING_SECRET", "")
+	t.Setenv("SLACK_SIGINING_SECRET", "s3cret")
+	t.Setenv("REDIS_PORT", "not-a-port")
+	t.Setenv("SLACK_BOT_TOKEN", "xoxb-1")
+
+	loading.reset()
+	assert.Equal(t, "/events", getEnv("SLACK_WEBHOOK_PATH", "/slack/events"))
+	assert.Equal(t, "", getEnv("SLACK_SIGNING_SECRET", ""))
+	assert.Equal(t, 6379, getEnvInt("REDIS_PORT", 6379))
+	assert.Equal(t, "xoxb-1", getEnv("SLACK_BOT_TOKEN", ""))
+	report := loading.build([]string{"SLACK_SIGINING_SECRET=s3cret", "SLACK_WEBHOOK_PATH=/events", "HOME=/root"})
+
+	assert.Equal(t, []Setting{
+		{Key: "REDIS_PORT", Value: "not-a-port", Default: "6379", Source: SourceInvalid},
+		{Key: "SLACK_BOT_TOKEN", Value: redacted, Default: "", Source: SourceEnv},
+		{Key: "SLACK_SIGNING_SECRET", Value: "", Default: "", Source: SourceDefault},
+		{Key: "SLACK_WEBHOOK_PATH", Value: "/events", Default: "/slack/events", Source: SourceEnv},
+	}, report.Settings)
+	assert.Len(t, report.Overridden(), 3)
+	assert.Equal(t, []UnknownEnv{{Key: "SLACK_SIGINING_SECRET", Suggestion: "SLACK_SIGNING_SECRET"}}, report.Unknown)
+	assert.Empty(t, report.Deprecated)
+}
+
+func TestReport_DeprecatedEnv(t *testing.T) {
+	t.Setenv("QUEUE_IDLE_TIMEOUT", "120")
+	t.Setenv("QUEUE_IDLE_TIMEOUT_SECONDS", "")
+	t.Setenv("EVENT_PROCESSING_TIMEOUT", "10")
+	t.Setenv("EVENT_PROCESSING_TIMEOUT_SECONDS", "30")
+
+	loading.reset()
+	// The old name applies until the new one is set
+	assert.Equal(t, 120, getEnvInt("QUEUE_IDLE_TIMEOUT_SECONDS", 300))
+	assert.Equal(t, 30, getEnvInt("EVENT_PROCESSING_TIMEOUT_SECONDS", 60))
+	report := loading.build([]string{"QUEUE_IDLE_TIMEOUT=120", "EVENT_PROCESSING_TIMEOUT=10", "EVENT_PROCESSING_TIMEOUT_SECONDS=30"})
+
+	assert.Equal(t, []DeprecatedEnv{
+		{Key: "EVENT_PROCESSING_TIMEOUT", Replacement: "EVENT_PROCESSING_TIMEOUT_SECONDS"},
+		{Key: "QUEUE_IDLE_TIMEOUT", Replacement: "QUEUE_IDLE_TIMEOUT_SECONDS"},
+	}, report.Deprecated)
+	assert.Empty(t, report.Unknown)
+}
+
+func TestLoad_Report(t *testing.T) {
+	t.Setenv("SLACK_BOT_TOKEN", "xoxb-1")
+	t.Setenv("SLACK_SIGNING_SECRET", "secret")
+	t.Setenv("GEMINI_API_KEY", "key")
+	t.Setenv("QUEUE_WATCHDOG_MAX_AGE", "90")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, cfg.Application.QueueWatchdogMaxAge)
+	assert.Contains(t, cfg.Report.Deprecated, DeprecatedEnv{Key: "QUEUE_WATCHDOG_MAX_AGE", Replacement: "QUEUE_WATCHDOG_MAX_AGE_SECONDS"})
+	assert.Contains(t, cfg.Report.Settings, Setting{Key: "GEMINI_API_KEY", Value: redacted, Default: "", Source: SourceEnv})
+	assert.Contains(t, cfg.Report.Settings, Setting{Key: "SERVER_PORT", Value: "8080", Default: "8080", Source: SourceDefault})
+}
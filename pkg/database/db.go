@@ -1,10 +1,8 @@
 package database
 
 import (
-	"database/sql"
 	"fmt"
 
-	_ "github.com/go-sql-driver/mysql"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
@@ -17,30 +15,10 @@ type DBConfig struct {
 	Database string
 }
 
-func NewDB(config DBConfig) (*sql.DB, error) {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true&charset=utf8mb4&collation=utf8mb4_unicode_ci",
-		config.User, config.Password, config.Host, config.Port, config.Database)
-
-	db, err := sql.Open("mysql", dsn)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
-	}
-
-	if err := db.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
-	}
-
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-
-	return db, nil
-}
-
+// NewGormDB opens the MySQL connection shared by every repository.
+// Use (*gorm.DB).DB() when a raw *sql.DB is needed (e.g. health checks).
 func NewGormDB(config DBConfig) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true&charset=utf8mb4&collation=utf8mb4_unicode_ci",
-		config.User, config.Password, config.Host, config.Port, config.Database)
-
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(mysql.Open(buildDSN(config)), &gorm.Config{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database with GORM: %w", err)
 	}
@@ -55,3 +33,8 @@ func NewGormDB(config DBConfig) (*gorm.DB, error) {
 
 	return db, nil
 }
+
+func buildDSN(config DBConfig) string {
+	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true&charset=utf8mb4&collation=utf8mb4_unicode_ci",
+		config.User, config.Password, config.Host, config.Port, config.Database)
+}
@@ -0,0 +1,104 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+const failoverCheckTimeout = 3 * time.Second
+
+// Failover watches the primary database and reports whether it accepts
+// writes. It is read-only while it cannot be reached or, e.g. during a
+// regional failover, while MySQL runs with read_only set. Callers then skip
+// persistence and read from a replica, instead of failing every request.
+type Failover struct {
+	primary  *sql.DB
+	logger   *zap.Logger
+	metrics  *metrics.Metrics
+	writable atomic.Bool
+}
+
+// NewFailover creates a monitor of primary, which is assumed writable until
+// the first check says otherwise
+func NewFailover(primary *sql.DB, logger *zap.Logger) *Failover {
+	f := &Failover{primary: primary, logger: logger}
+	f.writable.Store(true)
+	return f
+}
+
+// SetMetrics records the database state and skipped writes
+func (f *Failover) SetMetrics(m *metrics.Metrics) {
+	f.metrics = m
+	if m != nil {
+		m.RecordBackend("database", f.state())
+	}
+}
+
+// Writable reports whether the primary accepted writes at the last check
+func (f *Failover) Writable() bool {
+	return f.writable.Load()
+}
+
+// Run checks the primary every interval until ctx is done
+func (f *Failover) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			f.Check(ctx)
+		}
+	}
+}
+
+// Check probes the primary and updates Writable
+func (f *Failover) Check(ctx context.Context) {
+	err := f.probe(ctx)
+	writable := err == nil
+	if f.writable.Swap(writable) == writable {
+		return
+	}
+
+	if writable {
+		f.logger.Info("Primary database writable again, resuming persistence")
+	} else {
+		f.logger.Warn("Primary database not writable, skipping persistence", zap.Error(err))
+		if f.metrics != nil {
+			f.metrics.RecordError("database_failover")
+		}
+	}
+	if f.metrics != nil {
+		f.metrics.RecordBackend("database", f.state())
+	}
+}
+
+// probe returns why the primary does not accept writes, or nil
+func (f *Failover) probe(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, failoverCheckTimeout)
+	defer cancel()
+
+	var readOnly bool
+	if err := f.primary.QueryRowContext(ctx, "SELECT @@global.read_only").Scan(&readOnly); err != nil {
+		return fmt.Errorf("failed to reach primary database: %w", err)
+	}
+	if readOnly {
+		return fmt.Errorf("primary database is read-only")
+	}
+	return nil
+}
+
+func (f *Failover) state() string {
+	if f.Writable() {
+		return "primary"
+	}
+	return "read_only"
+}
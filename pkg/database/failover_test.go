@@ -0,0 +1,41 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFailover_Check(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	failover := NewFailover(db, zap.NewNop())
+	assert.True(t, failover.Writable())
+
+	// Unreachable primary
+	mock.ExpectQuery("SELECT @@global.read_only").WillReturnError(sql.ErrConnDone)
+	failover.Check(context.Background())
+	assert.False(t, failover.Writable())
+
+	// Reachable but read-only, e.g. demoted during a regional failover
+	mock.ExpectQuery("SELECT @@global.read_only").
+		WillReturnRows(sqlmock.NewRows([]string{"@@global.read_only"}).AddRow(1))
+	failover.Check(context.Background())
+	assert.False(t, failover.Writable())
+
+	mock.ExpectQuery("SELECT @@global.read_only").
+		WillReturnRows(sqlmock.NewRows([]string{"@@global.read_only"}).AddRow(0))
+	failover.Check(context.Background())
+	assert.True(t, failover.Writable())
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,36 @@
+package database
+
+import (
+	_ "embed"
+	"fmt"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+//go:embed sqlite_schema.sql
+var sqliteSchema string
+
+// NewSQLiteDB opens the SQLite database at path, e.g. for local development
+// without MySQL, and creates the tables it is missing. ":memory:" opens a
+// private in-memory database.
+func NewSQLiteDB(path string) (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(path+"?_busy_timeout=5000"), &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database instance: %w", err)
+	}
+	// SQLite serializes writes, and each connection to ":memory:" would
+	// open a database of its own
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := db.Exec(sqliteSchema).Error; err != nil {
+		return nil, fmt.Errorf("failed to create SQLite schema: %w", err)
+	}
+
+	return db, nil
+}
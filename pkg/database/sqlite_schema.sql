@@ -0,0 +1,203 @@
+-- Schema of database/migrations, for SQLite. Keep it in step with new
+-- migrations: it is applied as a whole each time the database is opened.
+
+CREATE TABLE IF NOT EXISTS channel_configs (
+    id VARCHAR(36) PRIMARY KEY,
+    channel_id VARCHAR(255) NOT NULL UNIQUE,
+    auto_translate BOOLEAN DEFAULT FALSE,
+    source_languages TEXT,
+    target_language VARCHAR(10) DEFAULT 'vi',
+    enabled BOOLEAN DEFAULT TRUE,
+    canary BOOLEAN DEFAULT FALSE,
+    debug BOOLEAN DEFAULT FALSE,
+    review_channel_id VARCHAR(255) NOT NULL DEFAULT '',
+    digest_interval_minutes INT NOT NULL DEFAULT 0,
+    digest_max_messages INT NOT NULL DEFAULT 0,
+    delivery_mode VARCHAR(20) NOT NULL DEFAULT '',
+    generation TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_channel_configs_enabled ON channel_configs (enabled);
+
+CREATE TABLE IF NOT EXISTS translations (
+    id VARCHAR(36) PRIMARY KEY,
+    source_message_id VARCHAR(255) NOT NULL,
+    source_text TEXT NOT NULL,
+    source_language VARCHAR(10),
+    target_language VARCHAR(10),
+    translated_text TEXT NOT NULL,
+    hash VARCHAR(64) NOT NULL,
+    user_id VARCHAR(255),
+    channel_id VARCHAR(255) NOT NULL,
+    provider VARCHAR(50) NOT NULL DEFAULT '',
+    prompt_version VARCHAR(20) NOT NULL DEFAULT '',
+    stage_timings TEXT,
+    failed_stage VARCHAR(20) NOT NULL DEFAULT '',
+    generation TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    ttl BIGINT
+);
+CREATE INDEX IF NOT EXISTS idx_translations_hash ON translations (hash);
+CREATE INDEX IF NOT EXISTS idx_translations_channel_id ON translations (channel_id);
+CREATE INDEX IF NOT EXISTS idx_translations_created_at ON translations (created_at);
+
+CREATE TABLE IF NOT EXISTS filter_rules (
+    id VARCHAR(36) PRIMARY KEY,
+    channel_id VARCHAR(255) NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    pattern TEXT,
+    "values" TEXT,
+    enabled BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_filter_rules_channel_id ON filter_rules (channel_id);
+
+CREATE TABLE IF NOT EXISTS audit_records (
+    id VARCHAR(36) PRIMARY KEY,
+    actor VARCHAR(255) NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    resource_type VARCHAR(64) NOT NULL,
+    resource_id VARCHAR(255) NOT NULL,
+    "before" TEXT,
+    "after" TEXT,
+    changed_fields TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_audit_records_resource ON audit_records (resource_type, resource_id);
+CREATE INDEX IF NOT EXISTS idx_audit_records_created_at ON audit_records (created_at);
+
+CREATE TABLE IF NOT EXISTS pending_translations (
+    id VARCHAR(36) PRIMARY KEY,
+    channel_id VARCHAR(255) NOT NULL,
+    thread_ts VARCHAR(32) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    source_language VARCHAR(50) NOT NULL DEFAULT '',
+    target_language VARCHAR(50) NOT NULL DEFAULT '',
+    original_text TEXT NOT NULL,
+    translated_text TEXT NOT NULL,
+    bot_name VARCHAR(255),
+    bot_avatar VARCHAR(1024),
+    as_quote BOOLEAN DEFAULT FALSE,
+    review_channel_id VARCHAR(255) NOT NULL,
+    review_message_ts VARCHAR(32),
+    status VARCHAR(16) NOT NULL,
+    reviewer_id VARCHAR(255),
+    reviewed_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_pending_translations_status ON pending_translations (status);
+
+CREATE TABLE IF NOT EXISTS translation_corrections (
+    id VARCHAR(36) PRIMARY KEY,
+    pending_translation_id VARCHAR(36) NOT NULL,
+    channel_id VARCHAR(255) NOT NULL,
+    source_language VARCHAR(50) NOT NULL,
+    target_language VARCHAR(50) NOT NULL,
+    source_text TEXT NOT NULL,
+    machine_text TEXT NOT NULL,
+    corrected_text TEXT NOT NULL,
+    reviewer_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_translation_corrections_channel_id ON translation_corrections (channel_id);
+
+CREATE TABLE IF NOT EXISTS channel_pairs (
+    id VARCHAR(36) PRIMARY KEY,
+    channel_id VARCHAR(255) NOT NULL UNIQUE,
+    paired_channel_id VARCHAR(255) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS workspaces (
+    team_id VARCHAR(32) PRIMARY KEY,
+    team_name VARCHAR(255) NOT NULL DEFAULT '',
+    bot_user_id VARCHAR(32) NOT NULL DEFAULT '',
+    bot_token_key_id VARCHAR(64) NOT NULL,
+    bot_token_wrapped_key BLOB NOT NULL,
+    bot_token_ciphertext BLOB NOT NULL,
+    signing_secret_key_id VARCHAR(64) NOT NULL DEFAULT '',
+    signing_secret_wrapped_key BLOB,
+    signing_secret_ciphertext BLOB,
+    installed_by VARCHAR(32) NOT NULL DEFAULT '',
+    generation TEXT,
+    monthly_budget DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    installed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS translation_feedback (
+    id VARCHAR(36) PRIMARY KEY,
+    translation_id VARCHAR(36) NOT NULL DEFAULT '',
+    channel_id VARCHAR(255) NOT NULL,
+    message_ts VARCHAR(32) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    source_language VARCHAR(50) NOT NULL,
+    target_language VARCHAR(50) NOT NULL,
+    rating TINYINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (channel_id, message_ts, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_translation_feedback_created_at ON translation_feedback (created_at);
+
+CREATE TABLE IF NOT EXISTS channel_usage (
+    channel_id VARCHAR(255) NOT NULL,
+    day DATE NOT NULL,
+    source_language VARCHAR(50) NOT NULL,
+    target_language VARCHAR(50) NOT NULL,
+    translations BIGINT NOT NULL DEFAULT 0,
+    cache_hits BIGINT NOT NULL DEFAULT 0,
+    prompt_tokens BIGINT NOT NULL DEFAULT 0,
+    output_tokens BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (channel_id, day, source_language, target_language)
+);
+CREATE INDEX IF NOT EXISTS idx_channel_usage_day ON channel_usage (day);
+
+CREATE TABLE IF NOT EXISTS user_preferences (
+    user_id VARCHAR(255) PRIMARY KEY,
+    preferred_language VARCHAR(50) NOT NULL DEFAULT '',
+    translate_own_messages BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS bot_posts (
+    id VARCHAR(36) PRIMARY KEY,
+    team_id VARCHAR(255) NOT NULL DEFAULT '',
+    channel_id VARCHAR(255) NOT NULL,
+    message_ts VARCHAR(32) NOT NULL DEFAULT '',
+    thread_ts VARCHAR(32) NOT NULL DEFAULT '',
+    action VARCHAR(16) NOT NULL,
+    user_id VARCHAR(255) NOT NULL DEFAULT '',
+    text TEXT,
+    blocks TEXT,
+    attachments TEXT,
+    posted_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_bot_posts_posted_at ON bot_posts (posted_at, id);
+CREATE INDEX IF NOT EXISTS idx_bot_posts_team_posted_at ON bot_posts (team_id, posted_at, id);
+
+CREATE TABLE IF NOT EXISTS compliance_exports (
+    id VARCHAR(36) PRIMARY KEY,
+    team_id VARCHAR(255) NOT NULL DEFAULT '',
+    range_start TIMESTAMP NOT NULL,
+    range_end TIMESTAMP NOT NULL,
+    object_key VARCHAR(1024) NOT NULL,
+    manifest_key VARCHAR(1024) NOT NULL,
+    records INT NOT NULL DEFAULT 0,
+    prev_hash CHAR(64) NOT NULL,
+    final_hash CHAR(64) NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    signature VARCHAR(255) NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_compliance_exports_created_at ON compliance_exports (created_at);
+
+CREATE TABLE IF NOT EXISTS injection_allowlist (
+    phrase VARCHAR(255) PRIMARY KEY,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const timeoutKey = "database:query_timeout"
+
+// SetQueryTimeout bounds each create, query, update, delete and raw statement
+// run through db at timeout, within the deadline of the statement's context,
+// e.g. one set with WithContext. Row scans (Rows, Row) are left alone, since
+// their rows are read after the statement returns.
+func SetQueryTimeout(db *gorm.DB, timeout time.Duration) error {
+	callbacks := db.Callback()
+	registrations := []struct {
+		name string
+		err  error
+	}{
+		{"create", callbacks.Create().Before("gorm:create").Register("timeout:start_create", startTimeout(timeout))},
+		{"create", callbacks.Create().After("gorm:create").Register("timeout:end_create", endTimeout)},
+		{"query", callbacks.Query().Before("gorm:query").Register("timeout:start_query", startTimeout(timeout))},
+		{"query", callbacks.Query().After("gorm:query").Register("timeout:end_query", endTimeout)},
+		{"update", callbacks.Update().Before("gorm:update").Register("timeout:start_update", startTimeout(timeout))},
+		{"update", callbacks.Update().After("gorm:update").Register("timeout:end_update", endTimeout)},
+		{"delete", callbacks.Delete().Before("gorm:delete").Register("timeout:start_delete", startTimeout(timeout))},
+		{"delete", callbacks.Delete().After("gorm:delete").Register("timeout:end_delete", endTimeout)},
+		{"raw", callbacks.Raw().Before("gorm:raw").Register("timeout:start_raw", startTimeout(timeout))},
+		{"raw", callbacks.Raw().After("gorm:raw").Register("timeout:end_raw", endTimeout)},
+	}
+	for _, registration := range registrations {
+		if registration.err != nil {
+			return fmt.Errorf("failed to register %s timeout: %w", registration.name, registration.err)
+		}
+	}
+	return nil
+}
+
+// queryTimeout is kept on a statement between its callbacks, to restore the
+// statement's own context once it has run
+type queryTimeout struct {
+	parent context.Context
+	cancel context.CancelFunc
+}
+
+func startTimeout(timeout time.Duration) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		parent := db.Statement.Context
+		if parent == nil {
+			parent = context.Background()
+		}
+		ctx, cancel := context.WithTimeout(parent, timeout)
+		db.Statement.Context = ctx
+		db.InstanceSet(timeoutKey, &queryTimeout{parent: parent, cancel: cancel})
+	}
+}
+
+func endTimeout(db *gorm.DB) {
+	if value, ok := db.InstanceGet(timeoutKey); ok {
+		timeout := value.(*queryTimeout)
+		timeout.cancel()
+		db.Statement.Context = timeout.parent
+	}
+}
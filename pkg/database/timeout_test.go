@@ -0,0 +1,41 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetQueryTimeout(t *testing.T) {
+	db, err := NewSQLiteDB(":memory:")
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	defer func() { _ = sqlDB.Close() }()
+
+	var count int64
+	require.NoError(t, SetQueryTimeout(db, time.Second))
+	// A reused chain runs again once its timeout is over
+	query := db.Table("translations")
+	require.NoError(t, query.Count(&count).Error)
+	require.NoError(t, query.Count(&count).Error)
+
+	// The statement's own context is kept: a cancelled one still aborts it
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = db.WithContext(ctx).Table("translations").Count(&count).Error
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// A query slower than the timeout is aborted
+	slow, err := NewSQLiteDB(":memory:")
+	require.NoError(t, err)
+	slowSQL, err := slow.DB()
+	require.NoError(t, err)
+	defer func() { _ = slowSQL.Close() }()
+	require.NoError(t, SetQueryTimeout(slow, time.Nanosecond))
+	err = slow.Table("translations").Count(&count).Error
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,65 @@
+package eventbus
+
+import (
+	"reflect"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// Bus delivers in-process events to the handlers subscribed to their type.
+// Handlers run synchronously, in the order they subscribed, on the
+// publisher's goroutine; a handler that does slow I/O should hand the event
+// off to a goroutine of its own. A panicking handler is logged and does not
+// stop the others. A nil Bus drops every event.
+type Bus struct {
+	logger *zap.Logger
+
+	mu       sync.RWMutex
+	handlers map[reflect.Type][]func(interface{})
+}
+
+func New(logger *zap.Logger) *Bus {
+	return &Bus{
+		logger:   logger,
+		handlers: make(map[reflect.Type][]func(interface{})),
+	}
+}
+
+// Subscribe calls handler with every event of type E published on b
+func Subscribe[E any](b *Bus, handler func(E)) {
+	eventType := reflect.TypeOf((*E)(nil)).Elem()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], func(event interface{}) {
+		handler(event.(E))
+	})
+}
+
+// Publish delivers event to the handlers subscribed to its type
+func (b *Bus) Publish(event interface{}) {
+	if b == nil || event == nil {
+		return
+	}
+	eventType := reflect.TypeOf(event)
+
+	b.mu.RLock()
+	handlers := b.handlers[eventType]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		b.deliver(eventType, handler, event)
+	}
+}
+
+func (b *Bus) deliver(eventType reflect.Type, handler func(interface{}), event interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.Error("Event handler panicked",
+				zap.String("event", eventType.String()),
+				zap.Any("panic", r))
+		}
+	}()
+	handler(event)
+}
@@ -0,0 +1,43 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type started struct{ ID string }
+
+type stopped struct{ ID string }
+
+func TestBus_DeliversByType(t *testing.T) {
+	bus := New(zap.NewNop())
+
+	var got []string
+	Subscribe(bus, func(e started) { got = append(got, "first:"+e.ID) })
+	Subscribe(bus, func(e started) { got = append(got, "second:"+e.ID) })
+	Subscribe(bus, func(e stopped) { got = append(got, "stopped:"+e.ID) })
+
+	bus.Publish(started{ID: "a"})
+	bus.Publish(stopped{ID: "b"})
+	bus.Publish("unsubscribed")
+
+	assert.Equal(t, []string{"first:a", "second:a", "stopped:b"}, got)
+}
+
+func TestBus_PanickingHandler(t *testing.T) {
+	bus := New(zap.NewNop())
+
+	delivered := false
+	Subscribe(bus, func(started) { panic("boom") })
+	Subscribe(bus, func(started) { delivered = true })
+
+	bus.Publish(started{ID: "a"})
+	assert.True(t, delivered)
+}
+
+func TestBus_Nil(t *testing.T) {
+	var bus *Bus
+	assert.NotPanics(t, func() { bus.Publish(started{ID: "a"}) })
+}
@@ -0,0 +1,133 @@
+// Package image downscales images shared in chat, e.g. into thumbnails
+// posted next to their translation
+package image
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif" // register the GIF decoder
+	"image/jpeg"
+	"image/png"
+)
+
+// maxPixels refuses images that would take too much memory to decode
+const maxPixels = 50_000_000
+
+// jpegQuality is the quality thumbnails of photos are encoded with
+const jpegQuality = 85
+
+var (
+	// ErrUnsupportedFormat is returned for images that are not PNG, JPEG or GIF
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	// ErrTooLarge is returned for images with more than maxPixels pixels
+	ErrTooLarge = errors.New("image has too many pixels")
+)
+
+// Thumbnail is a downscaled copy of an image
+type Thumbnail struct {
+	Data []byte
+	// Extension is the file extension of Data, "png" or "jpg"
+	Extension string
+	Width     int
+	Height    int
+}
+
+// NewThumbnail decodes a PNG, JPEG or GIF image and scales it down so its
+// longest side is at most maxSize pixels; smaller images keep their size.
+// PNG and GIF images become PNG thumbnails, keeping their transparency, and
+// JPEG images become JPEG thumbnails.
+func NewThumbnail(data []byte, maxSize int) (*Thumbnail, error) {
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("invalid thumbnail size %d", maxSize)
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if errors.Is(err, image.ErrFormat) {
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image header: %w", err)
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, ErrTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s image: %w", format, err)
+	}
+
+	width, height := fitSize(src.Bounds().Dx(), src.Bounds().Dy(), maxSize)
+	dst := downscale(src, width, height)
+
+	var buf bytes.Buffer
+	thumbnail := &Thumbnail{Width: width, Height: height}
+	if format == "jpeg" {
+		thumbnail.Extension = "jpg"
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: jpegQuality})
+	} else {
+		thumbnail.Extension = "png"
+		err = png.Encode(&buf, dst)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	thumbnail.Data = buf.Bytes()
+	return thumbnail, nil
+}
+
+// fitSize returns the size of a width x height image scaled down so its
+// longest side is at most maxSize, keeping the aspect ratio
+func fitSize(width, height, maxSize int) (int, int) {
+	if width <= maxSize && height <= maxSize {
+		return width, height
+	}
+	if width >= height {
+		return maxSize, max(1, height*maxSize/width)
+	}
+	return max(1, width*maxSize/height), maxSize
+}
+
+// downscale resizes src to width x height with a box filter: each pixel of
+// the result averages the source pixels it covers
+func downscale(src image.Image, width, height int) *image.NRGBA {
+	bounds := src.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+
+	srcW, srcH := rgba.Bounds().Dx(), rgba.Bounds().Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*srcH/height, max((y+1)*srcH/height, y*srcH/height+1)
+		for x := 0; x < width; x++ {
+			x0, x1 := x*srcW/width, max((x+1)*srcW/width, x*srcW/width+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				row := rgba.Pix[sy*rgba.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += uint64(p[0])
+					g += uint64(p[1])
+					b += uint64(p[2])
+					a += uint64(p[3])
+					n++
+				}
+			}
+
+			// Average premultiplied colors, then store them unpremultiplied
+			i := dst.PixOffset(x, y)
+			if a == 0 {
+				continue
+			}
+			dst.Pix[i] = uint8(r * 255 / a)
+			dst.Pix[i+1] = uint8(g * 255 / a)
+			dst.Pix[i+2] = uint8(b * 255 / a)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
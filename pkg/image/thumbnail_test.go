@@ -0,0 +1,87 @@
+package image
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestNewThumbnail_PNG(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 800, 400))
+	for y := 0; y < 400; y++ {
+		for x := 0; x < 800; x++ {
+			src.Set(x, y, color.NRGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+
+	thumbnail, err := NewThumbnail(encodePNG(t, src), 200)
+	require.NoError(t, err)
+	assert.Equal(t, "png", thumbnail.Extension)
+	assert.Equal(t, 200, thumbnail.Width)
+	assert.Equal(t, 100, thumbnail.Height)
+
+	decoded, err := png.Decode(bytes.NewReader(thumbnail.Data))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 200, 100), decoded.Bounds())
+	assert.Equal(t, color.NRGBA{R: 200, G: 100, B: 50, A: 255}, color.NRGBAModel.Convert(decoded.At(50, 50)))
+}
+
+func TestNewThumbnail_JPEG(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 300, 900))
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, src, nil))
+
+	thumbnail, err := NewThumbnail(buf.Bytes(), 300)
+	require.NoError(t, err)
+	assert.Equal(t, "jpg", thumbnail.Extension)
+	assert.Equal(t, 100, thumbnail.Width)
+	assert.Equal(t, 300, thumbnail.Height)
+
+	_, err = jpeg.Decode(bytes.NewReader(thumbnail.Data))
+	assert.NoError(t, err)
+}
+
+func TestNewThumbnail_SmallImageKeepsSize(t *testing.T) {
+	thumbnail, err := NewThumbnail(encodePNG(t, image.NewNRGBA(image.Rect(0, 0, 40, 30))), 200)
+	require.NoError(t, err)
+	assert.Equal(t, 40, thumbnail.Width)
+	assert.Equal(t, 30, thumbnail.Height)
+}
+
+func TestNewThumbnail_Errors(t *testing.T) {
+	_, err := NewThumbnail([]byte("not an image"), 200)
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+
+	_, err = NewThumbnail(encodePNG(t, image.NewNRGBA(image.Rect(0, 0, 10, 10))), 0)
+	assert.Error(t, err)
+}
+
+func TestFitSize(t *testing.T) {
+	tests := []struct {
+		width, height, maxSize int
+		wantW, wantH           int
+	}{
+		{width: 1000, height: 500, maxSize: 100, wantW: 100, wantH: 50},
+		{width: 500, height: 1000, maxSize: 100, wantW: 50, wantH: 100},
+		{width: 5000, height: 1, maxSize: 100, wantW: 100, wantH: 1},
+		{width: 80, height: 60, maxSize: 100, wantW: 80, wantH: 60},
+	}
+
+	for _, tt := range tests {
+		w, h := fitSize(tt.width, tt.height, tt.maxSize)
+		assert.Equal(t, tt.wantW, w)
+		assert.Equal(t, tt.wantH, h)
+	}
+}
@@ -0,0 +1,50 @@
+package language
+
+import "strings"
+
+// Unicode directional formatting characters
+const (
+	// rightToLeftMark is an invisible right-to-left character (RLM). Starting a
+	// line with it lays the line out right to left even when it begins with
+	// Latin text, e.g. a mention or a list marker.
+	rightToLeftMark = "\u200f"
+	// leftToRightIsolate and popDirectionalIsolate (LRI and PDI) enclose a
+	// left-to-right run, so punctuation next to it keeps the direction of the
+	// right-to-left text around it
+	leftToRightIsolate    = "\u2066"
+	popDirectionalIsolate = "\u2069"
+)
+
+// bidiControls are the directional marks, embeddings, overrides and isolates
+var bidiControls = strings.NewReplacer(
+	"\u200e", "", "\u200f", "", "\u061c", "",
+	"\u202a", "", "\u202b", "", "\u202c", "", "\u202d", "", "\u202e", "",
+	"\u2066", "", "\u2067", "", "\u2068", "", "\u2069", "",
+)
+
+// StripBidiControls removes directional formatting characters from text,
+// e.g. marks an AI model added inside a placeholder
+func StripBidiControls(text string) string {
+	return bidiControls.Replace(text)
+}
+
+// MarkRightToLeft starts each non-empty line of text with a right-to-left mark
+func MarkRightToLeft(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			lines[i] = rightToLeftMark + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// IsolateLeftToRight encloses a left-to-right run, e.g. a link or inline
+// code, in a directional isolate. Multi-line text is returned as is, since
+// isolates do not span paragraphs.
+func IsolateLeftToRight(text string) string {
+	if text == "" || strings.Contains(text, "\n") {
+		return text
+	}
+	return leftToRightIsolate + text + popDirectionalIsolate
+}
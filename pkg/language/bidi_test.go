@@ -0,0 +1,26 @@
+package language
+
+import "testing"
+
+func TestMarkRightToLeft(t *testing.T) {
+	got := MarkRightToLeft("שלום\n\n- רשימה")
+	want := "\u200fשלום\n\n\u200f- רשימה"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestIsolateLeftToRight(t *testing.T) {
+	if got := IsolateLeftToRight("<https://example.com>"); got != "\u2066<https://example.com>\u2069" {
+		t.Errorf("unexpected isolate: %q", got)
+	}
+	if got := IsolateLeftToRight("```\ncode\n```"); got != "```\ncode\n```" {
+		t.Errorf("multi-line text should not be isolated: %q", got)
+	}
+}
+
+func TestStripBidiControls(t *testing.T) {
+	if got := StripBidiControls("\u200fLINK\u200e0 مرحبا\u2069"); got != "LINK0 مرحبا" {
+		t.Errorf("unexpected text: %q", got)
+	}
+}
@@ -20,6 +20,9 @@ func NewLanguageDetector() (*LanguageDetector, error) {
 		lingua.Chinese,
 		lingua.Japanese,
 		lingua.Korean,
+		lingua.Thai,
+		lingua.Arabic,
+		lingua.Hebrew,
 	}
 
 	detector := lingua.NewLanguageDetectorBuilder().
@@ -49,9 +52,12 @@ func (ld *LanguageDetector) GetLanguageCode(langStr string) (string, error) {
 		"SPANISH":    "es",
 		"FRENCH":     "fr",
 		"GERMAN":     "de",
-		"CHINESE":    "zh",
+		"CHINESE":    "zh-Hans",
 		"JAPANESE":   "ja",
 		"KOREAN":     "ko",
+		"THAI":       "th",
+		"ARABIC":     "ar",
+		"HEBREW":     "he",
 	}
 
 	code, exists := codeMap[langStr]
@@ -0,0 +1,100 @@
+package language
+
+import (
+	"strings"
+	"unicode"
+)
+
+// simplifiedOnly and traditionalOnly are common Chinese characters written
+// differently in the two scripts, pair by pair
+const (
+	simplifiedOnly  = "这们来个时说国会对过还发么为学经动样现问间长开关见话东车马书门给听买卖电边认请让谢气业当应实从头进办网题没与选历"
+	traditionalOnly = "這們來個時說國會對過還發麼為學經動樣現問間長開關見話東車馬書門給聽買賣電邊認請讓謝氣業當應實從頭進辦網題沒與選歷"
+)
+
+// DetectScript returns the language code of text when its script tells the
+// language: "ko" for Hangul, "th" for Thai, "he" for Hebrew, "ar" for the
+// Arabic script, "ja" for kana, and "zh-Hans" or "zh-Hant" for Chinese
+// characters without kana, told apart by characters written differently in
+// Simplified and Traditional Chinese. It returns false
+// when no such script makes up at least half of the letters, e.g. for text
+// in the Latin script, whose language needs a detector.
+func DetectScript(text string) (string, bool) {
+	var hangul, thai, hebrew, arabic, kana, han, simplified, traditional, letters int
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.Is(unicode.Thai, r):
+			thai++
+		case unicode.Is(unicode.Hebrew, r):
+			hebrew++
+		case unicode.Is(unicode.Arabic, r):
+			arabic++
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			han++
+			if strings.ContainsRune(simplifiedOnly, r) {
+				simplified++
+			} else if strings.ContainsRune(traditionalOnly, r) {
+				traditional++
+			}
+		case unicode.IsLetter(r):
+			letters++
+		default:
+			continue
+		}
+	}
+	letters += hangul + thai + hebrew + arabic + kana + han
+
+	switch {
+	case letters == 0:
+		return "", false
+	case hangul*2 >= letters:
+		return "ko", true
+	case thai*2 >= letters:
+		return "th", true
+	case hebrew*2 >= letters:
+		return "he", true
+	case arabic*2 >= letters:
+		return "ar", true
+	case kana > 0 && (kana+han)*2 >= letters:
+		return "ja", true
+	case han*2 >= letters && traditional > simplified:
+		return "zh-Hant", true
+	case han*2 >= letters:
+		return "zh-Hans", true
+	default:
+		return "", false
+	}
+}
+
+// charsPerToken is how many characters of alphabetic scripts, e.g. Latin or
+// Vietnamese, make up a token on average
+const charsPerToken = 4
+
+// EstimateTokens estimates how many tokens an AI model splits text into.
+// Counting characters undercounts scripts without spaces between words:
+// each Chinese, Japanese or Korean character is about a token, and Thai
+// about two characters per token, against four characters of Latin text.
+func EstimateTokens(text string) int {
+	var wide, thai, other int
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			wide++
+		case unicode.Is(unicode.Thai, r):
+			thai++
+		default:
+			other++
+		}
+	}
+	return wide + (thai+1)/2 + (other+charsPerToken-1)/charsPerToken
+}
+
+// TokenBudget returns the tokens that maxChars characters of Latin text
+// make up, the token limit matching a length limit in characters
+func TokenBudget(maxChars int) int {
+	return (maxChars + charsPerToken - 1) / charsPerToken
+}
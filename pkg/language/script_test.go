@@ -0,0 +1,46 @@
+package language
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectScript(t *testing.T) {
+	tests := []struct {
+		text     string
+		wantCode string
+		wantOK   bool
+	}{
+		{text: "안녕하세요, 회의는 3시에 시작합니다", wantCode: "ko", wantOK: true},
+		{text: "สวัสดีครับ ประชุมเริ่มบ่ายสามโมง", wantCode: "th", wantOK: true},
+		{text: "שלום, הפגישה מתחילה בשלוש", wantCode: "he", wantOK: true},
+		{text: "مرحبا، يبدأ الاجتماع الساعة الثالثة", wantCode: "ar", wantOK: true},
+		{text: "会議は3時に始まります", wantCode: "ja", wantOK: true},
+		{text: "我们下午三点开会，请准时参加", wantCode: "zh-Hans", wantOK: true},
+		{text: "我們下午三點開會，請準時參加", wantCode: "zh-Hant", wantOK: true},
+		{text: "The deploy of 新功能 is done", wantOK: false},
+		{text: "Hello team", wantOK: false},
+		{text: "123 :tada:", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			code, ok := DetectScript(tt.text)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantCode, code)
+		})
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	assert.Equal(t, 3, EstimateTokens("Hello team"))
+	assert.Equal(t, 5, EstimateTokens("我们开会了"))
+	assert.Equal(t, 3, EstimateTokens("ครับผม"))
+	assert.Equal(t, 0, EstimateTokens(""))
+
+	// 1000 Chinese characters use as many tokens as 4000 Latin ones
+	assert.Equal(t, EstimateTokens(strings.Repeat("a", 4000)), EstimateTokens(strings.Repeat("我", 1000)))
+	assert.Equal(t, 1250, TokenBudget(5000))
+}
@@ -0,0 +1,35 @@
+package language
+
+import "unicode/utf8"
+
+// Truncate returns the first maxRunes characters of s, never splitting a
+// multibyte character such as Japanese kana and kanji
+func Truncate(s string, maxRunes int) string {
+	if maxRunes <= 0 {
+		return ""
+	}
+	count := 0
+	for i := range s {
+		if count == maxRunes {
+			return s[:i]
+		}
+		count++
+	}
+	return s
+}
+
+// TruncateBytes returns the longest prefix of s of at most maxBytes bytes
+// that ends on a character boundary
+func TruncateBytes(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	if maxBytes <= 0 {
+		return ""
+	}
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut]
+}
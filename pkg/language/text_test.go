@@ -0,0 +1,26 @@
+package language
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "Hello", Truncate("Hello world", 5))
+	assert.Equal(t, "こんにちは", Truncate("こんにちは世界", 5))
+	assert.Equal(t, "short", Truncate("short", 10))
+	assert.Equal(t, "", Truncate("こんにちは", 0))
+}
+
+func TestTruncateBytes(t *testing.T) {
+	// Each kana takes 3 bytes, so 7 bytes hold two of them
+	truncated := TruncateBytes("こんにちは", 7)
+	assert.Equal(t, "こん", truncated)
+	assert.True(t, utf8.ValidString(truncated))
+
+	assert.Equal(t, "abc", TruncateBytes("abc", 10))
+	assert.Equal(t, "ab", TruncateBytes("abc", 2))
+	assert.Equal(t, "", TruncateBytes("日本", 2))
+}
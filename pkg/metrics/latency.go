@@ -0,0 +1,224 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Stages of a translation reply measured by a LatencyTrace
+const (
+	StageQueueWait = "queue_wait"
+	StageCache     = "cache"
+	StageDatabase  = "database"
+	StageAI        = "ai"
+	StageSlackPost = "slack_post"
+)
+
+// latencyBuckets are the upper bounds of the reply latency histogram
+var latencyBuckets = []time.Duration{
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+}
+
+// replyLatencyRetention is how long individual reply latencies are kept for SLO burn-rate checks
+const replyLatencyRetention = time.Hour
+
+// LatencyTrace measures one translation from the Slack event being received
+// to the bot reply being posted, broken down by stage, and remembers the
+// first stage that failed. A nil trace ignores Add and Fail.
+type LatencyTrace struct {
+	ReceivedAt time.Time
+
+	mu          sync.Mutex
+	stages      map[string]time.Duration
+	failedStage string
+}
+
+func NewLatencyTrace(receivedAt time.Time) *LatencyTrace {
+	return &LatencyTrace{
+		ReceivedAt: receivedAt,
+		stages:     make(map[string]time.Duration),
+	}
+}
+
+// Add adds d to the time spent in stage
+func (t *LatencyTrace) Add(stage string, d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stages[stage] += d
+}
+
+// Fail records that stage failed, unless an earlier stage already did
+func (t *LatencyTrace) Fail(stage string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.failedStage == "" {
+		t.failedStage = stage
+	}
+}
+
+// FailedStage returns the first stage that failed, or "" when none did
+func (t *LatencyTrace) FailedStage() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.failedStage
+}
+
+// Stages returns a copy of the time spent per stage
+func (t *LatencyTrace) Stages() map[string]time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stages := make(map[string]time.Duration, len(t.stages))
+	for stage, d := range t.stages {
+		stages[stage] = d
+	}
+	return stages
+}
+
+type latencyTraceKey struct{}
+
+// WithLatencyTrace returns a copy of ctx carrying trace
+func WithLatencyTrace(ctx context.Context, trace *LatencyTrace) context.Context {
+	return context.WithValue(ctx, latencyTraceKey{}, trace)
+}
+
+// LatencyTraceFrom returns the trace carried by ctx, or nil
+func LatencyTraceFrom(ctx context.Context) *LatencyTrace {
+	trace, _ := ctx.Value(latencyTraceKey{}).(*LatencyTrace)
+	return trace
+}
+
+type replyLatency struct {
+	postedAt time.Time
+	latency  time.Duration
+}
+
+// RecordReplyLatency records the end-to-end latency of a reply posted at
+// postedAt, from the event being received, and the time spent per stage
+func (m *Metrics) RecordReplyLatency(trace *LatencyTrace, postedAt time.Time) time.Duration {
+	latency := postedAt.Sub(trace.ReceivedAt)
+	stages := trace.Stages()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bucket := len(latencyBuckets)
+	for i, bound := range latencyBuckets {
+		if latency <= bound {
+			bucket = i
+			break
+		}
+	}
+	m.ReplyLatencyBuckets[bucket]++
+	m.ReplyLatencyCount++
+	m.ReplyLatencyTotal += latency
+	for stage, d := range stages {
+		m.ReplyStageTotals[stage] += d
+	}
+
+	m.replyLatencies = append(m.replyLatencies, replyLatency{postedAt: postedAt, latency: latency})
+	cutoff := postedAt.Add(-replyLatencyRetention)
+	for len(m.replyLatencies) > 0 && m.replyLatencies[0].postedAt.Before(cutoff) {
+		m.replyLatencies = m.replyLatencies[1:]
+	}
+
+	return latency
+}
+
+// RecordStageFailure counts a translation whose first failure was in stage,
+// whether or not it was still served
+func (m *Metrics) RecordStageFailure(stage string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ReplyStageFailures[stage]++
+}
+
+// ReplyLatenciesSince returns the number of replies posted since the given
+// time and how many of them took longer than threshold. Only the last hour
+// of replies is kept.
+func (m *Metrics) ReplyLatenciesSince(since time.Time, threshold time.Duration) (total, slow int64) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, reply := range m.replyLatencies {
+		if reply.postedAt.Before(since) {
+			continue
+		}
+		total++
+		if reply.latency > threshold {
+			slow++
+		}
+	}
+	return total, slow
+}
+
+// AverageReplyLatencySince returns the average latency of the replies posted
+// since the given time and how many there were. Only the last hour of replies
+// is kept.
+func (m *Metrics) AverageReplyLatencySince(since time.Time) (time.Duration, int64) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var total time.Duration
+	var count int64
+	for _, reply := range m.replyLatencies {
+		if reply.postedAt.Before(since) {
+			continue
+		}
+		total += reply.latency
+		count++
+	}
+	if count == 0 {
+		return 0, 0
+	}
+	return total / time.Duration(count), count
+}
+
+func (m *Metrics) getReplyLatencyStats() map[string]interface{} {
+	// Buckets are cumulative, like a Prometheus histogram
+	buckets := make(map[string]int64, len(m.ReplyLatencyBuckets))
+	var cumulative int64
+	for i, count := range m.ReplyLatencyBuckets {
+		cumulative += count
+		label := "le_inf"
+		if i < len(latencyBuckets) {
+			label = fmt.Sprintf("le_%dms", latencyBuckets[i].Milliseconds())
+		}
+		buckets[label] = cumulative
+	}
+
+	stages := make(map[string]float64, len(m.ReplyStageTotals))
+	var average float64
+	if m.ReplyLatencyCount > 0 {
+		average = float64(m.ReplyLatencyTotal.Milliseconds()) / float64(m.ReplyLatencyCount)
+		for stage, total := range m.ReplyStageTotals {
+			stages[stage] = float64(total.Milliseconds()) / float64(m.ReplyLatencyCount)
+		}
+	}
+
+	failures := make(map[string]int64, len(m.ReplyStageFailures))
+	for stage, count := range m.ReplyStageFailures {
+		failures[stage] = count
+	}
+
+	return map[string]interface{}{
+		"count":            m.ReplyLatencyCount,
+		"average_ms":       average,
+		"buckets":          buckets,
+		"stage_average_ms": stages,
+		"stage_failures":   failures,
+	}
+}
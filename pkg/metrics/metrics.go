@@ -18,10 +18,98 @@ type Metrics struct {
 
 	CacheHits   int64
 	CacheMisses int64
+	// CacheTierHits and CacheTierMisses count the lookups of each tier of a
+	// tiered cache, e.g. "l1" for the local cache and "l2" for Redis
+	CacheTierHits   map[string]int64
+	CacheTierMisses map[string]int64
 
 	GeminiTokensUsed int64
+	// ProviderTokens counts tokens used per AI provider
+	ProviderTokens map[string]int64
 
 	ErrorsByType map[string]int64
+
+	Variants map[string]*VariantStats
+
+	MessageOrdering map[string]int64
+
+	InjectionVerdicts map[string]int64
+	InjectionFeedback map[string]int64
+
+	ThreatLevels   map[string]int64
+	ThreatPatterns map[string]int64
+	ThreatChannels map[string]int64
+
+	SafetyBlocks map[string]int64
+
+	// QueueDepths is the number of messages waiting per channel queue; empty queues are omitted
+	QueueDepths map[string]int64
+
+	// WorkerPools holds the worker and queue gauges of each worker pool, by pool name
+	WorkerPools map[string]WorkerPoolGauges
+
+	// Backends names the backend serving each failover-aware component, e.g.
+	// "cache" -> "secondary" or "database" -> "read_only"
+	Backends map[string]string
+
+	// RedisMemory is the latest sample of the Redis memory use and evictions
+	RedisMemory RedisMemoryStats
+
+	// TokenBudget is the latest count of the daily AI token budget, if any
+	TokenBudget TokenBudgetGauges
+
+	// ReplyLatencyBuckets counts replies per latencyBuckets bound, plus one overflow bucket
+	ReplyLatencyBuckets []int64
+	ReplyLatencyCount   int64
+	ReplyLatencyTotal   time.Duration
+	ReplyStageTotals    map[string]time.Duration
+	// ReplyStageFailures counts translations by the first stage that failed
+	ReplyStageFailures map[string]int64
+	replyLatencies     []replyLatency
+}
+
+// SecurityCounters is a point-in-time copy of the input security counters
+type SecurityCounters struct {
+	ThreatLevels map[string]int64
+	Patterns     map[string]int64
+	Channels     map[string]int64
+	Verdicts     map[string]int64
+}
+
+// RedisMemoryStats is a sample of Redis INFO memory and stats
+type RedisMemoryStats struct {
+	UsedBytes int64 `json:"used_bytes"`
+	// MaxBytes is maxmemory, 0 when Redis has no memory limit
+	MaxBytes    int64  `json:"max_bytes"`
+	Policy      string `json:"policy"`
+	EvictedKeys int64  `json:"evicted_keys"`
+	ExpiredKeys int64  `json:"expired_keys"`
+}
+
+// WorkerPoolGauges is the latest state of a worker pool
+type WorkerPoolGauges struct {
+	Workers int `json:"workers"`
+	Queues  int `json:"queues"`
+	// Waiting is the number of queues waiting for a worker slot
+	Waiting int `json:"waiting"`
+	// MaxWorkers is the worker cap, 0 when the pool has none
+	MaxWorkers int `json:"max_workers"`
+}
+
+// TokenBudgetGauges is the latest state of the daily AI token budget
+type TokenBudgetGauges struct {
+	Limit     int64 `json:"limit"`
+	Used      int64 `json:"used"`
+	Remaining int64 `json:"remaining"`
+	Exhausted bool  `json:"exhausted"`
+}
+
+// VariantStats aggregates AI translation calls served by one prompt/provider variant
+type VariantStats struct {
+	Requests           int64
+	Failures           int64
+	ValidationFailures int64
+	TotalLatency       time.Duration
 }
 
 func NewMetrics() *Metrics {
@@ -31,6 +119,23 @@ func NewMetrics() *Metrics {
 		ChannelRequests:     make(map[string]int64),
 		APILatencies:        make([]time.Duration, 0),
 		ErrorsByType:        make(map[string]int64),
+		ProviderTokens:      make(map[string]int64),
+		Variants:            make(map[string]*VariantStats),
+		MessageOrdering:     make(map[string]int64),
+		InjectionVerdicts:   make(map[string]int64),
+		InjectionFeedback:   make(map[string]int64),
+		ThreatLevels:        make(map[string]int64),
+		ThreatPatterns:      make(map[string]int64),
+		ThreatChannels:      make(map[string]int64),
+		SafetyBlocks:        make(map[string]int64),
+		QueueDepths:         make(map[string]int64),
+		WorkerPools:         make(map[string]WorkerPoolGauges),
+		Backends:            make(map[string]string),
+		ReplyLatencyBuckets: make([]int64, len(latencyBuckets)+1),
+		ReplyStageTotals:    make(map[string]time.Duration),
+		ReplyStageFailures:  make(map[string]int64),
+		CacheTierHits:       make(map[string]int64),
+		CacheTierMisses:     make(map[string]int64),
 	}
 }
 
@@ -62,10 +167,29 @@ func (m *Metrics) RecordCacheMiss() {
 	m.CacheMisses++
 }
 
+// RecordCacheTier records a lookup in one tier of a tiered cache
+func (m *Metrics) RecordCacheTier(tier string, hit bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if hit {
+		m.CacheTierHits[tier]++
+	} else {
+		m.CacheTierMisses[tier]++
+	}
+}
+
 func (m *Metrics) RecordGeminiTokens(tokens int64) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.GeminiTokensUsed += tokens
+	m.ProviderTokens["gemini"] += tokens
+}
+
+// RecordProviderTokens records tokens used by the named AI provider
+func (m *Metrics) RecordProviderTokens(provider string, tokens int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ProviderTokens[provider] += tokens
 }
 
 func (m *Metrics) RecordError(errorType string) {
@@ -74,6 +198,147 @@ func (m *Metrics) RecordError(errorType string) {
 	m.ErrorsByType[errorType]++
 }
 
+// RecordVariantTranslation records one AI translation call for a rollout variant.
+// validationFailed marks a response rejected by output validation.
+func (m *Metrics) RecordVariantTranslation(variant string, duration time.Duration, success, validationFailed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, ok := m.Variants[variant]
+	if !ok {
+		stats = &VariantStats{}
+		m.Variants[variant] = stats
+	}
+
+	stats.Requests++
+	stats.TotalLatency += duration
+	if !success {
+		stats.Failures++
+	}
+	if validationFailed {
+		stats.ValidationFailures++
+	}
+}
+
+// RecordMessageOrdering counts how a channel message was ordered on processing
+func (m *Metrics) RecordMessageOrdering(outcome string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.MessageOrdering[outcome]++
+}
+
+// RecordInjectionVerdict counts the outcome of input injection detection
+// (e.g. "clean", "flagged", "blocked", "shadow_blocked")
+func (m *Metrics) RecordInjectionVerdict(verdict string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.InjectionVerdicts[verdict]++
+}
+
+// RecordInjectionFeedback counts an admin review of a detection result
+func (m *Metrics) RecordInjectionFeedback(falsePositive bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if falsePositive {
+		m.InjectionFeedback["false_positive"]++
+	} else {
+		m.InjectionFeedback["confirmed"]++
+	}
+}
+
+// RecordInputThreat counts a validated input by threat level and detected
+// pattern. Channels are only counted for flagged inputs,
+// so the per-channel counter shows where suspicious input comes from.
+func (m *Metrics) RecordInputThreat(channelID, level string, patterns []string, flagged bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.ThreatLevels[level]++
+	for _, pattern := range patterns {
+		m.ThreatPatterns[pattern]++
+	}
+	if flagged && channelID != "" {
+		m.ThreatChannels[channelID]++
+	}
+}
+
+// RecordSafetyBlock counts a translation in channelID that the AI provider's
+// safety filters refused
+func (m *Metrics) RecordSafetyBlock(channelID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.SafetyBlocks[channelID]++
+}
+
+// RecordQueueDepth sets the number of messages waiting in a channel queue
+func (m *Metrics) RecordQueueDepth(queueKey string, depth int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if depth == 0 {
+		delete(m.QueueDepths, queueKey)
+		return
+	}
+	m.QueueDepths[queueKey] = int64(depth)
+}
+
+// RecordWorkerPool sets the worker and queue gauges of the named worker pool
+func (m *Metrics) RecordWorkerPool(pool string, workers, queues, waiting, maxWorkers int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.WorkerPools[pool] = WorkerPoolGauges{
+		Workers:    workers,
+		Queues:     queues,
+		Waiting:    waiting,
+		MaxWorkers: maxWorkers,
+	}
+}
+
+// RecordBackend records which backend currently serves a component
+func (m *Metrics) RecordBackend(component, backend string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Backends[component] = backend
+}
+
+// RecordRedisMemory sets the latest Redis memory sample
+func (m *Metrics) RecordRedisMemory(stats RedisMemoryStats) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.RedisMemory = stats
+}
+
+// RecordTokenBudget sets the daily AI token budget and the tokens used today
+func (m *Metrics) RecordTokenBudget(limit, used int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.TokenBudget = TokenBudgetGauges{
+		Limit:     limit,
+		Used:      used,
+		Remaining: max(limit-used, 0),
+		Exhausted: used >= limit,
+	}
+}
+
+// CacheCounts returns the translation cache hits and misses recorded so far
+func (m *Metrics) CacheCounts() (hits, misses int64) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.CacheHits, m.CacheMisses
+}
+
+// SecurityCounters returns a copy of the input security counters
+func (m *Metrics) SecurityCounters() SecurityCounters {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return SecurityCounters{
+		ThreatLevels: copyCounts(m.ThreatLevels),
+		Patterns:     copyCounts(m.ThreatPatterns),
+		Channels:     copyCounts(m.ThreatChannels),
+		Verdicts:     copyCounts(m.InjectionVerdicts),
+	}
+}
+
 func (m *Metrics) GetStats() map[string]interface{} {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -85,10 +350,29 @@ func (m *Metrics) GetStats() map[string]interface{} {
 	stats["success_rate"] = m.getSuccessRate()
 	stats["average_latency_ms"] = m.getAverageLatency()
 	stats["cache_hit_rate"] = m.getCacheHitRate()
+	stats["cache_tiers"] = m.getCacheTierStats()
 	stats["total_gemini_tokens"] = m.GeminiTokensUsed
+	stats["tokens_by_provider"] = m.ProviderTokens
 	stats["errors_by_type"] = m.ErrorsByType
 	stats["top_users"] = m.getTopUsers()
 	stats["top_channels"] = m.getTopChannels()
+	stats["variants"] = m.getVariantStats()
+	stats["message_ordering"] = m.MessageOrdering
+	stats["injection_detection"] = m.getInjectionStats()
+	stats["security"] = map[string]interface{}{
+		"threat_levels":    m.ThreatLevels,
+		"patterns":         m.ThreatPatterns,
+		"flagged_channels": m.ThreatChannels,
+	}
+	stats["safety_blocks"] = m.SafetyBlocks
+	stats["reply_latency"] = m.getReplyLatencyStats()
+	stats["queue_depth"] = m.QueueDepths
+	stats["worker_pools"] = m.WorkerPools
+	stats["backends"] = m.Backends
+	stats["redis_memory"] = m.RedisMemory
+	if m.TokenBudget.Limit > 0 {
+		stats["token_budget"] = m.TokenBudget
+	}
 
 	return stats
 }
@@ -117,6 +401,21 @@ func (m *Metrics) getAverageLatency() float64 {
 	return float64(totalDuration.Milliseconds()) / float64(len(m.APILatencies))
 }
 
+func (m *Metrics) getCacheTierStats() map[string]interface{} {
+	tiers := make(map[string]interface{})
+	for _, counts := range []map[string]int64{m.CacheTierHits, m.CacheTierMisses} {
+		for tier := range counts {
+			hits, misses := m.CacheTierHits[tier], m.CacheTierMisses[tier]
+			tiers[tier] = map[string]interface{}{
+				"hits":     hits,
+				"misses":   misses,
+				"hit_rate": float64(hits) / float64(hits+misses) * 100,
+			}
+		}
+	}
+	return tiers
+}
+
 func (m *Metrics) getCacheHitRate() float64 {
 	total := m.CacheHits + m.CacheMisses
 	if total == 0 {
@@ -144,3 +443,49 @@ func (m *Metrics) getTopChannels() map[string]int64 {
 	}
 	return top
 }
+
+func (m *Metrics) getVariantStats() map[string]map[string]interface{} {
+	variants := make(map[string]map[string]interface{}, len(m.Variants))
+	for name, v := range m.Variants {
+		var successRate, avgLatency float64
+		if v.Requests > 0 {
+			successRate = float64(v.Requests-v.Failures) / float64(v.Requests) * 100
+			avgLatency = float64(v.TotalLatency.Milliseconds()) / float64(v.Requests)
+		}
+		variants[name] = map[string]interface{}{
+			"requests":            v.Requests,
+			"failures":            v.Failures,
+			"validation_failures": v.ValidationFailures,
+			"success_rate":        successRate,
+			"average_latency_ms":  avgLatency,
+		}
+	}
+	return variants
+}
+
+// getInjectionStats reports detection verdicts and the false-positive rate
+// among reviewed detections
+func (m *Metrics) getInjectionStats() map[string]interface{} {
+	falsePositives := m.InjectionFeedback["false_positive"]
+	reviewed := falsePositives + m.InjectionFeedback["confirmed"]
+
+	var falsePositiveRate float64
+	if reviewed > 0 {
+		falsePositiveRate = float64(falsePositives) / float64(reviewed) * 100
+	}
+
+	return map[string]interface{}{
+		"verdicts":            m.InjectionVerdicts,
+		"reviewed":            reviewed,
+		"false_positives":     falsePositives,
+		"false_positive_rate": falsePositiveRate,
+	}
+}
+
+func copyCounts(counts map[string]int64) map[string]int64 {
+	copied := make(map[string]int64, len(counts))
+	for k, v := range counts {
+		copied[k] = v
+	}
+	return copied
+}
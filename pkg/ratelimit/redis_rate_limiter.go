@@ -15,34 +15,70 @@ const (
 )
 
 type RedisRateLimiter struct {
-	client *redis.Client
+	client       *redis.Client
+	userLimit    int
+	channelLimit int
+	keyPrefix    string
 }
 
 func NewRedisRateLimiter(client *redis.Client) *RedisRateLimiter {
-	return &RedisRateLimiter{client: client}
+	return &RedisRateLimiter{
+		client:       client,
+		userLimit:    UserRateLimit,
+		channelLimit: ChannelRateLimit,
+	}
+}
+
+// SetLimits replaces the default translations allowed per user and per
+// channel in each window
+func (r *RedisRateLimiter) SetLimits(userLimit, channelLimit int) {
+	r.userLimit = userLimit
+	r.channelLimit = channelLimit
+}
+
+// SetKeyPrefix namespaces the counters, e.g. with CACHE_KEY_PREFIX, so
+// deployments sharing a Redis keep separate limits
+func (r *RedisRateLimiter) SetKeyPrefix(prefix string) {
+	r.keyPrefix = prefix
 }
 
 func (r *RedisRateLimiter) CheckUserLimit(userID string) (bool, int, int64, error) {
-	key := fmt.Sprintf("rate_limit:user:%s", userID)
-	return r.checkLimit(key, UserRateLimit)
+	return r.checkLimit(r.userKey(userID), r.userLimit)
 }
 
 func (r *RedisRateLimiter) CheckChannelLimit(channelID string) (bool, int, int64, error) {
-	key := fmt.Sprintf("rate_limit:channel:%s", channelID)
-	return r.checkLimit(key, ChannelRateLimit)
+	return r.checkLimit(r.channelKey(channelID), r.channelLimit)
 }
 
 func (r *RedisRateLimiter) IncrementUserLimit(userID string) error {
-	key := fmt.Sprintf("rate_limit:user:%s", userID)
-	return r.increment(key)
+	return r.increment(r.userKey(userID))
 }
 
 func (r *RedisRateLimiter) IncrementChannelLimit(channelID string) error {
-	key := fmt.Sprintf("rate_limit:channel:%s", channelID)
-	return r.increment(key)
+	return r.increment(r.channelKey(channelID))
+}
+
+func (r *RedisRateLimiter) userKey(userID string) string {
+	return r.key(fmt.Sprintf("rate_limit:user:%s", userID))
+}
+
+func (r *RedisRateLimiter) channelKey(channelID string) string {
+	return r.key(fmt.Sprintf("rate_limit:channel:%s", channelID))
+}
+
+func (r *RedisRateLimiter) key(key string) string {
+	if r.keyPrefix == "" {
+		return key
+	}
+	return r.keyPrefix + ":" + key
 }
 
 func (r *RedisRateLimiter) checkLimit(key string, limit int) (bool, int, int64, error) {
+	// A limit of 0 or less disables it
+	if limit <= 0 {
+		return true, 0, 0, nil
+	}
+
 	ctx := context.Background()
 
 	count, err := r.client.Get(ctx, key).Int()
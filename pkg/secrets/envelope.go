@@ -0,0 +1,83 @@
+package secrets
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+)
+
+const dataKeySize = 32
+
+// Sealed is a secret encrypted with envelope encryption: a fresh data key
+// per secret encrypts the value, and the master key identified by KeyID
+// encrypts the data key. All three fields are stored alongside the row.
+type Sealed struct {
+	KeyID      string
+	WrappedKey []byte
+	Ciphertext []byte
+}
+
+// Envelope seals and opens secrets using data keys wrapped by a KeyService
+type Envelope struct {
+	keys KeyService
+}
+
+func NewEnvelope(keys KeyService) *Envelope {
+	return &Envelope{keys: keys}
+}
+
+// Seal encrypts the secret under a new data key wrapped with the current master key
+func (e *Envelope) Seal(ctx context.Context, secret Secret) (*Sealed, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	ciphertext, err := seal(dataKey, []byte(secret))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+
+	keyID := e.keys.CurrentKeyID()
+	wrapped, err := e.keys.Encrypt(ctx, keyID, dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	return &Sealed{KeyID: keyID, WrappedKey: wrapped, Ciphertext: ciphertext}, nil
+}
+
+// Open decrypts a sealed secret
+func (e *Envelope) Open(ctx context.Context, sealed *Sealed) (Secret, error) {
+	dataKey, err := e.keys.Decrypt(ctx, sealed.KeyID, sealed.WrappedKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+
+	plaintext, err := open(dataKey, sealed.Ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt secret: %w", err)
+	}
+	return Secret(plaintext), nil
+}
+
+// Rotate re-wraps the data key with the current master key. The secret
+// itself is not re-encrypted, so rotation never handles the plaintext.
+// It returns false when the secret already uses the current master key.
+func (e *Envelope) Rotate(ctx context.Context, sealed *Sealed) (*Sealed, bool, error) {
+	keyID := e.keys.CurrentKeyID()
+	if sealed.KeyID == keyID {
+		return sealed, false, nil
+	}
+
+	dataKey, err := e.keys.Decrypt(ctx, sealed.KeyID, sealed.WrappedKey)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	wrapped, err := e.keys.Encrypt(ctx, keyID, dataKey)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	return &Sealed{KeyID: keyID, WrappedKey: wrapped, Ciphertext: sealed.Ciphertext}, true, nil
+}
@@ -0,0 +1,111 @@
+package secrets
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+)
+
+// KeyService encrypts and decrypts data keys with a master key that never
+// leaves it, e.g. a cloud KMS. Several master keys may be active at once so
+// that rows sealed under a retired key can still be opened during rotation.
+type KeyService interface {
+	// CurrentKeyID returns the master key new data keys are wrapped with
+	CurrentKeyID() string
+	Encrypt(ctx context.Context, keyID string, plaintext []byte) ([]byte, error)
+	Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error)
+}
+
+var _ KeyService = (*LocalKeyService)(nil)
+
+// LocalKeyService is a KeyService backed by AES-256 master keys held in
+// process memory, for deployments without a KMS and for tests.
+type LocalKeyService struct {
+	currentID string
+	keys      map[string][]byte
+}
+
+// NewLocalKeyService builds a key service from base64-encoded 32-byte master
+// keys indexed by key ID; currentID selects the key used for new data keys.
+func NewLocalKeyService(encodedKeys map[string]string, currentID string) (*LocalKeyService, error) {
+	keys := make(map[string][]byte, len(encodedKeys))
+	for id, encoded := range encodedKeys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("master key %q is not valid base64: %w", id, err)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("master key %q must be 32 bytes, got %d", id, len(key))
+		}
+		keys[id] = key
+	}
+
+	if _, ok := keys[currentID]; !ok {
+		return nil, fmt.Errorf("current master key %q is not configured", currentID)
+	}
+
+	return &LocalKeyService{currentID: currentID, keys: keys}, nil
+}
+
+func (s *LocalKeyService) CurrentKeyID() string {
+	return s.currentID
+}
+
+func (s *LocalKeyService) Encrypt(_ context.Context, keyID string, plaintext []byte) ([]byte, error) {
+	key, ok := s.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown master key %q", keyID)
+	}
+	return seal(key, plaintext)
+}
+
+func (s *LocalKeyService) Decrypt(_ context.Context, keyID string, ciphertext []byte) ([]byte, error) {
+	key, ok := s.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown master key %q", keyID)
+	}
+	return open(key, ciphertext)
+}
+
+// seal encrypts plaintext with AES-GCM, prefixing the random nonce
+func seal(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// open reverses seal
+func open(key, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, body := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, body, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
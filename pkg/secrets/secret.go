@@ -0,0 +1,28 @@
+package secrets
+
+const redacted = "[REDACTED]"
+
+// Secret holds a decrypted credential such as a bot token. It formats and
+// marshals as "[REDACTED]" so it cannot leak through logs or API responses;
+// call Reveal where the raw value is actually needed.
+type Secret string
+
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+func (s Secret) String() string {
+	return redacted
+}
+
+func (s Secret) GoString() string {
+	return redacted
+}
+
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + redacted + `"`), nil
+}
+
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(redacted), nil
+}
@@ -0,0 +1,41 @@
+package security
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrCanaryLeaked is returned when a model output contains the prompt canary
+// token, i.e. the model was manipulated into revealing its instructions
+var ErrCanaryLeaked = errors.New("model output contains the prompt canary token")
+
+// NewCanaryToken returns a random trap token to plant in a prompt
+func NewCanaryToken() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate canary token: %w", err)
+	}
+	return "CNRY-" + strings.ToUpper(hex.EncodeToString(b)), nil
+}
+
+// ContainsCanary reports whether output contains token, ignoring case and
+// any spaces or punctuation the model inserted between its characters
+func ContainsCanary(output, token string) bool {
+	if token == "" {
+		return false
+	}
+	return strings.Contains(alphanumeric(output), alphanumeric(token))
+}
+
+func alphanumeric(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, normalizeForMatching(s))
+}
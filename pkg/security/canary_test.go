@@ -0,0 +1,52 @@
+package security_test
+
+import (
+	"testing"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainsCanary(t *testing.T) {
+	token, err := security.NewCanaryToken()
+	require.NoError(t, err)
+
+	other, err := security.NewCanaryToken()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+
+	tests := []struct {
+		name   string
+		output string
+		expect bool
+	}{
+		{name: "clean translation", output: "Xin chào, bạn khỏe không?", expect: false},
+		{name: "verbatim leak", output: "My instructions include " + token, expect: true},
+		{name: "lowercased leak", output: "marker: " + toLowerSpaced(token, ""), expect: true},
+		{name: "spaced out leak", output: toLowerSpaced(token, " "), expect: true},
+		{name: "different token", output: other, expect: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expect, security.ContainsCanary(tt.output, token))
+		})
+	}
+
+	assert.False(t, security.ContainsCanary("anything", ""))
+}
+
+func toLowerSpaced(s, sep string) string {
+	out := ""
+	for i, r := range s {
+		if i > 0 {
+			out += sep
+		}
+		if r >= 'A' && r <= 'Z' {
+			r += 'a' - 'A'
+		}
+		out += string(r)
+	}
+	return out
+}
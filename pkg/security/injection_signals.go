@@ -0,0 +1,79 @@
+package security
+
+import "regexp"
+
+// signalStrength is how much a matched signal contributes to the threat score
+type signalStrength int
+
+const (
+	// signalWeak is common in normal chat and only counts next to a stronger signal
+	signalWeak   signalStrength = 0
+	signalStrong signalStrength = 1
+	// signalRole is a chat role marker or model control token
+	signalRole signalStrength = 2
+	// signalCritical alone makes the input critical
+	signalCritical signalStrength = -1
+)
+
+type injectionSignal struct {
+	name     string
+	pattern  *regexp.Regexp
+	strength signalStrength
+}
+
+// sentenceStart matches the start of a line or sentence, allowing for
+// leading markdown such as quotes, bullets and headings
+const sentenceStart = `(?m)(?:^|[.!?]\s+)[\s>*_#\[(-]*`
+
+// injectionSignals returns the signals matched against normalized, lowercased input
+func injectionSignals() []injectionSignal {
+	signals := []struct {
+		name     string
+		pattern  string
+		strength signalStrength
+	}{
+		// Attempts to replace the translation instructions
+		{"instruction_override", `\b(ignore|disregard|forget|override)\s+(all\s+)?(of\s+)?(the\s+|your\s+|any\s+)?(previous|above|prior|earlier|preceding|system|your)\s+(instructions?|prompts?|rules?|directions?|context)\b`, signalCritical},
+		{"system_role", sentenceStart + `system\s*[:=]`, signalCritical},
+		// Slack delivers < and > HTML-escaped, so control tokens arrive as &lt;|im_start|&gt;
+		{"chatml_token", `(<|&lt;)\|(im_start|im_end|endoftext)\|(>|&gt;)`, signalCritical},
+		// The same override in the languages the bot most often translates
+		{"instruction_override_vi", `(bỏ qua|phớt lờ|quên)\s+((tất cả|mọi|các|những)\s+)*(hướng dẫn|chỉ dẫn|chỉ thị|quy tắc)\s+(trước|ở trên|phía trên|bên trên)`, signalCritical},
+		{"instruction_override_es", `\bignora\w*\s+(todas\s+)?(las\s+)?(instrucciones|indicaciones)\s+(anteriores|previas)`, signalCritical},
+		{"instruction_override_fr", `\bignor(e|ez|er)\s+(toutes\s+)?(les\s+)?(instructions|consignes)\s+(précédentes|precedentes|ci-dessus)`, signalCritical},
+		{"instruction_override_de", `\bignorier\w*\s+(alle\s+)?(vorherigen|bisherigen|obigen)\s+(anweisungen|instruktionen)`, signalCritical},
+		{"instruction_override_ja", `(以前|前|上記)の(すべての)?(指示|命令)を(すべて)?無視`, signalCritical},
+		{"instruction_override_zh", `忽略(之前|以上|前面|上面)的?(所有)?(指令|指示)`, signalCritical},
+		{"instruction_override_ko", `(이전|위의?)\s*(모든\s*)?(지시|명령|지침)(을|를)?\s*(모두\s*)?무시`, signalCritical},
+
+		{"chat_role", sentenceStart + `(assistant|user)\s*[:=]`, signalRole},
+		{"special_token", `</s>`, signalRole},
+
+		{"ignore_previous", `\b(ignore|disregard|forget)\s+(all\s+)?(the\s+)?(previous|above)\b`, signalStrong},
+		{"you_are_now", `\byou\s+are\s+now\b`, signalStrong},
+		{"new_instruction", `\b(new|updated?)\s+(instructions?|system\s+prompt)\b`, signalStrong},
+		{"dont_translate", `\b(don'?t|do\s+not)\s+translate\b`, signalStrong},
+		{"respond_with", `\b(respond|reply|answer)\s+(only\s+)?with\b`, signalStrong},
+		{"your_role", `\byour\s+(new\s+)?role\s+is\b`, signalStrong},
+		{"end_marker", `-{2,}\s*end\s*-{2,}`, signalStrong},
+
+		{"instead", `\binstead\b`, signalWeak},
+		{"act_as", `\bact\s+as\b`, signalWeak},
+		{"pretend", `\bpretend\b`, signalWeak},
+		{"simulate", `\bsimulate\b`, signalWeak},
+		{"override", `\boverride\b`, signalWeak},
+		{"you_are_a", `\b(you\s+are|you're)\s+(a|an)\s+\w+`, signalWeak},
+		{"code_fence", "```", signalWeak},
+		{"separator", `[#\-=*]{3,}`, signalWeak},
+	}
+
+	compiled := make([]injectionSignal, 0, len(signals))
+	for _, s := range signals {
+		compiled = append(compiled, injectionSignal{
+			name:     s.name,
+			pattern:  regexp.MustCompile(s.pattern),
+			strength: s.strength,
+		})
+	}
+	return compiled
+}
@@ -3,7 +3,10 @@ package security
 import (
 	"regexp"
 	"strings"
+	"sync"
 	"unicode/utf8"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/language"
 )
 
 type ThreatLevel int
@@ -17,9 +20,12 @@ const (
 )
 
 type InputValidator struct {
-	maxLength          int
-	suspiciousPatterns []*regexp.Regexp
-	blockList          []string
+	maxLength int
+	signals   []injectionSignal
+	blockList []string
+
+	mu        sync.RWMutex
+	allowlist []string
 }
 
 type ValidationResult struct {
@@ -32,12 +38,47 @@ type ValidationResult struct {
 
 func NewInputValidator(maxLength int) *InputValidator {
 	return &InputValidator{
-		maxLength:          maxLength,
-		suspiciousPatterns: compileSuspiciousPatterns(),
-		blockList:          loadBlockList(),
+		maxLength: maxLength,
+		signals:   injectionSignals(),
+		blockList: loadBlockList(),
 	}
 }
 
+// Allow adds phrases that are ignored by injection detection, for wording
+// that is routine in a workspace but looks like an injection (e.g. "act as owner").
+// Matching is case-insensitive.
+func (v *InputValidator) Allow(phrases ...string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for _, phrase := range phrases {
+		phrase = strings.ToLower(strings.TrimSpace(normalizeForMatching(phrase)))
+		if phrase == "" {
+			continue
+		}
+		exists := false
+		for _, allowed := range v.allowlist {
+			if allowed == phrase {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			v.allowlist = append(v.allowlist, phrase)
+		}
+	}
+}
+
+// Allowlist returns the allowed phrases
+func (v *InputValidator) Allowlist() []string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	allowlist := make([]string, len(v.allowlist))
+	copy(allowlist, v.allowlist)
+	return allowlist
+}
+
 func (v *InputValidator) Validate(text string) ValidationResult {
 	result := ValidationResult{
 		IsValid:          true,
@@ -47,8 +88,12 @@ func (v *InputValidator) Validate(text string) ValidationResult {
 		DetectedPatterns: []string{},
 	}
 
+	// Pattern checks run on the normalized text so full-width characters,
+	// zero-width characters and homoglyphs cannot hide an injection
+	normalized := normalizeForMatching(text)
+
 	// 0. Check for delimiter tag injection (CRITICAL)
-	if v.containsDelimiterTags(text) {
+	if v.containsDelimiterTags(normalized) {
 		result.IsValid = false
 		result.ThreatLevel = ThreatLevelCritical
 		result.Warnings = append(result.Warnings, "Delimiter tag injection detected")
@@ -56,28 +101,29 @@ func (v *InputValidator) Validate(text string) ValidationResult {
 		return result
 	}
 
-	// 1. Length validation
-	if utf8.RuneCountInString(text) > v.maxLength {
+	// 1. Length validation, in characters and in estimated tokens, since
+	// Chinese, Japanese, Korean and Thai hold far more per character
+	if utf8.RuneCountInString(text) > v.maxLength || language.EstimateTokens(text) > language.TokenBudget(v.maxLength) {
 		result.Warnings = append(result.Warnings, "Text exceeds maximum length")
 		result.ThreatLevel = maxThreatLevel(result.ThreatLevel, ThreatLevelLow)
 	}
 
 	// 2. Detect prompt injection patterns
-	detectedPatterns := v.detectInjectionPatterns(text)
-	if len(detectedPatterns) > 0 {
+	detectedPatterns, injectionThreat := v.detectInjection(normalized)
+	if injectionThreat > ThreatLevelNone {
 		result.DetectedPatterns = detectedPatterns
-		result.ThreatLevel = maxThreatLevel(result.ThreatLevel, v.calculateThreatLevel(detectedPatterns))
+		result.ThreatLevel = maxThreatLevel(result.ThreatLevel, injectionThreat)
 		result.Warnings = append(result.Warnings, "Suspicious prompt injection patterns detected")
 	}
 
 	// 3. Check against block list
-	if v.containsBlockedTerms(text) {
+	if v.containsBlockedTerms(normalized) {
 		result.ThreatLevel = maxThreatLevel(result.ThreatLevel, ThreatLevelHigh)
 		result.Warnings = append(result.Warnings, "Blocked terms detected")
 	}
 
 	// 4. Sanitize text
-	result.SanitizedText = v.sanitize(text)
+	result.SanitizedText = v.Sanitize(text)
 
 	// 5. Determine if valid
 	result.IsValid = result.ThreatLevel < ThreatLevelHigh
@@ -85,83 +131,70 @@ func (v *InputValidator) Validate(text string) ValidationResult {
 	return result
 }
 
-func (v *InputValidator) detectInjectionPatterns(text string) []string {
-	detected := []string{}
-	lowerText := strings.ToLower(text)
+// detectInjection matches the injection signals against the text and scores
+// them together. Weak signals are words that are common in everyday chat
+// ("instead", "act as", markdown separators) and only add to the score when a
+// stronger signal is present; role markers only count at the start of a line
+// or sentence.
+func (v *InputValidator) detectInjection(text string) ([]string, ThreatLevel) {
+	text = v.removeAllowed(strings.ToLower(text))
 
-	patterns := []string{
-		"ignore previous",
-		"ignore all previous",
-		"disregard previous",
-		"forget previous",
-		"ignore above",
-		"disregard above",
-		"system:",
-		"assistant:",
-		"user:",
-		"you are now",
-		"new instruction",
-		"override",
-		"instead",
-		"don't translate",
-		"do not translate",
-		"respond with",
-		"your role is",
-		"act as",
-		"pretend",
-		"simulate",
-		"</s>",
-		"<|im_end|>",
-		"<|endoftext|>",
-		"###",
-		"---END---",
+	detected := []string{}
+	critical := false
+	score, weak := 0, 0
+	for _, signal := range v.signals {
+		if !signal.pattern.MatchString(text) {
+			continue
+		}
+		detected = append(detected, signal.name)
+
+		switch signal.strength {
+		case signalCritical:
+			critical = true
+		case signalWeak:
+			weak++
+		default:
+			score += int(signal.strength)
+		}
 	}
 
-	for _, pattern := range patterns {
-		if strings.Contains(lowerText, pattern) {
-			detected = append(detected, pattern)
-		}
+	if critical {
+		return detected, ThreatLevelCritical
+	}
+	if score > 0 {
+		score += weak
 	}
 
-	for _, regex := range v.suspiciousPatterns {
-		if regex.MatchString(text) {
-			detected = append(detected, regex.String())
-		}
+	switch {
+	case score >= 3:
+		return detected, ThreatLevelHigh
+	case score == 2:
+		return detected, ThreatLevelMedium
+	case score == 1:
+		return detected, ThreatLevelLow
 	}
+	return detected, ThreatLevelNone
+}
+
+// removeAllowed blanks out allowlisted phrases in lowercased text
+func (v *InputValidator) removeAllowed(text string) string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
 
-	return detected
+	for _, phrase := range v.allowlist {
+		text = strings.ReplaceAll(text, phrase, " ")
+	}
+	return text
 }
 
-func (v *InputValidator) sanitize(text string) string {
+// Sanitize trims the text, collapses whitespace and removes control characters
+func (v *InputValidator) Sanitize(text string) string {
 	text = strings.TrimSpace(text)
 	text = regexp.MustCompile(`\s+`).ReplaceAllString(text, " ")
 	text = removeControlCharacters(text)
 	return text
 }
 
-func (v *InputValidator) calculateThreatLevel(patterns []string) ThreatLevel {
-	count := len(patterns)
-
-	critical := []string{"system:", "ignore previous", "override"}
-	for _, p := range patterns {
-		for _, c := range critical {
-			if strings.Contains(strings.ToLower(p), c) {
-				return ThreatLevelCritical
-			}
-		}
-	}
-
-	if count >= 3 {
-		return ThreatLevelHigh
-	} else if count >= 2 {
-		return ThreatLevelMedium
-	} else if count >= 1 {
-		return ThreatLevelLow
-	}
-
-	return ThreatLevelNone
-}
-
 func (v *InputValidator) containsBlockedTerms(text string) bool {
 	lowerText := strings.ToLower(text)
 	for _, term := range v.blockList {
@@ -204,25 +237,6 @@ func (v *InputValidator) containsDelimiterTags(text string) bool {
 	return false
 }
 
-func compileSuspiciousPatterns() []*regexp.Regexp {
-	patterns := []string{
-		`(?i)(you\s+are|you're)\s+(now|a|an)\s+\w+`,
-		`(?i)(new|updated?)\s+(instruction|rule|command|prompt)`,
-		`(?i)(system|assistant|user)\s*[:\-\=]`,
-		"```",
-		`[#\-=*]{3,}`,
-	}
-
-	compiled := make([]*regexp.Regexp, 0, len(patterns))
-	for _, p := range patterns {
-		if re, err := regexp.Compile(p); err == nil {
-			compiled = append(compiled, re)
-		}
-	}
-
-	return compiled
-}
-
 func loadBlockList() []string {
 	return []string{}
 }
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	gcs "google.golang.org/api/storage/v1"
+)
+
+var _ Store = (*GCSStore)(nil)
+
+// GCSStore keeps objects in a Google Cloud Storage bucket
+type GCSStore struct {
+	service *gcs.Service
+	bucket  string
+}
+
+// NewGCSStore connects to Cloud Storage with opts, by default with the
+// application default credentials
+func NewGCSStore(ctx context.Context, bucket string, opts ...option.ClientOption) (*GCSStore, error) {
+	service, err := gcs.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cloud Storage client: %w", err)
+	}
+	return &GCSStore{service: service, bucket: bucket}, nil
+}
+
+func (s *GCSStore) Create(ctx context.Context, key string, data []byte, contentType string) error {
+	if err := validKey(key); err != nil {
+		return err
+	}
+
+	// Generation 0 matches only a key that holds no object
+	_, err := s.service.Objects.Insert(s.bucket, &gcs.Object{Name: key, ContentType: contentType}).
+		IfGenerationMatch(0).
+		Media(bytes.NewReader(data), googleapi.ContentType(contentType)).
+		Context(ctx).
+		Do()
+	if apiStatus(err) == http.StatusPreconditionFailed {
+		return ErrExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *GCSStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := validKey(key); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.service.Objects.Get(s.bucket, key).Context(ctx).Download()
+	if apiStatus(err) == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", key, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", key, err)
+	}
+	return data, nil
+}
+
+// ExpireAfter adds a rule deleting the objects under prefix at the age of
+// days to the bucket's lifecycle configuration
+func (s *GCSStore) ExpireAfter(ctx context.Context, prefix string, days int) error {
+	if err := validExpiry(prefix, days); err != nil {
+		return err
+	}
+
+	bucket, err := s.service.Buckets.Get(s.bucket).Fields("lifecycle").Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("failed to read lifecycle of bucket %s: %w", s.bucket, err)
+	}
+	lifecycle := &gcs.BucketLifecycle{}
+	if bucket.Lifecycle != nil {
+		for _, rule := range bucket.Lifecycle.Rule {
+			if !expiresPrefix(rule, prefix) {
+				lifecycle.Rule = append(lifecycle.Rule, rule)
+			}
+		}
+	}
+	lifecycle.Rule = append(lifecycle.Rule, &gcs.BucketLifecycleRule{
+		Action:    &gcs.BucketLifecycleRuleAction{Type: "Delete"},
+		Condition: &gcs.BucketLifecycleRuleCondition{Age: googleapi.Int64(int64(days)), MatchesPrefix: []string{prefix}},
+	})
+
+	if _, err := s.service.Buckets.Patch(s.bucket, &gcs.Bucket{Lifecycle: lifecycle}).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("failed to update lifecycle of bucket %s: %w", s.bucket, err)
+	}
+	return nil
+}
+
+// expiresPrefix reports whether rule is the one ExpireAfter adds for prefix
+func expiresPrefix(rule *gcs.BucketLifecycleRule, prefix string) bool {
+	return rule.Action != nil && rule.Action.Type == "Delete" && rule.Condition != nil &&
+		len(rule.Condition.MatchesPrefix) == 1 && rule.Condition.MatchesPrefix[0] == prefix
+}
+
+// apiStatus returns the HTTP status of a Cloud Storage error, or 0
+func apiStatus(err error) int {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return 0
+}
@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// localSweepInterval is how often the local store deletes expired objects
+const localSweepInterval = time.Hour
+
+var _ Store = (*LocalStore)(nil)
+
+// LocalStore keeps objects as files of a directory, for local development
+type LocalStore struct {
+	dir string
+	now func() time.Time
+}
+
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{dir: dir, now: time.Now}
+}
+
+func (s *LocalStore) Create(_ context.Context, key string, data []byte, _ string) error {
+	if err := validKey(key); err != nil {
+		return err
+	}
+
+	name := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(name), 0o750); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	// O_EXCL fails when the file exists, which keeps objects write-once
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o440)
+	if errors.Is(err, os.ErrExist) {
+		return ErrExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", key, err)
+	}
+
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		_ = os.Remove(name)
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	return file.Close()
+}
+
+func (s *LocalStore) Get(_ context.Context, key string) ([]byte, error) {
+	if err := validKey(key); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	return data, nil
+}
+
+// ExpireAfter deletes the expired files under prefix now, then every hour
+// until ctx is done, standing in for a bucket's lifecycle rule. Failures of
+// later sweeps are retried at the next one.
+func (s *LocalStore) ExpireAfter(ctx context.Context, prefix string, days int) error {
+	if err := validExpiry(prefix, days); err != nil {
+		return err
+	}
+	maxAge := time.Duration(days) * 24 * time.Hour
+	if err := s.sweep(prefix, maxAge); err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(localSweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = s.sweep(prefix, maxAge)
+			}
+		}
+	}()
+	return nil
+}
+
+// sweep deletes the files under prefix last written more than maxAge ago
+func (s *LocalStore) sweep(prefix string, maxAge time.Duration) error {
+	cutoff := s.now().Add(-maxAge)
+	err := filepath.WalkDir(s.dir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(s.dir, name)
+		if err != nil || !strings.HasPrefix(filepath.ToSlash(rel), prefix) {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().Before(cutoff) {
+			if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		// Nothing was stored yet
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete expired objects: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalStore(t.TempDir())
+
+	require.NoError(t, store.Create(ctx, "exports/T1/2026-10.jsonl", []byte("{}\n"), "application/x-ndjson"))
+	data, err := store.Get(ctx, "exports/T1/2026-10.jsonl")
+	require.NoError(t, err)
+	assert.Equal(t, "{}\n", string(data))
+
+	assert.ErrorIs(t, store.Create(ctx, "exports/T1/2026-10.jsonl", []byte("[]"), "application/json"), ErrExists,
+		"objects cannot be replaced")
+	_, err = store.Get(ctx, "exports/T2/2026-10.jsonl")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.Error(t, store.Create(ctx, "../escape", nil, ""))
+	assert.Error(t, store.Create(ctx, "/etc/passwd", nil, ""))
+}
+
+func TestNew_Prefix(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := New(ctx, Config{Backend: BackendLocal, LocalDir: dir, Prefix: "compliance/"})
+	require.NoError(t, err)
+
+	require.NoError(t, store.Create(ctx, "a.json", []byte("1"), "application/json"))
+	data, err := NewLocalStore(dir).Get(ctx, "compliance/a.json")
+	require.NoError(t, err)
+	assert.Equal(t, "1", string(data))
+
+	_, err = New(ctx, Config{Backend: "ftp"})
+	assert.Error(t, err)
+}
+
+func TestLocalStore_ExpireAfter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir := t.TempDir()
+	store := NewLocalStore(dir)
+
+	for _, key := range []string{"artifacts/old", "artifacts/new", "exports/old"} {
+		require.NoError(t, store.Create(ctx, key, []byte("1"), "text/plain"))
+	}
+	old := time.Now().Add(-49 * time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "artifacts", "old"), old, old))
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "exports", "old"), old, old))
+
+	require.NoError(t, store.ExpireAfter(ctx, "artifacts/", 2))
+
+	_, err := store.Get(ctx, "artifacts/old")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = store.Get(ctx, "artifacts/new")
+	assert.NoError(t, err)
+	_, err = store.Get(ctx, "exports/old")
+	assert.NoError(t, err, "objects under other prefixes are kept")
+
+	assert.NoError(t, NewLocalStore(filepath.Join(dir, "missing")).ExpireAfter(ctx, "artifacts/", 1))
+	assert.Error(t, store.ExpireAfter(ctx, "artifacts/", 0))
+}
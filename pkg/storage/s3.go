@@ -0,0 +1,334 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+var _ Store = (*S3Store)(nil)
+
+// S3Store keeps objects in an S3 bucket, or a bucket of an S3-compatible
+// service, through the REST API signed with AWS Signature Version 4
+type S3Store struct {
+	endpoint   string
+	region     string
+	bucket     string
+	accessKey  string
+	secretKey  string
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewS3Store addresses bucket on AWS when endpoint is empty, and by path on
+// endpoint otherwise
+func NewS3Store(endpoint, region, bucket, accessKey, secretKey string) *S3Store {
+	return &S3Store{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		region:     region,
+		bucket:     bucket,
+		accessKey:  accessKey,
+		secretKey:  secretKey,
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+		now:        time.Now,
+	}
+}
+
+func (s *S3Store) Create(ctx context.Context, key string, data []byte, contentType string) error {
+	if err := validKey(key); err != nil {
+		return err
+	}
+
+	req, err := s.newRequest(ctx, http.MethodPut, key, "", data)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	// Conditional writes fail with 412 when the key is taken
+	req.Header.Set("If-None-Match", "*")
+	s.sign(req, data)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch {
+	case resp.StatusCode == http.StatusPreconditionFailed:
+		return ErrExists
+	case resp.StatusCode >= 300:
+		return s.responseError("upload", key, resp)
+	}
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := validKey(key); err != nil {
+		return nil, err
+	}
+
+	req, err := s.newRequest(ctx, http.MethodGet, key, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	s.sign(req, nil)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", key, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	case resp.StatusCode >= 300:
+		return nil, s.responseError("download", key, resp)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", key, err)
+	}
+	return data, nil
+}
+
+// s3LifecycleConfiguration is the lifecycle configuration of a bucket. Rules
+// are kept as they are, but for the one ExpireAfter replaces.
+type s3LifecycleConfiguration struct {
+	XMLName xml.Name          `xml:"LifecycleConfiguration"`
+	Rules   []s3LifecycleRule `xml:"Rule"`
+}
+
+type s3LifecycleRule struct {
+	Inner string `xml:",innerxml"`
+}
+
+// ExpireAfter adds a rule deleting the objects under prefix at the age of
+// days to the bucket's lifecycle configuration, which S3 replaces as a whole
+func (s *S3Store) ExpireAfter(ctx context.Context, prefix string, days int) error {
+	if err := validExpiry(prefix, days); err != nil {
+		return err
+	}
+
+	config, err := s.lifecycle(ctx)
+	if err != nil {
+		return err
+	}
+	ruleID := "expire-" + prefix
+	rules := config.Rules[:0]
+	for _, rule := range config.Rules {
+		var fields struct {
+			ID string `xml:"ID"`
+		}
+		if err := xml.Unmarshal([]byte("<Rule>"+rule.Inner+"</Rule>"), &fields); err != nil || fields.ID != ruleID {
+			rules = append(rules, rule)
+		}
+	}
+	var rule bytes.Buffer
+	rule.WriteString("<ID>")
+	_ = xml.EscapeText(&rule, []byte(ruleID))
+	rule.WriteString("</ID><Filter><Prefix>")
+	_ = xml.EscapeText(&rule, []byte(prefix))
+	fmt.Fprintf(&rule, "</Prefix></Filter><Status>Enabled</Status><Expiration><Days>%d</Days></Expiration>", days)
+	config.Rules = append(rules, s3LifecycleRule{Inner: rule.String()})
+
+	body, err := xml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to encode lifecycle configuration: %w", err)
+	}
+	req, err := s.newRequest(ctx, http.MethodPut, "", "lifecycle", body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/xml")
+	// S3 requires a checksum of lifecycle configurations
+	sum := md5.Sum(body)
+	req.Header.Set("Content-Md5", base64.StdEncoding.EncodeToString(sum[:]))
+	s.sign(req, body)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to update lifecycle of bucket %s: %w", s.bucket, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode >= 300 {
+		return s.responseError("update lifecycle of bucket", s.bucket, resp)
+	}
+	return nil
+}
+
+// lifecycle reads the bucket's lifecycle configuration, which is empty when
+// the bucket has none
+func (s *S3Store) lifecycle(ctx context.Context) (*s3LifecycleConfiguration, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, "", "lifecycle", nil)
+	if err != nil {
+		return nil, err
+	}
+	s.sign(req, nil)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lifecycle of bucket %s: %w", s.bucket, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	config := &s3LifecycleConfiguration{}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		// NoSuchLifecycleConfiguration
+		return config, nil
+	case resp.StatusCode >= 300:
+		return nil, s.responseError("read lifecycle of bucket", s.bucket, resp)
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(config); err != nil {
+		return nil, fmt.Errorf("failed to decode lifecycle of bucket %s: %w", s.bucket, err)
+	}
+	return config, nil
+}
+
+// newRequest addresses the object key, or the bucket when key is empty, with
+// an optional subresource such as "lifecycle"
+func (s *S3Store) newRequest(ctx context.Context, method, key, subresource string, data []byte) (*http.Request, error) {
+	var objectURL string
+	if s.endpoint != "" {
+		objectURL = fmt.Sprintf("%s/%s%s", s.endpoint, s.bucket, escapePath(key))
+	} else {
+		objectURL = fmt.Sprintf("https://%s.s3.%s.amazonaws.com%s", s.bucket, s.region, escapePath(key))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, objectURL, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.URL.RawQuery = subresource
+	return req, nil
+}
+
+// responseError reports an error response, whose body is an XML description
+func (s *S3Store) responseError(action, key string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("failed to %s %s: status %d: %s", action, key, resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// sign adds the Signature Version 4 Authorization header, signing the host
+// and every header already set on req
+func (s *S3Store) sign(req *http.Request, payload []byte) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, headers[name])
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		// newRequest escaped the path the way S3 expects
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, s.region)
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	for _, part := range []string{s.region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+// escapePath URI-encodes each segment of an object path the way S3 signs it:
+// every byte but unreserved characters is percent-encoded
+func escapePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = escapeSegment(segment)
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+func escapeSegment(segment string) string {
+	var b strings.Builder
+	for i := 0; i < len(segment); i++ {
+		c := segment[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, escapeSegment(key)+"="+escapeSegment(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,133 @@
+// Package storage keeps files such as compliance exports, downloaded
+// attachments and generated artifacts in object storage: an S3 bucket (or any
+// S3-compatible service), a Google Cloud Storage bucket or, for local
+// development, a directory.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+var (
+	// ErrExists is returned when writing to a key that already holds an object
+	ErrExists = errors.New("object already exists")
+	// ErrNotFound is returned when reading a key that holds no object
+	ErrNotFound = errors.New("object not found")
+)
+
+// Store keeps objects by key. Objects are written once: Create fails with
+// ErrExists when the key is taken, so nothing stored can be replaced.
+type Store interface {
+	Create(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	// ExpireAfter deletes the objects under prefix once they are days old.
+	// Buckets get a lifecycle rule, replacing an earlier rule of the same
+	// prefix, so calling it again with the same arguments changes nothing.
+	ExpireAfter(ctx context.Context, prefix string, days int) error
+}
+
+// Backends of STORAGE_BACKEND
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+	BackendGCS   = "gcs"
+)
+
+// Config selects and configures a Store
+type Config struct {
+	Backend string
+	// Bucket is the S3 or Cloud Storage bucket
+	Bucket string
+	// Prefix is prepended to every key, e.g. "compliance/"
+	Prefix string
+	// LocalDir is the directory of the local backend
+	LocalDir string
+	// S3Endpoint replaces AWS for S3-compatible services, e.g.
+	// "http://localhost:9000"; buckets are then addressed by path
+	S3Endpoint  string
+	S3Region    string
+	S3AccessKey string
+	S3SecretKey string
+}
+
+// New builds the Store of cfg.Backend. Cloud Storage uses the application
+// default credentials, e.g. GOOGLE_APPLICATION_CREDENTIALS.
+func New(ctx context.Context, cfg Config) (Store, error) {
+	var store Store
+	switch cfg.Backend {
+	case BackendLocal:
+		if cfg.LocalDir == "" {
+			return nil, fmt.Errorf("a directory is required for local storage")
+		}
+		store = NewLocalStore(cfg.LocalDir)
+	case BackendS3:
+		if cfg.Bucket == "" || cfg.S3Region == "" || cfg.S3AccessKey == "" || cfg.S3SecretKey == "" {
+			return nil, fmt.Errorf("a bucket, region and access keys are required for S3 storage")
+		}
+		store = NewS3Store(cfg.S3Endpoint, cfg.S3Region, cfg.Bucket, cfg.S3AccessKey, cfg.S3SecretKey)
+	case BackendGCS:
+		if cfg.Bucket == "" {
+			return nil, fmt.Errorf("a bucket is required for Cloud Storage")
+		}
+		gcs, err := NewGCSStore(ctx, cfg.Bucket)
+		if err != nil {
+			return nil, err
+		}
+		store = gcs
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q, expected %s, %s or %s", cfg.Backend, BackendLocal, BackendS3, BackendGCS)
+	}
+
+	return WithPrefix(store, cfg.Prefix), nil
+}
+
+// WithPrefix keeps the objects of the returned store under prefix of store,
+// e.g. "artifacts/"
+func WithPrefix(store Store, prefix string) Store {
+	if prefix == "" {
+		return store
+	}
+	return &prefixedStore{store: store, prefix: prefix}
+}
+
+// prefixedStore keeps its objects under a common prefix of another store
+type prefixedStore struct {
+	store  Store
+	prefix string
+}
+
+func (p *prefixedStore) Create(ctx context.Context, key string, data []byte, contentType string) error {
+	return p.store.Create(ctx, p.prefix+key, data, contentType)
+}
+
+func (p *prefixedStore) Get(ctx context.Context, key string) ([]byte, error) {
+	return p.store.Get(ctx, p.prefix+key)
+}
+
+func (p *prefixedStore) ExpireAfter(ctx context.Context, prefix string, days int) error {
+	return p.store.ExpireAfter(ctx, p.prefix+prefix, days)
+}
+
+// validExpiry rejects lifecycle rules that would expire a whole bucket or
+// nothing at all
+func validExpiry(prefix string, days int) error {
+	if prefix == "" {
+		return fmt.Errorf("a prefix is required to expire objects")
+	}
+	if days <= 0 {
+		return fmt.Errorf("objects expire after at least a day, got %d", days)
+	}
+	return nil
+}
+
+// validKey rejects keys that are empty or would escape a directory
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return fmt.Errorf("invalid object key %q", key)
+	}
+	return nil
+}
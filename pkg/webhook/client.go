@@ -0,0 +1,63 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Envelope is the JSON body of every outbound notification
+type Envelope struct {
+	Event  string      `json:"event"`
+	SentAt time.Time   `json:"sent_at"`
+	Data   interface{} `json:"data"`
+}
+
+// Client posts signed notifications to a single receiver URL
+type Client struct {
+	url        string
+	secret     string
+	httpClient *http.Client
+}
+
+func NewClient(url, secret string, timeout time.Duration) *Client {
+	return &Client{
+		url:        url,
+		secret:     secret,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Send posts the event with its data, signed with the shared secret
+func (c *Client) Send(ctx context.Context, event string, data interface{}) error {
+	body, err := json.Marshal(Envelope{Event: event, SentAt: time.Now().UTC(), Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if err := SignRequest(req, c.secret, body); err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook receiver returned status %d", resp.StatusCode)
+	}
+	return nil
+}
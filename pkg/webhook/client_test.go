@@ -0,0 +1,45 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_SendSignsRequest(t *testing.T) {
+	var verifyErr error
+	var envelope webhook.Envelope
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		verifyErr = webhook.Verify("secret", r.Header, body, time.Minute)
+		_ = json.Unmarshal(body, &envelope)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := webhook.NewClient(server.URL, "secret", time.Second)
+	err := client.Send(context.Background(), "channels.bulk_applied", map[string]int{"succeeded": 2})
+
+	assert.NoError(t, err)
+	assert.NoError(t, verifyErr)
+	assert.Equal(t, "channels.bulk_applied", envelope.Event)
+}
+
+func TestClient_SendReportsReceiverError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := webhook.NewClient(server.URL, "secret", time.Second)
+	err := client.Send(context.Background(), "channels.bulk_applied", nil)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Headers carried by every signed outbound request
+const (
+	HeaderTimestamp = "X-Assistant-Request-Timestamp"
+	HeaderNonce     = "X-Assistant-Request-Nonce"
+	HeaderSignature = "X-Assistant-Signature"
+)
+
+// signatureVersion prefixes the base string and the signature, like Slack's "v0"
+const signatureVersion = "v0"
+
+// Sign computes the signature of a request, mirroring Slack's scheme:
+// "v0=" + hex(HMAC-SHA256(secret, "v0:<timestamp>:<nonce>:<body>")).
+func Sign(secret, timestamp, nonce string, body []byte) string {
+	baseString := fmt.Sprintf("%s:%s:%s:%s", signatureVersion, timestamp, nonce, body)
+	hash := hmac.New(sha256.New, []byte(secret))
+	hash.Write([]byte(baseString))
+	return signatureVersion + "=" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// SignRequest sets the timestamp, a fresh nonce and the signature headers on req
+func SignRequest(req *http.Request, secret string, body []byte) error {
+	nonce, err := newNonce()
+	if err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderNonce, nonce)
+	req.Header.Set(HeaderSignature, Sign(secret, timestamp, nonce, body))
+	return nil
+}
+
+// Verify checks a signed request as a receiver would. Requests older than
+// maxAge are rejected; receivers should also remember nonces seen within
+// maxAge to reject replays.
+func Verify(secret string, header http.Header, body []byte, maxAge time.Duration) error {
+	timestamp := header.Get(HeaderTimestamp)
+	nonce := header.Get(HeaderNonce)
+	signature := header.Get(HeaderSignature)
+	if timestamp == "" || nonce == "" || signature == "" {
+		return fmt.Errorf("missing signature headers")
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp: %s", timestamp)
+	}
+	if age := time.Since(time.Unix(ts, 0)); age > maxAge || age < -maxAge {
+		return fmt.Errorf("request timestamp outside the allowed window")
+	}
+
+	expected := Sign(secret, timestamp, nonce, body)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
+func newNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
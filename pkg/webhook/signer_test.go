@@ -0,0 +1,93 @@
+package webhook_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign_DependsOnNonce(t *testing.T) {
+	sig := webhook.Sign("secret", "1700000000", "abc", []byte(`{"ok":true}`))
+
+	assert.Equal(t, sig, webhook.Sign("secret", "1700000000", "abc", []byte(`{"ok":true}`)))
+	assert.NotEqual(t, sig, webhook.Sign("secret", "1700000000", "abd", []byte(`{"ok":true}`)))
+	assert.Contains(t, sig, "v0=")
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"event":"channels.bulk_applied"}`)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+
+	tests := []struct {
+		name      string
+		header    http.Header
+		body      []byte
+		expectErr bool
+	}{
+		{
+			name:   "valid signature",
+			header: signedHeader("secret", now, "n1", body),
+			body:   body,
+		},
+		{
+			name:      "tampered body",
+			header:    signedHeader("secret", now, "n1", body),
+			body:      []byte(`{"event":"other"}`),
+			expectErr: true,
+		},
+		{
+			name:      "wrong secret",
+			header:    signedHeader("other-secret", now, "n1", body),
+			body:      body,
+			expectErr: true,
+		},
+		{
+			name:      "stale timestamp",
+			header:    signedHeader("secret", stale, "n1", body),
+			body:      body,
+			expectErr: true,
+		},
+		{
+			name:      "missing headers",
+			header:    http.Header{},
+			body:      body,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := webhook.Verify("secret", tt.header, tt.body, 5*time.Minute)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSignRequest_UsesFreshNonce(t *testing.T) {
+	body := []byte(`{}`)
+	first, _ := http.NewRequest(http.MethodPost, "http://example.com", nil)
+	second, _ := http.NewRequest(http.MethodPost, "http://example.com", nil)
+
+	assert.NoError(t, webhook.SignRequest(first, "secret", body))
+	assert.NoError(t, webhook.SignRequest(second, "secret", body))
+
+	assert.NotEqual(t, first.Header.Get(webhook.HeaderNonce), second.Header.Get(webhook.HeaderNonce))
+	assert.NoError(t, webhook.Verify("secret", first.Header, body, time.Minute))
+}
+
+func signedHeader(secret, timestamp, nonce string, body []byte) http.Header {
+	header := http.Header{}
+	header.Set(webhook.HeaderTimestamp, timestamp)
+	header.Set(webhook.HeaderNonce, nonce)
+	header.Set(webhook.HeaderSignature, webhook.Sign(secret, timestamp, nonce, body))
+	return header
+}
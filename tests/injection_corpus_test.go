@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ntttrang/go-genai-slack-assistant/internal/dto/request"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/middleware"
+	"github.com/ntttrang/go-genai-slack-assistant/internal/service"
+	"github.com/ntttrang/go-genai-slack-assistant/pkg/security"
+)
+
+// injectionCorpus is the curated set of prompt-injection payloads in
+// testdata/injection_corpus.json. Input texts are written the way Slack
+// delivers them, with <, > and & HTML-escaped.
+type injectionCorpus struct {
+	Inputs []struct {
+		Name     string `json:"name"`
+		Category string `json:"category"`
+		Language string `json:"language"`
+		Text     string `json:"text"`
+		Expect   string `json:"expect"`
+	} `json:"inputs"`
+	// Outputs are model responses that show the model followed an injection
+	Outputs []struct {
+		Name  string `json:"name"`
+		Input string `json:"input"`
+		Text  string `json:"text"`
+	} `json:"outputs"`
+}
+
+func loadInjectionCorpus(t *testing.T) injectionCorpus {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "injection_corpus.json"))
+	require.NoError(t, err)
+
+	var corpus injectionCorpus
+	require.NoError(t, json.Unmarshal(data, &corpus))
+	require.NotEmpty(t, corpus.Inputs)
+	return corpus
+}
+
+func newCorpusTranslationUseCase(translator *MockTranslator) *service.TranslationUseCase {
+	mockRepo := new(MockTranslationRepository)
+	mockCache := new(MockRedisCache)
+	mockCache.On("Get", mock.Anything).Return("", errors.New("cache miss"))
+	mockCache.On("Set", mock.Anything, mock.Anything, int64(86400)).Return(nil)
+	mockRepo.On("GetByHash", mock.Anything).Return(nil, errors.New("record not found"))
+	mockRepo.On("Save", mock.Anything).Return(nil)
+
+	logger := zap.NewNop()
+	securityMiddleware := middleware.NewSecurityMiddleware(
+		security.NewInputValidator(5000), security.NewOutputValidator(10000), logger, true, true)
+	return service.NewTranslationUseCase(logger, mockRepo, mockCache, translator, 86400, securityMiddleware, nil)
+}
+
+// TestInjectionCorpus_InputValidator runs every corpus input through the input validator
+func TestInjectionCorpus_InputValidator(t *testing.T) {
+	corpus := loadInjectionCorpus(t)
+	validator := security.NewInputValidator(5000)
+
+	for _, tc := range corpus.Inputs {
+		t.Run(tc.Language+"/"+tc.Name, func(t *testing.T) {
+			result := validator.Validate(tc.Text)
+
+			if tc.Expect == "blocked" {
+				assert.False(t, result.IsValid, "%s payload was not blocked", tc.Category)
+			} else {
+				assert.True(t, result.IsValid, "benign message was blocked: %v", result.DetectedPatterns)
+			}
+		})
+	}
+}
+
+// TestInjectionCorpus_PromptPath runs every corpus input through the full
+// translation flow, including format extraction, with a mock provider
+func TestInjectionCorpus_PromptPath(t *testing.T) {
+	corpus := loadInjectionCorpus(t)
+
+	for _, tc := range corpus.Inputs {
+		t.Run(tc.Language+"/"+tc.Name, func(t *testing.T) {
+			mockTranslator := new(MockTranslator)
+			mockTranslator.On("Translate", mock.Anything, "Auto", "English").Return("Translated message", nil)
+			tu := newCorpusTranslationUseCase(mockTranslator)
+
+			_, err := tu.Translate(request.Translation{
+				Text:           tc.Text,
+				SourceLanguage: "Auto",
+				TargetLanguage: "English",
+			})
+
+			if tc.Expect == "blocked" {
+				assert.Error(t, err)
+				mockTranslator.AssertNotCalled(t, "Translate", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				mockTranslator.AssertNumberOfCalls(t, "Translate", 1)
+			}
+		})
+	}
+}
+
+// TestInjectionCorpus_CompromisedOutput checks that responses showing the
+// model followed an injection are rejected before they reach the channel
+func TestInjectionCorpus_CompromisedOutput(t *testing.T) {
+	corpus := loadInjectionCorpus(t)
+
+	for _, tc := range corpus.Outputs {
+		t.Run(tc.Name, func(t *testing.T) {
+			mockTranslator := new(MockTranslator)
+			mockTranslator.On("Translate", mock.Anything, "Auto", "English").Return(tc.Text, nil)
+			tu := newCorpusTranslationUseCase(mockTranslator)
+
+			_, err := tu.Translate(request.Translation{
+				Text:           tc.Input,
+				SourceLanguage: "Auto",
+				TargetLanguage: "English",
+			})
+
+			assert.Error(t, err)
+		})
+	}
+}
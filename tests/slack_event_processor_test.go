@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"testing"
@@ -34,12 +35,12 @@ type MockTranslationRepository struct {
 	mock.Mock
 }
 
-func (m *MockTranslationRepository) Save(translation *model.Translation) error {
+func (m *MockTranslationRepository) Save(ctx context.Context, translation *model.Translation) error {
 	args := m.Called(translation)
 	return args.Error(0)
 }
 
-func (m *MockTranslationRepository) GetByHash(hash string) (*model.Translation, error) {
+func (m *MockTranslationRepository) GetByHash(ctx context.Context, hash string) (*model.Translation, error) {
 	args := m.Called(hash)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -47,7 +48,7 @@ func (m *MockTranslationRepository) GetByHash(hash string) (*model.Translation,
 	return args.Get(0).(*model.Translation), args.Error(1)
 }
 
-func (m *MockTranslationRepository) GetByID(id string) (*model.Translation, error) {
+func (m *MockTranslationRepository) GetByID(ctx context.Context, id string) (*model.Translation, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -55,7 +56,7 @@ func (m *MockTranslationRepository) GetByID(id string) (*model.Translation, erro
 	return args.Get(0).(*model.Translation), args.Error(1)
 }
 
-func (m *MockTranslationRepository) GetByChannelID(channelID string, limit int) ([]*model.Translation, error) {
+func (m *MockTranslationRepository) GetByChannelID(ctx context.Context, channelID string, limit int) ([]*model.Translation, error) {
 	args := m.Called(channelID, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -67,22 +68,22 @@ type MockRedisCache struct {
 	mock.Mock
 }
 
-func (m *MockRedisCache) Get(key string) (string, error) {
+func (m *MockRedisCache) Get(ctx context.Context, key string) (string, error) {
 	args := m.Called(key)
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockRedisCache) Set(key string, value string, ttl int64) error {
+func (m *MockRedisCache) Set(ctx context.Context, key string, value string, ttl int64) error {
 	args := m.Called(key, value, ttl)
 	return args.Error(0)
 }
 
-func (m *MockRedisCache) Delete(key string) error {
+func (m *MockRedisCache) Delete(ctx context.Context, key string) error {
 	args := m.Called(key)
 	return args.Error(0)
 }
 
-func (m *MockRedisCache) Exists(key string) (bool, error) {
+func (m *MockRedisCache) Exists(ctx context.Context, key string) (bool, error) {
 	args := m.Called(key)
 	return args.Bool(0), args.Error(1)
 }